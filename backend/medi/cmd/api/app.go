@@ -2,56 +2,230 @@ package main
 
 import (
 	"log/slog"
+	"time"
+
+	"medi/internal/alert"
+	"medi/internal/avalanche"
+	"medi/internal/bootstrap"
+	"medi/internal/cache"
 	"medi/internal/config"
 	"medi/internal/location"
+	"medi/internal/logging"
+	"medi/internal/mqtt"
+	"medi/internal/providers"
+	"medi/internal/providers/health"
+	"medi/internal/providers/mock"
+	"medi/internal/providers/quota"
+	"medi/internal/timezone"
+	"medi/internal/travel"
 	"medi/internal/weather"
 
 	"github.com/gin-gonic/gin"
+)
 
-	_ "medi/docs" // Ensure docs are imported
+// Cache freshness windows for the forecast endpoints
+const (
+	weatherCacheTTL   = 15 * time.Minute
+	avalancheCacheTTL = 30 * time.Minute
 )
 
+// maxForecastCacheEntries is the hard cap on each forecast cache; past it the
+// least recently used entry is evicted, counted by the metrics endpoint
+const maxForecastCacheEntries = 512
+
+// cacheTTLJitter spreads each cache entry's TTL by ±10% so entries cached
+// during one traffic burst do not all expire at once
+const cacheTTLJitter = 0.1
+
 // App encapsulates application dependencies
 type App struct {
-	router          *gin.Engine
-	logger          *slog.Logger
-	locationService location.Service
-	weatherService  weather.Service
-	cfg             *config.Config
+	// boot owns the shared lifecycle: middleware, standard endpoints, and
+	// graceful shutdown
+	boot             *bootstrap.HTTPApp
+	router           *gin.Engine
+	logger           *slog.Logger
+	locationService  location.Service
+	weatherService   weather.Service
+	avalancheService avalanche.Service
+	travelService    travel.Service
+	// timezoneService resolves IANA timezone names for the bundle endpoint;
+	// the singleton is shared with the weather service
+	timezoneService timezone.Service
+	weatherCache    *cache.Cache[*weather.Forecast]
+	avalancheCache  *cache.Cache[*avalanche.AvalancheForecast]
+	// avalancheFlight coalesces concurrent avalanche fetches for the same
+	// zone so a burst of misses costs one upstream call
+	avalancheFlight cache.Flight[*avalanche.AvalancheForecast]
+	// cfg holds the active configuration; handlers read tunables through it
+	// per request so a hot reload takes effect without a restart
+	cfg *config.Holder
+	// publisher pushes cache refreshes for priority locations to MQTT, nil
+	// when publishing is disabled
+	publisher Publisher
+	// prefetcher keeps forecasts for recently requested locations warm, nil
+	// when prefetching is disabled
+	prefetcher *prefetcher
+	// deprecations counts deprecated response fields served to compat
+	// clients, reported by the metrics endpoint
+	deprecations deprecationMetrics
+	// providerHealth records recent upstream call history for the health
+	// endpoint, nil when the registry carries no recorder (mock mode, tests)
+	providerHealth *health.Recorder
+	// providerQuota counts upstream calls against the soft daily limits for
+	// the quotas endpoint, nil when the registry carries no tracker
+	providerQuota *quota.Tracker
+	// idempotency remembers Idempotency-Key outcomes so retried POSTs replay
+	// the original response instead of mutating twice
+	idempotency idempotencyStore
+	// now is the time source, injectable for tests
+	now func() time.Time
+	// longPollTimeout overrides the configured long-poll window when set,
+	// used by tests to keep waits short
+	longPollTimeout time.Duration
+	// readyChecks are the startup gates the /ready endpoint waits on; empty
+	// means ready immediately (tests)
+	readyChecks []readyCheck
 }
 
-// NewApp creates a new application with injected dependencies
-func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
-	// Set Gin mode from configuration
-	gin.SetMode(cfg.Server.GinMode)
+// NewApp creates a new application with injected dependencies. The holder
+// carries the active configuration; non-reloadable sections (server,
+// providers, MQTT) are read once here, everything else per request.
+func NewApp(holder *config.Holder, logger *slog.Logger) (*App, error) {
+	cfg := holder.Current()
 
-	// Create Gin router
-	router := gin.New()
+	// The generated docs advertise whatever host and base path this
+	// deployment configured
+	applyDocsInfo(cfg)
 
-	// Add middleware
-	router.Use(gin.Recovery())
+	// Assemble the provider registry once, using mock providers when
+	// configured so the whole API runs self-contained (e.g. for load testing)
+	var p *providers.Providers
+	switch cfg.Providers.Mode {
+	case config.ProviderModeMock:
+		logger.Info("using mock providers", "mode", cfg.Providers.Mode)
+		p = providers.NewMock(logger, mock.Options{})
+	case config.ProviderModeScenario:
+		scenario, err := mock.LoadScenario(cfg.Providers.ScenarioFile)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("using scenario providers", "scenario", scenario.Name, "file", cfg.Providers.ScenarioFile)
+		p = providers.NewScenario(scenario, logger, mock.Options{})
+	default:
+		p = providers.NewLive(cfg, logger)
+	}
 
-	// Initialize weather service
-	weatherSvc, err := weather.NewWeatherService(cfg, logger)
+	// Reject malformed publish window strings at startup instead of the
+	// refresher silently skipping that center
+	if err := validatePublishWindows(cfg.App.AvalanchePublishWindows); err != nil {
+		return nil, err
+	}
+
+	// Seed the coordinate logging policy; config reloads keep it current
+	logging.SetPreciseDebugCoords(cfg.App.LogPreciseCoordinates)
+
+	weatherSvc, err := weather.NewWeatherService(p, holder, logger)
+	if err != nil {
+		return nil, err
+	}
+	locationSvc := location.NewLocationService(p, logger)
+	avalancheSvc := avalanche.NewAvalancheService(p, logger)
+	travelSvc := travel.NewTravelService(p, logger)
+	// Returns the singleton already loaded for the weather service
+	timezoneSvc, err := timezone.NewService()
 	if err != nil {
 		return nil, err
 	}
 
+	// Connect the optional MQTT publisher
+	var publisher Publisher
+	if cfg.MQTT.Enabled {
+		// Reject a broken alert payload template at startup instead of when
+		// the first alert fires
+		if _, alertErr := alert.NewPayloadTemplate(cfg.MQTT.AlertPreset, cfg.MQTT.AlertTemplate); alertErr != nil {
+			return nil, alertErr
+		}
+		client, mqttErr := mqtt.NewClient(cfg.MQTT, logger)
+		if mqttErr != nil {
+			return nil, mqttErr
+		}
+		publisher = client
+	}
+
 	app := &App{
-		router:          router,
-		logger:          logger,
-		locationService: location.NewLocationService(logger),
-		cfg:             cfg,
-		weatherService:  weatherSvc,
+		logger:           logger,
+		locationService:  locationSvc,
+		cfg:              holder,
+		weatherService:   weatherSvc,
+		avalancheService: avalancheSvc,
+		travelService:    travelSvc,
+		timezoneService:  timezoneSvc,
+		weatherCache:     cache.New[*weather.Forecast](weatherCacheTTL),
+		avalancheCache:   cache.New[*avalanche.AvalancheForecast](avalancheCacheTTL),
+		now:              time.Now,
+		publisher:        publisher,
+		providerHealth:   p.Health,
+		providerQuota:    p.Quota,
+		idempotency:      newMemoryIdempotencyStore(),
+	}
+	app.weatherCache.SetMaxEntries(maxForecastCacheEntries)
+	app.avalancheCache.SetMaxEntries(maxForecastCacheEntries)
+	// Jittered expiries keep a morning traffic peak from refetching every
+	// entry the moment a shared TTL elapses
+	app.weatherCache.SetTTLJitter(cacheTTLJitter)
+	app.avalancheCache.SetTTLJitter(cacheTTLJitter)
+
+	// Hand the shared lifecycle the route registration; it owns the router,
+	// middleware, and the standard health and version endpoints
+	app.boot = bootstrap.NewHTTPApp(cfg, logger, func(router *gin.Engine) {
+		app.router = router
+		app.registerRoutes()
+	})
+	// Close outbound integrations during shutdown so the MQTT status topic
+	// flips to offline without waiting for the last will
+	app.boot.OnClose(app.Close)
+
+	// Start the optional background forecast refresher
+	if cfg.App.PrefetchEnabled {
+		app.prefetcher = newPrefetcher(app,
+			cfg.App.PrefetchRecentLocations,
+			time.Duration(cfg.App.PrefetchInactivityHours)*time.Hour,
+		)
+		go app.prefetcher.run()
 	}
 
-	// Register routes
-	app.registerRoutes()
+	// /ready holds traffic until the timezone finder has loaded and, when
+	// prefetching is on, the startup warmup sweep has finished
+	app.readyChecks = []readyCheck{{name: "timezone", ready: timezone.Ready}}
+	if app.prefetcher != nil {
+		app.readyChecks = append(app.readyChecks, readyCheck{name: "warmup", ready: app.prefetcher.warmedUp})
+	}
 
 	return app, nil
 }
 
-// Run starts the HTTP server
+// Run starts the HTTP server with the request timeout handler in front of
+// the router and blocks until shutdown
 func (app *App) Run(addr string) error {
-	return app.router.Run(addr)
+	return app.boot.Run(addr, app.handler())
+}
+
+// config returns the active configuration snapshot, nil when the app was
+// built without one (tests)
+func (app *App) config() *config.Config {
+	if app.cfg == nil {
+		return nil
+	}
+	return app.cfg.Current()
+}
+
+// Close releases outbound integrations; the MQTT publisher announces itself
+// offline before disconnecting
+func (app *App) Close() {
+	if app.prefetcher != nil {
+		app.prefetcher.Stop()
+	}
+	if app.publisher != nil {
+		app.publisher.Close()
+	}
 }
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAvalancheForecastInput defines the query parameters for the avalanche forecast endpoint
+type GetAvalancheForecastInput struct {
+	CoordinateInput
+	// Format selects the response rendering: JSON by default, or "markdown"
+	// for chat integrations
+	Format string `form:"format" binding:"omitempty,oneof=json markdown"`
+}
+
+// AvalancheForecastResponse wraps an avalanche forecast with freshness metadata
+type AvalancheForecastResponse struct {
+	Freshness Freshness                    `json:"freshness"`
+	Forecast  *avalanche.AvalancheForecast `json:"forecast"`
+}
+
+// handleGetAvalancheForecast godoc
+// @Summary Get avalanche forecast
+// @Description Retrieve the avalanche forecast for the zone containing a given latitude and longitude
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param format query string false "Response format" Enums(json, markdown)
+// @Success 200 {object} AvalancheForecastResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/forecast [get]
+func (app *App) handleGetAvalancheForecast(c *gin.Context) {
+	var input GetAvalancheForecastInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key := forecastCacheKey(input.Latitude, input.Longitude)
+	// Track the location so the publish-window refresher keeps its zone
+	// forecast current while the center is publishing
+	if app.prefetcher != nil {
+		app.prefetcher.noteRequest(input.Latitude, input.Longitude)
+	}
+	entry, status := app.avalancheCache.Get(key)
+
+	if status == cache.StatusHit {
+		app.writeAvalancheForecast(c, entry.Value, input.Latitude, input.Longitude, entry.FetchedAt, cache.StatusHit)
+		return
+	}
+
+	// Coalesce concurrent misses for the same zone: during the morning
+	// publish rush every request past the first waits for the in-flight
+	// fetch instead of issuing its own
+	forecast, shared, err := app.avalancheFlight.Do(key, func() (*avalanche.AvalancheForecast, error) {
+		return app.avalancheService.GetForecast(input.Latitude, input.Longitude)
+	})
+	if err != nil {
+		// Fall back to a stale entry when the refresh fails
+		if status == cache.StatusStale {
+			app.logger.Warn("serving stale avalanche forecast after refresh failure",
+				"latitude", input.Latitude,
+				"longitude", input.Longitude,
+				"error", err,
+			)
+			app.writeAvalancheForecast(c, entry.Value, input.Latitude, input.Longitude, entry.FetchedAt, cache.StatusStale)
+			return
+		}
+
+		app.logger.Error("failed to get avalanche forecast",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get avalanche forecast"})
+		return
+	}
+
+	// The caller whose fetch actually ran stores and publishes the result;
+	// coalesced callers just serve it
+	if !shared {
+		app.avalancheCache.Set(key, forecast)
+		app.publishAvalancheRefresh(input.Latitude, input.Longitude, forecast)
+	}
+	app.writeAvalancheForecast(c, forecast, input.Latitude, input.Longitude, app.now(), cache.StatusMiss)
+}
+
+// writeAvalancheForecast annotates the forecast's problems against the
+// requester's terrain and writes the response with freshness headers. The
+// response is flagged stale when the cache entry expired or the forecast's
+// own ExpiresTime has passed but we are serving it anyway.
+func (app *App) writeAvalancheForecast(c *gin.Context, forecast *avalanche.AvalancheForecast, latitude, longitude float64, fetchedAt time.Time, disposition cache.Status) {
+	// A failing forecast point lookup only costs the annotations; the
+	// problems stay unannotated rather than the forecast failing
+	if point, err := app.locationService.GetForecastPoint(latitude, longitude); err != nil {
+		app.logger.Debug("skipping avalanche problem overlap annotation",
+			"latitude", latitude,
+			"longitude", longitude,
+			"error", err,
+		)
+	} else {
+		avalanche.AnnotateProblemOverlap(forecast, point)
+	}
+
+	now := app.now()
+
+	stale := disposition == cache.StatusStale
+	if !forecast.ExpiresTime.IsZero() && forecast.ExpiresTime.Before(now) {
+		stale = true
+	}
+
+	var sourceAge time.Duration
+	if !forecast.PublishedTime.IsZero() {
+		sourceAge = now.Sub(forecast.PublishedTime)
+	}
+
+	freshness := newFreshness(fetchedAt, disposition, stale, sourceAge)
+	setFreshnessHeaders(c, freshness)
+
+	if c.Query("format") == "markdown" {
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(avalanche.RenderMarkdown(forecast)))
+		return
+	}
+
+	c.JSON(http.StatusOK, AvalancheForecastResponse{
+		Freshness: freshness,
+		Forecast:  forecast,
+	})
+}
+
+// GetAvalancheIncidentsInput defines the query parameters for the avalanche incidents endpoint
+type GetAvalancheIncidentsInput struct {
+	CoordinateInput
+	RadiusKm float64 `form:"radiusKm"` // Search radius in kilometers, defaults to 50
+	// SeasonOnly limits results to the current avalanche season (since Oct 1)
+	SeasonOnly bool `form:"seasonOnly"`
+}
+
+// defaultIncidentRadiusKm is used when the radiusKm parameter is omitted
+const defaultIncidentRadiusKm = 50.0
+
+// handleGetAvalancheIncidents godoc
+// @Summary Get nearby avalanche incidents
+// @Description Retrieve recorded avalanche incidents within a radius of a given latitude and longitude, sorted by distance
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param radiusKm query number false "Search radius in kilometers" example(50)
+// @Param seasonOnly query boolean false "Limit to the current avalanche season"
+// @Success 200 {object} avalanche.IncidentSearch
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/incidents [get]
+func (app *App) handleGetAvalancheIncidents(c *gin.Context) {
+	var input GetAvalancheIncidentsInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	radiusKm := input.RadiusKm
+	if radiusKm == 0 {
+		radiusKm = defaultIncidentRadiusKm
+	}
+	if radiusKm < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "radiusKm must be positive"})
+		return
+	}
+
+	incidents, err := app.avalancheService.GetIncidents(input.Latitude, input.Longitude, radiusKm, input.SeasonOnly)
+	if err != nil {
+		app.logger.Error("failed to get avalanche incidents",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get avalanche incidents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, incidents)
+}
+
+// GetAvalancheZonesInput defines the query parameters for the avalanche zones endpoint
+type GetAvalancheZonesInput struct {
+	// BBox is minLon,minLat,maxLon,maxLat; only zones whose polygons
+	// intersect the box are returned
+	BBox string `form:"bbox"`
+	// DangerAtLeast drops zones below the given danger level (1-5)
+	DangerAtLeast int `form:"dangerAtLeast"`
+	// CenterId keeps zones belonging to one avalanche center
+	CenterId string `form:"centerId"`
+	// OffSeason filters by the off-season flag when set to true or false
+	OffSeason string `form:"offSeason"`
+	Limit     int    `form:"limit"`  // page size, defaults to 50
+	Offset    int    `form:"offset"` // page start within the filtered list
+}
+
+// defaultZonePageSize is used when the limit parameter is omitted
+const defaultZonePageSize = 50
+
+// handleGetAvalancheZones godoc
+// @Summary List avalanche forecast zones
+// @Description List forecast zones nationally with optional bounding box, danger level, and off-season filters, paged by limit and offset
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Param centerId query string false "Keep zones belonging to one avalanche center" example(CAIC)
+// @Param bbox query string false "Bounding box as minLon,minLat,maxLon,maxLat" example(-112.5,40.0,-111.0,41.0)
+// @Param dangerAtLeast query integer false "Minimum current danger level" minimum(1) maximum(5)
+// @Param offSeason query boolean false "Filter by the off-season flag"
+// @Param limit query integer false "Page size" example(50)
+// @Param offset query integer false "Page start within the filtered list"
+// @Success 200 {object} avalanche.ZoneList
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/zones [get]
+func (app *App) handleGetAvalancheZones(c *gin.Context) {
+	var input GetAvalancheZonesInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := avalanche.ZoneFilter{
+		CenterId:      input.CenterId,
+		DangerAtLeast: input.DangerAtLeast,
+		Limit:         input.Limit,
+		Offset:        input.Offset,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultZonePageSize
+	}
+	if filter.Limit < 0 || filter.Offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit and offset must not be negative"})
+		return
+	}
+
+	if input.BBox != "" {
+		bbox, err := parseBoundingBox(input.BBox)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.BBox = bbox
+	}
+
+	if input.OffSeason != "" {
+		offSeason, err := strconv.ParseBool(input.OffSeason)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offSeason must be true or false"})
+			return
+		}
+		filter.OffSeason = &offSeason
+	}
+
+	zones, err := app.avalancheService.GetZones(filter)
+	if err != nil {
+		app.logger.Error("failed to list avalanche zones", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list avalanche zones"})
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+// GetAvalancheSummaryInput defines the query parameters for the regional summary endpoint
+type GetAvalancheSummaryInput struct {
+	State    string `form:"state"`    // Two-letter state code, e.g. CO
+	CenterId string `form:"centerId"` // Avalanche center id, e.g. CAIC
+}
+
+// handleGetAvalancheSummary godoc
+// @Summary Summarize avalanche conditions across a region
+// @Description Aggregate every zone in a state or center into danger histograms for today and tomorrow, active warning counts, the worst-rated zones, and the most common problem types
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Param state query string false "Keep zones in one US state" example(CO)
+// @Param centerId query string false "Keep zones belonging to one avalanche center" example(CAIC)
+// @Success 200 {object} avalanche.RegionSummary
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/summary [get]
+func (app *App) handleGetAvalancheSummary(c *gin.Context) {
+	var input GetAvalancheSummaryInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.State == "" && input.CenterId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "state or centerId is required"})
+		return
+	}
+
+	summary, err := app.avalancheService.GetSummary(avalanche.SummaryFilter{
+		State:    input.State,
+		CenterId: input.CenterId,
+	})
+	if err != nil {
+		app.logger.Error("failed to summarize avalanche conditions", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to summarize avalanche conditions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// handleGetAvalancheCenters godoc
+// @Summary List avalanche centers
+// @Description List every avalanche center known from the national map layer with contact and coverage details
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Success 200 {object} avalanche.CenterList
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/centers [get]
+func (app *App) handleGetAvalancheCenters(c *gin.Context) {
+	centers, err := app.avalancheService.GetCenters()
+	if err != nil {
+		app.logger.Error("failed to list avalanche centers", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list avalanche centers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, centers)
+}
+
+// handleGetProblemTypes godoc
+// @Summary List avalanche problem types
+// @Description Reference catalog of the normalized avalanche problem taxonomy with a description and typical management advice per type
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Success 200 {array} avalanche.ProblemTypeInfo
+// @Router /avalanche/problem-types [get]
+func (app *App) handleGetProblemTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, avalanche.ProblemTypes())
+}
+
+// RouteWaypointInput is one ordered point of a planned tour
+type RouteWaypointInput struct {
+	Latitude  float64 `json:"latitude" binding:"min=-90,max=90"`    // Latitude in decimal degrees
+	Longitude float64 `json:"longitude" binding:"min=-180,max=180"` // Longitude in decimal degrees
+	// ElevationFt is optional; legs without it are matched against every
+	// elevation band
+	ElevationFt *float64 `json:"elevationFt"`
+}
+
+// CheckRouteInput defines the request body for the route check endpoint
+type CheckRouteInput struct {
+	Waypoints []RouteWaypointInput `json:"waypoints" binding:"required,min=2,max=50"`
+}
+
+// handleCheckRoute godoc
+// @Summary Check a planned route against avalanche forecasts
+// @Description Annotate each leg of an ordered waypoint list with the forecast zones it crosses, the elevation bands its endpoints span, and the current danger and relevant problems for the leg's aspects
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Param route body CheckRouteInput true "Ordered waypoints, 2 to 50 points"
+// @Success 200 {object} avalanche.RouteCheck
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/route-check [post]
+func (app *App) handleCheckRoute(c *gin.Context) {
+	var input CheckRouteInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	waypoints := make([]avalanche.RouteWaypoint, 0, len(input.Waypoints))
+	for _, waypoint := range input.Waypoints {
+		waypoints = append(waypoints, avalanche.RouteWaypoint{
+			Latitude:    waypoint.Latitude,
+			Longitude:   waypoint.Longitude,
+			ElevationFt: waypoint.ElevationFt,
+		})
+	}
+
+	check, err := app.avalancheService.CheckRoute(waypoints)
+	if err != nil {
+		app.logger.Error("failed to check route", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check route"})
+		return
+	}
+
+	c.JSON(http.StatusOK, check)
+}
+
+// parseBoundingBox parses the bbox parameter, minLon,minLat,maxLon,maxLat.
+func parseBoundingBox(raw string) (*avalanche.BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("bbox must be minLon,minLat,maxLon,maxLat")
+	}
+
+	values := make([]float64, 4)
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bbox value %q is not a number", part)
+		}
+		values[i] = value
+	}
+
+	bbox := &avalanche.BoundingBox{
+		MinLon: values[0],
+		MinLat: values[1],
+		MaxLon: values[2],
+		MaxLat: values[3],
+	}
+	if bbox.MinLon >= bbox.MaxLon || bbox.MinLat >= bbox.MaxLat {
+		return nil, fmt.Errorf("bbox min values must be less than max values")
+	}
+	return bbox, nil
+}
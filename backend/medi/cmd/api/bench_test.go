@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"medi/internal/config"
+)
+
+// Benchmarks for the full forecast handler path: routing, middleware, cache,
+// domain services, and JSON rendering, with the mock provider registry
+// standing in for the upstream APIs so no network is involved.
+//
+// Baseline on the development machine (go test -bench GetForecastHandler
+// -benchtime 2s ./cmd/api/):
+//
+//	BenchmarkGetForecastHandlerWarmCache    ~31 ms/op   ~12 MB/op   ~169k allocs/op
+//	BenchmarkGetForecastHandlerColdCache    ~67 ms/op   ~18 MB/op   ~214k allocs/op
+//
+// The warm path is dominated by JSON serialization of the 16-day 7-model
+// forecast; the cold path adds mock provider generation and domain mapping.
+// Treat a doubling against these numbers as a regression worth bisecting.
+
+// newBenchHandler assembles the complete App over the mock providers — the
+// same wiring providers.mode=mock uses in production — and returns the
+// HTTP handler the server would mount
+func newBenchHandler(b *testing.B) http.Handler {
+	b.Helper()
+
+	cfg, err := config.Load()
+	if err != nil {
+		b.Fatalf("failed to load config: %v", err)
+	}
+	cfg.Providers.Mode = config.ProviderModeMock
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	app, err := NewApp(config.NewHolder(cfg), logger)
+	if err != nil {
+		b.Fatalf("failed to build app: %v", err)
+	}
+	return app.handler()
+}
+
+// benchRequest drives one request through the handler, failing the benchmark
+// on a non-200 so a broken path cannot masquerade as a fast one
+func benchRequest(b *testing.B, handler http.Handler, path string) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code != http.StatusOK {
+		b.Fatalf("GET %s returned status %d: %s", path, w.Code, w.Body.String())
+	}
+}
+
+func BenchmarkGetForecastHandlerWarmCache(b *testing.B) {
+	handler := newBenchHandler(b)
+	path := "/weather/forecast?latitude=39.1154&longitude=-107.6584"
+
+	// Prime the cache so every timed iteration is a hit
+	benchRequest(b, handler, path)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchRequest(b, handler, path)
+	}
+}
+
+func BenchmarkGetForecastHandlerColdCache(b *testing.B) {
+	handler := newBenchHandler(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Walk the latitude in 0.001° steps: each request gets a distinct
+		// cache key (keys round to 4 decimals), and the cycle is long
+		// enough that the LRU cap evicts entries before they recur
+		latitude := 37 + float64(i%4000)*0.001
+		benchRequest(b, handler, fmt.Sprintf("/weather/forecast?latitude=%.4f&longitude=-107.6584", latitude))
+	}
+}
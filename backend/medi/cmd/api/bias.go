@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetBiasCorrectionInput defines the JSON body for the admin bias endpoint
+type SetBiasCorrectionInput struct {
+	Latitude  float64 `json:"latitude" binding:"required"`  // Latitude in decimal degrees
+	Longitude float64 `json:"longitude" binding:"required"` // Longitude in decimal degrees
+	Model     string  `json:"model" binding:"required"`     // Weather model name, e.g. GfsSeamless
+	// TemperatureOffsetF is added to the model's temperatures, bounded ±10°F
+	TemperatureOffsetF float64 `json:"temperatureOffsetF"`
+	// SnowfallFactor scales the model's snowfall, bounded 0.5–2.0
+	SnowfallFactor float64 `json:"snowfallFactor" binding:"required"`
+}
+
+// handleSetBiasCorrection godoc
+// @Summary Set a forecast bias correction
+// @Description Record a verified warm/cold or snowfall bias for one model at one location; subsequent forecasts there are corrected
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param correction body SetBiasCorrectionInput true "Bias correction to apply"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /admin/bias [post]
+func (app *App) handleSetBiasCorrection(c *gin.Context) {
+	var input SetBiasCorrectionInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	correction := weather.BiasCorrection{
+		TemperatureOffsetF: input.TemperatureOffsetF,
+		SnowfallFactor:     input.SnowfallFactor,
+	}
+	if err := app.weatherService.SetBiasCorrection(input.Latitude, input.Longitude, input.Model, correction); err != nil {
+		if errors.Is(err, weather.ErrInvalidBiasTemperatureOffset) || errors.Is(err, weather.ErrInvalidBiasSnowfallFactor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		app.logger.Error("failed to set bias correction", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set bias correction"})
+		return
+	}
+
+	// Corrected forecasts only appear on fresh fetches, so drop the cached
+	// entry for the location
+	app.weatherCache.Delete(forecastCacheKey(input.Latitude, input.Longitude))
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/location"
+	"medi/internal/travel"
+	"medi/internal/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBundlePasses caps how many nearby pass references the bundle carries;
+// clients wanting the full list use the travel endpoint
+const maxBundlePasses = 5
+
+// GetLocationBundleInput defines the query parameters for the location bundle endpoint
+type GetLocationBundleInput struct {
+	CoordinateInput
+}
+
+// avalancheStatusOffSeason marks the bundle's avalanche section as skipped
+// because the request date is outside the configured season window
+const avalancheStatusOffSeason = "off_season"
+
+// AvalancheZoneRef is a lightweight reference to the forecast zone covering a
+// point: identity and current danger only, no ratings detail or problems.
+// Outside the avalanche season it reduces to the off-season status stub.
+type AvalancheZoneRef struct {
+	CenterId string `json:"centerId,omitempty"`
+	ZoneId   int    `json:"zoneId,omitempty"`
+	Name     string `json:"name,omitempty"`
+	State    string `json:"state,omitempty"`
+	Danger   string `json:"danger,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// PassRef is a lightweight reference to a nearby mountain pass segment
+type PassRef struct {
+	Name       string  `json:"name"`
+	Route      string  `json:"route,omitempty"`
+	Status     string  `json:"status"`
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// TimezoneRef resolves the point's IANA timezone and its current UTC offset
+type TimezoneRef struct {
+	Name          string `json:"name"`          // e.g. "America/Denver"
+	UTCOffset     string `json:"utcOffset"`     // e.g. "-07:00"
+	OffsetSeconds int    `json:"offsetSeconds"` // offset east of UTC
+}
+
+// LocationBundleResponse is everything a location screen needs in one call:
+// the forecast point plus lightweight references, no full forecasts. Sections
+// other than the forecast point fail independently into Warnings.
+type LocationBundleResponse struct {
+	ForecastPoint *types.ForecastPoint `json:"forecastPoint"`
+	AvalancheZone *AvalancheZoneRef    `json:"avalancheZone,omitempty"`
+	Passes        []PassRef            `json:"passes,omitempty"`
+	Timezone      *TimezoneRef         `json:"timezone,omitempty"`
+	Warnings      []string             `json:"warnings,omitempty"`
+}
+
+// handleGetLocationBundle godoc
+// @Summary Get location bundle
+// @Description Retrieve the forecast point plus lightweight references for a location screen in one call: nearest avalanche zone with current danger, nearby pass conditions, and the resolved timezone
+// @Tags location
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Success 200 {object} LocationBundleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /location/bundle [get]
+func (app *App) handleGetLocationBundle(c *gin.Context) {
+	var input GetLocationBundleInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Resolve every section concurrently; each touches a different provider
+	var (
+		wg sync.WaitGroup
+
+		forecastPoint *types.ForecastPoint
+		pointErr      error
+
+		sections bundleSections
+	)
+
+	// Off-season zones 404 or serve stale content; outside the season window
+	// the fetch is skipped entirely rather than warned about
+	sections.inSeason = app.avalancheInSeason(input.Latitude)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		forecastPoint, pointErr = app.locationService.GetForecastPoint(input.Latitude, input.Longitude)
+	}()
+	if sections.inSeason {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sections.avalancheForecast, sections.avalancheErr = app.avalancheService.GetForecast(input.Latitude, input.Longitude)
+		}()
+	}
+	go func() {
+		defer wg.Done()
+		sections.passes, sections.passesErr = app.travelService.GetNearbyPasses(input.Latitude, input.Longitude, defaultPassRadiusKm)
+	}()
+	wg.Wait()
+
+	// The forecast point is the core of the bundle; without it there is
+	// nothing to hang the references on
+	if pointErr != nil {
+		if errors.Is(pointErr, location.ErrInvalidLatitude) || errors.Is(pointErr, location.ErrInvalidLongitude) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": pointErr.Error()})
+			return
+		}
+		app.logger.Error("failed to get forecast point for bundle",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", pointErr,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get forecast point"})
+		return
+	}
+
+	sections.timezone, sections.timezoneErr = app.bundleTimezone(input.Latitude, input.Longitude)
+
+	for _, failed := range []struct {
+		message string
+		err     error
+	}{
+		{"bundle avalanche zone unavailable", sections.avalancheErr},
+		{"bundle pass conditions unavailable", sections.passesErr},
+		{"bundle timezone unavailable", sections.timezoneErr},
+	} {
+		if failed.err == nil {
+			continue
+		}
+		app.logger.Warn(failed.message,
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", failed.err,
+		)
+	}
+
+	c.JSON(http.StatusOK, assembleLocationBundle(forecastPoint, sections))
+}
+
+// bundleSections carries every section result the bundle needs besides the
+// forecast point, resolved up front so the assembly below is a plain function
+// over them that tests can drive without providers
+type bundleSections struct {
+	inSeason bool
+
+	avalancheForecast *avalanche.AvalancheForecast
+	avalancheErr      error
+
+	passes    *travel.PassConditions
+	passesErr error
+
+	timezone    *TimezoneRef
+	timezoneErr error
+}
+
+// assembleLocationBundle folds the section results into the response,
+// degrading each failed section into a warning; the caller has already
+// rejected a failed forecast point
+func assembleLocationBundle(forecastPoint *types.ForecastPoint, sections bundleSections) LocationBundleResponse {
+	response := LocationBundleResponse{ForecastPoint: forecastPoint}
+
+	switch {
+	case !sections.inSeason:
+		response.AvalancheZone = &AvalancheZoneRef{Status: avalancheStatusOffSeason}
+	case sections.avalancheErr != nil:
+		response.Warnings = append(response.Warnings, "avalanche zone unavailable")
+	default:
+		response.AvalancheZone = bundleZoneRef(sections.avalancheForecast)
+	}
+
+	switch {
+	case sections.passesErr != nil:
+		response.Warnings = append(response.Warnings, "pass conditions unavailable")
+	case sections.passes.Warning != "":
+		response.Warnings = append(response.Warnings, sections.passes.Warning)
+	default:
+		response.Passes = bundlePassRefs(sections.passes.Segments)
+	}
+
+	if sections.timezoneErr != nil {
+		response.Warnings = append(response.Warnings, "timezone unavailable")
+	} else {
+		response.Timezone = sections.timezone
+	}
+
+	return response
+}
+
+// avalancheInSeason applies the configured season window to the request
+// latitude; without config the default northern-hemisphere window applies
+func (app *App) avalancheInSeason(latitude float64) bool {
+	start, end := avalanche.DefaultSeasonStart, avalanche.DefaultSeasonEnd
+	if cfg := app.config(); cfg != nil {
+		if cfg.App.AvalancheSeasonStart != "" {
+			start = cfg.App.AvalancheSeasonStart
+		}
+		if cfg.App.AvalancheSeasonEnd != "" {
+			end = cfg.App.AvalancheSeasonEnd
+		}
+	}
+	return avalanche.InSeason(app.now(), latitude, start, end)
+}
+
+// bundleZoneRef reduces a zone forecast to its identity and the highest
+// current danger across elevation bands
+func bundleZoneRef(forecast *avalanche.AvalancheForecast) *AvalancheZoneRef {
+	danger := avalanche.DangerNone
+	for _, rating := range forecast.DangerRatings {
+		if rating.ValidDay != "current" {
+			continue
+		}
+		for _, level := range []avalanche.DangerLevel{rating.Lower, rating.Middle, rating.Upper} {
+			if level > danger && level <= avalanche.DangerExtreme {
+				danger = level
+			}
+		}
+	}
+
+	return &AvalancheZoneRef{
+		CenterId: forecast.Center.Id,
+		ZoneId:   forecast.Zone.Id,
+		Name:     forecast.Zone.Name,
+		State:    forecast.Zone.State,
+		Danger:   danger.String(),
+		URL:      forecast.ForecastURL,
+	}
+}
+
+// bundlePassRefs slims the nearest segments down to bundle references
+func bundlePassRefs(segments []travel.PassSegment) []PassRef {
+	if len(segments) > maxBundlePasses {
+		segments = segments[:maxBundlePasses]
+	}
+	refs := make([]PassRef, 0, len(segments))
+	for _, segment := range segments {
+		refs = append(refs, PassRef{
+			Name:       segment.Name,
+			Route:      segment.Route,
+			Status:     segment.Status,
+			DistanceKm: segment.DistanceKm,
+		})
+	}
+	return refs
+}
+
+// bundleTimezone resolves the point's timezone name and its UTC offset as of
+// now
+func (app *App) bundleTimezone(latitude, longitude float64) (*TimezoneRef, error) {
+	if app.timezoneService == nil {
+		return nil, errors.New("timezone service not configured")
+	}
+	name, err := app.timezoneService.GetTimezone(latitude, longitude)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	local := app.now().In(loc)
+	_, offsetSeconds := local.Zone()
+	return &TimezoneRef{
+		Name:          name,
+		UTCOffset:     local.Format("-07:00"),
+		OffsetSeconds: offsetSeconds,
+	}, nil
+}
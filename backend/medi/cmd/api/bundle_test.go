@@ -0,0 +1,494 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/cache"
+	"medi/internal/travel"
+	"medi/internal/types"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+const bundlePath = "/location/bundle?latitude=39.11539&longitude=-107.65840"
+
+// mockTravelService returns canned pass conditions until fail is set
+type mockTravelService struct {
+	passes *travel.PassConditions
+	fail   bool
+}
+
+func (m *mockTravelService) GetNearbyPasses(latitude, longitude, radiusKm float64) (*travel.PassConditions, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return m.passes, nil
+}
+
+// mockTimezoneService returns a fixed timezone name until fail is set
+type mockTimezoneService struct {
+	name string
+	fail bool
+}
+
+func (m *mockTimezoneService) GetTimezone(latitude, longitude float64) (string, error) {
+	if m.fail {
+		return "", errors.New("no timezone for coordinates")
+	}
+	return m.name, nil
+}
+
+func bundleAvalancheForecast() *avalanche.AvalancheForecast {
+	return &avalanche.AvalancheForecast{
+		Zone:   avalanche.ForecastZone{Id: 1001, Name: "Aspen", State: "CO"},
+		Center: avalanche.AvalancheCenter{Id: "CAIC"},
+		DangerRatings: []avalanche.DangerRating{
+			{ValidDay: "current", Lower: avalanche.DangerLow, Middle: avalanche.DangerModerate, Upper: avalanche.DangerConsiderable},
+			{ValidDay: "tomorrow", Lower: avalanche.DangerHigh, Middle: avalanche.DangerHigh, Upper: avalanche.DangerHigh},
+		},
+		ForecastURL: "https://avalanche.state.co.us/forecasts/aspen",
+	}
+}
+
+func bundlePassConditions() *travel.PassConditions {
+	return &travel.PassConditions{Segments: []travel.PassSegment{
+		{Name: "McClure Pass", Route: "CO-133", Status: travel.StatusOpen, DistanceKm: 12.4},
+		{Name: "Independence Pass", Route: "CO-82", Status: travel.StatusClosed, DistanceKm: 41.0},
+	}}
+}
+
+// newBundleTestApp builds an App with every bundle section mocked
+func newBundleTestApp(avalancheSvc *mockAvalancheService, travelSvc *mockTravelService, timezoneSvc *mockTimezoneService, locationSvc *mockLocationService) *App {
+	gin.SetMode(gin.TestMode)
+
+	app := &App{
+		router:           gin.New(),
+		logger:           slog.Default(),
+		locationService:  locationSvc,
+		weatherService:   &mockWeatherService{},
+		avalancheService: avalancheSvc,
+		travelService:    travelSvc,
+		timezoneService:  timezoneSvc,
+		weatherCache:     cache.New[*weather.Forecast](weatherCacheTTL),
+		avalancheCache:   cache.New[*avalanche.AvalancheForecast](avalancheCacheTTL),
+		now: func() time.Time {
+			return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+		},
+	}
+	app.registerRoutes()
+	return app
+}
+
+func getBundle(t *testing.T, app *App) LocationBundleResponse {
+	t.Helper()
+
+	w := doRequest(t, app, bundlePath)
+	var response LocationBundleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal bundle response: %v", err)
+	}
+	return response
+}
+
+func TestLocationBundleFullSuccess(t *testing.T) {
+	app := newBundleTestApp(
+		&mockAvalancheService{forecast: bundleAvalancheForecast()},
+		&mockTravelService{passes: bundlePassConditions()},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{},
+	)
+
+	bundle := getBundle(t, app)
+
+	if bundle.ForecastPoint == nil {
+		t.Fatal("expected a forecast point in the bundle")
+	}
+	if len(bundle.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", bundle.Warnings)
+	}
+
+	zone := bundle.AvalancheZone
+	if zone == nil {
+		t.Fatal("expected an avalanche zone reference")
+	}
+	if zone.CenterId != "CAIC" || zone.ZoneId != 1001 || zone.Name != "Aspen" {
+		t.Errorf("unexpected zone identity: %+v", zone)
+	}
+	// The highest current band, not tomorrow's High
+	if zone.Danger != "Considerable" {
+		t.Errorf("zone danger = %s, want Considerable", zone.Danger)
+	}
+
+	if len(bundle.Passes) != 2 {
+		t.Fatalf("got %d passes, want 2", len(bundle.Passes))
+	}
+	if bundle.Passes[0].Name != "McClure Pass" || bundle.Passes[0].Status != travel.StatusOpen {
+		t.Errorf("unexpected nearest pass: %+v", bundle.Passes[0])
+	}
+
+	tz := bundle.Timezone
+	if tz == nil {
+		t.Fatal("expected a timezone reference")
+	}
+	if tz.Name != "America/Denver" || tz.UTCOffset != "-07:00" || tz.OffsetSeconds != -7*3600 {
+		t.Errorf("unexpected timezone reference: %+v", tz)
+	}
+}
+
+func TestLocationBundleSectionsFailIndependently(t *testing.T) {
+	tests := []struct {
+		name        string
+		avalanche   *mockAvalancheService
+		travel      *mockTravelService
+		timezone    *mockTimezoneService
+		wantWarning string
+	}{
+		{
+			name:        "avalanche down",
+			avalanche:   &mockAvalancheService{fail: true},
+			travel:      &mockTravelService{passes: bundlePassConditions()},
+			timezone:    &mockTimezoneService{name: "America/Denver"},
+			wantWarning: "avalanche zone unavailable",
+		},
+		{
+			name:        "travel down",
+			avalanche:   &mockAvalancheService{forecast: bundleAvalancheForecast()},
+			travel:      &mockTravelService{fail: true},
+			timezone:    &mockTimezoneService{name: "America/Denver"},
+			wantWarning: "pass conditions unavailable",
+		},
+		{
+			name:        "timezone down",
+			avalanche:   &mockAvalancheService{forecast: bundleAvalancheForecast()},
+			travel:      &mockTravelService{passes: bundlePassConditions()},
+			timezone:    &mockTimezoneService{fail: true},
+			wantWarning: "timezone unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newBundleTestApp(tt.avalanche, tt.travel, tt.timezone, &mockLocationService{})
+			bundle := getBundle(t, app)
+
+			if bundle.ForecastPoint == nil {
+				t.Error("the forecast point should survive a failing section")
+			}
+			found := false
+			for _, warning := range bundle.Warnings {
+				if warning == tt.wantWarning {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("warnings %v missing %q", bundle.Warnings, tt.wantWarning)
+			}
+			if len(bundle.Warnings) != 1 {
+				t.Errorf("got %d warnings, want exactly the failing section's", len(bundle.Warnings))
+			}
+		})
+	}
+}
+
+func TestLocationBundleOutOfCoverageWarns(t *testing.T) {
+	app := newBundleTestApp(
+		&mockAvalancheService{forecast: bundleAvalancheForecast()},
+		&mockTravelService{passes: &travel.PassConditions{Segments: []travel.PassSegment{}, Warning: "no road conditions coverage for (39.1154, -107.6584)"}},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{},
+	)
+
+	bundle := getBundle(t, app)
+	if len(bundle.Passes) != 0 {
+		t.Errorf("got %d passes, want none outside coverage", len(bundle.Passes))
+	}
+	if len(bundle.Warnings) != 1 {
+		t.Fatalf("got warnings %v, want the coverage warning", bundle.Warnings)
+	}
+}
+
+func TestLocationBundleOffSeasonSkipsAvalanche(t *testing.T) {
+	// An avalanche service that would fail proves the fetch is skipped: no
+	// warning may appear
+	app := newBundleTestApp(
+		&mockAvalancheService{fail: true},
+		&mockTravelService{passes: bundlePassConditions()},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{},
+	)
+	app.now = func() time.Time {
+		return time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC)
+	}
+
+	bundle := getBundle(t, app)
+
+	if len(bundle.Warnings) != 0 {
+		t.Errorf("off-season bundle carried warnings: %v", bundle.Warnings)
+	}
+	zone := bundle.AvalancheZone
+	if zone == nil || zone.Status != "off_season" {
+		t.Fatalf("avalanche section = %+v, want the off_season stub", zone)
+	}
+	if zone.CenterId != "" || zone.ZoneId != 0 || zone.Danger != "" {
+		t.Errorf("off-season stub carries zone detail: %+v", zone)
+	}
+}
+
+func TestLocationBundleSouthernHemisphereWinter(t *testing.T) {
+	app := newBundleTestApp(
+		&mockAvalancheService{forecast: bundleAvalancheForecast()},
+		&mockTravelService{passes: bundlePassConditions()},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{},
+	)
+	// July is midwinter below the equator; the lookup must still run
+	app.now = func() time.Time {
+		return time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC)
+	}
+
+	w := doRequest(t, app, "/location/bundle?latitude=-41.13&longitude=-71.30")
+	var bundle LocationBundleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &bundle); err != nil {
+		t.Fatalf("failed to unmarshal bundle response: %v", err)
+	}
+
+	zone := bundle.AvalancheZone
+	if zone == nil || zone.Status != "" {
+		t.Fatalf("avalanche section = %+v, want a fetched zone reference", zone)
+	}
+	if zone.ZoneId != 1001 {
+		t.Errorf("zone id = %d, want the mocked 1001", zone.ZoneId)
+	}
+}
+
+func TestLocationBundleFailsWithoutForecastPoint(t *testing.T) {
+	app := newBundleTestApp(
+		&mockAvalancheService{forecast: bundleAvalancheForecast()},
+		&mockTravelService{passes: bundlePassConditions()},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{err: errors.New("provider unavailable")},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, bundlePath, nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, want 500 when the forecast point fails", w.Code)
+	}
+}
+
+// bundleScenarioMocks is the full container of dependencies the bundle
+// handler touches; the reflection guard below checks the degradation
+// scenarios against its fields
+type bundleScenarioMocks struct {
+	Location  *mockLocationService
+	Avalanche *mockAvalancheService
+	Travel    *mockTravelService
+	Timezone  *mockTimezoneService
+}
+
+func healthyBundleMocks() bundleScenarioMocks {
+	return bundleScenarioMocks{
+		Location:  &mockLocationService{},
+		Avalanche: &mockAvalancheService{forecast: bundleAvalancheForecast()},
+		Travel:    &mockTravelService{passes: bundlePassConditions()},
+		Timezone:  &mockTimezoneService{name: "America/Denver"},
+	}
+}
+
+// bundleDegradationScenarios is the systematic partial-failure matrix: each
+// entry breaks named dependencies and pins the resulting status, section
+// presence, and warnings. New failure modes get a row here, not a one-off
+// test.
+var bundleDegradationScenarios = []struct {
+	name string
+	// fails names the bundleScenarioMocks fields this scenario breaks; the
+	// coverage guard reads it
+	fails        []string
+	mutate       func(*bundleScenarioMocks)
+	now          time.Time
+	wantStatus   int
+	wantError    string
+	wantZone     bool
+	wantPasses   bool
+	wantTimezone bool
+	wantWarnings []string
+}{
+	{
+		name:         "all providers healthy",
+		wantStatus:   http.StatusOK,
+		wantZone:     true,
+		wantPasses:   true,
+		wantTimezone: true,
+	},
+	{
+		name:       "geocode down takes the bundle with it",
+		fails:      []string{"Location"},
+		mutate:     func(m *bundleScenarioMocks) { m.Location.err = errors.New("provider unavailable") },
+		wantStatus: http.StatusInternalServerError,
+		wantError:  "failed to get forecast point",
+	},
+	{
+		name:         "avalanche center down",
+		fails:        []string{"Avalanche"},
+		mutate:       func(m *bundleScenarioMocks) { m.Avalanche.fail = true },
+		wantStatus:   http.StatusOK,
+		wantPasses:   true,
+		wantTimezone: true,
+		wantWarnings: []string{"avalanche zone unavailable"},
+	},
+	{
+		name:         "road conditions down",
+		fails:        []string{"Travel"},
+		mutate:       func(m *bundleScenarioMocks) { m.Travel.fail = true },
+		wantStatus:   http.StatusOK,
+		wantZone:     true,
+		wantTimezone: true,
+		wantWarnings: []string{"pass conditions unavailable"},
+	},
+	{
+		name:         "timezone lookup down",
+		fails:        []string{"Timezone"},
+		mutate:       func(m *bundleScenarioMocks) { m.Timezone.fail = true },
+		wantStatus:   http.StatusOK,
+		wantZone:     true,
+		wantPasses:   true,
+		wantWarnings: []string{"timezone unavailable"},
+	},
+	{
+		name:  "every reference section down at once",
+		fails: []string{"Avalanche", "Travel", "Timezone"},
+		mutate: func(m *bundleScenarioMocks) {
+			m.Avalanche.fail = true
+			m.Travel.fail = true
+			m.Timezone.fail = true
+		},
+		wantStatus:   http.StatusOK,
+		wantWarnings: []string{"avalanche zone unavailable", "pass conditions unavailable", "timezone unavailable"},
+	},
+	{
+		name:         "off season never reaches the avalanche center",
+		fails:        []string{"Avalanche"},
+		mutate:       func(m *bundleScenarioMocks) { m.Avalanche.fail = true },
+		now:          time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC),
+		wantStatus:   http.StatusOK,
+		wantZone:     true, // the off_season stub, not a fetched zone
+		wantPasses:   true,
+		wantTimezone: true,
+	},
+}
+
+func TestLocationBundleDegradationMatrix(t *testing.T) {
+	for _, tt := range bundleDegradationScenarios {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := healthyBundleMocks()
+			if tt.mutate != nil {
+				tt.mutate(&mocks)
+			}
+			app := newBundleTestApp(mocks.Avalanche, mocks.Travel, mocks.Timezone, mocks.Location)
+			if !tt.now.IsZero() {
+				now := tt.now
+				app.now = func() time.Time { return now }
+			}
+
+			req := httptest.NewRequest(http.MethodGet, bundlePath, nil)
+			w := httptest.NewRecorder()
+			app.router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var body map[string]string
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatalf("failed to unmarshal error body: %v", err)
+				}
+				if body["error"] != tt.wantError {
+					t.Errorf("error = %q, want %q", body["error"], tt.wantError)
+				}
+				return
+			}
+
+			var bundle LocationBundleResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &bundle); err != nil {
+				t.Fatalf("failed to unmarshal bundle response: %v", err)
+			}
+
+			if bundle.ForecastPoint == nil {
+				t.Error("the forecast point must survive every degraded scenario")
+			}
+			if got := bundle.AvalancheZone != nil; got != tt.wantZone {
+				t.Errorf("avalanche section present = %t, want %t", got, tt.wantZone)
+			}
+			if got := len(bundle.Passes) > 0; got != tt.wantPasses {
+				t.Errorf("passes section present = %t, want %t", got, tt.wantPasses)
+			}
+			if got := bundle.Timezone != nil; got != tt.wantTimezone {
+				t.Errorf("timezone section present = %t, want %t", got, tt.wantTimezone)
+			}
+
+			if len(bundle.Warnings) != len(tt.wantWarnings) {
+				t.Fatalf("warnings = %v, want %v", bundle.Warnings, tt.wantWarnings)
+			}
+			for i, want := range tt.wantWarnings {
+				if bundle.Warnings[i] != want {
+					t.Errorf("warnings[%d] = %q, want %q", i, bundle.Warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+// Every dependency the handler touches has to fail somewhere in the matrix;
+// adding a field to bundleScenarioMocks without a failing scenario for it
+// breaks here rather than slipping through review
+func TestLocationBundleMatrixCoversEveryDependency(t *testing.T) {
+	covered := make(map[string]bool)
+	mockType := reflect.TypeOf(bundleScenarioMocks{})
+	for _, scenario := range bundleDegradationScenarios {
+		for _, field := range scenario.fails {
+			if _, ok := mockType.FieldByName(field); !ok {
+				t.Errorf("scenario %q fails unknown dependency %q", scenario.name, field)
+			}
+			covered[field] = true
+		}
+	}
+	for i := 0; i < mockType.NumField(); i++ {
+		if name := mockType.Field(i).Name; !covered[name] {
+			t.Errorf("dependency %s never fails in any degradation scenario", name)
+		}
+	}
+}
+
+func TestAssembleLocationBundleWarningOrderIsStable(t *testing.T) {
+	sections := bundleSections{
+		inSeason:     true,
+		avalancheErr: errors.New("circuit open"),
+		passesErr:    errors.New("provider unavailable"),
+		timezoneErr:  errors.New("no timezone for coordinates"),
+	}
+	response := assembleLocationBundle(&types.ForecastPoint{}, sections)
+
+	want := []string{"avalanche zone unavailable", "pass conditions unavailable", "timezone unavailable"}
+	if len(response.Warnings) != len(want) {
+		t.Fatalf("warnings = %v, want %v", response.Warnings, want)
+	}
+	for i, warning := range want {
+		if response.Warnings[i] != warning {
+			t.Errorf("warnings[%d] = %q, want %q", i, response.Warnings[i], warning)
+		}
+	}
+	if response.AvalancheZone != nil || response.Passes != nil || response.Timezone != nil {
+		t.Errorf("failed sections serialized anyway: %+v", response)
+	}
+}
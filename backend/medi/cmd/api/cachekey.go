@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"medi/internal/weather"
+)
+
+// Cache key normalization policy for the forecast caches. Requests that
+// differ only by noise must share one cache entry, or repeated lookups at
+// slightly different values create unbounded distinct entries and upstream
+// load. The policy:
+//   - coordinates round to 4 decimals (≈11 m, finer than any forecast grid)
+//   - elevation rounds to the nearest 50 m
+//   - models are sorted, so the same set in any order yields the same key
+//   - forecast days bucket up to 3, 7, or 16, matching the windows clients
+//     actually request
+//   - ensemble-carrying requests key separately, since their cached value
+//     holds the extra probabilistic block
+//   - requests naming a thresholds profile key separately, since the derived
+//     blocks differ; the default profile and the empty name share one entry
+//
+// Interactive handlers only vary coordinates today; the other dimensions are
+// normalized here so any future caller that pins them cannot fragment the
+// cache. Model visibility filtering (the models and allModels params) is
+// applied to the serialized response after the cache, so it never enters the
+// key at all.
+
+// forecastRequest carries the parameters that distinguish one upstream
+// forecast fetch from another. Zero-valued fields are left out of the key.
+type forecastRequest struct {
+	Latitude        float64
+	Longitude       float64
+	ElevationMeters float64
+	Models          []string
+	Days            int
+	IncludeEnsemble bool
+	Profile         string
+}
+
+// normalizeForecastRequest builds the cache key for a forecast request by
+// applying the normalization policy above. It is pure: equal inputs always
+// produce equal keys and the input is not modified.
+func normalizeForecastRequest(r forecastRequest) string {
+	var key strings.Builder
+	fmt.Fprintf(&key, "%.4f,%.4f", r.Latitude, r.Longitude)
+	if r.ElevationMeters != 0 {
+		fmt.Fprintf(&key, ",e%.0f", math.Round(r.ElevationMeters/50)*50)
+	}
+	if r.Days != 0 {
+		fmt.Fprintf(&key, ",d%d", bucketForecastDays(r.Days))
+	}
+	if len(r.Models) != 0 {
+		models := append([]string(nil), r.Models...)
+		sort.Strings(models)
+		fmt.Fprintf(&key, ",m%s", strings.Join(models, "+"))
+	}
+	if r.IncludeEnsemble {
+		key.WriteString(",ens")
+	}
+	if r.Profile != "" && r.Profile != weather.DefaultThresholdProfile {
+		fmt.Fprintf(&key, ",p%s", r.Profile)
+	}
+	return key.String()
+}
+
+// bucketForecastDays snaps a forecast window up to the nearest standard
+// length: 3, 7, or 16 days
+func bucketForecastDays(days int) int {
+	switch {
+	case days <= 3:
+		return 3
+	case days <= 7:
+		return 7
+	default:
+		return 16
+	}
+}
+
+// forecastCacheKey builds the cache key for the coordinate-only lookups the
+// interactive handlers and the prefetcher perform
+func forecastCacheKey(latitude, longitude float64) string {
+	return normalizeForecastRequest(forecastRequest{Latitude: latitude, Longitude: longitude})
+}
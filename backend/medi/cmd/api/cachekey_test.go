@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/weather"
+)
+
+func TestNormalizeForecastRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  forecastRequest
+		expected string
+	}{
+		{
+			"coordinates round to 4 decimals",
+			forecastRequest{Latitude: 39.11539, Longitude: -107.65840},
+			"39.1154,-107.6584",
+		},
+		{
+			"nearby coordinates share a key",
+			forecastRequest{Latitude: 39.115391, Longitude: -107.658404},
+			"39.1154,-107.6584",
+		},
+		{
+			"elevation rounds to 50 m",
+			forecastRequest{Latitude: 39.1154, Longitude: -107.6584, ElevationMeters: 3327},
+			"39.1154,-107.6584,e3350",
+		},
+		{
+			"days bucket up to standard windows",
+			forecastRequest{Latitude: 39.1154, Longitude: -107.6584, Days: 5},
+			"39.1154,-107.6584,d7",
+		},
+		{
+			"models sort regardless of input order",
+			forecastRequest{Latitude: 39.1154, Longitude: -107.6584, Models: []string{"GfsSeamless", "EcmwIfs"}},
+			"39.1154,-107.6584,mEcmwIfs+GfsSeamless",
+		},
+		{
+			"ensemble requests key separately",
+			forecastRequest{Latitude: 39.1154, Longitude: -107.6584, IncludeEnsemble: true},
+			"39.1154,-107.6584,ens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeForecastRequest(tt.request); got != tt.expected {
+				t.Errorf("normalizeForecastRequest = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeForecastRequestDoesNotModifyInput(t *testing.T) {
+	models := []string{"GfsSeamless", "EcmwIfs"}
+	request := forecastRequest{Latitude: 39.1154, Longitude: -107.6584, Models: models}
+
+	first := normalizeForecastRequest(request)
+	if models[0] != "GfsSeamless" || models[1] != "EcmwIfs" {
+		t.Errorf("input models reordered to %v", models)
+	}
+	if second := normalizeForecastRequest(request); second != first {
+		t.Errorf("repeated calls returned %q then %q", first, second)
+	}
+}
+
+func TestBucketForecastDays(t *testing.T) {
+	tests := []struct {
+		days     int
+		expected int
+	}{
+		{1, 3}, {3, 3}, {4, 7}, {7, 7}, {8, 16}, {16, 16}, {30, 16},
+	}
+
+	for _, tt := range tests {
+		if got := bucketForecastDays(tt.days); got != tt.expected {
+			t.Errorf("bucketForecastDays(%d) = %d, want %d", tt.days, got, tt.expected)
+		}
+	}
+}
+
+func TestNearbyCoordinatesShareCacheEntry(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	app := newTestApp(
+		&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}},
+		&mockWeatherService{forecast: &weather.Forecast{}},
+		clock,
+	)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Fatalf("first request X-Cache = %q, want MISS", got)
+	}
+
+	// A request ~11 m away lands on the same normalized key
+	w = doRequest(t, app, "/weather/forecast?latitude=39.115391&longitude=-107.658404")
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("nearby request X-Cache = %q, want HIT from the shared entry", got)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"medi/internal/location"
+)
+
+// CoordinateInput is the shared latitude/longitude query input embedded by
+// every coordinate-taking endpoint, so the tags, docs, and validation live
+// in one place instead of being copy-pasted per handler
+type CoordinateInput struct {
+	Latitude  float64 `form:"latitude" binding:"required"`  // Latitude in decimal degrees
+	Longitude float64 `form:"longitude" binding:"required"` // Longitude in decimal degrees
+}
+
+// validateCoordinates applies the checks query binding cannot express: range
+// bounds, non-finite values ("NaN" and "Inf" parse as valid floats), and the
+// 0,0 null-island rejection unless config allows it. Every endpoint funnels
+// through here so an invalid latitude produces the same error shape
+// everywhere.
+func (app *App) validateCoordinates(input CoordinateInput) error {
+	latitude, longitude := input.Latitude, input.Longitude
+
+	if math.IsNaN(latitude) || math.IsInf(latitude, 0) || latitude < -90 || latitude > 90 {
+		return fmt.Errorf("%w: got %.6f", location.ErrInvalidLatitude, latitude)
+	}
+	if math.IsNaN(longitude) || math.IsInf(longitude, 0) || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("%w: got %.6f", location.ErrInvalidLongitude, longitude)
+	}
+
+	// 0,0 is technically a valid point in the Gulf of Guinea, but in practice
+	// it is an uninitialized client; reject it unless config says otherwise
+	cfg := app.config()
+	allowNullIsland := cfg != nil && cfg.App.AllowNullIsland
+	if latitude == 0 && longitude == 0 && !allowNullIsland {
+		return fmt.Errorf("coordinates 0,0 look uninitialized; pass a real location")
+	}
+
+	return nil
+}
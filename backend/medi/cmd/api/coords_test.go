@@ -0,0 +1,117 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"medi/internal/config"
+)
+
+func TestValidateCoordinates(t *testing.T) {
+	app := &App{}
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		wantErr   string
+	}{
+		{"valid point", 39.11539, -107.65840, ""},
+		{"latitude at north pole", 90, -107, ""},
+		{"latitude past north pole", 90.0001, -107, "latitude must be between"},
+		{"latitude past south pole", -91, -107, "latitude must be between"},
+		{"latitude NaN", math.NaN(), -107, "latitude must be between"},
+		{"latitude infinite", math.Inf(1), -107, "latitude must be between"},
+		{"longitude at antimeridian", 39, 180, ""},
+		{"longitude past antimeridian", 39, -180.5, "longitude must be between"},
+		{"longitude NaN", 39, math.NaN(), "longitude must be between"},
+		{"null island", 0, 0, "look uninitialized"},
+		{"zero latitude alone", 0, -107, ""},
+		{"zero longitude alone", 39, 0, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := app.validateCoordinates(CoordinateInput{Latitude: tc.latitude, Longitude: tc.longitude})
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCoordinatesNullIslandConfigurable(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.AllowNullIsland = true
+	app := &App{cfg: config.NewHolder(cfg)}
+
+	if err := app.validateCoordinates(CoordinateInput{}); err != nil {
+		t.Errorf("0,0 should pass when config allows it, got %v", err)
+	}
+}
+
+func TestValidateCoordinatesFollowsConfigReload(t *testing.T) {
+	holder := config.NewHolder(&config.Config{})
+	app := &App{cfg: holder}
+
+	if err := app.validateCoordinates(CoordinateInput{}); err == nil {
+		t.Fatal("0,0 should be rejected before the reload")
+	}
+
+	next := &config.Config{}
+	next.App.AllowNullIsland = true
+	holder.Replace(next, nil)
+
+	if err := app.validateCoordinates(CoordinateInput{}); err != nil {
+		t.Errorf("decision should follow the reloaded config without restart, got %v", err)
+	}
+}
+
+// TestCoordinateEndpointsShareErrorShape drives every coordinate-taking GET
+// endpoint with the same out-of-range latitude and demands the identical 400
+// body, so clients can handle coordinate errors uniformly
+func TestCoordinateEndpointsShareErrorShape(t *testing.T) {
+	app := newBundleTestApp(
+		&mockAvalancheService{forecast: bundleAvalancheForecast()},
+		&mockTravelService{passes: bundlePassConditions()},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{},
+	)
+
+	const wantBody = `{"error":"latitude must be between -90 and 90: got 91.000000"}`
+	paths := []string{
+		"/location/forecast-point",
+		"/location/bundle",
+		"/weather/forecast",
+		"/weather/forecast/hourly",
+		"/weather/forecast/wait",
+		"/weather/weekly",
+		"/weather/temperature-profile",
+		"/weather/discussion",
+		"/avalanche/forecast",
+		"/avalanche/incidents",
+		"/travel/passes",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, path+"?latitude=91&longitude=-107.65840", nil)
+			w := httptest.NewRecorder()
+			app.router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("got status %d, want 400", w.Code)
+			}
+			if w.Body.String() != wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Renamed response fields go through a managed deprecation instead of
+// breaking clients: the old name is still emitted, duplicated from the new
+// field, for requests that ask for compatibility with compat=1 or an
+// X-API-Version: v1 header. Each entry is tagged deprecated in the served
+// spec with its documented removal date, and every duplication is counted so
+// the metrics endpoint shows when a field is safe to drop.
+
+// deprecatedField records one renamed response field kept alive for
+// compatibility
+type deprecatedField struct {
+	// Definition is the spec definition the old property is documented under
+	Definition string
+	// Old and New are the JSON keys before and after the rename
+	Old string
+	New string
+	// RemovedAfter is the documented date the old field stops being served,
+	// as YYYY-MM-DD
+	RemovedAfter string
+}
+
+// deprecatedFields is the registry driving the duplication, the spec
+// annotations, and the metric labels
+var deprecatedFields = []deprecatedField{
+	{Definition: "weather.DailyForecast", Old: "TotalRainfall", New: "TotalRain", RemovedAfter: "2027-06-01"},
+}
+
+// compatRequested reports whether the client asked for deprecated fields to
+// be included
+func compatRequested(c *gin.Context) bool {
+	return c.Query("compat") == "1" || c.GetHeader("X-API-Version") == "v1"
+}
+
+// compatDocument serializes the payload, then duplicates every deprecated
+// field from its replacement wherever the replacement appears. It returns the
+// rewritten document and how many times each deprecated field was emitted.
+func compatDocument(payload any) (any, map[string]int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	served := map[string]int64{}
+	return duplicateDeprecated(doc, served), served, nil
+}
+
+// duplicateDeprecated walks a decoded JSON document and adds each registered
+// old key alongside its replacement, counting the duplications per field
+func duplicateDeprecated(node any, served map[string]int64) any {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			v[key] = duplicateDeprecated(child, served)
+		}
+		for _, field := range deprecatedFields {
+			value, ok := v[field.New]
+			if !ok {
+				continue
+			}
+			if _, exists := v[field.Old]; exists {
+				continue
+			}
+			v[field.Old] = value
+			served[field.Old]++
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = duplicateDeprecated(v[i], served)
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// deprecationMetrics accumulates how often each deprecated field has been
+// served since startup; the zero value is ready to use
+type deprecationMetrics struct {
+	mu     sync.Mutex
+	served map[string]int64
+}
+
+// add folds one response's duplication counts into the totals
+func (m *deprecationMetrics) add(counts map[string]int64) {
+	if len(counts) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.served == nil {
+		m.served = map[string]int64{}
+	}
+	for field, count := range counts {
+		m.served[field] += count
+	}
+}
+
+// snapshot copies the totals for the metrics endpoint
+func (m *deprecationMetrics) snapshot() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	totals := make(map[string]int64, len(m.served))
+	for field, count := range m.served {
+		totals[field] = count
+	}
+	return totals
+}
+
+// patchDeprecatedFields documents each registered old field in the served
+// spec, tagged deprecated with its removal date
+func patchDeprecatedFields(definitions map[string]any) {
+	for _, field := range deprecatedFields {
+		definition, ok := definitions[field.Definition].(map[string]any)
+		if !ok {
+			definition = map[string]any{"type": "object"}
+			definitions[field.Definition] = definition
+		}
+		properties, ok := definition["properties"].(map[string]any)
+		if !ok {
+			properties = map[string]any{}
+			definition["properties"] = properties
+		}
+		properties[field.Old] = map[string]any{
+			"deprecated": true,
+			"description": fmt.Sprintf(
+				"Deprecated: renamed to %s. Duplicated here for compat=1 or X-API-Version: v1 requests until %s.",
+				field.New, field.RemovedAfter,
+			),
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+	"medi/internal/weather"
+)
+
+func TestCompatDocumentDuplicatesFromTable(t *testing.T) {
+	payload := map[string]any{
+		"days": []any{
+			map[string]any{"TotalRain": map[string]any{"GfsSeamless": 0.4}},
+			map[string]any{"TotalRain": map[string]any{"GfsSeamless": 0.1}},
+		},
+		"untouched": map[string]any{"TotalSnowfall": 1.0},
+	}
+
+	doc, served, err := compatDocument(payload)
+	if err != nil {
+		t.Fatalf("compatDocument failed: %v", err)
+	}
+
+	days := doc.(map[string]any)["days"].([]any)
+	for i, raw := range days {
+		day := raw.(map[string]any)
+		oldValue, ok := day["TotalRainfall"]
+		if !ok {
+			t.Fatalf("day %d missing the deprecated TotalRainfall duplicate", i)
+		}
+		newValue := day["TotalRain"]
+		oldJSON, _ := json.Marshal(oldValue)
+		newJSON, _ := json.Marshal(newValue)
+		if string(oldJSON) != string(newJSON) {
+			t.Errorf("day %d deprecated field diverged: %s vs %s", i, oldJSON, newJSON)
+		}
+	}
+	if served["TotalRainfall"] != 2 {
+		t.Errorf("served[TotalRainfall] = %d, want 2", served["TotalRainfall"])
+	}
+	if _, ok := doc.(map[string]any)["untouched"].(map[string]any)["TotalRainfall"]; ok {
+		t.Error("object without the new field gained a deprecated duplicate")
+	}
+}
+
+// compatTestForecast carries one daily forecast with a TotalRain value so the
+// deprecated duplicate has something to mirror
+func compatTestForecast() *weather.Forecast {
+	denver, _ := time.LoadLocation("America/Denver")
+	return &weather.Forecast{
+		Timezone:  "America/Denver",
+		Timestamp: time.Date(2025, 1, 15, 8, 0, 0, 0, denver),
+		DailyForecasts: []weather.DailyForecast{{
+			Timestamp: time.Date(2025, 1, 15, 0, 0, 0, 0, denver),
+			TotalRain: weather.ModelValues[types.Precipitation]{
+				weather.ModelGfsSeamless: types.NewPrecipitationFromInches(0.4),
+			},
+		}},
+	}
+}
+
+func TestForecastEndpointCompatServesDeprecatedFields(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: compatTestForecast()}, time.Now)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&compat=1")
+	body := w.Body.String()
+	var response struct {
+		Forecast struct {
+			DailyForecasts []map[string]json.RawMessage `json:"DailyForecasts"`
+		} `json:"forecast"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("compat response is not valid JSON: %v\n%s", err, body)
+	}
+	day := response.Forecast.DailyForecasts[0]
+	if _, ok := day["TotalRainfall"]; !ok {
+		t.Fatalf("compat response missing deprecated TotalRainfall: %s", body)
+	}
+	if string(day["TotalRainfall"]) != string(day["TotalRain"]) {
+		t.Errorf("TotalRainfall = %s, want the TotalRain value %s", day["TotalRainfall"], day["TotalRain"])
+	}
+
+	// The duplication shows up in the deprecation metric, labeled by field
+	metrics := doRequest(t, app, "/metrics")
+	var report MetricsResponse
+	if err := json.Unmarshal(metrics.Body.Bytes(), &report); err != nil {
+		t.Fatalf("metrics response is not valid JSON: %v", err)
+	}
+	if report.DeprecatedFieldServedTotal["TotalRainfall"] == 0 {
+		t.Errorf("deprecated_field_served_total = %v, want a TotalRainfall count", report.DeprecatedFieldServedTotal)
+	}
+}
+
+func TestForecastEndpointOmitsDeprecatedFieldsByDefault(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: compatTestForecast()}, time.Now)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	var response struct {
+		Forecast struct {
+			DailyForecasts []map[string]json.RawMessage `json:"DailyForecasts"`
+		} `json:"forecast"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if _, ok := response.Forecast.DailyForecasts[0]["TotalRainfall"]; ok {
+		t.Error("deprecated TotalRainfall served without compat=1")
+	}
+}
+
+func TestForecastEndpointCompatViaVersionHeader(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: compatTestForecast()}, time.Now)
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/forecast?latitude=39.11539&longitude=-107.65840", nil)
+	req.Header.Set("X-API-Version", "v1")
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var response struct {
+		Forecast struct {
+			DailyForecasts []map[string]json.RawMessage `json:"DailyForecasts"`
+		} `json:"forecast"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if _, ok := response.Forecast.DailyForecasts[0]["TotalRainfall"]; !ok {
+		t.Error("X-API-Version: v1 did not include deprecated fields")
+	}
+}
+
+func TestAPISpecMarksDeprecatedFields(t *testing.T) {
+	patched, err := patchAPISpec([]byte(`{"swagger":"2.0","definitions":{}}`))
+	if err != nil {
+		t.Fatalf("patchAPISpec failed: %v", err)
+	}
+	var spec struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Deprecated  bool   `json:"deprecated"`
+				Description string `json:"description"`
+			} `json:"properties"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(patched, &spec); err != nil {
+		t.Fatalf("patched spec is not valid JSON: %v", err)
+	}
+
+	for _, field := range deprecatedFields {
+		property, ok := spec.Definitions[field.Definition].Properties[field.Old]
+		if !ok {
+			t.Fatalf("spec does not document deprecated %s.%s", field.Definition, field.Old)
+		}
+		if !property.Deprecated {
+			t.Errorf("%s.%s not tagged deprecated", field.Definition, field.Old)
+		}
+		if !containsAll(property.Description, field.New, field.RemovedAfter) {
+			t.Errorf("description %q does not name %s and the removal date %s", property.Description, field.New, field.RemovedAfter)
+		}
+	}
+}
+
+// containsAll reports whether s contains every needle
+func containsAll(s string, needles ...string) bool {
+	for _, needle := range needles {
+		if !strings.Contains(s, needle) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"medi/internal/location"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetForecastDiscussionInput defines the query parameters for the forecast discussion endpoint
+type GetForecastDiscussionInput struct {
+	CoordinateInput
+}
+
+// ForecastDiscussionResponse carries the NWS area forecast discussion in both
+// the original teletype layout and a normalized form for display
+type ForecastDiscussionResponse struct {
+	IssuingOffice  string                 `json:"issuingOffice"`
+	IssuanceTime   time.Time              `json:"issuanceTime"`
+	RawText        string                 `json:"rawText"`
+	NormalizedText string                 `json:"normalizedText"`
+	Sections       []DiscussionSectionDTO `json:"sections"`
+}
+
+type DiscussionSectionDTO struct {
+	Name string `json:"name"`
+	Text string `json:"text"`
+}
+
+// handleGetForecastDiscussion godoc
+// @Summary Get the area forecast discussion
+// @Description Retrieve the NWS forecaster discussion for the area covering a point, as raw product text and a normalized version with hard line wrapping undone and sections split out
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Success 200 {object} ForecastDiscussionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /weather/discussion [get]
+func (app *App) handleGetForecastDiscussion(c *gin.Context) {
+	var input GetForecastDiscussionInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	forecastPoint, err := app.locationService.GetForecastPoint(input.Latitude, input.Longitude)
+	if err != nil {
+		if errors.Is(err, location.ErrInvalidLatitude) || errors.Is(err, location.ErrInvalidLongitude) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		app.logger.Error("failed to get forecast point",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get forecast discussion"})
+		return
+	}
+
+	discussion, err := app.weatherService.GetForecastDiscussion(*forecastPoint)
+	if err != nil {
+		app.logger.Error("failed to get forecast discussion",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get forecast discussion"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newForecastDiscussionResponse(discussion))
+}
+
+func newForecastDiscussionResponse(discussion *weather.ForecastDiscussion) ForecastDiscussionResponse {
+	response := ForecastDiscussionResponse{
+		IssuingOffice:  discussion.IssuingOffice,
+		IssuanceTime:   discussion.IssuanceTime,
+		RawText:        discussion.RawText,
+		NormalizedText: discussion.NormalizedText,
+		Sections:       make([]DiscussionSectionDTO, 0, len(discussion.Sections)),
+	}
+	for _, section := range discussion.Sections {
+		response.Sections = append(response.Sections, DiscussionSectionDTO{
+			Name: section.Name,
+			Text: section.Text,
+		})
+	}
+	return response
+}
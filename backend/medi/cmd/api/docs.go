@@ -1,5 +1,18 @@
 package main
 
+import (
+	"fmt"
+	"net/http"
+
+	"medi/docs"
+	"medi/internal/config"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
+)
+
 // @title Medi API
 // @version 1.0.0
 // @description Weather and avalanche forecasting API for ski areas
@@ -7,3 +20,69 @@ package main
 // @contact.email support@example.com
 // @host localhost:8080
 // @BasePath /
+
+// applyDocsInfo points the generated specification at the deployment's
+// advertised host and base path instead of the values compiled into the docs
+// package, so the spec stays accurate without regenerating it per environment
+func applyDocsInfo(cfg *config.Config) {
+	host := cfg.Server.DocsHost
+	if host == "" {
+		host = fmt.Sprintf("localhost:%d", cfg.Server.Port)
+	}
+	basePath := cfg.Server.DocsBasePath
+	if basePath == "" {
+		basePath = "/"
+	}
+	docs.SwaggerInfo.Host = host
+	docs.SwaggerInfo.BasePath = basePath
+}
+
+// registerDocsRoutes serves the generated API specification and the
+// interactive documentation UI. All documentation routes are skipped when
+// server.enableDocs is off, so production deployments can keep them private.
+func (app *App) registerDocsRoutes() {
+	if cfg := app.config(); cfg != nil && !cfg.Server.EnableDocs {
+		return
+	}
+
+	app.router.GET("/openapi.json", app.handleGetAPISpec)
+
+	// Standalone JSON Schemas for the response DTOs, for clients that
+	// validate payloads outside OpenAPI
+	app.router.GET("/schema/:name", app.handleGetSchema)
+
+	// The UI bundle serves its assets relative to the wildcard, so the same
+	// handler backs both the new /docs path and the historical /swagger one
+	ui := func(prefix string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			if c.Param("any") == "/" {
+				c.Redirect(http.StatusMovedPermanently, prefix+"/index.html")
+				return
+			}
+			ginSwagger.WrapHandler(swaggerFiles.Handler)(c)
+		}
+	}
+	app.router.GET("/docs/*any", ui("/docs"))
+	app.router.GET("/swagger/*any", ui("/swagger"))
+}
+
+// handleGetAPISpec serves the swag-generated specification as plain JSON so
+// clients and tooling can fetch it without going through the UI bundle
+func (app *App) handleGetAPISpec(c *gin.Context) {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		app.logger.Error("failed to read generated API spec", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "API specification unavailable"})
+		return
+	}
+
+	// Layer in the hand-maintained definitions swag cannot express, like the
+	// model-name enum on ModelValues keys
+	patched, err := patchAPISpec([]byte(doc))
+	if err != nil {
+		app.logger.Error("failed to patch generated API spec", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "API specification unavailable"})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", patched)
+}
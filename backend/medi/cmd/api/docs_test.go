@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"medi/docs"
+	"medi/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newDocsTestApp builds an App with only what the documentation routes need
+func newDocsTestApp(docsEnabled bool) *App {
+	gin.SetMode(gin.TestMode)
+	app := &App{
+		router: gin.New(),
+		logger: slog.Default(),
+		cfg: config.NewHolder(&config.Config{
+			Server: config.ServerConfig{EnableDocs: docsEnabled},
+		}),
+	}
+	app.registerRoutes()
+	return app
+}
+
+func TestOpenAPISpecServed(t *testing.T) {
+	app := newDocsTestApp(true)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json returned status %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.Contains(contentType, "application/json") {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	var spec struct {
+		Swagger string                 `json:"swagger"`
+		Paths   map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Spec is not valid JSON: %v", err)
+	}
+	if spec.Swagger == "" {
+		t.Error("Spec is missing its version field")
+	}
+	for _, path := range []string{"/ping", "/location/forecast-point"} {
+		if _, ok := spec.Paths[path]; !ok {
+			t.Errorf("Spec is missing registered path %s", path)
+		}
+	}
+}
+
+func TestDocsUIServed(t *testing.T) {
+	app := newDocsTestApp(true)
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/index.html", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /docs/index.html returned status %d", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.Contains(contentType, "text/html") {
+		t.Errorf("Content-Type = %q, want text/html", contentType)
+	}
+
+	// The bare path redirects into the bundle rather than 404ing
+	req = httptest.NewRequest(http.MethodGet, "/docs/", nil)
+	w = httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusMovedPermanently {
+		t.Errorf("GET /docs/ returned status %d, want 301", w.Code)
+	}
+}
+
+func TestDocsDisabled(t *testing.T) {
+	app := newDocsTestApp(false)
+
+	for _, path := range []string{"/openapi.json", "/docs/index.html", "/swagger/index.html"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		app.router.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("GET %s returned status %d with docs disabled, want 404", path, w.Code)
+		}
+	}
+}
+
+func TestSwaggerDocJSONAdvertisesConfiguredHost(t *testing.T) {
+	prevHost, prevBasePath := docs.SwaggerInfo.Host, docs.SwaggerInfo.BasePath
+	t.Cleanup(func() {
+		docs.SwaggerInfo.Host = prevHost
+		docs.SwaggerInfo.BasePath = prevBasePath
+	})
+	applyDocsInfo(&config.Config{Server: config.ServerConfig{
+		Port:     8080,
+		DocsHost: "api.example.com",
+	}})
+
+	app := newDocsTestApp(true)
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /swagger/doc.json returned status %d: %s", w.Code, w.Body.String())
+	}
+
+	var spec struct {
+		Host     string                 `json:"host"`
+		BasePath string                 `json:"basePath"`
+		Paths    map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Spec is not valid JSON: %v", err)
+	}
+	if spec.Host != "api.example.com" {
+		t.Errorf("Spec advertises host %q, want the configured api.example.com", spec.Host)
+	}
+	if spec.BasePath != "/" {
+		t.Errorf("Spec advertises base path %q, want /", spec.BasePath)
+	}
+	if _, ok := spec.Paths["/location/forecast-point"]; !ok {
+		t.Error("Spec is missing the forecast-point operation")
+	}
+}
+
+func TestApplyDocsInfoDefaultsToConfiguredPort(t *testing.T) {
+	prevHost, prevBasePath := docs.SwaggerInfo.Host, docs.SwaggerInfo.BasePath
+	t.Cleanup(func() {
+		docs.SwaggerInfo.Host = prevHost
+		docs.SwaggerInfo.BasePath = prevBasePath
+	})
+
+	applyDocsInfo(&config.Config{Server: config.ServerConfig{Port: 9090}})
+	if docs.SwaggerInfo.Host != "localhost:9090" {
+		t.Errorf("Host = %q, want localhost:9090", docs.SwaggerInfo.Host)
+	}
+	if docs.SwaggerInfo.BasePath != "/" {
+		t.Errorf("BasePath = %q, want /", docs.SwaggerInfo.BasePath)
+	}
+}
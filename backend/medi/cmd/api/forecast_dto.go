@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"medi/internal/weather"
+)
+
+// LocalTimestamp is how forecast timestamps are serialized: an RFC3339 string
+// carrying the forecast timezone's UTC offset, plus epoch seconds for clients
+// that do their own date math.
+type LocalTimestamp struct {
+	RFC3339      string `json:"rfc3339"`
+	EpochSeconds int64  `json:"epochSeconds"`
+}
+
+func newLocalTimestamp(t time.Time, loc *time.Location) LocalTimestamp {
+	local := t.In(loc)
+	return LocalTimestamp{
+		RFC3339:      local.Format(time.RFC3339),
+		EpochSeconds: local.Unix(),
+	}
+}
+
+func localTimestamps(values weather.ModelValues[time.Time], loc *time.Location) weather.ModelValues[LocalTimestamp] {
+	if values == nil {
+		return nil
+	}
+	converted := weather.ModelValues[LocalTimestamp]{}
+	for model, t := range values {
+		converted[model] = newLocalTimestamp(t, loc)
+	}
+	return converted
+}
+
+func optionalLocalTimestamp(t *time.Time, loc *time.Location) *LocalTimestamp {
+	if t == nil {
+		return nil
+	}
+	converted := newLocalTimestamp(*t, loc)
+	return &converted
+}
+
+// ForecastDTO mirrors weather.Forecast for serialization. The domain types
+// keep plain time.Time; the DTO shadows every timestamp field with a
+// LocalTimestamp so responses always show the forecast point's local offset.
+type ForecastDTO struct {
+	*weather.Forecast
+	Timestamp LocalTimestamp
+
+	// UtcOffsetSeconds and TimezoneAbbreviation (e.g. MST or MDT) are
+	// resolved for the forecast start date
+	UtcOffsetSeconds     int    `json:"utcOffsetSeconds"`
+	TimezoneAbbreviation string `json:"timezoneAbbreviation"`
+
+	// CurrentConditions shadows the embedded forecast's block with a copy
+	// rounded to display precision (see precision.go)
+	CurrentConditions weather.CurrentConditions
+
+	// ModelHorizons shadows the per-model horizon ends as local timestamps
+	ModelHorizons weather.ModelValues[LocalTimestamp]
+
+	// Models carries the display metadata for every model the forecast
+	// includes, mirroring /weather/models so charts need no second fetch
+	Models []WeatherModelInfo `json:"models"`
+
+	DailyForecasts []DailyForecastDTO
+	PowderAlerts   []PowderAlertDTO
+
+	// Cumulative shadows the domain curves with local timestamps and display
+	// precision; left out of the payload unless the request opts in
+	Cumulative *CumulativeDTO `json:",omitempty"`
+
+	// ProbabilisticSnowfall shadows the ensemble block with local timestamps
+	// and display precision; present only when the request opted in
+	ProbabilisticSnowfall *ProbabilisticSnowfallDTO `json:",omitempty"`
+}
+
+// ProbabilisticSnowfallDTO renders the ensemble snowfall bands
+type ProbabilisticSnowfallDTO struct {
+	Members int                       `json:"members"`
+	Times   []LocalTimestamp          `json:"times"`
+	Hourly  []weather.PercentileBand  `json:"hourly"`
+	Daily   []DailySnowfallOutlookDTO `json:"daily"`
+}
+
+// DailySnowfallOutlookDTO carries one day's total bands and exceedance table
+type DailySnowfallOutlookDTO struct {
+	Date        LocalTimestamp                  `json:"date"`
+	Members     int                             `json:"members"`
+	TotalInches weather.PercentileBand          `json:"totalInches"`
+	Exceedance  weather.ExceedanceProbabilities `json:"exceedance"`
+}
+
+// CumulativeDTO renders the cumulative accumulation block for charting
+type CumulativeDTO struct {
+	Times               []LocalTimestamp    `json:"times"`
+	Snowfall            CumulativeSeriesDTO `json:"snowfall"`
+	LiquidPrecipitation CumulativeSeriesDTO `json:"liquidPrecipitation"`
+}
+
+// CumulativeSeriesDTO carries one variable's running totals in inches
+type CumulativeSeriesDTO struct {
+	PerModel weather.ModelValues[[]float64] `json:"perModel"`
+	P25      []float64                      `json:"p25"`
+	P50      []float64                      `json:"p50"`
+	P75      []float64                      `json:"p75"`
+}
+
+type DailyForecastDTO struct {
+	weather.DailyForecast
+	Timestamp           LocalTimestamp
+	HourlyForecasts     []HourlyForecastDTO
+	Sunrise             weather.ModelValues[LocalTimestamp]
+	Sunset              weather.ModelValues[LocalTimestamp]
+	PeakSnowfallHour    weather.ModelValues[LocalTimestamp]
+	ConsensusPeakWindow *PeakWindowDTO
+	Daylight            *DaylightDTO
+	Moon                *MoonDTO
+}
+
+// DaylightDTO renders the solar block with events as local timestamps and the
+// day length in minutes. Events are nil when the sun does not cross the
+// relevant altitude that day (polar night or midnight sun).
+type DaylightDTO struct {
+	Rise             *LocalTimestamp `json:"rise"`
+	Set              *LocalTimestamp `json:"set"`
+	CivilDawn        *LocalTimestamp `json:"civilDawn"`
+	CivilDusk        *LocalTimestamp `json:"civilDusk"`
+	DayLengthMinutes int             `json:"dayLengthMinutes"`
+}
+
+// MoonDTO renders the moon block with rise/set as local timestamps; Rise or
+// Set is nil when the moon does not cross the horizon that day.
+type MoonDTO struct {
+	Phase        string          `json:"phase"`
+	Illumination float64         `json:"illumination"`
+	Rise         *LocalTimestamp `json:"rise"`
+	Set          *LocalTimestamp `json:"set"`
+}
+
+type HourlyForecastDTO struct {
+	weather.HourlyForecast
+	Start LocalTimestamp
+	End   LocalTimestamp
+}
+
+type PeakWindowDTO struct {
+	weather.PeakWindow
+	Start LocalTimestamp
+	End   LocalTimestamp
+}
+
+type PowderAlertDTO struct {
+	weather.PowderAlert
+	Start LocalTimestamp
+	End   LocalTimestamp
+}
+
+// newForecastDTO builds the serialization DTO for a forecast, rendering all
+// timestamps in the forecast's own timezone and all measurements at display
+// precision. Hours spanning a DST transition show the offset in effect at
+// each hour.
+func newForecastDTO(forecast *weather.Forecast, includeCumulative bool) (*ForecastDTO, error) {
+	loc, err := time.LoadLocation(forecast.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load forecast timezone %q: %w", forecast.Timezone, err)
+	}
+
+	startDate := forecast.Timestamp
+	if len(forecast.DailyForecasts) > 0 {
+		startDate = forecast.DailyForecasts[0].Timestamp
+	}
+	abbreviation, offsetSeconds := startDate.In(loc).Zone()
+
+	dto := &ForecastDTO{
+		Forecast:             forecast,
+		Timestamp:            newLocalTimestamp(forecast.Timestamp, loc),
+		UtcOffsetSeconds:     offsetSeconds,
+		TimezoneAbbreviation: abbreviation,
+		CurrentConditions:    roundedCurrentConditions(forecast.CurrentConditions),
+		ModelHorizons:        localTimestamps(forecast.ModelHorizons, loc),
+		Models:               weatherModelInfos(forecastModels(forecast)),
+	}
+
+	for _, day := range forecast.DailyForecasts {
+		dto.DailyForecasts = append(dto.DailyForecasts, newDailyForecastDTO(day, loc))
+	}
+	for _, alert := range forecast.PowderAlerts {
+		dto.PowderAlerts = append(dto.PowderAlerts, PowderAlertDTO{
+			PowderAlert: roundedPowderAlert(alert),
+			Start:       newLocalTimestamp(alert.Start, loc),
+			End:         newLocalTimestamp(alert.End, loc),
+		})
+	}
+
+	if includeCumulative && forecast.Cumulative != nil {
+		dto.Cumulative = newCumulativeDTO(forecast.Cumulative, loc)
+	}
+	if forecast.ProbabilisticSnowfall != nil {
+		dto.ProbabilisticSnowfall = newProbabilisticSnowfallDTO(forecast.ProbabilisticSnowfall, loc)
+	}
+
+	return dto, nil
+}
+
+// forecastModels lists the models a forecast carries: the horizon map covers
+// the multi-model path, the first populated hour's temperature covers the
+// fallback path, which sets no horizons
+func forecastModels(forecast *weather.Forecast) []string {
+	if len(forecast.ModelHorizons) > 0 {
+		return forecast.ModelHorizons.Models()
+	}
+	for _, day := range forecast.DailyForecasts {
+		for _, hour := range day.HourlyForecasts {
+			if len(hour.Temperature) > 0 {
+				return hour.Temperature.Models()
+			}
+		}
+	}
+	return nil
+}
+
+// newProbabilisticSnowfallDTO copies the ensemble bands with the time axes
+// localized and values at display precision
+func newProbabilisticSnowfallDTO(probabilistic *weather.ProbabilisticSnowfall, loc *time.Location) *ProbabilisticSnowfallDTO {
+	dto := &ProbabilisticSnowfallDTO{
+		Members: probabilistic.Members,
+		Times:   make([]LocalTimestamp, 0, len(probabilistic.Times)),
+		Hourly:  make([]weather.PercentileBand, 0, len(probabilistic.Hourly)),
+		Daily:   make([]DailySnowfallOutlookDTO, 0, len(probabilistic.Daily)),
+	}
+	for _, t := range probabilistic.Times {
+		dto.Times = append(dto.Times, newLocalTimestamp(t, loc))
+	}
+	for _, band := range probabilistic.Hourly {
+		dto.Hourly = append(dto.Hourly, roundedPercentileBand(band))
+	}
+	for _, day := range probabilistic.Daily {
+		dto.Daily = append(dto.Daily, DailySnowfallOutlookDTO{
+			Date:        newLocalTimestamp(day.Date, loc),
+			Members:     day.Members,
+			TotalInches: roundedPercentileBand(day.TotalInches),
+			Exceedance: weather.ExceedanceProbabilities{
+				Above3In:  roundTo(day.Exceedance.Above3In, 2),
+				Above6In:  roundTo(day.Exceedance.Above6In, 2),
+				Above12In: roundTo(day.Exceedance.Above12In, 2),
+			},
+		})
+	}
+	return dto
+}
+
+func roundedPercentileBand(band weather.PercentileBand) weather.PercentileBand {
+	return weather.PercentileBand{
+		P10: roundTo(band.P10, 2),
+		P25: roundTo(band.P25, 2),
+		P50: roundTo(band.P50, 2),
+		P75: roundTo(band.P75, 2),
+		P90: roundTo(band.P90, 2),
+	}
+}
+
+// newCumulativeDTO copies the cumulative curves with the time axis localized
+// and totals at display precision, leaving the cached domain values untouched
+func newCumulativeDTO(cumulative *weather.CumulativeAccumulation, loc *time.Location) *CumulativeDTO {
+	dto := &CumulativeDTO{
+		Times:               make([]LocalTimestamp, 0, len(cumulative.Times)),
+		Snowfall:            newCumulativeSeriesDTO(cumulative.Snowfall),
+		LiquidPrecipitation: newCumulativeSeriesDTO(cumulative.LiquidPrecipitation),
+	}
+	for _, t := range cumulative.Times {
+		dto.Times = append(dto.Times, newLocalTimestamp(t, loc))
+	}
+	return dto
+}
+
+func newCumulativeSeriesDTO(series weather.CumulativeSeries) CumulativeSeriesDTO {
+	dto := CumulativeSeriesDTO{
+		PerModel: weather.ModelValues[[]float64]{},
+		P25:      roundedSlice(series.P25, 2),
+		P50:      roundedSlice(series.P50, 2),
+		P75:      roundedSlice(series.P75, 2),
+	}
+	for model, totals := range series.PerModel {
+		dto.PerModel[model] = roundedSlice(totals, 2)
+	}
+	return dto
+}
+
+func newDailyForecastDTO(day weather.DailyForecast, loc *time.Location) DailyForecastDTO {
+	dto := DailyForecastDTO{
+		DailyForecast:    roundedDailyForecast(day),
+		Timestamp:        newLocalTimestamp(day.Timestamp, loc),
+		Sunrise:          localTimestamps(day.Sunrise, loc),
+		Sunset:           localTimestamps(day.Sunset, loc),
+		PeakSnowfallHour: localTimestamps(day.PeakSnowfallHour, loc),
+	}
+
+	if day.Daylight != nil {
+		daylight := &DaylightDTO{
+			DayLengthMinutes: int(day.Daylight.DayLength.Round(time.Minute) / time.Minute),
+		}
+		daylight.Rise = optionalLocalTimestamp(day.Daylight.Rise, loc)
+		daylight.Set = optionalLocalTimestamp(day.Daylight.Set, loc)
+		daylight.CivilDawn = optionalLocalTimestamp(day.Daylight.CivilDawn, loc)
+		daylight.CivilDusk = optionalLocalTimestamp(day.Daylight.CivilDusk, loc)
+		dto.Daylight = daylight
+	}
+
+	if day.Moon != nil {
+		moon := &MoonDTO{
+			Phase:        day.Moon.Phase,
+			Illumination: day.Moon.Illumination,
+		}
+		if day.Moon.Rise != nil {
+			rise := newLocalTimestamp(*day.Moon.Rise, loc)
+			moon.Rise = &rise
+		}
+		if day.Moon.Set != nil {
+			set := newLocalTimestamp(*day.Moon.Set, loc)
+			moon.Set = &set
+		}
+		dto.Moon = moon
+	}
+
+	if day.ConsensusPeakWindow != nil {
+		dto.ConsensusPeakWindow = &PeakWindowDTO{
+			PeakWindow: *day.ConsensusPeakWindow,
+			Start:      newLocalTimestamp(day.ConsensusPeakWindow.Start, loc),
+			End:        newLocalTimestamp(day.ConsensusPeakWindow.End, loc),
+		}
+	}
+
+	for _, hour := range day.HourlyForecasts {
+		dto.HourlyForecasts = append(dto.HourlyForecasts, HourlyForecastDTO{
+			HourlyForecast: roundedHourlyForecast(hour),
+			Start:          newLocalTimestamp(hour.Start, loc),
+			End:            newLocalTimestamp(hour.End, loc),
+		})
+	}
+
+	return dto
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/weather"
+)
+
+// TestForecastDTODSTFallBack covers the November fall-back transition: hours
+// before and after the change must carry the offset in effect at that hour.
+func TestForecastDTODSTFallBack(t *testing.T) {
+	// 2025-11-02 in America/Denver: clocks fall back from MDT (-06:00) to
+	// MST (-07:00) at 02:00. Hourly times arrive from the mapping in UTC.
+	hours := []weather.HourlyForecast{
+		{
+			Start: time.Date(2025, 11, 2, 7, 0, 0, 0, time.UTC), // 01:00 MDT
+			End:   time.Date(2025, 11, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			Start: time.Date(2025, 11, 2, 8, 0, 0, 0, time.UTC), // 01:00 MST
+			End:   time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Start: time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC), // 02:00 MST
+			End:   time.Date(2025, 11, 2, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	forecast := &weather.Forecast{
+		Timestamp: time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC),
+		Timezone:  weather.TimezoneDenver,
+		DailyForecasts: []weather.DailyForecast{
+			{
+				Timestamp:       time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC), // midnight MDT
+				HourlyForecasts: hours,
+			},
+		},
+	}
+
+	dto, err := newForecastDTO(forecast, false)
+	if err != nil {
+		t.Fatalf("newForecastDTO returned error: %v", err)
+	}
+
+	// The start date is still on daylight time
+	if dto.TimezoneAbbreviation != "MDT" {
+		t.Errorf("timezone abbreviation = %q, want MDT", dto.TimezoneAbbreviation)
+	}
+	if dto.UtcOffsetSeconds != -6*3600 {
+		t.Errorf("utc offset = %d seconds, want %d", dto.UtcOffsetSeconds, -6*3600)
+	}
+
+	got := dto.DailyForecasts[0].HourlyForecasts
+	wantStarts := []string{
+		"2025-11-02T01:00:00-06:00",
+		"2025-11-02T01:00:00-07:00",
+		"2025-11-02T02:00:00-07:00",
+	}
+	for i, want := range wantStarts {
+		if got[i].Start.RFC3339 != want {
+			t.Errorf("hour %d start = %q, want %q", i, got[i].Start.RFC3339, want)
+		}
+		if got[i].Start.EpochSeconds != hours[i].Start.Unix() {
+			t.Errorf("hour %d epoch = %d, want %d", i, got[i].Start.EpochSeconds, hours[i].Start.Unix())
+		}
+	}
+}
+
+func TestForecastDTOBadTimezone(t *testing.T) {
+	forecast := &weather.Forecast{Timezone: "Not/AZone"}
+	if _, err := newForecastDTO(forecast, false); err == nil {
+		t.Fatal("expected error for unknown timezone, got nil")
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"medi/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Freshness describes how old the data behind a response is. It mirrors the
+// X-Data-Fetched-At, X-Data-Source-Age, and X-Cache headers for clients that
+// cannot read headers.
+type Freshness struct {
+	FetchedAt time.Time `json:"fetched_at" doc:"When the underlying provider data was fetched"`
+	SourceAge string    `json:"source_age,omitempty" doc:"Age of the provider's own data when known"`
+	Cache     string    `json:"cache" doc:"Cache disposition: HIT, MISS, or STALE"`
+	Stale     bool      `json:"stale" doc:"True when the served data is past its freshness window"`
+}
+
+// newFreshness builds a Freshness for a cache entry served with the given
+// disposition. sourceAge may be zero when the provider data age is unknown.
+func newFreshness(fetchedAt time.Time, disposition cache.Status, stale bool, sourceAge time.Duration) Freshness {
+	f := Freshness{
+		FetchedAt: fetchedAt.UTC(),
+		Cache:     string(disposition),
+		Stale:     stale,
+	}
+	if sourceAge > 0 {
+		f.SourceAge = sourceAge.Truncate(time.Second).String()
+	}
+	return f
+}
+
+// setFreshnessHeaders mirrors the freshness object onto response headers
+func setFreshnessHeaders(c *gin.Context, f Freshness) {
+	c.Header("X-Data-Fetched-At", f.FetchedAt.Format(time.RFC3339))
+	if f.SourceAge != "" {
+		c.Header("X-Data-Source-Age", f.SourceAge)
+	}
+	c.Header("X-Cache", f.Cache)
+	c.Header("ETag", forecastETag(f.FetchedAt))
+}
+
+// forecastETag derives the entity tag for a cached forecast from its fetch
+// time; every refresh stores a new fetch time, so the tag changes with it
+func forecastETag(fetchedAt time.Time) string {
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", fetchedAt.UTC().UnixNano()))
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/cache"
+	"medi/internal/location"
+	"medi/internal/types"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mockAvalancheService returns a canned forecast until fail is set
+type mockAvalancheService struct {
+	forecast *avalanche.AvalancheForecast
+	fail     bool
+	calls    int
+}
+
+func (m *mockAvalancheService) GetForecast(latitude, longitude float64) (*avalanche.AvalancheForecast, error) {
+	m.calls++
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return m.forecast, nil
+}
+
+func (m *mockAvalancheService) GetZoneSamplePoints(centerId string, zoneId int) ([]types.Coords, error) {
+	return nil, avalanche.ErrZoneNotFound
+}
+
+func (m *mockAvalancheService) GetZones(filter avalanche.ZoneFilter) (*avalanche.ZoneList, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return &avalanche.ZoneList{Zones: []avalanche.ZoneSummary{}}, nil
+}
+
+func (m *mockAvalancheService) GetCenters() (*avalanche.CenterList, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return &avalanche.CenterList{Centers: []avalanche.Center{}}, nil
+}
+
+func (m *mockAvalancheService) GetSummary(filter avalanche.SummaryFilter) (*avalanche.RegionSummary, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return &avalanche.RegionSummary{}, nil
+}
+
+func (m *mockAvalancheService) CheckRoute(waypoints []avalanche.RouteWaypoint) (*avalanche.RouteCheck, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return &avalanche.RouteCheck{}, nil
+}
+
+func (m *mockAvalancheService) GetIncidents(latitude, longitude, radiusKm float64, seasonOnly bool) (*avalanche.IncidentSearch, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return &avalanche.IncidentSearch{Incidents: []avalanche.Incident{}}, nil
+}
+
+// mockWeatherService returns a canned forecast until fail is set
+type mockWeatherService struct {
+	forecast *weather.Forecast
+	fail     bool
+	// probabilistic is returned from GetProbabilisticSnowfall when set;
+	// unset mimics a deployment without an ensemble provider
+	probabilistic *weather.ProbabilisticSnowfall
+	// snowReport is returned from GetSnowReport when set
+	snowReport *weather.SnowReport
+}
+
+func (m *mockWeatherService) GetForecast(_ context.Context, point types.ForecastPoint, profile string) (*weather.Forecast, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return m.forecast, nil
+}
+
+func (m *mockWeatherService) SetBiasCorrection(latitude, longitude float64, model string, correction weather.BiasCorrection) error {
+	return correction.Validate()
+}
+
+func (m *mockWeatherService) GetProbabilisticSnowfall(_ context.Context, point types.ForecastPoint) (*weather.ProbabilisticSnowfall, error) {
+	if m.probabilistic == nil {
+		return nil, weather.ErrEnsembleUnavailable
+	}
+	return m.probabilistic, nil
+}
+
+func (m *mockWeatherService) GetSnowReport(_ context.Context, point types.ForecastPoint) (*weather.SnowReport, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return m.snowReport, nil
+}
+
+func (m *mockWeatherService) GetForecastDiscussion(point types.ForecastPoint) (*weather.ForecastDiscussion, error) {
+	if m.fail {
+		return nil, errors.New("provider unavailable")
+	}
+	return &weather.ForecastDiscussion{}, nil
+}
+
+// mockLocationService returns a fixed forecast point, or err when set
+type mockLocationService struct {
+	err error
+}
+
+func (m *mockLocationService) GetForecastPoint(latitude, longitude float64) (*types.ForecastPoint, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &types.ForecastPoint{
+		Coordinates: types.NewCoords(latitude, longitude),
+	}, nil
+}
+
+func (m *mockLocationService) BuildForecastPoint(latitude, longitude float64, override location.ElevationOverride) (*types.ForecastPoint, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &types.ForecastPoint{
+		Coordinates:     types.NewCoords(latitude, longitude),
+		Elevation:       types.NewElevationFromMeters(override.ElevationMeters),
+		ElevationSource: location.ElevationSourceUserSupplied,
+	}, nil
+}
+
+// newTestApp builds an App with mock services and a controllable clock
+func newTestApp(avalancheSvc *mockAvalancheService, weatherSvc *mockWeatherService, clock func() time.Time) *App {
+	gin.SetMode(gin.TestMode)
+
+	app := &App{
+		router:           gin.New(),
+		logger:           slog.Default(),
+		locationService:  &mockLocationService{},
+		weatherService:   weatherSvc,
+		avalancheService: avalancheSvc,
+		weatherCache:     cache.New[*weather.Forecast](weatherCacheTTL),
+		avalancheCache:   cache.New[*avalanche.AvalancheForecast](avalancheCacheTTL),
+		idempotency:      newMemoryIdempotencyStore(),
+		now:              clock,
+	}
+	app.weatherCache.SetClock(clock)
+	app.avalancheCache.SetClock(clock)
+	app.registerRoutes()
+	return app
+}
+
+func doRequest(t *testing.T, app *App, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s returned status %d: %s", path, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestAvalancheForecastFreshnessTransitions(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	avalancheSvc := &mockAvalancheService{
+		forecast: &avalanche.AvalancheForecast{
+			PublishedTime: currentTime.Add(-2 * time.Hour),
+			ExpiresTime:   currentTime.Add(24 * time.Hour),
+		},
+	}
+	app := newTestApp(avalancheSvc, &mockWeatherService{forecast: &weather.Forecast{}}, clock)
+
+	path := "/avalanche/forecast?latitude=39.11539&longitude=-107.65840"
+
+	// First request misses and fetches
+	w := doRequest(t, app, path)
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("first request X-Cache = %q, want MISS", got)
+	}
+	if w.Header().Get("X-Data-Fetched-At") == "" {
+		t.Error("X-Data-Fetched-At header missing")
+	}
+	if w.Header().Get("X-Data-Source-Age") == "" {
+		t.Error("X-Data-Source-Age header missing")
+	}
+
+	// Second request hits the cache
+	w = doRequest(t, app, path)
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("second request X-Cache = %q, want HIT", got)
+	}
+
+	var resp AvalancheForecastResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Freshness.Cache != "HIT" {
+		t.Errorf("body freshness cache = %q, want HIT", resp.Freshness.Cache)
+	}
+	if resp.Freshness.Stale {
+		t.Error("fresh response flagged stale")
+	}
+
+	// Advance past the TTL with the provider down: stale entry is served
+	currentTime = currentTime.Add(avalancheCacheTTL + time.Minute)
+	avalancheSvc.fail = true
+
+	w = doRequest(t, app, path)
+	if got := w.Header().Get("X-Cache"); got != "STALE" {
+		t.Errorf("third request X-Cache = %q, want STALE", got)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Freshness.Stale {
+		t.Error("stale response not flagged stale")
+	}
+}
+
+func TestAvalancheForecastExpiredFlaggedStale(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	// ExpiresTime already passed: even a fresh fetch is flagged stale
+	avalancheSvc := &mockAvalancheService{
+		forecast: &avalanche.AvalancheForecast{
+			PublishedTime: currentTime.Add(-36 * time.Hour),
+			ExpiresTime:   currentTime.Add(-12 * time.Hour),
+		},
+	}
+	app := newTestApp(avalancheSvc, &mockWeatherService{forecast: &weather.Forecast{}}, clock)
+
+	w := doRequest(t, app, "/avalanche/forecast?latitude=39.11539&longitude=-107.65840")
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("X-Cache = %q, want MISS", got)
+	}
+
+	var resp AvalancheForecastResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Freshness.Stale {
+		t.Error("expired forecast not flagged stale")
+	}
+}
+
+func TestWeatherForecastFreshnessTransitions(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, clock)
+
+	path := "/weather/forecast?latitude=39.11539&longitude=-107.65840"
+
+	w := doRequest(t, app, path)
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("first request X-Cache = %q, want MISS", got)
+	}
+
+	w = doRequest(t, app, path)
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("second request X-Cache = %q, want HIT", got)
+	}
+
+	// Advance past the TTL with the provider down: stale entry is served
+	currentTime = currentTime.Add(weatherCacheTTL + time.Minute)
+	weatherSvc.fail = true
+
+	w = doRequest(t, app, path)
+	if got := w.Header().Get("X-Cache"); got != "STALE" {
+		t.Errorf("third request X-Cache = %q, want STALE", got)
+	}
+
+	var resp WeatherForecastResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Freshness.Stale {
+		t.Error("stale response not flagged stale")
+	}
+	if resp.Forecast.Timezone != "America/Denver" {
+		t.Errorf("stale response forecast timezone = %q, want America/Denver", resp.Forecast.Timezone)
+	}
+}
@@ -2,24 +2,73 @@ package main
 
 import (
 	"net/http"
+	"sort"
+
+	"medi/internal/providers/health"
 
 	"github.com/gin-gonic/gin"
 )
 
-// PingResponse represents the response for the ping endpoint
-type PingResponse struct {
-	Message string `json:"message" example:"pong"` // Response message
+// ProviderHealth reports one upstream's call history over the recent window:
+// the last 100 calls, aged out after 30 minutes.
+type ProviderHealth struct {
+	Provider string `json:"provider"`
+	// Calls is how many calls the window holds
+	Calls int `json:"calls"`
+	// SuccessRate is the fraction of windowed calls that succeeded, 0 to 1
+	SuccessRate float64 `json:"successRate"`
+	// P95LatencyMs is the 95th-percentile round-trip latency in milliseconds
+	P95LatencyMs int64 `json:"p95LatencyMs"`
+	// ConsecutiveFailures counts the unbroken failure run ending at the most
+	// recent call; a provider that just recovered reads zero
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+}
+
+// HealthResponse reports overall service status with the recent per-provider
+// call history
+type HealthResponse struct {
+	Status string `json:"status"`
+	// Providers lists every upstream with at least one windowed call, ordered
+	// by name; empty until the first provider call and in mock mode
+	Providers []ProviderHealth `json:"providers"`
 }
 
-// handlePing godoc
-// @Summary Ping health check
-// @Description Check if the API is running
+// healthConsecutiveFailureLimit is the unbroken failure run at which a
+// provider flips the overall status to degraded
+const healthConsecutiveFailureLimit = 3
+
+// handleGetHealth godoc
+// @Summary Service health
+// @Description Report per-provider success rate, p95 latency, and consecutive failures over the recent call window
 // @Tags health
 // @Produce json
-// @Success 200 {object} PingResponse
-// @Router /ping [get]
-func (app *App) handlePing(c *gin.Context) {
-	c.JSON(http.StatusOK, PingResponse{
-		Message: "pong",
+// @Success 200 {object} HealthResponse
+// @Router /health [get]
+func (app *App) handleGetHealth(c *gin.Context) {
+	response := HealthResponse{
+		Status:    "ok",
+		Providers: []ProviderHealth{},
+	}
+
+	var snapshot map[string]health.Stats
+	if app.providerHealth != nil {
+		snapshot = app.providerHealth.Snapshot()
+	}
+	for provider, stats := range snapshot {
+		response.Providers = append(response.Providers, ProviderHealth{
+			Provider:            provider,
+			Calls:               stats.Calls,
+			SuccessRate:         stats.SuccessRate,
+			P95LatencyMs:        stats.P95LatencyMs,
+			ConsecutiveFailures: stats.ConsecutiveFailures,
+		})
+		if stats.ConsecutiveFailures >= healthConsecutiveFailureLimit {
+			response.Status = "degraded"
+		}
+	}
+	sort.Slice(response.Providers, func(i, j int) bool {
+		return response.Providers[i].Provider < response.Providers[j].Provider
 	})
+
+	c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medi/internal/providers/health"
+)
+
+func TestHealthEndpointWithoutRecorder(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	w := doRequest(t, app, "/health")
+	var resp HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "ok" {
+		t.Errorf("status = %q, want ok", resp.Status)
+	}
+	if resp.Providers == nil || len(resp.Providers) != 0 {
+		t.Errorf("providers = %v, want an empty list", resp.Providers)
+	}
+}
+
+func TestHealthEndpointReportsProviderWindow(t *testing.T) {
+	codes := []int{200, 200, 500, 500, 500}
+	served := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(codes[served])
+		served++
+	}))
+	defer server.Close()
+
+	recorder := health.NewRecorder()
+	client := recorder.Client("openmeteo")
+	for range codes {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("instrumented request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.providerHealth = recorder
+
+	w := doRequest(t, app, "/health")
+	var resp HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Three straight failures push the overall status to degraded
+	if resp.Status != "degraded" {
+		t.Errorf("status = %q, want degraded", resp.Status)
+	}
+	if len(resp.Providers) != 1 {
+		t.Fatalf("got %d providers, want 1", len(resp.Providers))
+	}
+	provider := resp.Providers[0]
+	if provider.Provider != "openmeteo" {
+		t.Errorf("provider = %q, want openmeteo", provider.Provider)
+	}
+	if provider.Calls != 5 {
+		t.Errorf("calls = %d, want 5", provider.Calls)
+	}
+	if provider.SuccessRate != 0.4 {
+		t.Errorf("success rate = %v, want 0.4", provider.SuccessRate)
+	}
+	if provider.ConsecutiveFailures != 3 {
+		t.Errorf("consecutive failures = %d, want 3", provider.ConsecutiveFailures)
+	}
+}
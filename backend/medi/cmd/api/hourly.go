@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"medi/internal/cache"
+	"medi/internal/location"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetHourlySeriesInput defines the query parameters for the hourly series endpoint
+type GetHourlySeriesInput struct {
+	CoordinateInput
+	// Resolution is a duration like 15m that evenly divides one hour;
+	// defaults to 1h (the raw hourly points)
+	Resolution string `form:"resolution"`
+}
+
+// HourlySeriesResponse carries the charting series at the requested resolution
+type HourlySeriesResponse struct {
+	Timezone   string                 `json:"timezone"`
+	Resolution string                 `json:"resolution"`
+	Points     []InterpolatedPointDTO `json:"points"`
+}
+
+type InterpolatedPointDTO struct {
+	weather.InterpolatedPoint
+	Time LocalTimestamp
+}
+
+// handleGetHourlySeries godoc
+// @Summary Get sub-hourly forecast series
+// @Description Retrieve hourly forecast values interpolated to the requested resolution for charting
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param resolution query string false "Sample step that evenly divides one hour" example(15m)
+// @Success 200 {object} HourlySeriesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /weather/forecast/hourly [get]
+func (app *App) handleGetHourlySeries(c *gin.Context) {
+	var input GetHourlySeriesInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	step := time.Hour
+	if input.Resolution != "" {
+		parsed, err := time.ParseDuration(input.Resolution)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid resolution: " + err.Error()})
+			return
+		}
+		step = parsed
+	}
+
+	forecast, ok := app.cachedForecast(c, input.Latitude, input.Longitude)
+	if !ok {
+		return
+	}
+
+	points, err := weather.Interpolate(forecast, step)
+	if err != nil {
+		if errors.Is(err, weather.ErrInvalidStep) || errors.Is(err, weather.ErrTooManyPoints) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		app.logger.Error("failed to interpolate forecast", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build hourly series"})
+		return
+	}
+
+	locationZone, err := time.LoadLocation(forecast.Timezone)
+	if err != nil {
+		app.logger.Error("failed to load forecast timezone", "timezone", forecast.Timezone, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build hourly series"})
+		return
+	}
+
+	response := HourlySeriesResponse{
+		Timezone:   forecast.Timezone,
+		Resolution: step.String(),
+		Points:     make([]InterpolatedPointDTO, 0, len(points)),
+	}
+	for _, point := range points {
+		response.Points = append(response.Points, InterpolatedPointDTO{
+			InterpolatedPoint: point,
+			Time:              newLocalTimestamp(point.Time, locationZone),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// cachedForecast returns the cached forecast for the coordinates, refreshing
+// it on a miss. On failure it writes the error response and returns false.
+func (app *App) cachedForecast(c *gin.Context, latitude, longitude float64) (*weather.Forecast, bool) {
+	key := forecastCacheKey(latitude, longitude)
+	entry, status := app.weatherCache.Get(key)
+	if status == cache.StatusHit {
+		return entry.Value, true
+	}
+
+	forecast, err := app.fetchWeatherForecast(c.Request.Context(), latitude, longitude, nil, false, "")
+	if err != nil {
+		// Fall back to a stale entry when the refresh fails
+		if status == cache.StatusStale {
+			app.logger.Warn("serving stale weather forecast after refresh failure",
+				"latitude", latitude,
+				"longitude", longitude,
+				"error", err,
+			)
+			return entry.Value, true
+		}
+
+		if errors.Is(err, location.ErrInvalidLatitude) || errors.Is(err, location.ErrInvalidLongitude) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+
+		app.logger.Error("failed to get weather forecast",
+			"latitude", latitude,
+			"longitude", longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+		return nil, false
+	}
+
+	app.weatherCache.Set(key, forecast)
+	app.publishWeatherRefresh(latitude, longitude, forecast)
+	return forecast, true
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Replay protection for the mutation endpoints. Mobile clients retry POSTs on
+// flaky connections; an Idempotency-Key header lets a retry replay the first
+// attempt's recorded response instead of mutating twice. A key is remembered
+// with a hash of its request body for the configured window: a duplicate with
+// the same body replays the original response, the same key with a different
+// body is rejected with a 409, and a duplicate arriving while the first
+// request is still running waits for it to finish.
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayHeader marks a response served from a stored record
+	idempotentReplayHeader = "Idempotent-Replay"
+	// defaultIdempotencyWindow applies when no window is configured
+	defaultIdempotencyWindow = 24 * time.Hour
+	// idempotencyCleanupInterval gates how often a claim sweeps expired
+	// records out of the store
+	idempotencyCleanupInterval = 5 * time.Minute
+	// maxIdempotencyKeyBytes bounds the accepted key length; anything longer
+	// is a client bug, not a retry token
+	maxIdempotencyKeyBytes = 128
+)
+
+// idempotencyRecord is the stored outcome of the first request under a key
+type idempotencyRecord struct {
+	bodyHash    [sha256.Size]byte
+	status      int
+	contentType string
+	body        []byte
+	expiresAt   time.Time
+	// completed flips once the first request finished and the fields above
+	// are final; done is closed at the same moment so waiters wake up
+	completed bool
+	done      chan struct{}
+}
+
+// idempotencyStore persists request outcomes for the replay window. The
+// in-memory implementation serves a single replica; a shared backend can
+// replace it behind the same interface when the API scales out.
+type idempotencyStore interface {
+	// Claim returns the record for key, creating it when absent or expired;
+	// claimed reports whether this caller created it and must Complete it
+	Claim(key string, window time.Duration) (record *idempotencyRecord, claimed bool)
+	// Complete finalizes a claimed record with the response to replay
+	Complete(key string, bodyHash [sha256.Size]byte, status int, contentType string, body []byte)
+}
+
+// memoryIdempotencyStore keeps the records in a map, swept of expired entries
+// at most once per cleanup interval
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	records   map[string]*idempotencyRecord
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{
+		records: make(map[string]*idempotencyRecord),
+		now:     time.Now,
+	}
+}
+
+// SetClock overrides the time source, for tests
+func (s *memoryIdempotencyStore) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+func (s *memoryIdempotencyStore) Claim(key string, window time.Duration) (*idempotencyRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.now()
+	if current.Sub(s.lastSweep) >= idempotencyCleanupInterval {
+		s.lastSweep = current
+		for k, record := range s.records {
+			if record.completed && record.expiresAt.Before(current) {
+				delete(s.records, k)
+			}
+		}
+	}
+
+	if record, ok := s.records[key]; ok {
+		if !record.completed || !record.expiresAt.Before(current) {
+			return record, false
+		}
+		// Expired: the key is free to claim again
+		delete(s.records, key)
+	}
+
+	record := &idempotencyRecord{
+		expiresAt: current.Add(window),
+		done:      make(chan struct{}),
+	}
+	s.records[key] = record
+	return record, true
+}
+
+func (s *memoryIdempotencyStore) Complete(key string, bodyHash [sha256.Size]byte, status int, contentType string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || record.completed {
+		return
+	}
+	record.bodyHash = bodyHash
+	record.status = status
+	record.contentType = contentType
+	record.body = body
+	record.completed = true
+	close(record.done)
+}
+
+// idempotencyWindow reads the configured replay window
+func (app *App) idempotencyWindow() time.Duration {
+	cfg := app.config()
+	if cfg == nil || cfg.Server.IdempotencyWindowHours <= 0 {
+		return defaultIdempotencyWindow
+	}
+	return time.Duration(cfg.Server.IdempotencyWindowHours) * time.Hour
+}
+
+// captureWriter tees the response body so it can be stored for replay
+type captureWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.buf.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencyGuard is the middleware applied to the POST endpoints. Requests
+// without an Idempotency-Key header pass through untouched. The stored
+// response is replayed whatever its status; a client that wants a genuinely
+// fresh attempt sends a new key.
+func (app *App) idempotencyGuard(c *gin.Context) {
+	key := c.GetHeader(idempotencyKeyHeader)
+	if key == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyBytes {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "idempotency key too long"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	bodyHash := sha256.Sum256(body)
+
+	record, claimed := app.idempotency.Claim(key, app.idempotencyWindow())
+	if !claimed {
+		// A previous or still-running request holds this key
+		select {
+		case <-record.done:
+		case <-c.Request.Context().Done():
+			c.Abort()
+			return
+		}
+		if record.bodyHash != bodyHash {
+			c.AbortWithStatusJSON(http.StatusConflict,
+				gin.H{"error": "idempotency key was already used with a different request body"})
+			return
+		}
+		c.Header(idempotentReplayHeader, "true")
+		c.Data(record.status, record.contentType, record.body)
+		c.Abort()
+		return
+	}
+
+	writer := &captureWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	// Complete in a defer so a panicking handler still releases waiters; the
+	// panic is re-raised for the recovery middleware after recording the 500
+	defer func() {
+		if r := recover(); r != nil {
+			app.idempotency.Complete(key, bodyHash, http.StatusInternalServerError, "", nil)
+			panic(r)
+		}
+		app.idempotency.Complete(key, bodyHash, writer.Status(),
+			writer.Header().Get("Content-Type"), writer.buf.Bytes())
+	}()
+	c.Next()
+}
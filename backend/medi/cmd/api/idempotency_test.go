@@ -0,0 +1,163 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"medi/internal/weather"
+)
+
+// countingWeatherService counts bias correction calls, optionally blocking
+// each one until release is closed
+type countingWeatherService struct {
+	mockWeatherService
+	biasCalls atomic.Int32
+	release   chan struct{}
+}
+
+func (s *countingWeatherService) SetBiasCorrection(latitude, longitude float64, model string, correction weather.BiasCorrection) error {
+	s.biasCalls.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	return correction.Validate()
+}
+
+const biasBody = `{"latitude":39.11539,"longitude":-107.65840,"model":"gfs_seamless","snowfallFactor":1.2}`
+
+func postBias(t *testing.T, app *App, key, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/bias", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysSameBody(t *testing.T) {
+	weatherSvc := &countingWeatherService{}
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.weatherService = weatherSvc
+
+	first := postBias(t, app, "retry-1", biasBody)
+	if first.Code != http.StatusOK {
+		t.Fatalf("first request status = %d: %s", first.Code, first.Body.String())
+	}
+	if first.Header().Get(idempotentReplayHeader) != "" {
+		t.Error("first request flagged as a replay")
+	}
+
+	second := postBias(t, app, "retry-1", biasBody)
+	if second.Code != http.StatusOK {
+		t.Fatalf("replayed request status = %d: %s", second.Code, second.Body.String())
+	}
+	if second.Header().Get(idempotentReplayHeader) != "true" {
+		t.Error("replayed request missing the replay header")
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("replayed body %q differs from original %q", second.Body.String(), first.Body.String())
+	}
+	if calls := weatherSvc.biasCalls.Load(); calls != 1 {
+		t.Errorf("service called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRejectsReusedKeyWithDifferentBody(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	if w := postBias(t, app, "retry-2", biasBody); w.Code != http.StatusOK {
+		t.Fatalf("first request status = %d: %s", w.Code, w.Body.String())
+	}
+
+	other := strings.Replace(biasBody, "1.2", "1.5", 1)
+	w := postBias(t, app, "retry-2", other)
+	if w.Code != http.StatusConflict {
+		t.Errorf("reused key with different body status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestIdempotencyKeyExpires(t *testing.T) {
+	weatherSvc := &countingWeatherService{}
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.weatherService = weatherSvc
+
+	store := newMemoryIdempotencyStore()
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	store.SetClock(func() time.Time { return currentTime })
+	app.idempotency = store
+
+	if w := postBias(t, app, "retry-3", biasBody); w.Code != http.StatusOK {
+		t.Fatalf("first request status = %d: %s", w.Code, w.Body.String())
+	}
+
+	// Past the window the same key executes fresh instead of replaying
+	currentTime = currentTime.Add(defaultIdempotencyWindow + time.Minute)
+	w := postBias(t, app, "retry-3", biasBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("post-expiry request status = %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get(idempotentReplayHeader) != "" {
+		t.Error("post-expiry request served as a replay")
+	}
+	if calls := weatherSvc.biasCalls.Load(); calls != 2 {
+		t.Errorf("service called %d times, want 2", calls)
+	}
+}
+
+func TestIdempotencyConcurrentDuplicatesExecuteOnce(t *testing.T) {
+	weatherSvc := &countingWeatherService{release: make(chan struct{})}
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.weatherService = weatherSvc
+
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 2)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = postBias(t, app, "retry-4", biasBody)
+		}(i)
+	}
+
+	// Let both requests arrive before the first finishes its handler
+	time.Sleep(50 * time.Millisecond)
+	close(weatherSvc.release)
+	wg.Wait()
+
+	for i, w := range responses {
+		if w.Code != http.StatusOK {
+			t.Errorf("request %d status = %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+	if responses[0].Body.String() != responses[1].Body.String() {
+		t.Errorf("concurrent duplicates returned different bodies: %q vs %q",
+			responses[0].Body.String(), responses[1].Body.String())
+	}
+	if calls := weatherSvc.biasCalls.Load(); calls != 1 {
+		t.Errorf("service called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRequiresNoHeader(t *testing.T) {
+	weatherSvc := &countingWeatherService{}
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.weatherService = weatherSvc
+
+	for i := 0; i < 2; i++ {
+		if w := postBias(t, app, "", biasBody); w.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+	if calls := weatherSvc.biasCalls.Load(); calls != 2 {
+		t.Errorf("service called %d times, want both to execute", calls)
+	}
+}
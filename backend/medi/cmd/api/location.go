@@ -3,8 +3,11 @@ package main
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"medi/internal/location"
+	"medi/internal/providers/quota"
+	"medi/internal/providers/retry"
 	_ "medi/internal/types" // imported for swagger type definitions
 
 	"github.com/gin-gonic/gin"
@@ -12,8 +15,7 @@ import (
 
 // GetForecastPointInput defines the query parameters for the forecast point endpoint
 type GetForecastPointInput struct {
-	Latitude  float64 `form:"latitude" binding:"required"`  // Latitude in decimal degrees
-	Longitude float64 `form:"longitude" binding:"required"` // Longitude in decimal degrees
+	CoordinateInput
 }
 
 // handleGetForecastPoint godoc
@@ -37,6 +39,11 @@ func (app *App) handleGetForecastPoint(c *gin.Context) {
 		return
 	}
 
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Delegate to business layer
 	forecastPoint, err := app.locationService.GetForecastPoint(input.Latitude, input.Longitude)
 	if err != nil {
@@ -46,6 +53,21 @@ func (app *App) handleGetForecastPoint(c *gin.Context) {
 			return
 		}
 
+		// A rate-limited upstream becomes our own 503, passing the advised
+		// delay on to the client
+		var providerErr *retry.ProviderError
+		if errors.As(err, &providerErr) && providerErr.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(providerErr.RetryAfter.Seconds())))
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "upstream rate limited, retry later"})
+			return
+		}
+
+		// A spent daily quota is also a 503; the budget resets at UTC midnight
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "provider daily quota exceeded, retry later"})
+			return
+		}
+
 		// Other errors are internal server errors
 		app.logger.Error("failed to get forecast point",
 			"latitude", input.Latitude,
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/providers/retry"
+	"medi/internal/weather"
+)
+
+func TestForecastPointPropagatesRetryAfter(t *testing.T) {
+	app := newTestApp(
+		&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}},
+		&mockWeatherService{forecast: &weather.Forecast{}},
+		time.Now,
+	)
+	app.locationService = &mockLocationService{
+		err: fmt.Errorf("failed to look up location: %w", &retry.ProviderError{
+			StatusCode: http.StatusTooManyRequests,
+			RetryAfter: 7 * time.Second,
+		}),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/location/forecast-point?latitude=39.11539&longitude=-107.65840", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "7" {
+		t.Errorf("Retry-After header = %q, want 7", got)
+	}
+}
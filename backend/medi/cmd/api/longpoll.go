@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"medi/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLongPollTimeout bounds the wait endpoint when no timeout is
+// configured, just under typical 60 s proxy idle limits
+const defaultLongPollTimeout = 55 * time.Second
+
+// WaitForecastUpdateInput defines the query parameters for the long-poll endpoint
+type WaitForecastUpdateInput struct {
+	CoordinateInput
+	// Since is the ETag the client already holds; the request returns as soon
+	// as the cached forecast carries a different one
+	Since string `form:"since"`
+}
+
+// handleWaitForecastUpdate godoc
+// @Summary Wait for a forecast update
+// @Description Long-poll for the location's forecast: held open until the cached forecast is refreshed with an ETag different from "since", or answered 304 Not Modified on timeout
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param since query string false "ETag from a previous forecast response"
+// @Success 200 {object} WeatherForecastResponse
+// @Success 304 {string} string "no update within the wait window"
+// @Failure 400 {object} map[string]string
+// @Router /weather/forecast/wait [get]
+func (app *App) handleWaitForecastUpdate(c *gin.Context) {
+	var input WaitForecastUpdateInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key := forecastCacheKey(input.Latitude, input.Longitude)
+	deadline := time.NewTimer(app.waitTimeout())
+	defer deadline.Stop()
+
+	for {
+		if app.serveForecastIfChanged(c, key, input.Since) {
+			return
+		}
+
+		refreshed := app.weatherCache.Subscribe(key)
+		// The entry may have refreshed between the check above and the
+		// subscription; re-check so that refresh is not missed
+		if app.serveForecastIfChanged(c, key, input.Since) {
+			app.weatherCache.Unsubscribe(key, refreshed)
+			return
+		}
+
+		select {
+		case <-refreshed:
+			// Loop around and serve the refreshed entry
+		case <-c.Request.Context().Done():
+			app.weatherCache.Unsubscribe(key, refreshed)
+			return
+		case <-deadline.C:
+			app.weatherCache.Unsubscribe(key, refreshed)
+			if entry, status := app.weatherCache.Get(key); status != cache.StatusMiss {
+				c.Header("ETag", forecastETag(entry.FetchedAt))
+			}
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+}
+
+// serveForecastIfChanged writes the cached forecast when its ETag differs
+// from the one the client holds, reporting whether a response was written
+func (app *App) serveForecastIfChanged(c *gin.Context, key, since string) bool {
+	entry, status := app.weatherCache.Get(key)
+	if status == cache.StatusMiss || forecastETag(entry.FetchedAt) == since {
+		return false
+	}
+
+	freshness := newFreshness(entry.FetchedAt, status, status == cache.StatusStale, 0)
+	app.writeForecastResponse(c, freshness, entry.Value, false, nil)
+	return true
+}
+
+// waitTimeout resolves the long-poll window from config, injectable for tests
+// through the App field
+func (app *App) waitTimeout() time.Duration {
+	if app.longPollTimeout > 0 {
+		return app.longPollTimeout
+	}
+	if cfg := app.config(); cfg != nil && cfg.App.LongPollTimeoutSeconds > 0 {
+		return time.Duration(cfg.App.LongPollTimeoutSeconds) * time.Second
+	}
+	return defaultLongPollTimeout
+}
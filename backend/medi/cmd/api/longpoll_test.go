@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/weather"
+)
+
+const waitPath = "/weather/forecast/wait?latitude=39.11539&longitude=-107.65840"
+
+// newLongPollTestApp builds a test app with a short wait window and a
+// forecast already cached at the clock's current time
+func newLongPollTestApp(clock func() time.Time) (*App, string) {
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, clock)
+	app.longPollTimeout = 100 * time.Millisecond
+
+	key := forecastCacheKey(39.11539, -107.65840)
+	app.weatherCache.Set(key, weatherSvc.forecast)
+	return app, key
+}
+
+func TestWaitReturnsImmediatelyWhenETagDiffers(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	app, _ := newLongPollTestApp(func() time.Time { return currentTime })
+
+	// A client without an ETag (or with an outdated one) is served at once
+	w := doRequest(t, app, waitPath)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("ETag"); got != forecastETag(currentTime) {
+		t.Errorf("ETag = %q, want %q", got, forecastETag(currentTime))
+	}
+	if n := app.weatherCache.SubscriberCount(); n != 0 {
+		t.Errorf("subscribers after return = %d, want 0", n)
+	}
+}
+
+func TestWaitReturnsWhenForecastRefreshesMidWait(t *testing.T) {
+	t0 := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	t1 := t0.Add(weatherCacheTTL / 2)
+
+	currentTime := t0
+	app, key := newLongPollTestApp(func() time.Time { return currentTime })
+
+	// Advance the clock before the refresh goroutine starts so the handler
+	// and the refresher only read it concurrently
+	currentTime = t1
+	refreshed := &weather.Forecast{Timezone: "America/Denver"}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		app.weatherCache.Set(key, refreshed)
+	}()
+
+	w := doRequest(t, app, waitPath+"&since="+forecastETag(t0))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("ETag"); got != forecastETag(t1) {
+		t.Errorf("ETag = %q, want the refreshed entry's %q", got, forecastETag(t1))
+	}
+	if n := app.weatherCache.SubscriberCount(); n != 0 {
+		t.Errorf("subscribers after return = %d, want 0", n)
+	}
+}
+
+func TestWaitTimesOutWithNotModified(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	app, _ := newLongPollTestApp(func() time.Time { return currentTime })
+
+	req := httptest.NewRequest(http.MethodGet, waitPath+"&since="+forecastETag(currentTime), nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotModified)
+	}
+	// The 304 carries the current tag so the client can resubscribe
+	if got := w.Header().Get("ETag"); got != forecastETag(currentTime) {
+		t.Errorf("ETag = %q, want %q", got, forecastETag(currentTime))
+	}
+	if n := app.weatherCache.SubscriberCount(); n != 0 {
+		t.Errorf("subscribers after timeout = %d, want 0", n)
+	}
+}
+
+func TestWaitReleasesWaiterOnDisconnect(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	app, _ := newLongPollTestApp(func() time.Time { return currentTime })
+	app.longPollTimeout = 10 * time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, waitPath+"&since="+forecastETag(currentTime), nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		app.router.ServeHTTP(w, req)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not return after the client disconnected")
+	}
+	if n := app.weatherCache.SubscriberCount(); n != 0 {
+		t.Errorf("subscribers after disconnect = %d, want 0", n)
+	}
+}
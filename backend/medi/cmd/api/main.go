@@ -3,14 +3,27 @@ package main
 //go:generate go run github.com/swaggo/swag/cmd/swag@latest init -g docs.go -o ../../docs --parseDependency
 
 import (
+	"flag"
 	"log"
 	"log/slog"
+
 	"medi/internal/config"
 
 	_ "medi/docs" // Import generated docs
 )
 
 func main() {
+	// Generator mode for go generate: write the response JSON Schema
+	// documents and exit without starting the server
+	writeSchemas := flag.String("write-schemas", "", "write response JSON Schema documents to this directory and exit")
+	flag.Parse()
+	if *writeSchemas != "" {
+		if err := writeResponseSchemas(*writeSchemas); err != nil {
+			log.Fatalf("Failed to write schemas: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -21,12 +34,16 @@ func main() {
 	logger := cfg.NewLogger()
 	slog.SetDefault(logger) // Set as default logger for the application
 
-	// Create app
-	app, err := NewApp(cfg, logger)
+	// Create app; the bootstrap lifecycle handles signals and shutdown
+	holder := config.NewHolder(cfg)
+	app, err := NewApp(holder, logger)
 	if err != nil {
 		log.Fatalf("Failed to create app: %v", err)
 	}
 
+	// Re-read tunables when the config file changes or on SIGHUP
+	holder.Watch(logger)
+
 	// Start server
 	logger.Info("starting server", "addr", cfg.GetServerAddr())
 	if err := app.Run(cfg.GetServerAddr()); err != nil {
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+
+	"medi/internal/weather"
+)
+
+// ModelValues serializes as an object keyed by model name, which OpenAPI can
+// only describe as a free-form map. Two improvements to that contract live
+// here: the served spec constrains the keys to the model enum (see
+// patchAPISpec), and modelFormat=array selects an alternative rendering as a
+// list of {model, value} objects for clients whose generators handle typed
+// arrays better than maps.
+
+// modelValueEntry is one model's value in the array rendering
+type modelValueEntry struct {
+	Model string `json:"model"`
+	Value any    `json:"value"`
+}
+
+var modelNameRank = func() map[string]int {
+	ranks := make(map[string]int, len(weather.ModelNames()))
+	for i, model := range weather.ModelNames() {
+		ranks[model] = i
+	}
+	return ranks
+}()
+
+// isModelValuesObject reports whether a decoded JSON object is a ModelValues
+// rendering: non-empty, with every key a known model name. No other response
+// object is keyed exclusively by model names.
+func isModelValuesObject(obj map[string]any) bool {
+	if len(obj) == 0 {
+		return false
+	}
+	for key := range obj {
+		if _, ok := modelNameRank[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// toModelArrays rewrites every ModelValues object in a decoded JSON document
+// into the array form, entries in canonical model order, and returns the
+// document. Everything else passes through untouched.
+func toModelArrays(node any) any {
+	switch v := node.(type) {
+	case map[string]any:
+		if isModelValuesObject(v) {
+			models := make([]string, 0, len(v))
+			for model := range v {
+				models = append(models, model)
+			}
+			sort.Slice(models, func(a, b int) bool { return modelNameRank[models[a]] < modelNameRank[models[b]] })
+
+			entries := make([]modelValueEntry, 0, len(models))
+			for _, model := range models {
+				entries = append(entries, modelValueEntry{Model: model, Value: toModelArrays(v[model])})
+			}
+			return entries
+		}
+		for key, child := range v {
+			v[key] = toModelArrays(child)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = toModelArrays(v[i])
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// modelArrayDocument serializes the payload, then rewrites its ModelValues
+// objects into the array form. The round-trip through generic JSON costs one
+// extra encode but keeps the DTO types free of format branching.
+func modelArrayDocument(payload any) (any, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return toModelArrays(doc), nil
+}
+
+// patchAPISpec adds the reusable ModelValues definitions to the generated
+// spec: the map form with its keys constrained to the model enum, and the
+// array form clients opt into with modelFormat=array
+func patchAPISpec(doc []byte) ([]byte, error) {
+	var spec map[string]any
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return nil, err
+	}
+	definitions, ok := spec["definitions"].(map[string]any)
+	if !ok {
+		definitions = map[string]any{}
+		spec["definitions"] = definitions
+	}
+
+	models := weather.ModelNames()
+	definitions["weather.ModelValues"] = map[string]any{
+		"type":                 "object",
+		"description":          "Per-model values keyed by model name. Pass modelFormat=array for the list rendering described by weather.ModelValuesArray.",
+		"propertyNames":        map[string]any{"enum": models},
+		"additionalProperties": true,
+	}
+	definitions["weather.ModelValuesArray"] = map[string]any{
+		"type":        "array",
+		"description": "Alternative rendering of weather.ModelValues selected with modelFormat=array, one entry per model in canonical order.",
+		"items": map[string]any{
+			"type":     "object",
+			"required": []string{"model", "value"},
+			"properties": map[string]any{
+				"model": map[string]any{"type": "string", "enum": models},
+				"value": map[string]any{},
+			},
+		},
+	}
+
+	patchDeprecatedFields(definitions)
+
+	return json.Marshal(spec)
+}
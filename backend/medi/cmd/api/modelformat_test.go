@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medi/internal/weather"
+)
+
+func TestModelValuesArrayRoundTrip(t *testing.T) {
+	original := weather.ModelValues[float64]{
+		weather.ModelEcmwIfs:     28.5,
+		weather.ModelGfsSeamless: 31,
+		weather.ModelMetNo:       29,
+	}
+
+	doc, err := modelArrayDocument(map[string]any{"highTemperature": original})
+	if err != nil {
+		t.Fatalf("modelArrayDocument failed: %v", err)
+	}
+
+	entries, ok := doc.(map[string]any)["highTemperature"].([]modelValueEntry)
+	if !ok {
+		t.Fatalf("ModelValues not rewritten to entries: %T", doc.(map[string]any)["highTemperature"])
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	// Canonical order: the primary seamless model first, the fallback last
+	if entries[0].Model != weather.ModelGfsSeamless || entries[2].Model != weather.ModelMetNo {
+		t.Errorf("entries out of canonical order: %v", entries)
+	}
+
+	// The array form carries the same data as the map form
+	recovered := weather.ModelValues[float64]{}
+	for _, entry := range entries {
+		recovered[entry.Model] = entry.Value.(float64)
+	}
+	for model, want := range original {
+		if got := recovered[model]; got != want {
+			t.Errorf("round-trip lost %s: got %v, want %v", model, got, want)
+		}
+	}
+}
+
+func TestModelValuesMapFormRoundTrip(t *testing.T) {
+	original := weather.ModelValues[int]{
+		weather.ModelGfsSeamless: 1,
+		weather.ModelEcmwIfs:     2,
+	}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var recovered weather.ModelValues[int]
+	if err := json.Unmarshal(data, &recovered); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(recovered) != 2 || recovered[weather.ModelGfsSeamless] != 1 || recovered[weather.ModelEcmwIfs] != 2 {
+		t.Errorf("map form round-trip = %v, want %v", recovered, original)
+	}
+}
+
+func TestToModelArraysLeavesOtherObjectsAlone(t *testing.T) {
+	doc := toModelArrays(map[string]any{
+		"freshness": map[string]any{"disposition": "hit"},
+		"empty":     map[string]any{},
+	})
+	m := doc.(map[string]any)
+	if _, ok := m["freshness"].(map[string]any); !ok {
+		t.Errorf("non-model object rewritten: %T", m["freshness"])
+	}
+	if _, ok := m["empty"].(map[string]any); !ok {
+		t.Errorf("empty object rewritten: %T", m["empty"])
+	}
+}
+
+func TestForecastEndpointModelFormatArray(t *testing.T) {
+	denver, _ := time.LoadLocation("America/Denver")
+	forecast := &weather.Forecast{
+		Timezone:  "America/Denver",
+		Timestamp: time.Date(2025, 1, 15, 8, 0, 0, 0, denver),
+		ModelHorizons: weather.ModelValues[time.Time]{
+			weather.ModelGfsSeamless: time.Date(2025, 1, 31, 0, 0, 0, 0, denver),
+		},
+	}
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: forecast}, time.Now)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&modelFormat=array")
+
+	var response struct {
+		Forecast struct {
+			ModelHorizons []struct {
+				Model string `json:"model"`
+			} `json:"ModelHorizons"`
+		} `json:"forecast"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("array-form response is not valid JSON: %v\n%s", err, w.Body.String())
+	}
+	if len(response.Forecast.ModelHorizons) != 1 || response.Forecast.ModelHorizons[0].Model != weather.ModelGfsSeamless {
+		t.Errorf("ModelHorizons not in array form: %s", w.Body.String())
+	}
+}
+
+func TestForecastEndpointRejectsUnknownModelFormat(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: &weather.Forecast{}}, time.Now)
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/forecast?latitude=39.11539&longitude=-107.65840&modelFormat=xml", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown modelFormat returned status %d, want 400", w.Code)
+	}
+}
+
+func TestAPISpecEnumeratesModelNames(t *testing.T) {
+	app := newDocsTestApp(true)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json returned status %d", w.Code)
+	}
+
+	var spec struct {
+		Definitions map[string]struct {
+			PropertyNames *struct {
+				Enum []string `json:"enum"`
+			} `json:"propertyNames"`
+			Items *struct {
+				Properties map[string]struct {
+					Enum []string `json:"enum"`
+				} `json:"properties"`
+			} `json:"items"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+
+	mapForm, ok := spec.Definitions["weather.ModelValues"]
+	if !ok || mapForm.PropertyNames == nil {
+		t.Fatal("spec is missing the weather.ModelValues definition with propertyNames")
+	}
+	if len(mapForm.PropertyNames.Enum) != len(weather.ModelNames()) {
+		t.Errorf("propertyNames enum has %d entries, want %d",
+			len(mapForm.PropertyNames.Enum), len(weather.ModelNames()))
+	}
+
+	arrayForm, ok := spec.Definitions["weather.ModelValuesArray"]
+	if !ok || arrayForm.Items == nil {
+		t.Fatal("spec is missing the weather.ModelValuesArray definition")
+	}
+	if len(arrayForm.Items.Properties["model"].Enum) == 0 {
+		t.Error("array form model property is missing the enum")
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeatherModelInfo is one model's registry entry with its display metadata,
+// served by /weather/models and echoed in the forecast's models block so
+// charting clients color and label every model consistently
+type WeatherModelInfo struct {
+	Model      string `json:"model"`
+	Name       string `json:"name"`
+	ShortLabel string `json:"shortLabel"`
+	Color      string `json:"color"`
+	SortOrder  int    `json:"sortOrder"`
+	// DefaultVisible marks the models a chart shows before the client
+	// expands "more models"
+	DefaultVisible bool `json:"defaultVisible"`
+	HorizonHours   int  `json:"horizonHours"`
+}
+
+// ModelsResponse lists the weather model registry in display sort order
+type ModelsResponse struct {
+	Models []WeatherModelInfo `json:"models"`
+}
+
+// weatherModelInfos builds the DTO list for the given model names, in display
+// sort order; names without a registry entry are skipped
+func weatherModelInfos(models []string) []WeatherModelInfo {
+	infos := make([]WeatherModelInfo, 0, len(models))
+	for _, model := range models {
+		info, ok := weather.ModelInfoFor(model)
+		if !ok {
+			continue
+		}
+		infos = append(infos, WeatherModelInfo{
+			Model:          model,
+			Name:           info.Name,
+			ShortLabel:     info.ShortLabel,
+			Color:          info.Color,
+			SortOrder:      info.SortOrder,
+			DefaultVisible: info.DefaultVisible,
+			HorizonHours:   info.HorizonHours,
+		})
+	}
+	sort.Slice(infos, func(a, b int) bool { return infos[a].SortOrder < infos[b].SortOrder })
+	return infos
+}
+
+// handleGetWeatherModels godoc
+// @Summary List weather models
+// @Description List every weather model with its display metadata: short label, chart color, sort order, and default visibility
+// @Tags weather
+// @Produce json
+// @Success 200 {object} ModelsResponse
+// @Router /weather/models [get]
+func (app *App) handleGetWeatherModels(c *gin.Context) {
+	c.JSON(http.StatusOK, ModelsResponse{Models: weatherModelInfos(weather.ModelNames())})
+}
+
+// modelVisibility resolves the forecast endpoint's model filter into the set
+// of model names to serve. An explicit models param wins over everything,
+// allModels disables filtering (nil set), and otherwise only the
+// default-visible models are served.
+func modelVisibility(modelsParam string, allModels bool) (map[string]bool, error) {
+	if modelsParam != "" {
+		visible := map[string]bool{}
+		for _, model := range strings.Split(modelsParam, ",") {
+			model = strings.TrimSpace(model)
+			if _, ok := weather.ModelInfoFor(model); !ok {
+				return nil, fmt.Errorf("unknown model %q, valid models: %s", model, strings.Join(weather.ModelNames(), ", "))
+			}
+			visible[model] = true
+		}
+		return visible, nil
+	}
+	if allModels {
+		return nil, nil
+	}
+	visible := map[string]bool{}
+	for _, model := range weather.DefaultVisibleModels() {
+		visible[model] = true
+	}
+	return visible, nil
+}
+
+// visibleModelDocument serializes the payload, then strips the models outside
+// the visible set from every ModelValues object, model-name list, and models
+// metadata block. Like the array rendering, the round-trip through generic
+// JSON keeps the DTO types free of filter branching — and since it runs after
+// the cache, filtered requests share one cache entry with unfiltered ones.
+func visibleModelDocument(payload any, visible map[string]bool) (any, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return filterVisibleModels(doc, visible), nil
+}
+
+// filterVisibleModels walks a decoded JSON document and removes the models
+// outside the visible set: keys of ModelValues objects, entries of arrays
+// holding only model names (AvailableModels), and entries of arrays of
+// objects carrying a model name under "model" (the models metadata block and
+// the array rendering).
+func filterVisibleModels(node any, visible map[string]bool) any {
+	switch v := node.(type) {
+	case map[string]any:
+		if isModelValuesObject(v) {
+			for model := range v {
+				if !visible[model] {
+					delete(v, model)
+				}
+			}
+			return v
+		}
+		for key, child := range v {
+			v[key] = filterVisibleModels(child, visible)
+		}
+		return v
+	case []any:
+		if isModelNamesArray(v) {
+			kept := make([]any, 0, len(v))
+			for _, entry := range v {
+				if visible[entry.(string)] {
+					kept = append(kept, entry)
+				}
+			}
+			return kept
+		}
+		if isModelEntriesArray(v) {
+			kept := make([]any, 0, len(v))
+			for _, entry := range v {
+				model := entry.(map[string]any)["model"].(string)
+				if visible[model] {
+					kept = append(kept, filterVisibleModels(entry, visible))
+				}
+			}
+			return kept
+		}
+		for i := range v {
+			v[i] = filterVisibleModels(v[i], visible)
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// isModelNamesArray reports whether a decoded JSON array holds only model
+// names; no other response array carries model names as bare strings
+func isModelNamesArray(arr []any) bool {
+	if len(arr) == 0 {
+		return false
+	}
+	for _, entry := range arr {
+		name, ok := entry.(string)
+		if !ok {
+			return false
+		}
+		if _, known := modelNameRank[name]; !known {
+			return false
+		}
+	}
+	return true
+}
+
+// isModelEntriesArray reports whether a decoded JSON array holds only objects
+// keyed by a model name under "model"
+func isModelEntriesArray(arr []any) bool {
+	if len(arr) == 0 {
+		return false
+	}
+	for _, entry := range arr {
+		obj, ok := entry.(map[string]any)
+		if !ok {
+			return false
+		}
+		name, ok := obj["model"].(string)
+		if !ok {
+			return false
+		}
+		if _, known := modelNameRank[name]; !known {
+			return false
+		}
+	}
+	return true
+}
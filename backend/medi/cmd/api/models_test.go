@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"medi/internal/weather"
+)
+
+func TestWeatherModelsEndpointMetadataComplete(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	w := doRequest(t, app, "/weather/models")
+	var resp ModelsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(resp.Models) != len(weather.ModelNames()) {
+		t.Fatalf("got %d models, want %d", len(resp.Models), len(weather.ModelNames()))
+	}
+
+	hexColor := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	seenColors := map[string]string{}
+	for i, model := range resp.Models {
+		if model.Name == "" || model.ShortLabel == "" {
+			t.Errorf("%s missing display labels: %+v", model.Model, model)
+		}
+		if !hexColor.MatchString(model.Color) {
+			t.Errorf("%s color = %q, want a lowercase hex color", model.Model, model.Color)
+		}
+		if prior, taken := seenColors[model.Color]; taken {
+			t.Errorf("%s shares color %s with %s", model.Model, model.Color, prior)
+		}
+		seenColors[model.Color] = model.Model
+		if model.HorizonHours <= 0 {
+			t.Errorf("%s horizonHours = %d, want positive", model.Model, model.HorizonHours)
+		}
+		if i > 0 && model.SortOrder <= resp.Models[i-1].SortOrder {
+			t.Errorf("models not in strictly ascending sort order at %s", model.Model)
+		}
+	}
+}
+
+// visibilityTestForecast carries one default-visible model and one hidden
+// behind "more models"
+func visibilityTestForecast() *weather.Forecast {
+	denver, _ := time.LoadLocation("America/Denver")
+	horizon := time.Date(2025, 1, 18, 0, 0, 0, 0, denver)
+	return &weather.Forecast{
+		Timezone:  "America/Denver",
+		Timestamp: time.Date(2025, 1, 15, 8, 0, 0, 0, denver),
+		ModelHorizons: weather.ModelValues[time.Time]{
+			weather.ModelGfsSeamless:  horizon,
+			weather.ModelNcepNamConus: horizon,
+		},
+	}
+}
+
+// forecastModelSet runs a forecast request and returns the models block's
+// names plus the ModelHorizons keys actually served
+func forecastModelSet(t *testing.T, app *App, path string) (metadata, horizons map[string]bool) {
+	t.Helper()
+
+	w := doRequest(t, app, path)
+	var resp struct {
+		Forecast struct {
+			Models        []WeatherModelInfo         `json:"models"`
+			ModelHorizons map[string]json.RawMessage `json:"ModelHorizons"`
+		} `json:"forecast"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	metadata = map[string]bool{}
+	for _, model := range resp.Forecast.Models {
+		metadata[model.Model] = true
+	}
+	horizons = map[string]bool{}
+	for model := range resp.Forecast.ModelHorizons {
+		horizons[model] = true
+	}
+	return metadata, horizons
+}
+
+func TestForecastDefaultVisibilityHidesModels(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: visibilityTestForecast()}, time.Now)
+
+	metadata, horizons := forecastModelSet(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	if !metadata[weather.ModelGfsSeamless] || !horizons[weather.ModelGfsSeamless] {
+		t.Errorf("default-visible model missing: metadata=%v horizons=%v", metadata, horizons)
+	}
+	if metadata[weather.ModelNcepNamConus] || horizons[weather.ModelNcepNamConus] {
+		t.Errorf("hidden model served by default: metadata=%v horizons=%v", metadata, horizons)
+	}
+}
+
+func TestForecastAllModelsDisablesFilter(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: visibilityTestForecast()}, time.Now)
+
+	metadata, horizons := forecastModelSet(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&allModels=true")
+	if !metadata[weather.ModelNcepNamConus] || !horizons[weather.ModelNcepNamConus] {
+		t.Errorf("hidden model missing with allModels=true: metadata=%v horizons=%v", metadata, horizons)
+	}
+}
+
+func TestForecastExplicitModelsParamWinsOverVisibility(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: visibilityTestForecast()}, time.Now)
+
+	// The hidden NAM is named explicitly, so it is served and the
+	// default-visible GFS is not
+	metadata, horizons := forecastModelSet(t, app,
+		"/weather/forecast?latitude=39.11539&longitude=-107.65840&models="+weather.ModelNcepNamConus)
+	if !metadata[weather.ModelNcepNamConus] || !horizons[weather.ModelNcepNamConus] {
+		t.Errorf("explicitly named model missing: metadata=%v horizons=%v", metadata, horizons)
+	}
+	if metadata[weather.ModelGfsSeamless] || horizons[weather.ModelGfsSeamless] {
+		t.Errorf("unrequested model served: metadata=%v horizons=%v", metadata, horizons)
+	}
+}
+
+func TestForecastRejectsUnknownModelsParam(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: visibilityTestForecast()}, time.Now)
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/forecast?latitude=39.11539&longitude=-107.65840&models=hrrr", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d for an unknown model, want 400", w.Code)
+	}
+}
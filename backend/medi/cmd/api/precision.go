@@ -0,0 +1,206 @@
+package main
+
+import (
+	"math"
+
+	"medi/internal/types"
+	"medi/internal/weather"
+)
+
+// Display-precision policy for forecast responses. Raw model floats carry
+// artifacts like 3.2800000000000002; responses round to the precision a
+// client would display: precipitation to 0.1 in / 1 mm, temperatures to
+// whole °F / 0.1 °C, wind speeds to whole mph/kph, percentages to whole
+// percent, snow depth to 0.1 ft / 1 cm. Rounding happens only while
+// building the DTO, on fresh copies of the domain maps, so internal math
+// and cached forecasts keep full precision.
+
+// roundTo rounds a value to the given number of decimal places.
+func roundTo(value float64, decimals int) float64 {
+	pow := math.Pow(10, float64(decimals))
+	return math.Round(value*pow) / pow
+}
+
+// roundedSlice returns a new slice with every value rounded.
+func roundedSlice(values []float64, decimals int) []float64 {
+	if values == nil {
+		return nil
+	}
+	rounded := make([]float64, len(values))
+	for i, value := range values {
+		rounded[i] = roundTo(value, decimals)
+	}
+	return rounded
+}
+
+// roundedFloats returns a new map with every value rounded.
+func roundedFloats(values weather.ModelValues[float64], decimals int) weather.ModelValues[float64] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[float64]{}
+	for model, value := range values {
+		rounded[model] = roundTo(value, decimals)
+	}
+	return rounded
+}
+
+func roundedTemperature(t types.Temperature) types.Temperature {
+	return types.Temperature{
+		Fahrenheit: roundTo(t.Fahrenheit, 0),
+		Celsius:    roundTo(t.Celsius, 1),
+	}
+}
+
+func roundedTemperatures(values weather.ModelValues[types.Temperature]) weather.ModelValues[types.Temperature] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[types.Temperature]{}
+	for model, t := range values {
+		rounded[model] = roundedTemperature(t)
+	}
+	return rounded
+}
+
+func roundedFeelsLikes(values weather.ModelValues[types.FeelsLike]) weather.ModelValues[types.FeelsLike] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[types.FeelsLike]{}
+	for model, f := range values {
+		f.Temperature = roundedTemperature(f.Temperature)
+		rounded[model] = f
+	}
+	return rounded
+}
+
+func roundedPrecipitations(values weather.ModelValues[types.Precipitation]) weather.ModelValues[types.Precipitation] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[types.Precipitation]{}
+	for model, p := range values {
+		rounded[model] = types.Precipitation{
+			Inches: roundTo(p.Inches, 1),
+			Mm:     roundTo(p.Mm, 0),
+		}
+	}
+	return rounded
+}
+
+func roundedWindSpeed(s types.WindSpeed) types.WindSpeed {
+	return types.WindSpeed{
+		Mph: roundTo(s.Mph, 0),
+		Kph: roundTo(s.Kph, 0),
+	}
+}
+
+func roundedWindSpeeds(values weather.ModelValues[types.WindSpeed]) weather.ModelValues[types.WindSpeed] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[types.WindSpeed]{}
+	for model, s := range values {
+		rounded[model] = roundedWindSpeed(s)
+	}
+	return rounded
+}
+
+func roundedWinds(values weather.ModelValues[types.Wind]) weather.ModelValues[types.Wind] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[types.Wind]{}
+	for model, w := range values {
+		wind := types.Wind{
+			Speed:     roundedWindSpeed(w.Speed),
+			Direction: w.Direction,
+		}
+		if w.Gusts != nil {
+			gusts := roundedWindSpeed(*w.Gusts)
+			wind.Gusts = &gusts
+		}
+		rounded[model] = wind
+	}
+	return rounded
+}
+
+func roundedSnowDepths(values weather.ModelValues[types.SnowDepth]) weather.ModelValues[types.SnowDepth] {
+	if values == nil {
+		return nil
+	}
+	rounded := weather.ModelValues[types.SnowDepth]{}
+	for model, d := range values {
+		rounded[model] = types.SnowDepth{
+			Feet:   roundTo(d.Feet, 1),
+			Meters: roundTo(d.Meters, 2),
+		}
+	}
+	return rounded
+}
+
+// roundedCurrentConditions returns a copy of the current conditions block
+// with display precision applied.
+func roundedCurrentConditions(c weather.CurrentConditions) weather.CurrentConditions {
+	c.Temperature = roundedTemperatures(c.Temperature)
+	c.Wind = roundedWinds(c.Wind)
+	c.CloudCover = roundedFloats(c.CloudCover, 0)
+	c.CloudCoverLow = roundedFloats(c.CloudCoverLow, 0)
+	c.CloudCoverMid = roundedFloats(c.CloudCoverMid, 0)
+	c.CloudCoverHigh = roundedFloats(c.CloudCoverHigh, 0)
+	c.RelativeHumidity = roundedFloats(c.RelativeHumidity, 0)
+	return c
+}
+
+// roundedDailyForecast applies display precision to a daily forecast's
+// day-level aggregates. Hourly entries are rounded separately as they are
+// copied into their own DTOs.
+func roundedDailyForecast(day weather.DailyForecast) weather.DailyForecast {
+	day.SnowfallWaterEquivalentSum = roundedFloats(day.SnowfallWaterEquivalentSum, 1)
+	day.HighTemperature = roundedTemperatures(day.HighTemperature)
+	day.LowTemperature = roundedTemperatures(day.LowTemperature)
+	day.MaxFeelsLike = roundedFeelsLikes(day.MaxFeelsLike)
+	day.MinFeelsLike = roundedFeelsLikes(day.MinFeelsLike)
+	day.TotalPrecipitation = roundedPrecipitations(day.TotalPrecipitation)
+	day.TotalRain = roundedPrecipitations(day.TotalRain)
+	day.TotalShowers = roundedPrecipitations(day.TotalShowers)
+	day.TotalSnowfall = roundedPrecipitations(day.TotalSnowfall)
+	day.TotalLiquidPrecipitation = roundedPrecipitations(day.TotalLiquidPrecipitation)
+	day.MaxWindSpeed = roundedWindSpeeds(day.MaxWindSpeed)
+	day.MinWindSpeed = roundedWindSpeeds(day.MinWindSpeed)
+	day.MaxWindGusts = roundedWindSpeeds(day.MaxWindGusts)
+	day.MinWindGusts = roundedWindSpeeds(day.MinWindGusts)
+	return day
+}
+
+// roundedHourlyForecast applies display precision to an hourly forecast.
+func roundedHourlyForecast(hour weather.HourlyForecast) weather.HourlyForecast {
+	hour.Temperature = roundedTemperatures(hour.Temperature)
+	hour.ApparentTemperature = roundedTemperatures(hour.ApparentTemperature)
+	hour.FeelsLike = roundedFeelsLikes(hour.FeelsLike)
+	hour.PrecipitationProbability = roundedFloats(hour.PrecipitationProbability, 0)
+	hour.Precipitation = roundedPrecipitations(hour.Precipitation)
+	hour.CloudCover = roundedFloats(hour.CloudCover, 0)
+	hour.CloudCoverLow = roundedFloats(hour.CloudCoverLow, 0)
+	hour.CloudCoverMid = roundedFloats(hour.CloudCoverMid, 0)
+	hour.CloudCoverHigh = roundedFloats(hour.CloudCoverHigh, 0)
+	hour.Wind = roundedWinds(hour.Wind)
+	hour.RelativeHumidity = roundedFloats(hour.RelativeHumidity, 0)
+	hour.Rainfall = roundedPrecipitations(hour.Rainfall)
+	hour.Showers = roundedPrecipitations(hour.Showers)
+	hour.Snowfall = roundedPrecipitations(hour.Snowfall)
+	hour.SnowDepth = roundedSnowDepths(hour.SnowDepth)
+	hour.LiquidPrecipitation = roundedPrecipitations(hour.LiquidPrecipitation)
+	return hour
+}
+
+// roundedPowderAlert applies display precision to a powder alert: snowfall
+// bounds to 0.1 in, confidence to whole percent.
+func roundedPowderAlert(alert weather.PowderAlert) weather.PowderAlert {
+	alert.MedianInches = roundTo(alert.MedianInches, 1)
+	alert.ExpectedMinInches = roundTo(alert.ExpectedMinInches, 1)
+	alert.ExpectedMaxInches = roundTo(alert.ExpectedMaxInches, 1)
+	alert.Confidence = roundTo(alert.Confidence, 2)
+	return alert
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+	"medi/internal/weather"
+)
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		value    float64
+		decimals int
+		want     float64
+	}{
+		{3.2800000000000002, 1, 3.3},
+		{3.24, 1, 3.2},
+		{71.499, 0, 71},
+		{71.5, 0, 72},
+		{-12.34, 0, -12},
+		{0.456, 2, 0.46},
+	}
+
+	for _, tt := range tests {
+		if got := roundTo(tt.value, tt.decimals); got != tt.want {
+			t.Errorf("roundTo(%v, %d) = %v, want %v", tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestRoundedTemperatures(t *testing.T) {
+	values := weather.ModelValues[types.Temperature]{
+		weather.ModelGfsSeamless: {Fahrenheit: 28.472, Celsius: -1.9599999999999997},
+	}
+
+	rounded := roundedTemperatures(values)
+
+	got := rounded[weather.ModelGfsSeamless]
+	if got.Fahrenheit != 28 {
+		t.Errorf("Fahrenheit = %v, want whole degrees 28", got.Fahrenheit)
+	}
+	if got.Celsius != -2.0 {
+		t.Errorf("Celsius = %v, want -2.0", got.Celsius)
+	}
+
+	// The source map keeps full precision
+	if values[weather.ModelGfsSeamless].Fahrenheit != 28.472 {
+		t.Error("roundedTemperatures mutated the source map")
+	}
+}
+
+func TestRoundedWindsCopiesGusts(t *testing.T) {
+	gusts := types.WindSpeed{Mph: 34.715, Kph: 55.86}
+	values := weather.ModelValues[types.Wind]{
+		weather.ModelEcmwIfs: {
+			Speed: types.WindSpeed{Mph: 17.3, Kph: 27.84},
+			Gusts: &gusts,
+		},
+	}
+
+	rounded := roundedWinds(values)
+
+	got := rounded[weather.ModelEcmwIfs]
+	if got.Speed.Mph != 17 || got.Speed.Kph != 28 {
+		t.Errorf("Speed = %+v, want whole 17 mph / 28 kph", got.Speed)
+	}
+	if got.Gusts.Mph != 35 {
+		t.Errorf("Gusts.Mph = %v, want 35", got.Gusts.Mph)
+	}
+	// The rounded gusts must be a copy, not the domain's pointer
+	if gusts.Mph != 34.715 {
+		t.Error("roundedWinds mutated the domain gusts value")
+	}
+}
+
+// TestForecastDTODisplayPrecision feeds raw model floats through the DTO and
+// checks the response carries rounded values while the domain forecast, which
+// stays in the cache, keeps full precision.
+func TestForecastDTODisplayPrecision(t *testing.T) {
+	day := time.Date(2025, 1, 15, 7, 0, 0, 0, time.UTC)
+	snowfall := weather.ModelValues[types.Precipitation]{
+		weather.ModelGfsSeamless: {Inches: 3.2800000000000002, Mm: 83.31200000000001},
+	}
+
+	forecast := &weather.Forecast{
+		Timestamp: day,
+		Timezone:  weather.TimezoneDenver,
+		CurrentConditions: weather.CurrentConditions{
+			RelativeHumidity: weather.ModelValues[float64]{
+				weather.ModelGfsSeamless: 71.49999999999999,
+			},
+		},
+		DailyForecasts: []weather.DailyForecast{
+			{
+				Timestamp:     day,
+				TotalSnowfall: snowfall,
+				HourlyForecasts: []weather.HourlyForecast{
+					{
+						Start: day,
+						End:   day.Add(time.Hour),
+						SnowDepth: weather.ModelValues[types.SnowDepth]{
+							weather.ModelGfsSeamless: {Feet: 3.9370078740157477, Meters: 1.2000000000000002},
+						},
+					},
+				},
+			},
+		},
+		PowderAlerts: []weather.PowderAlert{
+			{Start: day, End: day.Add(24 * time.Hour), MedianInches: 7.124999999999999, Confidence: 0.5714285714285714},
+		},
+	}
+
+	dto, err := newForecastDTO(forecast, false)
+	if err != nil {
+		t.Fatalf("newForecastDTO returned error: %v", err)
+	}
+
+	if got := dto.CurrentConditions.RelativeHumidity[weather.ModelGfsSeamless]; got != 71 {
+		t.Errorf("current humidity = %v, want 71", got)
+	}
+	if got := dto.DailyForecasts[0].TotalSnowfall[weather.ModelGfsSeamless]; got.Inches != 3.3 || got.Mm != 83 {
+		t.Errorf("daily snowfall = %+v, want 3.3 in / 83 mm", got)
+	}
+	if got := dto.DailyForecasts[0].HourlyForecasts[0].SnowDepth[weather.ModelGfsSeamless]; got.Feet != 3.9 || got.Meters != 1.2 {
+		t.Errorf("snow depth = %+v, want 3.9 ft / 1.2 m", got)
+	}
+	if got := dto.PowderAlerts[0]; got.MedianInches != 7.1 || got.Confidence != 0.57 {
+		t.Errorf("powder alert = %+v, want 7.1 in at 0.57 confidence", got.PowderAlert)
+	}
+
+	// Full precision survives in the domain model
+	if forecast.DailyForecasts[0].TotalSnowfall[weather.ModelGfsSeamless].Inches != 3.2800000000000002 {
+		t.Error("DTO construction mutated the domain snowfall map")
+	}
+	if forecast.CurrentConditions.RelativeHumidity[weather.ModelGfsSeamless] != 71.49999999999999 {
+		t.Error("DTO construction mutated the domain humidity map")
+	}
+}
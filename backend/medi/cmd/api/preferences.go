@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"medi/internal/preferences"
+)
+
+// defaultPreferences is the config-default preference layer, passed last to
+// preferences.Resolve by every handler that reads a preference
+func (app *App) defaultPreferences() preferences.Preferences {
+	cfg := app.config()
+	if cfg == nil {
+		return preferences.Preferences{}
+	}
+	return preferences.Preferences{
+		Units:    cfg.App.DefaultUnits,
+		Language: cfg.App.DefaultLanguage,
+	}
+}
+
+// resolveUnits resolves the units preference, query over the configured
+// default, and rejects values naming no known system; empty means both
+// systems stay in the payload
+func (app *App) resolveUnits(query string) (string, error) {
+	units := preferences.Resolve(preferences.Preferences{Units: query}, app.defaultPreferences()).Units
+	switch units {
+	case "", preferences.UnitsImperial, preferences.UnitsMetric:
+		return units, nil
+	}
+	return "", fmt.Errorf("unknown units %q: valid values are %s and %s",
+		units, preferences.UnitsImperial, preferences.UnitsMetric)
+}
+
+// unitPairs maps each measurement's metric key to its imperial twin, as the
+// unit types in internal/types serialize them
+var unitPairs = map[string]string{
+	"celsius": "fahrenheit",
+	"mm":      "inches",
+	"meters":  "feet",
+	"kph":     "mph",
+}
+
+// unitSystemDocument serializes the payload and strips the other system's
+// figure from every measurement object. Like the model filters, the
+// round-trip through generic JSON runs after the cache, so requests for
+// either system share one cache entry carrying both.
+func unitSystemDocument(payload any, units string) (any, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return pruneUnitSystem(doc, units), nil
+}
+
+func pruneUnitSystem(node any, units string) any {
+	switch v := node.(type) {
+	case map[string]any:
+		if metric, imperial, ok := unitPairKeys(v); ok {
+			if units == preferences.UnitsMetric {
+				delete(v, imperial)
+			} else {
+				delete(v, metric)
+			}
+			return v
+		}
+		for key, child := range v {
+			v[key] = pruneUnitSystem(child, units)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = pruneUnitSystem(v[i], units)
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// unitPairKeys reports whether the object is a measurement: exactly two keys
+// forming one metric/imperial pair
+func unitPairKeys(v map[string]any) (metric, imperial string, ok bool) {
+	if len(v) != 2 {
+		return "", "", false
+	}
+	for m, i := range unitPairs {
+		if _, hasMetric := v[m]; !hasMetric {
+			continue
+		}
+		if _, hasImperial := v[i]; hasImperial {
+			return m, i, true
+		}
+	}
+	return "", "", false
+}
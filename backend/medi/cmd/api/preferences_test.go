@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/preferences"
+	"medi/internal/types"
+	"medi/internal/weather"
+)
+
+// unitsTestForecast carries one temperature and one snowfall figure, so the
+// serialized document holds a celsius/fahrenheit and an inches/mm pair
+func unitsTestForecast() *weather.Forecast {
+	denver, _ := time.LoadLocation("America/Denver")
+	start := time.Date(2025, 1, 15, 8, 0, 0, 0, denver)
+	return &weather.Forecast{
+		Timezone:  "America/Denver",
+		Timestamp: start,
+		DailyForecasts: []weather.DailyForecast{{
+			Timestamp: start,
+			HighTemperature: weather.ModelValues[types.Temperature]{
+				weather.ModelGfsSeamless: types.NewTemperatureFromFahrenheit(30),
+			},
+			TotalSnowfall: weather.ModelValues[types.Precipitation]{
+				weather.ModelGfsSeamless: types.NewPrecipitationFromInches(3),
+			},
+		}},
+	}
+}
+
+func unitsTestApp(defaults config.AppConfig) *App {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: unitsTestForecast()}, time.Now)
+	app.cfg = config.NewHolder(&config.Config{App: defaults})
+	return app
+}
+
+const forecastPath = "/weather/forecast?latitude=39.11539&longitude=-107.65840"
+
+func TestForecastKeepsBothUnitSystemsByDefault(t *testing.T) {
+	app := unitsTestApp(config.AppConfig{})
+
+	body := doRequest(t, app, forecastPath).Body.String()
+	for _, key := range []string{`"fahrenheit"`, `"celsius"`, `"inches"`, `"mm"`} {
+		if !strings.Contains(body, key) {
+			t.Errorf("response missing %s; both unit systems should serialize without a preference", key)
+		}
+	}
+}
+
+func TestForecastUnitsParamPrunesOtherSystem(t *testing.T) {
+	tests := []struct {
+		units         string
+		keep, dropped []string
+	}{
+		{preferences.UnitsMetric, []string{`"celsius"`, `"mm"`}, []string{`"fahrenheit"`, `"inches"`}},
+		{preferences.UnitsImperial, []string{`"fahrenheit"`, `"inches"`}, []string{`"celsius"`, `"mm"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.units, func(t *testing.T) {
+			app := unitsTestApp(config.AppConfig{})
+
+			body := doRequest(t, app, forecastPath+"&units="+tt.units).Body.String()
+			for _, key := range tt.keep {
+				if !strings.Contains(body, key) {
+					t.Errorf("response missing the requested system's %s", key)
+				}
+			}
+			for _, key := range tt.dropped {
+				if strings.Contains(body, key) {
+					t.Errorf("response still carries %s with units=%s", key, tt.units)
+				}
+			}
+		})
+	}
+}
+
+func TestForecastConfigDefaultUnitsApply(t *testing.T) {
+	app := unitsTestApp(config.AppConfig{DefaultUnits: preferences.UnitsMetric})
+
+	// No query parameter: the configured default prunes to metric
+	body := doRequest(t, app, forecastPath).Body.String()
+	if strings.Contains(body, `"fahrenheit"`) {
+		t.Error("configured metric default left imperial figures in the response")
+	}
+	if !strings.Contains(body, `"celsius"`) {
+		t.Error("configured metric default dropped the metric figures too")
+	}
+
+	// An explicit query parameter still wins over the configured default
+	body = doRequest(t, app, forecastPath+"&units=imperial").Body.String()
+	if !strings.Contains(body, `"fahrenheit"`) || strings.Contains(body, `"celsius"`) {
+		t.Error("units=imperial did not win over the configured metric default")
+	}
+}
+
+func TestForecastRejectsUnknownUnits(t *testing.T) {
+	app := unitsTestApp(config.AppConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, forecastPath+"&units=nautical", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400 for an unknown unit system", w.Code)
+	}
+}
+
+func TestWeeklyConfigDefaultLanguageApplies(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC) }
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{fail: true}, clock)
+	app.cfg = config.NewHolder(&config.Config{App: config.AppConfig{DefaultLanguage: "es"}})
+	app.weatherCache.Set(forecastCacheKey(39.11539, -107.65840), weeklyTestForecast(5))
+
+	var resp WeeklySummaryResponse
+	w := doRequest(t, app, "/weather/weekly?latitude=39.11539&longitude=-107.65840")
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Days[0].Label != "Hoy" {
+		t.Errorf("first label = %q, want the configured Spanish default", resp.Days[0].Label)
+	}
+
+	// The query parameter still wins over the configured default
+	w = doRequest(t, app, "/weather/weekly?latitude=39.11539&longitude=-107.65840&language=en")
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Days[0].Label != "Today" {
+		t.Errorf("first label = %q, want the explicit English override", resp.Days[0].Label)
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/cache"
+	"medi/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// How the background refresher paces itself
+const (
+	// prefetchLead is how long before a cache entry's TTL elapses that the
+	// refresher considers it due for a re-fetch
+	prefetchLead = 2 * time.Minute
+	// prefetchInterval is how often the refresher sweeps its tracked locations
+	prefetchInterval = time.Minute
+	// prefetchFetchTimeout bounds each background fetch so one slow provider
+	// call cannot stall a whole sweep
+	prefetchFetchTimeout = 30 * time.Second
+)
+
+// prefetchLocation is one tracked coordinate with the time a client last
+// requested it
+type prefetchLocation struct {
+	key           string
+	latitude      float64
+	longitude     float64
+	lastRequested time.Time
+}
+
+// prefetcher keeps forecasts warm for the locations clients actually use. It
+// tracks the most recently requested points in a bounded LRU alongside the
+// configured priority locations, and re-fetches each one shortly before its
+// cache entry's TTL elapses so repeat requests keep hitting the cache.
+// Fetches run one at a time, so background refreshes never compete with
+// interactive requests for provider throughput.
+type prefetcher struct {
+	app *App
+
+	mu    sync.Mutex
+	byKey map[string]*list.Element
+	order *list.List // front is most recently requested
+	// capacity bounds how many recent locations are tracked; the least
+	// recently requested is evicted first
+	capacity int
+	// inactivity is the sliding window: a location not requested within it is
+	// dropped instead of refreshed
+	inactivity time.Duration
+
+	// runs counts background refresh attempts and errors the failed ones,
+	// reported by the metrics endpoint
+	runs   atomic.Int64
+	errors atomic.Int64
+	// avalancheRuns and avalancheErrors count the publish-window avalanche
+	// refreshes separately from the weather sweeps
+	avalancheRuns   atomic.Int64
+	avalancheErrors atomic.Int64
+
+	stop chan struct{}
+	done chan struct{}
+	// warmed is closed once the startup sweep that pre-warms the priority
+	// locations has finished; the readiness endpoint gates on it
+	warmed chan struct{}
+}
+
+func newPrefetcher(app *App, capacity int, inactivity time.Duration) *prefetcher {
+	return &prefetcher{
+		app:        app,
+		byKey:      make(map[string]*list.Element),
+		order:      list.New(),
+		capacity:   capacity,
+		inactivity: inactivity,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+		warmed:     make(chan struct{}),
+	}
+}
+
+// noteRequest records that a client asked for this location, promoting it to
+// most recently used and evicting the oldest entry past capacity
+func (p *prefetcher) noteRequest(latitude, longitude float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := forecastCacheKey(latitude, longitude)
+	if element, ok := p.byKey[key]; ok {
+		element.Value.(*prefetchLocation).lastRequested = p.app.now()
+		p.order.MoveToFront(element)
+		return
+	}
+
+	p.byKey[key] = p.order.PushFront(&prefetchLocation{
+		key:           key,
+		latitude:      latitude,
+		longitude:     longitude,
+		lastRequested: p.app.now(),
+	})
+	for p.order.Len() > p.capacity {
+		oldest := p.order.Back()
+		delete(p.byKey, oldest.Value.(*prefetchLocation).key)
+		p.order.Remove(oldest)
+	}
+}
+
+// run performs a startup sweep to warm the priority locations, then sweeps
+// the tracked locations on a fixed interval until Stop is called
+func (p *prefetcher) run() {
+	defer close(p.done)
+
+	p.sweep(context.Background())
+	close(p.warmed)
+
+	ticker := time.NewTicker(prefetchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.sweep(context.Background())
+		}
+	}
+}
+
+// Stop halts the background loop and waits for an in-flight sweep to finish
+func (p *prefetcher) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+// sweep drops locations outside the inactivity window, then re-fetches every
+// candidate whose cache entry is missing, stale, or within prefetchLead of
+// going stale. Priority locations are always candidates, so they stay warm
+// even when nobody has requested them recently. Candidates with a cached
+// avalanche forecast are additionally refreshed on a short cadence while
+// their center's publish window is open.
+func (p *prefetcher) sweep(ctx context.Context) {
+	now := p.app.now()
+	cfg := p.app.config()
+
+	p.mu.Lock()
+	var candidates []prefetchLocation
+	var element *list.Element
+	for e := p.order.Front(); e != nil; e = element {
+		element = e.Next()
+		loc := e.Value.(*prefetchLocation)
+		if now.Sub(loc.lastRequested) > p.inactivity {
+			delete(p.byKey, loc.key)
+			p.order.Remove(e)
+			continue
+		}
+		candidates = append(candidates, *loc)
+	}
+	p.mu.Unlock()
+
+	seen := make(map[string]bool, len(candidates))
+	for _, loc := range candidates {
+		seen[loc.key] = true
+	}
+	if cfg != nil {
+		for _, priority := range cfg.App.PriorityLocations {
+			key := forecastCacheKey(priority.Latitude, priority.Longitude)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, prefetchLocation{
+				key:       key,
+				latitude:  priority.Latitude,
+				longitude: priority.Longitude,
+			})
+		}
+	}
+
+	for _, loc := range candidates {
+		entry, status := p.app.weatherCache.Get(loc.key)
+		if status == cache.StatusHit && now.Sub(entry.FetchedAt) < weatherCacheTTL-prefetchLead {
+			continue
+		}
+		p.refresh(ctx, loc)
+	}
+
+	for _, loc := range candidates {
+		p.refreshAvalancheIfDue(ctx, loc, now, cfg)
+	}
+}
+
+// refreshAvalancheIfDue re-fetches a cached avalanche forecast when its
+// center's publish window is open and the entry has not been refreshed within
+// the publish cadence. Outside the window the forecast ages normally under
+// its cache TTL.
+func (p *prefetcher) refreshAvalancheIfDue(ctx context.Context, loc prefetchLocation, now time.Time, cfg *config.Config) {
+	entry, status := p.app.avalancheCache.Get(loc.key)
+	if status == cache.StatusMiss {
+		return
+	}
+	window, ok := publishWindowFor(cfg, entry.Value.Center.Id)
+	if !ok || !window.contains(now) {
+		return
+	}
+	if now.Sub(entry.FetchedAt) < avalanchePublishRefreshInterval {
+		return
+	}
+
+	p.avalancheRuns.Add(1)
+	forecast, shared, err := p.app.avalancheFlight.Do(loc.key, func() (*avalanche.AvalancheForecast, error) {
+		return p.app.avalancheService.GetForecast(loc.latitude, loc.longitude)
+	})
+	if err != nil {
+		p.avalancheErrors.Add(1)
+		p.app.logger.Warn("publish-window avalanche refresh failed",
+			"latitude", loc.latitude,
+			"longitude", loc.longitude,
+			"error", err,
+		)
+		return
+	}
+	if !shared {
+		p.app.avalancheCache.Set(loc.key, forecast)
+		p.app.publishAvalancheRefresh(loc.latitude, loc.longitude, forecast)
+	}
+}
+
+// refresh fetches a fresh forecast for one location and stores it; a failed
+// fetch is counted and logged, leaving any stale entry in place as a fallback
+func (p *prefetcher) refresh(ctx context.Context, loc prefetchLocation) {
+	p.runs.Add(1)
+
+	fetchCtx, cancel := context.WithTimeout(ctx, prefetchFetchTimeout)
+	defer cancel()
+
+	forecast, err := p.app.fetchWeatherForecast(fetchCtx, loc.latitude, loc.longitude, nil, false, "")
+	if err != nil {
+		p.errors.Add(1)
+		p.app.logger.Warn("prefetch refresh failed",
+			"latitude", loc.latitude,
+			"longitude", loc.longitude,
+			"error", err,
+		)
+		return
+	}
+
+	p.app.weatherCache.Set(loc.key, forecast)
+	p.app.publishWeatherRefresh(loc.latitude, loc.longitude, forecast)
+}
+
+// warmedUp reports whether the startup sweep has finished
+func (p *prefetcher) warmedUp() bool {
+	select {
+	case <-p.warmed:
+		return true
+	default:
+		return false
+	}
+}
+
+// trackedLocations reports how many recent locations the LRU currently holds
+func (p *prefetcher) trackedLocations() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.order.Len()
+}
+
+// MetricsResponse reports background refresher activity and cache eviction
+// counters
+type MetricsResponse struct {
+	PrefetchRunsTotal        int64 `json:"prefetch_runs_total"`
+	PrefetchErrorsTotal      int64 `json:"prefetch_errors_total"`
+	PrefetchTrackedLocations int   `json:"prefetch_tracked_locations"`
+
+	// The publish-window avalanche refreshes, counted apart from the weather
+	// sweeps they ride along with
+	AvalancheRefreshRunsTotal   int64 `json:"avalanche_refresh_runs_total"`
+	AvalancheRefreshErrorsTotal int64 `json:"avalanche_refresh_errors_total"`
+
+	WeatherCacheEvictionsTotal   int64 `json:"weather_cache_evictions_total"`
+	AvalancheCacheEvictionsTotal int64 `json:"avalanche_cache_evictions_total"`
+
+	// DeprecatedFieldServedTotal counts deprecated response fields served to
+	// compat clients, keyed by field; a field stuck at zero is safe to remove
+	DeprecatedFieldServedTotal map[string]int64 `json:"deprecated_field_served_total"`
+}
+
+// handleGetMetrics godoc
+// @Summary Service metrics
+// @Description Report activity counters for the background forecast prefetcher and the forecast cache caps
+// @Tags health
+// @Produce json
+// @Success 200 {object} MetricsResponse
+// @Router /metrics [get]
+func (app *App) handleGetMetrics(c *gin.Context) {
+	var metrics MetricsResponse
+	if app.prefetcher != nil {
+		metrics.PrefetchRunsTotal = app.prefetcher.runs.Load()
+		metrics.PrefetchErrorsTotal = app.prefetcher.errors.Load()
+		metrics.PrefetchTrackedLocations = app.prefetcher.trackedLocations()
+		metrics.AvalancheRefreshRunsTotal = app.prefetcher.avalancheRuns.Load()
+		metrics.AvalancheRefreshErrorsTotal = app.prefetcher.avalancheErrors.Load()
+	}
+	metrics.WeatherCacheEvictionsTotal = app.weatherCache.Evictions()
+	metrics.AvalancheCacheEvictionsTotal = app.avalancheCache.Evictions()
+	metrics.DeprecatedFieldServedTotal = app.deprecations.snapshot()
+	c.JSON(http.StatusOK, metrics)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/cache"
+	"medi/internal/config"
+	"medi/internal/weather"
+)
+
+// newPrefetchTestApp builds a test app with an attached prefetcher that is
+// swept manually rather than by the background loop
+func newPrefetchTestApp(weatherSvc *mockWeatherService, clock func() time.Time, capacity int) (*App, *prefetcher) {
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, clock)
+	p := newPrefetcher(app, capacity, 6*time.Hour)
+	app.prefetcher = p
+	return app, p
+}
+
+func TestPrefetchRefreshTiming(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{}}
+	app, p := newPrefetchTestApp(weatherSvc, clock, 50)
+
+	doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	key := forecastCacheKey(39.11539, -107.65840)
+
+	// Well inside the TTL nothing is due
+	currentTime = currentTime.Add(5 * time.Minute)
+	p.sweep(context.Background())
+	if got := p.runs.Load(); got != 0 {
+		t.Fatalf("sweep inside TTL made %d refreshes, want 0", got)
+	}
+
+	// Inside the lead window before expiry the entry is re-fetched
+	currentTime = currentTime.Add(weatherCacheTTL - prefetchLead - 4*time.Minute)
+	p.sweep(context.Background())
+	if got := p.runs.Load(); got != 1 {
+		t.Fatalf("sweep in lead window made %d refreshes, want 1", got)
+	}
+	entry, status := app.weatherCache.Get(key)
+	if status != cache.StatusHit {
+		t.Errorf("cache status after prefetch = %v, want HIT", status)
+	}
+	if !entry.FetchedAt.Equal(currentTime) {
+		t.Errorf("cache entry FetchedAt = %v, want refresh time %v", entry.FetchedAt, currentTime)
+	}
+
+	// A failed refresh is counted and leaves the previous entry in place
+	weatherSvc.fail = true
+	refreshedAt := currentTime
+	currentTime = currentTime.Add(weatherCacheTTL - time.Minute)
+	p.sweep(context.Background())
+	if got := p.errors.Load(); got != 1 {
+		t.Errorf("errors after failed refresh = %d, want 1", got)
+	}
+	entry, _ = app.weatherCache.Get(key)
+	if !entry.FetchedAt.Equal(refreshedAt) {
+		t.Errorf("failed refresh replaced the cache entry, FetchedAt = %v", entry.FetchedAt)
+	}
+}
+
+func TestPrefetchLRUEviction(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	app, p := newPrefetchTestApp(&mockWeatherService{forecast: &weather.Forecast{}}, clock, 2)
+
+	latitudes := []float64{39.1, 39.2, 39.3}
+	for _, latitude := range latitudes {
+		doRequest(t, app, fmt.Sprintf("/weather/forecast?latitude=%v&longitude=-107.65840", latitude))
+	}
+
+	if got := p.trackedLocations(); got != 2 {
+		t.Fatalf("tracked locations = %d, want 2", got)
+	}
+	p.mu.Lock()
+	_, oldestTracked := p.byKey[forecastCacheKey(39.1, -107.65840)]
+	_, newestTracked := p.byKey[forecastCacheKey(39.3, -107.65840)]
+	p.mu.Unlock()
+	if oldestTracked {
+		t.Error("least recently requested location not evicted")
+	}
+	if !newestTracked {
+		t.Error("most recently requested location missing from tracker")
+	}
+}
+
+func TestPrefetchInactivitySkip(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	_, p := newPrefetchTestApp(&mockWeatherService{forecast: &weather.Forecast{}}, clock, 50)
+	app := p.app
+
+	doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+
+	// Past the inactivity window the stale entry is dropped, not refreshed
+	currentTime = currentTime.Add(7 * time.Hour)
+	p.sweep(context.Background())
+	if got := p.runs.Load(); got != 0 {
+		t.Errorf("sweep refreshed %d inactive locations, want 0", got)
+	}
+	if got := p.trackedLocations(); got != 0 {
+		t.Errorf("tracked locations after inactivity = %d, want 0", got)
+	}
+}
+
+func TestPrefetchPriorityLocationsAlwaysWarm(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	app, p := newPrefetchTestApp(&mockWeatherService{forecast: &weather.Forecast{}}, clock, 50)
+	app.cfg = config.NewHolder(&config.Config{App: config.AppConfig{
+		PriorityLocations: []config.PriorityLocation{
+			{Name: "home", Latitude: 39.11539, Longitude: -107.65840},
+		},
+	}})
+
+	// Never requested by a client, yet the first sweep warms it
+	p.sweep(context.Background())
+	if got := p.runs.Load(); got != 1 {
+		t.Fatalf("sweep made %d refreshes, want 1 for the priority location", got)
+	}
+	_, status := app.weatherCache.Get(forecastCacheKey(39.11539, -107.65840))
+	if status != cache.StatusHit {
+		t.Errorf("priority location cache status = %v, want HIT", status)
+	}
+}
+
+func TestPrefetchStop(t *testing.T) {
+	_, p := newPrefetchTestApp(&mockWeatherService{forecast: &weather.Forecast{}}, time.Now, 50)
+
+	go p.run()
+	stopped := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return")
+	}
+}
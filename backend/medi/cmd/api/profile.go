@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"medi/internal/types"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultProfileHours is the matrix window when the query does not give one
+const defaultProfileHours = 24
+
+// defaultLapseRateCPerKm mirrors the config default for apps built without a
+// config, e.g. in tests
+const defaultLapseRateCPerKm = 6.5
+
+// GetTemperatureProfileInput defines the query parameters for the temperature profile endpoint
+type GetTemperatureProfileInput struct {
+	CoordinateInput
+	// Hours is how many hourly rows the matrix covers; defaults to 24
+	Hours int `form:"hours"`
+}
+
+// TemperatureProfileResponse carries the hour × elevation temperature matrix
+type TemperatureProfileResponse struct {
+	Timezone        string                      `json:"timezone"`
+	PrimaryModel    string                      `json:"primaryModel"`
+	PointElevation  types.Elevation             `json:"pointElevation"`
+	LapseRateCPerKm float64                     `json:"lapseRateCPerKm"`
+	BandElevations  []types.Elevation           `json:"bandElevations"`
+	Hours           []TemperatureProfileHourDTO `json:"hours"`
+}
+
+type TemperatureProfileHourDTO struct {
+	weather.TemperatureProfileHour
+	Start LocalTimestamp
+}
+
+// handleGetTemperatureProfile godoc
+// @Summary Get a vertical temperature profile
+// @Description Estimate hourly temperatures at standard elevation bands around the point, from 500 m below to 1500 m above, with the freezing altitude per hour
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param hours query integer false "Hourly rows to return" example(24)
+// @Success 200 {object} TemperatureProfileResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /weather/temperature-profile [get]
+func (app *App) handleGetTemperatureProfile(c *gin.Context) {
+	var input GetTemperatureProfileInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hours := input.Hours
+	if hours == 0 {
+		hours = defaultProfileHours
+	}
+
+	lapseRate := defaultLapseRateCPerKm
+	if cfg := app.config(); cfg != nil && cfg.App.LapseRateCPerKm > 0 {
+		lapseRate = cfg.App.LapseRateCPerKm
+	}
+
+	forecast, ok := app.cachedForecast(c, input.Latitude, input.Longitude)
+	if !ok {
+		return
+	}
+
+	profile, err := weather.ComputeTemperatureProfile(forecast, lapseRate, hours)
+	if err != nil {
+		if errors.Is(err, weather.ErrInvalidProfileHours) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		app.logger.Error("failed to build temperature profile", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build temperature profile"})
+		return
+	}
+
+	locationZone, err := time.LoadLocation(profile.Timezone)
+	if err != nil {
+		app.logger.Error("failed to load forecast timezone", "timezone", profile.Timezone, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build temperature profile"})
+		return
+	}
+
+	response := TemperatureProfileResponse{
+		Timezone:        profile.Timezone,
+		PrimaryModel:    profile.PrimaryModel,
+		PointElevation:  profile.PointElevation,
+		LapseRateCPerKm: profile.LapseRateCPerKm,
+		BandElevations:  profile.BandElevations,
+		Hours:           make([]TemperatureProfileHourDTO, 0, len(profile.Hours)),
+	}
+	for _, hour := range profile.Hours {
+		response.Hours = append(response.Hours, TemperatureProfileHourDTO{
+			TemperatureProfileHour: hour,
+			Start:                  newLocalTimestamp(hour.Start, locationZone),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"medi/internal/alert"
+	"medi/internal/avalanche"
+	"medi/internal/weather"
+)
+
+// Publisher pushes retained messages for home automation subscribers. The
+// App's publisher is nil when MQTT publishing is disabled.
+type Publisher interface {
+	Publish(topic string, payload []byte, retained bool) error
+	Close()
+}
+
+// priorityLocationName returns the configured name for coordinates matching a
+// priority location's cache key, or "" when none matches
+func (app *App) priorityLocationName(latitude, longitude float64) string {
+	cfg := app.config()
+	if cfg == nil {
+		return ""
+	}
+	key := forecastCacheKey(latitude, longitude)
+	for _, location := range cfg.App.PriorityLocations {
+		if forecastCacheKey(location.Latitude, location.Longitude) == key {
+			return location.Name
+		}
+	}
+	return ""
+}
+
+// publishWeatherRefresh pushes a freshly fetched forecast for a priority
+// location as retained current and daily messages. It piggybacks on cache
+// refreshes, so publishing never triggers extra upstream calls.
+func (app *App) publishWeatherRefresh(latitude, longitude float64, forecast *weather.Forecast) {
+	if app.publisher == nil {
+		return
+	}
+	name := app.priorityLocationName(latitude, longitude)
+	if name == "" {
+		return
+	}
+
+	dto, err := newForecastDTO(forecast, false)
+	if err != nil {
+		app.logger.Error("failed to build forecast for publishing", "location", name, "error", err)
+		return
+	}
+
+	app.publishJSON(app.locationTopic(name, "current"), dto.CurrentConditions)
+	app.publishJSON(app.locationTopic(name, "daily"), dto.DailyForecasts)
+	app.publishPowderAlert(name, latitude, longitude, forecast)
+}
+
+// publishPowderAlert renders the configured alert payload for the forecast's
+// top powder alert window and publishes it retained. The template was
+// validated at startup; a render failure here still only costs the alert
+// message.
+func (app *App) publishPowderAlert(name string, latitude, longitude float64, forecast *weather.Forecast) {
+	if len(forecast.PowderAlerts) == 0 {
+		return
+	}
+	cfg := app.config()
+
+	payloadTemplate, err := alert.NewPayloadTemplate(cfg.MQTT.AlertPreset, cfg.MQTT.AlertTemplate)
+	if err != nil {
+		app.logger.Error("failed to build alert payload template", "location", name, "error", err)
+		return
+	}
+
+	top := forecast.PowderAlerts[0]
+	payload, err := payloadTemplate.Render(alert.Context{
+		LocationName: name,
+		Metric:       "snowfall24hInches",
+		Threshold:    cfg.App.PowderAlertInches,
+		Actual:       top.MedianInches,
+		ForecastLink: fmt.Sprintf("/weather/forecast?latitude=%.4f&longitude=%.4f", latitude, longitude),
+	})
+	if err != nil {
+		app.logger.Error("failed to render alert payload", "location", name, "error", err)
+		return
+	}
+
+	if err := app.publisher.Publish(app.locationTopic(name, "alert"), payload, true); err != nil {
+		app.logger.Error("failed to publish", "topic", app.locationTopic(name, "alert"), "error", err)
+	}
+}
+
+// publishAvalancheRefresh pushes a freshly fetched avalanche forecast for a
+// priority location as a retained message
+func (app *App) publishAvalancheRefresh(latitude, longitude float64, forecast *avalanche.AvalancheForecast) {
+	if app.publisher == nil {
+		return
+	}
+	name := app.priorityLocationName(latitude, longitude)
+	if name == "" {
+		return
+	}
+
+	app.publishJSON(app.locationTopic(name, "avalanche"), forecast)
+}
+
+func (app *App) locationTopic(name, suffix string) string {
+	return fmt.Sprintf("%s/%s/%s", app.config().MQTT.TopicPrefix, name, suffix)
+}
+
+func (app *App) publishJSON(topic string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		app.logger.Error("failed to marshal payload for publishing", "topic", topic, "error", err)
+		return
+	}
+	if err := app.publisher.Publish(topic, data, true); err != nil {
+		app.logger.Error("failed to publish", "topic", topic, "error", err)
+	}
+}
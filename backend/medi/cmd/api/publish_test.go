@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/config"
+	"medi/internal/weather"
+)
+
+// capturePublisher records published messages for assertions
+type capturePublisher struct {
+	messages []capturedMessage
+	closed   bool
+}
+
+type capturedMessage struct {
+	topic    string
+	payload  []byte
+	retained bool
+}
+
+func (p *capturePublisher) Publish(topic string, payload []byte, retained bool) error {
+	p.messages = append(p.messages, capturedMessage{topic: topic, payload: payload, retained: retained})
+	return nil
+}
+
+func (p *capturePublisher) Close() {
+	p.closed = true
+}
+
+func (p *capturePublisher) countByTopic() map[string]int {
+	counts := map[string]int{}
+	for _, message := range p.messages {
+		counts[message.topic]++
+	}
+	return counts
+}
+
+func newPublishTestApp(t *testing.T) (*App, *capturePublisher) {
+	t.Helper()
+
+	clock := func() time.Time { return time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC) }
+	avalancheSvc := &mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(avalancheSvc, weatherSvc, clock)
+
+	publisher := &capturePublisher{}
+	app.publisher = publisher
+	app.cfg = config.NewHolder(&config.Config{
+		App: config.AppConfig{
+			PriorityLocations: []config.PriorityLocation{
+				{Name: "aspen", Latitude: 39.11539, Longitude: -107.65840},
+			},
+		},
+		MQTT: config.MQTTConfig{TopicPrefix: "medisnow"},
+	})
+	return app, publisher
+}
+
+func TestWeatherRefreshPublishesPriorityLocation(t *testing.T) {
+	app, publisher := newPublishTestApp(t)
+
+	path := "/weather/forecast?latitude=39.11539&longitude=-107.65840"
+
+	// The miss refreshes the cache and publishes; the following hit must not
+	doRequest(t, app, path)
+	doRequest(t, app, path)
+
+	counts := publisher.countByTopic()
+	for _, topic := range []string{"medisnow/aspen/current", "medisnow/aspen/daily"} {
+		if counts[topic] != 1 {
+			t.Errorf("topic %s published %d times, want 1", topic, counts[topic])
+		}
+	}
+	if len(publisher.messages) != 2 {
+		t.Errorf("published %d messages, want 2", len(publisher.messages))
+	}
+	for _, message := range publisher.messages {
+		if !message.retained {
+			t.Errorf("message on %s not retained", message.topic)
+		}
+		if len(message.payload) == 0 {
+			t.Errorf("message on %s has empty payload", message.topic)
+		}
+	}
+}
+
+func TestAvalancheRefreshPublishesPriorityLocation(t *testing.T) {
+	app, publisher := newPublishTestApp(t)
+
+	doRequest(t, app, "/avalanche/forecast?latitude=39.11539&longitude=-107.65840")
+
+	counts := publisher.countByTopic()
+	if counts["medisnow/aspen/avalanche"] != 1 {
+		t.Errorf("avalanche topic published %d times, want 1", counts["medisnow/aspen/avalanche"])
+	}
+	if !publisher.messages[0].retained {
+		t.Error("avalanche message not retained")
+	}
+}
+
+func TestRefreshForUnlistedLocationDoesNotPublish(t *testing.T) {
+	app, publisher := newPublishTestApp(t)
+
+	doRequest(t, app, "/weather/forecast?latitude=40.5&longitude=-111.6")
+
+	if len(publisher.messages) != 0 {
+		t.Errorf("published %d messages for an unlisted location, want 0", len(publisher.messages))
+	}
+}
+
+func TestAppCloseClosesPublisher(t *testing.T) {
+	app, publisher := newPublishTestApp(t)
+
+	app.Close()
+	if !publisher.closed {
+		t.Error("App.Close did not close the publisher")
+	}
+}
+
+func TestWeatherRefreshPublishesPowderAlert(t *testing.T) {
+	app, publisher := newPublishTestApp(t)
+	weatherSvc := app.weatherService.(*mockWeatherService)
+	weatherSvc.forecast.PowderAlerts = []weather.PowderAlert{{
+		Start:        time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC),
+		MedianInches: 9.5,
+	}}
+
+	doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+
+	var alertPayload []byte
+	for _, message := range publisher.messages {
+		if message.topic == "medisnow/aspen/alert" {
+			alertPayload = message.payload
+		}
+	}
+	if alertPayload == nil {
+		t.Fatalf("no alert message published; topics: %v", publisher.countByTopic())
+	}
+	text := string(alertPayload)
+	for _, want := range []string{"aspen", "9.5", "snowfall24hInches"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("alert payload %q missing %q", text, want)
+		}
+	}
+}
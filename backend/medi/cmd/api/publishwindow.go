@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"medi/internal/config"
+)
+
+// avalanchePublishRefreshInterval is how often a cached zone forecast is
+// re-fetched while its center's publish window is open, so users see the new
+// product within minutes of publication without request-path latency
+const avalanchePublishRefreshInterval = 15 * time.Minute
+
+// publishWindow is a daily time-of-day interval in minutes since midnight,
+// start inclusive and end exclusive
+type publishWindow struct {
+	start, end int
+}
+
+// parsePublishWindow parses a "HH:MM-HH:MM" window. A window may cross
+// midnight, e.g. "23:00-01:00".
+func parsePublishWindow(raw string) (publishWindow, error) {
+	from, to, ok := strings.Cut(raw, "-")
+	if !ok {
+		return publishWindow{}, fmt.Errorf("publish window %q must be HH:MM-HH:MM", raw)
+	}
+	start, err := parseClockMinutes(from)
+	if err != nil {
+		return publishWindow{}, fmt.Errorf("publish window %q: %w", raw, err)
+	}
+	end, err := parseClockMinutes(to)
+	if err != nil {
+		return publishWindow{}, fmt.Errorf("publish window %q: %w", raw, err)
+	}
+	if start == end {
+		return publishWindow{}, fmt.Errorf("publish window %q is empty", raw)
+	}
+	return publishWindow{start: start, end: end}, nil
+}
+
+// parseClockMinutes converts "HH:MM" to minutes since midnight
+func parseClockMinutes(raw string) (int, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", raw)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+// contains reports whether the time of day falls inside the window
+func (w publishWindow) contains(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return minutes >= w.start && minutes < w.end
+	}
+	// Window crosses midnight
+	return minutes >= w.start || minutes < w.end
+}
+
+// publishWindowFor resolves the publish window for an avalanche center from
+// the configured per-center map, falling back to the "*" default; ok is false
+// when no window applies. Windows are validated at startup, so a malformed
+// entry cannot appear here.
+func publishWindowFor(cfg *config.Config, centerId string) (publishWindow, bool) {
+	if cfg == nil {
+		return publishWindow{}, false
+	}
+	raw, ok := cfg.App.AvalanchePublishWindows[centerId]
+	if !ok {
+		raw, ok = cfg.App.AvalanchePublishWindows["*"]
+	}
+	if !ok {
+		return publishWindow{}, false
+	}
+	window, err := parsePublishWindow(raw)
+	if err != nil {
+		return publishWindow{}, false
+	}
+	return window, true
+}
+
+// validatePublishWindows rejects malformed window strings at startup instead
+// of silently disabling the publish-window refresh for that center
+func validatePublishWindows(windows map[string]string) error {
+	for centerId, raw := range windows {
+		if _, err := parsePublishWindow(raw); err != nil {
+			return fmt.Errorf("avalanche publish window for %q: %w", centerId, err)
+		}
+	}
+	return nil
+}
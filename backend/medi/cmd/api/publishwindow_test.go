@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/config"
+	"medi/internal/weather"
+)
+
+func TestParsePublishWindow(t *testing.T) {
+	cases := []struct {
+		raw        string
+		start, end int
+		wantErr    bool
+	}{
+		{raw: "06:00-08:00", start: 360, end: 480},
+		{raw: "16:30-17:30", start: 990, end: 1050},
+		{raw: "23:00-01:00", start: 1380, end: 60},
+		{raw: "6am-8am", wantErr: true},
+		{raw: "06:00", wantErr: true},
+		{raw: "06:00-06:00", wantErr: true},
+	}
+	for _, tc := range cases {
+		window, err := parsePublishWindow(tc.raw)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parsePublishWindow(%q) accepted a malformed window", tc.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePublishWindow(%q) returned error: %v", tc.raw, err)
+			continue
+		}
+		if window.start != tc.start || window.end != tc.end {
+			t.Errorf("parsePublishWindow(%q) = %+v, want start %d end %d", tc.raw, window, tc.start, tc.end)
+		}
+	}
+}
+
+func TestPublishWindowContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 15, hour, minute, 0, 0, time.UTC)
+	}
+
+	morning := publishWindow{start: 360, end: 480} // 06:00-08:00
+	if !morning.contains(at(6, 0)) || !morning.contains(at(7, 59)) {
+		t.Error("morning window excludes times inside it")
+	}
+	if morning.contains(at(8, 0)) || morning.contains(at(5, 59)) {
+		t.Error("morning window includes times outside it")
+	}
+
+	overnight := publishWindow{start: 1380, end: 60} // 23:00-01:00
+	if !overnight.contains(at(23, 30)) || !overnight.contains(at(0, 30)) {
+		t.Error("overnight window excludes times inside it")
+	}
+	if overnight.contains(at(12, 0)) {
+		t.Error("overnight window includes midday")
+	}
+}
+
+// newPublishWindowTestApp builds a test app whose prefetcher tracks one
+// location with a cached avalanche forecast for the given center
+func newPublishWindowTestApp(avalancheSvc *mockAvalancheService, clock func() time.Time, windows map[string]string) (*App, *prefetcher, string) {
+	app := newTestApp(avalancheSvc, &mockWeatherService{forecast: &weather.Forecast{}}, clock)
+	app.cfg = config.NewHolder(&config.Config{App: config.AppConfig{
+		AvalanchePublishWindows: windows,
+	}})
+	p := newPrefetcher(app, 50, 6*time.Hour)
+	app.prefetcher = p
+
+	p.noteRequest(39.11539, -107.65840)
+	key := forecastCacheKey(39.11539, -107.65840)
+	app.avalancheCache.Set(key, avalancheSvc.forecast)
+	return app, p, key
+}
+
+func TestPublishWindowRefreshCadence(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 6, 50, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	avalancheSvc := &mockAvalancheService{forecast: &avalanche.AvalancheForecast{
+		Center: avalanche.AvalancheCenter{Id: "CAIC"},
+	}}
+	app, p, key := newPublishWindowTestApp(avalancheSvc, clock, map[string]string{"*": "06:00-08:00"})
+
+	// Inside the window but the entry is younger than the refresh cadence
+	currentTime = currentTime.Add(5 * time.Minute)
+	p.sweep(context.Background())
+	if got := p.avalancheRuns.Load(); got != 0 {
+		t.Fatalf("sweep refreshed a %v-old entry, runs = %d", 5*time.Minute, got)
+	}
+
+	// At the cadence the entry is re-fetched and restamped
+	currentTime = currentTime.Add(10 * time.Minute)
+	p.sweep(context.Background())
+	if got := p.avalancheRuns.Load(); got != 1 {
+		t.Fatalf("sweep at the refresh cadence made %d refreshes, want 1", got)
+	}
+	if entry, _ := app.avalancheCache.Get(key); !entry.FetchedAt.Equal(currentTime) {
+		t.Errorf("entry FetchedAt = %v, want the refresh time %v", entry.FetchedAt, currentTime)
+	}
+
+	// The cadence restarts from the refresh, not from the original entry
+	currentTime = currentTime.Add(5 * time.Minute)
+	p.sweep(context.Background())
+	if got := p.avalancheRuns.Load(); got != 1 {
+		t.Fatalf("sweep inside the new cadence made %d total refreshes, want 1", got)
+	}
+	currentTime = currentTime.Add(10 * time.Minute)
+	p.sweep(context.Background())
+	if got := p.avalancheRuns.Load(); got != 2 {
+		t.Fatalf("second cadence interval made %d total refreshes, want 2", got)
+	}
+}
+
+func TestPublishWindowQuietOutsideWindow(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	avalancheSvc := &mockAvalancheService{forecast: &avalanche.AvalancheForecast{
+		Center: avalanche.AvalancheCenter{Id: "CAIC"},
+	}}
+	_, p, _ := newPublishWindowTestApp(avalancheSvc, clock, map[string]string{"*": "06:00-08:00"})
+
+	// Hours past the cadence, but the window is closed: the entry ages under
+	// its normal TTL instead of being proactively refreshed
+	currentTime = currentTime.Add(2 * time.Hour)
+	p.sweep(context.Background())
+	if got := p.avalancheRuns.Load(); got != 0 {
+		t.Errorf("sweep outside the publish window made %d refreshes, want 0", got)
+	}
+	if avalancheSvc.calls != 0 {
+		t.Errorf("avalanche service called %d times outside the window, want 0", avalancheSvc.calls)
+	}
+}
+
+func TestPublishWindowPerCenterOverride(t *testing.T) {
+	// An afternoon-publishing center gets its own window; 16:45 is inside it
+	// but outside the default morning window
+	currentTime := time.Date(2025, 1, 15, 16, 30, 0, 0, time.UTC)
+	clock := func() time.Time { return currentTime }
+
+	avalancheSvc := &mockAvalancheService{forecast: &avalanche.AvalancheForecast{
+		Center: avalanche.AvalancheCenter{Id: "BTAC"},
+	}}
+	_, p, _ := newPublishWindowTestApp(avalancheSvc, clock, map[string]string{
+		"*":    "06:00-08:00",
+		"BTAC": "16:00-18:00",
+	})
+
+	currentTime = currentTime.Add(15 * time.Minute)
+	p.sweep(context.Background())
+	if got := p.avalancheRuns.Load(); got != 1 {
+		t.Errorf("sweep in the center's own window made %d refreshes, want 1", got)
+	}
+}
+
+func TestValidatePublishWindows(t *testing.T) {
+	if err := validatePublishWindows(map[string]string{"*": "06:00-08:00", "BTAC": "16:00-18:00"}); err != nil {
+		t.Errorf("valid windows rejected: %v", err)
+	}
+	if err := validatePublishWindows(map[string]string{"CAIC": "dawn"}); err == nil {
+		t.Error("malformed window accepted")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProviderQuota reports one upstream's daily call accounting against its
+// configured soft limit.
+type ProviderQuota struct {
+	Provider string `json:"provider"`
+	// Used is how many calls the provider has made today (UTC)
+	Used int64 `json:"used"`
+	// Limit is the configured soft daily limit, zero when unlimited
+	Limit int64 `json:"limit"`
+	// PercentUsed is Used against Limit, rounded; zero without a limit
+	PercentUsed int `json:"percentUsed"`
+}
+
+// QuotasResponse reports today's per-provider quota accounting
+type QuotasResponse struct {
+	// Day is the UTC date the counts cover, YYYY-MM-DD
+	Day string `json:"day"`
+	// Providers lists every upstream with calls today or a configured
+	// limit, ordered by name; empty in mock mode
+	Providers []ProviderQuota `json:"providers"`
+}
+
+// handleGetQuotas godoc
+// @Summary Provider quota accounting
+// @Description Report today's per-provider call counts against the configured soft daily limits, UTC day boundary
+// @Tags admin
+// @Produce json
+// @Success 200 {object} QuotasResponse
+// @Router /admin/quotas [get]
+func (app *App) handleGetQuotas(c *gin.Context) {
+	response := QuotasResponse{Providers: []ProviderQuota{}}
+
+	if app.providerQuota != nil {
+		day, usage := app.providerQuota.Snapshot()
+		response.Day = day
+		for _, u := range usage {
+			response.Providers = append(response.Providers, ProviderQuota{
+				Provider:    u.Provider,
+				Used:        u.Used,
+				Limit:       u.Limit,
+				PercentUsed: u.PercentUsed,
+			})
+		}
+	}
+	sort.Slice(response.Providers, func(i, j int) bool {
+		return response.Providers[i].Provider < response.Providers[j].Provider
+	})
+
+	c.JSON(http.StatusOK, response)
+}
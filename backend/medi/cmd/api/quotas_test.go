@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"medi/internal/providers/quota"
+)
+
+func TestQuotasEndpointWithoutTracker(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	w := doRequest(t, app, "/admin/quotas")
+	var resp QuotasResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Providers == nil || len(resp.Providers) != 0 {
+		t.Errorf("providers = %v, want an empty list", resp.Providers)
+	}
+}
+
+func TestQuotasEndpointReportsDailyUsage(t *testing.T) {
+	tracker := quota.NewTracker(nil, map[string]int{"openstreetmap": 1000, "nws": 5000}, false, nil)
+	tracker.SetClock(func() time.Time { return time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC) })
+	for i := 0; i < 250; i++ {
+		if err := tracker.Record("openstreetmap"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.providerQuota = tracker
+
+	w := doRequest(t, app, "/admin/quotas")
+	var resp QuotasResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Day != "2025-03-10" {
+		t.Errorf("day = %q, want 2025-03-10", resp.Day)
+	}
+	// Both configured providers appear, ordered by name, even the unused one
+	if len(resp.Providers) != 2 {
+		t.Fatalf("got %d providers, want 2", len(resp.Providers))
+	}
+	if got := resp.Providers[0]; got.Provider != "nws" || got.Used != 0 || got.Limit != 5000 {
+		t.Errorf("providers[0] = %+v, want untouched nws at 0 of 5000", got)
+	}
+	if got := resp.Providers[1]; got.Provider != "openstreetmap" || got.Used != 250 || got.PercentUsed != 25 {
+		t.Errorf("providers[1] = %+v, want openstreetmap at 250 used, 25%%", got)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyCheck is one named startup gate the readiness endpoint waits on
+type readyCheck struct {
+	name  string
+	ready func() bool
+}
+
+// ReadyResponse reports whether the service is ready for traffic. Unlike
+// /health, which describes upstream call history on a running service, this
+// covers startup: orchestrators should hold traffic until it reads ready.
+type ReadyResponse struct {
+	Status string `json:"status"`
+	// Waiting lists the startup gates still in progress, empty once ready
+	Waiting []string `json:"waiting,omitempty"`
+}
+
+// handleGetReady godoc
+// @Summary Service readiness
+// @Description Report whether startup gates (timezone finder load, forecast warmup) have completed; 503 until they have
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadyResponse
+// @Failure 503 {object} ReadyResponse
+// @Router /ready [get]
+func (app *App) handleGetReady(c *gin.Context) {
+	response := ReadyResponse{Status: "ready"}
+	for _, check := range app.readyChecks {
+		if !check.ready() {
+			response.Waiting = append(response.Waiting, check.name)
+		}
+	}
+	if len(response.Waiting) > 0 {
+		response.Status = "loading"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func doReadyRequest(t *testing.T, app *App) (*httptest.ResponseRecorder, ReadyResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+
+	var resp ReadyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+func TestReadyFlipsOnceGatesPass(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	var loaded atomic.Bool
+	app.readyChecks = []readyCheck{{name: "timezone", ready: loaded.Load}}
+
+	w, resp := doReadyRequest(t, app)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d while loading, want 503", w.Code)
+	}
+	if resp.Status != "loading" || len(resp.Waiting) != 1 || resp.Waiting[0] != "timezone" {
+		t.Errorf("response = %+v, want loading with the timezone gate waiting", resp)
+	}
+
+	loaded.Store(true)
+	w, resp = doReadyRequest(t, app)
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d once loaded, want 200", w.Code)
+	}
+	if resp.Status != "ready" || len(resp.Waiting) != 0 {
+		t.Errorf("response = %+v, want ready with no gates waiting", resp)
+	}
+}
+
+func TestReadyWarmupGateFollowsStartupSweep(t *testing.T) {
+	_, p := newPrefetchTestApp(&mockWeatherService{forecast: nil}, time.Now, 50)
+
+	if p.warmedUp() {
+		t.Fatal("warmedUp before run started")
+	}
+	go p.run()
+	defer p.Stop()
+
+	deadline := time.After(time.Second)
+	for !p.warmedUp() {
+		select {
+		case <-deadline:
+			t.Fatal("warmup gate never opened after the startup sweep")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
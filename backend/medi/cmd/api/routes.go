@@ -1,26 +1,44 @@
 package main
 
-import (
-	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-)
-
-// registerRoutes sets up all API endpoints
+// registerRoutes sets up the backend's API endpoints; the bootstrap package
+// registers the standard health and version endpoints before calling this
 func (app *App) registerRoutes() {
-	// Health check endpoint
-	app.router.GET("/ping", app.handlePing)
+	// Metrics and health endpoints
+	app.router.GET("/metrics", app.handleGetMetrics)
+	app.router.GET("/health", app.handleGetHealth)
+	app.router.GET("/ready", app.handleGetReady)
 
 	// Location endpoints
 	app.router.GET("/location/forecast-point", app.handleGetForecastPoint)
+	app.router.GET("/location/bundle", app.handleGetLocationBundle)
+
+	// Weather endpoints
+	app.router.GET("/weather/forecast", app.handleGetWeatherForecast)
+	app.router.GET("/weather/models", app.handleGetWeatherModels)
+	app.router.GET("/weather/forecast/hourly", app.handleGetHourlySeries)
+	app.router.GET("/weather/forecast/wait", app.handleWaitForecastUpdate)
+	app.router.GET("/weather/weekly", app.handleGetWeeklySummary)
+	app.router.GET("/weather/temperature-profile", app.handleGetTemperatureProfile)
+	app.router.GET("/weather/discussion", app.handleGetForecastDiscussion)
+	app.router.GET("/weather/snow-report", app.handleGetSnowReport)
+
+	// Avalanche endpoints
+	app.router.GET("/avalanche/forecast", app.handleGetAvalancheForecast)
+	app.router.GET("/avalanche/incidents", app.handleGetAvalancheIncidents)
+	app.router.GET("/avalanche/zones", app.handleGetAvalancheZones)
+	app.router.GET("/avalanche/centers", app.handleGetAvalancheCenters)
+	app.router.GET("/avalanche/problem-types", app.handleGetProblemTypes)
+	app.router.GET("/avalanche/summary", app.handleGetAvalancheSummary)
+	app.router.POST("/avalanche/route-check", app.idempotencyGuard, app.handleCheckRoute)
+	app.router.GET("/avalanche/zone/:centerId/:zoneId/weather", app.handleGetZoneWeather)
+
+	// Travel endpoints
+	app.router.GET("/travel/passes", app.handleGetTravelPasses)
+
+	// Admin endpoints
+	app.router.POST("/admin/bias", app.idempotencyGuard, app.handleSetBiasCorrection)
+	app.router.GET("/admin/quotas", app.handleGetQuotas)
 
-	// Swagger documentation
-	app.router.GET("/swagger/*any", func(c *gin.Context) {
-		path := c.Param("any")
-		if path == "/" {
-			c.Redirect(301, "/swagger/index.html")
-			return
-		}
-		ginSwagger.WrapHandler(swaggerFiles.Handler)(c)
-	})
+	// API documentation (spec and interactive UI), optional in production
+	app.registerDocsRoutes()
 }
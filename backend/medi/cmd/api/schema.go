@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"medi/internal/avalanche"
+	"medi/internal/schema"
+	"medi/internal/types"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The committed documents under schema/ are regenerated alongside the
+// swagger spec; the drift test fails when a struct change leaves them behind
+//go:generate go run . -write-schemas ../../schema
+
+// responseSchemaDocuments builds the standalone JSON Schema documents for the
+// response DTOs, derived from the Go structs by reflection. The enum tables
+// pin the value sets a validating client may rely on: danger levels,
+// likelihoods, problem types, WMO weather codes, and snow density classes.
+func responseSchemaDocuments() (map[string]map[string]any, error) {
+	enums := map[reflect.Type][]any{
+		reflect.TypeOf(avalanche.DangerLevel(0)): intRange(int(avalanche.DangerNone), int(avalanche.DangerExtreme)),
+		reflect.TypeOf(avalanche.Likelihood(0)):  intRange(0, int(avalanche.LikelihoodAlmostCertain)),
+		reflect.TypeOf(avalanche.ProblemType(0)): intRange(int(avalanche.ProblemUnknown), int(avalanche.ProblemGlide)),
+	}
+
+	weatherCodes := make([]any, 0)
+	for _, code := range types.WeatherCodes() {
+		weatherCodes = append(weatherCodes, code)
+	}
+	propertyEnums := map[string][]any{
+		"Weather.code": weatherCodes,
+		"HourlyForecast.SnowDensity": {
+			weather.SnowDensityVeryLight,
+			weather.SnowDensityLight,
+			weather.SnowDensityModerate,
+			weather.SnowDensityDense,
+			weather.SnowDensityWet,
+		},
+	}
+
+	roots := []struct {
+		name  string
+		title string
+		value any
+	}{
+		{"forecast", "Weather forecast response", WeatherForecastResponse{}},
+		{"avalanche", "Avalanche forecast response", AvalancheForecastResponse{}},
+		{"conditions", "Location conditions bundle response", LocationBundleResponse{}},
+	}
+
+	documents := map[string]map[string]any{}
+	for _, root := range roots {
+		doc, err := schema.Generate(root.value, schema.Options{
+			Title:         root.title,
+			Enums:         enums,
+			PropertyEnums: propertyEnums,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s schema: %w", root.name, err)
+		}
+		documents[root.name] = doc
+	}
+	return documents, nil
+}
+
+// intRange builds the inclusive integer enum list for a normalized enum type
+func intRange(lowest, highest int) []any {
+	values := make([]any, 0, highest-lowest+1)
+	for v := lowest; v <= highest; v++ {
+		values = append(values, v)
+	}
+	return values
+}
+
+// marshalSchema renders one document the way the committed files store it
+func marshalSchema(doc map[string]any) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// writeResponseSchemas regenerates the committed schema documents, invoked by
+// the -write-schemas flag from go generate
+func writeResponseSchemas(dir string) error {
+	documents, err := responseSchemaDocuments()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for name, doc := range documents {
+		data, err := marshalSchema(doc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s schema: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleGetSchema serves the response schemas at /schema/<name>.json for
+// clients that validate payloads outside OpenAPI
+func (app *App) handleGetSchema(c *gin.Context) {
+	name, ok := strings.CutSuffix(c.Param("name"), ".json")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown schema"})
+		return
+	}
+	documents, err := responseSchemaDocuments()
+	if err != nil {
+		app.logger.Error("failed to build response schemas", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build response schemas"})
+		return
+	}
+	doc, ok := documents[name]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown schema"})
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/cache"
+	"medi/internal/config"
+	"medi/internal/providers/nac"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/schema"
+	"medi/internal/testsupport"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// committedSchema loads one of the schema documents published under /schema
+func committedSchema(t *testing.T, name string) map[string]any {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "schema", name+".json"))
+	if err != nil {
+		t.Fatalf("failed to read committed schema: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("committed %s schema is not valid JSON: %v", name, err)
+	}
+	return doc
+}
+
+func TestCommittedSchemasMatchGenerated(t *testing.T) {
+	documents, err := responseSchemaDocuments()
+	if err != nil {
+		t.Fatalf("failed to build response schemas: %v", err)
+	}
+	for name, doc := range documents {
+		want, err := marshalSchema(doc)
+		if err != nil {
+			t.Fatalf("failed to marshal %s schema: %v", name, err)
+		}
+		got, err := os.ReadFile(filepath.Join("..", "..", "schema", name+".json"))
+		if err != nil {
+			t.Fatalf("missing committed schema for %s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("schema/%s.json is stale; regenerate with go generate ./cmd/api", name)
+		}
+	}
+}
+
+func TestSchemaEndpointServesDocuments(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	w := doRequest(t, app, "/schema/forecast.json")
+	var doc map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("served schema is not valid JSON: %v", err)
+	}
+	if doc["$schema"] != schema.Draft {
+		t.Errorf("$schema = %v, want %v", doc["$schema"], schema.Draft)
+	}
+
+	for _, path := range []string{"/schema/nope.json", "/schema/forecast"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		app.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s returned status %d, want 404", path, rec.Code)
+		}
+	}
+}
+
+// fixtureForecastProvider feeds the committed Open-Meteo snapshot through the
+// real weather service, so the validated response carries every populated
+// field a live one would
+type fixtureForecastProvider struct{ t *testing.T }
+
+func (p *fixtureForecastProvider) GetForecast(_ context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error) {
+	return testsupport.NewForecastFixture(p.t), nil
+}
+
+type fixtureMapLayerProvider struct{ t *testing.T }
+
+func (p *fixtureMapLayerProvider) GetMapLayer() (*nac.MapLayerResponse, error) {
+	return testsupport.NACMapLayerFixture(p.t), nil
+}
+
+type fixtureNacForecastProvider struct{ t *testing.T }
+
+func (p *fixtureNacForecastProvider) GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error) {
+	return testsupport.NACForecastFixture(p.t), nil
+}
+
+// newContractTestApp builds an App around real domain services fed from
+// committed fixtures
+func newContractTestApp(weatherSvc weather.Service, avalancheSvc avalanche.Service) *App {
+	gin.SetMode(gin.TestMode)
+
+	app := &App{
+		router:           gin.New(),
+		logger:           slog.Default(),
+		locationService:  &mockLocationService{},
+		weatherService:   weatherSvc,
+		avalancheService: avalancheSvc,
+		weatherCache:     cache.New[*weather.Forecast](weatherCacheTTL),
+		avalancheCache:   cache.New[*avalanche.AvalancheForecast](avalancheCacheTTL),
+		now:              time.Now,
+	}
+	app.registerRoutes()
+	return app
+}
+
+func validateResponse(t *testing.T, schemaName string, body []byte) {
+	t.Helper()
+
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if err := schema.Validate(committedSchema(t, schemaName), payload); err != nil {
+		t.Errorf("response violates the %s schema: %v", schemaName, err)
+	}
+}
+
+func TestForecastResponseMatchesSchema(t *testing.T) {
+	cfg := config.NewHolder(&config.Config{App: config.AppConfig{ForecastDays: 3, PowderAlertInches: 6}})
+	weatherSvc := weather.NewWeatherServiceWithProvider(
+		&fixtureForecastProvider{t}, nil, nil, nil, nil, nil,
+		&mockTimezoneService{name: "America/Denver"}, cfg, nil,
+	)
+	app := newContractTestApp(weatherSvc, &mockAvalancheService{forecast: &avalanche.AvalancheForecast{}})
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	validateResponse(t, "forecast", w.Body.Bytes())
+}
+
+func TestAvalancheResponseMatchesSchema(t *testing.T) {
+	avalancheSvc := avalanche.NewAvalancheServiceWithProviders(
+		slog.Default(), &fixtureMapLayerProvider{t}, &fixtureNacForecastProvider{t}, nil,
+	)
+	app := newContractTestApp(&mockWeatherService{forecast: &weather.Forecast{}}, avalancheSvc)
+
+	w := doRequest(t, app, "/avalanche/forecast?latitude=39.11539&longitude=-107.65840")
+	validateResponse(t, "avalanche", w.Body.Bytes())
+}
+
+func TestBundleResponseMatchesSchema(t *testing.T) {
+	app := newBundleTestApp(
+		&mockAvalancheService{forecast: bundleAvalancheForecast()},
+		&mockTravelService{passes: bundlePassConditions()},
+		&mockTimezoneService{name: "America/Denver"},
+		&mockLocationService{},
+	)
+
+	w := doRequest(t, app, bundlePath)
+	validateResponse(t, "conditions", w.Body.Bytes())
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"medi/internal/location"
+	"medi/internal/providers/quota"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSnowReportInput defines the query parameters for the snow report endpoint
+type GetSnowReportInput struct {
+	CoordinateInput
+}
+
+// SnowReportFigureDTO is one figure of the report with its data source and
+// the timestamp that source was valid, in the forecast timezone
+type SnowReportFigureDTO struct {
+	Inches float64        `json:"inches"`
+	Source string         `json:"source"`
+	AsOf   LocalTimestamp `json:"asOf"`
+}
+
+// SnowReportResponse carries the resort-style snow report; figures without a
+// data source behind them are left out, as is their segment of the report
+// string
+type SnowReportResponse struct {
+	Past24h     *SnowReportFigureDTO `json:"past24h,omitempty"`
+	Past48h     *SnowReportFigureDTO `json:"past48h,omitempty"`
+	Outlook7Day *SnowReportFigureDTO `json:"outlook7day,omitempty"`
+	Base        *SnowReportFigureDTO `json:"base,omitempty"`
+	Season      *SnowReportFigureDTO `json:"season,omitempty"`
+	// Report is the preformatted string, e.g. "24h: 7in, 48h: 11in,
+	// 7-day: 23in, Base: 45in, Season: 210in"
+	Report string `json:"report"`
+}
+
+// handleGetSnowReport godoc
+// @Summary Get the snow report
+// @Description Retrieve resort-style snow report figures — recent snowfall, the week's outlook, base depth, and season total — each with its data source, plus a preformatted report string
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Success 200 {object} SnowReportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /weather/snow-report [get]
+func (app *App) handleGetSnowReport(c *gin.Context) {
+	var input GetSnowReportInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	forecastPoint, err := app.locationService.GetForecastPoint(input.Latitude, input.Longitude)
+	if err != nil {
+		if errors.Is(err, location.ErrInvalidLatitude) || errors.Is(err, location.ErrInvalidLongitude) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		app.logger.Error("failed to get forecast point",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get snow report"})
+		return
+	}
+
+	report, err := app.weatherService.GetSnowReport(c.Request.Context(), *forecastPoint)
+	if err != nil {
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "provider daily quota exceeded, retry later"})
+			return
+		}
+		app.logger.Error("failed to get snow report",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get snow report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newSnowReportResponse(report))
+}
+
+func newSnowReportResponse(report *weather.SnowReport) SnowReportResponse {
+	loc, err := time.LoadLocation(report.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	figure := func(f *weather.SnowReportFigure) *SnowReportFigureDTO {
+		if f == nil {
+			return nil
+		}
+		return &SnowReportFigureDTO{
+			Inches: roundTo(f.Inches, 1),
+			Source: f.Source,
+			AsOf:   newLocalTimestamp(f.AsOf, loc),
+		}
+	}
+
+	return SnowReportResponse{
+		Past24h:     figure(report.Past24h),
+		Past48h:     figure(report.Past48h),
+		Outlook7Day: figure(report.Outlook7Day),
+		Base:        figure(report.Base),
+		Season:      figure(report.Season),
+		Report:      report.Report,
+	}
+}
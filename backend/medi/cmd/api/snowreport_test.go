@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"medi/internal/weather"
+)
+
+func TestSnowReportEndpoint(t *testing.T) {
+	asOf := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	svc := &mockWeatherService{snowReport: &weather.SnowReport{
+		Past24h:  &weather.SnowReportFigure{Inches: 7, Source: "archive", AsOf: asOf},
+		Base:     &weather.SnowReportFigure{Inches: 45.04, Source: "station:Fremont Pass", AsOf: asOf},
+		Timezone: "America/Denver",
+		Report:   "24h: 7in, Base: 45in",
+	}}
+	app := newTestApp(&mockAvalancheService{}, svc, time.Now)
+
+	w := doRequest(t, app, "/weather/snow-report?latitude=39.11539&longitude=-107.65840")
+	var resp SnowReportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Report != "24h: 7in, Base: 45in" {
+		t.Errorf("report = %q, want the preformatted string", resp.Report)
+	}
+	if resp.Base == nil || resp.Base.Inches != 45 || resp.Base.Source != "station:Fremont Pass" {
+		t.Errorf("base = %+v, want the rounded station figure", resp.Base)
+	}
+	// The as-of timestamps render in the forecast timezone
+	if resp.Past24h == nil || resp.Past24h.AsOf.RFC3339 != "2025-01-15T01:00:00-07:00" {
+		t.Errorf("past 24h = %+v, want the as-of time in America/Denver", resp.Past24h)
+	}
+	if resp.Outlook7Day != nil || resp.Season != nil {
+		t.Errorf("absent figures serialized: %+v / %+v", resp.Outlook7Day, resp.Season)
+	}
+}
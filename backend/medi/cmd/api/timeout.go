@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRequestTimeout bounds handlers when no timeout is configured
+const defaultRequestTimeout = 15 * time.Second
+
+// timeoutExemptPrefixes lists path prefixes that are allowed to run without a
+// deadline. Long-lived endpoints (streaming, websockets, report generation)
+// belong here rather than in RouteTimeoutSeconds with a huge value.
+var timeoutExemptPrefixes = []string{
+	"/swagger",
+	// The long-poll endpoint bounds itself with its own configured timeout
+	"/weather/forecast/wait",
+}
+
+// requestTimeout resolves the timeout for a request path: zero for exempt
+// prefixes, then the per-route override, then the global default
+func (app *App) requestTimeout(path string) time.Duration {
+	for _, prefix := range timeoutExemptPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return 0
+		}
+	}
+
+	if cfg := app.config(); cfg != nil {
+		if seconds, ok := cfg.Server.RouteTimeoutSeconds[path]; ok {
+			return time.Duration(seconds) * time.Second
+		}
+		if cfg.Server.RequestTimeoutSeconds > 0 {
+			return time.Duration(cfg.Server.RequestTimeoutSeconds) * time.Second
+		}
+	}
+
+	return defaultRequestTimeout
+}
+
+// handler wraps the router with the request timeout handler; Run serves this
+// instead of the bare router
+func (app *App) handler() http.Handler {
+	return newTimeoutHandler(app.router, app.requestTimeout)
+}
+
+// newTimeoutHandler bounds each request by the duration timeoutFor returns
+// for its path. The request context is cancelled at the deadline so in-flight
+// provider calls abort, and the client receives a 504 with the structured
+// error body. A zero duration disables the timeout for that path.
+func newTimeoutHandler(next http.Handler, timeoutFor func(path string) time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout := timeoutFor(r.URL.Path)
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		tw := &timeoutWriter{w: w, header: make(http.Header)}
+		done := make(chan struct{})
+		panicked := make(chan any, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+					return
+				}
+				close(done)
+			}()
+			next.ServeHTTP(tw, r.WithContext(ctx))
+		}()
+
+		select {
+		case <-done:
+			tw.flush()
+		case p := <-panicked:
+			panic(p)
+		case <-ctx.Done():
+			tw.timeout()
+		}
+	})
+}
+
+// timeoutWriter buffers the handler's response so that nothing reaches the
+// client until the handler either finishes (flush) or misses its deadline
+// (timeout). The mutex makes the two outcomes mutually exclusive: a handler
+// that completes just after the deadline has its buffered writes discarded
+// instead of corrupting the 504 already sent.
+type timeoutWriter struct {
+	w http.ResponseWriter
+
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	code     int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.code != 0 {
+		return
+	}
+	tw.code = code
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return len(b), nil
+	}
+	return tw.body.Write(b)
+}
+
+// flush copies the buffered response to the real writer after the handler
+// completed within its deadline
+func (tw *timeoutWriter) flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+
+	dst := tw.w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+	if tw.code != 0 {
+		tw.w.WriteHeader(tw.code)
+	}
+	_, _ = tw.w.Write(tw.body.Bytes())
+}
+
+// timeout writes the 504 response and marks the writer so late handler
+// writes are dropped
+func (tw *timeoutWriter) timeout() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	tw.timedOut = true
+
+	tw.w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	tw.w.WriteHeader(http.StatusGatewayTimeout)
+	_, _ = fmt.Fprint(tw.w, `{"error":"request timed out"}`)
+}
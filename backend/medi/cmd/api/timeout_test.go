@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/types"
+	"medi/internal/weather"
+)
+
+// slowWeatherService blocks until the request context is cancelled and
+// records that the cancellation reached it
+type slowWeatherService struct {
+	delay     time.Duration
+	forecast  *weather.Forecast
+	cancelled chan struct{}
+}
+
+func (m *slowWeatherService) GetForecast(ctx context.Context, point types.ForecastPoint, profile string) (*weather.Forecast, error) {
+	select {
+	case <-ctx.Done():
+		close(m.cancelled)
+		return nil, ctx.Err()
+	case <-time.After(m.delay):
+		return m.forecast, nil
+	}
+}
+
+func (m *slowWeatherService) GetForecastDiscussion(point types.ForecastPoint) (*weather.ForecastDiscussion, error) {
+	return &weather.ForecastDiscussion{}, nil
+}
+
+func (m *slowWeatherService) GetSnowReport(ctx context.Context, point types.ForecastPoint) (*weather.SnowReport, error) {
+	return &weather.SnowReport{}, nil
+}
+
+func (m *slowWeatherService) SetBiasCorrection(latitude, longitude float64, model string, correction weather.BiasCorrection) error {
+	return nil
+}
+
+func (m *slowWeatherService) GetProbabilisticSnowfall(ctx context.Context, point types.ForecastPoint) (*weather.ProbabilisticSnowfall, error) {
+	return nil, weather.ErrEnsembleUnavailable
+}
+
+// timeoutAfter builds a timeoutFor func returning a fixed duration for
+// every path, keeping the tests well under the second-granularity config
+func timeoutAfter(d time.Duration) func(string) time.Duration {
+	return func(string) time.Duration { return d }
+}
+
+func TestTimeoutHandlerCancelsSlowProvider(t *testing.T) {
+	slow := &slowWeatherService{delay: 5 * time.Second, cancelled: make(chan struct{})}
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+	app.weatherService = slow
+
+	handler := newTimeoutHandler(app.router, timeoutAfter(50*time.Millisecond))
+	req := httptest.NewRequest(http.MethodGet, "/weather/forecast?latitude=39.11539&longitude=-107.65840", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusGatewayTimeout)
+	}
+	if !strings.Contains(w.Body.String(), "request timed out") {
+		t.Errorf("body %q missing timeout error", w.Body.String())
+	}
+
+	select {
+	case <-slow.cancelled:
+	case <-time.After(time.Second):
+		t.Error("context cancellation never reached the provider")
+	}
+}
+
+func TestTimeoutHandlerPassesThroughFastHandlers(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}, time.Now)
+
+	handler := newTimeoutHandler(app.router, timeoutAfter(time.Second))
+	req := httptest.NewRequest(http.MethodGet, "/weather/forecast?latitude=39.11539&longitude=-107.65840", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "America/Denver") {
+		t.Errorf("body %q missing forecast payload", w.Body.String())
+	}
+}
+
+func TestTimeoutHandlerDiscardsLateWrites(t *testing.T) {
+	wrote := make(chan struct{})
+	late := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Deliberately ignore the context to finish after the deadline
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("late body"))
+		close(wrote)
+	})
+
+	handler := newTimeoutHandler(late, timeoutAfter(20*time.Millisecond))
+	req := httptest.NewRequest(http.MethodGet, "/anything", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// Wait for the straggling handler so its writes happened before we assert
+	<-wrote
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusGatewayTimeout)
+	}
+	if strings.Contains(w.Body.String(), "late body") {
+		t.Errorf("late handler write reached the client: %q", w.Body.String())
+	}
+}
+
+func TestRequestTimeoutResolution(t *testing.T) {
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{}, time.Now)
+
+	if got := app.requestTimeout("/weather/forecast"); got != defaultRequestTimeout {
+		t.Errorf("nil config timeout = %v, want default %v", got, defaultRequestTimeout)
+	}
+	if got := app.requestTimeout("/swagger/index.html"); got != 0 {
+		t.Errorf("exempt prefix timeout = %v, want 0", got)
+	}
+
+	app.cfg = config.NewHolder(&config.Config{
+		Server: config.ServerConfig{
+			RequestTimeoutSeconds: 30,
+			RouteTimeoutSeconds:   map[string]int{"/weather/forecast": 45},
+		},
+	})
+	if got := app.requestTimeout("/weather/forecast"); got != 45*time.Second {
+		t.Errorf("route override timeout = %v, want 45s", got)
+	}
+	if got := app.requestTimeout("/avalanche/forecast"); got != 30*time.Second {
+		t.Errorf("global timeout = %v, want 30s", got)
+	}
+}
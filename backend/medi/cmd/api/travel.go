@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTravelPassesInput defines the query parameters for the travel passes endpoint
+type GetTravelPassesInput struct {
+	CoordinateInput
+	RadiusKm float64 `form:"radiusKm"` // Search radius in kilometers, defaults to 75
+}
+
+// defaultPassRadiusKm is used when the radiusKm parameter is omitted
+const defaultPassRadiusKm = 75.0
+
+// handleGetTravelPasses godoc
+// @Summary Get nearby pass conditions
+// @Description Retrieve road and pass condition segments within a radius of a given latitude and longitude, sorted by distance
+// @Tags travel
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param radiusKm query number false "Search radius in kilometers" example(75)
+// @Success 200 {object} travel.PassConditions
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /travel/passes [get]
+func (app *App) handleGetTravelPasses(c *gin.Context) {
+	var input GetTravelPassesInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	radiusKm := input.RadiusKm
+	if radiusKm == 0 {
+		radiusKm = defaultPassRadiusKm
+	}
+	if radiusKm < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "radiusKm must be positive"})
+		return
+	}
+
+	passes, err := app.travelService.GetNearbyPasses(input.Latitude, input.Longitude, radiusKm)
+	if err != nil {
+		app.logger.Error("failed to get pass conditions",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get pass conditions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, passes)
+}
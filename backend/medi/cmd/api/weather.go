@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"medi/internal/cache"
+	"medi/internal/location"
+	"medi/internal/preferences"
+	"medi/internal/providers/quota"
+	"medi/internal/timing"
+	"medi/internal/types"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWeatherForecastInput defines the query parameters for the weather forecast endpoint
+type GetWeatherForecastInput struct {
+	CoordinateInput
+	// IncludeCumulative adds the cumulative precipitation curves to the
+	// response; off by default to limit payload
+	IncludeCumulative bool `form:"includeCumulative"`
+	// IncludeEnsemble attaches the probabilistic snowfall block, which costs
+	// a second upstream fetch against the ensemble API; off by default
+	IncludeEnsemble bool `form:"includeEnsemble"`
+	// ElevationMeters overrides the elevation provider with a caller-supplied
+	// value, for points where the caller knows better than the DEM
+	ElevationMeters *float64 `form:"elevationMeters"`
+	// Name optionally labels an overridden point (e.g. a couloir name)
+	Name string `form:"name"`
+	// DebugTiming adds the per-provider timings block to the response; only
+	// honored outside release mode
+	DebugTiming bool `form:"debugTiming"`
+	// ModelFormat selects how ModelValues serialize: the default map keyed
+	// by model name, or "array" for a list of {model, value} objects
+	ModelFormat string `form:"modelFormat" binding:"omitempty,oneof=map array"`
+	// Profile selects a configured thresholds profile for the snow-focused
+	// derived blocks (powder alerts, first tracks, weekly scores)
+	Profile string `form:"profile"`
+	// Models restricts the response to an explicit comma-separated model
+	// set, taking precedence over the default-visibility filter
+	Models string `form:"models"`
+	// AllModels includes the models hidden behind "more models"; without it
+	// only the default-visible set is returned
+	AllModels bool `form:"allModels"`
+	// Units prunes the response to one unit system's figures; both systems
+	// are included when neither the request nor the config names one
+	Units string `form:"units"`
+}
+
+// elevationOverride builds the location override from the input, nil when no
+// override was requested
+func (input GetWeatherForecastInput) elevationOverride() *location.ElevationOverride {
+	if input.ElevationMeters == nil {
+		return nil
+	}
+	return &location.ElevationOverride{
+		ElevationMeters: *input.ElevationMeters,
+		Name:            input.Name,
+	}
+}
+
+// WeatherForecastResponse wraps a forecast with freshness metadata
+type WeatherForecastResponse struct {
+	Freshness Freshness    `json:"freshness"`
+	Forecast  *ForecastDTO `json:"forecast"`
+	// Timings is the debug latency breakdown, present only when the request
+	// asked for it with debugTiming=true outside release mode
+	Timings *timing.Report `json:"timings,omitempty"`
+}
+
+// debugTimingAllowed gates the timings block to non-production deployments;
+// release mode never exposes upstream latency detail
+func (app *App) debugTimingAllowed() bool {
+	cfg := app.config()
+	return cfg == nil || cfg.Server.GinMode != gin.ReleaseMode
+}
+
+// writeForecastResponse converts the forecast to its serialization DTO and
+// writes it with freshness headers
+func (app *App) writeForecastResponse(c *gin.Context, freshness Freshness, forecast *weather.Forecast, includeCumulative bool, timings *timing.Report) {
+	dto, err := newForecastDTO(forecast, includeCumulative)
+	if err != nil {
+		app.logger.Error("failed to build forecast response", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+		return
+	}
+
+	setFreshnessHeaders(c, freshness)
+	response := WeatherForecastResponse{
+		Freshness: freshness,
+		Forecast:  dto,
+		Timings:   timings,
+	}
+
+	var payload any = response
+
+	// The visibility filter runs on the serialized document, after the
+	// cache, so filtered requests share cache entries with unfiltered ones
+	allModels, _ := strconv.ParseBool(c.Query("allModels"))
+	visible, visErr := modelVisibility(c.Query("models"), allModels)
+	if visErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": visErr.Error()})
+		return
+	}
+	if visible != nil {
+		doc, filterErr := visibleModelDocument(payload, visible)
+		if filterErr != nil {
+			app.logger.Error("failed to filter forecast models", "error", filterErr)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+			return
+		}
+		payload = doc
+	}
+
+	// The units preference prunes the serialized document the same way, so
+	// the cached forecast keeps both unit systems
+	units, unitsErr := app.resolveUnits(c.Query("units"))
+	if unitsErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": unitsErr.Error()})
+		return
+	}
+	if units != "" {
+		doc, pruneErr := unitSystemDocument(payload, units)
+		if pruneErr != nil {
+			app.logger.Error("failed to prune forecast unit system", "error", pruneErr)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+			return
+		}
+		payload = doc
+	}
+
+	if c.Query("modelFormat") == "array" {
+		doc, arrErr := modelArrayDocument(response)
+		if arrErr != nil {
+			app.logger.Error("failed to build array-form forecast response", "error", arrErr)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+			return
+		}
+		payload = doc
+	}
+
+	if compatRequested(c) {
+		doc, served, compatErr := compatDocument(payload)
+		if compatErr != nil {
+			app.logger.Error("failed to build compat forecast response", "error", compatErr)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+			return
+		}
+		app.deprecations.add(served)
+		payload = doc
+	}
+
+	c.JSON(http.StatusOK, payload)
+}
+
+// handleGetWeatherForecast godoc
+// @Summary Get weather forecast
+// @Description Retrieve a multi-model weather forecast for a given latitude and longitude
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param includeCumulative query boolean false "Include cumulative precipitation curves" example(false)
+// @Param includeEnsemble query boolean false "Include the probabilistic snowfall block (extra upstream fetch)" example(false)
+// @Param elevationMeters query number false "Override elevation instead of looking it up" minimum(-430) maximum(8850) example(3650)
+// @Param name query string false "Display name for an overridden point" example(Cristo Couloir)
+// @Param debugTiming query boolean false "Include the debug timings block (non-production only)" example(false)
+// @Param modelFormat query string false "ModelValues serialization form" Enums(map, array)
+// @Param profile query string false "Thresholds profile for the snow-focused derived blocks" example(powder-hound)
+// @Param models query string false "Explicit comma-separated model set, overriding the default-visibility filter" example(GfsSeamless,EcmwIfs)
+// @Param allModels query boolean false "Include the models hidden behind the default-visibility filter" example(false)
+// @Param units query string false "Keep only one unit system's figures in the payload; both are included when unset" Enums(imperial, metric)
+// @Param compat query boolean false "Also emit deprecated response fields (equivalent to the X-API-Version: v1 header)"
+// @Success 200 {object} WeatherForecastResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /weather/forecast [get]
+func (app *App) handleGetWeatherForecast(c *gin.Context) {
+	var input GetWeatherForecastInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Reject an unknown models filter or unit system before spending an
+	// upstream fetch
+	if _, err := modelVisibility(input.Models, input.AllModels); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := app.resolveUnits(input.Units); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	override := input.elevationOverride()
+
+	// Resolve the thresholds profile through the preference layers, query
+	// over the configured defaults; saved location and API key layers slot
+	// in here once their storage lands
+	profile := preferences.Resolve(preferences.Preferences{Profile: input.Profile}, app.defaultPreferences()).Profile
+
+	// The timings collector only exists when the request opted in and the
+	// deployment allows it; everything downstream no-ops without one
+	var collector *timing.Collector
+	if input.DebugTiming && app.debugTimingAllowed() {
+		collector = timing.NewCollector(nil)
+	}
+
+	// Overridden and ensemble-carrying requests get their own cache entries;
+	// the prefetcher only notes and refreshes coordinate-only lookups
+	request := forecastRequest{
+		Latitude:        input.Latitude,
+		Longitude:       input.Longitude,
+		IncludeEnsemble: input.IncludeEnsemble,
+		Profile:         profile,
+	}
+	if override != nil {
+		request.ElevationMeters = override.ElevationMeters
+	} else if app.prefetcher != nil {
+		app.prefetcher.noteRequest(input.Latitude, input.Longitude)
+	}
+	key := normalizeForecastRequest(request)
+
+	entry, status := app.weatherCache.Get(key)
+	collector.Record(timing.Span{Name: "weatherCache", CacheStatus: string(status)})
+
+	if status == cache.StatusHit {
+		freshness := newFreshness(entry.FetchedAt, cache.StatusHit, false, 0)
+		app.writeForecastResponse(c, freshness, entry.Value, input.IncludeCumulative, collector.Finish())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if collector != nil {
+		ctx = timing.NewContext(ctx, collector)
+	}
+	forecast, err := app.fetchWeatherForecast(ctx, input.Latitude, input.Longitude, override, input.IncludeEnsemble, profile)
+	if err != nil {
+		// Fall back to a stale entry when the refresh fails
+		if status == cache.StatusStale {
+			app.logger.Warn("serving stale weather forecast after refresh failure",
+				"latitude", input.Latitude,
+				"longitude", input.Longitude,
+				"error", err,
+			)
+			freshness := newFreshness(entry.FetchedAt, cache.StatusStale, true, 0)
+			app.writeForecastResponse(c, freshness, entry.Value, input.IncludeCumulative, collector.Finish())
+			return
+		}
+
+		if errors.Is(err, location.ErrInvalidLatitude) || errors.Is(err, location.ErrInvalidLongitude) ||
+			errors.Is(err, location.ErrInvalidElevation) || errors.Is(err, weather.ErrUnknownThresholdProfile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// A spent provider quota with no cached forecast to fall back on
+		// becomes a 503; the budget resets at UTC midnight
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "provider daily quota exceeded, retry later"})
+			return
+		}
+
+		app.logger.Error("failed to get weather forecast",
+			"latitude", input.Latitude,
+			"longitude", input.Longitude,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather forecast"})
+		return
+	}
+
+	app.weatherCache.Set(key, forecast)
+	if override == nil {
+		app.publishWeatherRefresh(input.Latitude, input.Longitude, forecast)
+	}
+
+	freshness := newFreshness(app.now(), cache.StatusMiss, false, 0)
+	app.writeForecastResponse(c, freshness, forecast, input.IncludeCumulative, collector.Finish())
+}
+
+// fetchWeatherForecast resolves the forecast point and fetches a fresh
+// forecast; a non-nil override builds the point from the caller's elevation
+// instead of the elevation provider, includeEnsemble adds the separate
+// probabilistic snowfall fetch, and profile names the thresholds profile for
+// the derived blocks (empty for the default)
+func (app *App) fetchWeatherForecast(ctx context.Context, latitude, longitude float64, override *location.ElevationOverride, includeEnsemble bool, profile string) (*weather.Forecast, error) {
+	var forecastPoint *types.ForecastPoint
+	var err error
+	locationSpan := timing.FromContext(ctx).StartSpan("location.forecastPoint")
+	if override != nil {
+		forecastPoint, err = app.locationService.BuildForecastPoint(latitude, longitude, *override)
+	} else {
+		forecastPoint, err = app.locationService.GetForecastPoint(latitude, longitude)
+	}
+	locationSpan()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecast point: %w", err)
+	}
+
+	forecast, err := app.weatherService.GetForecast(ctx, *forecastPoint, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if includeEnsemble {
+		probabilistic, ensembleErr := app.weatherService.GetProbabilisticSnowfall(ctx, *forecastPoint)
+		if ensembleErr != nil {
+			// The deterministic forecast stands on its own; a failed
+			// ensemble fetch only costs the probabilistic block
+			app.logger.Warn("serving forecast without the requested ensemble block",
+				"latitude", latitude,
+				"longitude", longitude,
+				"error", ensembleErr,
+			)
+		} else {
+			forecast.ProbabilisticSnowfall = probabilistic
+		}
+	}
+	return forecast, nil
+}
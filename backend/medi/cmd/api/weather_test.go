@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"medi/internal/avalanche"
+	"medi/internal/config"
+	"medi/internal/weather"
+)
+
+func decodeForecastResponse(t *testing.T, body []byte) WeatherForecastResponse {
+	t.Helper()
+
+	var resp WeatherForecastResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestDebugTimingIncludesSpans(t *testing.T) {
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, time.Now)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&debugTiming=true")
+	resp := decodeForecastResponse(t, w.Body.Bytes())
+
+	if resp.Timings == nil {
+		t.Fatal("expected a timings block with debugTiming=true")
+	}
+	spans := map[string]string{}
+	for _, span := range resp.Timings.Spans {
+		spans[span.Name] = span.CacheStatus
+	}
+	if status, ok := spans["weatherCache"]; !ok || status != "MISS" {
+		t.Errorf("weatherCache span = (%q, %v), want a MISS entry", status, ok)
+	}
+	if _, ok := spans["location.forecastPoint"]; !ok {
+		t.Errorf("timings %v missing the location span", spans)
+	}
+
+	// A cache hit reports its disposition and skips the fetch spans
+	w = doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&debugTiming=true")
+	resp = decodeForecastResponse(t, w.Body.Bytes())
+	if resp.Timings == nil {
+		t.Fatal("expected a timings block on the cache hit")
+	}
+	if len(resp.Timings.Spans) != 1 || resp.Timings.Spans[0].CacheStatus != "HIT" {
+		t.Errorf("cache hit spans = %+v, want only the HIT entry", resp.Timings.Spans)
+	}
+}
+
+func TestIncludeEnsembleAttachesBlockAndKeysCache(t *testing.T) {
+	weatherSvc := &mockWeatherService{
+		forecast: &weather.Forecast{Timezone: "America/Denver"},
+		probabilistic: &weather.ProbabilisticSnowfall{
+			Members: 82,
+			Times:   []time.Time{time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+			Hourly:  []weather.PercentileBand{{P10: 0.1, P50: 0.25, P90: 0.4}},
+			Daily: []weather.DailySnowfallOutlook{{
+				Date:        time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				Members:     82,
+				TotalInches: weather.PercentileBand{P50: 6},
+				Exceedance:  weather.ExceedanceProbabilities{Above3In: 0.8, Above6In: 0.5},
+			}},
+		},
+	}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, time.Now)
+
+	// A plain request carries no block
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	if resp := decodeForecastResponse(t, w.Body.Bytes()); resp.Forecast.ProbabilisticSnowfall != nil {
+		t.Error("probabilistic block present without includeEnsemble")
+	}
+
+	// The ensemble request misses despite the cached plain entry: the two
+	// response shapes key separately
+	w = doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&includeEnsemble=true")
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("ensemble request X-Cache = %q, want MISS", got)
+	}
+	resp := decodeForecastResponse(t, w.Body.Bytes())
+	if resp.Forecast.ProbabilisticSnowfall == nil {
+		t.Fatal("probabilistic block missing with includeEnsemble=true")
+	}
+	if got := resp.Forecast.ProbabilisticSnowfall.Members; got != 82 {
+		t.Errorf("block members = %d, want 82", got)
+	}
+	if len(resp.Forecast.ProbabilisticSnowfall.Daily) != 1 {
+		t.Fatalf("block daily = %+v, want one outlook", resp.Forecast.ProbabilisticSnowfall.Daily)
+	}
+	if got := resp.Forecast.ProbabilisticSnowfall.Daily[0].Exceedance.Above3In; got != 0.8 {
+		t.Errorf("Above3In = %v, want 0.8", got)
+	}
+}
+
+func TestIncludeEnsembleUnavailableStillServesForecast(t *testing.T) {
+	// No probabilistic forecast configured mimics a deployment without an
+	// ensemble provider: the deterministic forecast serves without the block
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, time.Now)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&includeEnsemble=true")
+	if resp := decodeForecastResponse(t, w.Body.Bytes()); resp.Forecast.ProbabilisticSnowfall != nil {
+		t.Error("probabilistic block present despite the unavailable ensemble")
+	}
+}
+
+func TestDebugTimingAbsentWithoutFlag(t *testing.T) {
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, time.Now)
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840")
+	if resp := decodeForecastResponse(t, w.Body.Bytes()); resp.Timings != nil {
+		t.Errorf("timings block present without the flag: %+v", resp.Timings)
+	}
+}
+
+func TestDebugTimingAbsentInReleaseMode(t *testing.T) {
+	weatherSvc := &mockWeatherService{forecast: &weather.Forecast{Timezone: "America/Denver"}}
+	app := newTestApp(&mockAvalancheService{forecast: &avalanche.AvalancheForecast{}}, weatherSvc, time.Now)
+	app.cfg = config.NewHolder(&config.Config{Server: config.ServerConfig{GinMode: "release"}})
+
+	w := doRequest(t, app, "/weather/forecast?latitude=39.11539&longitude=-107.65840&debugTiming=true")
+	if resp := decodeForecastResponse(t, w.Body.Bytes()); resp.Timings != nil {
+		t.Errorf("timings block present in release mode: %+v", resp.Timings)
+	}
+}
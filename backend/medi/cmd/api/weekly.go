@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"medi/internal/preferences"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWeeklySummaryInput defines the query parameters for the weekly summary endpoint
+type GetWeeklySummaryInput struct {
+	CoordinateInput
+	// StartDate shifts the seven-day window within the forecast horizon,
+	// formatted YYYY-MM-DD in the location's timezone; defaults to today
+	StartDate string `form:"startDate"`
+	// Language is the BCP 47 tag the day labels are localized to
+	Language string `form:"language"`
+}
+
+// WeeklySummaryResponse carries the compact week strip
+type WeeklySummaryResponse struct {
+	Timezone string         `json:"timezone"`
+	Days     []WeeklyDayDTO `json:"days"`
+}
+
+type WeeklyDayDTO struct {
+	weather.WeeklyDay
+	Date LocalTimestamp
+	// Label names the day for display: Today, Tonight, or Tomorrow for the
+	// request's own window, weekday names beyond, in the requested language
+	Label string `json:"label"`
+}
+
+// handleGetWeeklySummary godoc
+// @Summary Get weekly forecast summary
+// @Description Retrieve a compact seven-day outlook with ensemble essentials per day, designed for week-strip UIs
+// @Tags weather
+// @Accept json
+// @Produce json
+// @Param latitude query number true "Latitude in decimal degrees" minimum(-90) maximum(90) example(39.11539)
+// @Param longitude query number true "Longitude in decimal degrees" minimum(-180) maximum(180) example(-107.65840)
+// @Param startDate query string false "First day of the window, YYYY-MM-DD in the location's timezone" example(2025-01-16)
+// @Param language query string false "BCP 47 language tag for the day labels" example(es)
+// @Success 200 {object} WeeklySummaryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /weather/weekly [get]
+func (app *App) handleGetWeeklySummary(c *gin.Context) {
+	var input GetWeeklySummaryInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := app.validateCoordinates(input.CoordinateInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	forecast, ok := app.cachedForecast(c, input.Latitude, input.Longitude)
+	if !ok {
+		return
+	}
+
+	locationZone, err := time.LoadLocation(forecast.Timezone)
+	if err != nil {
+		app.logger.Error("failed to load forecast timezone", "timezone", forecast.Timezone, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build weekly summary"})
+		return
+	}
+
+	start := app.now()
+	if input.StartDate != "" {
+		start, err = time.ParseInLocation(time.DateOnly, input.StartDate, locationZone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid startDate: " + err.Error()})
+			return
+		}
+	}
+
+	language := preferences.Resolve(preferences.Preferences{Language: input.Language}, app.defaultPreferences()).Language
+
+	days := forecast.WeeklySummary(start)
+	response := WeeklySummaryResponse{
+		Timezone: forecast.Timezone,
+		Days:     make([]WeeklyDayDTO, 0, len(days)),
+	}
+	for _, day := range days {
+		response.Days = append(response.Days, WeeklyDayDTO{
+			WeeklyDay: day,
+			Date:      newLocalTimestamp(day.Date, locationZone),
+			// Labels are relative to the request time, not the window start,
+			// so a shifted window still reads Today where it overlaps now
+			Label: weather.DayLabel(day.Date, locationZone, app.now(), language),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+	"medi/internal/weather"
+)
+
+// weeklyTestForecast builds a Denver forecast with the given number of days
+// starting Jan 15, one model per daily field.
+func weeklyTestForecast(days int) *weather.Forecast {
+	denver, _ := time.LoadLocation("America/Denver")
+	forecast := &weather.Forecast{Timezone: "America/Denver"}
+	for i := 0; i < days; i++ {
+		forecast.DailyForecasts = append(forecast.DailyForecasts, weather.DailyForecast{
+			Timestamp: time.Date(2025, 1, 15+i, 0, 0, 0, 0, denver),
+			HighTemperature: weather.ModelValues[types.Temperature]{
+				weather.ModelGfsSeamless: types.NewTemperatureFromFahrenheit(30),
+			},
+			TotalSnowfall: weather.ModelValues[types.Precipitation]{
+				weather.ModelGfsSeamless: types.NewPrecipitationFromInches(3),
+			},
+		})
+	}
+	return forecast
+}
+
+func TestWeeklySummaryServedFromCache(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC) }
+
+	// The provider is down; only the seeded cache entry can serve the request
+	weatherSvc := &mockWeatherService{fail: true}
+	app := newTestApp(&mockAvalancheService{}, weatherSvc, clock)
+	app.weatherCache.Set(forecastCacheKey(39.11539, -107.65840), weeklyTestForecast(5))
+
+	w := doRequest(t, app, "/weather/weekly?latitude=39.11539&longitude=-107.65840")
+
+	var resp WeeklySummaryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Timezone != "America/Denver" {
+		t.Errorf("timezone = %q, want America/Denver", resp.Timezone)
+	}
+	// The cache holds only 5 of the 7 window days
+	if len(resp.Days) != 5 {
+		t.Fatalf("got %d days, want the 5 remaining in the horizon", len(resp.Days))
+	}
+	if resp.Days[0].PowderScore != 3 {
+		t.Errorf("powder score = %d, want 3", resp.Days[0].PowderScore)
+	}
+}
+
+func TestWeeklySummaryStartDateShiftsWindow(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC) }
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{fail: true}, clock)
+	app.weatherCache.Set(forecastCacheKey(39.11539, -107.65840), weeklyTestForecast(5))
+
+	w := doRequest(t, app, "/weather/weekly?latitude=39.11539&longitude=-107.65840&startDate=2025-01-17")
+
+	var resp WeeklySummaryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Days) != 3 {
+		t.Fatalf("got %d days, want 3 from Jan 17 to the horizon end", len(resp.Days))
+	}
+}
+
+func TestWeeklySummaryRejectsBadStartDate(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC) }
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{fail: true}, clock)
+	app.weatherCache.Set(forecastCacheKey(39.11539, -107.65840), weeklyTestForecast(5))
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/weather/weekly?latitude=39.11539&longitude=-107.65840&startDate=tomorrow", nil)
+	w := httptest.NewRecorder()
+	app.router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400 for a malformed startDate", w.Code)
+	}
+}
+
+func TestWeeklySummaryLocalizedLabels(t *testing.T) {
+	clock := func() time.Time { return time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC) }
+
+	app := newTestApp(&mockAvalancheService{}, &mockWeatherService{fail: true}, clock)
+	app.weatherCache.Set(forecastCacheKey(39.11539, -107.65840), weeklyTestForecast(5))
+
+	w := doRequest(t, app, "/weather/weekly?latitude=39.11539&longitude=-107.65840&language=es")
+
+	var resp WeeklySummaryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Days) < 3 {
+		t.Fatalf("got %d days, want at least 3", len(resp.Days))
+	}
+	// 15:00 UTC is morning in Denver: the window opens relatively and then
+	// switches to weekday names
+	if resp.Days[0].Label != "Hoy" {
+		t.Errorf("first label = %q, want Hoy", resp.Days[0].Label)
+	}
+	if resp.Days[1].Label != "Mañana" {
+		t.Errorf("second label = %q, want Mañana", resp.Days[1].Label)
+	}
+	if resp.Days[2].Label != "Viernes" {
+		t.Errorf("third label = %q, want Viernes", resp.Days[2].Label)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"medi/internal/avalanche"
+	"medi/internal/types"
+	"medi/internal/weather"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ZoneWeatherResponse is the weather rollup for an avalanche forecast zone
+type ZoneWeatherResponse struct {
+	CenterId string         `json:"centerId"`
+	ZoneId   int            `json:"zoneId"`
+	Rollup   weather.Rollup `json:"rollup"`
+	// Bands breaks the next 24 hours down per danger elevation band, one
+	// column each for below, near, and above the zone's band boundaries
+	Bands []ZoneWeatherBand `json:"bands"`
+}
+
+// ZoneWeatherBand is one column of the elevation band table with the zone's
+// display label for the band
+type ZoneWeatherBand struct {
+	weather.BandForecast
+	Label string `json:"label"`
+}
+
+// handleGetZoneWeather godoc
+// @Summary Get zone weather rollup
+// @Description Aggregate weather forecasts sampled across an avalanche forecast zone polygon
+// @Tags avalanche
+// @Accept json
+// @Produce json
+// @Param centerId path string true "Avalanche center identifier" example(CAIC)
+// @Param zoneId path int true "Forecast zone identifier" example(1001)
+// @Success 200 {object} ZoneWeatherResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /avalanche/zone/{centerId}/{zoneId}/weather [get]
+func (app *App) handleGetZoneWeather(c *gin.Context) {
+	centerId := c.Param("centerId")
+	zoneId, err := strconv.Atoi(c.Param("zoneId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "zoneId must be an integer"})
+		return
+	}
+
+	points, err := app.avalancheService.GetZoneSamplePoints(centerId, zoneId)
+	if err != nil {
+		if errors.Is(err, avalanche.ErrZoneNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		app.logger.Error("failed to sample zone points",
+			"center_id", centerId,
+			"zone_id", zoneId,
+			"error", err,
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to sample zone points"})
+		return
+	}
+
+	// Fetch a forecast for every sample point concurrently
+	forecasts := make([]*weather.Forecast, len(points))
+	fetchErrs := make([]error, len(points))
+	var wg sync.WaitGroup
+	for i, point := range points {
+		wg.Add(1)
+		go func(i int, point types.Coords) {
+			defer wg.Done()
+			forecast, err := app.fetchWeatherForecast(c.Request.Context(), point.Latitude, point.Longitude, nil, false, "")
+			if err != nil {
+				fetchErrs[i] = err
+				return
+			}
+			forecasts[i] = forecast
+		}(i, point)
+	}
+	wg.Wait()
+
+	for i, fetchErr := range fetchErrs {
+		if fetchErr != nil {
+			app.logger.Error("failed to get weather for zone sample point",
+				"center_id", centerId,
+				"zone_id", zoneId,
+				"latitude", points[i].Latitude,
+				"longitude", points[i].Longitude,
+				"error", fetchErr,
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get weather for zone"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, ZoneWeatherResponse{
+		CenterId: centerId,
+		ZoneId:   zoneId,
+		Rollup:   weather.ComputeRollup(forecasts),
+		Bands:    app.zoneWeatherBands(points[0], forecasts[0]),
+	})
+}
+
+// zoneWeatherBands builds the per-band table from the forecast at the zone's
+// first sample point. The band boundaries come from the zone's published
+// config when the center ships one — the config blob arrives with the
+// avalanche forecast for the point — falling back to the treeline heuristic.
+func (app *App) zoneWeatherBands(point types.Coords, forecast *weather.Forecast) []ZoneWeatherBand {
+	var zoneConfig *avalanche.ZoneConfig
+	if zoneForecast, err := app.avalancheService.GetForecast(point.Latitude, point.Longitude); err == nil {
+		zoneConfig = zoneForecast.Zone.Config
+	}
+	lowerFt, upperFt := avalanche.BandBoundariesFt(zoneConfig, point.Latitude)
+
+	lapseRate := 0.0
+	if cfg := app.config(); cfg != nil {
+		lapseRate = cfg.App.LapseRateCPerKm
+	}
+
+	labels := avalanche.DangerBandLabels(zoneConfig)
+	names := []string{labels.Lower, labels.Middle, labels.Upper}
+	bands := make([]ZoneWeatherBand, 0, len(names))
+	for i, column := range weather.ComputeBandForecasts(forecast, lowerFt, upperFt, lapseRate) {
+		bands = append(bands, ZoneWeatherBand{BandForecast: column, Label: names[i]})
+	}
+	return bands
+}
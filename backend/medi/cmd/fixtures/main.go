@@ -0,0 +1,344 @@
+// Command fixtures refreshes the committed provider testdata snapshots from
+// the live APIs. It fetches each provider's response with the real client,
+// scrubs volatile fields (generation times zeroed, timestamps normalized) so
+// the diff against the committed fixture stays reviewable, writes the result
+// to the testdata locations, and records fetch time and request parameters
+// in a manifest next to them.
+//
+// Usage:
+//
+//	fixtures [--lat 39.11539 --lon -107.65840] [--provider openmeteo,nws] [--dry-run] [--out DIR]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"medi/internal/providers/nac"
+	"medi/internal/providers/nws"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/providers/openstreetmap"
+	"medi/internal/providers/usgs"
+)
+
+// defaultElevationMeters matches the elevation the snapshot capture passes
+// to Open-Meteo for the default Aspen coordinate
+const defaultElevationMeters = 2743.5 * 0.3048
+
+// manifestName is the file recording what the last refresh fetched
+const manifestName = "fixtures_manifest.json"
+
+// fixture is one committed snapshot the tool can refresh
+type fixture struct {
+	provider string
+	relPath  string // destination, relative to the module root
+	fetch    func(ctx context.Context, env *fetchEnv) ([]byte, error)
+	scrub    scrubber
+}
+
+// fetchEnv carries the shared inputs plus values earlier fixtures resolve
+// for later ones (the NAC zone for the forecast, the NWS CWA for the
+// discussion)
+type fetchEnv struct {
+	latitude  float64
+	longitude float64
+	logger    *slog.Logger
+
+	nacCenterId string
+	nacZoneId   int
+	nwsCwa      string
+}
+
+// manifest records when and with what parameters the fixtures were refreshed
+type manifest struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Files     []string  `json:"files"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run parses flags and refreshes the selected fixtures. It is split from
+// main so tests can drive the tool.
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("fixtures", flag.ContinueOnError)
+	latitude := flags.Float64("lat", 39.11539, "latitude the snapshots are captured for")
+	longitude := flags.Float64("lon", -107.65840, "longitude the snapshots are captured for")
+	outDir := flags.String("out", moduleRoot(), "module root the testdata paths are resolved against")
+	providerFilter := flags.String("provider", "", "comma-separated provider filter, e.g. openmeteo,nws; empty refreshes all")
+	dryRun := flags.Bool("dry-run", false, "diff fetched responses against the committed fixtures without writing")
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	selected := map[string]bool{}
+	for _, name := range strings.Split(*providerFilter, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected[name] = true
+		}
+	}
+
+	env := &fetchEnv{
+		latitude:  *latitude,
+		longitude: *longitude,
+		logger:    slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})),
+	}
+
+	record := manifest{FetchedAt: time.Now().UTC(), Latitude: *latitude, Longitude: *longitude}
+	ctx := context.Background()
+	for _, f := range fixtures() {
+		if len(selected) > 0 && !selected[f.provider] {
+			continue
+		}
+
+		data, err := f.fetch(ctx, env)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.relPath, err)
+		}
+		data, err = applyScrub(data, f.scrub)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.relPath, err)
+		}
+
+		path := filepath.Join(*outDir, f.relPath)
+		if *dryRun {
+			committed, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("%s: %w", f.relPath, err)
+			}
+			fmt.Fprintf(out, "%s: %s\n", f.relPath, diffSummary(committed, data))
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "wrote %s (%d bytes)\n", f.relPath, len(data))
+		record.Files = append(record.Files, f.relPath)
+	}
+
+	if *dryRun || len(record.Files) == 0 {
+		return nil
+	}
+	manifestData, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(*outDir, manifestName), append(manifestData, '\n'), 0o644)
+}
+
+// fixtures lists every refreshable snapshot in fetch order; later entries
+// may depend on values earlier ones store in the env
+func fixtures() []fixture {
+	return []fixture{
+		{
+			provider: "openmeteo",
+			relPath:  "internal/weather/testdata/openmeteo_forecast_response.json",
+			scrub:    scrubOpenMeteo,
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				client := openmeteo.NewClient(env.logger)
+				resp, err := client.GetForecast(ctx, env.latitude, env.longitude, defaultElevationMeters, 16, "America/Denver")
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(resp)
+			},
+		},
+		{
+			provider: "nac",
+			relPath:  "internal/avalanche/testdata/nac_map_layer_response.json",
+			scrub:    scrubNAC,
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				// Fetched raw: MapLayerGeometry keeps its coordinates in
+				// unexported fields, so a marshal round-trip would drop them
+				data, err := fetchRaw(ctx, "https://api.avalanche.org/v2/public/products/map-layer")
+				if err != nil {
+					return nil, err
+				}
+				var mapLayer nac.MapLayerResponse
+				if err := json.Unmarshal(data, &mapLayer); err != nil {
+					return nil, err
+				}
+				zone := nac.FindZone(env.latitude, env.longitude, &mapLayer)
+				if zone == nil {
+					return nil, fmt.Errorf("no NAC zone covers (%.5f, %.5f)", env.latitude, env.longitude)
+				}
+				env.nacCenterId = zone.Properties.CenterId
+				env.nacZoneId = zone.Id
+				return data, nil
+			},
+		},
+		{
+			provider: "nac",
+			relPath:  "internal/avalanche/testdata/nac_forecast_response.json",
+			scrub:    scrubNAC,
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				if env.nacCenterId == "" {
+					return nil, fmt.Errorf("no zone resolved by the map layer fetch")
+				}
+				url := fmt.Sprintf("https://api.avalanche.org/v2/public/product?type=forecast&center_id=%s&zone_id=%d",
+					env.nacCenterId, env.nacZoneId)
+				return fetchRaw(ctx, url)
+			},
+		},
+		{
+			provider: "usgs",
+			relPath:  "internal/location/testdata/usgs_elevation_response.json",
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				client := usgs.NewClient(env.logger)
+				resp, err := client.GetElevationPoint(env.latitude, env.longitude)
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(resp)
+			},
+		},
+		{
+			provider: "openstreetmap",
+			relPath:  "internal/location/testdata/openstreetmap_lookup_response.json",
+			scrub:    scrubOpenStreetMap,
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				client := openstreetmap.NewClient(env.logger)
+				resp, err := client.Lookup(env.latitude, env.longitude)
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(resp)
+			},
+		},
+		{
+			provider: "nws",
+			relPath:  "internal/weather/testdata/nws_point_response.json",
+			scrub:    scrubNWS,
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				client := nws.NewClient(env.logger)
+				resp, err := client.GetPoint(env.latitude, env.longitude)
+				if err != nil {
+					return nil, err
+				}
+				env.nwsCwa = resp.Properties.Cwa
+				return json.Marshal(resp)
+			},
+		},
+		{
+			provider: "nws",
+			relPath:  "internal/weather/testdata/nws_afd_response.json",
+			scrub:    scrubNWS,
+			fetch: func(ctx context.Context, env *fetchEnv) ([]byte, error) {
+				if env.nwsCwa == "" {
+					return nil, fmt.Errorf("no CWA resolved by the point fetch")
+				}
+				client := nws.NewClient(env.logger)
+				resp, err := client.GetAreaForecastDiscussion(env.nwsCwa)
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(resp)
+			},
+		},
+	}
+}
+
+// applyScrub decodes the response, runs the provider's scrubber when there
+// is one, and re-encodes indented so fixtures stay diff-friendly
+func applyScrub(data []byte, scrub scrubber) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("response is not JSON: %w", err)
+	}
+	if scrub != nil {
+		scrub(doc)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// fetchRaw retrieves a URL body as-is, for providers whose responses cannot
+// survive a typed marshal round-trip
+func fetchRaw(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GET %s returned %d: %s", url, resp.StatusCode, body)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// diffSummary describes how a refreshed fixture differs from the committed
+// one: line counts only present on each side, with a few examples
+func diffSummary(committed, refreshed []byte) string {
+	if committed == nil {
+		return "new fixture"
+	}
+	oldOnly := missingLines(refreshed, committed)
+	newOnly := missingLines(committed, refreshed)
+	if len(oldOnly) == 0 && len(newOnly) == 0 {
+		return "unchanged"
+	}
+
+	summary := fmt.Sprintf("-%d +%d lines", len(oldOnly), len(newOnly))
+	for _, line := range firstLines(oldOnly, 3) {
+		summary += "\n  - " + strings.TrimSpace(line)
+	}
+	for _, line := range firstLines(newOnly, 3) {
+		summary += "\n  + " + strings.TrimSpace(line)
+	}
+	return summary
+}
+
+// missingLines returns the lines of b absent from a, counting duplicates
+func missingLines(a, b []byte) []string {
+	counts := map[string]int{}
+	for _, line := range strings.Split(string(a), "\n") {
+		counts[line]++
+	}
+	var missing []string
+	for _, line := range strings.Split(string(b), "\n") {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		missing = append(missing, line)
+	}
+	return missing
+}
+
+func firstLines(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[:n]
+	}
+	return lines
+}
+
+// moduleRoot returns the path to the Go module root (where go.mod lives)
+func moduleRoot() string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"time"
+)
+
+// A scrubber rewrites the volatile parts of a decoded provider response so a
+// refreshed fixture diffs cleanly against the committed one: generation
+// times say nothing about schema changes, and timestamp formatting drift
+// (offset vs Zulu, fractional seconds) buries the real differences.
+type scrubber func(doc any)
+
+// scrubOpenMeteo zeroes the per-request generation time; everything else in
+// an Open-Meteo response is forecast data worth reviewing
+func scrubOpenMeteo(doc any) {
+	zeroFields(doc, "generationtime_ms")
+}
+
+// scrubNAC normalizes the product and revision timestamps NAC attaches to
+// forecasts; updated_at in particular moves on every server-side touch
+func scrubNAC(doc any) {
+	normalizeTimeFields(doc, "created_at", "updated_at", "published_time", "expires_time", "start_date", "end_date")
+}
+
+// scrubNWS normalizes the issuance and update stamps on NWS products
+func scrubNWS(doc any) {
+	normalizeTimeFields(doc, "issuanceTime", "updated", "updateTime", "generatedAt")
+}
+
+// scrubOpenStreetMap zeroes the Nominatim place id, which the service
+// documents as unstable between requests for the same place
+func scrubOpenStreetMap(doc any) {
+	zeroFields(doc, "place_id")
+}
+
+// zeroFields walks doc and sets every field with one of the names to zero
+func zeroFields(doc any, names ...string) {
+	walkFields(doc, names, func(m map[string]any, key string) {
+		m[key] = 0
+	})
+}
+
+// normalizeTimeFields walks doc and rewrites every named field whose value
+// parses as RFC 3339 to UTC with whole-second precision
+func normalizeTimeFields(doc any, names ...string) {
+	walkFields(doc, names, func(m map[string]any, key string) {
+		value, ok := m[key].(string)
+		if !ok {
+			return
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return
+		}
+		m[key] = parsed.UTC().Truncate(time.Second).Format(time.RFC3339)
+	})
+}
+
+// walkFields visits every map field named in names, recursing through nested
+// objects and arrays
+func walkFields(doc any, names []string, visit func(m map[string]any, key string)) {
+	switch node := doc.(type) {
+	case map[string]any:
+		for key, value := range node {
+			for _, name := range names {
+				if key == name {
+					visit(node, key)
+					break
+				}
+			}
+			walkFields(value, names, visit)
+		}
+	case []any:
+		for _, value := range node {
+			walkFields(value, names, visit)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, raw string) any {
+	t.Helper()
+	var doc any
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatalf("bad test document: %v", err)
+	}
+	return doc
+}
+
+func TestScrubOpenMeteoZeroesGenerationTime(t *testing.T) {
+	doc := decode(t, `{"generationtime_ms": 104.747, "hourly": {"temperature_2m": [1.5]}}`)
+
+	scrubOpenMeteo(doc)
+
+	m := doc.(map[string]any)
+	if m["generationtime_ms"] != 0 {
+		t.Errorf("generationtime_ms = %v, want 0", m["generationtime_ms"])
+	}
+	if m["hourly"].(map[string]any)["temperature_2m"].([]any)[0] != 1.5 {
+		t.Error("scrubber touched forecast data")
+	}
+}
+
+func TestScrubNACNormalizesTimestamps(t *testing.T) {
+	doc := decode(t, `{
+		"published_time": "2026-02-19T23:30:00+00:00",
+		"updated_at": "2026-02-20T04:20:06.123456-07:00",
+		"forecast_zone": [{"created_at": "2026-02-19T23:31:05+00:00", "name": "Aspen"}]
+	}`)
+
+	scrubNAC(doc)
+
+	m := doc.(map[string]any)
+	if got := m["published_time"]; got != "2026-02-19T23:30:00Z" {
+		t.Errorf("published_time = %v, want Zulu form", got)
+	}
+	// Offset converted to UTC, fractional seconds dropped
+	if got := m["updated_at"]; got != "2026-02-20T11:20:06Z" {
+		t.Errorf("updated_at = %v", got)
+	}
+	nested := m["forecast_zone"].([]any)[0].(map[string]any)
+	if got := nested["created_at"]; got != "2026-02-19T23:31:05Z" {
+		t.Errorf("nested created_at = %v", got)
+	}
+	if nested["name"] != "Aspen" {
+		t.Error("scrubber touched a non-time field")
+	}
+}
+
+func TestNormalizeTimeFieldsLeavesNonTimestampsAlone(t *testing.T) {
+	doc := decode(t, `{"updated": "tomorrow", "updateTime": 12}`)
+
+	scrubNWS(doc)
+
+	m := doc.(map[string]any)
+	if m["updated"] != "tomorrow" || m["updateTime"] != float64(12) {
+		t.Errorf("non-timestamp values changed: %v", m)
+	}
+}
+
+func TestScrubOpenStreetMapZeroesPlaceId(t *testing.T) {
+	doc := decode(t, `{"place_id": 297236456, "display_name": "Aspen"}`)
+
+	scrubOpenStreetMap(doc)
+
+	m := doc.(map[string]any)
+	if m["place_id"] != 0 {
+		t.Errorf("place_id = %v, want 0", m["place_id"])
+	}
+}
+
+func TestDiffSummary(t *testing.T) {
+	committed := []byte("{\n  \"a\": 1,\n  \"b\": 2\n}")
+
+	if got := diffSummary(committed, committed); got != "unchanged" {
+		t.Errorf("identical fixtures diffed as %q", got)
+	}
+	if got := diffSummary(nil, committed); got != "new fixture" {
+		t.Errorf("missing fixture diffed as %q", got)
+	}
+
+	refreshed := []byte("{\n  \"a\": 1,\n  \"b\": 3\n}")
+	got := diffSummary(committed, refreshed)
+	if !strings.HasPrefix(got, "-1 +1 lines") {
+		t.Errorf("summary = %q, want -1 +1 line counts", got)
+	}
+	if !strings.Contains(got, `- "b": 2`) || !strings.Contains(got, `+ "b": 3`) {
+		t.Errorf("summary %q missing the changed lines", got)
+	}
+}
@@ -0,0 +1,135 @@
+// Command forecast-diff compares two saved forecast JSON snapshots and prints
+// what changed between them: ensemble daily medians for snowfall, temperatures,
+// and winds, plus the per-model moves behind each change. It is a developer
+// tool for eyeballing how an upstream model run shifted a forecast.
+//
+// Usage:
+//
+//	forecast-diff [--format text|json] old.json new.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"medi/internal/weather"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run parses the flags and arguments and writes the diff to out. It is split
+// from main so tests can drive the tool against fixture files.
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("forecast-diff", flag.ContinueOnError)
+	format := flags.String("format", "text", "output format: text or json")
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("usage: forecast-diff [--format text|json] old.json new.json")
+	}
+
+	oldForecast, err := loadForecast(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	newForecast, err := loadForecast(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := weather.DiffForecasts(oldForecast, newForecast)
+
+	switch *format {
+	case "text":
+		writeText(out, diff)
+		return nil
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	default:
+		return fmt.Errorf("unknown format %q, want text or json", *format)
+	}
+}
+
+// loadForecast reads one saved forecast snapshot.
+func loadForecast(path string) (*weather.Forecast, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read forecast: %w", err)
+	}
+	var forecast weather.Forecast
+	if err := json.Unmarshal(data, &forecast); err != nil {
+		return nil, fmt.Errorf("failed to parse forecast %s: %w", path, err)
+	}
+	return &forecast, nil
+}
+
+// writeText renders the diff for a terminal, one block per changed day.
+func writeText(out io.Writer, diff *weather.ForecastDiff) {
+	fmt.Fprintf(out, "forecast diff: %s -> %s (%s)\n",
+		diff.OldTimestamp.Format("2006-01-02 15:04 MST"),
+		diff.NewTimestamp.Format("2006-01-02 15:04 MST"),
+		diff.Timezone)
+
+	if len(diff.Days) == 0 {
+		fmt.Fprintln(out, "no changes")
+		return
+	}
+
+	for _, day := range diff.Days {
+		fmt.Fprintf(out, "\n%s", day.Date.Format("Mon Jan 2"))
+		switch {
+		case day.OnlyInOld:
+			fmt.Fprint(out, "  (dropped from forecast window)")
+		case day.OnlyInNew:
+			fmt.Fprint(out, "  (new in forecast window)")
+		}
+		fmt.Fprintln(out)
+
+		for _, field := range day.Fields {
+			fmt.Fprintf(out, "  %-16s %s -> %s  (%s)\n", field.Field,
+				formatValue(field.OldMedian, field.Unit),
+				formatValue(field.NewMedian, field.Unit),
+				formatDelta(field.Delta, field.Unit))
+			for _, model := range field.Models {
+				fmt.Fprintf(out, "    %-20s %s\n", model.Model, formatModelDelta(model, field.Unit))
+			}
+		}
+	}
+}
+
+func formatModelDelta(delta weather.ModelDelta, unit string) string {
+	switch {
+	case !delta.InOld:
+		return fmt.Sprintf("added at %s", formatValue(delta.New, unit))
+	case !delta.InNew:
+		return fmt.Sprintf("removed, was %s", formatValue(delta.Old, unit))
+	default:
+		return fmt.Sprintf("%s -> %s  (%s)", formatValue(delta.Old, unit),
+			formatValue(delta.New, unit), formatDelta(delta.New-delta.Old, unit))
+	}
+}
+
+func formatValue(value float64, unit string) string {
+	return fmt.Sprintf("%.1f%s", value, unit)
+}
+
+func formatDelta(delta float64, unit string) string {
+	formatted := formatValue(delta, unit)
+	if !strings.HasPrefix(formatted, "-") {
+		return "+" + formatted
+	}
+	return formatted
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"medi/internal/weather"
+)
+
+func TestTextOutputMatchesGolden(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"testdata/forecast_old.json", "testdata/forecast_new.json"}, &out)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	golden, err := os.ReadFile("testdata/diff.golden")
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if out.String() != string(golden) {
+		t.Errorf("text output does not match testdata/diff.golden\ngot:\n%s\nwant:\n%s",
+			out.String(), golden)
+	}
+}
+
+func TestJSONOutput(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"--format", "json", "testdata/forecast_old.json", "testdata/forecast_new.json"}, &out)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	var diff weather.ForecastDiff
+	if err := json.Unmarshal(out.Bytes(), &diff); err != nil {
+		t.Fatalf("json output does not parse: %v", err)
+	}
+	if diff.Timezone != "America/Denver" {
+		t.Errorf("timezone = %q, want America/Denver", diff.Timezone)
+	}
+	// Jan 15 dropped, Jan 16 changed, Jan 17 unchanged and omitted, Jan 18 new
+	if len(diff.Days) != 3 {
+		t.Fatalf("got %d day diffs, want 3", len(diff.Days))
+	}
+}
+
+func TestRunRejectsBadArguments(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"only-one.json"}, &out); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("one argument: err = %v, want usage error", err)
+	}
+	if err := run([]string{"--format", "yaml", "testdata/forecast_old.json", "testdata/forecast_new.json"}, &out); err == nil || !strings.Contains(err.Error(), "yaml") {
+		t.Errorf("bad format: err = %v, want unknown format error", err)
+	}
+	if err := run([]string{"testdata/missing.json", "testdata/forecast_new.json"}, &out); err == nil {
+		t.Error("missing file: expected an error, got nil")
+	}
+}
@@ -0,0 +1,179 @@
+// Command loadtest fires concurrent requests at a running medi instance and
+// reports latency percentiles and error rates. Point it at a server started
+// with providers.mode=mock for a self-contained throughput check, or at a
+// staging instance for end-to-end numbers. The JSON format is stable so CI
+// can track the percentiles across commits.
+//
+// Usage:
+//
+//	loadtest [--url http://localhost:8080] [--path /weather/forecast]
+//	         [--latitude 39.11539] [--longitude -107.65840]
+//	         [--concurrency 8] [--requests 200] [--spread 1]
+//	         [--format text|json]
+//
+// --spread rotates requests through that many distinct coordinates so cache
+// misses are part of the mix; the default of 1 measures the warm-cache path.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// report summarizes one load run. Latencies are milliseconds so the JSON is
+// directly plottable.
+type report struct {
+	Requests          int     `json:"requests"`
+	Concurrency       int     `json:"concurrency"`
+	Errors            int     `json:"errors"`
+	ErrorRate         float64 `json:"errorRate"`
+	DurationSeconds   float64 `json:"durationSeconds"`
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	P50Ms             float64 `json:"p50Ms"`
+	P95Ms             float64 `json:"p95Ms"`
+	P99Ms             float64 `json:"p99Ms"`
+	MaxMs             float64 `json:"maxMs"`
+}
+
+// run parses the flags, drives the load, and writes the report to out. It is
+// split from main so tests can aim it at an httptest server.
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	baseURL := flags.String("url", "http://localhost:8080", "base URL of the running instance")
+	path := flags.String("path", "/weather/forecast", "endpoint path to hit")
+	latitude := flags.Float64("latitude", 39.11539, "latitude query parameter")
+	longitude := flags.Float64("longitude", -107.65840, "longitude query parameter")
+	concurrency := flags.Int("concurrency", 8, "number of concurrent workers")
+	requests := flags.Int("requests", 200, "total number of requests to send")
+	spread := flags.Int("spread", 1, "number of distinct coordinates to rotate through")
+	timeout := flags.Duration("timeout", 30*time.Second, "per-request timeout")
+	format := flags.String("format", "text", "output format: text or json")
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *concurrency < 1 || *requests < 1 || *spread < 1 {
+		return fmt.Errorf("concurrency, requests, and spread must all be at least 1")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q, want text or json", *format)
+	}
+
+	urls := make([]string, *spread)
+	for i := range urls {
+		// 0.001° steps keep every coordinate on a distinct forecast cache key
+		urls[i] = fmt.Sprintf("%s%s?latitude=%.4f&longitude=%.4f",
+			*baseURL, *path, *latitude+float64(i)*0.001, *longitude)
+	}
+
+	result := fire(urls, *requests, *concurrency, *timeout)
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	default:
+		writeText(out, result)
+		return nil
+	}
+}
+
+// fire sends the requests from a worker pool and aggregates the results
+func fire(urls []string, requests, concurrency int, timeout time.Duration) report {
+	client := &http.Client{Timeout: timeout}
+	work := make(chan string, requests)
+	for i := 0; i < requests; i++ {
+		work <- urls[i%len(urls)]
+	}
+	close(work)
+
+	var mu sync.Mutex
+	latencies := make([]time.Duration, 0, requests)
+	errors := 0
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range work {
+				began := time.Now()
+				ok := doRequest(client, url)
+				elapsed := time.Since(began)
+
+				mu.Lock()
+				latencies = append(latencies, elapsed)
+				if !ok {
+					errors++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	wall := time.Since(start)
+
+	sort.Slice(latencies, func(a, b int) bool { return latencies[a] < latencies[b] })
+	return report{
+		Requests:          requests,
+		Concurrency:       concurrency,
+		Errors:            errors,
+		ErrorRate:         float64(errors) / float64(requests),
+		DurationSeconds:   wall.Seconds(),
+		RequestsPerSecond: float64(requests) / wall.Seconds(),
+		P50Ms:             percentileMs(latencies, 50),
+		P95Ms:             percentileMs(latencies, 95),
+		P99Ms:             percentileMs(latencies, 99),
+		MaxMs:             float64(latencies[len(latencies)-1]) / float64(time.Millisecond),
+	}
+}
+
+// doRequest issues one request, draining the body so connections are reused.
+// Transport failures and non-200 statuses both count as errors.
+func doRequest(client *http.Client, url string) bool {
+	resp, err := client.Get(url)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode == http.StatusOK
+}
+
+// percentileMs reads the pth percentile from sorted latencies, in
+// milliseconds, using the nearest-rank method
+func percentileMs(sorted []time.Duration, p float64) float64 {
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return float64(sorted[rank]) / float64(time.Millisecond)
+}
+
+// writeText renders the report for a terminal
+func writeText(out io.Writer, r report) {
+	fmt.Fprintf(out, "%d requests, %d concurrent: %.1f req/s over %.1fs\n",
+		r.Requests, r.Concurrency, r.RequestsPerSecond, r.DurationSeconds)
+	fmt.Fprintf(out, "latency p50 %.1fms  p95 %.1fms  p99 %.1fms  max %.1fms\n",
+		r.P50Ms, r.P95Ms, r.P99Ms, r.MaxMs)
+	fmt.Fprintf(out, "errors: %d (%.1f%%)\n", r.Errors, r.ErrorRate*100)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunReportsAgainstTestServer(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every fourth request fails so the error rate is observable
+		if calls.Add(1)%4 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	err := run([]string{
+		"--url", server.URL,
+		"--requests", "40",
+		"--concurrency", "4",
+		"--format", "json",
+	}, &out)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	var r report
+	if err := json.Unmarshal(out.Bytes(), &r); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if r.Requests != 40 || r.Concurrency != 4 {
+		t.Errorf("report = %d requests at %d concurrent, want 40 at 4", r.Requests, r.Concurrency)
+	}
+	if r.Errors != 10 {
+		t.Errorf("Errors = %d, want 10 with every fourth request failing", r.Errors)
+	}
+	if r.ErrorRate != 0.25 {
+		t.Errorf("ErrorRate = %v, want 0.25", r.ErrorRate)
+	}
+	if r.P50Ms <= 0 || r.P99Ms < r.P50Ms {
+		t.Errorf("implausible percentiles: p50 %v, p99 %v", r.P50Ms, r.P99Ms)
+	}
+}
+
+func TestRunSpreadRotatesCoordinates(t *testing.T) {
+	// A single worker serializes the requests, so no locking is needed
+	seen := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen[r.URL.Query().Get("latitude")] = true
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	err := run([]string{
+		"--url", server.URL,
+		"--requests", "12",
+		"--concurrency", "1",
+		"--spread", "3",
+	}, &out)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("server saw %d distinct latitudes, want 3: %v", len(seen), seen)
+	}
+	if !strings.Contains(out.String(), "errors: 0") {
+		t.Errorf("text report missing error line: %s", out.String())
+	}
+}
+
+func TestRunRejectsBadFlags(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"--requests", "0"}, &out); err == nil {
+		t.Error("zero requests should be rejected")
+	}
+	if err := run([]string{"--format", "xml"}, &out); err == nil {
+		t.Error("unknown format should be rejected")
+	}
+}
+
+func TestPercentileMs(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for _, tt := range []struct {
+		p    float64
+		want float64
+	}{
+		{50, 50}, {95, 95}, {99, 99}, {100, 100},
+	} {
+		if got := percentileMs(sorted, tt.p); got != tt.want {
+			t.Errorf("percentileMs(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentileMs([]time.Duration{7 * time.Millisecond}, 50); got != 7 {
+		t.Errorf("single-sample percentile = %v, want 7", got)
+	}
+}
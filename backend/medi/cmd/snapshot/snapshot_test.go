@@ -3,6 +3,7 @@
 package snapshot
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -92,7 +93,7 @@ func TestCaptureSnapshots(t *testing.T) {
 	// --- OpenMeteo forecast ---
 	t.Run("openmeteo_forecast", func(t *testing.T) {
 		client := openmeteo.NewClient(logger)
-		resp, err := client.GetForecast(aspenLat, aspenLon, 2743.5*0.3048, 16, "America/Denver")
+		resp, err := client.GetForecast(context.Background(), aspenLat, aspenLon, 2743.5*0.3048, 16, "America/Denver")
 		if err != nil {
 			t.Fatalf("openmeteo GetForecast: %v", err)
 		}
@@ -0,0 +1,121 @@
+// Package alert renders outbound alert payloads. Receivers want different
+// shapes — Slack incoming webhooks take {"text": ...}, ntfy takes a plain
+// body — so the payload is a Go text/template rendered against a small
+// documented context, chosen from a library of named presets or supplied as a
+// custom template validated up front.
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// maxPayloadBytes caps rendered payload size; a runaway template should fail
+// loudly rather than push megabytes at a webhook receiver.
+const maxPayloadBytes = 16 << 10
+
+// Context is what a payload template renders against. Every field is
+// available as {{.Name}}; JSON-target templates should pass values through
+// the json function to keep user-controlled strings escaped.
+type Context struct {
+	// LocationName is the configured name of the alerting location
+	LocationName string
+	// Metric names what crossed the threshold, e.g. snowfall24hInches
+	Metric string
+	// Threshold is the configured trigger value
+	Threshold float64
+	// Actual is the forecast value that crossed it
+	Actual float64
+	// ForecastLink points at the full forecast for the location
+	ForecastLink string
+}
+
+// SampleContext is the context new templates are validated against, so a
+// template that references an unknown field or renders over the size cap is
+// rejected when the rule is created rather than when an alert fires.
+var SampleContext = Context{
+	LocationName: "Berthoud Pass",
+	Metric:       "snowfall24hInches",
+	Threshold:    6,
+	Actual:       9.5,
+	ForecastLink: "https://example.com/weather/forecast?latitude=39.7980&longitude=-105.7770",
+}
+
+// presets is the library of named payload shapes selectable instead of a
+// custom template. JSON-target presets escape every interpolated value
+// through the json function.
+var presets = map[string]string{
+	"slack": `{"text": {{json (printf "%s: %s %.1f crossed %.1f — %s" .LocationName .Metric .Actual .Threshold .ForecastLink)}}}`,
+	"ntfy": `{{.LocationName}}: {{.Metric}} {{printf "%.1f" .Actual}} crossed {{printf "%.1f" .Threshold}}
+{{.ForecastLink}}`,
+	"plain": `{{.LocationName}}: {{.Metric}} {{printf "%.1f" .Actual}} crossed threshold {{printf "%.1f" .Threshold}} ({{.ForecastLink}})`,
+}
+
+// funcs are the helpers available to payload templates. json marshals its
+// argument, which both quotes and escapes strings for JSON-target payloads.
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// PresetNames lists the available presets, sorted
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PayloadTemplate is a validated, ready-to-render payload shape
+type PayloadTemplate struct {
+	tmpl *template.Template
+}
+
+// NewPayloadTemplate builds a payload template from a preset name or, when
+// custom is non-empty, a caller-supplied template string. Custom templates
+// are parsed and rendered against SampleContext here, so an invalid one is
+// rejected with its parse or render error at creation time.
+func NewPayloadTemplate(preset, custom string) (*PayloadTemplate, error) {
+	text := custom
+	if text == "" {
+		name := preset
+		if name == "" {
+			name = "plain"
+		}
+		var ok bool
+		text, ok = presets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown alert payload preset %q, want one of %v", name, PresetNames())
+		}
+	}
+
+	tmpl, err := template.New("payload").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse alert payload template: %w", err)
+	}
+
+	payload := &PayloadTemplate{tmpl: tmpl}
+	if _, err := payload.Render(SampleContext); err != nil {
+		return nil, fmt.Errorf("alert payload template failed validation: %w", err)
+	}
+	return payload, nil
+}
+
+// Render executes the template against the context, enforcing the size cap
+func (p *PayloadTemplate) Render(ctx Context) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, ctx); err != nil {
+		return nil, fmt.Errorf("failed to render alert payload: %w", err)
+	}
+	if buf.Len() > maxPayloadBytes {
+		return nil, fmt.Errorf("alert payload is %d bytes, over the %d byte cap", buf.Len(), maxPayloadBytes)
+	}
+	return buf.Bytes(), nil
+}
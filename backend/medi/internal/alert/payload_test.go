@@ -0,0 +1,137 @@
+package alert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSlackPresetRendersValidJSON(t *testing.T) {
+	payloadTemplate, err := NewPayloadTemplate("slack", "")
+	if err != nil {
+		t.Fatalf("slack preset failed to build: %v", err)
+	}
+
+	payload, err := payloadTemplate.Render(SampleContext)
+	if err != nil {
+		t.Fatalf("slack preset failed to render: %v", err)
+	}
+
+	var message struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(payload, &message); err != nil {
+		t.Fatalf("slack payload is not valid JSON: %v\n%s", err, payload)
+	}
+	for _, want := range []string{SampleContext.LocationName, SampleContext.Metric, SampleContext.ForecastLink} {
+		if !strings.Contains(message.Text, want) {
+			t.Errorf("slack text %q missing %q", message.Text, want)
+		}
+	}
+}
+
+func TestSlackPresetEscapesUserContent(t *testing.T) {
+	payloadTemplate, err := NewPayloadTemplate("slack", "")
+	if err != nil {
+		t.Fatalf("slack preset failed to build: %v", err)
+	}
+
+	// A location name full of JSON metacharacters must not break the payload
+	hostile := SampleContext
+	hostile.LocationName = `Corbet's "Couloir" \ {"injected": true}` + "\n"
+	payload, err := payloadTemplate.Render(hostile)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	var message struct {
+		Text     string `json:"text"`
+		Injected *bool  `json:"injected"`
+	}
+	if err := json.Unmarshal(payload, &message); err != nil {
+		t.Fatalf("hostile location name broke the JSON payload: %v\n%s", err, payload)
+	}
+	if message.Injected != nil {
+		t.Errorf("user content injected a JSON field: %s", payload)
+	}
+	if !strings.Contains(message.Text, `Corbet's "Couloir"`) {
+		t.Errorf("escaping mangled the location name: %q", message.Text)
+	}
+}
+
+func TestTextPresetsRender(t *testing.T) {
+	for _, preset := range []string{"ntfy", "plain"} {
+		payloadTemplate, err := NewPayloadTemplate(preset, "")
+		if err != nil {
+			t.Fatalf("%s preset failed to build: %v", preset, err)
+		}
+		payload, err := payloadTemplate.Render(SampleContext)
+		if err != nil {
+			t.Fatalf("%s preset failed to render: %v", preset, err)
+		}
+		text := string(payload)
+		for _, want := range []string{SampleContext.LocationName, "9.5", "6.0", SampleContext.ForecastLink} {
+			if !strings.Contains(text, want) {
+				t.Errorf("%s payload %q missing %q", preset, text, want)
+			}
+		}
+	}
+}
+
+func TestNewPayloadTemplateRejectsInvalidCustom(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+	}{
+		{"parse error", `{{.LocationName`},
+		{"unknown field", `{{.NoSuchField}}`},
+		{"unknown function", `{{shout .Metric}}`},
+	}
+	for _, tc := range cases {
+		if _, err := NewPayloadTemplate("", tc.template); err == nil {
+			t.Errorf("%s: template %q accepted, want a validation error", tc.name, tc.template)
+		}
+	}
+}
+
+func TestNewPayloadTemplateRejectsUnknownPreset(t *testing.T) {
+	_, err := NewPayloadTemplate("discord", "")
+	if err == nil {
+		t.Fatal("unknown preset accepted")
+	}
+	if !strings.Contains(err.Error(), "discord") {
+		t.Errorf("error %q does not name the bad preset", err)
+	}
+}
+
+func TestCustomTemplateRenders(t *testing.T) {
+	payloadTemplate, err := NewPayloadTemplate("", `{"zone": {{json .LocationName}}, "inches": {{.Actual}}}`)
+	if err != nil {
+		t.Fatalf("custom template failed validation: %v", err)
+	}
+	payload, err := payloadTemplate.Render(Context{LocationName: "Loveland Pass", Actual: 12})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	var message struct {
+		Zone   string  `json:"zone"`
+		Inches float64 `json:"inches"`
+	}
+	if err := json.Unmarshal(payload, &message); err != nil {
+		t.Fatalf("custom payload is not valid JSON: %v\n%s", err, payload)
+	}
+	if message.Zone != "Loveland Pass" || message.Inches != 12 {
+		t.Errorf("payload = %+v, want Loveland Pass at 12 inches", message)
+	}
+}
+
+func TestRenderEnforcesSizeCap(t *testing.T) {
+	// The validation render against SampleContext already trips the cap for a
+	// template whose output is constant and oversized
+	oversized := strings.Repeat("x", maxPayloadBytes+1)
+	if _, err := NewPayloadTemplate("", oversized); err == nil {
+		t.Fatal("template rendering over the size cap accepted")
+	} else if !strings.Contains(err.Error(), "cap") {
+		t.Errorf("error %q does not mention the size cap", err)
+	}
+}
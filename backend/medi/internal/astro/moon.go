@@ -0,0 +1,234 @@
+// Package astro computes solar and lunar quantities locally, without any
+// external API. The formulas are the low-precision series from Meeus,
+// "Astronomical Algorithms" (2nd ed.), good to a few arcminutes for the moon
+// — a few minutes of clock time for rise and set events.
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+const degToRad = math.Pi / 180
+
+// The eight common lunar phase names.
+const (
+	PhaseNew            = "New Moon"
+	PhaseWaxingCrescent = "Waxing Crescent"
+	PhaseFirstQuarter   = "First Quarter"
+	PhaseWaxingGibbous  = "Waxing Gibbous"
+	PhaseFull           = "Full Moon"
+	PhaseWaningGibbous  = "Waning Gibbous"
+	PhaseLastQuarter    = "Last Quarter"
+	PhaseWaningCrescent = "Waning Crescent"
+)
+
+// MoonInfo describes the moon for one local calendar day at a location.
+type MoonInfo struct {
+	Phase        string     // one of the eight common phase names
+	Illumination float64    // illuminated fraction of the disk, 0..1, at local noon
+	Rise         *time.Time // nil when the moon does not rise this day
+	Set          *time.Time // nil when the moon does not set this day
+}
+
+// Moon computes the phase, illuminated fraction, and rise/set times of the
+// moon for the local calendar day containing date at the given coordinates.
+// Rise and set are returned in date's time zone. At high latitudes either
+// event can be absent when the moon stays above or below the horizon.
+func Moon(date time.Time, latitude, longitude float64) MoonInfo {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	noon := dayStart.Add(12 * time.Hour)
+
+	info := MoonInfo{
+		Phase:        phaseName(elongation(julianDay(noon))),
+		Illumination: illuminatedFraction(julianDay(noon)),
+	}
+	info.Rise, info.Set = riseSet(dayStart, latitude, longitude)
+	return info
+}
+
+// julianDay converts a time to the Julian day number.
+func julianDay(t time.Time) float64 {
+	return 2440587.5 + float64(t.UnixMilli())/86400000.0
+}
+
+// centuries converts a Julian day to Julian centuries from J2000.0.
+func centuries(jd float64) float64 {
+	return (jd - 2451545.0) / 36525.0
+}
+
+// normalizeDegrees wraps an angle into [0, 360).
+func normalizeDegrees(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+// moonPosition returns the geocentric ecliptic longitude and latitude of the
+// moon in degrees plus its horizontal parallax, from the low-precision series
+// in the Astronomical Almanac (accurate to roughly 0.3 degrees).
+func moonPosition(jd float64) (lon, lat, parallax float64) {
+	t := centuries(jd)
+
+	lon = 218.32 + 481267.881*t +
+		6.29*math.Sin((135.0+477198.87*t)*degToRad) -
+		1.27*math.Sin((259.3-413335.36*t)*degToRad) +
+		0.66*math.Sin((235.7+890534.22*t)*degToRad) +
+		0.21*math.Sin((269.9+954397.74*t)*degToRad) -
+		0.19*math.Sin((357.5+35999.05*t)*degToRad) -
+		0.11*math.Sin((186.5+966404.03*t)*degToRad)
+
+	lat = 5.13*math.Sin((93.3+483202.02*t)*degToRad) +
+		0.28*math.Sin((228.2+960400.89*t)*degToRad) -
+		0.28*math.Sin((318.3+6003.15*t)*degToRad) -
+		0.17*math.Sin((217.6-407332.21*t)*degToRad)
+
+	parallax = 0.9508 +
+		0.0518*math.Cos((135.0+477198.87*t)*degToRad) +
+		0.0095*math.Cos((259.3-413335.36*t)*degToRad) +
+		0.0078*math.Cos((235.7+890534.22*t)*degToRad) +
+		0.0028*math.Cos((269.9+954397.74*t)*degToRad)
+
+	return normalizeDegrees(lon), lat, parallax
+}
+
+// sunLongitude returns the true ecliptic longitude of the sun in degrees.
+func sunLongitude(jd float64) float64 {
+	t := centuries(jd)
+	meanLongitude := 280.46646 + 36000.76983*t
+	meanAnomaly := (357.52911 + 35999.05029*t) * degToRad
+	center := 1.914602*math.Sin(meanAnomaly) +
+		0.019993*math.Sin(2*meanAnomaly) +
+		0.000289*math.Sin(3*meanAnomaly)
+	return normalizeDegrees(meanLongitude + center)
+}
+
+// elongation returns the moon's ecliptic longitude minus the sun's, in
+// [0, 360): 0 at new moon, 180 at full, waxing below 180 and waning above.
+func elongation(jd float64) float64 {
+	moonLon, _, _ := moonPosition(jd)
+	return normalizeDegrees(moonLon - sunLongitude(jd))
+}
+
+// illuminatedFraction returns the sunlit fraction of the lunar disk using the
+// phase-angle series from Meeus ch. 48.
+func illuminatedFraction(jd float64) float64 {
+	t := centuries(jd)
+	d := (297.8501921 + 445267.1114034*t) * degToRad  // mean elongation
+	m := (357.5291092 + 35999.0502909*t) * degToRad   // sun mean anomaly
+	mp := (134.9633964 + 477198.8675055*t) * degToRad // moon mean anomaly
+
+	phaseAngle := 180 - d/degToRad -
+		6.289*math.Sin(mp) +
+		2.100*math.Sin(m) -
+		1.274*math.Sin(2*d-mp) -
+		0.658*math.Sin(2*d) -
+		0.214*math.Sin(2*mp) -
+		0.110*math.Sin(d)
+
+	return (1 + math.Cos(phaseAngle*degToRad)) / 2
+}
+
+// phaseName buckets the sun-moon elongation into the eight common phases,
+// each spanning 45 degrees centered on its principal angle.
+func phaseName(elongation float64) string {
+	names := []string{
+		PhaseNew, PhaseWaxingCrescent, PhaseFirstQuarter, PhaseWaxingGibbous,
+		PhaseFull, PhaseWaningGibbous, PhaseLastQuarter, PhaseWaningCrescent,
+	}
+	index := int(math.Floor(normalizeDegrees(elongation+22.5)/45)) % 8
+	return names[index]
+}
+
+// obliquity returns the mean obliquity of the ecliptic in degrees.
+func obliquity(jd float64) float64 {
+	return 23.4393 - 0.0130*centuries(jd)
+}
+
+// equatorial converts ecliptic coordinates to right ascension and
+// declination, all in degrees.
+func equatorial(jd, eclipticLon, eclipticLat float64) (ra, dec float64) {
+	eps := obliquity(jd) * degToRad
+	lonRad := eclipticLon * degToRad
+	latRad := eclipticLat * degToRad
+
+	sinDec := math.Sin(latRad)*math.Cos(eps) + math.Cos(latRad)*math.Sin(eps)*math.Sin(lonRad)
+	dec = math.Asin(sinDec) / degToRad
+
+	y := math.Sin(lonRad)*math.Cos(eps) - math.Tan(latRad)*math.Sin(eps)
+	ra = normalizeDegrees(math.Atan2(y, math.Cos(lonRad)) / degToRad)
+	return ra, dec
+}
+
+// siderealTime returns the Greenwich mean sidereal time in degrees.
+func siderealTime(jd float64) float64 {
+	t := centuries(jd)
+	return normalizeDegrees(280.46061837 + 360.98564736629*(jd-2451545.0) + 0.000387933*t*t)
+}
+
+// moonAltitude returns the geocentric altitude of the moon in degrees for an
+// observer at the given coordinates, along with its horizontal parallax.
+func moonAltitude(jd, latitude, longitude float64) (altitude, parallax float64) {
+	eclipticLon, eclipticLat, parallax := moonPosition(jd)
+	ra, dec := equatorial(jd, eclipticLon, eclipticLat)
+	return altitudeFrom(jd, latitude, longitude, ra, dec), parallax
+}
+
+// altitudeFrom returns the altitude in degrees of a body at the given right
+// ascension and declination for an observer at the given coordinates.
+func altitudeFrom(jd, latitude, longitude, ra, dec float64) float64 {
+	hourAngle := (siderealTime(jd) + longitude - ra) * degToRad
+	latRad := latitude * degToRad
+	decRad := dec * degToRad
+
+	sinAlt := math.Sin(latRad)*math.Sin(decRad) + math.Cos(latRad)*math.Cos(decRad)*math.Cos(hourAngle)
+	return math.Asin(sinAlt) / degToRad
+}
+
+// riseSetStep is the altitude sampling interval when scanning for horizon
+// crossings; the crossing itself is refined by linear interpolation.
+const riseSetStep = 5 * time.Minute
+
+// riseSet scans the local day starting at dayStart for the moon crossing its
+// standard rise/set altitude. Either event may be absent at high latitudes.
+func riseSet(dayStart time.Time, latitude, longitude float64) (rise, set *time.Time) {
+	// The corrected altitude is the geocentric altitude minus the standard
+	// event altitude: parallax brings the moon down, refraction lifts it
+	return crossings(dayStart, func(t time.Time) float64 {
+		altitude, parallax := moonAltitude(julianDay(t), latitude, longitude)
+		return altitude - (0.7275*parallax - 0.566)
+	})
+}
+
+// crossings scans the local day starting at dayStart for the altitude
+// function's first upward and first downward zero crossing. Either may be
+// absent when the body stays above or below the event altitude all day.
+func crossings(dayStart time.Time, altitude func(time.Time) float64) (up, down *time.Time) {
+	previousTime := dayStart
+	previous := altitude(previousTime)
+	for elapsed := riseSetStep; elapsed <= 24*time.Hour; elapsed += riseSetStep {
+		currentTime := dayStart.Add(elapsed)
+		current := altitude(currentTime)
+
+		if previous < 0 && current >= 0 && up == nil {
+			crossing := interpolateCrossing(previousTime, previous, current)
+			up = &crossing
+		}
+		if previous >= 0 && current < 0 && down == nil {
+			crossing := interpolateCrossing(previousTime, previous, current)
+			down = &crossing
+		}
+
+		previousTime, previous = currentTime, current
+	}
+	return up, down
+}
+
+// interpolateCrossing locates the zero crossing between two altitude samples
+// one riseSetStep apart.
+func interpolateCrossing(start time.Time, before, after float64) time.Time {
+	fraction := before / (before - after)
+	return start.Add(time.Duration(fraction * float64(riseSetStep))).Round(time.Minute)
+}
@@ -0,0 +1,122 @@
+package astro
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// localDay parses a date in the named zone.
+func localDay(t *testing.T, date, tz string) time.Time {
+	t.Helper()
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		t.Fatalf("failed to load location %s: %v", tz, err)
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		t.Fatalf("failed to parse date %s: %v", date, err)
+	}
+	return day
+}
+
+// assertEventNear checks a rise/set time against an almanac value.
+func assertEventNear(t *testing.T, name string, got *time.Time, want string, tolerance time.Duration) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("%s is nil, want %s", name, want)
+	}
+	wantTime, err := time.ParseInLocation("2006-01-02 15:04", want, got.Location())
+	if err != nil {
+		t.Fatalf("bad want time %s: %v", want, err)
+	}
+	diff := got.Sub(wantTime)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > tolerance {
+		t.Errorf("%s = %s, want %s (off by %s)", name, got.Format("15:04"), want, diff)
+	}
+}
+
+// Almanac values below are for Denver, CO (39.7392, -104.9903); rise and set
+// must agree within a few minutes and illumination within a couple percent.
+func TestMoonDenverAlmanac(t *testing.T) {
+	const tolerance = 5 * time.Minute
+
+	// Full moon (2025-01-13 22:27 UTC)
+	full := Moon(localDay(t, "2025-01-13", "America/Denver"), 39.7392, -104.9903)
+	if full.Phase != PhaseFull {
+		t.Errorf("2025-01-13 phase = %s, want %s", full.Phase, PhaseFull)
+	}
+	if math.Abs(full.Illumination-1.0) > 0.02 {
+		t.Errorf("2025-01-13 illumination = %.3f, want ~1.0", full.Illumination)
+	}
+	assertEventNear(t, "moonrise", full.Rise, "2025-01-13 16:41", tolerance)
+	assertEventNear(t, "moonset", full.Set, "2025-01-13 07:32", tolerance)
+
+	// New moon (2025-01-29 12:36 UTC)
+	new := Moon(localDay(t, "2025-01-29", "America/Denver"), 39.7392, -104.9903)
+	if new.Phase != PhaseNew {
+		t.Errorf("2025-01-29 phase = %s, want %s", new.Phase, PhaseNew)
+	}
+	if new.Illumination > 0.02 {
+		t.Errorf("2025-01-29 illumination = %.3f, want ~0", new.Illumination)
+	}
+	assertEventNear(t, "moonrise", new.Rise, "2025-01-29 07:37", tolerance)
+	assertEventNear(t, "moonset", new.Set, "2025-01-29 17:36", tolerance)
+
+	// First quarter (2025-01-06 23:56 UTC); the moon sets after midnight, so
+	// this local day has a rise but no set
+	quarter := Moon(localDay(t, "2025-01-06", "America/Denver"), 39.7392, -104.9903)
+	if quarter.Phase != PhaseFirstQuarter {
+		t.Errorf("2025-01-06 phase = %s, want %s", quarter.Phase, PhaseFirstQuarter)
+	}
+	if math.Abs(quarter.Illumination-0.48) > 0.02 {
+		t.Errorf("2025-01-06 illumination = %.3f, want ~0.48", quarter.Illumination)
+	}
+	assertEventNear(t, "moonrise", quarter.Rise, "2025-01-06 11:21", tolerance)
+	if quarter.Set != nil {
+		t.Errorf("2025-01-06 moonset = %s, want none before midnight", quarter.Set.Format("15:04"))
+	}
+}
+
+// At Tromsø (69.65 N) the winter full moon is circumpolar: it neither rises
+// nor sets around full, and near last quarter it only sets.
+func TestMoonHighLatitude(t *testing.T) {
+	circumpolar := Moon(localDay(t, "2025-01-13", "Europe/Oslo"), 69.6492, 18.9553)
+	if circumpolar.Rise != nil || circumpolar.Set != nil {
+		t.Errorf("2025-01-13 Tromsø rise/set = %v/%v, want none (moon above horizon all day)",
+			circumpolar.Rise, circumpolar.Set)
+	}
+
+	setOnly := Moon(localDay(t, "2025-01-20", "Europe/Oslo"), 69.6492, 18.9553)
+	if setOnly.Rise != nil {
+		t.Errorf("2025-01-20 Tromsø moonrise = %s, want none", setOnly.Rise.Format("15:04"))
+	}
+	assertEventNear(t, "moonset", setOnly.Set, "2025-01-20 09:18", 5*time.Minute)
+}
+
+func TestPhaseNameBuckets(t *testing.T) {
+	tests := []struct {
+		elongation float64
+		want       string
+	}{
+		{0, PhaseNew},
+		{350, PhaseNew},
+		{45, PhaseWaxingCrescent},
+		{90, PhaseFirstQuarter},
+		{135, PhaseWaxingGibbous},
+		{180, PhaseFull},
+		{225, PhaseWaningGibbous},
+		{270, PhaseLastQuarter},
+		{315, PhaseWaningCrescent},
+	}
+	for _, tc := range tests {
+		if got := phaseName(tc.elongation); got != tc.want {
+			t.Errorf("phaseName(%v) = %s, want %s", tc.elongation, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package astro
+
+import (
+	"time"
+)
+
+// Event altitudes below the geometric horizon, in degrees. Sunrise and sunset
+// use the conventional 0.833 (refraction plus the solar semidiameter); civil
+// twilight runs until the center of the sun is 6 degrees down.
+const (
+	sunStandardAltitude   = 0.833
+	civilTwilightAltitude = 6.0
+)
+
+// SunInfo describes daylight for one local calendar day at a location.
+type SunInfo struct {
+	Rise      *time.Time    // nil when the sun does not rise this day
+	Set       *time.Time    // nil when the sun does not set this day
+	CivilDawn *time.Time    // morning start of civil twilight, nil when absent
+	CivilDusk *time.Time    // evening end of civil twilight, nil when absent
+	DayLength time.Duration // time with the sun above the horizon
+}
+
+// Sun computes sunrise, sunset, the civil twilight boundaries, and the day
+// length for the local calendar day containing date at the given coordinates.
+// Times are returned in date's time zone. At high latitudes any event can be
+// absent: during polar night the sun never rises but civil twilight may still
+// occur around midday.
+func Sun(date time.Time, latitude, longitude float64) SunInfo {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	altitude := func(t time.Time) float64 {
+		return sunAltitude(julianDay(t), latitude, longitude)
+	}
+
+	info := SunInfo{}
+	info.Rise, info.Set = crossings(dayStart, func(t time.Time) float64 {
+		return altitude(t) + sunStandardAltitude
+	})
+	info.CivilDawn, info.CivilDusk = crossings(dayStart, func(t time.Time) float64 {
+		return altitude(t) + civilTwilightAltitude
+	})
+	info.DayLength = dayLength(dayStart, info.Rise, info.Set, altitude)
+	return info
+}
+
+// sunAltitude returns the altitude of the sun in degrees for an observer at
+// the given coordinates.
+func sunAltitude(jd, latitude, longitude float64) float64 {
+	ra, dec := equatorial(jd, sunLongitude(jd), 0)
+	return altitudeFrom(jd, latitude, longitude, ra, dec)
+}
+
+// dayLength measures how long the sun spends above the horizon. With a clean
+// rise-then-set pair it is their difference; otherwise (polar day or night,
+// or a day with a single event) it falls back to sampling the altitude.
+func dayLength(dayStart time.Time, rise, set *time.Time, altitude func(time.Time) float64) time.Duration {
+	if rise != nil && set != nil && set.After(*rise) {
+		return set.Sub(*rise)
+	}
+
+	var up time.Duration
+	for elapsed := time.Duration(0); elapsed < 24*time.Hour; elapsed += riseSetStep {
+		if altitude(dayStart.Add(elapsed+riseSetStep/2))+sunStandardAltitude >= 0 {
+			up += riseSetStep
+		}
+	}
+	return up
+}
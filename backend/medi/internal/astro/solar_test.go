@@ -0,0 +1,58 @@
+package astro
+
+import (
+	"testing"
+	"time"
+)
+
+// NOAA solar calculator values for Denver, CO (39.7392, -104.9903); events
+// must agree within a few minutes.
+func TestSunDenverNOAA(t *testing.T) {
+	const tolerance = 4 * time.Minute
+
+	// Midwinter day
+	winter := Sun(localDay(t, "2025-01-13", "America/Denver"), 39.7392, -104.9903)
+	assertEventNear(t, "civil dawn", winter.CivilDawn, "2025-01-13 06:50", tolerance)
+	assertEventNear(t, "sunrise", winter.Rise, "2025-01-13 07:20", tolerance)
+	assertEventNear(t, "sunset", winter.Set, "2025-01-13 16:58", tolerance)
+	assertEventNear(t, "civil dusk", winter.CivilDusk, "2025-01-13 17:28", tolerance)
+	if diff := (winter.DayLength - (9*time.Hour + 38*time.Minute)).Abs(); diff > 8*time.Minute {
+		t.Errorf("winter day length = %s, want ~9h38m", winter.DayLength)
+	}
+
+	// Summer solstice
+	summer := Sun(localDay(t, "2025-06-21", "America/Denver"), 39.7392, -104.9903)
+	assertEventNear(t, "civil dawn", summer.CivilDawn, "2025-06-21 04:59", tolerance)
+	assertEventNear(t, "sunrise", summer.Rise, "2025-06-21 05:32", tolerance)
+	assertEventNear(t, "sunset", summer.Set, "2025-06-21 20:31", tolerance)
+	assertEventNear(t, "civil dusk", summer.CivilDusk, "2025-06-21 21:04", tolerance)
+	if diff := (summer.DayLength - 15*time.Hour).Abs(); diff > 8*time.Minute {
+		t.Errorf("summer day length = %s, want ~15h", summer.DayLength)
+	}
+}
+
+// Tromsø, Norway (69.6492, 18.9553) covers the polar cases: midwinter has
+// civil twilight around midday but no sunrise, midsummer has neither event
+// and a 24-hour day.
+func TestSunHighLatitude(t *testing.T) {
+	const tolerance = 6 * time.Minute
+
+	polarNight := Sun(localDay(t, "2025-01-13", "Europe/Oslo"), 69.6492, 18.9553)
+	if polarNight.Rise != nil || polarNight.Set != nil {
+		t.Error("2025-01-13 Tromsø: expected no sunrise or sunset during polar night")
+	}
+	assertEventNear(t, "civil dawn", polarNight.CivilDawn, "2025-01-13 09:02", tolerance)
+	assertEventNear(t, "civil dusk", polarNight.CivilDusk, "2025-01-13 14:45", tolerance)
+	if polarNight.DayLength != 0 {
+		t.Errorf("polar night day length = %s, want 0", polarNight.DayLength)
+	}
+
+	midnightSun := Sun(localDay(t, "2025-06-21", "Europe/Oslo"), 69.6492, 18.9553)
+	if midnightSun.Rise != nil || midnightSun.Set != nil ||
+		midnightSun.CivilDawn != nil || midnightSun.CivilDusk != nil {
+		t.Error("2025-06-21 Tromsø: expected no solar events under the midnight sun")
+	}
+	if midnightSun.DayLength != 24*time.Hour {
+		t.Errorf("midnight sun day length = %s, want 24h", midnightSun.DayLength)
+	}
+}
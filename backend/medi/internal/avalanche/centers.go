@@ -0,0 +1,111 @@
+package avalanche
+
+import (
+	"sort"
+)
+
+// centerEnrichment carries the details the map layer does not publish about
+// an avalanche center. The table is maintained by hand; centers missing from
+// it simply have these fields empty in the directory.
+type centerEnrichment struct {
+	City        string
+	SeasonStart string // month the center typically starts forecasting
+	SeasonEnd   string // month the center typically stops
+}
+
+var centerEnrichments = map[string]centerEnrichment{
+	"BTAC":   {City: "Jackson", SeasonStart: "November", SeasonEnd: "April"},
+	"CAIC":   {City: "Boulder", SeasonStart: "October", SeasonEnd: "May"},
+	"CBAC":   {City: "Crested Butte", SeasonStart: "November", SeasonEnd: "April"},
+	"CNFAIC": {City: "Girdwood", SeasonStart: "November", SeasonEnd: "April"},
+	"ESAC":   {City: "Mammoth Lakes", SeasonStart: "November", SeasonEnd: "April"},
+	"GNFAC":  {City: "Bozeman", SeasonStart: "October", SeasonEnd: "April"},
+	"NWAC":   {City: "Seattle", SeasonStart: "November", SeasonEnd: "April"},
+	"SNFAC":  {City: "Ketchum", SeasonStart: "November", SeasonEnd: "April"},
+	"UAC":    {City: "Salt Lake City", SeasonStart: "November", SeasonEnd: "April"},
+}
+
+// Center is one avalanche center in the national directory, aggregated from
+// its forecast zones plus the enrichment table.
+type Center struct {
+	Id   string
+	Name string
+	URL  string
+	// States lists every state the center's zones fall in, sorted
+	States    []string
+	City      string
+	ZoneCount int
+	// SeasonStart and SeasonEnd name the months the center typically issues
+	// forecasts, empty for centers outside the enrichment table
+	SeasonStart string
+	SeasonEnd   string
+	// WarningActive is set when any of the center's zones currently carries
+	// an avalanche warning product
+	WarningActive bool
+}
+
+// CenterList is the national avalanche center directory.
+type CenterList struct {
+	Centers []Center
+	// Freshness reports the age of the map-layer snapshot the directory was
+	// built from
+	Freshness MapLayerFreshness
+}
+
+// GetCenters assembles the center directory from the map layer, deduplicating
+// zone properties per center and joining the enrichment table. Centers are
+// sorted by id.
+func (s *avalancheService) GetCenters() (*CenterList, error) {
+	mapLayer, freshness, err := s.getMapLayer()
+	if err != nil {
+		return nil, err
+	}
+
+	byId := map[string]*Center{}
+	states := map[string]map[string]bool{}
+	for i := range mapLayer.Features {
+		props := &mapLayer.Features[i].Properties
+		if props.CenterId == "" {
+			continue
+		}
+
+		center, ok := byId[props.CenterId]
+		if !ok {
+			enrichment := centerEnrichments[props.CenterId]
+			center = &Center{
+				Id:          props.CenterId,
+				City:        enrichment.City,
+				SeasonStart: enrichment.SeasonStart,
+				SeasonEnd:   enrichment.SeasonEnd,
+			}
+			byId[props.CenterId] = center
+			states[props.CenterId] = map[string]bool{}
+		}
+
+		center.ZoneCount++
+		if center.Name == "" {
+			center.Name = props.Center
+		}
+		if center.URL == "" {
+			center.URL = props.CenterLink
+		}
+		if props.State != "" {
+			states[props.CenterId][props.State] = true
+		}
+		if props.Warning.Product != "" {
+			center.WarningActive = true
+		}
+	}
+
+	list := &CenterList{Centers: make([]Center, 0, len(byId)), Freshness: freshness}
+	for id, center := range byId {
+		for state := range states[id] {
+			center.States = append(center.States, state)
+		}
+		sort.Strings(center.States)
+		list.Centers = append(list.Centers, *center)
+	}
+	sort.Slice(list.Centers, func(i, j int) bool { return list.Centers[i].Id < list.Centers[j].Id })
+
+	return list, nil
+}
@@ -0,0 +1,147 @@
+package avalanche
+
+import (
+	"encoding/json"
+	"testing"
+
+	"medi/internal/providers/nac"
+)
+
+// centersTestMapLayer builds a map layer with zones from three centers: two
+// CAIC zones (one under warning), one NWAC zone, and one zone from a center
+// absent from the enrichment table
+func centersTestMapLayer(t *testing.T) *nac.MapLayerResponse {
+	t.Helper()
+
+	raw := `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"id": 100,
+				"type": "Feature",
+				"properties": {
+					"name": "Front Range",
+					"center": "Colorado Avalanche Information Center",
+					"center_link": "https://avalanche.state.co.us/",
+					"center_id": "CAIC",
+					"state": "CO",
+					"warning": {"product": "warning"}
+				}
+			},
+			{
+				"id": 101,
+				"type": "Feature",
+				"properties": {
+					"name": "Vail Summit",
+					"center": "Colorado Avalanche Information Center",
+					"center_link": "https://avalanche.state.co.us/",
+					"center_id": "CAIC",
+					"state": "CO",
+					"warning": {"product": null}
+				}
+			},
+			{
+				"id": 200,
+				"type": "Feature",
+				"properties": {
+					"name": "West Slopes North",
+					"center": "Northwest Avalanche Center",
+					"center_link": "https://nwac.us/",
+					"center_id": "NWAC",
+					"state": "WA",
+					"warning": {"product": null}
+				}
+			},
+			{
+				"id": 300,
+				"type": "Feature",
+				"properties": {
+					"name": "Unlisted Peaks",
+					"center": "Unlisted Avalanche Center",
+					"center_link": "https://unlisted.example.org/",
+					"center_id": "ULAC",
+					"state": "NH",
+					"warning": {"product": null}
+				}
+			}
+		]
+	}`
+
+	var mapLayer nac.MapLayerResponse
+	if err := json.Unmarshal([]byte(raw), &mapLayer); err != nil {
+		t.Fatalf("failed to unmarshal map layer fixture: %v", err)
+	}
+	return &mapLayer
+}
+
+func TestGetCenters(t *testing.T) {
+	svc := zonesTestService(t, centersTestMapLayer(t))
+
+	list, err := svc.GetCenters()
+	if err != nil {
+		t.Fatalf("GetCenters failed: %v", err)
+	}
+	if len(list.Centers) != 3 {
+		t.Fatalf("got %d centers, want 3 after dedup", len(list.Centers))
+	}
+
+	// Sorted by id: CAIC, NWAC, ULAC
+	caic := list.Centers[0]
+	if caic.Id != "CAIC" {
+		t.Fatalf("first center id = %s, want CAIC", caic.Id)
+	}
+	if caic.Name != "Colorado Avalanche Information Center" {
+		t.Errorf("CAIC name = %q", caic.Name)
+	}
+	if caic.URL != "https://avalanche.state.co.us/" {
+		t.Errorf("CAIC URL = %q", caic.URL)
+	}
+	if caic.ZoneCount != 2 {
+		t.Errorf("CAIC zone count = %d, want 2", caic.ZoneCount)
+	}
+	if len(caic.States) != 1 || caic.States[0] != "CO" {
+		t.Errorf("CAIC states = %v, want [CO]", caic.States)
+	}
+	if !caic.WarningActive {
+		t.Error("CAIC warning not flagged with a zone under warning")
+	}
+	if caic.City != "Boulder" || caic.SeasonStart != "October" || caic.SeasonEnd != "May" {
+		t.Errorf("CAIC enrichment = %q %q..%q", caic.City, caic.SeasonStart, caic.SeasonEnd)
+	}
+
+	nwac := list.Centers[1]
+	if nwac.Id != "NWAC" || nwac.ZoneCount != 1 || nwac.WarningActive {
+		t.Errorf("NWAC = %+v, want 1 zone, no warning", nwac)
+	}
+
+	// A center outside the enrichment table keeps its map layer fields and
+	// has empty enrichment details
+	unlisted := list.Centers[2]
+	if unlisted.Id != "ULAC" {
+		t.Fatalf("third center id = %s, want ULAC", unlisted.Id)
+	}
+	if unlisted.Name != "Unlisted Avalanche Center" || unlisted.URL != "https://unlisted.example.org/" {
+		t.Errorf("ULAC map layer fields = %q %q", unlisted.Name, unlisted.URL)
+	}
+	if unlisted.City != "" || unlisted.SeasonStart != "" || unlisted.SeasonEnd != "" {
+		t.Errorf("ULAC enrichment should be empty, got %q %q %q",
+			unlisted.City, unlisted.SeasonStart, unlisted.SeasonEnd)
+	}
+}
+
+func TestGetZonesCenterIdFilter(t *testing.T) {
+	svc := zonesTestService(t, centersTestMapLayer(t))
+
+	list, err := svc.GetZones(ZoneFilter{CenterId: "caic"})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+	if list.Total != 2 {
+		t.Fatalf("center filter matched %d zones, want 2", list.Total)
+	}
+	for _, zone := range list.Zones {
+		if zone.CenterId != "CAIC" {
+			t.Errorf("zone %d belongs to %s, want CAIC", zone.Id, zone.CenterId)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package avalanche
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"medi/internal/cache"
+	"medi/internal/providers/nac"
+	"medi/internal/types"
+)
+
+// incidentsCacheTTL refreshes the accident dataset daily; it changes rarely
+// and the upstream list is large.
+const incidentsCacheTTL = 24 * time.Hour
+
+// accidentsCacheKey is the single cache key for the full accident dataset.
+const accidentsCacheKey = "accidents"
+
+// GetIncidents returns incidents within radiusKm of the coordinates, sorted
+// by distance, plus a zone-level count of incidents that lack coordinates.
+func (s *avalancheService) GetIncidents(latitude, longitude, radiusKm float64, seasonOnly bool) (*IncidentSearch, error) {
+	incidents, err := s.incidentDataset()
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the forecast zone containing the query point so unlocated
+	// incidents in the same zone can still be surfaced. A map layer failure
+	// only loses the zone-level count, not the radius results.
+	var zoneName, centerId string
+	mapLayer, _, err := s.getMapLayer()
+	if err != nil {
+		s.logger.Warn("failed to get map layer for incident zone matching", "error", err)
+	} else if zone := nac.FindZone(latitude, longitude, mapLayer); zone != nil {
+		zoneName = zone.Properties.Name
+		centerId = zone.Properties.CenterId
+	}
+
+	var since time.Time
+	if seasonOnly {
+		since = seasonStart(time.Now())
+	}
+
+	return searchIncidents(incidents, latitude, longitude, radiusKm, zoneName, centerId, since), nil
+}
+
+// incidentDataset returns the cached accident dataset, refreshing it when the
+// daily TTL has expired and falling back to a stale copy if the refresh fails.
+func (s *avalancheService) incidentDataset() ([]Incident, error) {
+	entry, status := s.incidentsCache.Get(accidentsCacheKey)
+	if status == cache.StatusHit {
+		return entry.Value, nil
+	}
+
+	accidents, err := s.accidentProvider.GetAccidents()
+	if err != nil {
+		if status == cache.StatusStale {
+			s.logger.Warn("serving stale accident dataset after refresh failure", "error", err)
+			return entry.Value, nil
+		}
+		s.logger.Error("failed to get accidents", "error", err)
+		return nil, fmt.Errorf("failed to get accidents: %w", err)
+	}
+
+	incidents := mapAccidentsResponse(accidents)
+	s.incidentsCache.Set(accidentsCacheKey, incidents)
+	return incidents, nil
+}
+
+// searchIncidents filters incidents to those within radiusKm of the query
+// point and on or after since (zero disables the date filter), sorted nearest
+// first. Incidents without coordinates are excluded from the radius results
+// but counted when their zone matches the query point's zone.
+func searchIncidents(incidents []Incident, latitude, longitude, radiusKm float64, zoneName, centerId string, since time.Time) *IncidentSearch {
+	result := &IncidentSearch{
+		Incidents: []Incident{},
+		ZoneName:  zoneName,
+	}
+
+	for _, incident := range incidents {
+		if !since.IsZero() && incident.Date.Before(since) {
+			continue
+		}
+
+		if incident.Coordinates == nil {
+			if zoneName != "" && incident.ZoneName == zoneName && incident.CenterId == centerId {
+				result.UnlocatedInZone++
+			}
+			continue
+		}
+
+		distance := types.HaversineKm(latitude, longitude, incident.Coordinates.Latitude, incident.Coordinates.Longitude)
+		if distance > radiusKm {
+			continue
+		}
+
+		incident.DistanceKm = distance
+		result.Incidents = append(result.Incidents, incident)
+	}
+
+	sort.Slice(result.Incidents, func(i, j int) bool {
+		return result.Incidents[i].DistanceKm < result.Incidents[j].DistanceKm
+	})
+
+	return result
+}
+
+// seasonStart returns the start of the avalanche season containing now:
+// October 1st of the current season year.
+func seasonStart(now time.Time) time.Time {
+	year := now.Year()
+	if now.Month() < time.October {
+		year--
+	}
+	return time.Date(year, time.October, 1, 0, 0, 0, 0, time.UTC)
+}
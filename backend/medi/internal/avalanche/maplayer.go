@@ -0,0 +1,93 @@
+package avalanche
+
+import (
+	"fmt"
+	"time"
+
+	"medi/internal/cache"
+	"medi/internal/providers/nac"
+)
+
+// mapLayerSoftTTL is how long a fetched map layer counts as fresh. Past it
+// the cached copy is still served, marked stale, while a background refresh
+// runs — zone metadata should not 502 just because NAC is down.
+const mapLayerSoftTTL = 30 * time.Minute
+
+// mapLayerHardTTL is the oldest map layer any caller will accept. Past it
+// the stale copy is abandoned and a failing refresh becomes the caller's
+// error; day-old zone boundaries and danger ratings are worse than none.
+const mapLayerHardTTL = 24 * time.Hour
+
+// mapLayerCacheKey is the single key under which the national layer is cached
+const mapLayerCacheKey = "national"
+
+// MapLayerFreshness reports the age of the map-layer snapshot behind a
+// response, so clients can tell a live answer from one served through an
+// outage.
+type MapLayerFreshness struct {
+	// FetchedAt is when the snapshot was retrieved from NAC
+	FetchedAt time.Time
+	// AgeSeconds is how old the snapshot was when the response was built
+	AgeSeconds int
+	// Stale is set when the snapshot outlived its refresh interval and a
+	// background refresh is underway
+	Stale bool
+}
+
+// getMapLayer returns the cached national map layer under the two-tier
+// staleness policy: a fresh copy is served as-is, a copy past the soft TTL is
+// served marked stale while a refresh runs in the background, and a copy
+// past the hard TTL is discarded in favor of a synchronous fetch whose
+// failure the caller sees.
+func (s *avalancheService) getMapLayer() (*nac.MapLayerResponse, MapLayerFreshness, error) {
+	entry, status := s.mapLayerCache.Get(mapLayerCacheKey)
+	if status == cache.StatusHit {
+		return entry.Value, s.mapLayerFreshness(entry.FetchedAt, false), nil
+	}
+
+	age := s.now().Sub(entry.FetchedAt)
+	if status == cache.StatusStale && age <= mapLayerHardTTL {
+		s.refreshMapLayerAsync()
+		return entry.Value, s.mapLayerFreshness(entry.FetchedAt, true), nil
+	}
+
+	mapLayer, err := s.fetchMapLayer()
+	if err != nil {
+		return nil, MapLayerFreshness{}, fmt.Errorf("failed to get map layer: %w", err)
+	}
+	return mapLayer, s.mapLayerFreshness(s.now(), false), nil
+}
+
+func (s *avalancheService) mapLayerFreshness(fetchedAt time.Time, stale bool) MapLayerFreshness {
+	return MapLayerFreshness{
+		FetchedAt:  fetchedAt,
+		AgeSeconds: int(s.now().Sub(fetchedAt).Seconds()),
+		Stale:      stale,
+	}
+}
+
+// fetchMapLayer retrieves the layer from the provider and stores it
+func (s *avalancheService) fetchMapLayer() (*nac.MapLayerResponse, error) {
+	mapLayer, err := s.mapLayerProvider.GetMapLayer()
+	if err != nil {
+		s.logger.Error("failed to get map layer", "error", err)
+		return nil, err
+	}
+	s.mapLayerCache.Set(mapLayerCacheKey, mapLayer)
+	return mapLayer, nil
+}
+
+// refreshMapLayerAsync refreshes the cached layer in the background, at most
+// one refresh in flight; failures only log, the caller already holds a
+// servable copy
+func (s *avalancheService) refreshMapLayerAsync() {
+	if !s.mapLayerRefreshing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer s.mapLayerRefreshing.Store(false)
+		if _, err := s.fetchMapLayer(); err != nil {
+			s.logger.Warn("background map layer refresh failed", "error", err)
+		}
+	}()
+}
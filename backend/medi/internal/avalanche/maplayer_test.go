@@ -0,0 +1,149 @@
+package avalanche
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"medi/internal/providers/nac"
+)
+
+// flakyMapLayerProvider counts calls and fails on demand, safe for the
+// background refresh goroutine
+type flakyMapLayerProvider struct {
+	mu    sync.Mutex
+	fail  bool
+	calls int
+}
+
+func (p *flakyMapLayerProvider) GetMapLayer() (*nac.MapLayerResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.fail {
+		return nil, errors.New("nac unavailable")
+	}
+	return &nac.MapLayerResponse{}, nil
+}
+
+func (p *flakyMapLayerProvider) setFail(fail bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fail = fail
+}
+
+func (p *flakyMapLayerProvider) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// mapLayerTestService wires a service around the flaky provider with a
+// settable clock
+func mapLayerTestService(t *testing.T, provider *flakyMapLayerProvider) (*avalancheService, *time.Time) {
+	t.Helper()
+
+	current := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAvalancheServiceWithProviders(
+		logger, provider, &mockForecastProvider{}, &mockAccidentProvider{},
+	).(*avalancheService)
+	service.now = func() time.Time { return current }
+	service.mapLayerCache.SetClock(service.now)
+	return service, &current
+}
+
+func TestMapLayerFreshServedFromCache(t *testing.T) {
+	provider := &flakyMapLayerProvider{}
+	service, clock := mapLayerTestService(t, provider)
+
+	zones, err := service.GetZones(ZoneFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if zones.Freshness.Stale || zones.Freshness.AgeSeconds != 0 {
+		t.Errorf("first fetch should be fresh, got %+v", zones.Freshness)
+	}
+
+	// A second call within the soft TTL must not touch the provider
+	*clock = clock.Add(10 * time.Minute)
+	zones, err = service.GetZones(ZoneFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.callCount() != 1 {
+		t.Errorf("provider called %d times, want 1", provider.callCount())
+	}
+	if zones.Freshness.Stale {
+		t.Error("entry within the soft TTL reported stale")
+	}
+	if zones.Freshness.AgeSeconds != 600 {
+		t.Errorf("age = %ds, want 600", zones.Freshness.AgeSeconds)
+	}
+}
+
+func TestMapLayerSoftStaleServedThroughOutage(t *testing.T) {
+	provider := &flakyMapLayerProvider{}
+	service, clock := mapLayerTestService(t, provider)
+
+	if _, err := service.GetZones(ZoneFilter{}); err != nil {
+		t.Fatalf("seeding the cache failed: %v", err)
+	}
+
+	// Past the soft TTL with NAC down, the cached copy is served marked stale
+	*clock = clock.Add(mapLayerSoftTTL + time.Minute)
+	provider.setFail(true)
+
+	zones, err := service.GetZones(ZoneFilter{})
+	if err != nil {
+		t.Fatalf("soft-stale copy should be served, got %v", err)
+	}
+	if !zones.Freshness.Stale {
+		t.Error("served copy not marked stale")
+	}
+	if want := int((mapLayerSoftTTL + time.Minute).Seconds()); zones.Freshness.AgeSeconds != want {
+		t.Errorf("age = %ds, want %d", zones.Freshness.AgeSeconds, want)
+	}
+
+	centers, err := service.GetCenters()
+	if err != nil {
+		t.Fatalf("centers should also survive the outage, got %v", err)
+	}
+	if !centers.Freshness.Stale {
+		t.Error("center directory not marked stale")
+	}
+}
+
+func TestMapLayerHardStaleRejected(t *testing.T) {
+	provider := &flakyMapLayerProvider{}
+	service, clock := mapLayerTestService(t, provider)
+
+	if _, err := service.GetZones(ZoneFilter{}); err != nil {
+		t.Fatalf("seeding the cache failed: %v", err)
+	}
+
+	// Past the hard TTL the stale copy is no longer acceptable; with NAC
+	// still down the failure reaches the caller
+	*clock = clock.Add(mapLayerHardTTL + time.Minute)
+	provider.setFail(true)
+
+	if _, err := service.GetZones(ZoneFilter{}); err == nil {
+		t.Fatal("expected an error for a hard-stale map layer during an outage")
+	} else if !strings.Contains(err.Error(), "failed to get map layer") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Once NAC recovers, the synchronous refetch replaces the copy
+	provider.setFail(false)
+	zones, err := service.GetZones(ZoneFilter{})
+	if err != nil {
+		t.Fatalf("recovery fetch failed: %v", err)
+	}
+	if zones.Freshness.Stale || zones.Freshness.AgeSeconds != 0 {
+		t.Errorf("recovered fetch should be fresh, got %+v", zones.Freshness)
+	}
+}
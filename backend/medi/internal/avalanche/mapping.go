@@ -3,7 +3,9 @@ package avalanche
 import (
 	"encoding/json"
 	"medi/internal/providers/nac"
+	"medi/internal/types"
 	"strconv"
+	"time"
 )
 
 // mapForecastResponse translates a NAC ForecastResponse into a provider-agnostic
@@ -30,7 +32,7 @@ func mapForecastResponse(zone *nac.MapLayerFeature, resp *nac.ForecastResponse)
 		ForecastURL:      zone.Properties.Link,
 	}
 
-	// Map the forecast zone state from the response if available
+	// Map the forecast zone state and config from the response if available
 	if len(resp.ForecastZone) > 0 {
 		for _, fz := range resp.ForecastZone {
 			if fz.Id == zone.Id {
@@ -38,13 +40,14 @@ func mapForecastResponse(zone *nac.MapLayerFeature, resp *nac.ForecastResponse)
 				if fz.Url != "" {
 					forecast.Zone.URL = fz.Url
 				}
+				forecast.Zone.Config = parseZoneConfig(fz.Config)
 				break
 			}
 		}
 	}
 
 	// Map danger ratings
-	forecast.DangerRatings = mapDangerRatings(resp)
+	forecast.DangerRatings = mapDangerRatings(resp, forecast.Zone.Config)
 
 	// Map avalanche problems
 	forecast.Problems = mapAvalancheProblems(resp)
@@ -52,8 +55,10 @@ func mapForecastResponse(zone *nac.MapLayerFeature, resp *nac.ForecastResponse)
 	return forecast
 }
 
-// mapDangerRatings converts NAC danger entries to domain DangerRating values.
-func mapDangerRatings(resp *nac.ForecastResponse) []DangerRating {
+// mapDangerRatings converts NAC danger entries to domain DangerRating values,
+// labeling the elevation bands from the zone config when one exists.
+func mapDangerRatings(resp *nac.ForecastResponse, config *ZoneConfig) []DangerRating {
+	bands := DangerBandLabels(config)
 	ratings := make([]DangerRating, 0, len(resp.Danger))
 	for _, d := range resp.Danger {
 		ratings = append(ratings, DangerRating{
@@ -61,6 +66,7 @@ func mapDangerRatings(resp *nac.ForecastResponse) []DangerRating {
 			Lower:    DangerLevel(d.Lower),
 			Middle:   DangerLevel(d.Middle),
 			Upper:    DangerLevel(d.Upper),
+			Bands:    bands,
 		})
 	}
 	return ratings
@@ -72,6 +78,7 @@ func mapAvalancheProblems(resp *nac.ForecastResponse) []AvalancheProblem {
 	for _, p := range resp.ForecastAvalancheProblems {
 		problem := AvalancheProblem{
 			Name:       p.Name,
+			Type:       ParseProblemType(p.Name, p.AvalancheProblemId),
 			Rank:       p.Rank,
 			Likelihood: ParseLikelihood(p.Likelihood),
 			Discussion: p.Discussion,
@@ -139,3 +146,35 @@ func extractMediaURL(raw json.RawMessage) string {
 
 	return ""
 }
+
+// mapAccidentsResponse translates NAC accident records into domain incidents.
+// Records with unparseable dates are dropped; missing coordinates are kept as
+// nil so the search can still count them at the zone level.
+func mapAccidentsResponse(accidents []nac.Accident) []Incident {
+	incidents := make([]Incident, 0, len(accidents))
+	for _, accident := range accidents {
+		date, err := time.Parse("2006-01-02", accident.Date)
+		if err != nil {
+			continue
+		}
+
+		incident := Incident{
+			Id:       accident.Id,
+			Date:     date,
+			Location: accident.LocationName,
+			State:    accident.State,
+			CenterId: accident.CenterId,
+			ZoneName: accident.ForecastZone,
+			Caught:   accident.Caught,
+			Buried:   accident.Buried,
+			Killed:   accident.Killed,
+			URL:      accident.Url,
+		}
+		if accident.Latitude != nil && accident.Longitude != nil {
+			coords := types.NewCoords(*accident.Latitude, *accident.Longitude)
+			incident.Coordinates = &coords
+		}
+		incidents = append(incidents, incident)
+	}
+	return incidents
+}
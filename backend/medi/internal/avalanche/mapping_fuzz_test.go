@@ -0,0 +1,24 @@
+package avalanche
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// FuzzExtractMediaURL covers the polymorphic NAC media.url field, which
+// arrives as a size-keyed object, a plain string, or garbage; the extractor
+// must return a string without panicking on any input.
+func FuzzExtractMediaURL(f *testing.F) {
+	f.Add([]byte(`{"large":"https://example.com/l.jpg","original":"https://example.com/o.jpg"}`))
+	f.Add([]byte(`"https://example.com/plain.jpg"`))
+	f.Add([]byte(`{"original":123}`))
+	f.Add([]byte(`["https://example.com/in-array.jpg"]`))
+	f.Add([]byte(`"\xff\xfe"`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(``))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		extractMediaURL(json.RawMessage(data))
+	})
+}
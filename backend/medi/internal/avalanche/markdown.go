@@ -0,0 +1,304 @@
+package avalanche
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// markdownTimeLayout formats the published/expires lines in the document header
+const markdownTimeLayout = "Jan 2, 2006 3:04 PM MST"
+
+// RenderMarkdown renders the forecast as a deterministic Markdown document
+// for chat integrations: the danger ratings as a table, the problems as
+// sections, the HTML bottom line and discussions converted to Markdown, and
+// the center's forecast page as a footer link.
+func RenderMarkdown(f *AvalancheForecast) string {
+	var doc strings.Builder
+
+	fmt.Fprintf(&doc, "# Avalanche Forecast — %s\n\n", f.Zone.Name)
+
+	issued := fmt.Sprintf("Issued by %s", f.Center.Name)
+	if f.Author != "" {
+		issued += fmt.Sprintf(" (%s)", f.Author)
+	}
+	if !f.PublishedTime.IsZero() {
+		issued += fmt.Sprintf(", published %s", f.PublishedTime.Format(markdownTimeLayout))
+	}
+	if !f.ExpiresTime.IsZero() {
+		issued += fmt.Sprintf(", expires %s", f.ExpiresTime.Format(markdownTimeLayout))
+	}
+	doc.WriteString(issued + ".\n")
+
+	if len(f.DangerRatings) > 0 {
+		doc.WriteString("\n## Danger Ratings\n\n")
+		bands := f.DangerRatings[0].Bands
+		fmt.Fprintf(&doc, "| Day | %s | %s | %s |\n", bands.Upper, bands.Middle, bands.Lower)
+		doc.WriteString("| --- | --- | --- | --- |\n")
+		for _, rating := range f.DangerRatings {
+			fmt.Fprintf(&doc, "| %s | %s | %s | %s |\n",
+				rating.ValidDay, rating.Upper, rating.Middle, rating.Lower)
+		}
+	}
+
+	if bottomLine := htmlToMarkdown(f.BottomLine); bottomLine != "" {
+		doc.WriteString("\n## Bottom Line\n\n")
+		doc.WriteString(bottomLine + "\n")
+	}
+
+	if len(f.Problems) > 0 {
+		doc.WriteString("\n## Problems\n")
+		for _, problem := range f.Problems {
+			fmt.Fprintf(&doc, "\n### %d. %s\n\n", problem.Rank, problem.Name)
+			fmt.Fprintf(&doc, "- Likelihood: %s\n", problem.Likelihood)
+			fmt.Fprintf(&doc, "- Size: D%g–D%g\n", problem.Size.Min, problem.Size.Max)
+			if len(problem.Location) > 0 {
+				fmt.Fprintf(&doc, "- Location: %s\n", strings.Join(problem.Location, ", "))
+			}
+			if discussion := htmlToMarkdown(problem.Discussion); discussion != "" {
+				doc.WriteString("\n" + discussion + "\n")
+			}
+		}
+	}
+
+	if f.ForecastURL != "" {
+		doc.WriteString("\n---\n\n")
+		fmt.Fprintf(&doc, "[Full forecast](%s)\n", f.ForecastURL)
+	}
+
+	return doc.String()
+}
+
+// htmlToMarkdown converts the basic HTML the avalanche centers publish into
+// Markdown: p, br, strong/b, em/i, ul/ol/li (nested), and a. Unsupported tags
+// are stripped with their text content kept, and entities are unescaped.
+func htmlToMarkdown(source string) string {
+	c := newMarkdownConverter()
+	for i := 0; i < len(source); {
+		if source[i] == '<' {
+			end := strings.IndexByte(source[i:], '>')
+			if end < 0 {
+				c.text(source[i:])
+				break
+			}
+			c.tag(source[i+1 : i+end])
+			i += end + 1
+			continue
+		}
+		next := strings.IndexByte(source[i:], '<')
+		if next < 0 {
+			c.text(source[i:])
+			break
+		}
+		c.text(source[i : i+next])
+		i += next
+	}
+	return c.finish()
+}
+
+// markdownList tracks one level of list nesting
+type markdownList struct {
+	ordered bool
+	index   int
+}
+
+// markdownConverter accumulates the converted document. Link text renders
+// into its own builder so the [text](href) form can be assembled when the
+// anchor closes.
+type markdownConverter struct {
+	builders []*strings.Builder
+	hrefs    []string
+	lists    []markdownList
+}
+
+func newMarkdownConverter() *markdownConverter {
+	return &markdownConverter{builders: []*strings.Builder{{}}}
+}
+
+func (c *markdownConverter) out() *strings.Builder {
+	return c.builders[len(c.builders)-1]
+}
+
+// text writes a text node with HTML whitespace semantics: entity references
+// unescaped and whitespace runs collapsed to single spaces
+func (c *markdownConverter) text(raw string) {
+	collapsed := collapseWhitespace(html.UnescapeString(raw))
+	if strings.TrimSpace(collapsed) == "" {
+		return
+	}
+	out := c.out()
+	if strings.HasPrefix(collapsed, " ") && endsWithWhitespace(out.String()) {
+		collapsed = collapsed[1:]
+	}
+	out.WriteString(collapsed)
+}
+
+func (c *markdownConverter) tag(raw string) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw[0] == '!' || raw[0] == '?' {
+		return
+	}
+
+	closing := strings.HasPrefix(raw, "/")
+	name := strings.ToLower(strings.TrimPrefix(raw, "/"))
+	if cut := strings.IndexAny(name, " \t\n/"); cut >= 0 {
+		name = name[:cut]
+	}
+
+	switch name {
+	case "p":
+		c.blankLine()
+	case "br":
+		c.newline()
+	case "strong", "b":
+		c.out().WriteString("**")
+	case "em", "i":
+		c.out().WriteString("*")
+	case "ul", "ol":
+		if closing {
+			if len(c.lists) > 0 {
+				c.lists = c.lists[:len(c.lists)-1]
+			}
+			if len(c.lists) == 0 {
+				c.blankLine()
+			}
+		} else {
+			if len(c.lists) == 0 {
+				c.blankLine()
+			}
+			c.lists = append(c.lists, markdownList{ordered: name == "ol"})
+		}
+	case "li":
+		if !closing {
+			c.newline()
+			depth := len(c.lists)
+			if depth == 0 {
+				depth = 1
+				c.lists = append(c.lists, markdownList{})
+			}
+			c.out().WriteString(strings.Repeat("  ", depth-1))
+			list := &c.lists[depth-1]
+			if list.ordered {
+				list.index++
+				fmt.Fprintf(c.out(), "%d. ", list.index)
+			} else {
+				c.out().WriteString("- ")
+			}
+		}
+	case "a":
+		if closing {
+			c.closeLink()
+		} else {
+			c.builders = append(c.builders, &strings.Builder{})
+			c.hrefs = append(c.hrefs, attributeValue(raw, "href"))
+		}
+	default:
+		// Unsupported tag: drop the tag, keep its content
+	}
+}
+
+// closeLink pops the innermost link and writes it in Markdown form; an anchor
+// without an href degrades to its bare text
+func (c *markdownConverter) closeLink() {
+	if len(c.hrefs) == 0 {
+		return
+	}
+	text := c.builders[len(c.builders)-1].String()
+	href := c.hrefs[len(c.hrefs)-1]
+	c.builders = c.builders[:len(c.builders)-1]
+	c.hrefs = c.hrefs[:len(c.hrefs)-1]
+
+	if href == "" {
+		c.out().WriteString(text)
+		return
+	}
+	fmt.Fprintf(c.out(), "[%s](%s)", strings.TrimSpace(text), href)
+}
+
+func (c *markdownConverter) finish() string {
+	for len(c.hrefs) > 0 {
+		c.closeLink()
+	}
+
+	lines := strings.Split(c.builders[0].String(), "\n")
+	var cleaned []string
+	blanks := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blanks++
+			if blanks > 1 {
+				continue
+			}
+		} else {
+			blanks = 0
+		}
+		cleaned = append(cleaned, line)
+	}
+	return strings.TrimSpace(strings.Join(cleaned, "\n"))
+}
+
+// blankLine ensures the output sits at the start of a new paragraph
+func (c *markdownConverter) blankLine() {
+	current := c.out().String()
+	switch {
+	case current == "" || strings.HasSuffix(current, "\n\n"):
+	case strings.HasSuffix(current, "\n"):
+		c.out().WriteString("\n")
+	default:
+		c.out().WriteString("\n\n")
+	}
+}
+
+// newline ensures the output sits at the start of a new line
+func (c *markdownConverter) newline() {
+	if current := c.out().String(); current != "" && !strings.HasSuffix(current, "\n") {
+		c.out().WriteString("\n")
+	}
+}
+
+// attributeValue extracts a quoted attribute value from a raw tag
+func attributeValue(tag, name string) string {
+	lower := strings.ToLower(tag)
+	idx := strings.Index(lower, name+"=")
+	if idx < 0 {
+		return ""
+	}
+	rest := tag[idx+len(name)+1:]
+	if rest == "" {
+		return ""
+	}
+	quote := rest[0]
+	if quote != '"' && quote != '\'' {
+		if end := strings.IndexAny(rest, " \t\n"); end >= 0 {
+			return rest[:end]
+		}
+		return rest
+	}
+	rest = rest[1:]
+	if end := strings.IndexByte(rest, quote); end >= 0 {
+		return rest[:end]
+	}
+	return rest
+}
+
+func collapseWhitespace(s string) string {
+	var b strings.Builder
+	inSpace := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			if !inSpace {
+				b.WriteByte(' ')
+				inSpace = true
+			}
+			continue
+		}
+		inSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func endsWithWhitespace(s string) bool {
+	return s == "" || strings.HasSuffix(s, " ") || strings.HasSuffix(s, "\n")
+}
@@ -0,0 +1,124 @@
+package avalanche
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHtmlToMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "paragraphs with emphasis",
+			source: `<p>First <strong>bold</strong> point.</p><p>Second <em>subtle</em> point.</p>`,
+			want:   "First **bold** point.\n\nSecond *subtle* point.",
+		},
+		{
+			name:   "link",
+			source: `<p>Read the <a href="https://example.com/obs">observations</a> today.</p>`,
+			want:   "Read the [observations](https://example.com/obs) today.",
+		},
+		{
+			name:   "anchor without href degrades to text",
+			source: `<p>An <a name="marker">anchored phrase</a> survives.</p>`,
+			want:   "An anchored phrase survives.",
+		},
+		{
+			name:   "nested unordered list",
+			source: `<ul><li>Wind slabs</li><li>Weak layers<ul><li>North aspects</li><li>Shaded terrain</li></ul></li></ul>`,
+			want:   "- Wind slabs\n- Weak layers\n  - North aspects\n  - Shaded terrain",
+		},
+		{
+			name:   "ordered list",
+			source: `<ol><li>Check the forecast</li><li>Dig a pit</li></ol>`,
+			want:   "1. Check the forecast\n2. Dig a pit",
+		},
+		{
+			name:   "unsupported tags stripped with content kept",
+			source: `<div class="wrap"><p>A <span style="color:red">buried layer</span> remains.</p><img src="x.png"></div>`,
+			want:   "A buried layer remains.",
+		},
+		{
+			name:   "line break",
+			source: `<p>Line one<br>Line two</p>`,
+			want:   "Line one\nLine two",
+		},
+		{
+			name:   "entities and whitespace collapse",
+			source: "<p>Wind &amp; snow\n   loading &gt; expected</p>",
+			want:   "Wind & snow loading > expected",
+		},
+		{
+			name:   "empty input",
+			source: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToMarkdown(tt.source); got != tt.want {
+				t.Errorf("htmlToMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownGolden(t *testing.T) {
+	forecast := &AvalancheForecast{
+		Zone:          ForecastZone{Name: "Aspen"},
+		Center:        AvalancheCenter{Name: "Colorado Avalanche Information Center"},
+		Author:        "J. Forecaster",
+		PublishedTime: time.Date(2025, 1, 15, 7, 0, 0, 0, time.UTC),
+		ExpiresTime:   time.Date(2025, 1, 16, 7, 0, 0, 0, time.UTC),
+		BottomLine: `<p>Dangerous avalanche conditions exist near and above treeline. ` +
+			`<strong>Avoid wind-loaded slopes</strong> steeper than 30 degrees.</p>` +
+			`<p>Watch for:</p><ul><li>Recent wind slabs</li><li>Persistent weak layers, especially:` +
+			`<ul><li>North through east aspects</li><li>Shaded terrain features</li></ul></li></ul>`,
+		DangerRatings: []DangerRating{
+			{
+				ValidDay: "current",
+				Lower:    DangerLow, Middle: DangerModerate, Upper: DangerConsiderable,
+				Bands: treelineBandLabels,
+			},
+			{
+				ValidDay: "tomorrow",
+				Lower:    DangerLow, Middle: DangerConsiderable, Upper: DangerConsiderable,
+				Bands: treelineBandLabels,
+			},
+		},
+		Problems: []AvalancheProblem{
+			{
+				Name:       "Wind Slab",
+				Rank:       1,
+				Likelihood: LikelihoodLikely,
+				Size:       AvalancheSize{Min: 1, Max: 2.5},
+				Location:   []string{"north upper", "east upper"},
+				Discussion: `<p>Strong westerly winds built <em>fresh slabs</em> overnight. ` +
+					`See the <a href="https://avalanche.state.co.us/wind">wind summary</a> for details.</p>`,
+			},
+			{
+				Name:       "Persistent Slab",
+				Rank:       2,
+				Likelihood: LikelihoodPossible,
+				Size:       AvalancheSize{Min: 2, Max: 3},
+				Location:   []string{"north middle"},
+				Discussion: `<div><p>A <span class="layer">buried facet layer</span> remains reactive.</p></div>`,
+			},
+		},
+		ForecastURL: "https://avalanche.state.co.us/forecasts/aspen",
+	}
+
+	want, err := os.ReadFile("testdata/forecast_markdown.md")
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	if got := RenderMarkdown(forecast); got != string(want) {
+		t.Errorf("RenderMarkdown mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
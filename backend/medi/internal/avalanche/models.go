@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"medi/internal/types"
 )
 
 // AvalancheForecast is the top-level provider-agnostic domain model.
@@ -26,6 +28,9 @@ type ForecastZone struct {
 	Name  string
 	State string
 	URL   string
+	// Config holds per-zone settings when the center publishes them, nil
+	// otherwise
+	Config *ZoneConfig
 }
 
 // AvalancheCenter identifies the issuing avalanche center.
@@ -72,17 +77,30 @@ type DangerRating struct {
 	Lower    DangerLevel
 	Middle   DangerLevel
 	Upper    DangerLevel
+	// Bands labels the elevation bands with the zone's configured boundary
+	// elevations, or the treeline convention when none are published
+	Bands BandLabels
 }
 
 // AvalancheProblem describes a specific avalanche problem in the forecast.
 type AvalancheProblem struct {
-	Name       string
+	Name string
+	// Type is the problem normalized to the cross-center taxonomy;
+	// ProblemUnknown when the center's name and id are both unrecognized
+	Type       ProblemType
 	Rank       int // 1 = primary problem
 	Likelihood Likelihood
 	Discussion string   // HTML
 	Location   []string // aspect/elevation combos, e.g. "north upper"
 	Size       AvalancheSize
 	MediaURL   string // image URL if available (original size)
+	// AppliesToYourLocation reports whether Location covers the requesting
+	// point's aspect and elevation band; nil when the request carried no
+	// usable terrain estimate
+	AppliesToYourLocation *bool
+	// LocationReason explains the verdict in a sentence, empty when
+	// AppliesToYourLocation is nil
+	LocationReason string
 }
 
 // Likelihood is a normalized enum for avalanche problem likelihood.
@@ -140,3 +158,29 @@ type AvalancheSize struct {
 	Min float64
 	Max float64
 }
+
+// Incident is a single avalanche accident/incident record.
+type Incident struct {
+	Id          string
+	Date        time.Time
+	Location    string
+	State       string
+	CenterId    string
+	ZoneName    string
+	Coordinates *types.Coords // nil when the record has no coordinates
+	Caught      int
+	Buried      int
+	Killed      int
+	URL         string
+	DistanceKm  float64 // distance from the query point, set on search results
+}
+
+// IncidentSearch holds incidents near a query point.
+type IncidentSearch struct {
+	Incidents []Incident // within the radius, sorted by distance
+	ZoneName  string     // forecast zone containing the query point, if any
+	// UnlocatedInZone counts incidents without coordinates whose forecast
+	// zone matches the query point's zone; they cannot appear in the radius
+	// results but still signal local accident history
+	UnlocatedInZone int
+}
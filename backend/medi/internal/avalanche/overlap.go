@@ -0,0 +1,119 @@
+package avalanche
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"medi/internal/types"
+)
+
+// roseAspects are the eight aspect words NAC danger roses use, clockwise
+// from north; problem locations combine one with an elevation band, e.g.
+// "northeast upper"
+var roseAspects = [8]string{"north", "northeast", "east", "southeast", "south", "southwest", "west", "northwest"}
+
+// treelineBandHalfWidthFt is how far the "near treeline" band extends to
+// either side of the estimated treeline when a center publishes no
+// elevation boundaries
+const treelineBandHalfWidthFt = 500.0
+
+// AnnotateProblemOverlap ties each problem's danger rose to the requesting
+// point's terrain, setting AppliesToYourLocation and a one-sentence reason.
+// Points without a usable terrain estimate (no aspect, variable aspect, or
+// no elevation) keep nil annotations — unknown terrain is not "does not
+// apply".
+func AnnotateProblemOverlap(forecast *AvalancheForecast, point *types.ForecastPoint) {
+	if forecast == nil || point == nil {
+		return
+	}
+	aspect := point.TerrainAspect
+	if aspect == nil || aspect.IsVariable() || point.Elevation.Feet <= 0 {
+		return
+	}
+
+	aspectWord := roseAspects[int(math.Floor(*aspect.Degrees/45+0.5))%8]
+	band, bandPhrase := elevationBand(point.Elevation.Feet, forecast.Zone.Config, point.Coordinates.Latitude)
+
+	for i := range forecast.Problems {
+		problem := &forecast.Problems[i]
+
+		combo := aspectWord + " " + band
+		applies, aspectFlagged, bandFlagged := false, false, false
+		for _, location := range problem.Location {
+			location = strings.ToLower(strings.TrimSpace(location))
+			if location == combo {
+				applies = true
+			}
+			if strings.HasPrefix(location, aspectWord+" ") {
+				aspectFlagged = true
+			}
+			if strings.HasSuffix(location, " "+band) {
+				bandFlagged = true
+			}
+		}
+
+		problem.AppliesToYourLocation = &applies
+		switch {
+		case applies:
+			problem.LocationReason = fmt.Sprintf("you are on a %s aspect %s, which is flagged for %s",
+				aspect.Cardinal, bandPhrase, problem.Name)
+		case bandFlagged:
+			problem.LocationReason = fmt.Sprintf("your %s aspect is not flagged for %s at your elevation",
+				aspect.Cardinal, problem.Name)
+		case aspectFlagged:
+			problem.LocationReason = fmt.Sprintf("%s is flagged on %s aspects at other elevations, not %s",
+				problem.Name, aspect.Cardinal, bandPhrase)
+		default:
+			problem.LocationReason = fmt.Sprintf("%s is not flagged on %s aspects %s",
+				problem.Name, aspect.Cardinal, bandPhrase)
+		}
+	}
+}
+
+// elevationBand places an elevation in the zone's lower/middle/upper band,
+// returning the band word used in problem locations and a phrase for prose.
+// Published boundaries are used when the zone config has them; otherwise the
+// bands fall around an estimated treeline.
+func elevationBand(feet float64, config *ZoneConfig, latitude float64) (string, string) {
+	if config != nil && len(config.ElevationBandsFt) >= 2 {
+		lower, upper := config.ElevationBandsFt[0], config.ElevationBandsFt[1]
+		switch {
+		case feet < lower:
+			return "lower", fmt.Sprintf("below %.0f ft", lower)
+		case feet > upper:
+			return "upper", fmt.Sprintf("above %.0f ft", upper)
+		default:
+			return "middle", fmt.Sprintf("between %.0f and %.0f ft", lower, upper)
+		}
+	}
+
+	treeline := approximateTreelineFt(latitude)
+	switch {
+	case feet < treeline-treelineBandHalfWidthFt:
+		return "lower", "below treeline"
+	case feet > treeline+treelineBandHalfWidthFt:
+		return "upper", "above treeline"
+	default:
+		return "middle", "near treeline"
+	}
+}
+
+// BandBoundariesFt returns the elevations splitting the zone's three danger
+// bands, ascending: the published config boundaries when the zone has at
+// least two, otherwise the estimated treeline's band edges at the latitude.
+func BandBoundariesFt(config *ZoneConfig, latitude float64) (float64, float64) {
+	if config != nil && len(config.ElevationBandsFt) >= 2 {
+		return config.ElevationBandsFt[0], config.ElevationBandsFt[1]
+	}
+	treeline := approximateTreelineFt(latitude)
+	return treeline - treelineBandHalfWidthFt, treeline + treelineBandHalfWidthFt
+}
+
+// approximateTreelineFt estimates the local treeline elevation when a
+// center publishes no boundaries: about 12,000 ft at 35° latitude, dropping
+// roughly 330 ft per degree poleward, clamped to sane bounds
+func approximateTreelineFt(latitude float64) float64 {
+	treeline := 12000 - 330*(math.Abs(latitude)-35)
+	return math.Min(12500, math.Max(3000, treeline))
+}
@@ -0,0 +1,140 @@
+package avalanche
+
+import (
+	"strings"
+	"testing"
+
+	"medi/internal/types"
+)
+
+// overlapForecast builds a forecast with configured band boundaries at
+// 10,500 and 11,500 ft and a single Wind Slab problem on the given
+// aspect/band combos
+func overlapForecast(locations ...string) *AvalancheForecast {
+	return &AvalancheForecast{
+		Zone: ForecastZone{
+			Name:   "Aspen",
+			Config: &ZoneConfig{ElevationBandsFt: []float64{10500, 11500}},
+		},
+		Problems: []AvalancheProblem{{
+			Name:     "Wind Slab",
+			Type:     ProblemWindSlab,
+			Rank:     1,
+			Location: locations,
+		}},
+	}
+}
+
+// overlapPoint builds a forecast point at the given elevation facing the
+// given aspect in degrees
+func overlapPoint(elevationFt, aspectDegrees float64) *types.ForecastPoint {
+	aspect := types.NewWindDirection(aspectDegrees)
+	return &types.ForecastPoint{
+		Coordinates:   types.NewCoords(39.11539, -107.65840),
+		Elevation:     types.NewElevationFromFeet(elevationFt),
+		TerrainAspect: &aspect,
+	}
+}
+
+func TestAnnotateProblemOverlapMatching(t *testing.T) {
+	forecast := overlapForecast("northeast upper", "north upper", "east upper")
+	// 12,000 ft facing 45°: the "northeast upper" combo
+	AnnotateProblemOverlap(forecast, overlapPoint(12000, 45))
+
+	problem := forecast.Problems[0]
+	if problem.AppliesToYourLocation == nil || !*problem.AppliesToYourLocation {
+		t.Fatalf("AppliesToYourLocation = %v, want true", problem.AppliesToYourLocation)
+	}
+	if !strings.Contains(problem.LocationReason, "NE aspect") ||
+		!strings.Contains(problem.LocationReason, "Wind Slab") {
+		t.Errorf("reason does not name the aspect and problem: %q", problem.LocationReason)
+	}
+}
+
+func TestAnnotateProblemOverlapAspectMismatch(t *testing.T) {
+	forecast := overlapForecast("northeast upper", "north upper")
+	// Upper elevation matches but the point faces south
+	AnnotateProblemOverlap(forecast, overlapPoint(12000, 180))
+
+	problem := forecast.Problems[0]
+	if problem.AppliesToYourLocation == nil || *problem.AppliesToYourLocation {
+		t.Fatalf("AppliesToYourLocation = %v, want false", problem.AppliesToYourLocation)
+	}
+	if !strings.Contains(problem.LocationReason, "not flagged for Wind Slab at your elevation") {
+		t.Errorf("partial match should call out the aspect: %q", problem.LocationReason)
+	}
+}
+
+func TestAnnotateProblemOverlapElevationMismatch(t *testing.T) {
+	forecast := overlapForecast("northeast upper")
+	// The right aspect, but 9,000 ft is below the lower boundary
+	AnnotateProblemOverlap(forecast, overlapPoint(9000, 45))
+
+	problem := forecast.Problems[0]
+	if problem.AppliesToYourLocation == nil || *problem.AppliesToYourLocation {
+		t.Fatalf("AppliesToYourLocation = %v, want false", problem.AppliesToYourLocation)
+	}
+	if !strings.Contains(problem.LocationReason, "at other elevations") ||
+		!strings.Contains(problem.LocationReason, "below 10500 ft") {
+		t.Errorf("reason should point at the elevation mismatch: %q", problem.LocationReason)
+	}
+}
+
+func TestAnnotateProblemOverlapMissingTerrain(t *testing.T) {
+	flat := &types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.65840),
+		Elevation:   types.NewElevationFromFeet(12000),
+	}
+	variable := overlapPoint(12000, 45)
+	variableAspect := types.VariableWindDirection()
+	variable.TerrainAspect = &variableAspect
+
+	for name, point := range map[string]*types.ForecastPoint{
+		"nil point":       nil,
+		"no aspect":       flat,
+		"variable aspect": variable,
+	} {
+		forecast := overlapForecast("northeast upper")
+		AnnotateProblemOverlap(forecast, point)
+
+		problem := forecast.Problems[0]
+		if problem.AppliesToYourLocation != nil {
+			t.Errorf("%s: AppliesToYourLocation = %v, want nil", name, *problem.AppliesToYourLocation)
+		}
+		if problem.LocationReason != "" {
+			t.Errorf("%s: LocationReason = %q, want empty", name, problem.LocationReason)
+		}
+	}
+}
+
+func TestAnnotateProblemOverlapTreelineFallback(t *testing.T) {
+	forecast := overlapForecast("northeast upper")
+	forecast.Zone.Config = nil
+	// Around 39°N treeline sits near 10,700 ft, so 12,000 ft is above it
+	AnnotateProblemOverlap(forecast, overlapPoint(12000, 45))
+
+	problem := forecast.Problems[0]
+	if problem.AppliesToYourLocation == nil || !*problem.AppliesToYourLocation {
+		t.Fatalf("AppliesToYourLocation = %v, want true", problem.AppliesToYourLocation)
+	}
+	if !strings.Contains(problem.LocationReason, "above treeline") {
+		t.Errorf("reason should use the treeline phrasing: %q", problem.LocationReason)
+	}
+}
+
+func TestBandBoundariesFt(t *testing.T) {
+	config := &ZoneConfig{ElevationBandsFt: []float64{10000, 11000}}
+	if lower, upper := BandBoundariesFt(config, 39.0); lower != 10000 || upper != 11000 {
+		t.Errorf("BandBoundariesFt = %v, %v, want the published 10000/11000", lower, upper)
+	}
+
+	// Without published boundaries the bands straddle the estimated
+	// treeline: about 10680 ft at 39° latitude, ±500 ft
+	lower, upper := BandBoundariesFt(nil, 39.0)
+	if upper-lower != 2*treelineBandHalfWidthFt {
+		t.Errorf("heuristic band width = %v ft, want %v", upper-lower, 2*treelineBandHalfWidthFt)
+	}
+	if treeline := approximateTreelineFt(39.0); lower != treeline-treelineBandHalfWidthFt {
+		t.Errorf("lower boundary = %v, want %v below treeline", lower, treelineBandHalfWidthFt)
+	}
+}
@@ -0,0 +1,212 @@
+package avalanche
+
+import "strings"
+
+// ProblemType is a normalized enum for the avalanche problem taxonomy.
+// Centers name the same problem differently ("Wind Slab" vs "Wind-Drifted
+// Snow"), so the raw name is kept alongside the normalized type.
+type ProblemType int
+
+const (
+	ProblemUnknown ProblemType = iota
+	ProblemLooseDry
+	ProblemLooseWet
+	ProblemStormSlab
+	ProblemWindSlab
+	ProblemPersistentSlab
+	ProblemDeepPersistentSlab
+	ProblemWetSlab
+	ProblemCornice
+	ProblemGlide
+)
+
+// String returns the canonical problem type name.
+func (p ProblemType) String() string {
+	switch p {
+	case ProblemLooseDry:
+		return "Loose Dry"
+	case ProblemLooseWet:
+		return "Loose Wet"
+	case ProblemStormSlab:
+		return "Storm Slab"
+	case ProblemWindSlab:
+		return "Wind Slab"
+	case ProblemPersistentSlab:
+		return "Persistent Slab"
+	case ProblemDeepPersistentSlab:
+		return "Deep Persistent Slab"
+	case ProblemWetSlab:
+		return "Wet Slab"
+	case ProblemCornice:
+		return "Cornice"
+	case ProblemGlide:
+		return "Glide"
+	default:
+		return "Unknown"
+	}
+}
+
+// nacProblemIds maps the NAC avalanche_problem_id enumeration to the domain
+// taxonomy. The id is authoritative when present; names only break ties for
+// centers that omit it.
+var nacProblemIds = map[int]ProblemType{
+	1: ProblemLooseDry,
+	2: ProblemStormSlab,
+	3: ProblemWindSlab,
+	4: ProblemPersistentSlab,
+	5: ProblemDeepPersistentSlab,
+	6: ProblemLooseWet,
+	7: ProblemWetSlab,
+	8: ProblemCornice,
+	9: ProblemGlide,
+}
+
+// problemNameVariants maps known center spellings, folded by
+// foldProblemName, to the taxonomy. Extend it when the unrecognized-name
+// warning surfaces a new variant.
+var problemNameVariants = map[string]ProblemType{
+	"loosedry":            ProblemLooseDry,
+	"dryloose":            ProblemLooseDry,
+	"drylooseavalanches":  ProblemLooseDry,
+	"loosesnow":           ProblemLooseDry,
+	"sluffing":            ProblemLooseDry,
+	"loosewet":            ProblemLooseWet,
+	"wetloose":            ProblemLooseWet,
+	"wetlooseavalanches":  ProblemLooseWet,
+	"wetsluff":            ProblemLooseWet,
+	"stormslab":           ProblemStormSlab,
+	"stormslabs":          ProblemStormSlab,
+	"stormsnow":           ProblemStormSlab,
+	"newsnow":             ProblemStormSlab,
+	"windslab":            ProblemWindSlab,
+	"windslabs":           ProblemWindSlab,
+	"winddriftedsnow":     ProblemWindSlab,
+	"winddrift":           ProblemWindSlab,
+	"persistentslab":      ProblemPersistentSlab,
+	"persistentslabs":     ProblemPersistentSlab,
+	"persistentweaklayer": ProblemPersistentSlab,
+	"deeppersistentslab":  ProblemDeepPersistentSlab,
+	"deepslab":            ProblemDeepPersistentSlab,
+	"wetslab":             ProblemWetSlab,
+	"wetslabs":            ProblemWetSlab,
+	"cornice":             ProblemCornice,
+	"cornices":            ProblemCornice,
+	"cornicefall":         ProblemCornice,
+	"cornicefalls":        ProblemCornice,
+	"glide":               ProblemGlide,
+	"glideavalanches":     ProblemGlide,
+	"glideslab":           ProblemGlide,
+}
+
+// ParseProblemType normalizes a problem to the taxonomy. The NAC
+// avalanche_problem_id wins when it is a known id; otherwise the name is
+// folded and looked up among the known center variants. Unrecognized
+// problems map to ProblemUnknown.
+func ParseProblemType(name string, nacProblemId int) ProblemType {
+	if problemType, ok := nacProblemIds[nacProblemId]; ok {
+		return problemType
+	}
+	if problemType, ok := problemNameVariants[foldProblemName(name)]; ok {
+		return problemType
+	}
+	return ProblemUnknown
+}
+
+// foldProblemName lowercases a center's problem name and strips separators so
+// "Wind-Drifted Snow" and "wind drifted snow" fold to the same key
+func foldProblemName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch r {
+		case ' ', '-', '_', '/':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// warnUnknownProblems logs problems the taxonomy could not normalize so the
+// variant table can be extended
+func (s *avalancheService) warnUnknownProblems(forecast *AvalancheForecast) {
+	for _, problem := range forecast.Problems {
+		if problem.Type == ProblemUnknown {
+			s.logger.Warn("unrecognized avalanche problem name",
+				"name", problem.Name,
+				"center_id", forecast.Center.Id,
+				"zone_id", forecast.Zone.Id,
+			)
+		}
+	}
+}
+
+// ProblemTypeInfo describes one taxonomy entry for the reference endpoint.
+type ProblemTypeInfo struct {
+	Type        ProblemType
+	Name        string
+	Description string
+	// Advice is the typical management approach; it never replaces the
+	// forecast's own travel advice
+	Advice string
+}
+
+// ProblemTypes returns the reference catalog of the taxonomy, in enum order,
+// excluding Unknown.
+func ProblemTypes() []ProblemTypeInfo {
+	return []ProblemTypeInfo{
+		{
+			Type:        ProblemLooseDry,
+			Name:        ProblemLooseDry.String(),
+			Description: "Dry, cohesionless surface snow sluffing from a point and fanning out as it descends.",
+			Advice:      "Usually manageable in size; watch your sluff in steep, confined terrain where even small volumes can carry you into a hazard.",
+		},
+		{
+			Type:        ProblemLooseWet,
+			Name:        ProblemLooseWet.String(),
+			Description: "Wet, cohesionless snow releasing from a point as the surface loses strength to melt or rain.",
+			Advice:      "Time your travel: move to cooler aspects or lower-angle terrain before the surface becomes unsupportable, and avoid terrain traps below.",
+		},
+		{
+			Type:        ProblemStormSlab,
+			Name:        ProblemStormSlab.String(),
+			Description: "A cohesive slab of new snow releasing within the storm snow or at the old surface during or just after a storm.",
+			Advice:      "Give the new snow a day or two to settle; smaller test slopes reveal how well it has bonded before committing to bigger terrain.",
+		},
+		{
+			Type:        ProblemWindSlab,
+			Name:        ProblemWindSlab.String(),
+			Description: "A stiff slab of wind-drifted snow deposited on leeward and cross-loaded slopes, often well after snowfall ends.",
+			Advice:      "Look for pillowed, smooth deposits below ridgelines and behind terrain features and keep to windward or sheltered slopes while they adjust.",
+		},
+		{
+			Type:        ProblemPersistentSlab,
+			Name:        ProblemPersistentSlab.String(),
+			Description: "A slab over a buried persistent weak layer such as facets or surface hoar that can stay reactive for weeks.",
+			Advice:      "Stability tests and recent avalanches are more trustworthy than a quiet day; margin comes from conservative terrain choices, not timing.",
+		},
+		{
+			Type:        ProblemDeepPersistentSlab,
+			Name:        ProblemDeepPersistentSlab.String(),
+			Description: "A thick, hard slab over a persistent weak layer near the base of the snowpack, producing infrequent but very large avalanches.",
+			Advice:      "Low likelihood, high consequence: avoid the avalanche path entirely, especially shallow, rocky trigger points where the weak layer is within reach.",
+		},
+		{
+			Type:        ProblemWetSlab,
+			Name:        ProblemWetSlab.String(),
+			Description: "A cohesive slab releasing as free water weakens the snowpack or a buried layer, typically during warm-ups or rain-on-snow.",
+			Advice:      "Once wet slabs run the day is over; plan to be off of and out from under steep slopes before sustained thaw or rain reaches them.",
+		},
+		{
+			Type:        ProblemCornice,
+			Name:        ProblemCornice.String(),
+			Description: "An overhanging mass of wind-drifted snow along a ridgeline that can break farther back than expected and trigger slopes below.",
+			Advice:      "Give cornices a wide berth from above — they break behind the apparent edge — and limit time spent beneath them, especially during warming.",
+		},
+		{
+			Type:        ProblemGlide,
+			Name:        ProblemGlide.String(),
+			Description: "The full snowpack creeping over smooth ground, opening glide cracks that can release as unpredictable full-depth avalanches.",
+			Advice:      "Release timing defies forecasting; treat slopes with glide cracks like open water and simply stay out from underneath them.",
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package avalanche
+
+import "testing"
+
+func TestParseProblemTypeNameVariants(t *testing.T) {
+	// Name spellings observed across centers; the NAC id is absent (zero)
+	tests := []struct {
+		center string
+		name   string
+		want   ProblemType
+	}{
+		{"CAIC", "Persistent Slab", ProblemPersistentSlab},
+		{"CAIC", "Loose Dry", ProblemLooseDry},
+		{"NWAC", "Wind Slabs", ProblemWindSlab},
+		{"NWAC", "Loose Wet", ProblemLooseWet},
+		{"BTAC", "Wind-Drifted Snow", ProblemWindSlab},
+		{"BTAC", "New Snow", ProblemStormSlab},
+		{"UAC", "Persistent Weak Layer", ProblemPersistentSlab},
+		{"UAC", "Wet Slabs", ProblemWetSlab},
+		{"SAC", "Storm Slab", ProblemStormSlab},
+		{"SAC", "Cornice Fall", ProblemCornice},
+		{"CNFAIC", "Deep Persistent Slab", ProblemDeepPersistentSlab},
+		{"CNFAIC", "Glide Avalanches", ProblemGlide},
+		{"GNFAC", "dry loose", ProblemLooseDry},
+		{"GNFAC", "wet_loose", ProblemLooseWet},
+	}
+
+	for _, tt := range tests {
+		if got := ParseProblemType(tt.name, 0); got != tt.want {
+			t.Errorf("ParseProblemType(%q) [%s] = %s, want %s", tt.name, tt.center, got, tt.want)
+		}
+	}
+}
+
+func TestParseProblemTypeIdWinsOverName(t *testing.T) {
+	// A known id is authoritative even when the name says otherwise
+	if got := ParseProblemType("Wind Slab", 4); got != ProblemPersistentSlab {
+		t.Errorf("ParseProblemType with id 4 = %s, want %s", got, ProblemPersistentSlab)
+	}
+	// An unknown id falls back to the name
+	if got := ParseProblemType("Wind Slab", 42); got != ProblemWindSlab {
+		t.Errorf("ParseProblemType with unknown id = %s, want %s", got, ProblemWindSlab)
+	}
+}
+
+func TestParseProblemTypeUnrecognized(t *testing.T) {
+	for _, name := range []string{"Normal Caution", "Firn Slip", ""} {
+		if got := ParseProblemType(name, 0); got != ProblemUnknown {
+			t.Errorf("ParseProblemType(%q) = %s, want Unknown", name, got)
+		}
+	}
+}
+
+func TestProblemTypesCatalogIsComplete(t *testing.T) {
+	catalog := ProblemTypes()
+	if len(catalog) != 9 {
+		t.Fatalf("catalog has %d entries, want 9", len(catalog))
+	}
+
+	seen := map[ProblemType]bool{}
+	for _, info := range catalog {
+		if info.Type == ProblemUnknown {
+			t.Error("the catalog should not describe Unknown")
+		}
+		if seen[info.Type] {
+			t.Errorf("duplicate catalog entry for %s", info.Type)
+		}
+		seen[info.Type] = true
+		if info.Name != info.Type.String() {
+			t.Errorf("catalog name %q does not match type %s", info.Name, info.Type)
+		}
+		if info.Description == "" || info.Advice == "" {
+			t.Errorf("catalog entry %s is missing description or advice", info.Type)
+		}
+	}
+}
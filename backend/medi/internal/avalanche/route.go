@@ -0,0 +1,295 @@
+package avalanche
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"medi/internal/providers/nac"
+	"medi/internal/types"
+)
+
+// MaxRouteWaypoints caps how many ordered points a route check accepts.
+const MaxRouteWaypoints = 50
+
+// RouteWaypoint is one ordered point of a planned tour. ElevationFt is
+// optional; legs missing an endpoint elevation are matched against every
+// elevation band.
+type RouteWaypoint struct {
+	Latitude    float64
+	Longitude   float64
+	ElevationFt *float64
+}
+
+// RouteZone annotates a leg with one forecast zone it touches.
+type RouteZone struct {
+	ZoneId   int
+	CenterId string
+	Name     string
+	// Bands labels the elevation bands the leg's elevation range touches
+	// within this zone
+	Bands []string
+	// Danger is the highest current danger across the touched bands
+	Danger DangerLevel
+	// Problems names the forecast problems whose locations include one of
+	// the leg's aspects
+	Problems []string
+}
+
+// RouteLeg annotates the segment between two consecutive waypoints.
+type RouteLeg struct {
+	Start      types.Coords
+	End        types.Coords
+	DistanceKm float64
+	// BearingDegrees is the initial great-circle bearing from Start to End
+	BearingDegrees float64
+	// Aspects holds the compass aspects the leg is exposed to, inferred from
+	// its bearing: the direction of travel and its opposite
+	Aspects []string
+	// Zones covers each forecast zone an endpoint falls in; both appear when
+	// the leg crosses a zone boundary. Empty outside forecast coverage.
+	Zones []RouteZone
+	// MaxDanger is the highest danger across the leg's zones
+	MaxDanger DangerLevel
+}
+
+// RouteCheck is the danger annotation for a full tour.
+type RouteCheck struct {
+	Legs []RouteLeg
+	// MaxDanger is the highest danger across all legs
+	MaxDanger DangerLevel
+	// Warnings lists zones whose forecasts could not be fetched; legs through
+	// them carry no danger annotation
+	Warnings []string
+}
+
+// CheckRoute annotates each leg of an ordered waypoint list with the forecast
+// zones it crosses, the elevation bands its endpoints span, and the current
+// danger and relevant problems for those bands and the leg's aspects.
+func (s *avalancheService) CheckRoute(waypoints []RouteWaypoint) (*RouteCheck, error) {
+	if len(waypoints) < 2 {
+		return nil, fmt.Errorf("route check requires at least two waypoints, got %d", len(waypoints))
+	}
+	if len(waypoints) > MaxRouteWaypoints {
+		return nil, fmt.Errorf("route check accepts at most %d waypoints, got %d", MaxRouteWaypoints, len(waypoints))
+	}
+
+	mapLayer, _, err := s.getMapLayer()
+	if err != nil {
+		return nil, err
+	}
+
+	// Forecasts are fetched once per distinct zone across the whole route
+	forecasts := map[string]*AvalancheForecast{}
+	failed := map[string]bool{}
+	check := &RouteCheck{}
+
+	for i := 0; i+1 < len(waypoints); i++ {
+		start, end := waypoints[i], waypoints[i+1]
+		bearing := initialBearing(start.Latitude, start.Longitude, end.Latitude, end.Longitude)
+		leg := RouteLeg{
+			Start:          types.NewCoords(start.Latitude, start.Longitude),
+			End:            types.NewCoords(end.Latitude, end.Longitude),
+			DistanceKm:     types.HaversineKm(start.Latitude, start.Longitude, end.Latitude, end.Longitude),
+			BearingDegrees: bearing,
+			Aspects:        legAspects(bearing),
+		}
+
+		for _, feature := range legZoneFeatures(start, end, mapLayer) {
+			key := fmt.Sprintf("%s/%d", feature.Properties.CenterId, feature.Id)
+			forecast, ok := forecasts[key]
+			if !ok && !failed[key] {
+				resp, fetchErr := s.forecastProvider.GetForecast(feature.Properties.CenterId, feature.Id)
+				if fetchErr != nil {
+					s.logger.Warn("failed to get forecast for route zone",
+						"center_id", feature.Properties.CenterId,
+						"zone_id", feature.Id,
+						"error", fetchErr,
+					)
+					failed[key] = true
+					check.Warnings = append(check.Warnings,
+						fmt.Sprintf("no forecast available for zone %s (%s)", feature.Properties.Name, key))
+				} else {
+					forecast = mapForecastResponse(feature, resp)
+					s.warnUnknownProblems(forecast)
+					forecasts[key] = forecast
+				}
+			}
+			if forecast == nil {
+				continue
+			}
+
+			zone := annotateRouteZone(forecast, start.ElevationFt, end.ElevationFt, leg.Aspects)
+			leg.Zones = append(leg.Zones, zone)
+			if zone.Danger > leg.MaxDanger {
+				leg.MaxDanger = zone.Danger
+			}
+		}
+
+		if leg.MaxDanger > check.MaxDanger {
+			check.MaxDanger = leg.MaxDanger
+		}
+		check.Legs = append(check.Legs, leg)
+	}
+
+	return check, nil
+}
+
+// legZoneFeatures resolves the forecast zones the leg's endpoints fall in,
+// deduplicated; a leg crossing a zone boundary yields both zones.
+func legZoneFeatures(start, end RouteWaypoint, mapLayer *nac.MapLayerResponse) []*nac.MapLayerFeature {
+	var features []*nac.MapLayerFeature
+	for _, waypoint := range []RouteWaypoint{start, end} {
+		feature := nac.FindZone(waypoint.Latitude, waypoint.Longitude, mapLayer)
+		if feature == nil {
+			continue
+		}
+		duplicate := false
+		for _, seen := range features {
+			if seen.Id == feature.Id && seen.Properties.CenterId == feature.Properties.CenterId {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+// annotateRouteZone builds the per-zone annotation for one leg: the bands its
+// elevation range touches, the worst current danger across them, and the
+// problems listing one of the leg's aspects.
+func annotateRouteZone(forecast *AvalancheForecast, startElevationFt, endElevationFt *float64, aspects []string) RouteZone {
+	zone := RouteZone{
+		ZoneId:   forecast.Zone.Id,
+		CenterId: forecast.Center.Id,
+		Name:     forecast.Zone.Name,
+	}
+
+	rating := currentDangerRating(forecast.DangerRatings)
+	indices := touchedBandIndices(forecast.Zone.Config, startElevationFt, endElevationFt)
+
+	labels := DangerBandLabels(forecast.Zone.Config)
+	if rating != nil {
+		labels = rating.Bands
+	}
+	bandLabels := []string{labels.Lower, labels.Middle, labels.Upper}
+	for _, index := range indices {
+		zone.Bands = append(zone.Bands, bandLabels[index])
+		if rating == nil {
+			continue
+		}
+		if danger := [3]DangerLevel{rating.Lower, rating.Middle, rating.Upper}[index]; danger > zone.Danger {
+			zone.Danger = danger
+		}
+	}
+
+	for _, problem := range forecast.Problems {
+		if problemTouchesAspects(problem.Location, aspects) {
+			zone.Problems = append(zone.Problems, problem.Name)
+		}
+	}
+
+	return zone
+}
+
+// currentDangerRating picks the "current" valid day, falling back to the
+// first rating; nil when the forecast carries none.
+func currentDangerRating(ratings []DangerRating) *DangerRating {
+	for i := range ratings {
+		if ratings[i].ValidDay == "current" {
+			return &ratings[i]
+		}
+	}
+	if len(ratings) > 0 {
+		return &ratings[0]
+	}
+	return nil
+}
+
+// touchedBandIndices maps the leg's elevation range onto the zone's bands
+// (0 lower, 1 middle, 2 upper). A leg spanning a band boundary touches every
+// band between its endpoints. Without published boundaries or with a missing
+// endpoint elevation the whole range is assumed.
+func touchedBandIndices(config *ZoneConfig, startElevationFt, endElevationFt *float64) []int {
+	if config == nil || len(config.ElevationBandsFt) < 2 ||
+		startElevationFt == nil || endElevationFt == nil {
+		return []int{0, 1, 2}
+	}
+
+	low := bandIndex(config, *startElevationFt)
+	high := bandIndex(config, *endElevationFt)
+	if low > high {
+		low, high = high, low
+	}
+
+	indices := make([]int, 0, high-low+1)
+	for index := low; index <= high; index++ {
+		indices = append(indices, index)
+	}
+	return indices
+}
+
+// bandIndex places an elevation into the zone's lower/middle/upper band.
+func bandIndex(config *ZoneConfig, elevationFt float64) int {
+	switch {
+	case elevationFt < config.ElevationBandsFt[0]:
+		return 0
+	case elevationFt < config.ElevationBandsFt[1]:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// initialBearing computes the initial great-circle bearing in degrees from
+// the first point to the second, normalized to [0, 360).
+func initialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	deltaLambda := (lon2 - lon1) * math.Pi / 180
+
+	y := math.Sin(deltaLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(deltaLambda)
+	bearing := math.Atan2(y, x) * 180 / math.Pi
+	return math.Mod(bearing+360, 360)
+}
+
+// compassPoints are the eight aspect names centers use in problem locations.
+var compassPoints = []string{"north", "northeast", "east", "southeast", "south", "southwest", "west", "northwest"}
+
+// compassPoint buckets a bearing into the nearest of the eight points.
+func compassPoint(degrees float64) string {
+	normalized := math.Mod(degrees+360, 360)
+	index := int(math.Round(normalized/45)) % len(compassPoints)
+	return compassPoints[index]
+}
+
+// legAspects infers the aspects a leg is exposed to from its bearing: slopes
+// along a skin track face roughly across or against the direction of travel,
+// so both the bearing's compass point and its opposite are included.
+func legAspects(bearing float64) []string {
+	forward := compassPoint(bearing)
+	backward := compassPoint(bearing + 180)
+	if forward == backward {
+		return []string{forward}
+	}
+	return []string{forward, backward}
+}
+
+// problemTouchesAspects reports whether any of the problem's location entries
+// (e.g. "north upper") names one of the leg's aspects.
+func problemTouchesAspects(locations, aspects []string) bool {
+	for _, location := range locations {
+		for _, token := range strings.Fields(strings.ToLower(location)) {
+			for _, aspect := range aspects {
+				if token == aspect {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package avalanche
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"medi/internal/providers/nac"
+)
+
+// routeTestMapLayer builds two adjacent square zones split at 105.5°W: the
+// West Zone (id 101) and the East Zone (id 102), both CAIC.
+func routeTestMapLayer(t *testing.T) *nac.MapLayerResponse {
+	t.Helper()
+
+	raw := `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"id": 101,
+				"type": "Feature",
+				"properties": {"name": "West Zone", "center_id": "CAIC"},
+				"geometry": {"type": "Polygon", "coordinates": [[
+					[-106.0, 39.0], [-105.5, 39.0], [-105.5, 40.0], [-106.0, 40.0], [-106.0, 39.0]
+				]]}
+			},
+			{
+				"id": 102,
+				"type": "Feature",
+				"properties": {"name": "East Zone", "center_id": "CAIC"},
+				"geometry": {"type": "Polygon", "coordinates": [[
+					[-105.5, 39.0], [-105.0, 39.0], [-105.0, 40.0], [-105.5, 40.0], [-105.5, 39.0]
+				]]}
+			}
+		]
+	}`
+
+	var mapLayer nac.MapLayerResponse
+	if err := json.Unmarshal([]byte(raw), &mapLayer); err != nil {
+		t.Fatalf("Failed to build test map layer: %v", err)
+	}
+	return &mapLayer
+}
+
+// routeForecastProvider serves a canned forecast per zone id.
+type routeForecastProvider struct {
+	responses map[int]*nac.ForecastResponse
+	err       error
+}
+
+func (m *routeForecastProvider) GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.responses[zoneId], nil
+}
+
+// routeForecastResponse decodes a forecast response from raw JSON.
+func routeForecastResponse(t *testing.T, raw string) *nac.ForecastResponse {
+	t.Helper()
+	var resp nac.ForecastResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("Failed to build test forecast response: %v", err)
+	}
+	return &resp
+}
+
+// newRouteTestService wires the two-zone map layer with per-zone forecasts:
+// the West Zone has published elevation bands and rising danger with
+// elevation, the East Zone has no config and severe upper danger.
+func newRouteTestService(t *testing.T) Service {
+	t.Helper()
+
+	provider := &routeForecastProvider{responses: map[int]*nac.ForecastResponse{
+		101: routeForecastResponse(t, `{
+			"avalanche_center": {"id": "CAIC"},
+			"danger": [{"lower": 1, "middle": 2, "upper": 3, "valid_day": "current"}],
+			"forecast_zone": [{"id": 101, "name": "West Zone", "config": {"elevation_bands": [9000, 11500]}}],
+			"forecast_avalanche_problems": [
+				{"rank": 1, "name": "Wind Slab", "location": ["north upper", "northeast upper"]},
+				{"rank": 2, "name": "Persistent Slab", "location": ["southwest middle"]}
+			]
+		}`),
+		102: routeForecastResponse(t, `{
+			"avalanche_center": {"id": "CAIC"},
+			"danger": [{"lower": 2, "middle": 2, "upper": 4, "valid_day": "current"}],
+			"forecast_zone": [{"id": 102, "name": "East Zone", "config": null}],
+			"forecast_avalanche_problems": [
+				{"rank": 1, "name": "Wind Slab", "location": ["east upper"]}
+			]
+		}`),
+	}}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewAvalancheServiceWithProviders(logger,
+		&mockMapLayerProvider{response: routeTestMapLayer(t)}, provider, &mockAccidentProvider{})
+}
+
+func elevation(feet float64) *float64 {
+	return &feet
+}
+
+func TestCompassPoint(t *testing.T) {
+	tests := []struct {
+		degrees  float64
+		expected string
+	}{
+		{0, "north"},
+		{350, "north"},
+		{44, "northeast"},
+		{90, "east"},
+		{135, "southeast"},
+		{180, "south"},
+		{236, "southwest"},
+		{270, "west"},
+		{315, "northwest"},
+	}
+
+	for _, tt := range tests {
+		if got := compassPoint(tt.degrees); got != tt.expected {
+			t.Errorf("compassPoint(%v) = %q, want %q", tt.degrees, got, tt.expected)
+		}
+	}
+}
+
+func TestLegAspects(t *testing.T) {
+	aspects := legAspects(92)
+	if len(aspects) != 2 || aspects[0] != "east" || aspects[1] != "west" {
+		t.Errorf("legAspects(92) = %v, want [east west]", aspects)
+	}
+}
+
+func TestTouchedBandIndices(t *testing.T) {
+	config := &ZoneConfig{ElevationBandsFt: []float64{9000, 11500}}
+
+	tests := []struct {
+		name     string
+		config   *ZoneConfig
+		start    *float64
+		end      *float64
+		expected []int
+	}{
+		{"both below treeline", config, elevation(8000), elevation(8800), []int{0}},
+		{"within middle band", config, elevation(9500), elevation(11000), []int{1}},
+		{"climb spanning all bands", config, elevation(8800), elevation(11600), []int{0, 1, 2}},
+		{"descent spanning a boundary", config, elevation(12000), elevation(11000), []int{1, 2}},
+		{"missing elevation", config, elevation(9500), nil, []int{0, 1, 2}},
+		{"no published bands", nil, elevation(9500), elevation(9600), []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := touchedBandIndices(tt.config, tt.start, tt.end)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("touchedBandIndices = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("touchedBandIndices = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestProblemTouchesAspects(t *testing.T) {
+	locations := []string{"north upper", "northeast upper"}
+
+	if !problemTouchesAspects(locations, []string{"northeast", "southwest"}) {
+		t.Error("Expected a northeast leg to touch a northeast problem")
+	}
+	if problemTouchesAspects(locations, []string{"east", "west"}) {
+		t.Error("Expected an east-west leg not to touch a north/northeast problem")
+	}
+	// "north" must not match inside "northeast"
+	if problemTouchesAspects([]string{"northeast upper"}, []string{"north", "south"}) {
+		t.Error("Expected aspect matching to compare whole tokens")
+	}
+}
+
+func TestCheckRouteBandAndZoneBoundaries(t *testing.T) {
+	service := newRouteTestService(t)
+
+	// Leg one climbs within the West Zone's middle band; leg two keeps
+	// climbing east across the zone boundary into the East Zone.
+	check, err := service.CheckRoute([]RouteWaypoint{
+		{Latitude: 39.5, Longitude: -105.8, ElevationFt: elevation(9500)},
+		{Latitude: 39.5, Longitude: -105.6, ElevationFt: elevation(11000)},
+		{Latitude: 39.5, Longitude: -105.2, ElevationFt: elevation(11600)},
+	})
+	if err != nil {
+		t.Fatalf("CheckRoute returned error: %v", err)
+	}
+	if len(check.Legs) != 2 {
+		t.Fatalf("Legs count = %d, want 2", len(check.Legs))
+	}
+
+	first := check.Legs[0]
+	if len(first.Zones) != 1 || first.Zones[0].ZoneId != 101 {
+		t.Fatalf("First leg zones = %+v, want only the West Zone", first.Zones)
+	}
+	if len(first.Zones[0].Bands) != 1 || first.Zones[0].Bands[0] != "9000 to 11500 ft" {
+		t.Errorf("First leg bands = %v, want only the middle band", first.Zones[0].Bands)
+	}
+	if first.MaxDanger != DangerModerate {
+		t.Errorf("First leg MaxDanger = %v, want Moderate", first.MaxDanger)
+	}
+	if len(first.Zones[0].Problems) != 0 {
+		t.Errorf("First leg problems = %v, want none for an east-west leg", first.Zones[0].Problems)
+	}
+
+	second := check.Legs[1]
+	if len(second.Zones) != 2 {
+		t.Fatalf("Second leg zones = %+v, want both zones across the boundary", second.Zones)
+	}
+	west, east := second.Zones[0], second.Zones[1]
+	if west.ZoneId != 101 || east.ZoneId != 102 {
+		t.Fatalf("Second leg zone order = %d, %d, want 101, 102", west.ZoneId, east.ZoneId)
+	}
+	if len(west.Bands) != 2 || west.Bands[0] != "9000 to 11500 ft" || west.Bands[1] != "Above 11500 ft" {
+		t.Errorf("West bands = %v, want middle and upper", west.Bands)
+	}
+	if west.Danger != DangerConsiderable {
+		t.Errorf("West danger = %v, want Considerable from the upper band", west.Danger)
+	}
+	// The East Zone publishes no bands, so the leg is matched conservatively
+	// against all three
+	if len(east.Bands) != 3 {
+		t.Errorf("East bands = %v, want all three without published boundaries", east.Bands)
+	}
+	if east.Danger != DangerHigh {
+		t.Errorf("East danger = %v, want High", east.Danger)
+	}
+	if len(east.Problems) != 1 || east.Problems[0] != "Wind Slab" {
+		t.Errorf("East problems = %v, want the east-aspect Wind Slab", east.Problems)
+	}
+	if second.MaxDanger != DangerHigh {
+		t.Errorf("Second leg MaxDanger = %v, want High", second.MaxDanger)
+	}
+
+	if check.MaxDanger != DangerHigh {
+		t.Errorf("Route MaxDanger = %v, want High", check.MaxDanger)
+	}
+	if len(check.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none", check.Warnings)
+	}
+}
+
+func TestCheckRouteOutsideCoverage(t *testing.T) {
+	service := newRouteTestService(t)
+
+	check, err := service.CheckRoute([]RouteWaypoint{
+		{Latitude: 45.0, Longitude: -100.0},
+		{Latitude: 45.1, Longitude: -100.0},
+	})
+	if err != nil {
+		t.Fatalf("CheckRoute returned error: %v", err)
+	}
+	if len(check.Legs) != 1 {
+		t.Fatalf("Legs count = %d, want 1", len(check.Legs))
+	}
+	if len(check.Legs[0].Zones) != 0 {
+		t.Errorf("Zones = %+v, want none outside coverage", check.Legs[0].Zones)
+	}
+	if check.MaxDanger != DangerNone {
+		t.Errorf("MaxDanger = %v, want none", check.MaxDanger)
+	}
+}
+
+func TestCheckRouteForecastUnavailable(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAvalancheServiceWithProviders(logger,
+		&mockMapLayerProvider{response: routeTestMapLayer(t)},
+		&routeForecastProvider{err: errors.New("center offline")},
+		&mockAccidentProvider{})
+
+	check, err := service.CheckRoute([]RouteWaypoint{
+		{Latitude: 39.5, Longitude: -105.8},
+		{Latitude: 39.5, Longitude: -105.7},
+	})
+	if err != nil {
+		t.Fatalf("CheckRoute returned error: %v", err)
+	}
+	if len(check.Warnings) != 1 {
+		t.Fatalf("Warnings = %v, want one for the unreachable zone", check.Warnings)
+	}
+	if len(check.Legs[0].Zones) != 0 {
+		t.Errorf("Zones = %+v, want no annotation without a forecast", check.Legs[0].Zones)
+	}
+}
+
+func TestCheckRouteValidation(t *testing.T) {
+	service := newRouteTestService(t)
+
+	if _, err := service.CheckRoute([]RouteWaypoint{{Latitude: 39.5, Longitude: -105.8}}); err == nil {
+		t.Error("Expected an error for a single waypoint")
+	}
+
+	tooMany := make([]RouteWaypoint, MaxRouteWaypoints+1)
+	for i := range tooMany {
+		tooMany[i] = RouteWaypoint{Latitude: 39.5, Longitude: -105.8}
+	}
+	if _, err := service.CheckRoute(tooMany); err == nil {
+		t.Error("Expected an error above the waypoint cap")
+	}
+}
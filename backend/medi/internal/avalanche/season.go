@@ -0,0 +1,67 @@
+package avalanche
+
+import "time"
+
+// Default bounds of the northern-hemisphere avalanche season, as "MM-DD"
+const (
+	DefaultSeasonStart = "11-01"
+	DefaultSeasonEnd   = "05-31"
+)
+
+// InSeason reports whether now falls inside the avalanche season for a
+// location. start and end bound the northern-hemisphere season as inclusive
+// "MM-DD" dates; southern latitudes shift the window six months. Malformed
+// bounds fall back to the defaults.
+func InSeason(now time.Time, latitude float64, start, end string) bool {
+	startMonth, startDay, ok := parseMonthDay(start)
+	if !ok {
+		startMonth, startDay, _ = parseMonthDay(DefaultSeasonStart)
+	}
+	endMonth, endDay, ok := parseMonthDay(end)
+	if !ok {
+		endMonth, endDay, _ = parseMonthDay(DefaultSeasonEnd)
+	}
+
+	if latitude < 0 {
+		startMonth, startDay = shiftHalfYear(startMonth, startDay)
+		endMonth, endDay = shiftHalfYear(endMonth, endDay)
+	}
+
+	day := monthDayOrdinal(now.Month(), now.Day())
+	first := monthDayOrdinal(startMonth, startDay)
+	last := monthDayOrdinal(endMonth, endDay)
+
+	// A window like November through May wraps the turn of the year
+	if first > last {
+		return day >= first || day <= last
+	}
+	return day >= first && day <= last
+}
+
+func parseMonthDay(s string) (time.Month, int, bool) {
+	t, err := time.Parse("01-02", s)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Month(), t.Day(), true
+}
+
+// shiftHalfYear moves a month-day bound six months, clamping the day for
+// shorter target months (May 31 becomes November 30)
+func shiftHalfYear(month time.Month, day int) (time.Month, int) {
+	shifted := time.Month((int(month)+5)%12 + 1)
+	if last := lastDayOf(shifted); day > last {
+		day = last
+	}
+	return shifted, day
+}
+
+// lastDayOf returns the day count of a month in a non-leap year
+func lastDayOf(month time.Month) int {
+	return time.Date(2001, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// monthDayOrdinal orders month-day pairs within a year
+func monthDayOrdinal(month time.Month, day int) int {
+	return int(month)*100 + day
+}
@@ -0,0 +1,52 @@
+package avalanche
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInSeason(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 12, 0, 0, 0, time.UTC)
+	}
+	const colorado, patagonia = 39.1, -41.1
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		latitude float64
+		want     bool
+	}{
+		{"northern midwinter", date(time.January, 15), colorado, true},
+		{"northern summer", date(time.July, 15), colorado, false},
+		{"northern season opens", date(time.November, 1), colorado, true},
+		{"northern season closes", date(time.May, 31), colorado, true},
+		{"northern day after close", date(time.June, 1), colorado, false},
+		{"southern winter", date(time.July, 15), patagonia, true},
+		{"southern summer", date(time.January, 15), patagonia, false},
+		// The shifted close lands on November 30 (May 31 plus six months,
+		// clamped to the shorter month)
+		{"southern season closes", date(time.November, 30), patagonia, true},
+		{"southern day after close", date(time.December, 1), patagonia, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := InSeason(tc.now, tc.latitude, DefaultSeasonStart, DefaultSeasonEnd)
+			if got != tc.want {
+				t.Errorf("InSeason(%s, %v) = %v, want %v",
+					tc.now.Format("Jan 2"), tc.latitude, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInSeasonMalformedBoundsFallBack(t *testing.T) {
+	july := time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC)
+	if InSeason(july, 39.1, "winter", "whenever") {
+		t.Error("malformed bounds should fall back to the default window")
+	}
+	january := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	if !InSeason(january, 39.1, "", "") {
+		t.Error("empty bounds should fall back to the default window")
+	}
+}
@@ -1,11 +1,25 @@
 package avalanche
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"medi/internal/cache"
+	"medi/internal/logging"
+	"medi/internal/providers"
 	"medi/internal/providers/nac"
+	"medi/internal/types"
 )
 
+// ErrZoneNotFound indicates no forecast zone matched the requested center and zone ids.
+var ErrZoneNotFound = errors.New("avalanche zone not found")
+
+// maxZoneSamplePoints caps how many representative points are sampled per zone.
+const maxZoneSamplePoints = 5
+
 // MapLayerProvider fetches the NAC map layer with all forecast zone polygons.
 type MapLayerProvider interface {
 	GetMapLayer() (*nac.MapLayerResponse, error)
@@ -16,21 +30,40 @@ type ForecastProvider interface {
 	GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error)
 }
 
+// AccidentProvider fetches the avalanche accident dataset.
+type AccidentProvider interface {
+	GetAccidents() ([]nac.Accident, error)
+}
+
 // Service provides avalanche forecast data.
 type Service interface {
 	GetForecast(latitude, longitude float64) (*AvalancheForecast, error)
+	GetZoneSamplePoints(centerId string, zoneId int) ([]types.Coords, error)
+	GetIncidents(latitude, longitude, radiusKm float64, seasonOnly bool) (*IncidentSearch, error)
+	GetZones(filter ZoneFilter) (*ZoneList, error)
+	GetCenters() (*CenterList, error)
+	GetSummary(filter SummaryFilter) (*RegionSummary, error)
+	CheckRoute(waypoints []RouteWaypoint) (*RouteCheck, error)
 }
 
 type avalancheService struct {
 	mapLayerProvider MapLayerProvider
 	forecastProvider ForecastProvider
-	logger           *slog.Logger
+	accidentProvider AccidentProvider
+	incidentsCache   *cache.Cache[[]Incident]
+	forecastCache    *cache.Cache[*AvalancheForecast]
+	// mapLayerCache holds the national map layer under the two-tier staleness
+	// policy in maplayer.go; mapLayerRefreshing keeps background refreshes to
+	// one in flight
+	mapLayerCache      *cache.Cache[*nac.MapLayerResponse]
+	mapLayerRefreshing atomic.Bool
+	logger             *slog.Logger
+	now                func() time.Time
 }
 
-// NewAvalancheService creates a new avalanche service with a real NAC client.
-func NewAvalancheService(logger *slog.Logger) Service {
-	client := nac.NewClient(logger)
-	return NewAvalancheServiceWithProviders(logger, client, client)
+// NewAvalancheService creates a new avalanche service from the provider registry.
+func NewAvalancheService(p *providers.Providers, logger *slog.Logger) Service {
+	return NewAvalancheServiceWithProviders(logger, p.AvalancheMapLayer, p.AvalancheForecast, p.Accidents)
 }
 
 // NewAvalancheServiceWithProviders creates a new avalanche service with custom providers.
@@ -39,11 +72,17 @@ func NewAvalancheServiceWithProviders(
 	logger *slog.Logger,
 	mapLayerProvider MapLayerProvider,
 	forecastProvider ForecastProvider,
+	accidentProvider AccidentProvider,
 ) Service {
 	return &avalancheService{
 		mapLayerProvider: mapLayerProvider,
 		forecastProvider: forecastProvider,
-		logger:           logger.With("component", "avalanche-service"),
+		accidentProvider: accidentProvider,
+		incidentsCache:   cache.New[[]Incident](incidentsCacheTTL),
+		forecastCache:    cache.New[*AvalancheForecast](zoneForecastCacheTTL),
+		mapLayerCache:    cache.New[*nac.MapLayerResponse](mapLayerSoftTTL),
+		logger:           logging.OrDefault(logger).With("component", "avalanche-service"),
+		now:              time.Now,
 	}
 }
 
@@ -52,23 +91,20 @@ func NewAvalancheServiceWithProviders(
 // it to domain types.
 func (s *avalancheService) GetForecast(latitude, longitude float64) (*AvalancheForecast, error) {
 	s.logger.Debug("getting avalanche forecast",
-		"latitude", latitude,
-		"longitude", longitude,
+		logging.DebugCoords(latitude, longitude),
 	)
 
 	// Fetch the map layer to find the matching zone
-	mapLayer, err := s.mapLayerProvider.GetMapLayer()
+	mapLayer, _, err := s.getMapLayer()
 	if err != nil {
-		s.logger.Error("failed to get map layer", "error", err)
-		return nil, fmt.Errorf("failed to get map layer: %w", err)
+		return nil, err
 	}
 
 	// Find the zone containing the given coordinates
 	zone := nac.FindZone(latitude, longitude, mapLayer)
 	if zone == nil {
 		s.logger.Warn("no avalanche forecast zone found for coordinates",
-			"latitude", latitude,
-			"longitude", longitude,
+			logging.Coords(latitude, longitude),
 		)
 		return nil, fmt.Errorf("no avalanche forecast zone found for coordinates (%.6f, %.6f)", latitude, longitude)
 	}
@@ -92,6 +128,7 @@ func (s *avalancheService) GetForecast(latitude, longitude float64) (*AvalancheF
 
 	// Map NAC response to domain model
 	forecast := mapForecastResponse(zone, forecastResp)
+	s.warnUnknownProblems(forecast)
 
 	s.logger.Debug("successfully mapped avalanche forecast",
 		"zone_name", forecast.Zone.Name,
@@ -102,3 +139,39 @@ func (s *avalancheService) GetForecast(latitude, longitude float64) (*AvalancheF
 
 	return forecast, nil
 }
+
+// GetZoneSamplePoints returns representative coordinates inside a forecast
+// zone: the centroid plus interior quantile grid points, capped at
+// maxZoneSamplePoints.
+func (s *avalancheService) GetZoneSamplePoints(centerId string, zoneId int) ([]types.Coords, error) {
+	mapLayer, _, err := s.getMapLayer()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range mapLayer.Features {
+		feature := &mapLayer.Features[i]
+		if feature.Id != zoneId || feature.Properties.CenterId != centerId {
+			continue
+		}
+
+		samples := nac.SamplePoints(&feature.Geometry, maxZoneSamplePoints)
+		if len(samples) == 0 {
+			return nil, fmt.Errorf("zone %s/%d has no usable geometry", centerId, zoneId)
+		}
+
+		coords := make([]types.Coords, 0, len(samples))
+		for _, sample := range samples {
+			coords = append(coords, types.NewCoords(sample.Latitude, sample.Longitude))
+		}
+
+		s.logger.Debug("sampled zone points",
+			"center_id", centerId,
+			"zone_id", zoneId,
+			"points", len(coords),
+		)
+		return coords, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s/%d", ErrZoneNotFound, centerId, zoneId)
+}
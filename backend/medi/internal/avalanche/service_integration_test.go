@@ -4,6 +4,8 @@ package avalanche
 
 import (
 	"log/slog"
+	"medi/internal/config"
+	"medi/internal/providers"
 	"os"
 	"testing"
 )
@@ -17,7 +19,7 @@ func TestAvalancheService_GetForecast_Integration(t *testing.T) {
 		Level: slog.LevelDebug,
 	}))
 
-	svc := NewAvalancheService(logger)
+	svc := NewAvalancheService(providers.NewLive(&config.Config{}, logger), logger)
 
 	t.Logf("Fetching avalanche forecast for coordinates: lat=%f, lon=%f", lat, lon)
 
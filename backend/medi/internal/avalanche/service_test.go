@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"medi/internal/providers/nac"
+	"medi/internal/testsupport"
 	"os"
 	"testing"
 	"time"
@@ -171,90 +172,35 @@ func TestMapForecastResponse(t *testing.T) {
 
 	mediaURL := json.RawMessage(`{"large":"https://example.com/large.jpg","medium":"https://example.com/med.jpg","original":"https://example.com/orig.jpg","thumbnail":"https://example.com/thumb.jpg"}`)
 
-	resp := &nac.ForecastResponse{
-		Id:               12345,
-		PublishedTime:    published,
-		ExpiresTime:      expires,
-		Author:           "John Doe",
-		BottomLine:       "<p>Moderate danger</p>",
-		HazardDiscussion: "<p>Watch for wind slabs</p>",
-		AvalancheCenter: struct {
-			Id    string `json:"id"`
-			Name  string `json:"name"`
-			Url   string `json:"url"`
-			City  string `json:"city"`
-			State string `json:"state"`
-		}{
-			Id:    "CAIC",
-			Name:  "Colorado Avalanche Information Center",
-			Url:   "https://avalanche.state.co.us",
-			City:  "Boulder",
-			State: "CO",
-		},
-		Danger: []struct {
-			Lower    int    `json:"lower"`
-			Upper    int    `json:"upper"`
-			Middle   int    `json:"middle"`
-			ValidDay string `json:"valid_day"`
-		}{
-			{Lower: 1, Middle: 2, Upper: 3, ValidDay: "current"},
-			{Lower: 1, Middle: 1, Upper: 2, ValidDay: "tomorrow"},
-		},
-		ForecastZone: []struct {
-			Id     int         `json:"id"`
-			Name   string      `json:"name"`
-			Url    string      `json:"url"`
-			State  string      `json:"state"`
-			ZoneId string      `json:"zone_id"`
-			Config interface{} `json:"config"`
-		}{
-			{Id: 2690, Name: "Aspen", Url: "https://avalanche.state.co.us/forecasts/aspen", State: "CO"},
-		},
-	}
-
-	// Set forecast avalanche problems
-	resp.ForecastAvalancheProblems = []struct {
-		Id                 int    `json:"id"`
-		ForecastId         int    `json:"forecast_id"`
-		AvalancheProblemId int    `json:"avalanche_problem_id"`
-		Rank               int    `json:"rank"`
-		Likelihood         string `json:"likelihood"`
-		Discussion         string `json:"discussion"`
-		Media              struct {
-			Url     json.RawMessage `json:"url"`
-			Type    string          `json:"type"`
-			Title   interface{}     `json:"title"`
-			Caption string          `json:"caption"`
-		} `json:"media"`
-		Location           []string `json:"location"`
-		Size               []string `json:"size"`
-		Name               string   `json:"name"`
-		ProblemDescription string   `json:"problem_description"`
-		Icon               string   `json:"icon"`
-	}{
-		{
+	resp := testsupport.NewNACForecastBuilder().
+		Id(12345).
+		Published(published).
+		Expires(expires).
+		Author("John Doe").
+		BottomLine("<p>Moderate danger</p>").
+		HazardDiscussion("<p>Watch for wind slabs</p>").
+		Center("CAIC", "Colorado Avalanche Information Center", "https://avalanche.state.co.us", "Boulder", "CO").
+		Danger(1, 2, 3, "current").
+		Danger(1, 1, 2, "tomorrow").
+		Zone(2690, "Aspen", "https://avalanche.state.co.us/forecasts/aspen", "CO", nil).
+		Problem(testsupport.NACProblem{
 			Rank:       1,
+			Name:       "Wind Slab",
 			Likelihood: "veryLikely",
 			Discussion: "<p>Wind slab discussion</p>",
-			Name:       "Wind Slab",
 			Location:   []string{"north upper", "northeast upper"},
 			Size:       []string{"1.5", "2.5"},
-			Media: struct {
-				Url     json.RawMessage `json:"url"`
-				Type    string          `json:"type"`
-				Title   interface{}     `json:"title"`
-				Caption string          `json:"caption"`
-			}{Url: mediaURL},
-		},
-		{
+			MediaURL:   mediaURL,
+		}).
+		Problem(testsupport.NACProblem{
 			Rank:       2,
+			Name:       "Persistent Slab",
 			Likelihood: "possible",
 			Discussion: "<p>Persistent slab discussion</p>",
-			Name:       "Persistent Slab",
 			Location:   []string{"north upper"},
 			Size:       []string{"2", "3"},
-		},
-	}
+		}).
+		Build(t)
 
 	forecast := mapForecastResponse(zone, resp)
 
@@ -309,6 +255,13 @@ func TestMapForecastResponse(t *testing.T) {
 	if forecast.DangerRatings[0].Upper != DangerConsiderable {
 		t.Errorf("DangerRatings[0].Upper = %d, want %d", forecast.DangerRatings[0].Upper, DangerConsiderable)
 	}
+	// The fixture zone ships a null config, so bands use the treeline labels
+	if forecast.Zone.Config != nil {
+		t.Errorf("Zone.Config = %+v, want nil for a null config", forecast.Zone.Config)
+	}
+	if forecast.DangerRatings[0].Bands != treelineBandLabels {
+		t.Errorf("DangerRatings[0].Bands = %+v, want treeline labels", forecast.DangerRatings[0].Bands)
+	}
 
 	// Verify problems
 	if len(forecast.Problems) != 2 {
@@ -347,38 +300,22 @@ func TestMapForecastResponse(t *testing.T) {
 }
 
 func TestAvalancheService_GetForecast_AspenSnapshot(t *testing.T) {
-	// Load map layer snapshot
-	mapLayerData, err := os.ReadFile("testdata/nac_map_layer_response.json")
-	if err != nil {
-		t.Fatalf("Failed to read map layer testdata: %v", err)
-	}
-	var mapLayer nac.MapLayerResponse
-	if err := json.Unmarshal(mapLayerData, &mapLayer); err != nil {
-		t.Fatalf("Failed to unmarshal map layer: %v", err)
-	}
-
-	// Load forecast snapshot
-	forecastData, err := os.ReadFile("testdata/nac_forecast_response.json")
-	if err != nil {
-		t.Fatalf("Failed to read forecast testdata: %v", err)
-	}
-	var forecastResp nac.ForecastResponse
-	if err := json.Unmarshal(forecastData, &forecastResp); err != nil {
-		t.Fatalf("Failed to unmarshal forecast: %v", err)
-	}
+	// Load the committed snapshots
+	mapLayer := testsupport.NACMapLayerFixture(t)
+	forecastResp := testsupport.NACForecastFixture(t)
 
 	// Find the zone for Aspen coordinates
-	zone := nac.FindZone(39.11539, -107.65840, &mapLayer)
+	zone := nac.FindZone(39.11539, -107.65840, mapLayer)
 	if zone == nil {
 		t.Fatal("No zone found for Aspen coordinates in snapshot")
 	}
 
 	// Mock providers that return the snapshot data
-	mapLayerProvider := &mockMapLayerProvider{response: &mapLayer}
-	forecastProvider := &mockForecastProvider{response: &forecastResp}
+	mapLayerProvider := &mockMapLayerProvider{response: mapLayer}
+	forecastProvider := &mockForecastProvider{response: forecastResp}
 
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
-	service := NewAvalancheServiceWithProviders(logger, mapLayerProvider, forecastProvider)
+	service := NewAvalancheServiceWithProviders(logger, mapLayerProvider, forecastProvider, &mockAccidentProvider{})
 
 	forecast, err := service.GetForecast(39.11539, -107.65840)
 	if err != nil {
@@ -490,17 +427,11 @@ func TestMapForecastResponse_EmptyForecast(t *testing.T) {
 		},
 	}
 
-	resp := &nac.ForecastResponse{
-		PublishedTime: time.Now(),
-		ExpiresTime:   time.Now().Add(24 * time.Hour),
-		AvalancheCenter: struct {
-			Id    string `json:"id"`
-			Name  string `json:"name"`
-			Url   string `json:"url"`
-			City  string `json:"city"`
-			State string `json:"state"`
-		}{Id: "UAC", Name: "Utah Avalanche Center"},
-	}
+	resp := testsupport.NewNACForecastBuilder().
+		Published(time.Now()).
+		Expires(time.Now().Add(24*time.Hour)).
+		Center("UAC", "Utah Avalanche Center", "", "", "").
+		Build(t)
 
 	forecast := mapForecastResponse(zone, resp)
 
@@ -517,3 +448,126 @@ func TestMapForecastResponse_EmptyForecast(t *testing.T) {
 		t.Errorf("Center.Id = %q, want %q", forecast.Center.Id, "UAC")
 	}
 }
+
+type mockAccidentProvider struct {
+	accidents []nac.Accident
+	err       error
+	calls     int
+}
+
+func (m *mockAccidentProvider) GetAccidents() ([]nac.Accident, error) {
+	m.calls++
+	return m.accidents, m.err
+}
+
+func TestMapAccidentsResponse(t *testing.T) {
+	accidents := testsupport.NACAccidentsFixture(t)
+	incidents := mapAccidentsResponse(accidents)
+
+	// The record with an unparseable date is dropped; everything else maps
+	if len(incidents) != 6 {
+		t.Fatalf("mapped %d incidents, want 6", len(incidents))
+	}
+
+	first := incidents[0]
+	if first.Id != "acc-2025-014" {
+		t.Errorf("Id = %q, want acc-2025-014", first.Id)
+	}
+	if want := time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC); !first.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", first.Date, want)
+	}
+	if first.Coordinates == nil {
+		t.Fatal("Coordinates is nil for a located record")
+	}
+	if first.Coordinates.Latitude != 39.1633 || first.Coordinates.Longitude != -106.8769 {
+		t.Errorf("Coordinates = %+v, want (39.1633, -106.8769)", *first.Coordinates)
+	}
+	if first.Caught != 2 || first.Buried != 1 || first.Killed != 0 {
+		t.Errorf("involvement = caught %d buried %d killed %d, want 2/1/0", first.Caught, first.Buried, first.Killed)
+	}
+	if first.ZoneName != "Aspen" || first.CenterId != "CAIC" {
+		t.Errorf("zone = %s/%s, want Aspen/CAIC", first.CenterId, first.ZoneName)
+	}
+
+	unlocated := 0
+	for _, incident := range incidents {
+		if incident.Coordinates == nil {
+			unlocated++
+		}
+	}
+	if unlocated != 2 {
+		t.Errorf("%d incidents without coordinates, want 2", unlocated)
+	}
+}
+
+func TestSearchIncidentsRadiusAndSorting(t *testing.T) {
+	incidents := mapAccidentsResponse(testsupport.NACAccidentsFixture(t))
+
+	result := searchIncidents(incidents, 39.11539, -107.65840, 100, "Aspen", "CAIC", time.Time{})
+
+	// Three located Aspen-area incidents are in range; the Front Range one
+	// (~180 km away) is not
+	if len(result.Incidents) != 3 {
+		t.Fatalf("got %d incidents in radius, want 3", len(result.Incidents))
+	}
+	wantOrder := []string{"acc-2024-031", "acc-2025-014", "acc-2024-102"}
+	for i, want := range wantOrder {
+		if result.Incidents[i].Id != want {
+			t.Errorf("Incidents[%d].Id = %q, want %q (sorted by distance)", i, result.Incidents[i].Id, want)
+		}
+	}
+	for i := 1; i < len(result.Incidents); i++ {
+		if result.Incidents[i].DistanceKm < result.Incidents[i-1].DistanceKm {
+			t.Errorf("Incidents[%d] closer than Incidents[%d]", i, i-1)
+		}
+	}
+	if result.Incidents[0].DistanceKm <= 0 {
+		t.Error("DistanceKm not populated on results")
+	}
+
+	// Only the unlocated incident in the matching zone is counted
+	if result.UnlocatedInZone != 1 {
+		t.Errorf("UnlocatedInZone = %d, want 1 (Aspen only, not Gunnison)", result.UnlocatedInZone)
+	}
+	if result.ZoneName != "Aspen" {
+		t.Errorf("ZoneName = %q, want Aspen", result.ZoneName)
+	}
+}
+
+func TestSearchIncidentsSeasonFilter(t *testing.T) {
+	incidents := mapAccidentsResponse(testsupport.NACAccidentsFixture(t))
+
+	since := seasonStart(time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC); !since.Equal(want) {
+		t.Fatalf("seasonStart = %v, want %v", since, want)
+	}
+
+	result := searchIncidents(incidents, 39.11539, -107.65840, 100, "Aspen", "CAIC", since)
+
+	// The March 2024 accident belongs to the previous season
+	if len(result.Incidents) != 2 {
+		t.Fatalf("got %d incidents, want 2", len(result.Incidents))
+	}
+	for _, incident := range result.Incidents {
+		if incident.Id == "acc-2024-031" {
+			t.Error("previous-season incident survived the season filter")
+		}
+	}
+}
+
+func TestIncidentDatasetCachedDaily(t *testing.T) {
+	provider := &mockAccidentProvider{accidents: testsupport.NACAccidentsFixture(t)}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAvalancheServiceWithProviders(logger, &mockMapLayerProvider{response: &nac.MapLayerResponse{}}, &mockForecastProvider{}, provider)
+
+	if _, err := service.GetIncidents(39.11539, -107.65840, 100, false); err != nil {
+		t.Fatalf("GetIncidents returned error: %v", err)
+	}
+	if _, err := service.GetIncidents(39.11539, -107.65840, 100, false); err != nil {
+		t.Fatalf("GetIncidents returned error: %v", err)
+	}
+
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1 (dataset cached)", provider.calls)
+	}
+}
@@ -0,0 +1,203 @@
+package avalanche
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"medi/internal/cache"
+	"medi/internal/providers/nac"
+)
+
+// summaryFetchConcurrency caps how many zone forecasts a regional summary
+// fetches in parallel.
+const summaryFetchConcurrency = 4
+
+// zoneForecastCacheTTL keeps fetched zone forecasts around between summary
+// requests; centers publish at most a few times a day.
+const zoneForecastCacheTTL = 30 * time.Minute
+
+// SummaryFilter selects the zones a regional summary covers. At least one
+// field must be set; both restrict the selection when given together.
+type SummaryFilter struct {
+	// State keeps zones in one US state, matched case-insensitively
+	State string
+	// CenterId keeps zones belonging to one avalanche center, matched
+	// case-insensitively
+	CenterId string
+}
+
+// DangerHistogram counts zones per overall danger level, indexed by
+// DangerLevel: 0 (no rating) through 5 (extreme).
+type DangerHistogram [6]int
+
+// ProblemCount ranks one avalanche problem type by how many zones' forecasts
+// report it.
+type ProblemCount struct {
+	Name  string
+	Zones int
+}
+
+// RegionSummary condenses the state of the snowpack across every zone a
+// filter selects: danger histograms for today and tomorrow, active warnings,
+// the worst-rated zones, and the problem types forecasters name most.
+type RegionSummary struct {
+	// ZonesMatched counts the zones the filter selected, ZonesIncluded how
+	// many of them contributed a forecast, and ZonesFailed how many could
+	// not be fetched
+	ZonesMatched  int
+	ZonesIncluded int
+	ZonesFailed   int
+	// ZonesUnderWarning counts matched zones carrying an active avalanche
+	// warning product
+	ZonesUnderWarning int
+	// CurrentDanger and TomorrowDanger histogram the included zones by their
+	// overall danger for each valid day
+	CurrentDanger  DangerHistogram
+	TomorrowDanger DangerHistogram
+	// HighestDanger is the top current overall danger across included
+	// zones; HighestDangerZones names the zones rated there
+	HighestDanger      DangerLevel
+	HighestDangerZones []string
+	// CommonProblems ranks problem types by the number of zones reporting
+	// them, most common first
+	CommonProblems []ProblemCount
+}
+
+// GetSummary aggregates the forecasts of every zone the filter selects.
+// Zones whose forecast cannot be fetched are dropped from the histograms and
+// counted in ZonesFailed, so a single struggling center does not sink the
+// whole dashboard.
+func (s *avalancheService) GetSummary(filter SummaryFilter) (*RegionSummary, error) {
+	if filter.State == "" && filter.CenterId == "" {
+		return nil, fmt.Errorf("summary requires a state or center filter")
+	}
+
+	mapLayer, _, err := s.getMapLayer()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*nac.MapLayerFeature
+	for i := range mapLayer.Features {
+		feature := &mapLayer.Features[i]
+		props := feature.Properties
+		if filter.State != "" && !strings.EqualFold(props.State, filter.State) {
+			continue
+		}
+		if filter.CenterId != "" && !strings.EqualFold(props.CenterId, filter.CenterId) {
+			continue
+		}
+		matched = append(matched, feature)
+	}
+
+	summary := &RegionSummary{ZonesMatched: len(matched)}
+	for _, feature := range matched {
+		if feature.Properties.Warning.Product != "" {
+			summary.ZonesUnderWarning++
+		}
+	}
+
+	// Fan out the forecast fetches, bounded by the concurrency cap
+	forecasts := make([]*AvalancheForecast, len(matched))
+	fetchErrs := make([]error, len(matched))
+	sem := make(chan struct{}, summaryFetchConcurrency)
+	var wg sync.WaitGroup
+	for i, feature := range matched {
+		wg.Add(1)
+		go func(i int, feature *nac.MapLayerFeature) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			forecasts[i], fetchErrs[i] = s.zoneForecast(feature)
+		}(i, feature)
+	}
+	wg.Wait()
+
+	problemZones := map[string]int{}
+	for i, forecast := range forecasts {
+		if fetchErrs[i] != nil {
+			s.logger.Warn("failed to get forecast for summary zone",
+				"center_id", matched[i].Properties.CenterId,
+				"zone_id", matched[i].Id,
+				"error", fetchErrs[i],
+			)
+			summary.ZonesFailed++
+			continue
+		}
+		summary.ZonesIncluded++
+
+		current := overallDanger(forecast, "current")
+		summary.CurrentDanger[current]++
+		summary.TomorrowDanger[overallDanger(forecast, "tomorrow")]++
+
+		if current > summary.HighestDanger {
+			summary.HighestDanger = current
+			summary.HighestDangerZones = summary.HighestDangerZones[:0]
+		}
+		if current == summary.HighestDanger {
+			summary.HighestDangerZones = append(summary.HighestDangerZones, forecast.Zone.Name)
+		}
+
+		// Each problem type counts once per zone no matter how often the
+		// forecast lists it
+		seen := map[string]bool{}
+		for _, problem := range forecast.Problems {
+			if problem.Name == "" || seen[problem.Name] {
+				continue
+			}
+			seen[problem.Name] = true
+			problemZones[problem.Name]++
+		}
+	}
+
+	for name, zones := range problemZones {
+		summary.CommonProblems = append(summary.CommonProblems, ProblemCount{Name: name, Zones: zones})
+	}
+	sort.Slice(summary.CommonProblems, func(i, j int) bool {
+		a, b := summary.CommonProblems[i], summary.CommonProblems[j]
+		if a.Zones != b.Zones {
+			return a.Zones > b.Zones
+		}
+		return a.Name < b.Name
+	})
+
+	return summary, nil
+}
+
+// zoneForecast returns the mapped forecast for one zone, served from the
+// zone forecast cache when a fresh entry exists.
+func (s *avalancheService) zoneForecast(feature *nac.MapLayerFeature) (*AvalancheForecast, error) {
+	key := fmt.Sprintf("%s/%d", feature.Properties.CenterId, feature.Id)
+	if entry, status := s.forecastCache.Get(key); status == cache.StatusHit {
+		return entry.Value, nil
+	}
+
+	resp, err := s.forecastProvider.GetForecast(feature.Properties.CenterId, feature.Id)
+	if err != nil {
+		return nil, err
+	}
+	forecast := mapForecastResponse(feature, resp)
+	s.warnUnknownProblems(forecast)
+	s.forecastCache.Set(key, forecast)
+	return forecast, nil
+}
+
+// overallDanger reduces a zone's ratings for one valid day to the highest
+// level across its elevation bands, DangerNone when the day is unrated.
+func overallDanger(forecast *AvalancheForecast, validDay string) DangerLevel {
+	overall := DangerNone
+	for _, rating := range forecast.DangerRatings {
+		if !strings.EqualFold(rating.ValidDay, validDay) {
+			continue
+		}
+		for _, level := range []DangerLevel{rating.Lower, rating.Middle, rating.Upper} {
+			if level > overall && level <= DangerExtreme {
+				overall = level
+			}
+		}
+	}
+	return overall
+}
@@ -0,0 +1,221 @@
+package avalanche
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"medi/internal/providers/nac"
+	"medi/internal/testsupport"
+)
+
+// summaryTestMapLayer builds a map layer with three Colorado zones (one under
+// warning) and one Utah zone from a different center
+func summaryTestMapLayer(t *testing.T) *nac.MapLayerResponse {
+	t.Helper()
+
+	raw := `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"id": 201,
+				"type": "Feature",
+				"properties": {
+					"name": "Aspen",
+					"center_id": "CAIC",
+					"state": "CO",
+					"warning": {"product": "warning"}
+				}
+			},
+			{
+				"id": 202,
+				"type": "Feature",
+				"properties": {
+					"name": "Gunnison",
+					"center_id": "CAIC",
+					"state": "CO",
+					"warning": {"product": null}
+				}
+			},
+			{
+				"id": 203,
+				"type": "Feature",
+				"properties": {
+					"name": "Front Range",
+					"center_id": "CAIC",
+					"state": "CO",
+					"warning": {"product": null}
+				}
+			},
+			{
+				"id": 301,
+				"type": "Feature",
+				"properties": {
+					"name": "Salt Lake",
+					"center_id": "UAC",
+					"state": "UT",
+					"warning": {"product": null}
+				}
+			}
+		]
+	}`
+
+	var mapLayer nac.MapLayerResponse
+	if err := json.Unmarshal([]byte(raw), &mapLayer); err != nil {
+		t.Fatalf("Failed to build test map layer: %v", err)
+	}
+	return &mapLayer
+}
+
+// summaryForecastProvider serves a canned forecast per zone id, erroring for
+// absent zones, and counts the fetches per zone.
+type summaryForecastProvider struct {
+	responses map[int]*nac.ForecastResponse
+	calls     map[int]int
+}
+
+func (m *summaryForecastProvider) GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error) {
+	m.calls[zoneId]++
+	resp, ok := m.responses[zoneId]
+	if !ok {
+		return nil, errors.New("center offline")
+	}
+	return resp, nil
+}
+
+// newSummaryTestService wires the four-zone map layer with forecasts for all
+// zones except the Front Range, whose center is offline.
+func newSummaryTestService(t *testing.T) (Service, *summaryForecastProvider) {
+	t.Helper()
+
+	provider := &summaryForecastProvider{
+		calls: map[int]int{},
+		responses: map[int]*nac.ForecastResponse{
+			201: testsupport.NewNACForecastBuilder().
+				Zone(201, "Aspen", "", "CO", nil).
+				Danger(2, 3, 3, "current").
+				Danger(1, 2, 2, "tomorrow").
+				Problem(testsupport.NACProblem{Rank: 1, Name: "Wind Slab"}).
+				Problem(testsupport.NACProblem{Rank: 2, Name: "Persistent Slab"}).
+				Problem(testsupport.NACProblem{Rank: 3, Name: "Wind Slab"}).
+				Build(t),
+			202: testsupport.NewNACForecastBuilder().
+				Zone(202, "Gunnison", "", "CO", nil).
+				Danger(1, 2, 3, "current").
+				Danger(3, 3, 4, "tomorrow").
+				Problem(testsupport.NACProblem{Rank: 1, Name: "Wind Slab"}).
+				Build(t),
+			301: testsupport.NewNACForecastBuilder().
+				Zone(301, "Salt Lake", "", "UT", nil).
+				Danger(1, 1, 2, "current").
+				Build(t),
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAvalancheServiceWithProviders(logger,
+		&mockMapLayerProvider{response: summaryTestMapLayer(t)}, provider, &mockAccidentProvider{})
+	return service, provider
+}
+
+func TestGetSummaryHistogramsAndProblems(t *testing.T) {
+	service, _ := newSummaryTestService(t)
+
+	// The state filter is case-insensitive
+	summary, err := service.GetSummary(SummaryFilter{State: "co"})
+	if err != nil {
+		t.Fatalf("GetSummary returned error: %v", err)
+	}
+
+	if summary.ZonesMatched != 3 {
+		t.Errorf("ZonesMatched = %d, want 3", summary.ZonesMatched)
+	}
+	if summary.ZonesIncluded != 2 {
+		t.Errorf("ZonesIncluded = %d, want 2", summary.ZonesIncluded)
+	}
+	if summary.ZonesFailed != 1 {
+		t.Errorf("ZonesFailed = %d, want 1 for the offline Front Range", summary.ZonesFailed)
+	}
+	if summary.ZonesUnderWarning != 1 {
+		t.Errorf("ZonesUnderWarning = %d, want 1", summary.ZonesUnderWarning)
+	}
+
+	// Both included zones top out at considerable today
+	expectedCurrent := DangerHistogram{DangerConsiderable: 2}
+	if summary.CurrentDanger != expectedCurrent {
+		t.Errorf("CurrentDanger = %v, want %v", summary.CurrentDanger, expectedCurrent)
+	}
+	// Tomorrow Aspen eases to moderate while Gunnison's upper band hits high
+	expectedTomorrow := DangerHistogram{DangerModerate: 1, DangerHigh: 1}
+	if summary.TomorrowDanger != expectedTomorrow {
+		t.Errorf("TomorrowDanger = %v, want %v", summary.TomorrowDanger, expectedTomorrow)
+	}
+
+	if summary.HighestDanger != DangerConsiderable {
+		t.Errorf("HighestDanger = %v, want Considerable", summary.HighestDanger)
+	}
+	if len(summary.HighestDangerZones) != 2 ||
+		summary.HighestDangerZones[0] != "Aspen" || summary.HighestDangerZones[1] != "Gunnison" {
+		t.Errorf("HighestDangerZones = %v, want [Aspen Gunnison]", summary.HighestDangerZones)
+	}
+
+	// Wind Slab counts once per zone despite Aspen listing it twice
+	expectedProblems := []ProblemCount{
+		{Name: "Wind Slab", Zones: 2},
+		{Name: "Persistent Slab", Zones: 1},
+	}
+	if len(summary.CommonProblems) != len(expectedProblems) {
+		t.Fatalf("CommonProblems = %v, want %v", summary.CommonProblems, expectedProblems)
+	}
+	for i, expected := range expectedProblems {
+		if summary.CommonProblems[i] != expected {
+			t.Errorf("CommonProblems[%d] = %v, want %v", i, summary.CommonProblems[i], expected)
+		}
+	}
+}
+
+func TestGetSummaryByCenter(t *testing.T) {
+	service, _ := newSummaryTestService(t)
+
+	summary, err := service.GetSummary(SummaryFilter{CenterId: "uac"})
+	if err != nil {
+		t.Fatalf("GetSummary returned error: %v", err)
+	}
+
+	if summary.ZonesMatched != 1 || summary.ZonesIncluded != 1 || summary.ZonesFailed != 0 {
+		t.Errorf("Zone counts = %d/%d/%d, want 1 matched, 1 included, 0 failed",
+			summary.ZonesMatched, summary.ZonesIncluded, summary.ZonesFailed)
+	}
+	expectedCurrent := DangerHistogram{DangerModerate: 1}
+	if summary.CurrentDanger != expectedCurrent {
+		t.Errorf("CurrentDanger = %v, want %v", summary.CurrentDanger, expectedCurrent)
+	}
+	if len(summary.HighestDangerZones) != 1 || summary.HighestDangerZones[0] != "Salt Lake" {
+		t.Errorf("HighestDangerZones = %v, want [Salt Lake]", summary.HighestDangerZones)
+	}
+}
+
+func TestGetSummaryRequiresFilter(t *testing.T) {
+	service, _ := newSummaryTestService(t)
+
+	if _, err := service.GetSummary(SummaryFilter{}); err == nil {
+		t.Error("Expected an error without a state or center filter")
+	}
+}
+
+func TestGetSummaryReusesCachedForecasts(t *testing.T) {
+	service, provider := newSummaryTestService(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.GetSummary(SummaryFilter{State: "CO"}); err != nil {
+			t.Fatalf("GetSummary returned error: %v", err)
+		}
+	}
+
+	if provider.calls[201] != 1 || provider.calls[202] != 1 {
+		t.Errorf("Forecast fetches = %d/%d, want the second summary served from cache",
+			provider.calls[201], provider.calls[202])
+	}
+}
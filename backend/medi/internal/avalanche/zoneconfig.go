@@ -0,0 +1,145 @@
+package avalanche
+
+import (
+	"strconv"
+)
+
+// ZoneConfig carries the per-zone settings some centers publish in the NAC
+// forecast_zone config blob. The shape varies by center and many zones ship
+// null, so parsing is tolerant: recognized fields are typed, everything else
+// is preserved in Extra, and a malformed blob simply yields a nil config.
+type ZoneConfig struct {
+	// ElevationBandsFt holds the elevation band boundaries in feet,
+	// ascending; the first two values split lower/middle and middle/upper
+	ElevationBandsFt []float64
+	// DangerValidHours is how long the center expects a danger rating to
+	// stay valid, zero when not published
+	DangerValidHours int
+	// Extra preserves config fields this parser does not recognize
+	Extra map[string]interface{}
+}
+
+// elevationBandKeys and dangerValidHourKeys are the field names observed
+// across centers for the same settings.
+var (
+	elevationBandKeys   = []string{"elevation_bands", "elevations"}
+	dangerValidHourKeys = []string{"danger_valid_hours", "expected_danger_valid_hours", "valid_hours"}
+)
+
+// recognizedConfigKey reports whether the parser maps this field to a typed
+// ZoneConfig field.
+func recognizedConfigKey(key string) bool {
+	for _, known := range elevationBandKeys {
+		if key == known {
+			return true
+		}
+	}
+	for _, known := range dangerValidHourKeys {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
+// parseZoneConfig interprets the decoded config blob for a zone. It returns
+// nil for null, non-object, or empty configs; unknown fields never cause an
+// error so a config surprise cannot fail the forecast.
+func parseZoneConfig(raw interface{}) *ZoneConfig {
+	fields, ok := raw.(map[string]interface{})
+	if !ok || len(fields) == 0 {
+		return nil
+	}
+
+	config := &ZoneConfig{}
+
+	for _, key := range elevationBandKeys {
+		if bands := toFloatSlice(fields[key]); len(bands) > 0 {
+			config.ElevationBandsFt = bands
+			break
+		}
+	}
+	for _, key := range dangerValidHourKeys {
+		if hours, ok := toFloat(fields[key]); ok {
+			config.DangerValidHours = int(hours)
+			break
+		}
+	}
+
+	for key, value := range fields {
+		if recognizedConfigKey(key) {
+			continue
+		}
+		if config.Extra == nil {
+			config.Extra = map[string]interface{}{}
+		}
+		config.Extra[key] = value
+	}
+
+	return config
+}
+
+// toFloat coerces a decoded JSON value that may arrive as a number or a
+// numeric string.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return parsed, true
+	default:
+		return 0, false
+	}
+}
+
+// toFloatSlice coerces a decoded JSON array of numbers or numeric strings,
+// dropping entries that are neither.
+func toFloatSlice(value interface{}) []float64 {
+	entries, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var floats []float64
+	for _, entry := range entries {
+		if f, ok := toFloat(entry); ok {
+			floats = append(floats, f)
+		}
+	}
+	return floats
+}
+
+// BandLabels names the three danger rating elevation bands.
+type BandLabels struct {
+	Lower  string
+	Middle string
+	Upper  string
+}
+
+// treelineBandLabels is the fallback naming convention when a center does not
+// publish elevation boundaries.
+var treelineBandLabels = BandLabels{
+	Lower:  "Below Treeline",
+	Middle: "Near Treeline",
+	Upper:  "Above Treeline",
+}
+
+// DangerBandLabels labels the elevation bands with the zone's real boundary
+// elevations when the config provides at least two, falling back to the
+// treeline convention otherwise.
+func DangerBandLabels(config *ZoneConfig) BandLabels {
+	if config == nil || len(config.ElevationBandsFt) < 2 {
+		return treelineBandLabels
+	}
+
+	lowerBoundary := strconv.FormatFloat(config.ElevationBandsFt[0], 'f', -1, 64)
+	upperBoundary := strconv.FormatFloat(config.ElevationBandsFt[1], 'f', -1, 64)
+	return BandLabels{
+		Lower:  "Below " + lowerBoundary + " ft",
+		Middle: lowerBoundary + " to " + upperBoundary + " ft",
+		Upper:  "Above " + upperBoundary + " ft",
+	}
+}
@@ -0,0 +1,112 @@
+package avalanche
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeConfig mimics how the NAC client delivers the config blob: decoded
+// JSON in an interface{}.
+func decodeConfig(t *testing.T, blob string) interface{} {
+	t.Helper()
+	var raw interface{}
+	if err := json.Unmarshal([]byte(blob), &raw); err != nil {
+		t.Fatalf("Failed to decode config blob: %v", err)
+	}
+	return raw
+}
+
+func TestParseZoneConfigNumericBands(t *testing.T) {
+	// CAIC-style shape: numeric boundaries plus fields we do not type
+	raw := decodeConfig(t, `{
+		"elevation_bands": [9000, 11500],
+		"danger_valid_hours": 24,
+		"blog": true,
+		"display_order": 3
+	}`)
+
+	config := parseZoneConfig(raw)
+	if config == nil {
+		t.Fatal("Expected a parsed config")
+	}
+
+	if len(config.ElevationBandsFt) != 2 || config.ElevationBandsFt[0] != 9000 || config.ElevationBandsFt[1] != 11500 {
+		t.Errorf("ElevationBandsFt = %v, want [9000 11500]", config.ElevationBandsFt)
+	}
+	if config.DangerValidHours != 24 {
+		t.Errorf("DangerValidHours = %d, want 24", config.DangerValidHours)
+	}
+	if len(config.Extra) != 2 || config.Extra["blog"] != true {
+		t.Errorf("Extra = %v, want the blog and display_order fields preserved", config.Extra)
+	}
+}
+
+func TestParseZoneConfigStringBands(t *testing.T) {
+	// Other centers publish boundaries as numeric strings under "elevations"
+	raw := decodeConfig(t, `{
+		"elevations": ["8500", "10500"],
+		"valid_hours": "18"
+	}`)
+
+	config := parseZoneConfig(raw)
+	if config == nil {
+		t.Fatal("Expected a parsed config")
+	}
+
+	if len(config.ElevationBandsFt) != 2 || config.ElevationBandsFt[0] != 8500 || config.ElevationBandsFt[1] != 10500 {
+		t.Errorf("ElevationBandsFt = %v, want [8500 10500]", config.ElevationBandsFt)
+	}
+	if config.DangerValidHours != 18 {
+		t.Errorf("DangerValidHours = %d, want 18", config.DangerValidHours)
+	}
+	if config.Extra != nil {
+		t.Errorf("Extra = %v, want nil when every field is recognized", config.Extra)
+	}
+}
+
+func TestParseZoneConfigTolerant(t *testing.T) {
+	tests := []struct {
+		name string
+		blob string
+	}{
+		{"null config", `null`},
+		{"empty object", `{}`},
+		{"array instead of object", `[1, 2]`},
+		{"scalar", `"legacy"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if config := parseZoneConfig(decodeConfig(t, tt.blob)); config != nil {
+				t.Errorf("parseZoneConfig(%s) = %+v, want nil", tt.blob, config)
+			}
+		})
+	}
+
+	// Malformed field values are dropped rather than failing the parse
+	raw := decodeConfig(t, `{"elevation_bands": "not-a-list", "danger_valid_hours": {"nested": true}}`)
+	config := parseZoneConfig(raw)
+	if config == nil {
+		t.Fatal("Expected a config despite malformed fields")
+	}
+	if config.ElevationBandsFt != nil || config.DangerValidHours != 0 {
+		t.Errorf("Expected malformed fields dropped, got %+v", config)
+	}
+}
+
+func TestDangerBandLabels(t *testing.T) {
+	if got := DangerBandLabels(nil); got != treelineBandLabels {
+		t.Errorf("nil config labels = %+v, want treeline convention", got)
+	}
+
+	config := &ZoneConfig{ElevationBandsFt: []float64{9000, 11500}}
+	got := DangerBandLabels(config)
+	if got.Lower != "Below 9000 ft" || got.Middle != "9000 to 11500 ft" || got.Upper != "Above 11500 ft" {
+		t.Errorf("configured labels = %+v", got)
+	}
+
+	// A single boundary is not enough to split three bands
+	if got := DangerBandLabels(&ZoneConfig{ElevationBandsFt: []float64{9000}}); got != treelineBandLabels {
+		t.Errorf("single-boundary labels = %+v, want treeline convention", got)
+	}
+}
@@ -0,0 +1,119 @@
+package avalanche
+
+import (
+	"strings"
+
+	"medi/internal/providers/nac"
+)
+
+// BoundingBox is a latitude/longitude box for zone filtering.
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLon float64
+	MaxLon float64
+}
+
+// ZoneFilter selects and pages the national zone list.
+type ZoneFilter struct {
+	// CenterId keeps zones belonging to one avalanche center, matched
+	// case-insensitively; empty for no filter
+	CenterId string
+	// BBox keeps zones whose polygons intersect the box, nil for no filter
+	BBox *BoundingBox
+	// DangerAtLeast keeps zones with a current danger level at or above the
+	// value; zero keeps everything including unrated zones
+	DangerAtLeast int
+	// OffSeason keeps zones whose off-season flag matches, nil for no filter
+	OffSeason *bool
+	// Limit and Offset page the filtered list; a zero Limit returns all
+	// remaining zones
+	Limit  int
+	Offset int
+}
+
+// ZoneSummary is one forecast zone in the national list.
+type ZoneSummary struct {
+	Id           int
+	Name         string
+	CenterId     string
+	DangerLevel  DangerLevel
+	Danger       string
+	TravelAdvice string
+	OffSeason    bool
+	URL          string
+}
+
+// ZoneList is a page of the filtered zone list.
+type ZoneList struct {
+	// Total counts every zone matching the filter, across all pages
+	Total  int
+	Limit  int
+	Offset int
+	Zones  []ZoneSummary
+	// Freshness reports the age of the map-layer snapshot the list was
+	// built from
+	Freshness MapLayerFreshness
+}
+
+// GetZones lists the forecast zones matching the filter, paged by its limit
+// and offset, in map layer order.
+func (s *avalancheService) GetZones(filter ZoneFilter) (*ZoneList, error) {
+	mapLayer, freshness, err := s.getMapLayer()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]ZoneSummary, 0, len(mapLayer.Features))
+	for i := range mapLayer.Features {
+		feature := &mapLayer.Features[i]
+		props := feature.Properties
+
+		if filter.CenterId != "" && !strings.EqualFold(props.CenterId, filter.CenterId) {
+			continue
+		}
+		if filter.BBox != nil && !nac.IntersectsBox(&feature.Geometry,
+			filter.BBox.MinLat, filter.BBox.MaxLat, filter.BBox.MinLon, filter.BBox.MaxLon) {
+			continue
+		}
+		if filter.DangerAtLeast > 0 && props.DangerLevel < filter.DangerAtLeast {
+			continue
+		}
+		if filter.OffSeason != nil && props.OffSeason != *filter.OffSeason {
+			continue
+		}
+
+		matched = append(matched, ZoneSummary{
+			Id:           feature.Id,
+			Name:         props.Name,
+			CenterId:     props.CenterId,
+			DangerLevel:  DangerLevel(props.DangerLevel),
+			Danger:       props.Danger,
+			TravelAdvice: props.TravelAdvice,
+			OffSeason:    props.OffSeason,
+			URL:          props.Link,
+		})
+	}
+
+	list := &ZoneList{
+		Total:     len(matched),
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+		Freshness: freshness,
+	}
+
+	start := filter.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := len(matched)
+	if filter.Limit > 0 && start+filter.Limit < end {
+		end = start + filter.Limit
+	}
+	list.Zones = matched[start:end]
+
+	return list, nil
+}
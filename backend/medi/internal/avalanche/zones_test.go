@@ -0,0 +1,154 @@
+package avalanche
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"medi/internal/providers/nac"
+	"medi/internal/testsupport"
+)
+
+func zonesTestService(t *testing.T, mapLayer *nac.MapLayerResponse) Service {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewAvalancheServiceWithProviders(logger,
+		&mockMapLayerProvider{response: mapLayer}, &mockForecastProvider{}, &mockAccidentProvider{})
+}
+
+func TestGetZonesUnfiltered(t *testing.T) {
+	mapLayer := testsupport.NACMapLayerFixture(t)
+	svc := zonesTestService(t, mapLayer)
+
+	list, err := svc.GetZones(ZoneFilter{})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+
+	if list.Total != len(mapLayer.Features) {
+		t.Errorf("Total = %d, want every zone (%d)", list.Total, len(mapLayer.Features))
+	}
+	if len(list.Zones) != list.Total {
+		t.Errorf("Zones count = %d, want all %d with no limit", len(list.Zones), list.Total)
+	}
+}
+
+func TestGetZonesBBox(t *testing.T) {
+	svc := zonesTestService(t, testsupport.NACMapLayerFixture(t))
+
+	// Wasatch box around Salt Lake City
+	list, err := svc.GetZones(ZoneFilter{
+		BBox: &BoundingBox{MinLat: 40.0, MaxLat: 41.0, MinLon: -112.5, MaxLon: -111.0},
+	})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+
+	if list.Total == 0 {
+		t.Fatal("Expected zones inside the Wasatch box")
+	}
+	byName := map[string]bool{}
+	for _, zone := range list.Zones {
+		byName[zone.Name] = true
+		if zone.CenterId != "UAC" {
+			t.Errorf("Zone %q center = %s, want only UAC zones in the box", zone.Name, zone.CenterId)
+		}
+	}
+	if !byName["Salt Lake"] {
+		t.Error("Expected the Salt Lake zone inside the box")
+	}
+	if byName["Moab"] {
+		t.Error("Did not expect the Moab zone inside the box")
+	}
+}
+
+func TestGetZonesDangerAtLeast(t *testing.T) {
+	svc := zonesTestService(t, testsupport.NACMapLayerFixture(t))
+
+	list, err := svc.GetZones(ZoneFilter{DangerAtLeast: 4})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+
+	if list.Total == 0 {
+		t.Fatal("Expected high danger zones in the fixture")
+	}
+	for _, zone := range list.Zones {
+		if zone.DangerLevel < DangerHigh {
+			t.Errorf("Zone %q danger = %d, want at least %d", zone.Name, zone.DangerLevel, DangerHigh)
+		}
+	}
+}
+
+func TestGetZonesOffSeason(t *testing.T) {
+	mapLayer := testsupport.NACMapLayerFixture(t)
+	mapLayer.Features[0].Properties.OffSeason = true
+	svc := zonesTestService(t, mapLayer)
+
+	offSeason := true
+	list, err := svc.GetZones(ZoneFilter{OffSeason: &offSeason})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+	if list.Total != 1 || list.Zones[0].Id != mapLayer.Features[0].Id {
+		t.Errorf("Expected only the off-season zone, got %d zones", list.Total)
+	}
+
+	inSeason := false
+	list, err = svc.GetZones(ZoneFilter{OffSeason: &inSeason})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+	if list.Total != len(mapLayer.Features)-1 {
+		t.Errorf("In-season total = %d, want %d", list.Total, len(mapLayer.Features)-1)
+	}
+}
+
+func TestGetZonesPagination(t *testing.T) {
+	mapLayer := testsupport.NACMapLayerFixture(t)
+	svc := zonesTestService(t, mapLayer)
+
+	first, err := svc.GetZones(ZoneFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+	if len(first.Zones) != 10 {
+		t.Fatalf("First page size = %d, want 10", len(first.Zones))
+	}
+	if first.Total != len(mapLayer.Features) {
+		t.Errorf("Total = %d, want %d regardless of paging", first.Total, len(mapLayer.Features))
+	}
+
+	second, err := svc.GetZones(ZoneFilter{Limit: 10, Offset: 10})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+	if len(second.Zones) != 10 {
+		t.Fatalf("Second page size = %d, want 10", len(second.Zones))
+	}
+	if second.Zones[0].Id == first.Zones[0].Id {
+		t.Error("Second page repeats the first")
+	}
+
+	// Offset past the end returns an empty page, not an error
+	past, err := svc.GetZones(ZoneFilter{Limit: 10, Offset: len(mapLayer.Features) + 5})
+	if err != nil {
+		t.Fatalf("GetZones failed: %v", err)
+	}
+	if len(past.Zones) != 0 {
+		t.Errorf("Past-the-end page size = %d, want 0", len(past.Zones))
+	}
+}
+
+func TestGetZonesNilLogger(t *testing.T) {
+	// Construction with a nil logger defaults to slog.Default(), so the error
+	// log on a failed map layer fetch does not panic
+	svc := NewAvalancheServiceWithProviders(nil,
+		&mockMapLayerProvider{err: errors.New("nac unavailable")},
+		&mockForecastProvider{}, &mockAccidentProvider{})
+
+	if _, err := svc.GetZones(ZoneFilter{}); err == nil {
+		t.Fatal("expected error when the map layer fetch fails, got nil")
+	}
+}
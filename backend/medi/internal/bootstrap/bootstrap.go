@@ -0,0 +1,141 @@
+// Package bootstrap owns the HTTP app lifecycle shared by the backends:
+// router and middleware construction, the standard health and version
+// endpoints, and server startup with graceful shutdown. A backend supplies
+// only its service wiring and a route registration function, so cross-cutting
+// lifecycle features are implemented once.
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// shutdownTimeout bounds how long in-flight requests may drain after a
+// shutdown signal before the server gives up on them
+const shutdownTimeout = 10 * time.Second
+
+// HTTPApp wraps a backend's routes with the shared lifecycle.
+type HTTPApp struct {
+	// Router is the configured gin engine the backend registered its routes on
+	Router *gin.Engine
+	logger *slog.Logger
+	// closers run after the listener has drained, most recently added first,
+	// so dependents shut down before what they depend on
+	closers []func()
+}
+
+// NewHTTPApp builds the router with the standard middleware stack and
+// endpoints, then hands it to register for the backend's own routes.
+func NewHTTPApp(cfg *config.Config, logger *slog.Logger, register func(router *gin.Engine)) *HTTPApp {
+	gin.SetMode(cfg.Server.GinMode)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	app := &HTTPApp{
+		Router: router,
+		logger: logging.OrDefault(logger),
+	}
+	router.GET("/ping", handlePing)
+	router.GET("/version", handleGetVersion)
+
+	if register != nil {
+		register(router)
+	}
+	return app
+}
+
+// OnClose registers a hook to run during shutdown, after the listener has
+// stopped accepting requests
+func (app *HTTPApp) OnClose(fn func()) {
+	app.closers = append(app.closers, fn)
+}
+
+// Run serves handler on addr until SIGINT or SIGTERM, then drains in-flight
+// requests and runs the close hooks. A nil handler serves the router
+// directly; backends pass their own when they wrap it in extra middleware.
+func (app *HTTPApp) Run(addr string, handler http.Handler) error {
+	if handler == nil {
+		handler = app.Router
+	}
+	server := &http.Server{Addr: addr, Handler: handler}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-sigCh:
+		app.logger.Info("shutting down", "signal", sig.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(ctx)
+
+	for i := len(app.closers) - 1; i >= 0; i-- {
+		app.closers[i]()
+	}
+	return err
+}
+
+// PingResponse represents the response for the ping endpoint
+type PingResponse struct {
+	Message string `json:"message" example:"pong"` // Response message
+}
+
+// handlePing godoc
+// @Summary Ping health check
+// @Description Check if the API is running
+// @Tags health
+// @Produce json
+// @Success 200 {object} PingResponse
+// @Router /ping [get]
+func handlePing(c *gin.Context) {
+	c.JSON(http.StatusOK, PingResponse{
+		Message: "pong",
+	})
+}
+
+// VersionResponse reports what build is serving
+type VersionResponse struct {
+	Version   string `json:"version"`   // Module version from build info, "dev" for local builds
+	GoVersion string `json:"goVersion"` // Go runtime the binary was built with
+}
+
+// handleGetVersion godoc
+// @Summary Build version
+// @Description Report the module version and Go runtime of the running build
+// @Tags health
+// @Produce json
+// @Success 200 {object} VersionResponse
+// @Router /version [get]
+func handleGetVersion(c *gin.Context) {
+	response := VersionResponse{
+		Version:   "dev",
+		GoVersion: runtime.Version(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		response.Version = info.Main.Version
+	}
+	c.JSON(http.StatusOK, response)
+}
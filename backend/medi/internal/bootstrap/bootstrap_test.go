@@ -0,0 +1,101 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"medi/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestHTTPApp(register func(router *gin.Engine)) *HTTPApp {
+	cfg := &config.Config{Server: config.ServerConfig{GinMode: gin.TestMode}}
+	return NewHTTPApp(cfg, nil, register)
+}
+
+func get(t *testing.T, app *HTTPApp, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	app.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s returned status %d: %s", path, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestStandardEndpoints(t *testing.T) {
+	app := newTestHTTPApp(nil)
+
+	var ping PingResponse
+	if err := json.Unmarshal(get(t, app, "/ping").Body.Bytes(), &ping); err != nil {
+		t.Fatalf("failed to decode ping response: %v", err)
+	}
+	if ping.Message != "pong" {
+		t.Errorf("ping message = %q, want pong", ping.Message)
+	}
+
+	var version VersionResponse
+	if err := json.Unmarshal(get(t, app, "/version").Body.Bytes(), &version); err != nil {
+		t.Fatalf("failed to decode version response: %v", err)
+	}
+	if version.Version == "" {
+		t.Error("version response missing its version field")
+	}
+	if version.GoVersion == "" {
+		t.Error("version response missing its Go version field")
+	}
+}
+
+func TestRegisterAddsBackendRoutes(t *testing.T) {
+	app := newTestHTTPApp(func(router *gin.Engine) {
+		router.GET("/custom", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+		})
+	})
+
+	get(t, app, "/custom")
+	get(t, app, "/ping")
+}
+
+func TestRunShutsDownGracefullyAndRunsCloseHooks(t *testing.T) {
+	app := newTestHTTPApp(nil)
+
+	closed := make(chan string, 2)
+	app.OnClose(func() { closed <- "first" })
+	app.OnClose(func() { closed <- "second" })
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- app.Run("127.0.0.1:0", nil)
+	}()
+
+	// Give the listener a moment to start before signalling
+	time.Sleep(50 * time.Millisecond)
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("failed to signal the test process: %v", err)
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after SIGTERM")
+	}
+
+	// Hooks run most recently added first
+	if got := <-closed; got != "second" {
+		t.Errorf("first close hook = %q, want second", got)
+	}
+	if got := <-closed; got != "first" {
+		t.Errorf("second close hook = %q, want first", got)
+	}
+}
@@ -0,0 +1,253 @@
+// Package cache provides a small in-memory TTL cache that keeps metadata with
+// each entry so callers can report data freshness and serve stale values when
+// a refresh fails.
+package cache
+
+import (
+	"container/list"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Status describes the result of a cache lookup
+type Status string
+
+const (
+	// StatusMiss means no entry exists for the key
+	StatusMiss Status = "MISS"
+	// StatusHit means a fresh entry was found
+	StatusHit Status = "HIT"
+	// StatusStale means an entry was found but its TTL has elapsed. Stale
+	// entries are retained so callers can serve them when a refresh fails.
+	StatusStale Status = "STALE"
+)
+
+// Entry wraps a cached value with the metadata needed for freshness reporting
+type Entry[T any] struct {
+	Value T
+	// FetchedAt is when the value was stored
+	FetchedAt time.Time
+}
+
+// item is what the recency list holds: the key is kept alongside the entry so
+// eviction can remove it from the index
+type item[T any] struct {
+	key   string
+	entry Entry[T]
+	// ttl is this entry's jittered freshness window; zero falls back to the
+	// cache-wide TTL
+	ttl time.Duration
+}
+
+// Cache is a concurrency-safe in-memory TTL cache. An optional entry cap
+// turns it into an LRU: storing past the cap evicts the least recently used
+// entry, and both Get and Set count as use.
+type Cache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front is most recently used
+	ttl     time.Duration
+	// maxEntries bounds the cache when positive; zero means unbounded
+	maxEntries int
+	evictions  int64
+	now        func() time.Time
+	// jitter spreads each entry's TTL by up to ±jitter (a fraction), so
+	// entries stored in the same burst do not all expire in the same burst
+	jitter    float64
+	jitterRng *rand.Rand
+	// subscribers holds one-shot channels per key, closed when the key is
+	// next stored; long-poll waiters use them to wake on refresh
+	subscribers map[string][]chan struct{}
+}
+
+// New creates a cache whose entries are considered fresh for ttl
+func New[T any](ttl time.Duration) *Cache[T] {
+	return &Cache[T]{
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		ttl:         ttl,
+		now:         time.Now,
+		subscribers: make(map[string][]chan struct{}),
+	}
+}
+
+// SetClock overrides the time source, for tests
+func (c *Cache[T]) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// SetTTLJitter spreads entry expiries by up to ±fraction of the TTL (e.g. 0.1
+// for ±10%), drawn per entry when it is stored. Without it, entries cached in
+// one traffic burst all expire together and the next burst refetches them all
+// at once.
+func (c *Cache[T]) SetTTLJitter(fraction float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jitter = fraction
+	if fraction > 0 && c.jitterRng == nil {
+		c.jitterRng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+}
+
+// SetMaxEntries caps the cache at n entries, evicting the least recently used
+// past it; n <= 0 removes the cap
+func (c *Cache[T]) SetMaxEntries(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxEntries = n
+	c.evictPastCap()
+}
+
+// Get returns the entry for key along with its freshness status. The entry is
+// returned even when stale so callers can fall back to it.
+func (c *Cache[T]) Get(key string) (Entry[T], Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return Entry[T]{}, StatusMiss
+	}
+	c.order.MoveToFront(element)
+
+	it := element.Value.(*item[T])
+	ttl := it.ttl
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	if c.now().Sub(it.entry.FetchedAt) > ttl {
+		return it.entry, StatusStale
+	}
+	return it.entry, StatusHit
+}
+
+// Set stores a value for key, stamped with the current time
+func (c *Cache[T]) Set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := Entry[T]{
+		Value:     value,
+		FetchedAt: c.now(),
+	}
+	ttl := c.entryTTL()
+	if element, ok := c.entries[key]; ok {
+		it := element.Value.(*item[T])
+		it.entry = entry
+		it.ttl = ttl
+		c.order.MoveToFront(element)
+		c.notifySubscribers(key)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&item[T]{key: key, entry: entry, ttl: ttl})
+	c.evictPastCap()
+	c.notifySubscribers(key)
+}
+
+// entryTTL draws the jittered TTL for a new entry, or zero when jitter is off
+// so the entry tracks the cache-wide TTL. Callers must hold the mutex.
+func (c *Cache[T]) entryTTL() time.Duration {
+	if c.jitter <= 0 {
+		return 0
+	}
+	spread := (c.jitterRng.Float64()*2 - 1) * c.jitter
+	return time.Duration(float64(c.ttl) * (1 + spread))
+}
+
+// Subscribe returns a channel closed the next time key is stored. Callers
+// that stop waiting before then must Unsubscribe, or the channel lingers
+// until the key's next Set.
+func (c *Cache[T]) Subscribe(key string) chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan struct{})
+	c.subscribers[key] = append(c.subscribers[key], ch)
+	return ch
+}
+
+// Unsubscribe removes a channel obtained from Subscribe; it is a no-op when
+// the channel was already consumed by a Set
+func (c *Cache[T]) Unsubscribe(key string, ch chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	subs := c.subscribers[key]
+	for i, sub := range subs {
+		if sub == ch {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(c.subscribers, key)
+		return
+	}
+	c.subscribers[key] = subs
+}
+
+// SubscriberCount reports how many channels are currently waiting across all
+// keys, for metrics and leak checks in tests
+func (c *Cache[T]) SubscriberCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for _, subs := range c.subscribers {
+		count += len(subs)
+	}
+	return count
+}
+
+// notifySubscribers wakes everything waiting on key. Callers must hold the
+// mutex.
+func (c *Cache[T]) notifySubscribers(key string) {
+	for _, ch := range c.subscribers[key] {
+		close(ch)
+	}
+	delete(c.subscribers, key)
+}
+
+// Delete removes the entry for key if present
+func (c *Cache[T]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+// Len reports how many entries the cache currently holds
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Evictions reports how many entries the cap has evicted over the cache's
+// lifetime
+func (c *Cache[T]) Evictions() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.evictions
+}
+
+// evictPastCap drops least recently used entries until the cache fits its
+// cap. Callers must hold the mutex.
+func (c *Cache[T]) evictPastCap() {
+	if c.maxEntries <= 0 {
+		return
+	}
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		delete(c.entries, oldest.Value.(*item[T]).key)
+		c.order.Remove(oldest)
+		c.evictions++
+	}
+}
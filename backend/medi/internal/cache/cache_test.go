@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGetReportsFreshness(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	c := New[string](time.Minute)
+	c.SetClock(func() time.Time { return currentTime })
+
+	if _, status := c.Get("a"); status != StatusMiss {
+		t.Errorf("status = %v, want MISS before any Set", status)
+	}
+
+	c.Set("a", "one")
+	entry, status := c.Get("a")
+	if status != StatusHit || entry.Value != "one" {
+		t.Errorf("got (%q, %v), want (one, HIT)", entry.Value, status)
+	}
+
+	currentTime = currentTime.Add(time.Minute + time.Second)
+	entry, status = c.Get("a")
+	if status != StatusStale || entry.Value != "one" {
+		t.Errorf("got (%q, %v), want the stale entry retained", entry.Value, status)
+	}
+}
+
+func TestSetMaxEntriesEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[int](time.Minute)
+	c.SetMaxEntries(2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// Reading "a" makes "b" the least recently used, so a third entry
+	// evicts "b"
+	c.Get("a")
+	c.Set("c", 3)
+
+	if _, status := c.Get("b"); status != StatusMiss {
+		t.Errorf("b status = %v, want MISS after eviction", status)
+	}
+	if _, status := c.Get("a"); status != StatusHit {
+		t.Errorf("a status = %v, want HIT for the recently used entry", status)
+	}
+	if _, status := c.Get("c"); status != StatusHit {
+		t.Errorf("c status = %v, want HIT", status)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+	if got := c.Evictions(); got != 1 {
+		t.Errorf("Evictions = %d, want 1", got)
+	}
+}
+
+func TestSetMaxEntriesShrinksExistingCache(t *testing.T) {
+	c := New[int](time.Minute)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		c.Set(key, 0)
+	}
+
+	c.SetMaxEntries(2)
+
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2 after lowering the cap", got)
+	}
+	if got := c.Evictions(); got != 2 {
+		t.Errorf("Evictions = %d, want 2", got)
+	}
+	// The most recently stored entries survive
+	for _, key := range []string{"c", "d"} {
+		if _, status := c.Get(key); status != StatusHit {
+			t.Errorf("%s status = %v, want HIT", key, status)
+		}
+	}
+}
+
+func TestSetExistingKeyDoesNotEvict(t *testing.T) {
+	c := New[int](time.Minute)
+	c.SetMaxEntries(2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("a", 3)
+
+	if got := c.Evictions(); got != 0 {
+		t.Errorf("Evictions = %d, want 0 when overwriting an existing key", got)
+	}
+	entry, status := c.Get("a")
+	if status != StatusHit || entry.Value != 3 {
+		t.Errorf("got (%d, %v), want the overwritten value", entry.Value, status)
+	}
+}
+
+func TestSetTTLJitterSpreadsExpiries(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	c := New[int](10 * time.Minute)
+	c.SetClock(func() time.Time { return currentTime })
+	c.SetTTLJitter(0.1)
+
+	const keys = 200
+	for i := 0; i < keys; i++ {
+		c.Set(fmt.Sprintf("k%d", i), i)
+	}
+
+	// Inside the minimum jittered TTL (90% of 10m) everything is fresh
+	currentTime = currentTime.Add(9*time.Minute - time.Second)
+	for i := 0; i < keys; i++ {
+		if _, status := c.Get(fmt.Sprintf("k%d", i)); status != StatusHit {
+			t.Fatalf("k%d stale before the -10%% jitter bound", i)
+		}
+	}
+
+	// At the nominal TTL expiries are spread: some entries have already
+	// lapsed, others have not
+	currentTime = currentTime.Add(time.Minute + time.Second)
+	hits, stales := 0, 0
+	for i := 0; i < keys; i++ {
+		if _, status := c.Get(fmt.Sprintf("k%d", i)); status == StatusHit {
+			hits++
+		} else {
+			stales++
+		}
+	}
+	if hits == 0 || stales == 0 {
+		t.Errorf("at the nominal TTL got %d hits and %d stales, want expiries spread across it", hits, stales)
+	}
+
+	// Past the maximum jittered TTL (110% of 10m) everything is stale
+	currentTime = currentTime.Add(2*time.Minute + time.Second)
+	for i := 0; i < keys; i++ {
+		if _, status := c.Get(fmt.Sprintf("k%d", i)); status != StatusStale {
+			t.Fatalf("k%d still fresh past the +10%% jitter bound", i)
+		}
+	}
+}
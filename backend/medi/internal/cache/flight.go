@@ -0,0 +1,49 @@
+package cache
+
+import "sync"
+
+// Flight coalesces concurrent fetches for the same key: the first caller runs
+// the fetch, everyone else arriving before it finishes blocks and shares the
+// result. This keeps a cache miss under burst traffic from fanning out into
+// one upstream request per waiting client. The zero value is ready to use.
+type Flight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+// flightCall is one in-progress fetch; done is closed when value and err are
+// populated
+type flightCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Do runs fn for key unless a call for the same key is already in flight, in
+// which case it waits for that call and returns its result. shared reports
+// whether the result came from another caller's fetch, so only the caller
+// that actually fetched stores and publishes it.
+func (f *Flight[T]) Do(key string, fn func() (T, error)) (value T, shared bool, err error) {
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]*flightCall[T])
+	}
+	if call, ok := f.calls[key]; ok {
+		f.mu.Unlock()
+		<-call.done
+		return call.value, true, call.err
+	}
+
+	call := &flightCall[T]{done: make(chan struct{})}
+	f.calls[key] = call
+	f.mu.Unlock()
+
+	call.value, call.err = fn()
+	close(call.done)
+
+	f.mu.Lock()
+	delete(f.calls, key)
+	f.mu.Unlock()
+
+	return call.value, false, call.err
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightCoalescesConcurrentCalls(t *testing.T) {
+	var f Flight[int]
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	leaderIn := make(chan struct{})
+
+	type result struct {
+		value  int
+		shared bool
+		err    error
+	}
+	results := make(chan result, 5)
+
+	go func() {
+		value, shared, err := f.Do("zone", func() (int, error) {
+			close(leaderIn)
+			<-release
+			fetches.Add(1)
+			return 42, nil
+		})
+		results <- result{value, shared, err}
+	}()
+	<-leaderIn
+
+	var followers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		followers.Add(1)
+		go func() {
+			defer followers.Done()
+			value, shared, err := f.Do("zone", func() (int, error) {
+				fetches.Add(1)
+				return 42, nil
+			})
+			results <- result{value, shared, err}
+		}()
+	}
+	// Give the followers time to join the in-flight call before it finishes
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	followers.Wait()
+
+	sharedCount := 0
+	for i := 0; i < 5; i++ {
+		r := <-results
+		if r.err != nil || r.value != 42 {
+			t.Errorf("Do returned (%d, %v), want (42, nil)", r.value, r.err)
+		}
+		if r.shared {
+			sharedCount++
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("%d fetches ran for 5 concurrent callers, want 1", got)
+	}
+	if sharedCount != 4 {
+		t.Errorf("%d callers reported a shared result, want the 4 followers", sharedCount)
+	}
+}
+
+func TestFlightSequentialCallsFetchIndependently(t *testing.T) {
+	var f Flight[string]
+	fetches := 0
+
+	value, shared, err := f.Do("zone", func() (string, error) {
+		fetches++
+		return "first", nil
+	})
+	if value != "first" || shared || err != nil {
+		t.Errorf("first Do returned (%q, %v, %v)", value, shared, err)
+	}
+
+	// The key is released once a call completes; errors propagate
+	wantErr := errors.New("provider unavailable")
+	_, shared, err = f.Do("zone", func() (string, error) {
+		fetches++
+		return "", wantErr
+	})
+	if shared || !errors.Is(err, wantErr) {
+		t.Errorf("second Do returned (%v, %v), want the fetch error unshared", shared, err)
+	}
+	if fetches != 2 {
+		t.Errorf("%d fetches ran for 2 sequential callers, want 2", fetches)
+	}
+}
@@ -7,31 +7,219 @@ import (
 	"os"
 	"strings"
 
+	"medi/internal/logging"
+
 	"github.com/spf13/viper"
 )
 
+// Provider mode values for ProvidersConfig.Mode
+const (
+	ProviderModeLive     = "live"
+	ProviderModeMock     = "mock"
+	ProviderModeScenario = "scenario"
+)
+
 // Config holds all configuration for the application
 type Config struct {
-	Server ServerConfig
-	Log    LogConfig
-	App    AppConfig
+	Server    ServerConfig
+	Log       LogConfig
+	App       AppConfig
+	Providers ProvidersConfig
+	MQTT      MQTTConfig
 }
 
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
 	Port    int
 	GinMode string // debug, release, test
+	// RequestTimeoutSeconds bounds how long any handler may run before the
+	// request context is cancelled and a 504 is returned
+	RequestTimeoutSeconds int
+	// RouteTimeoutSeconds overrides the global timeout for specific routes,
+	// keyed by request path, e.g. "/weather/forecast": 30
+	RouteTimeoutSeconds map[string]int
+	// EnableDocs controls whether the API specification and interactive docs
+	// UI are served; turn off for deployments that should not expose them
+	EnableDocs bool
+	// DocsHost is the host:port the generated API docs advertise; when empty
+	// the docs advertise localhost with the configured port
+	DocsHost string
+	// DocsBasePath is the path prefix the docs advertise, for deployments
+	// that serve the API behind a reverse-proxy prefix
+	DocsBasePath string
+	// IdempotencyWindowHours is how long a mutation endpoint remembers an
+	// Idempotency-Key so client retries replay the original response
+	IdempotencyWindowHours int
 }
 
 // LogConfig holds logging configuration
 type LogConfig struct {
 	Level  string // debug, info, warn, error
 	Format string // json, text
+	// Sampling suppresses bursts of identical lines (same level, message,
+	// and attributes, with coordinates rounded), keeping the first occurrence
+	// per minute plus a 1-in-10 sample, and reports the suppressed count.
+	// Errors are never sampled.
+	Sampling bool
 }
 
 // AppConfig holds application-specific configuration
 type AppConfig struct {
-	ForecastDays int // Number of days to forecast
+	ForecastDays      int                // Number of days to forecast
+	PowderAlertInches float64            // 24-hour ensemble median snowfall that triggers a powder alert
+	SkinningMaxTempF  float64            // daylight hours below this temperature count toward SkinningHours
+	PriorityLocations []PriorityLocation // Named locations whose refreshes are pushed to MQTT
+
+	// PrefetchEnabled turns on the background refresher that keeps forecasts
+	// for priority and recently requested locations warm in the cache
+	PrefetchEnabled bool
+	// PrefetchRecentLocations bounds how many recently requested points the
+	// refresher tracks (LRU)
+	PrefetchRecentLocations int
+	// PrefetchInactivityHours is the sliding window: locations not requested
+	// within it are dropped from the refresher
+	PrefetchInactivityHours int
+	// ThresholdProfiles are named bundles of the snow-focused tunables,
+	// selectable per request via profile=. "conservative" and "powder-hound"
+	// ship as defaults; a "default" entry overrides the profile used when a
+	// request names none, which otherwise inherits the app-level settings.
+	ThresholdProfiles map[string]ThresholdProfile
+
+	// AvalanchePublishWindows maps an avalanche center id to its daily
+	// publish window as "HH:MM-HH:MM" server-local time; the "*" key is the
+	// default for centers without their own entry. Inside its window a
+	// center's cached zone forecasts are refreshed in the background every
+	// few minutes so the new product is served soon after publication.
+	AvalanchePublishWindows map[string]string
+
+	// LogPreciseCoordinates opts debug-level log lines into full-precision
+	// user coordinates; by default all lines round them to ~1 km
+	LogPreciseCoordinates bool
+
+	// DefaultUnits is the config-default preference layer for units=: when a
+	// request names no system, responses are pruned to "imperial" or
+	// "metric" figures; empty keeps both systems in every payload
+	DefaultUnits string
+	// DefaultLanguage is the config-default preference layer for language=,
+	// a BCP 47 tag localized text falls back to
+	DefaultLanguage string
+
+	// EstimateMissingGusts backfills hourly gusts for models that do not
+	// report them, estimated from wind speed and flagged as estimated
+	EstimateMissingGusts bool
+	// GustEstimateFactor multiplies wind speed when no co-reporting model
+	// provides a gust/speed ratio for the hour
+	GustEstimateFactor float64
+
+	// LapseRateCPerKm is the fixed temperature lapse rate, in °C per km of
+	// elevation, used to extrapolate band temperatures when the model
+	// freezing level cannot calibrate one
+	LapseRateCPerKm float64
+
+	// LongPollTimeoutSeconds bounds how long the forecast long-poll endpoint
+	// holds a request open before answering 304 Not Modified
+	LongPollTimeoutSeconds int
+
+	// RefreezeMinHours is how many overnight hours must drop below the
+	// refreeze threshold before a night counts as an overnight refreeze
+	RefreezeMinHours int
+
+	// FirstTracksMaxTempF is the warming ceiling for the first-tracks
+	// window; the window closes once the temperature climbs above it
+	FirstTracksMaxTempF float64
+
+	// AvalancheSeasonStart and AvalancheSeasonEnd bound the northern-
+	// hemisphere avalanche season as inclusive "MM-DD" dates; the bundle
+	// endpoint skips avalanche lookups outside the window. Southern
+	// latitudes use the window shifted six months.
+	AvalancheSeasonStart string
+	AvalancheSeasonEnd   string
+
+	// StationBlendEnabled nudges the first forecast hours toward the latest
+	// nearby snow-telemetry observation, with a weight decaying to zero
+	// across StationBlendHours. Only state variables (temperature, snow
+	// depth) blend; snowfall accumulation never does.
+	StationBlendEnabled bool
+	// StationBlendHours is how many leading hours blend toward the observation
+	StationBlendHours int
+	// StationBlendMaxDistanceKm and StationBlendMaxElevationDeltaFt bound
+	// which stations may anchor a blend; one outside either limit is ignored
+	StationBlendMaxDistanceKm       float64
+	StationBlendMaxElevationDeltaFt float64
+	// StationBlendMaxObservationAgeHours skips observations older than this
+	StationBlendMaxObservationAgeHours int
+
+	// AllowNullIsland permits requests for exactly 0,0 — a valid ocean point,
+	// but in practice almost always an uninitialized client, so rejected by
+	// default
+	AllowNullIsland bool
+}
+
+// ThresholdProfile overrides the snow-focused tunables under one selectable
+// name. Zero fields inherit the default profile's values, so a configured
+// profile only states what it changes.
+type ThresholdProfile struct {
+	// PowderAlertInches is the 24-hour ensemble median snowfall that
+	// triggers a powder alert
+	PowderAlertInches float64
+	// PowderDayInches is the daily median snowfall scoring a full 10 on the
+	// weekly powder scale
+	PowderDayInches float64
+	// StormMinRateInHr is the hourly snowfall rate at or above which a storm
+	// counts as still running
+	StormMinRateInHr float64
+	// WindPenaltyGustMph is the gust speed past which wind is judged to
+	// degrade the snow surface
+	WindPenaltyGustMph float64
+}
+
+// PriorityLocation names a coordinate of special interest, e.g. a home resort
+type PriorityLocation struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+// MQTTConfig configures the optional MQTT publisher for home automation
+// subscribers. Publishing is off unless Enabled is set.
+type MQTTConfig struct {
+	Enabled     bool
+	BrokerURL   string // e.g. tcp://homeassistant.local:1883
+	Username    string
+	Password    string
+	TopicPrefix string // first topic segment, defaults to "medisnow"
+	QoS         int
+
+	// AlertPreset selects the payload shape for published powder alert
+	// messages: slack, ntfy, or plain. AlertTemplate overrides it with a
+	// custom Go text/template rendered against alert.Context, validated at
+	// startup.
+	AlertPreset   string
+	AlertTemplate string
+}
+
+// ProvidersConfig controls which provider implementations are wired into the services
+type ProvidersConfig struct {
+	Mode string // live, mock, scenario
+	// ScenarioFile is the YAML storyline served in scenario mode; required
+	// when Mode is scenario
+	ScenarioFile string
+	// COTripAPIKey authenticates requests to the Colorado DOT road
+	// conditions feed; road data is unavailable without it
+	COTripAPIKey string
+	// RetryMaxDelaySeconds caps how long a provider retry waits, even when
+	// an upstream Retry-After header advises more
+	RetryMaxDelaySeconds int
+	// QuotaLimits are soft daily call limits per provider name, tracked
+	// against the informal free-tier quotas; zero or absent means unlimited
+	QuotaLimits map[string]int
+	// QuotaRejectOverLimit turns a spent soft limit from a warning into
+	// refusal: further non-cached calls to that provider fail until the UTC
+	// day rolls over
+	QuotaRejectOverLimit bool
+	// QuotaStatePath is the file the daily counters persist to so restarts
+	// do not reset them; empty keeps them in memory only
+	QuotaStatePath string
 }
 
 // Load reads configuration from file and environment variables
@@ -46,9 +234,57 @@ func Load() (*Config, error) {
 	// Set defaults
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("server.ginmode", "release")
+	viper.SetDefault("server.requestTimeoutSeconds", 15)
+	viper.SetDefault("server.enableDocs", true)
+	viper.SetDefault("server.docsHost", "")
+	viper.SetDefault("server.docsBasePath", "/")
+	viper.SetDefault("server.idempotencyWindowHours", 24)
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "text")
+	viper.SetDefault("log.sampling", true)
 	viper.SetDefault("app.forecastDays", 16)
+	viper.SetDefault("app.powderAlertInches", 6.0)
+	viper.SetDefault("app.skinningMaxTempF", 40.0)
+	viper.SetDefault("app.prefetchEnabled", false)
+	viper.SetDefault("app.prefetchRecentLocations", 50)
+	viper.SetDefault("app.prefetchInactivityHours", 6)
+	viper.SetDefault("app.avalanchePublishWindows", map[string]string{"*": "06:00-08:00"})
+	viper.SetDefault("app.thresholdProfiles", map[string]map[string]float64{
+		"conservative": {"powderAlertInches": 10.0, "powderDayInches": 14.0, "stormMinRateInHr": 0.25, "windPenaltyGustMph": 20.0},
+		"powder-hound": {"powderAlertInches": 3.0, "powderDayInches": 6.0, "stormMinRateInHr": 0.05, "windPenaltyGustMph": 30.0},
+	})
+	viper.SetDefault("app.logPreciseCoordinates", false)
+	viper.SetDefault("app.defaultUnits", "")
+	viper.SetDefault("app.defaultLanguage", "")
+	viper.SetDefault("app.estimateMissingGusts", false)
+	viper.SetDefault("app.gustEstimateFactor", 1.5)
+	viper.SetDefault("app.lapseRateCPerKm", 6.5)
+	viper.SetDefault("app.longPollTimeoutSeconds", 55)
+	viper.SetDefault("app.refreezeMinHours", 3)
+	viper.SetDefault("app.firstTracksMaxTempF", 35.0)
+	viper.SetDefault("app.avalancheSeasonStart", "11-01")
+	viper.SetDefault("app.avalancheSeasonEnd", "05-31")
+	viper.SetDefault("app.stationBlendEnabled", false)
+	viper.SetDefault("app.stationBlendHours", 6)
+	viper.SetDefault("app.stationBlendMaxDistanceKm", 30.0)
+	viper.SetDefault("app.stationBlendMaxElevationDeltaFt", 1000.0)
+	viper.SetDefault("app.stationBlendMaxObservationAgeHours", 3)
+	viper.SetDefault("app.allowNullIsland", false)
+	viper.SetDefault("providers.mode", ProviderModeLive)
+	viper.SetDefault("providers.scenarioFile", "")
+	viper.SetDefault("providers.cotripapikey", "")
+	viper.SetDefault("providers.retryMaxDelaySeconds", 30)
+	viper.SetDefault("providers.quotaLimits", map[string]int{
+		"openstreetmap": 1000,
+		"nws":           5000,
+		"openmeteo":     10000,
+	})
+	viper.SetDefault("providers.quotaRejectOverLimit", false)
+	viper.SetDefault("providers.quotaStatePath", "")
+	viper.SetDefault("mqtt.enabled", false)
+	viper.SetDefault("mqtt.topicPrefix", "medisnow")
+	viper.SetDefault("mqtt.qos", 1)
+	viper.SetDefault("mqtt.alertPreset", "plain")
 
 	// Read from environment variables
 	viper.SetEnvPrefix("MEDI")
@@ -63,12 +299,16 @@ func Load() (*Config, error) {
 		}
 	}
 
-	// Unmarshal into config struct
+	return decode()
+}
+
+// decode unmarshals viper's current state into a Config; shared by Load and
+// the hot-reload path
+func decode() (*Config, error) {
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
-
 	return &cfg, nil
 }
 
@@ -108,5 +348,9 @@ func (c *Config) NewLogger() *slog.Logger {
 		handler = slog.NewTextHandler(os.Stdout, opts)
 	}
 
+	if c.Log.Sampling {
+		handler = logging.NewSamplingHandler(handler)
+	}
+
 	return slog.New(handler)
 }
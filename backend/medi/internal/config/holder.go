@@ -0,0 +1,103 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"medi/internal/logging"
+)
+
+// Holder publishes the active configuration behind an atomic pointer so the
+// tunable subset — cache windows, thresholds, scoring weights — can change at
+// runtime without a restart. Readers call Current on every use instead of
+// capturing values at construction; each read sees one consistent snapshot.
+//
+// Not everything can reload. The Server, Providers, and MQTT sections wire up
+// listeners and clients at startup, so changes to them are ignored with a
+// warning until the next restart.
+type Holder struct {
+	current atomic.Pointer[Config]
+}
+
+// NewHolder wraps an initial configuration, typically the one Load returned
+func NewHolder(cfg *Config) *Holder {
+	h := &Holder{}
+	h.current.Store(cfg)
+	return h
+}
+
+// Current returns the active configuration snapshot. Callers must not
+// modify it; a reload replaces the pointer, never the contents.
+func (h *Holder) Current() *Config {
+	return h.current.Load()
+}
+
+// Replace swaps in the next configuration, keeping the old values for the
+// non-reloadable sections and warning when a change to one is dropped
+func (h *Holder) Replace(next *Config, logger *slog.Logger) {
+	logger = logging.OrDefault(logger)
+	old := h.Current()
+
+	if !reflect.DeepEqual(next.Server, old.Server) {
+		logger.Warn("ignoring change to non-reloadable server config; restart to apply")
+		next.Server = old.Server
+	}
+	if !reflect.DeepEqual(next.Providers, old.Providers) {
+		logger.Warn("ignoring change to non-reloadable providers config; restart to apply")
+		next.Providers = old.Providers
+	}
+	if !reflect.DeepEqual(next.MQTT, old.MQTT) {
+		logger.Warn("ignoring change to non-reloadable mqtt config; restart to apply")
+		next.MQTT = old.MQTT
+	}
+
+	h.current.Store(next)
+
+	// The coordinate logging policy lives in the logging package rather than
+	// behind the holder, so reloads push the opt-in to it
+	logging.SetPreciseDebugCoords(next.App.LogPreciseCoordinates)
+}
+
+// Watch re-reads the configuration when the config file changes or the
+// process receives SIGHUP (for deployments where the file watch cannot see
+// the change, e.g. a bind-mounted file replaced atomically). A reload that
+// fails to parse keeps the running configuration.
+func (h *Holder) Watch(logger *slog.Logger) {
+	logger = logging.OrDefault(logger)
+
+	viper.OnConfigChange(func(fsnotify.Event) {
+		// WatchConfig already re-read the file into viper
+		h.reload(logger)
+	})
+	viper.WatchConfig()
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			if err := viper.ReadInConfig(); err != nil {
+				logger.Warn("config reload failed to re-read file, keeping current config", "error", err)
+				continue
+			}
+			h.reload(logger)
+		}
+	}()
+}
+
+// reload decodes whatever viper currently holds and swaps it in
+func (h *Holder) reload(logger *slog.Logger) {
+	next, err := decode()
+	if err != nil {
+		logger.Warn("config reload failed, keeping current config", "error", err)
+		return
+	}
+	h.Replace(next, logger)
+	logger.Info("configuration reloaded")
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestHolderReplaceSwapsTunables(t *testing.T) {
+	holder := NewHolder(&Config{App: AppConfig{PowderAlertInches: 6, RefreezeMinHours: 3}})
+
+	holder.Replace(&Config{App: AppConfig{PowderAlertInches: 10, RefreezeMinHours: 2}}, nil)
+
+	cfg := holder.Current()
+	if cfg.App.PowderAlertInches != 10 || cfg.App.RefreezeMinHours != 2 {
+		t.Errorf("tunables not swapped: %+v", cfg.App)
+	}
+}
+
+func TestHolderReplaceKeepsNonReloadableSections(t *testing.T) {
+	holder := NewHolder(&Config{
+		Server:    ServerConfig{Port: 8080, RequestTimeoutSeconds: 15},
+		Providers: ProvidersConfig{Mode: ProviderModeLive},
+		MQTT:      MQTTConfig{TopicPrefix: "medisnow"},
+		App:       AppConfig{ForecastDays: 16},
+	})
+
+	holder.Replace(&Config{
+		Server:    ServerConfig{Port: 9090},
+		Providers: ProvidersConfig{Mode: ProviderModeMock},
+		MQTT:      MQTTConfig{TopicPrefix: "other"},
+		App:       AppConfig{ForecastDays: 7},
+	}, nil)
+
+	cfg := holder.Current()
+	if cfg.Server.Port != 8080 || cfg.Server.RequestTimeoutSeconds != 15 {
+		t.Errorf("server config should survive a reload: %+v", cfg.Server)
+	}
+	if cfg.Providers.Mode != ProviderModeLive {
+		t.Errorf("providers mode should survive a reload: %q", cfg.Providers.Mode)
+	}
+	if cfg.MQTT.TopicPrefix != "medisnow" {
+		t.Errorf("mqtt config should survive a reload: %+v", cfg.MQTT)
+	}
+	if cfg.App.ForecastDays != 7 {
+		t.Errorf("app tunables should reload: %+v", cfg.App)
+	}
+}
+
+func TestHolderCurrentSeesReplacementWithoutRestart(t *testing.T) {
+	holder := NewHolder(&Config{App: AppConfig{AllowNullIsland: false}})
+	before := holder.Current()
+
+	holder.Replace(&Config{App: AppConfig{AllowNullIsland: true}}, nil)
+
+	if !holder.Current().App.AllowNullIsland {
+		t.Error("reader consulting the holder should see the new value")
+	}
+	if before.App.AllowNullIsland {
+		t.Error("an already-taken snapshot must not change underneath its reader")
+	}
+}
@@ -1,9 +1,12 @@
 package location
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"medi/internal/logging"
+	"medi/internal/providers"
 	"medi/internal/providers/openstreetmap"
 	"medi/internal/providers/usgs"
 	"medi/internal/types"
@@ -15,12 +18,36 @@ var (
 	ErrInvalidLatitude = errors.New("latitude must be between -90 and 90")
 	// ErrInvalidLongitude indicates longitude is out of valid range
 	ErrInvalidLongitude = errors.New("longitude must be between -180 and 180")
+	// ErrInvalidElevation indicates an elevation override outside the range of
+	// the Earth's surface
+	ErrInvalidElevation = errors.New("elevation must be between -430 and 8850 meters")
 )
 
+// Elevation overrides are bounded by the Earth's surface: the Dead Sea shore
+// and the summit of Everest
+const (
+	minOverrideElevationMeters = -430.0
+	maxOverrideElevationMeters = 8850.0
+)
+
+// ElevationSourceUserSupplied marks a forecast point whose elevation came from
+// the caller rather than the elevation provider
+const ElevationSourceUserSupplied = "user-supplied"
+
+// ElevationOverride carries a caller-supplied elevation and optional display
+// name for a forecast point, bypassing the elevation provider
+type ElevationOverride struct {
+	ElevationMeters float64
+	Name            string
+}
+
 // Service provides location and elevation data for weather forecasting
 type Service interface {
 	// GetForecastPoint retrieves comprehensive location data for a given coordinate
 	GetForecastPoint(latitude, longitude float64) (*types.ForecastPoint, error)
+	// BuildForecastPoint builds a forecast point from a caller-supplied
+	// elevation, skipping the elevation provider entirely
+	BuildForecastPoint(latitude, longitude float64, override ElevationOverride) (*types.ForecastPoint, error)
 }
 
 // ElevationProvider defines the interface for elevation data providers
@@ -28,6 +55,13 @@ type ElevationProvider interface {
 	GetElevationPoint(latitude, longitude float64) (*usgs.ElevationPointAPIResponse, error)
 }
 
+// BulkElevationProvider fetches elevations in meters for many points per
+// request, aligned to the input order. Optional: when absent the terrain
+// sampler falls back to parallel per-point lookups.
+type BulkElevationProvider interface {
+	GetElevations(ctx context.Context, latitudes, longitudes []float64) ([]float64, error)
+}
+
 // ReverseGeocodeProvider defines the interface for location data providers
 type ReverseGeocodeProvider interface {
 	Lookup(latitude, longitude float64) (*openstreetmap.LookupAPIResponse, error)
@@ -36,16 +70,25 @@ type ReverseGeocodeProvider interface {
 // locationService implements the Service interface
 type locationService struct {
 	elevationProvider ElevationProvider
-	locationProvider  ReverseGeocodeProvider
-	logger            *slog.Logger
+	// bulkElevationProvider serves multi-point lookups for terrain sampling,
+	// nil when no batch upstream exists
+	bulkElevationProvider BulkElevationProvider
+	locationProvider      ReverseGeocodeProvider
+	logger                *slog.Logger
+
+	// terrainCache memoizes terrain estimates per point; terrain does not
+	// change, so entries never expire
+	terrainMu    sync.Mutex
+	terrainCache map[string]terrainEstimate
 }
 
 // NewLocationService creates a new location service with real provider clients
-func NewLocationService(logger *slog.Logger) Service {
+func NewLocationService(p *providers.Providers, logger *slog.Logger) Service {
 	return NewLocationServiceWithProviders(
 		logger,
-		usgs.NewClient(logger),
-		openstreetmap.NewClient(logger),
+		p.Elevation,
+		p.BulkElevation,
+		p.ReverseGeocode,
 	)
 }
 
@@ -54,12 +97,14 @@ func NewLocationService(logger *slog.Logger) Service {
 func NewLocationServiceWithProviders(
 	logger *slog.Logger,
 	elevationProvider ElevationProvider,
+	bulkElevationProvider BulkElevationProvider,
 	locationProvider ReverseGeocodeProvider,
 ) Service {
 	return &locationService{
-		logger:            logger.With("component", "location-service"),
-		elevationProvider: elevationProvider,
-		locationProvider:  locationProvider,
+		logger:                logging.OrDefault(logger).With("component", "location-service"),
+		elevationProvider:     elevationProvider,
+		bulkElevationProvider: bulkElevationProvider,
+		locationProvider:      locationProvider,
 	}
 }
 
@@ -68,16 +113,14 @@ func (s *locationService) GetForecastPoint(latitude, longitude float64) (*types.
 	// Validate coordinates
 	if err := validateCoordinates(latitude, longitude); err != nil {
 		s.logger.Warn("invalid coordinates",
-			"latitude", latitude,
-			"longitude", longitude,
+			logging.Coords(latitude, longitude),
 			"error", err,
 		)
 		return nil, err
 	}
 
 	s.logger.Debug("getting forecast point",
-		"latitude", latitude,
-		"longitude", longitude,
+		logging.DebugCoords(latitude, longitude),
 	)
 
 	var (
@@ -115,8 +158,7 @@ func (s *locationService) GetForecastPoint(latitude, longitude float64) (*types.
 	// Check for errors
 	if elevationErr != nil && locationErr != nil {
 		s.logger.Error("multiple provider errors",
-			"latitude", latitude,
-			"longitude", longitude,
+			logging.Coords(latitude, longitude),
 			"elevation_error", elevationErr,
 			"location_error", locationErr,
 		)
@@ -125,16 +167,14 @@ func (s *locationService) GetForecastPoint(latitude, longitude float64) (*types.
 
 	if elevationErr != nil {
 		s.logger.Error("elevation provider error",
-			"latitude", latitude,
-			"longitude", longitude,
+			logging.Coords(latitude, longitude),
 			"error", elevationErr,
 		)
 		return nil, elevationErr
 	}
 	if locationErr != nil {
 		s.logger.Error("location provider error",
-			"latitude", latitude,
-			"longitude", longitude,
+			logging.Coords(latitude, longitude),
 			"error", locationErr,
 		)
 		return nil, locationErr
@@ -157,22 +197,69 @@ func (s *locationService) GetForecastPoint(latitude, longitude float64) (*types.
 		Location:    locationInfo,
 	}
 
+	// Attach the estimated terrain aspect and slope, degrading the point when
+	// the sampling fails
+	s.applyTerrain(forecastPoint)
+
 	s.logger.Debug("successfully retrieved forecast point",
-		"latitude", latitude,
-		"longitude", longitude,
-		"location_name", locationInfo.Name,
+		logging.DebugCoords(latitude, longitude),
+		logging.PlaceName(locationInfo.Name),
 	)
 
 	return forecastPoint, nil
 }
 
-// translateElevation converts an OpenMeteo elevation response to domain Elevation type
+// BuildForecastPoint builds a forecast point directly from the override. The
+// elevation provider is never called — the caller knows the elevation better
+// than the DEM does (a specific couloir, say), and that also rules out terrain
+// sampling, which reads the same DEM. The reverse geocode still runs but only
+// best-effort: a failure degrades to the override name rather than erroring.
+func (s *locationService) BuildForecastPoint(latitude, longitude float64, override ElevationOverride) (*types.ForecastPoint, error) {
+	if err := validateCoordinates(latitude, longitude); err != nil {
+		s.logger.Warn("invalid coordinates",
+			logging.Coords(latitude, longitude),
+			"error", err,
+		)
+		return nil, err
+	}
+	if override.ElevationMeters < minOverrideElevationMeters || override.ElevationMeters > maxOverrideElevationMeters {
+		return nil, fmt.Errorf("%w: got %.1f", ErrInvalidElevation, override.ElevationMeters)
+	}
+
+	forecastPoint := &types.ForecastPoint{
+		Coordinates:     types.NewCoords(latitude, longitude),
+		Elevation:       types.NewElevationFromMeters(override.ElevationMeters),
+		ElevationSource: ElevationSourceUserSupplied,
+		Location:        types.LocationInfo{Name: override.Name},
+	}
+
+	locationResp, err := s.locationProvider.Lookup(latitude, longitude)
+	if err != nil {
+		s.logger.Warn("reverse geocode failed for overridden point",
+			logging.Coords(latitude, longitude),
+			"error", err,
+		)
+		return forecastPoint, nil
+	}
+	locationInfo, err := s.translateLocationInfo(locationResp)
+	if err != nil {
+		return forecastPoint, nil
+	}
+	if override.Name != "" {
+		locationInfo.Name = override.Name
+	}
+	forecastPoint.Location = locationInfo
+
+	return forecastPoint, nil
+}
+
+// translateElevation converts a USGS elevation response to domain Elevation type
 func (s *locationService) translateElevation(resp *usgs.ElevationPointAPIResponse) (types.Elevation, error) {
 	if resp == nil {
 		return types.Elevation{}, fmt.Errorf("elevation response is nil")
 	}
 
-	// OpenMeteo returns elevation in meters
+	// The USGS query requests units=Feet, so Value arrives in feet
 	return types.NewElevationFromFeet(resp.Value), nil
 }
 
@@ -1,14 +1,15 @@
 package location
 
 import (
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"medi/internal/providers/openstreetmap"
 	"medi/internal/providers/usgs"
+	"medi/internal/testsupport"
 	"medi/internal/types"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -17,12 +18,24 @@ import (
 type mockElevationProvider struct {
 	response *usgs.ElevationPointAPIResponse
 	err      error
+
+	mu    sync.Mutex
+	calls int
 }
 
 func (m *mockElevationProvider) GetElevationPoint(latitude, longitude float64) (*usgs.ElevationPointAPIResponse, error) {
+	m.mu.Lock()
+	m.calls++
+	m.mu.Unlock()
 	return m.response, m.err
 }
 
+func (m *mockElevationProvider) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
 type mockLocationProvider struct {
 	response *openstreetmap.LookupAPIResponse
 	err      error
@@ -33,28 +46,9 @@ func (m *mockLocationProvider) Lookup(latitude, longitude float64) (*openstreetm
 }
 
 func TestLocationService_GetForecastPoint_AspenSnapshot(t *testing.T) {
-	// Load USGS elevation snapshot
-	elevData, err := os.ReadFile("testdata/usgs_elevation_response.json")
-	if err != nil {
-		t.Fatalf("Failed to read elevation testdata: %v", err)
-	}
-	var elevResp usgs.ElevationPointAPIResponse
-	if err := json.Unmarshal(elevData, &elevResp); err != nil {
-		t.Fatalf("Failed to unmarshal elevation: %v", err)
-	}
-
-	// Load OpenStreetMap lookup snapshot
-	locData, err := os.ReadFile("testdata/openstreetmap_lookup_response.json")
-	if err != nil {
-		t.Fatalf("Failed to read location testdata: %v", err)
-	}
-	var locResp openstreetmap.LookupAPIResponse
-	if err := json.Unmarshal(locData, &locResp); err != nil {
-		t.Fatalf("Failed to unmarshal location: %v", err)
-	}
-
-	elevProvider := &mockElevationProvider{response: &elevResp}
-	locProvider := &mockLocationProvider{response: &locResp}
+	// Load the committed provider snapshots
+	elevProvider := &mockElevationProvider{response: testsupport.USGSElevationFixture(t)}
+	locProvider := &mockLocationProvider{response: testsupport.OSMLookupFixture(t)}
 
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	service := &locationService{
@@ -252,3 +246,117 @@ func TestLocationService_GetForecastPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestLocationService_NilLoggerDefaults(t *testing.T) {
+	service := NewLocationServiceWithProviders(nil,
+		&mockElevationProvider{err: errors.New("provider down")},
+		nil,
+		&mockLocationProvider{err: errors.New("provider down")},
+	)
+
+	// Out-of-range latitude hits the warn-and-return path; with a nil logger
+	// the service logs through slog.Default() instead of panicking
+	if _, err := service.GetForecastPoint(91, 0); !errors.Is(err, ErrInvalidLatitude) {
+		t.Errorf("GetForecastPoint() error = %v, want ErrInvalidLatitude", err)
+	}
+}
+
+func newOverrideTestService(elevProvider *mockElevationProvider, locProvider *mockLocationProvider) *locationService {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	return &locationService{
+		elevationProvider: elevProvider,
+		locationProvider:  locProvider,
+		logger:            logger,
+	}
+}
+
+func TestLocationService_BuildForecastPoint(t *testing.T) {
+	elevProvider := &mockElevationProvider{err: errors.New("should not be called")}
+	locProvider := &mockLocationProvider{response: testsupport.OSMLookupFixture(t)}
+	service := newOverrideTestService(elevProvider, locProvider)
+
+	fp, err := service.BuildForecastPoint(39.11539, -107.65840, ElevationOverride{
+		ElevationMeters: 3650,
+		Name:            "Cristo Couloir",
+	})
+	if err != nil {
+		t.Fatalf("BuildForecastPoint returned error: %v", err)
+	}
+
+	if fp.Elevation.Meters != 3650 {
+		t.Errorf("Elevation.Meters = %v, want 3650", fp.Elevation.Meters)
+	}
+	if fp.ElevationSource != ElevationSourceUserSupplied {
+		t.Errorf("ElevationSource = %q, want %q", fp.ElevationSource, ElevationSourceUserSupplied)
+	}
+	// The override name wins over the geocoded one; the rest of the geocode
+	// still fills in
+	if fp.Location.Name != "Cristo Couloir" {
+		t.Errorf("Location.Name = %q, want Cristo Couloir", fp.Location.Name)
+	}
+	if fp.Location.State != "Colorado" {
+		t.Errorf("Location.State = %q, want Colorado", fp.Location.State)
+	}
+	if elevProvider.callCount() != 0 {
+		t.Errorf("elevation provider calls = %d, want 0", elevProvider.callCount())
+	}
+}
+
+func TestLocationService_BuildForecastPointWithoutName(t *testing.T) {
+	elevProvider := &mockElevationProvider{}
+	locProvider := &mockLocationProvider{response: testsupport.OSMLookupFixture(t)}
+	service := newOverrideTestService(elevProvider, locProvider)
+
+	fp, err := service.BuildForecastPoint(39.11539, -107.65840, ElevationOverride{ElevationMeters: 2900})
+	if err != nil {
+		t.Fatalf("BuildForecastPoint returned error: %v", err)
+	}
+	if fp.Location.Name == "" {
+		t.Error("expected the geocoded name when the override has none")
+	}
+}
+
+func TestLocationService_BuildForecastPointGeocodeFailureDegrades(t *testing.T) {
+	elevProvider := &mockElevationProvider{}
+	locProvider := &mockLocationProvider{err: errors.New("geocode down")}
+	service := newOverrideTestService(elevProvider, locProvider)
+
+	fp, err := service.BuildForecastPoint(39.11539, -107.65840, ElevationOverride{
+		ElevationMeters: 3650,
+		Name:            "Cristo Couloir",
+	})
+	if err != nil {
+		t.Fatalf("BuildForecastPoint returned error: %v", err)
+	}
+	if fp.Location.Name != "Cristo Couloir" {
+		t.Errorf("Location.Name = %q, want the override name after a geocode failure", fp.Location.Name)
+	}
+	if fp.ElevationSource != ElevationSourceUserSupplied {
+		t.Errorf("ElevationSource = %q, want %q", fp.ElevationSource, ElevationSourceUserSupplied)
+	}
+}
+
+func TestLocationService_BuildForecastPointInvalidInputs(t *testing.T) {
+	service := newOverrideTestService(&mockElevationProvider{}, &mockLocationProvider{})
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		meters   float64
+		wantErr  error
+	}{
+		{"below the Dead Sea shore", 39.11539, -107.65840, -431, ErrInvalidElevation},
+		{"above Everest", 39.11539, -107.65840, 8851, ErrInvalidElevation},
+		{"invalid latitude", 91, -107.65840, 3000, ErrInvalidLatitude},
+		{"invalid longitude", 39.11539, -181, 3000, ErrInvalidLongitude},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.BuildForecastPoint(tt.lat, tt.lon, ElevationOverride{ElevationMeters: tt.meters})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("BuildForecastPoint() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
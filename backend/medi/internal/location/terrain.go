@@ -0,0 +1,213 @@
+package location
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"medi/internal/logging"
+	"medi/internal/types"
+)
+
+// Terrain aspect and slope are estimated by sampling elevation on a ring of
+// points around the forecast point and fitting a plane through the samples.
+// The ring is fetched through the bulk elevation provider in one request when
+// available; otherwise one request per sample, in parallel like the main
+// point lookup.
+const (
+	// terrainSampleDistanceMeters is the ring radius; ~100 m captures the
+	// slope a skier stands on without averaging across whole drainages
+	terrainSampleDistanceMeters = 100.0
+	// terrainSampleCount spaces the ring samples every 45 degrees
+	terrainSampleCount = 8
+	// metersPerDegreeLatitude converts sample offsets to coordinate deltas
+	metersPerDegreeLatitude = 111320.0
+	// minTerrainSlopeDegrees is the slope below which the fitted plane is
+	// treated as flat and no aspect is reported
+	minTerrainSlopeDegrees = 1.0
+
+	// bulkElevationMinPoints is the batch size past which the bulk provider
+	// beats parallel per-point lookups; tiny batches stay on the per-point
+	// path, whose provider is also the one the main lookup already exercised
+	bulkElevationMinPoints = 4
+)
+
+// terrainSample is one elevation sample offset from the point, in meters
+// east and north
+type terrainSample struct {
+	eastMeters      float64
+	northMeters     float64
+	elevationMeters float64
+}
+
+// terrainEstimate is a memoized terrain computation for one point
+type terrainEstimate struct {
+	aspect     *types.WindDirection
+	slopeAngle float64
+}
+
+// applyTerrain attaches the estimated terrain aspect and slope angle to the
+// forecast point. Terrain does not change, so estimates are cached forever;
+// estimation failures degrade the point rather than failing the lookup.
+func (s *locationService) applyTerrain(point *types.ForecastPoint) {
+	key := fmt.Sprintf("%.4f,%.4f", point.Coordinates.Latitude, point.Coordinates.Longitude)
+
+	s.terrainMu.Lock()
+	estimate, ok := s.terrainCache[key]
+	s.terrainMu.Unlock()
+
+	if !ok {
+		var err error
+		estimate, err = s.estimateTerrain(point.Coordinates.Latitude, point.Coordinates.Longitude)
+		if err != nil {
+			s.logger.Warn("terrain estimation failed",
+				logging.Coords(point.Coordinates.Latitude, point.Coordinates.Longitude),
+				"error", err,
+			)
+			return
+		}
+
+		s.terrainMu.Lock()
+		if s.terrainCache == nil {
+			s.terrainCache = make(map[string]terrainEstimate)
+		}
+		s.terrainCache[key] = estimate
+		s.terrainMu.Unlock()
+	}
+
+	point.TerrainAspect = estimate.aspect
+	point.SlopeAngle = estimate.slopeAngle
+}
+
+// estimateTerrain samples the elevation ring around the point and fits a
+// plane through the samples
+func (s *locationService) estimateTerrain(latitude, longitude float64) (terrainEstimate, error) {
+	latitudes := make([]float64, terrainSampleCount)
+	longitudes := make([]float64, terrainSampleCount)
+	samples := make([]terrainSample, terrainSampleCount)
+	for i := 0; i < terrainSampleCount; i++ {
+		// Ring bearings run clockwise from north
+		bearing := float64(i) * (360.0 / terrainSampleCount) * math.Pi / 180.0
+		east := terrainSampleDistanceMeters * math.Sin(bearing)
+		north := terrainSampleDistanceMeters * math.Cos(bearing)
+
+		latitudes[i] = latitude + north/metersPerDegreeLatitude
+		longitudes[i] = longitude + east/(metersPerDegreeLatitude*math.Cos(latitude*math.Pi/180.0))
+		samples[i] = terrainSample{eastMeters: east, northMeters: north}
+	}
+
+	elevations, err := s.resolveElevations(latitudes, longitudes)
+	if err != nil {
+		return terrainEstimate{}, err
+	}
+	for i := range samples {
+		samples[i].elevationMeters = elevations[i]
+	}
+
+	aspectDegrees, slopeDegrees, err := fitTerrainPlane(samples)
+	if err != nil {
+		return terrainEstimate{}, err
+	}
+
+	estimate := terrainEstimate{slopeAngle: slopeDegrees}
+	if slopeDegrees >= minTerrainSlopeDegrees {
+		aspect := types.NewWindDirection(aspectDegrees)
+		estimate.aspect = &aspect
+	}
+	return estimate, nil
+}
+
+// resolveElevations fetches the elevation in meters for every point, aligned
+// to the input order. Batches past bulkElevationMinPoints go through the bulk
+// provider in as few requests as possible; smaller batches, or a missing or
+// failing bulk provider, fall back to parallel per-point lookups.
+func (s *locationService) resolveElevations(latitudes, longitudes []float64) ([]float64, error) {
+	if s.bulkElevationProvider != nil && len(latitudes) >= bulkElevationMinPoints {
+		elevations, err := s.bulkElevationProvider.GetElevations(context.Background(), latitudes, longitudes)
+		if err == nil {
+			return elevations, nil
+		}
+		s.logger.Warn("bulk elevation lookup failed, falling back to per-point lookups",
+			"points", len(latitudes),
+			"error", err,
+		)
+	}
+
+	elevations := make([]float64, len(latitudes))
+	errs := make([]error, len(latitudes))
+	var wg sync.WaitGroup
+	for i := range latitudes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := s.elevationProvider.GetElevationPoint(latitudes[i], longitudes[i])
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to sample elevation at point %d: %w", i, err)
+				return
+			}
+			// The USGS query requests units=Feet, so Value arrives in feet
+			elevations[i] = types.NewElevationFromFeet(resp.Value).Meters
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return elevations, nil
+}
+
+// fitTerrainPlane least-squares fits a plane through the samples and returns
+// the downslope direction as a compass bearing and the slope angle, both in
+// degrees. The aspect is meaningless when the returned slope is near zero.
+func fitTerrainPlane(samples []terrainSample) (aspectDegrees, slopeDegrees float64, err error) {
+	if len(samples) < 3 {
+		return 0, 0, fmt.Errorf("plane fit needs at least 3 samples, got %d", len(samples))
+	}
+
+	// Center the coordinates so the constant term drops out of the fit
+	var meanEast, meanNorth, meanElevation float64
+	for _, sample := range samples {
+		meanEast += sample.eastMeters
+		meanNorth += sample.northMeters
+		meanElevation += sample.elevationMeters
+	}
+	n := float64(len(samples))
+	meanEast /= n
+	meanNorth /= n
+	meanElevation /= n
+
+	var sxx, syy, sxy, sxz, syz float64
+	for _, sample := range samples {
+		x := sample.eastMeters - meanEast
+		y := sample.northMeters - meanNorth
+		z := sample.elevationMeters - meanElevation
+		sxx += x * x
+		syy += y * y
+		sxy += x * y
+		sxz += x * z
+		syz += y * z
+	}
+
+	det := sxx*syy - sxy*sxy
+	if det == 0 {
+		return 0, 0, fmt.Errorf("plane fit is degenerate: samples are collinear")
+	}
+
+	// Elevation gradient in meters per meter, east and north
+	gradientEast := (sxz*syy - syz*sxy) / det
+	gradientNorth := (syz*sxx - sxz*sxy) / det
+
+	slopeDegrees = math.Atan(math.Hypot(gradientEast, gradientNorth)) * 180.0 / math.Pi
+
+	// Downslope points opposite the gradient; bearing is clockwise from north
+	aspectDegrees = math.Atan2(-gradientEast, -gradientNorth) * 180.0 / math.Pi
+	if aspectDegrees < 0 {
+		aspectDegrees += 360.0
+	}
+
+	return aspectDegrees, slopeDegrees, nil
+}
@@ -0,0 +1,304 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"os"
+	"sync"
+	"testing"
+
+	"medi/internal/providers/usgs"
+	"medi/internal/types"
+)
+
+func pointAt(latitude, longitude float64) *types.ForecastPoint {
+	return &types.ForecastPoint{Coordinates: types.NewCoords(latitude, longitude)}
+}
+
+// planarElevationProvider serves elevations from a synthetic planar surface
+// so the fitted aspect and slope are known exactly
+type planarElevationProvider struct {
+	baseLatitude  float64
+	baseLongitude float64
+	baseMeters    float64
+	gradientEast  float64 // meters of elevation per meter east
+	gradientNorth float64 // meters of elevation per meter north
+	mu            sync.Mutex
+	calls         int
+}
+
+func (p *planarElevationProvider) metersAt(latitude, longitude float64) float64 {
+	north := (latitude - p.baseLatitude) * metersPerDegreeLatitude
+	east := (longitude - p.baseLongitude) * metersPerDegreeLatitude * math.Cos(p.baseLatitude*math.Pi/180.0)
+	return p.baseMeters + p.gradientEast*east + p.gradientNorth*north
+}
+
+func (p *planarElevationProvider) GetElevationPoint(latitude, longitude float64) (*usgs.ElevationPointAPIResponse, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+
+	return &usgs.ElevationPointAPIResponse{Value: p.metersAt(latitude, longitude) / 0.3048}, nil
+}
+
+func (p *planarElevationProvider) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// ringSamples builds the 8-point sample ring over a planar surface
+func ringSamples(gradientEast, gradientNorth float64) []terrainSample {
+	samples := make([]terrainSample, terrainSampleCount)
+	for i := range samples {
+		bearing := float64(i) * (360.0 / terrainSampleCount) * math.Pi / 180.0
+		east := terrainSampleDistanceMeters * math.Sin(bearing)
+		north := terrainSampleDistanceMeters * math.Cos(bearing)
+		samples[i] = terrainSample{
+			eastMeters:      east,
+			northMeters:     north,
+			elevationMeters: 3000.0 + gradientEast*east + gradientNorth*north,
+		}
+	}
+	return samples
+}
+
+func TestFitTerrainPlane(t *testing.T) {
+	tests := []struct {
+		name          string
+		gradientEast  float64
+		gradientNorth float64
+		wantAspect    float64
+		wantSlope     float64
+	}{
+		{
+			// Elevation drops to the east, so the slope faces east
+			name:         "east facing",
+			gradientEast: -0.5,
+			wantAspect:   90.0,
+			wantSlope:    26.565,
+		},
+		{
+			// Elevation rises to the south, so downslope points north
+			name:          "north facing",
+			gradientNorth: -math.Tan(30.0 * math.Pi / 180.0),
+			wantAspect:    0.0,
+			wantSlope:     30.0,
+		},
+		{
+			name:          "southwest facing",
+			gradientEast:  0.3,
+			gradientNorth: 0.3,
+			wantAspect:    225.0,
+			wantSlope:     22.993,
+		},
+		{
+			name:      "flat",
+			wantSlope: 0.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aspect, slope, err := fitTerrainPlane(ringSamples(tt.gradientEast, tt.gradientNorth))
+			if err != nil {
+				t.Fatalf("fitTerrainPlane returned error: %v", err)
+			}
+
+			if math.Abs(slope-tt.wantSlope) > 0.01 {
+				t.Errorf("slope = %.3f, want %.3f", slope, tt.wantSlope)
+			}
+			if tt.wantSlope > 0 {
+				delta := math.Abs(math.Mod(aspect-tt.wantAspect+540.0, 360.0) - 180.0)
+				if delta > 0.1 {
+					t.Errorf("aspect = %.3f, want %.3f", aspect, tt.wantAspect)
+				}
+			}
+		})
+	}
+}
+
+func TestFitTerrainPlaneRejectsDegenerateSamples(t *testing.T) {
+	if _, _, err := fitTerrainPlane(ringSamples(0, 0)[:2]); err == nil {
+		t.Error("expected an error for fewer than 3 samples")
+	}
+
+	// Collinear samples leave the fit underdetermined
+	collinear := []terrainSample{
+		{eastMeters: -100, elevationMeters: 3000},
+		{eastMeters: 0, elevationMeters: 3010},
+		{eastMeters: 100, elevationMeters: 3020},
+	}
+	if _, _, err := fitTerrainPlane(collinear); err == nil {
+		t.Error("expected an error for collinear samples")
+	}
+}
+
+// planarBulkElevationProvider serves the same synthetic surface through the
+// bulk interface
+type planarBulkElevationProvider struct {
+	planar *planarElevationProvider
+	err    error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *planarBulkElevationProvider) GetElevations(ctx context.Context, latitudes, longitudes []float64) ([]float64, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	elevations := make([]float64, len(latitudes))
+	for i := range latitudes {
+		elevations[i] = p.planar.metersAt(latitudes[i], longitudes[i])
+	}
+	return elevations, nil
+}
+
+func (p *planarBulkElevationProvider) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+func newTerrainTestService(provider ElevationProvider) *locationService {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	return &locationService{
+		elevationProvider: provider,
+		logger:            logger,
+	}
+}
+
+func TestEstimateTerrainFromPlanarSurface(t *testing.T) {
+	// A surface dropping to the east at 0.5 m/m fits to an east aspect
+	provider := &planarElevationProvider{
+		baseLatitude:  39.11539,
+		baseLongitude: -107.65840,
+		baseMeters:    3000.0,
+		gradientEast:  -0.5,
+	}
+	service := newTerrainTestService(provider)
+
+	estimate, err := service.estimateTerrain(provider.baseLatitude, provider.baseLongitude)
+	if err != nil {
+		t.Fatalf("estimateTerrain returned error: %v", err)
+	}
+
+	if estimate.aspect == nil {
+		t.Fatal("expected an aspect for a sloped surface")
+	}
+	if estimate.aspect.Cardinal != "E" {
+		t.Errorf("aspect cardinal = %s, want E", estimate.aspect.Cardinal)
+	}
+	if math.Abs(estimate.slopeAngle-26.565) > 0.1 {
+		t.Errorf("slope angle = %.3f, want 26.565", estimate.slopeAngle)
+	}
+	if provider.callCount() != terrainSampleCount {
+		t.Errorf("provider calls = %d, want %d", provider.callCount(), terrainSampleCount)
+	}
+}
+
+func TestEstimateTerrainPrefersBulkProvider(t *testing.T) {
+	planar := &planarElevationProvider{
+		baseLatitude:  39.11539,
+		baseLongitude: -107.65840,
+		baseMeters:    3000.0,
+		gradientEast:  -0.5,
+	}
+	bulk := &planarBulkElevationProvider{planar: planar}
+	service := newTerrainTestService(planar)
+	service.bulkElevationProvider = bulk
+
+	estimate, err := service.estimateTerrain(planar.baseLatitude, planar.baseLongitude)
+	if err != nil {
+		t.Fatalf("estimateTerrain returned error: %v", err)
+	}
+
+	// The 8-point ring crosses the bulk threshold, so the whole ring goes
+	// through one bulk request and never touches the per-point provider
+	if bulk.callCount() != 1 {
+		t.Errorf("bulk provider calls = %d, want 1", bulk.callCount())
+	}
+	if planar.callCount() != 0 {
+		t.Errorf("per-point provider calls = %d, want 0", planar.callCount())
+	}
+	if estimate.aspect == nil || estimate.aspect.Cardinal != "E" {
+		t.Errorf("aspect = %+v, want E", estimate.aspect)
+	}
+}
+
+func TestEstimateTerrainFallsBackWhenBulkFails(t *testing.T) {
+	planar := &planarElevationProvider{
+		baseLatitude:  39.11539,
+		baseLongitude: -107.65840,
+		baseMeters:    3000.0,
+		gradientEast:  -0.5,
+	}
+	service := newTerrainTestService(planar)
+	service.bulkElevationProvider = &planarBulkElevationProvider{err: errors.New("bulk upstream down")}
+
+	estimate, err := service.estimateTerrain(planar.baseLatitude, planar.baseLongitude)
+	if err != nil {
+		t.Fatalf("estimateTerrain returned error: %v", err)
+	}
+
+	if planar.callCount() != terrainSampleCount {
+		t.Errorf("per-point provider calls = %d, want %d after bulk failure", planar.callCount(), terrainSampleCount)
+	}
+	if estimate.aspect == nil || estimate.aspect.Cardinal != "E" {
+		t.Errorf("aspect = %+v, want E", estimate.aspect)
+	}
+}
+
+func TestEstimateTerrainFlatSurfaceHasNoAspect(t *testing.T) {
+	provider := &planarElevationProvider{
+		baseLatitude:  39.11539,
+		baseLongitude: -107.65840,
+		baseMeters:    3000.0,
+	}
+	service := newTerrainTestService(provider)
+
+	estimate, err := service.estimateTerrain(provider.baseLatitude, provider.baseLongitude)
+	if err != nil {
+		t.Fatalf("estimateTerrain returned error: %v", err)
+	}
+
+	if estimate.aspect != nil {
+		t.Errorf("expected no aspect for flat terrain, got %+v", estimate.aspect)
+	}
+	if estimate.slopeAngle != 0 {
+		t.Errorf("slope angle = %.3f, want 0", estimate.slopeAngle)
+	}
+}
+
+func TestApplyTerrainCachesPerPoint(t *testing.T) {
+	provider := &planarElevationProvider{
+		baseLatitude:  39.11539,
+		baseLongitude: -107.65840,
+		baseMeters:    3000.0,
+		gradientNorth: -0.3,
+	}
+	service := newTerrainTestService(provider)
+
+	first := pointAt(provider.baseLatitude, provider.baseLongitude)
+	service.applyTerrain(first)
+	if first.TerrainAspect == nil || first.TerrainAspect.Cardinal != "N" {
+		t.Fatalf("TerrainAspect = %+v, want N", first.TerrainAspect)
+	}
+
+	// The second lookup for the same point is served from the cache
+	second := pointAt(provider.baseLatitude, provider.baseLongitude)
+	service.applyTerrain(second)
+	if provider.callCount() != terrainSampleCount {
+		t.Errorf("provider calls = %d, want %d after cached lookup", provider.callCount(), terrainSampleCount)
+	}
+	if second.TerrainAspect == nil || second.SlopeAngle != first.SlopeAngle {
+		t.Error("cached terrain did not match the first estimate")
+	}
+}
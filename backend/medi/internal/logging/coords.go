@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"log/slog"
+	"math"
+	"sync/atomic"
+)
+
+// Coordinate logging policy. User coordinates are personal data: at full
+// precision they pinpoint a trailhead or a driveway. Log lines therefore
+// carry coordinates rounded to 2 decimals (~1 km). Debug-level lines may
+// carry full precision, but only when the deployment opts in via config,
+// and place names appear only when the caller already fetched them — a
+// reverse geocode is never triggered just to enrich a log line.
+
+// preciseDebugCoords gates full-precision coordinates on debug-level lines;
+// off by default and toggled from the active configuration
+var preciseDebugCoords atomic.Bool
+
+// SetPreciseDebugCoords applies the configured opt-in for full-precision
+// coordinates on debug-level lines
+func SetPreciseDebugCoords(enabled bool) {
+	preciseDebugCoords.Store(enabled)
+}
+
+// Coords returns the latitude/longitude attribute pair at the policy's
+// reduced precision. The empty group key makes handlers inline the pair, so
+// lines keep their flat latitude/longitude keys.
+func Coords(latitude, longitude float64) slog.Attr {
+	return slog.Group("",
+		slog.Float64("latitude", reduceCoordinate(latitude)),
+		slog.Float64("longitude", reduceCoordinate(longitude)),
+	)
+}
+
+// DebugCoords is Coords for debug-level lines: full precision when the
+// deployment opted in, the reduced default otherwise
+func DebugCoords(latitude, longitude float64) slog.Attr {
+	if preciseDebugCoords.Load() {
+		return slog.Group("",
+			slog.Float64("latitude", latitude),
+			slog.Float64("longitude", longitude),
+		)
+	}
+	return Coords(latitude, longitude)
+}
+
+// PlaceName returns a location name attribute, or an empty attribute that
+// handlers drop when no name is on hand. Callers pass whatever name an
+// earlier lookup produced; logging never performs the lookup itself.
+func PlaceName(name string) slog.Attr {
+	if name == "" {
+		return slog.Attr{}
+	}
+	return slog.String("location_name", name)
+}
+
+// reduceCoordinate rounds a coordinate to 2 decimals, about 1 km — close
+// enough to correlate log lines with a region, too coarse to identify a spot
+func reduceCoordinate(value float64) float64 {
+	return math.Round(value*100) / 100
+}
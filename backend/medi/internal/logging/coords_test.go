@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// recordFloats flattens a record's attributes, inlining the empty-key group
+// Coords emits, and returns the float-valued ones by key
+func recordFloats(r slog.Record) map[string]float64 {
+	floats := map[string]float64{}
+	r.Attrs(func(attr slog.Attr) bool {
+		if attr.Value.Kind() == slog.KindGroup && attr.Key == "" {
+			for _, inner := range attr.Value.Group() {
+				if inner.Value.Kind() == slog.KindFloat64 {
+					floats[inner.Key] = inner.Value.Float64()
+				}
+			}
+			return true
+		}
+		if attr.Value.Kind() == slog.KindFloat64 {
+			floats[attr.Key] = attr.Value.Float64()
+		}
+		return true
+	})
+	return floats
+}
+
+func TestCoordsReducePrecision(t *testing.T) {
+	captured := &captureHandler{}
+	logger := slog.New(captured)
+
+	logger.Info("serving forecast", Coords(39.11539, -107.65840))
+
+	floats := recordFloats(captured.last())
+	if floats["latitude"] != 39.12 {
+		t.Errorf("latitude = %v, want 39.12", floats["latitude"])
+	}
+	if floats["longitude"] != -107.66 {
+		t.Errorf("longitude = %v, want -107.66", floats["longitude"])
+	}
+}
+
+func TestDebugCoordsFollowPreciseOptIn(t *testing.T) {
+	captured := &captureHandler{}
+	logger := slog.New(captured)
+	t.Cleanup(func() { SetPreciseDebugCoords(false) })
+
+	// Default: debug lines are reduced like every other level
+	logger.Debug("getting forecast point", DebugCoords(39.11539, -107.65840))
+	floats := recordFloats(captured.last())
+	if floats["latitude"] != 39.12 || floats["longitude"] != -107.66 {
+		t.Errorf("default debug coords = %v, %v, want 39.12, -107.66", floats["latitude"], floats["longitude"])
+	}
+
+	// The opt-in restores full precision on debug lines only
+	SetPreciseDebugCoords(true)
+	logger.Debug("getting forecast point", DebugCoords(39.11539, -107.65840))
+	floats = recordFloats(captured.last())
+	if floats["latitude"] != 39.11539 || floats["longitude"] != -107.65840 {
+		t.Errorf("opted-in debug coords = %v, %v, want full precision", floats["latitude"], floats["longitude"])
+	}
+
+	logger.Warn("invalid coordinates", Coords(39.11539, -107.65840))
+	floats = recordFloats(captured.last())
+	if floats["latitude"] != 39.12 || floats["longitude"] != -107.66 {
+		t.Errorf("warn coords = %v, %v, want reduced despite opt-in", floats["latitude"], floats["longitude"])
+	}
+}
+
+func TestPlaceNameOnlyWhenAlreadyFetched(t *testing.T) {
+	if attr := PlaceName("Marble"); attr.Key != "location_name" || attr.Value.String() != "Marble" {
+		t.Errorf("PlaceName(Marble) = %v", attr)
+	}
+	// No name on hand: an empty attribute, which handlers drop
+	if attr := PlaceName(""); !attr.Equal(slog.Attr{}) {
+		t.Errorf("PlaceName(\"\") = %v, want empty attr", attr)
+	}
+}
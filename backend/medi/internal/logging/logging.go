@@ -0,0 +1,14 @@
+// Package logging holds small helpers shared by the domain services.
+package logging
+
+import "log/slog"
+
+// OrDefault returns the logger, or slog.Default() when it is nil. Service
+// constructors call it before deriving component loggers so a nil logger
+// falls back to the process default instead of panicking.
+func OrDefault(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// samplingWindow is how long identical lines stay grouped before the
+	// window closes and the suppression counter is reported
+	samplingWindow = time.Minute
+	// samplingRate keeps 1 in N identical lines within a window after the
+	// first occurrence
+	samplingRate = 10
+	// coordinatePrecision rounds coordinate attributes for grouping, so
+	// nearby-but-not-identical requests still share a window (~1 km)
+	coordinatePrecision = "%.2f"
+)
+
+// coordinateAttrs are the attribute keys rounded when building a sampling key
+var coordinateAttrs = map[string]bool{
+	"latitude":  true,
+	"longitude": true,
+	"lat":       true,
+	"lon":       true,
+}
+
+// SamplingHandler wraps another slog.Handler and suppresses bursts of
+// identical lines. Lines are grouped by level, message, and their attributes
+// (with coordinates rounded); the first occurrence in a window passes
+// through, later ones are sampled at 1 in samplingRate, and the number of
+// suppressed lines is reported when the window closes. Records at error
+// level or above always pass through unsampled.
+type SamplingHandler struct {
+	inner slog.Handler
+	state *samplingState
+}
+
+// samplingState is shared across handlers derived via WithAttrs/WithGroup so
+// a burst is recognized no matter which component logger emits it
+type samplingState struct {
+	mu      sync.Mutex
+	now     func() time.Time // injectable for tests
+	windows map[string]*sampleWindow
+}
+
+// sampleWindow tracks one group of identical lines
+type sampleWindow struct {
+	start      time.Time
+	seen       int // occurrences after the first
+	suppressed int
+}
+
+// NewSamplingHandler wraps inner with burst suppression.
+func NewSamplingHandler(inner slog.Handler) *SamplingHandler {
+	return &SamplingHandler{
+		inner: inner,
+		state: &samplingState{
+			now:     time.Now,
+			windows: map[string]*sampleWindow{},
+		},
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the level
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle passes the record through, drops it, or additionally reports a
+// closed window's suppression count, per the sampling policy.
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelError {
+		return h.inner.Handle(ctx, r)
+	}
+
+	key := samplingKey(r)
+
+	h.state.mu.Lock()
+	now := h.state.now()
+	window, ok := h.state.windows[key]
+	if ok && now.Sub(window.start) >= samplingWindow {
+		suppressed := window.suppressed
+		delete(h.state.windows, key)
+		h.state.mu.Unlock()
+		if suppressed > 0 {
+			if err := h.reportSuppressed(ctx, r, suppressed); err != nil {
+				return err
+			}
+		}
+		ok = false
+		h.state.mu.Lock()
+	}
+	if !ok {
+		h.state.windows[key] = &sampleWindow{start: now}
+		h.state.mu.Unlock()
+		return h.inner.Handle(ctx, r)
+	}
+
+	window.seen++
+	if window.seen%samplingRate == 0 {
+		h.state.mu.Unlock()
+		return h.inner.Handle(ctx, r)
+	}
+	window.suppressed++
+	h.state.mu.Unlock()
+	return nil
+}
+
+// WithAttrs derives a handler carrying the attributes; sampling state stays
+// shared
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{inner: h.inner.WithAttrs(attrs), state: h.state}
+}
+
+// WithGroup derives a handler opening the group; sampling state stays shared
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{inner: h.inner.WithGroup(name), state: h.state}
+}
+
+// reportSuppressed emits the closed window's counter at the record's level,
+// keeping the original message so the summary lands next to its lines
+func (h *SamplingHandler) reportSuppressed(ctx context.Context, r slog.Record, suppressed int) error {
+	summary := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	summary.AddAttrs(
+		slog.Int("suppressedRepeats", suppressed),
+		slog.Duration("samplingWindow", samplingWindow),
+	)
+	return h.inner.Handle(ctx, summary)
+}
+
+// samplingKey groups records by level, message, and attributes, rounding
+// coordinate attributes so a burst for one location shares a window
+func samplingKey(r slog.Record) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s", r.Level, r.Message)
+	r.Attrs(func(attr slog.Attr) bool {
+		value := attr.Value.Resolve()
+		if coordinateAttrs[attr.Key] && value.Kind() == slog.KindFloat64 {
+			fmt.Fprintf(&b, "|%s="+coordinatePrecision, attr.Key, value.Float64())
+			return true
+		}
+		fmt.Fprintf(&b, "|%s=%s", attr.Key, value)
+		return true
+	})
+	return b.String()
+}
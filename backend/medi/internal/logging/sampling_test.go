@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// captureHandler records every record it is handed
+type captureHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler      { return h }
+
+func (h *captureHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.records)
+}
+
+func (h *captureHandler) last() slog.Record {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.records[len(h.records)-1]
+}
+
+// newSamplingTestLogger wires a sampling handler over a capture handler with
+// a controllable clock
+func newSamplingTestLogger() (*slog.Logger, *captureHandler, *time.Time) {
+	captured := &captureHandler{}
+	sampler := NewSamplingHandler(captured)
+	currentTime := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+	sampler.state.now = func() time.Time { return currentTime }
+	return slog.New(sampler), captured, &currentTime
+}
+
+func TestSamplingHandlerSuppressesIdenticalBurst(t *testing.T) {
+	logger, captured, _ := newSamplingTestLogger()
+
+	for i := 0; i < 100; i++ {
+		logger.Debug("serving forecast", "latitude", 39.11539, "longitude", -107.65840)
+	}
+
+	// The first line passes, then 1 in 10 of the 99 repeats
+	if got := captured.count(); got != 10 {
+		t.Errorf("captured %d of 100 identical lines, want 10", got)
+	}
+}
+
+func TestSamplingHandlerReportsSuppressedWhenWindowCloses(t *testing.T) {
+	logger, captured, currentTime := newSamplingTestLogger()
+
+	for i := 0; i < 100; i++ {
+		logger.Debug("serving forecast", "latitude", 39.11539, "longitude", -107.65840)
+	}
+	*currentTime = currentTime.Add(samplingWindow)
+	logger.Debug("serving forecast", "latitude", 39.11539, "longitude", -107.65840)
+
+	// 10 sampled lines, the suppression summary, and the fresh window's first
+	if got := captured.count(); got != 12 {
+		t.Fatalf("captured %d lines, want 12", got)
+	}
+
+	var suppressed int64 = -1
+	captured.records[10].Attrs(func(attr slog.Attr) bool {
+		if attr.Key == "suppressedRepeats" {
+			suppressed = attr.Value.Int64()
+		}
+		return true
+	})
+	if suppressed != 90 {
+		t.Errorf("summary reported %d suppressed lines, want 90", suppressed)
+	}
+}
+
+func TestSamplingHandlerNeverSamplesErrors(t *testing.T) {
+	logger, captured, _ := newSamplingTestLogger()
+
+	for i := 0; i < 100; i++ {
+		logger.Error("provider request failed", "latitude", 39.11539, "longitude", -107.65840)
+	}
+
+	if got := captured.count(); got != 100 {
+		t.Errorf("captured %d of 100 error lines, want all of them", got)
+	}
+}
+
+func TestSamplingHandlerKeysOnRoundedCoordinates(t *testing.T) {
+	logger, captured, _ := newSamplingTestLogger()
+
+	// Within rounding distance: shares the first line's window
+	logger.Debug("serving forecast", "latitude", 39.11100, "longitude", -107.65840)
+	logger.Debug("serving forecast", "latitude", 39.11200, "longitude", -107.65840)
+	if got := captured.count(); got != 1 {
+		t.Errorf("captured %d lines for nearby coordinates, want 1", got)
+	}
+
+	// A different location opens its own window
+	logger.Debug("serving forecast", "latitude", 40.0, "longitude", -105.0)
+	if got := captured.count(); got != 2 {
+		t.Errorf("captured %d lines after a new location, want 2", got)
+	}
+
+	// A different message opens its own window too
+	logger.Debug("serving hourly forecast", "latitude", 39.11100, "longitude", -107.65840)
+	if got := captured.count(); got != 3 {
+		t.Errorf("captured %d lines after a new message, want 3", got)
+	}
+}
+
+func TestSamplingHandlerSharesStateAcrossDerivedLoggers(t *testing.T) {
+	logger, captured, _ := newSamplingTestLogger()
+
+	logger.Debug("serving forecast", "latitude", 39.11539, "longitude", -107.65840)
+	derived := logger.With("component", "weather")
+	derived.Debug("serving forecast", "latitude", 39.11539, "longitude", -107.65840)
+
+	if got := captured.count(); got != 1 {
+		t.Errorf("captured %d lines across derived loggers, want 1", got)
+	}
+}
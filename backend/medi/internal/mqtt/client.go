@@ -0,0 +1,82 @@
+// Package mqtt wraps the paho MQTT client for publishing retained forecast
+// updates to home automation brokers.
+package mqtt
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"medi/internal/config"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	connectTimeout = 10 * time.Second
+	publishTimeout = 5 * time.Second
+	// disconnectQuiesceMs is how long Disconnect waits for in-flight work
+	disconnectQuiesceMs = 250
+)
+
+type Client struct {
+	client      paho.Client
+	logger      *slog.Logger
+	qos         byte
+	statusTopic string
+}
+
+// NewClient connects to the configured broker. The connection registers a
+// last-will message so subscribers see "offline" on the status topic if the
+// process dies without a clean shutdown, and publishes "online" on every
+// (re)connect.
+func NewClient(cfg config.MQTTConfig, logger *slog.Logger) (*Client, error) {
+	qos := byte(cfg.QoS)
+	statusTopic := cfg.TopicPrefix + "/status"
+
+	opts := paho.NewClientOptions().
+		AddBroker(cfg.BrokerURL).
+		SetClientID("medi-snow-api").
+		SetAutoReconnect(true).
+		SetWill(statusTopic, "offline", qos, true)
+	if cfg.Username != "" {
+		opts.SetUsername(cfg.Username)
+		opts.SetPassword(cfg.Password)
+	}
+	opts.SetOnConnectHandler(func(c paho.Client) {
+		c.Publish(statusTopic, qos, true, "online")
+	})
+
+	client := paho.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		return nil, fmt.Errorf("timed out connecting to MQTT broker %s", cfg.BrokerURL)
+	}
+	if token.Error() != nil {
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s: %w", cfg.BrokerURL, token.Error())
+	}
+
+	return &Client{
+		client:      client,
+		logger:      logger.With("component", "mqtt-client"),
+		qos:         qos,
+		statusTopic: statusTopic,
+	}, nil
+}
+
+// Publish sends a message at the configured QoS
+func (c *Client) Publish(topic string, payload []byte, retained bool) error {
+	token := c.client.Publish(topic, c.qos, retained, payload)
+	if !token.WaitTimeout(publishTimeout) {
+		return fmt.Errorf("timed out publishing to %s", topic)
+	}
+	return token.Error()
+}
+
+// Close publishes the offline status and disconnects cleanly
+func (c *Client) Close() {
+	if err := c.Publish(c.statusTopic, []byte("offline"), true); err != nil {
+		c.logger.Warn("failed to publish offline status", "error", err)
+	}
+	c.client.Disconnect(disconnectQuiesceMs)
+}
@@ -0,0 +1,49 @@
+// Package preferences resolves client presentation preferences from layered
+// sources. The order, most specific first, is query parameters, then a saved
+// location's preferences, then the API key's preferences, then the configured
+// defaults. Key and location storage do not exist yet; handlers resolve query
+// parameters over the configured defaults today, and the middle layers slot
+// in here without touching each handler once their storage lands.
+package preferences
+
+// Units values
+const (
+	UnitsImperial = "imperial"
+	UnitsMetric   = "metric"
+)
+
+// Preferences holds the presentation preferences a client may set. Zero
+// values mean "no preference at this layer" and defer to the next layer.
+type Preferences struct {
+	// Units prunes responses to one unit system's figures; empty keeps both
+	Units string
+	// Language is the BCP 47 tag for localized text, e.g. "fr"
+	Language string
+	// Profile selects the thresholds profile for the snow-focused derived
+	// blocks, by configured profile name
+	Profile string
+}
+
+// IsZero reports whether no preference is set at this layer
+func (p Preferences) IsZero() bool {
+	return p == Preferences{}
+}
+
+// Resolve merges preference layers field by field, ordered most specific
+// first; for each field the first layer that set it wins. Callers pass the
+// layers as query > location > key > config default.
+func Resolve(layers ...Preferences) Preferences {
+	var resolved Preferences
+	for _, layer := range layers {
+		if resolved.Units == "" {
+			resolved.Units = layer.Units
+		}
+		if resolved.Language == "" {
+			resolved.Language = layer.Language
+		}
+		if resolved.Profile == "" {
+			resolved.Profile = layer.Profile
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,73 @@
+package preferences
+
+import "testing"
+
+func TestResolveLayerOrder(t *testing.T) {
+	query := Preferences{Units: UnitsMetric}
+	location := Preferences{Units: UnitsImperial, Language: "fr"}
+	key := Preferences{Units: UnitsImperial, Language: "de", Profile: "powder-hound"}
+	defaults := Preferences{Units: UnitsImperial, Language: "en", Profile: "conservative"}
+
+	tests := []struct {
+		name   string
+		layers []Preferences
+		want   Preferences
+	}{
+		{
+			name:   "no layers",
+			layers: nil,
+			want:   Preferences{},
+		},
+		{
+			name:   "defaults alone",
+			layers: []Preferences{defaults},
+			want:   defaults,
+		},
+		{
+			name:   "empty layers fall through to defaults",
+			layers: []Preferences{{}, {}, {}, defaults},
+			want:   defaults,
+		},
+		{
+			name:   "query wins per field, unset fields fall through",
+			layers: []Preferences{query, location, key, defaults},
+			want:   Preferences{Units: UnitsMetric, Language: "fr", Profile: "powder-hound"},
+		},
+		{
+			name:   "location beats key and defaults",
+			layers: []Preferences{{}, location, key, defaults},
+			want:   Preferences{Units: UnitsImperial, Language: "fr", Profile: "powder-hound"},
+		},
+		{
+			name:   "key beats defaults",
+			layers: []Preferences{{}, {}, key, defaults},
+			want:   key,
+		},
+		{
+			name:   "each field can come from a different layer",
+			layers: []Preferences{{Language: "es"}, {Profile: "powder-hound"}, {}, defaults},
+			want:   Preferences{Units: UnitsImperial, Language: "es", Profile: "powder-hound"},
+		},
+		{
+			name:   "no layer sets a field",
+			layers: []Preferences{{Language: "es"}, {Language: "fr"}},
+			want:   Preferences{Language: "es"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Resolve(tc.layers...); got != tc.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	if !(Preferences{}).IsZero() {
+		t.Error("empty preferences should be zero")
+	}
+	if (Preferences{Units: UnitsMetric}).IsZero() {
+		t.Error("preferences with units set should not be zero")
+	}
+}
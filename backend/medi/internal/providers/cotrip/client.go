@@ -0,0 +1,86 @@
+package cotrip
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"medi/internal/providers/decode"
+	"net/http"
+	"net/url"
+)
+
+// API Docs: https://www.cotrip.org/help/api.html
+const baseURL = "https://data.cotrip.org/api/v1/roadConditions"
+
+// Colorado bounding box for coverage detection.
+const (
+	coverageMinLat = 36.99
+	coverageMaxLat = 41.00
+	coverageMinLon = -109.06
+	coverageMaxLon = -102.04
+)
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	logger     *slog.Logger
+}
+
+func NewClient(apiKey string, logger *slog.Logger) *Client {
+	return &Client{
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		logger:     logger.With("component", "cotrip-client"),
+	}
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// Covers reports whether the coordinates fall inside Colorado, the extent of
+// the COtrip feed.
+func (c *Client) Covers(latitude, longitude float64) bool {
+	return latitude >= coverageMinLat && latitude <= coverageMaxLat &&
+		longitude >= coverageMinLon && longitude <= coverageMaxLon
+}
+
+// GetRoadConditions fetches the statewide road condition segments.
+func (c *Client) GetRoadConditions() (*RoadConditionsAPIResponse, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("apiKey", c.apiKey)
+	u.RawQuery = q.Encode()
+
+	c.logger.Debug("fetching COtrip road conditions")
+
+	resp, err := c.httpClient.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("fetch returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp RoadConditionsAPIResponse
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &apiResp); err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug("successfully fetched COtrip road conditions", "segment_count", len(apiResp.Features))
+
+	return &apiResp, nil
+}
@@ -0,0 +1,77 @@
+package cotrip
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RoadConditionsAPIResponse is the GeoJSON FeatureCollection returned by the
+// COtrip road conditions endpoint.
+type RoadConditionsAPIResponse struct {
+	Type     string                 `json:"type"`
+	Features []RoadConditionFeature `json:"features"`
+}
+
+type RoadConditionFeature struct {
+	Type       string                  `json:"type"`
+	Properties RoadConditionProperties `json:"properties"`
+	Geometry   RoadConditionGeometry   `json:"geometry"`
+}
+
+type RoadConditionProperties struct {
+	Id        string `json:"id"`
+	RouteName string `json:"routeName"`
+	// NameId is the human-readable segment name, e.g. "I-70: Eisenhower Tunnel"
+	NameId            string             `json:"nameId"`
+	CurrentConditions []CurrentCondition `json:"currentConditions"`
+	LastUpdated       string             `json:"lastUpdated"` // RFC 3339
+}
+
+type CurrentCondition struct {
+	ConditionId          int    `json:"conditionId"`
+	ConditionDescription string `json:"conditionDescription"`
+}
+
+// RoadConditionGeometry handles both LineString and MultiLineString GeoJSON
+// types, flattening the vertices for proximity checks.
+type RoadConditionGeometry struct {
+	Type string `json:"type"`
+	// points stores all vertices as [lon, lat] regardless of geometry type.
+	points [][2]float64
+}
+
+func (g *RoadConditionGeometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	g.Type = raw.Type
+
+	switch raw.Type {
+	case "LineString":
+		var line [][2]float64
+		if err := json.Unmarshal(raw.Coordinates, &line); err != nil {
+			return fmt.Errorf("failed to unmarshal LineString coordinates: %w", err)
+		}
+		g.points = line
+	case "MultiLineString":
+		var lines [][][2]float64
+		if err := json.Unmarshal(raw.Coordinates, &lines); err != nil {
+			return fmt.Errorf("failed to unmarshal MultiLineString coordinates: %w", err)
+		}
+		for _, line := range lines {
+			g.points = append(g.points, line...)
+		}
+	default:
+		return fmt.Errorf("unsupported geometry type %q", raw.Type)
+	}
+	return nil
+}
+
+// Points returns all segment vertices as [lon, lat] pairs.
+func (g *RoadConditionGeometry) Points() [][2]float64 {
+	return g.points
+}
@@ -0,0 +1,72 @@
+package cotrip
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestRoadConditionGeometryUnmarshalLineString(t *testing.T) {
+	data := []byte(`{"type":"LineString","coordinates":[[-105.9,39.6],[-105.8,39.7]]}`)
+
+	var g RoadConditionGeometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	points := g.Points()
+	if len(points) != 2 {
+		t.Fatalf("Expected 2 points, got %d", len(points))
+	}
+	if points[0][0] != -105.9 || points[0][1] != 39.6 {
+		t.Errorf("Expected first point [-105.9, 39.6], got %v", points[0])
+	}
+}
+
+func TestRoadConditionGeometryUnmarshalMultiLineString(t *testing.T) {
+	data := []byte(`{"type":"MultiLineString","coordinates":[[[-106.2,39.5],[-106.1,39.55]],[[-106.1,39.55],[-106.0,39.6]]]}`)
+
+	var g RoadConditionGeometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(g.Points()) != 4 {
+		t.Fatalf("Expected 4 flattened points, got %d", len(g.Points()))
+	}
+}
+
+func TestRoadConditionGeometryUnmarshalUnsupported(t *testing.T) {
+	data := []byte(`{"type":"Point","coordinates":[-105.9,39.6]}`)
+
+	var g RoadConditionGeometry
+	if err := json.Unmarshal(data, &g); err == nil {
+		t.Fatal("Expected an error for unsupported geometry type")
+	}
+}
+
+func TestCovers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client := NewClient("", logger)
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      bool
+	}{
+		{"Loveland Pass, CO", 39.6633, -105.8794, true},
+		{"Durango, CO", 37.2753, -107.8801, true},
+		{"Salt Lake City, UT", 40.7608, -111.8910, false},
+		{"Jackson, WY", 43.4799, -110.7624, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.Covers(tt.latitude, tt.longitude); got != tt.want {
+				t.Errorf("Covers(%f, %f) = %v, want %v", tt.latitude, tt.longitude, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package decode bounds JSON decoding of provider responses. A misbehaving
+// upstream or an interposed proxy can return an enormous payload or an HTML
+// error page; every provider client funnels its response body through JSON so
+// neither is buffered blindly.
+package decode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxBytes caps response bodies for providers without a larger
+// declared limit.
+const DefaultMaxBytes int64 = 1 << 20 // 1 MB
+
+// snippetBytes is how much of a non-JSON body is quoted in the error.
+const snippetBytes = 200
+
+// ErrResponseTooLarge flags a response body over the caller's byte limit.
+var ErrResponseTooLarge = errors.New("response body exceeds size limit")
+
+// ErrEmptyBody flags a response that carried no body at all. Upstreams under
+// load occasionally send a 200 with nothing behind it.
+var ErrEmptyBody = errors.New("empty response body")
+
+// ErrTruncatedBody flags a body cut off mid-JSON.
+var ErrTruncatedBody = errors.New("truncated response body")
+
+// JSON streams the response body into v, reading at most maxBytes bytes. A
+// larger body fails with an error wrapping ErrResponseTooLarge. Bodies whose
+// Content-Type is not JSON are not decoded at all; the error quotes the start
+// of the body so proxy error pages are recognizable in logs. A non-positive
+// maxBytes falls back to DefaultMaxBytes.
+func JSON(resp *http.Response, maxBytes int64, v any) error {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); !jsonContentType(contentType) {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, snippetBytes))
+		return fmt.Errorf("expected JSON response, got content type %q: %s",
+			contentType, strings.TrimSpace(string(snippet)))
+	}
+
+	// One byte past the limit distinguishes an exactly-full body from an
+	// oversized one
+	reader := &countingReader{reader: io.LimitReader(resp.Body, maxBytes+1)}
+	if err := json.NewDecoder(reader).Decode(v); err != nil {
+		if reader.read > maxBytes {
+			return fmt.Errorf("%w (%d bytes allowed)", ErrResponseTooLarge, maxBytes)
+		}
+		// The decoder reports a missing body as EOF and a cut-off one as
+		// unexpected EOF; both get typed errors so clients can retry them
+		if errors.Is(err, io.EOF) && reader.read == 0 {
+			return ErrEmptyBody
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w after %d bytes", ErrTruncatedBody, reader.read)
+		}
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if reader.read > maxBytes {
+		return fmt.Errorf("%w (%d bytes allowed)", ErrResponseTooLarge, maxBytes)
+	}
+	return nil
+}
+
+// jsonContentType accepts application/json and structured syntaxes like
+// application/geo+json. A missing header is tolerated; some upstreams omit it.
+func jsonContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json") ||
+		mediaType == "text/json"
+}
+
+// countingReader tracks how many bytes the decoder pulled so an oversized
+// body is told apart from plain malformed JSON.
+type countingReader struct {
+	reader io.Reader
+	read   int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.read += int64(n)
+	return n, err
+}
@@ -0,0 +1,132 @@
+package decode
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testPayload struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// get performs a GET against a handler and returns the live response.
+func get(t *testing.T, handler http.HandlerFunc) *http.Response {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestJSONDecodesWithinLimit(t *testing.T) {
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "aspen", "count": 3}`))
+	})
+
+	var payload testPayload
+	if err := JSON(resp, 64, &payload); err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	if payload.Name != "aspen" || payload.Count != 3 {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestJSONAcceptsStructuredSyntaxes(t *testing.T) {
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/geo+json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"name": "zones"}`))
+	})
+
+	var payload testPayload
+	if err := JSON(resp, 0, &payload); err != nil {
+		t.Fatalf("JSON rejected geo+json: %v", err)
+	}
+}
+
+func TestJSONOversizedBody(t *testing.T) {
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "` + strings.Repeat("x", 1024) + `"}`))
+	})
+
+	var payload testPayload
+	err := JSON(resp, 64, &payload)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("err = %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestJSONHTMLErrorPage(t *testing.T) {
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	})
+
+	var payload testPayload
+	err := JSON(resp, 64, &payload)
+	if err == nil {
+		t.Fatal("expected an error for an HTML body, got nil")
+	}
+	if !strings.Contains(err.Error(), "text/html") {
+		t.Errorf("error %q does not name the content type", err)
+	}
+	if !strings.Contains(err.Error(), "502 Bad Gateway") {
+		t.Errorf("error %q does not quote the body snippet", err)
+	}
+}
+
+func TestJSONMalformedBodyWithinLimit(t *testing.T) {
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": `))
+	})
+
+	var payload testPayload
+	err := JSON(resp, 64, &payload)
+	if err == nil {
+		t.Fatal("expected a decode error, got nil")
+	}
+	if errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("malformed small body misreported as too large: %v", err)
+	}
+}
+
+func TestJSONEmptyBody(t *testing.T) {
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+	})
+
+	var payload testPayload
+	if err := JSON(resp, 64, &payload); !errors.Is(err, ErrEmptyBody) {
+		t.Fatalf("err = %v, want ErrEmptyBody", err)
+	}
+}
+
+func TestJSONTruncatedBodyReportsBytes(t *testing.T) {
+	body := `{"name": "asp`
+	resp := get(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})
+
+	var payload testPayload
+	err := JSON(resp, 64, &payload)
+	if !errors.Is(err, ErrTruncatedBody) {
+		t.Fatalf("err = %v, want ErrTruncatedBody", err)
+	}
+	if want := "after 13 bytes"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not report %q", err, want)
+	}
+}
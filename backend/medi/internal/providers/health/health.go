@@ -0,0 +1,196 @@
+// Package health records the outcome of recent provider calls in bounded
+// per-provider ring buffers, so the health endpoint can answer "is this
+// upstream flaky right now?" instead of only "is it reachable?". The registry
+// routes each live client through an instrumented HTTP transport that feeds
+// the buffers; stats are computed over a sliding window when asked.
+package health
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// windowCalls caps how many calls each provider's buffer retains
+	windowCalls = 100
+	// windowAge is how long a retained call counts toward the stats
+	windowAge = 30 * time.Minute
+)
+
+// call is one recorded provider round trip
+type call struct {
+	at         time.Time
+	ok         bool
+	statusCode int
+	latency    time.Duration
+}
+
+// ring holds a provider's most recent calls in a fixed-capacity buffer;
+// writing past the capacity overwrites the oldest entry
+type ring struct {
+	calls [windowCalls]call
+	next  int
+	size  int
+}
+
+func (r *ring) add(c call) {
+	r.calls[r.next] = c
+	r.next = (r.next + 1) % windowCalls
+	if r.size < windowCalls {
+		r.size++
+	}
+}
+
+// recent returns the retained calls oldest first
+func (r *ring) recent() []call {
+	out := make([]call, 0, r.size)
+	start := r.next - r.size
+	if start < 0 {
+		start += windowCalls
+	}
+	for i := 0; i < r.size; i++ {
+		out = append(out, r.calls[(start+i)%windowCalls])
+	}
+	return out
+}
+
+// Stats summarizes one provider's calls over the retained window.
+type Stats struct {
+	// Calls is how many calls the window holds
+	Calls int
+	// SuccessRate is the fraction of windowed calls that succeeded, 0 to 1
+	SuccessRate float64
+	// P95LatencyMs is the 95th-percentile round-trip latency in milliseconds
+	P95LatencyMs int64
+	// ConsecutiveFailures counts the unbroken run of failures ending at the
+	// most recent call; zero whenever the latest call succeeded
+	ConsecutiveFailures int
+}
+
+// Recorder aggregates per-provider call history. All methods are safe for
+// concurrent use; memory is bounded at windowCalls entries per provider.
+type Recorder struct {
+	mu      sync.Mutex
+	buffers map[string]*ring
+	now     func() time.Time
+}
+
+// NewRecorder builds an empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		buffers: make(map[string]*ring),
+		now:     time.Now,
+	}
+}
+
+// SetClock overrides the time source, for tests
+func (r *Recorder) SetClock(now func() time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.now = now
+}
+
+// Client returns an HTTP client whose every request is recorded against the
+// named provider. The instrumented transport delegates to the default
+// transport, so connection pooling behaves as with a plain client.
+func (r *Recorder) Client(provider string) *http.Client {
+	return &http.Client{
+		Transport: &transport{recorder: r, provider: provider, base: http.DefaultTransport},
+	}
+}
+
+// Snapshot computes the current stats for every provider with at least one
+// call inside the window. Calls older than the window age are excluded even
+// while the buffer still holds them.
+func (r *Recorder) Snapshot() map[string]Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := r.now().Add(-windowAge)
+	snapshot := make(map[string]Stats, len(r.buffers))
+	for provider, buffer := range r.buffers {
+		var windowed []call
+		for _, c := range buffer.recent() {
+			if c.at.After(cutoff) {
+				windowed = append(windowed, c)
+			}
+		}
+		if len(windowed) == 0 {
+			continue
+		}
+		snapshot[provider] = computeStats(windowed)
+	}
+	return snapshot
+}
+
+// record appends one call outcome to the provider's buffer
+func (r *Recorder) record(provider string, ok bool, statusCode int, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buffer, exists := r.buffers[provider]
+	if !exists {
+		buffer = &ring{}
+		r.buffers[provider] = buffer
+	}
+	buffer.add(call{at: r.now(), ok: ok, statusCode: statusCode, latency: latency})
+}
+
+// clock reads the current time under the lock
+func (r *Recorder) clock() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.now()
+}
+
+// computeStats reduces a windowed slice of calls, ordered oldest first
+func computeStats(windowed []call) Stats {
+	stats := Stats{Calls: len(windowed)}
+
+	successes := 0
+	latencies := make([]time.Duration, 0, len(windowed))
+	for _, c := range windowed {
+		if c.ok {
+			successes++
+		}
+		latencies = append(latencies, c.latency)
+	}
+	stats.SuccessRate = float64(successes) / float64(len(windowed))
+
+	for i := len(windowed) - 1; i >= 0 && !windowed[i].ok; i-- {
+		stats.ConsecutiveFailures++
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rank := (95*len(latencies) + 99) / 100 // ceil, nearest-rank percentile
+	stats.P95LatencyMs = latencies[rank-1].Milliseconds()
+
+	return stats
+}
+
+// transport records the outcome of every round trip against one provider. A
+// response counts as a success unless the transport itself failed or the
+// upstream answered with a server error or a 429; client errors are this
+// service's fault, not the provider's health.
+type transport struct {
+	recorder *Recorder
+	provider string
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := t.recorder.clock()
+	resp, err := t.base.RoundTrip(req)
+	latency := t.recorder.clock().Sub(start)
+
+	statusCode := 0
+	ok := false
+	if err == nil {
+		statusCode = resp.StatusCode
+		ok = statusCode < http.StatusInternalServerError && statusCode != http.StatusTooManyRequests
+	}
+	t.recorder.record(t.provider, ok, statusCode, latency)
+	return resp, err
+}
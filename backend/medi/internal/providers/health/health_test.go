@@ -0,0 +1,171 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// popStatusServer serves the given status codes in order, repeating the last
+// one once the list is exhausted
+func popStatusServer(t *testing.T, codes []int) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	served := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		code := codes[len(codes)-1]
+		if served < len(codes) {
+			code = codes[served]
+		}
+		served++
+		mu.Unlock()
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecorderComputesWindowStats(t *testing.T) {
+	server := popStatusServer(t, []int{200, 200, 500, 200, 429, 503})
+
+	recorder := NewRecorder()
+	current := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	recorder.SetClock(func() time.Time { return current })
+
+	client := recorder.Client("openmeteo")
+	for i := 0; i < 6; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	stats, ok := recorder.Snapshot()["openmeteo"]
+	if !ok {
+		t.Fatal("no stats recorded for openmeteo")
+	}
+	if stats.Calls != 6 {
+		t.Errorf("calls = %d, want 6", stats.Calls)
+	}
+	// 200, 200, 200 succeed; 500, 429, 503 do not
+	if stats.SuccessRate != 0.5 {
+		t.Errorf("success rate = %v, want 0.5", stats.SuccessRate)
+	}
+	// The window ends 429, 503
+	if stats.ConsecutiveFailures != 2 {
+		t.Errorf("consecutive failures = %d, want 2", stats.ConsecutiveFailures)
+	}
+}
+
+func TestRecorderTransportErrorCountsAsFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	recorder := NewRecorder()
+	client := recorder.Client("usgs")
+	if _, err := client.Get(url); err == nil {
+		t.Fatal("expected request against a closed server to fail")
+	}
+
+	stats := recorder.Snapshot()["usgs"]
+	if stats.Calls != 1 || stats.SuccessRate != 0 {
+		t.Errorf("stats = %+v, want one failed call", stats)
+	}
+	if stats.ConsecutiveFailures != 1 {
+		t.Errorf("consecutive failures = %d, want 1", stats.ConsecutiveFailures)
+	}
+}
+
+func TestRecorderBufferOverwritesOldestCalls(t *testing.T) {
+	recorder := NewRecorder()
+	current := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	recorder.SetClock(func() time.Time { return current })
+
+	// 50 failures pushed out by 100 successes: only the successes remain
+	for i := 0; i < 50; i++ {
+		recorder.record("nws", false, 503, 0)
+	}
+	for i := 0; i < windowCalls; i++ {
+		recorder.record("nws", true, 200, 0)
+	}
+
+	stats := recorder.Snapshot()["nws"]
+	if stats.Calls != windowCalls {
+		t.Errorf("calls = %d, want the %d-entry cap", stats.Calls, windowCalls)
+	}
+	if stats.SuccessRate != 1 {
+		t.Errorf("success rate = %v, want 1 once the failures were overwritten", stats.SuccessRate)
+	}
+}
+
+func TestRecorderAgesOutOldCalls(t *testing.T) {
+	recorder := NewRecorder()
+	current := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	recorder.SetClock(func() time.Time { return current })
+
+	for i := 0; i < 10; i++ {
+		recorder.record("nac", false, 500, 0)
+	}
+	recorder.record("cotrip", false, 500, 0)
+
+	// Half an hour later the failures no longer count against either provider
+	current = current.Add(windowAge + time.Minute)
+	for i := 0; i < 5; i++ {
+		recorder.record("nac", true, 200, 0)
+	}
+
+	snapshot := recorder.Snapshot()
+	stats := snapshot["nac"]
+	if stats.Calls != 5 {
+		t.Errorf("calls = %d, want only the 5 recent ones", stats.Calls)
+	}
+	if stats.SuccessRate != 1 {
+		t.Errorf("success rate = %v, want 1 with the failures aged out", stats.SuccessRate)
+	}
+	if stats.ConsecutiveFailures != 0 {
+		t.Errorf("consecutive failures = %d, want 0", stats.ConsecutiveFailures)
+	}
+	if _, ok := snapshot["cotrip"]; ok {
+		t.Error("provider with every call aged out still reported")
+	}
+}
+
+func TestRecorderP95Latency(t *testing.T) {
+	recorder := NewRecorder()
+	for i := 1; i <= 100; i++ {
+		recorder.record("metno", true, 200, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := recorder.Snapshot()["metno"]
+	if stats.P95LatencyMs != 95 {
+		t.Errorf("p95 latency = %dms, want 95ms over 1..100ms", stats.P95LatencyMs)
+	}
+}
+
+func TestRecorderConcurrentRecording(t *testing.T) {
+	recorder := NewRecorder()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				recorder.record("openmeteo", true, 200, time.Millisecond)
+				recorder.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := recorder.Snapshot()["openmeteo"]
+	if stats.Calls != windowCalls {
+		t.Errorf("calls = %d, want the buffer full at %d", stats.Calls, windowCalls)
+	}
+}
@@ -0,0 +1,86 @@
+package metno
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"medi/internal/providers/decode"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// API Docs: https://api.met.no/weatherapi/locationforecast/2.0/documentation
+// Sample request: https://api.met.no/weatherapi/locationforecast/2.0/compact?lat=39.12&lon=-107.66&altitude=2900
+const (
+	baseCompactURL = "https://api.met.no/weatherapi/locationforecast/2.0/compact"
+	// MET Norway's terms of service require an identifying User-Agent;
+	// anonymous requests are rejected
+	userAgent = "medi-snow github.com/albernhagen/medi-snow"
+)
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	logger     *slog.Logger
+}
+
+func NewClient(logger *slog.Logger) *Client {
+	return &Client{
+		httpClient: &http.Client{},
+		baseURL:    baseCompactURL,
+		logger:     logger.With("component", "metno-client"),
+	}
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// GetForecast fetches the compact location forecast for the given latitude,
+// longitude, and elevation in meters
+func (c *Client) GetForecast(ctx context.Context, latitude, longitude, elevationMeters float64) (*CompactAPIResponse, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	q := u.Query()
+	// The API truncates coordinates to four decimals and rejects more
+	q.Set("lat", fmt.Sprintf("%.4f", latitude))
+	q.Set("lon", fmt.Sprintf("%.4f", longitude))
+	q.Set("altitude", strconv.Itoa(int(elevationMeters)))
+	u.RawQuery = q.Encode()
+
+	fullUrl := u.String()
+	c.logger.Debug("fetching fallback forecast", "url", fullUrl)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("fetch returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp CompactAPIResponse
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp, nil
+}
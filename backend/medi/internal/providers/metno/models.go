@@ -0,0 +1,56 @@
+package metno
+
+// CompactAPIResponse is the Locationforecast 2.0 compact response: a GeoJSON
+// Feature whose properties carry the forecast timeseries.
+type CompactAPIResponse struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"` // [longitude, latitude, altitude]
+	} `json:"geometry"`
+	Properties struct {
+		Meta struct {
+			UpdatedAt string            `json:"updated_at"`
+			Units     map[string]string `json:"units"`
+		} `json:"meta"`
+		Timeseries []TimeStep `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// TimeStep is one entry of the forecast timeseries. Near-term entries are
+// hourly and carry Next1Hours; further out the API switches to 6-hour steps.
+type TimeStep struct {
+	Time string       `json:"time"`
+	Data TimeStepData `json:"data"`
+}
+
+type TimeStepData struct {
+	Instant struct {
+		Details InstantDetails `json:"details"`
+	} `json:"instant"`
+	Next1Hours *NextHours `json:"next_1_hours"`
+	Next6Hours *NextHours `json:"next_6_hours"`
+}
+
+// InstantDetails holds the instantaneous values valid at the step's time.
+// Units are metric: celsius, m/s, percent.
+type InstantDetails struct {
+	AirPressureAtSeaLevel float64  `json:"air_pressure_at_sea_level"`
+	AirTemperature        float64  `json:"air_temperature"`
+	CloudAreaFraction     float64  `json:"cloud_area_fraction"`
+	RelativeHumidity      float64  `json:"relative_humidity"`
+	WindFromDirection     float64  `json:"wind_from_direction"`
+	WindSpeed             float64  `json:"wind_speed"`
+	WindSpeedOfGust       *float64 `json:"wind_speed_of_gust"`
+}
+
+// NextHours describes the period following the step's time: a symbol code
+// like "lightsnow" or "partlycloudy_day" and the precipitation in mm.
+type NextHours struct {
+	Summary struct {
+		SymbolCode string `json:"symbol_code"`
+	} `json:"summary"`
+	Details struct {
+		PrecipitationAmount float64 `json:"precipitation_amount"`
+	} `json:"details"`
+}
@@ -0,0 +1,266 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"medi/internal/providers/nac"
+)
+
+// AvalancheClient is a mock implementation of the avalanche service's
+// MapLayerProvider and ForecastProvider interfaces.
+type AvalancheClient struct {
+	client
+}
+
+// NewAvalancheClient creates a mock avalanche client.
+func NewAvalancheClient(logger *slog.Logger, opts Options) *AvalancheClient {
+	return &AvalancheClient{
+		client: newClient(logger, opts, "mock-avalanche-client"),
+	}
+}
+
+// mockZone describes a synthetic forecast zone polygon.
+type mockZone struct {
+	id       int
+	name     string
+	centerId string
+	// bounding box: [minLon, minLat, maxLon, maxLat]
+	bounds [4]float64
+}
+
+// mockZones carve the western US into a few rectangular zones so most mountain
+// coordinates fall inside one of them.
+var mockZones = []mockZone{
+	{id: 1001, name: "Mock Northern Mountains", centerId: "MOCK", bounds: [4]float64{-125, 43, -104, 49}},
+	{id: 1002, name: "Mock Central Mountains", centerId: "MOCK", bounds: [4]float64{-125, 37, -104, 43}},
+	{id: 1003, name: "Mock Southern Mountains", centerId: "MOCK", bounds: [4]float64{-125, 31, -104, 37}},
+}
+
+// GetMapLayer returns a synthetic GeoJSON map layer with the mock zones.
+// The response is assembled as JSON so the custom geometry unmarshaling in the
+// nac package populates its unexported polygon data.
+func (c *AvalancheClient) GetMapLayer() (*nac.MapLayerResponse, error) {
+	if err := c.simulateCall("map layer"); err != nil {
+		return nil, err
+	}
+
+	features := make([]map[string]interface{}, 0, len(mockZones))
+	for _, zone := range mockZones {
+		ring := [][2]float64{
+			{zone.bounds[0], zone.bounds[1]},
+			{zone.bounds[2], zone.bounds[1]},
+			{zone.bounds[2], zone.bounds[3]},
+			{zone.bounds[0], zone.bounds[3]},
+			{zone.bounds[0], zone.bounds[1]},
+		}
+		features = append(features, map[string]interface{}{
+			"id":   zone.id,
+			"type": "Feature",
+			"properties": map[string]interface{}{
+				"name":      zone.name,
+				"center_id": zone.centerId,
+				"link":      fmt.Sprintf("https://example.com/forecasts/%d", zone.id),
+			},
+			"geometry": map[string]interface{}{
+				"type":        "Polygon",
+				"coordinates": [][][2]float64{ring},
+			},
+		})
+	}
+
+	raw := map[string]interface{}{
+		"type":     "FeatureCollection",
+		"features": features,
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal synthetic map layer: %w", err)
+	}
+
+	var apiResp nac.MapLayerResponse
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode synthetic map layer: %w", err)
+	}
+
+	return &apiResp, nil
+}
+
+// GetForecast generates a deterministic synthetic avalanche forecast for the
+// given center and zone.
+func (c *AvalancheClient) GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error) {
+	if err := c.simulateCall("forecast"); err != nil {
+		return nil, err
+	}
+
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s/%d", centerId, zoneId)
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	now := time.Now().UTC()
+
+	var apiResp nac.ForecastResponse
+	apiResp.Id = zoneId
+	apiResp.PublishedTime = now.Add(-time.Duration(rng.Intn(12)) * time.Hour)
+	apiResp.ExpiresTime = apiResp.PublishedTime.Add(24 * time.Hour)
+	apiResp.Author = "Mock Forecaster"
+	apiResp.ProductType = "forecast"
+	apiResp.BottomLine = "<p>Synthetic avalanche forecast generated by the mock provider.</p>"
+	apiResp.HazardDiscussion = "<p>Conditions in this forecast are synthetic and deterministic per zone.</p>"
+	apiResp.Status = "published"
+	apiResp.AvalancheCenter.Id = centerId
+	apiResp.AvalancheCenter.Name = "Mock Avalanche Center"
+	apiResp.AvalancheCenter.Url = "https://example.com"
+	apiResp.AvalancheCenter.City = "Mockville"
+	apiResp.AvalancheCenter.State = "CO"
+
+	base := 1 + rng.Intn(3)
+	apiResp.Danger = append(apiResp.Danger, struct {
+		Lower    int    `json:"lower"`
+		Upper    int    `json:"upper"`
+		Middle   int    `json:"middle"`
+		ValidDay string `json:"valid_day"`
+	}{
+		Lower:    base,
+		Middle:   base,
+		Upper:    minInt(base+1, 5),
+		ValidDay: "current",
+	})
+
+	problemNames := []string{"Wind Slab", "Storm Slab", "Persistent Slab", "Loose Dry"}
+	likelihoods := []string{"unlikely", "possible", "likely", "very likely"}
+	problemCount := 1 + rng.Intn(2)
+	for i := 0; i < problemCount; i++ {
+		apiResp.ForecastAvalancheProblems = append(apiResp.ForecastAvalancheProblems, mockProblem(
+			apiResp.Id,
+			i+1,
+			problemNames[rng.Intn(len(problemNames))],
+			likelihoods[rng.Intn(len(likelihoods))],
+		))
+	}
+
+	apiResp.ForecastZone = append(apiResp.ForecastZone, struct {
+		Id     int         `json:"id"`
+		Name   string      `json:"name"`
+		Url    string      `json:"url"`
+		State  string      `json:"state"`
+		ZoneId string      `json:"zone_id"`
+		Config interface{} `json:"config"`
+	}{
+		Id:     zoneId,
+		Name:   fmt.Sprintf("Mock Zone %d", zoneId),
+		Url:    fmt.Sprintf("https://example.com/forecasts/%d", zoneId),
+		State:  "CO",
+		ZoneId: fmt.Sprintf("%d", zoneId),
+	})
+
+	return &apiResp, nil
+}
+
+// mockProblem builds a single synthetic avalanche problem entry matching the
+// anonymous struct layout in nac.ForecastResponse.
+func mockProblem(forecastId, rank int, name, likelihood string) struct {
+	Id                 int    `json:"id"`
+	ForecastId         int    `json:"forecast_id"`
+	AvalancheProblemId int    `json:"avalanche_problem_id"`
+	Rank               int    `json:"rank"`
+	Likelihood         string `json:"likelihood"`
+	Discussion         string `json:"discussion"`
+	Media              struct {
+		Url     json.RawMessage `json:"url"`
+		Type    string          `json:"type"`
+		Title   interface{}     `json:"title"`
+		Caption string          `json:"caption"`
+	} `json:"media"`
+	Location           []string `json:"location"`
+	Size               []string `json:"size"`
+	Name               string   `json:"name"`
+	ProblemDescription string   `json:"problem_description"`
+	Icon               string   `json:"icon"`
+} {
+	return struct {
+		Id                 int    `json:"id"`
+		ForecastId         int    `json:"forecast_id"`
+		AvalancheProblemId int    `json:"avalanche_problem_id"`
+		Rank               int    `json:"rank"`
+		Likelihood         string `json:"likelihood"`
+		Discussion         string `json:"discussion"`
+		Media              struct {
+			Url     json.RawMessage `json:"url"`
+			Type    string          `json:"type"`
+			Title   interface{}     `json:"title"`
+			Caption string          `json:"caption"`
+		} `json:"media"`
+		Location           []string `json:"location"`
+		Size               []string `json:"size"`
+		Name               string   `json:"name"`
+		ProblemDescription string   `json:"problem_description"`
+		Icon               string   `json:"icon"`
+	}{
+		Id:         forecastId*10 + rank,
+		ForecastId: forecastId,
+		Rank:       rank,
+		Likelihood: likelihood,
+		Discussion: "<p>Synthetic avalanche problem.</p>",
+		Location:   []string{"north upper", "northeast upper", "east middle"},
+		Size:       []string{"1", "2"},
+		Name:       name,
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// GetAccidents returns a small deterministic accident dataset spread across
+// the mock zones. One record per zone omits coordinates to exercise the
+// zone-level fallback counting.
+func (c *AvalancheClient) GetAccidents() ([]nac.Accident, error) {
+	if err := c.simulateCall("accidents"); err != nil {
+		return nil, err
+	}
+
+	season := time.Now().UTC().Year()
+	accidents := make([]nac.Accident, 0, len(mockZones)*3)
+	for _, zone := range mockZones {
+		h := fnv.New64a()
+		_, _ = fmt.Fprintf(h, "accidents/%d", zone.id)
+		rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+		centerLat := (zone.bounds[1] + zone.bounds[3]) / 2
+		centerLon := (zone.bounds[0] + zone.bounds[2]) / 2
+		for i := 0; i < 3; i++ {
+			date := time.Date(season, time.January, 1, 0, 0, 0, 0, time.UTC).
+				AddDate(0, 0, rng.Intn(90)-45)
+			accident := nac.Accident{
+				Id:           fmt.Sprintf("mock-%d-%d", zone.id, i),
+				Date:         date.Format("2006-01-02"),
+				LocationName: fmt.Sprintf("%s Site %d", zone.name, i+1),
+				State:        "CO",
+				CenterId:     zone.centerId,
+				ForecastZone: zone.name,
+				Caught:       1 + rng.Intn(3),
+				Buried:       rng.Intn(2),
+				Killed:       rng.Intn(2),
+				Url:          fmt.Sprintf("https://example.com/accidents/mock-%d-%d", zone.id, i),
+			}
+			if i < 2 {
+				lat := centerLat + rng.Float64() - 0.5
+				lon := centerLon + rng.Float64() - 0.5
+				accident.Latitude = &lat
+				accident.Longitude = &lon
+			}
+			accidents = append(accidents, accident)
+		}
+	}
+
+	return accidents, nil
+}
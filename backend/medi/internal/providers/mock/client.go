@@ -0,0 +1,71 @@
+// Package mock provides deterministic, self-contained implementations of the
+// provider interfaces consumed by the domain services. It exists so the API can
+// be load tested (or demoed offline) without hammering the real upstream APIs.
+//
+// All synthetic data is generated from a PRNG seeded by the input coordinates,
+// so the same latitude/longitude always yields the same response. Optional
+// timing jitter and a configurable error rate approximate real provider
+// behavior under load.
+package mock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// Options configures the behavior shared by all mock clients.
+type Options struct {
+	// Jitter is the maximum artificial latency added to each call.
+	// Zero disables jitter entirely.
+	Jitter time.Duration
+	// ErrorRate is the probability (0.0-1.0) that a call fails with a
+	// synthetic error. Zero disables errors entirely.
+	ErrorRate float64
+}
+
+// client holds the shared state embedded by the concrete mock clients.
+type client struct {
+	opts   Options
+	logger *slog.Logger
+	// failRng drives the error-rate and jitter rolls. It is intentionally
+	// separate from the data generators so failures do not perturb the
+	// deterministic coordinate-seeded output.
+	failRng *rand.Rand
+}
+
+func newClient(logger *slog.Logger, opts Options, component string) client {
+	return client{
+		opts:    opts,
+		logger:  logger.With("component", component),
+		failRng: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// simulateCall applies jitter and rolls the error rate. It returns a synthetic
+// error when the roll fails.
+func (c *client) simulateCall(operation string) error {
+	if c.opts.Jitter > 0 {
+		time.Sleep(time.Duration(c.failRng.Int63n(int64(c.opts.Jitter))))
+	}
+	if c.opts.ErrorRate > 0 && c.failRng.Float64() < c.opts.ErrorRate {
+		c.logger.Debug("mock provider returning synthetic error", "operation", operation)
+		return fmt.Errorf("mock %s: synthetic provider error", operation)
+	}
+	return nil
+}
+
+// seedFor derives a stable PRNG seed from coordinates. Coordinates are rounded
+// to four decimal places (~11m) so float noise does not change the seed.
+func seedFor(latitude, longitude float64) int64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%.4f,%.4f", latitude, longitude)
+	return int64(h.Sum64())
+}
+
+// rngFor returns a PRNG deterministically seeded from coordinates.
+func rngFor(latitude, longitude float64) *rand.Rand {
+	return rand.New(rand.NewSource(seedFor(latitude, longitude)))
+}
@@ -0,0 +1,140 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func testLogger() *slog.Logger {
+	return slog.Default()
+}
+
+func TestWeatherClientDeterministicForecast(t *testing.T) {
+	c := NewWeatherClient(testLogger(), Options{})
+
+	first, err := c.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 16, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	second, err := c.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 16, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	firstJSON, err := json.Marshal(first)
+	if err != nil {
+		t.Fatalf("failed to marshal first forecast: %v", err)
+	}
+	secondJSON, err := json.Marshal(second)
+	if err != nil {
+		t.Fatalf("failed to marshal second forecast: %v", err)
+	}
+
+	if string(firstJSON) != string(secondJSON) {
+		t.Error("same coordinates produced different forecasts")
+	}
+
+	if len(first.Hourly.Time) != 16*24 {
+		t.Errorf("Hourly.Time has %d entries, want %d", len(first.Hourly.Time), 16*24)
+	}
+	if len(first.Daily.Time) != 16 {
+		t.Errorf("Daily.Time has %d entries, want 16", len(first.Daily.Time))
+	}
+	if first.Timezone != "America/Denver" {
+		t.Errorf("Timezone = %q, want America/Denver", first.Timezone)
+	}
+	if len(first.Hourly.Temperature2MGfsSeamless) != 16*24 {
+		t.Errorf("GFS temperature has %d entries, want %d", len(first.Hourly.Temperature2MGfsSeamless), 16*24)
+	}
+}
+
+func TestWeatherClientDifferentCoordinatesDiffer(t *testing.T) {
+	c := NewWeatherClient(testLogger(), Options{})
+
+	aspen, err := c.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 2, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+	jackson, err := c.GetForecast(context.Background(), 43.4799, -110.7624, 1900, 2, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	same := true
+	for i := range aspen.Hourly.Temperature2MGfsSeamless {
+		if aspen.Hourly.Temperature2MGfsSeamless[i] != jackson.Hourly.Temperature2MGfsSeamless[i] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("different coordinates produced identical temperature series")
+	}
+}
+
+func TestErrorRate(t *testing.T) {
+	t.Run("error rate 1.0 always fails", func(t *testing.T) {
+		c := NewLocationClient(testLogger(), Options{ErrorRate: 1.0})
+		for i := 0; i < 10; i++ {
+			if _, err := c.GetElevationPoint(39.0, -107.0); err == nil {
+				t.Fatal("expected synthetic error, got nil")
+			}
+		}
+	})
+
+	t.Run("error rate 0 never fails", func(t *testing.T) {
+		c := NewLocationClient(testLogger(), Options{})
+		for i := 0; i < 10; i++ {
+			if _, err := c.GetElevationPoint(39.0, -107.0); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+	})
+}
+
+func TestAvalancheClientMapLayerContainsZones(t *testing.T) {
+	c := NewAvalancheClient(testLogger(), Options{})
+
+	mapLayer, err := c.GetMapLayer()
+	if err != nil {
+		t.Fatalf("GetMapLayer returned error: %v", err)
+	}
+
+	if len(mapLayer.Features) != len(mockZones) {
+		t.Fatalf("map layer has %d features, want %d", len(mapLayer.Features), len(mockZones))
+	}
+
+	for _, feature := range mapLayer.Features {
+		if len(feature.Geometry.Coordinates()) == 0 {
+			t.Errorf("feature %d has no polygon coordinates", feature.Id)
+		}
+	}
+}
+
+func TestAvalancheClientDeterministicForecast(t *testing.T) {
+	c := NewAvalancheClient(testLogger(), Options{})
+
+	first, err := c.GetForecast("MOCK", 1002)
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+	second, err := c.GetForecast("MOCK", 1002)
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	if len(first.Danger) != len(second.Danger) {
+		t.Fatal("same zone produced different danger rating counts")
+	}
+	for i := range first.Danger {
+		if first.Danger[i] != second.Danger[i] {
+			t.Errorf("danger rating %d differs between calls", i)
+		}
+	}
+	if len(first.ForecastAvalancheProblems) != len(second.ForecastAvalancheProblems) {
+		t.Error("same zone produced different problem counts")
+	}
+}
@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"fmt"
+	"log/slog"
+
+	"medi/internal/providers/openstreetmap"
+	"medi/internal/providers/usgs"
+)
+
+// LocationClient is a mock implementation of the location service's
+// ElevationProvider and ReverseGeocodeProvider interfaces.
+type LocationClient struct {
+	client
+}
+
+// NewLocationClient creates a mock location client.
+func NewLocationClient(logger *slog.Logger, opts Options) *LocationClient {
+	return &LocationClient{
+		client: newClient(logger, opts, "mock-location-client"),
+	}
+}
+
+// GetElevationPoint generates a deterministic synthetic elevation for the
+// given coordinates.
+func (c *LocationClient) GetElevationPoint(latitude, longitude float64) (*usgs.ElevationPointAPIResponse, error) {
+	if err := c.simulateCall("elevation point"); err != nil {
+		return nil, err
+	}
+
+	rng := rngFor(latitude, longitude)
+
+	var apiResp usgs.ElevationPointAPIResponse
+	apiResp.Location.X = longitude
+	apiResp.Location.Y = latitude
+	// Mountain-ish elevations in feet
+	apiResp.Value = 6000 + rng.Float64()*8000
+	apiResp.Resolution = 10
+	return &apiResp, nil
+}
+
+// Lookup generates a deterministic synthetic reverse geocode result for the
+// given coordinates.
+func (c *LocationClient) Lookup(latitude, longitude float64) (*openstreetmap.LookupAPIResponse, error) {
+	if err := c.simulateCall("lookup"); err != nil {
+		return nil, err
+	}
+
+	rng := rngFor(latitude, longitude)
+
+	names := []string{"Mock Peak", "Mock Pass", "Mock Basin", "Mock Bowl", "Mock Ridge"}
+	counties := []string{"Summit County", "Pitkin County", "San Miguel County", "Gunnison County"}
+
+	var apiResp openstreetmap.LookupAPIResponse
+	apiResp.PlaceId = rng.Intn(1000000)
+	apiResp.OsmType = "node"
+	apiResp.Lat = fmt.Sprintf("%.7f", latitude)
+	apiResp.Lon = fmt.Sprintf("%.7f", longitude)
+	apiResp.Class = "natural"
+	apiResp.Type = "peak"
+	apiResp.Name = names[rng.Intn(len(names))]
+	apiResp.Address.County = counties[rng.Intn(len(counties))]
+	apiResp.Address.State = "Colorado"
+	apiResp.Address.Country = "United States"
+	apiResp.Address.CountryCode = "us"
+	apiResp.DisplayName = fmt.Sprintf("%s, %s, %s, %s",
+		apiResp.Name, apiResp.Address.County, apiResp.Address.State, apiResp.Address.Country)
+	return &apiResp, nil
+}
@@ -0,0 +1,434 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"medi/internal/providers/nac"
+	"medi/internal/providers/openmeteo"
+
+	"github.com/spf13/viper"
+)
+
+// A Scenario scripts a weather storyline for demos: a baseline climate plus
+// ordered segments that turn snowfall on and ramp wind and temperature toward
+// targets, with a matching avalanche danger progression. Unlike the
+// coordinate-seeded mock data, every location gets the same storyline, so a
+// demo can be rehearsed.
+type Scenario struct {
+	Name string
+	// DurationHours bounds the storyline; hours past it stay calm at the
+	// last scripted values
+	DurationHours int
+	// BaseTempF and BaseWindMph set the climate before the first segment
+	BaseTempF   float64
+	BaseWindMph float64
+	Segments    []ScenarioSegment
+	Avalanche   ScenarioAvalanche
+}
+
+// ScenarioSegment is one storyline beat over [FromHour, ToHour). Snowfall
+// holds a constant rate; wind and temperature ramp linearly from their value
+// at FromHour to the segment's target, or hold when no target is given.
+type ScenarioSegment struct {
+	FromHour      int
+	ToHour        int
+	SnowInPerHour float64
+	// WindMph and TempF are the values reached at ToHour
+	WindMph *float64
+	TempF   *float64
+}
+
+// ScenarioAvalanche scripts the synthetic avalanche forecast
+type ScenarioAvalanche struct {
+	// DangerByDay names the overall danger per forecast day, e.g.
+	// [considerable, high, high]; the last entry holds for later days
+	DangerByDay []string
+	BottomLine  string
+}
+
+// scenarioDangerLevels maps the danger names a scenario may use onto the
+// North American 0-5 scale
+var scenarioDangerLevels = map[string]int{
+	"none":         0,
+	"low":          1,
+	"moderate":     2,
+	"considerable": 3,
+	"high":         4,
+	"extreme":      5,
+}
+
+// LoadScenario reads and validates a YAML scenario file
+func LoadScenario(path string) (*Scenario, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+	var scenario Scenario
+	if err := v.Unmarshal(&scenario); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario file: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %q: %w", scenario.Name, err)
+	}
+	return &scenario, nil
+}
+
+// Validate checks the storyline is coherent: ordered non-overlapping segments
+// inside the duration, non-negative snowfall, and known danger names
+func (s *Scenario) Validate() error {
+	if s.DurationHours <= 0 {
+		return fmt.Errorf("durationHours must be positive, got %d", s.DurationHours)
+	}
+	previousEnd := 0
+	for i, segment := range s.Segments {
+		if segment.ToHour <= segment.FromHour {
+			return fmt.Errorf("segment %d runs %d-%d, want fromHour < toHour", i, segment.FromHour, segment.ToHour)
+		}
+		if segment.FromHour < previousEnd {
+			return fmt.Errorf("segment %d starts at hour %d before the previous segment ends at %d", i, segment.FromHour, previousEnd)
+		}
+		if segment.ToHour > s.DurationHours {
+			return fmt.Errorf("segment %d ends at hour %d past the %d hour duration", i, segment.ToHour, s.DurationHours)
+		}
+		if segment.SnowInPerHour < 0 {
+			return fmt.Errorf("segment %d has negative snowfall rate", i)
+		}
+		previousEnd = segment.ToHour
+	}
+	for _, name := range s.Avalanche.DangerByDay {
+		if _, ok := scenarioDangerLevels[strings.ToLower(name)]; !ok {
+			names := make([]string, 0, len(scenarioDangerLevels))
+			for known := range scenarioDangerLevels {
+				names = append(names, known)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unknown danger level %q, want one of %v", name, names)
+		}
+	}
+	return nil
+}
+
+// script expands the storyline into per-hour snowfall rate, wind, and
+// temperature series covering the requested horizon
+func (s *Scenario) script(hours int) (snow, wind, temp []float64) {
+	snow = make([]float64, hours)
+	wind = make([]float64, hours)
+	temp = make([]float64, hours)
+
+	currentWind := s.BaseWindMph
+	currentTemp := s.BaseTempF
+	segmentIndex := 0
+	for h := 0; h < hours; h++ {
+		for segmentIndex < len(s.Segments) && h >= s.Segments[segmentIndex].ToHour {
+			segment := s.Segments[segmentIndex]
+			if segment.WindMph != nil {
+				currentWind = *segment.WindMph
+			}
+			if segment.TempF != nil {
+				currentTemp = *segment.TempF
+			}
+			segmentIndex++
+		}
+
+		wind[h] = currentWind
+		temp[h] = currentTemp
+		if segmentIndex < len(s.Segments) {
+			segment := s.Segments[segmentIndex]
+			if h >= segment.FromHour {
+				snow[h] = segment.SnowInPerHour
+				progress := float64(h-segment.FromHour+1) / float64(segment.ToHour-segment.FromHour)
+				if segment.WindMph != nil {
+					wind[h] = currentWind + (*segment.WindMph-currentWind)*progress
+				}
+				if segment.TempF != nil {
+					temp[h] = currentTemp + (*segment.TempF-currentTemp)*progress
+				}
+			}
+		}
+	}
+	return snow, wind, temp
+}
+
+// dangerForDay resolves the scripted danger level for a forecast day,
+// holding the last entry once the script runs out
+func (s *Scenario) dangerForDay(day int) int {
+	if len(s.Avalanche.DangerByDay) == 0 {
+		return scenarioDangerLevels["moderate"]
+	}
+	if day >= len(s.Avalanche.DangerByDay) {
+		day = len(s.Avalanche.DangerByDay) - 1
+	}
+	return scenarioDangerLevels[strings.ToLower(s.Avalanche.DangerByDay[day])]
+}
+
+// ScenarioWeatherClient serves the scripted storyline through the weather
+// provider interfaces. The discussion endpoints come from the embedded mock
+// client unchanged.
+type ScenarioWeatherClient struct {
+	WeatherClient
+	scenario *Scenario
+}
+
+// NewScenarioWeatherClient creates a weather client scripted by the scenario
+func NewScenarioWeatherClient(logger *slog.Logger, opts Options, scenario *Scenario) *ScenarioWeatherClient {
+	return &ScenarioWeatherClient{
+		WeatherClient: WeatherClient{client: newClient(logger, opts, "scenario-weather-client")},
+		scenario:      scenario,
+	}
+}
+
+// modelRngFor seeds the inter-model spread from the scenario name and model
+// suffix, so reruns of a demo stay identical
+func modelRngFor(scenarioName, suffix string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s/%s", scenarioName, suffix)
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// GetForecast expands the storyline into full per-model hourly arrays. Models
+// disagree by a stable offset and scale — a degree or two of temperature, a
+// few percent of snowfall, and a touch of wind — so ensemble spread charts
+// look plausible without obscuring the scripted storm.
+func (c *ScenarioWeatherClient) GetForecast(_ context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error) {
+	if err := c.simulateCall("scenario forecast"); err != nil {
+		return nil, err
+	}
+
+	if timezone == "" {
+		timezone = "GMT"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone location %s: %w", timezone, err)
+	}
+
+	now := time.Now().In(location)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	hours := forecastDays * 24
+
+	hourlyTime := make([]string, hours)
+	for i := 0; i < hours; i++ {
+		hourlyTime[i] = dayStart.Add(time.Duration(i) * time.Hour).Format("2006-01-02T15:04")
+	}
+	dailyTime := make([]string, forecastDays)
+	for i := 0; i < forecastDays; i++ {
+		dailyTime[i] = dayStart.AddDate(0, 0, i).Format("2006-01-02")
+	}
+
+	snowScript, windScript, tempScript := c.scenario.script(hours)
+
+	raw := map[string]interface{}{
+		"latitude":              latitude,
+		"longitude":             longitude,
+		"generationtime_ms":     1.0,
+		"utc_offset_seconds":    0,
+		"timezone":              timezone,
+		"timezone_abbreviation": timezone,
+		"elevation":             elevationMeters,
+	}
+	hourly := map[string]interface{}{"time": hourlyTime}
+	daily := map[string]interface{}{"time": dailyTime}
+
+	nowIndex := int(now.Sub(dayStart).Hours())
+	if nowIndex >= hours {
+		nowIndex = hours - 1
+	}
+	current := map[string]interface{}{
+		"time":     hourlyTime[nowIndex],
+		"interval": 900,
+	}
+
+	for _, suffix := range modelSuffixes {
+		rng := modelRngFor(c.scenario.Name, suffix)
+		tempOffset := rng.Float64()*4 - 2 // ±2 °F
+		snowFactor := 0.9 + rng.Float64()*0.2
+		windFactor := 0.9 + rng.Float64()*0.2
+
+		temps := make([]float64, hours)
+		apparent := make([]float64, hours)
+		freezingLevel := make([]float64, hours)
+		isDay := make([]int, hours)
+		weatherCode := make([]int, hours)
+		precipProb := make([]int, hours)
+		precip := make([]float64, hours)
+		cloud := make([]int, hours)
+		cloudLow := make([]int, hours)
+		cloudMid := make([]int, hours)
+		cloudHigh := make([]int, hours)
+		visibility := make([]float64, hours)
+		windSpeed := make([]float64, hours)
+		windDir := make([]int, hours)
+		windGusts := make([]float64, hours)
+		humidity := make([]int, hours)
+		rain := make([]float64, hours)
+		showers := make([]float64, hours)
+		snowfall := make([]float64, hours)
+		snowDepth := make([]float64, hours)
+
+		depth := 2.0 // feet of base
+		for i := 0; i < hours; i++ {
+			hourOfDay := i % 24
+			diurnal := 6 * math.Sin(float64(hourOfDay-9)*math.Pi/12)
+
+			temps[i] = tempScript[i] + tempOffset + diurnal + rng.Float64()
+			apparent[i] = temps[i] - 3 - rng.Float64()*3
+			freezingLevel[i] = 5000 + (temps[i]-20)*250
+			if hourOfDay >= 7 && hourOfDay <= 17 {
+				isDay[i] = 1
+			}
+
+			snowfall[i] = snowScript[i] * snowFactor
+			precip[i] = snowfall[i] / 10
+			switch {
+			case snowfall[i] >= 0.25:
+				weatherCode[i] = 75
+			case snowfall[i] >= 0.1:
+				weatherCode[i] = 73
+			case snowfall[i] > 0:
+				weatherCode[i] = 71
+			default:
+				weatherCode[i] = []int{0, 1, 2}[rng.Intn(3)]
+			}
+			if snowfall[i] > 0 {
+				precipProb[i] = 80 + rng.Intn(20)
+				cloud[i] = 85 + rng.Intn(15)
+				humidity[i] = 80 + rng.Intn(15)
+			} else {
+				precipProb[i] = rng.Intn(20)
+				cloud[i] = rng.Intn(50)
+				humidity[i] = 40 + rng.Intn(30)
+			}
+			depth += snowfall[i] / 12
+
+			cloudLow[i] = cloud[i] * (50 + rng.Intn(50)) / 100
+			cloudMid[i] = cloud[i] * (30 + rng.Intn(50)) / 100
+			cloudHigh[i] = cloud[i] * (20 + rng.Intn(50)) / 100
+			visibility[i] = 120000 - float64(cloud[i])*1000
+			windSpeed[i] = windScript[i] * windFactor
+			windDir[i] = 270 + rng.Intn(40) - 20 // storms ride the westerlies
+			windGusts[i] = windSpeed[i] * (1.3 + rng.Float64()*0.4)
+			snowDepth[i] = depth
+		}
+
+		hourly["freezing_level_height_"+suffix] = freezingLevel
+		hourly["is_day_"+suffix] = isDay
+		hourly["temperature_2m_"+suffix] = temps
+		hourly["weather_code_"+suffix] = weatherCode
+		hourly["apparent_temperature_"+suffix] = apparent
+		hourly["precipitation_probability_"+suffix] = precipProb
+		hourly["precipitation_"+suffix] = precip
+		hourly["cloud_cover_"+suffix] = cloud
+		hourly["cloud_cover_low_"+suffix] = cloudLow
+		hourly["cloud_cover_mid_"+suffix] = cloudMid
+		hourly["cloud_cover_high_"+suffix] = cloudHigh
+		hourly["visibility_"+suffix] = visibility
+		hourly["wind_speed_10m_"+suffix] = windSpeed
+		hourly["wind_direction_10m_"+suffix] = windDir
+		hourly["wind_gusts_10m_"+suffix] = windGusts
+		hourly["relative_humidity_2m_"+suffix] = humidity
+		hourly["rain_"+suffix] = rain
+		hourly["showers_"+suffix] = showers
+		hourly["snowfall_"+suffix] = snowfall
+		hourly["snow_depth_"+suffix] = snowDepth
+
+		dailyWeatherCode := make([]int, forecastDays)
+		dailySwe := make([]float64, forecastDays)
+		sunrise := make([]string, forecastDays)
+		sunset := make([]string, forecastDays)
+		dominantDir := make([]int, forecastDays)
+		for d := 0; d < forecastDays; d++ {
+			dailyWeatherCode[d] = weatherCode[d*24+12]
+			for h := 0; h < 24; h++ {
+				dailySwe[d] += snowfall[d*24+h] / 10
+			}
+			day := dayStart.AddDate(0, 0, d)
+			sunrise[d] = day.Add(7 * time.Hour).Format("2006-01-02T15:04")
+			sunset[d] = day.Add(17 * time.Hour).Format("2006-01-02T15:04")
+			dominantDir[d] = windDir[d*24+12]
+		}
+
+		daily["weather_code_"+suffix] = dailyWeatherCode
+		daily["snowfall_water_equivalent_sum_"+suffix] = dailySwe
+		daily["sunrise_"+suffix] = sunrise
+		daily["sunset_"+suffix] = sunset
+		daily["wind_direction_10m_dominant_"+suffix] = dominantDir
+
+		current["temperature_2m_"+suffix] = temps[nowIndex]
+		current["weather_code_"+suffix] = weatherCode[nowIndex]
+		current["wind_speed_10m_"+suffix] = windSpeed[nowIndex]
+		current["wind_direction_10m_"+suffix] = windDir[nowIndex]
+		current["wind_gusts_10m_"+suffix] = windGusts[nowIndex]
+		current["cloud_cover_"+suffix] = cloud[nowIndex]
+		current["relative_humidity_2m_"+suffix] = humidity[nowIndex]
+	}
+
+	raw["hourly"] = hourly
+	raw["daily"] = daily
+	raw["current"] = current
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scenario forecast: %w", err)
+	}
+	var apiResp openmeteo.ForecastAPIResponse
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario forecast: %w", err)
+	}
+	return &apiResp, nil
+}
+
+// ScenarioAvalancheClient serves the scripted danger progression; the map
+// layer and accident dataset come from the embedded mock client unchanged
+type ScenarioAvalancheClient struct {
+	AvalancheClient
+	scenario *Scenario
+}
+
+// NewScenarioAvalancheClient creates an avalanche client scripted by the
+// scenario
+func NewScenarioAvalancheClient(logger *slog.Logger, opts Options, scenario *Scenario) *ScenarioAvalancheClient {
+	return &ScenarioAvalancheClient{
+		AvalancheClient: AvalancheClient{client: newClient(logger, opts, "scenario-avalanche-client")},
+		scenario:        scenario,
+	}
+}
+
+// GetForecast builds the synthetic avalanche forecast with the scripted
+// danger for today and tomorrow instead of the mock's random levels
+func (c *ScenarioAvalancheClient) GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error) {
+	apiResp, err := c.AvalancheClient.GetForecast(centerId, zoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiResp.Danger = apiResp.Danger[:0]
+	for day, validDay := range []string{"current", "tomorrow"} {
+		level := c.scenario.dangerForDay(day)
+		apiResp.Danger = append(apiResp.Danger, struct {
+			Lower    int    `json:"lower"`
+			Upper    int    `json:"upper"`
+			Middle   int    `json:"middle"`
+			ValidDay string `json:"valid_day"`
+		}{
+			Lower:    level,
+			Middle:   level,
+			Upper:    level,
+			ValidDay: validDay,
+		})
+	}
+	if c.scenario.Avalanche.BottomLine != "" {
+		apiResp.BottomLine = "<p>" + c.scenario.Avalanche.BottomLine + "</p>"
+	}
+	return apiResp, nil
+}
@@ -0,0 +1,248 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"medi/internal/providers/openmeteo"
+)
+
+// demoScenarioPath is the committed atmospheric river storyline the round-trip
+// tests replay
+const demoScenarioPath = "../../../scenarios/atmospheric-river.yaml"
+
+func loadDemoScenario(t *testing.T) *Scenario {
+	t.Helper()
+	scenario, err := LoadScenario(demoScenarioPath)
+	if err != nil {
+		t.Fatalf("failed to load committed scenario: %v", err)
+	}
+	return scenario
+}
+
+func TestLoadScenarioParsesCommittedStoryline(t *testing.T) {
+	scenario := loadDemoScenario(t)
+
+	if scenario.Name != "48h atmospheric river" {
+		t.Errorf("Name = %q, want the committed storyline name", scenario.Name)
+	}
+	if scenario.DurationHours != 48 {
+		t.Errorf("DurationHours = %d, want 48", scenario.DurationHours)
+	}
+	if len(scenario.Segments) != 3 {
+		t.Fatalf("scenario has %d segments, want 3", len(scenario.Segments))
+	}
+	storm := scenario.Segments[1]
+	if storm.FromHour != 12 || storm.ToHour != 36 || storm.SnowInPerHour != 0.3 {
+		t.Errorf("storm segment = %+v, want 0.3 in/hr over hours 12-36", storm)
+	}
+	if storm.WindMph == nil || *storm.WindMph != 60 {
+		t.Errorf("storm wind target = %v, want 60 mph", storm.WindMph)
+	}
+	if len(scenario.Avalanche.DangerByDay) != 3 || scenario.Avalanche.DangerByDay[1] != "high" {
+		t.Errorf("DangerByDay = %v, want danger rising to high on day 1", scenario.Avalanche.DangerByDay)
+	}
+}
+
+func TestScenarioValidateRejectsBrokenStorylines(t *testing.T) {
+	cases := []struct {
+		name     string
+		scenario Scenario
+		want     string
+	}{
+		{
+			"zero duration",
+			Scenario{},
+			"durationHours",
+		},
+		{
+			"inverted segment",
+			Scenario{DurationHours: 24, Segments: []ScenarioSegment{{FromHour: 6, ToHour: 6}}},
+			"fromHour < toHour",
+		},
+		{
+			"overlapping segments",
+			Scenario{DurationHours: 24, Segments: []ScenarioSegment{
+				{FromHour: 0, ToHour: 12},
+				{FromHour: 10, ToHour: 20},
+			}},
+			"before the previous segment ends",
+		},
+		{
+			"segment past duration",
+			Scenario{DurationHours: 24, Segments: []ScenarioSegment{{FromHour: 12, ToHour: 30}}},
+			"past the 24 hour duration",
+		},
+		{
+			"negative snowfall",
+			Scenario{DurationHours: 24, Segments: []ScenarioSegment{{FromHour: 0, ToHour: 12, SnowInPerHour: -1}}},
+			"negative snowfall",
+		},
+		{
+			"unknown danger level",
+			Scenario{DurationHours: 24, Avalanche: ScenarioAvalanche{DangerByDay: []string{"apocalyptic"}}},
+			"unknown danger level",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.scenario.Validate()
+			if err == nil {
+				t.Fatal("broken scenario validated")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestScenarioScriptExpandsSegments(t *testing.T) {
+	scenario := loadDemoScenario(t)
+	snow, wind, temp := scenario.script(72)
+
+	// Snowfall rate holds per segment and stops past the duration
+	for hour, want := range map[int]float64{0: 0, 11: 0, 12: 0.3, 35: 0.3, 36: 0.1, 47: 0.1, 48: 0, 71: 0} {
+		if snow[hour] != want {
+			t.Errorf("snow rate at hour %d = %v, want %v", hour, snow[hour], want)
+		}
+	}
+
+	// Wind ramps linearly to each segment's target
+	if wind[11] != 25 {
+		t.Errorf("wind at hour 11 = %v, want the 25 mph first-segment target", wind[11])
+	}
+	if wind[35] != 60 {
+		t.Errorf("wind at peak hour 35 = %v, want 60", wind[35])
+	}
+	if !(wind[23] > 25 && wind[23] < 60) {
+		t.Errorf("wind mid-storm = %v, want between 25 and 60", wind[23])
+	}
+	if wind[47] != 15 {
+		t.Errorf("wind after frontal passage = %v, want 15", wind[47])
+	}
+
+	// Temperature holds the base, warms into the storm, then crashes
+	if temp[0] != 28 {
+		t.Errorf("temp at hour 0 = %v, want the 28F base", temp[0])
+	}
+	if temp[35] != 30 {
+		t.Errorf("temp at peak hour 35 = %v, want 30", temp[35])
+	}
+	if temp[47] != 14 {
+		t.Errorf("temp after frontal passage = %v, want 14", temp[47])
+	}
+}
+
+// modelSnowfall returns the hourly snowfall series for each model suffix
+func modelSnowfall(resp *openmeteo.ForecastAPIResponse) map[string][]float64 {
+	return map[string][]float64{
+		"gem_seamless":         resp.Hourly.SnowfallGemSeamless,
+		"ecmwf_ifs":            resp.Hourly.SnowfallEcmwfIfs,
+		"gfs_seamless":         resp.Hourly.SnowfallGfsSeamless,
+		"ncep_nbm_conus":       resp.Hourly.SnowfallNcepNbmConus,
+		"gfs_graphcast025":     resp.Hourly.SnowfallGfsGraphcast025,
+		"ecmwf_aifs025_single": resp.Hourly.SnowfallEcmwfAifs025Single,
+		"ncep_nam_conus":       resp.Hourly.SnowfallNcepNamConus,
+	}
+}
+
+func TestScenarioForecastDailyTotals(t *testing.T) {
+	c := NewScenarioWeatherClient(testLogger(), Options{}, loadDemoScenario(t))
+
+	resp, err := c.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 3, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+	if len(resp.Hourly.Time) != 72 {
+		t.Fatalf("Hourly.Time has %d entries, want 72", len(resp.Hourly.Time))
+	}
+
+	// The storyline scripts 3.6 in on day 0 (hours 12-23), 4.8 in on day 1
+	// (the storm tail plus the taper), and nothing on day 2; each model scales
+	// that by at most 10%
+	wantDaily := []float64{3.6, 4.8, 0}
+	for suffix, snowfall := range modelSnowfall(resp) {
+		if len(snowfall) != 72 {
+			t.Fatalf("%s snowfall has %d entries, want 72", suffix, len(snowfall))
+		}
+		for day, want := range wantDaily {
+			total := 0.0
+			for h := 0; h < 24; h++ {
+				total += snowfall[day*24+h]
+			}
+			if total < want*0.89 || total > want*1.11 {
+				t.Errorf("%s day %d snowfall = %.2f in, want %.2f within model spread", suffix, day, total, want)
+			}
+		}
+	}
+
+	// Winds build toward the scripted 60 mph peak at storm hour 35
+	peak := resp.Hourly.WindSpeed10MGfsSeamless[35]
+	if peak < 60*0.89 || peak > 60*1.11 {
+		t.Errorf("GFS peak wind = %.1f mph, want near 60", peak)
+	}
+}
+
+func TestScenarioForecastModelSpread(t *testing.T) {
+	c := NewScenarioWeatherClient(testLogger(), Options{}, loadDemoScenario(t))
+
+	resp, err := c.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 2, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	// Models must disagree, or the ensemble charts collapse to one line
+	if resp.Hourly.SnowfallGfsSeamless[18] == resp.Hourly.SnowfallGemSeamless[18] {
+		t.Error("GFS and GEM report identical snowfall mid-storm")
+	}
+
+	// The storyline is location independent: a different coordinate replays
+	// the same storm
+	elsewhere, err := c.GetForecast(context.Background(), 43.4799, -110.7624, 1900, 2, "America/Denver")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+	aJSON, _ := json.Marshal(resp.Hourly.SnowfallGfsSeamless)
+	bJSON, _ := json.Marshal(elsewhere.Hourly.SnowfallGfsSeamless)
+	if string(aJSON) != string(bJSON) {
+		t.Error("scenario snowfall differs by location")
+	}
+}
+
+func TestScenarioAvalancheForecastScriptsDanger(t *testing.T) {
+	c := NewScenarioAvalancheClient(testLogger(), Options{}, loadDemoScenario(t))
+
+	resp, err := c.GetForecast("MOCK", 1002)
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	if len(resp.Danger) != 2 {
+		t.Fatalf("forecast has %d danger entries, want current and tomorrow", len(resp.Danger))
+	}
+	if resp.Danger[0].ValidDay != "current" || resp.Danger[0].Middle != 3 {
+		t.Errorf("current danger = %+v, want considerable (3)", resp.Danger[0])
+	}
+	if resp.Danger[1].ValidDay != "tomorrow" || resp.Danger[1].Middle != 4 {
+		t.Errorf("tomorrow danger = %+v, want high (4)", resp.Danger[1])
+	}
+	if !strings.Contains(resp.BottomLine, "Danger rises to") {
+		t.Errorf("BottomLine = %q, want the scripted bottom line", resp.BottomLine)
+	}
+}
+
+func TestScenarioDangerHoldsPastScript(t *testing.T) {
+	scenario := Scenario{
+		DurationHours: 24,
+		Avalanche:     ScenarioAvalanche{DangerByDay: []string{"Moderate", "Considerable"}},
+	}
+	if got := scenario.dangerForDay(0); got != 2 {
+		t.Errorf("dangerForDay(0) = %d, want 2; names should be case insensitive", got)
+	}
+	if got := scenario.dangerForDay(5); got != 3 {
+		t.Errorf("dangerForDay(5) = %d, want the last scripted level", got)
+	}
+}
@@ -0,0 +1,269 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"time"
+
+	"medi/internal/providers/nws"
+	"medi/internal/providers/openmeteo"
+)
+
+// modelSuffixes are the Open-Meteo model name suffixes used in response field
+// names, matching the models requested by the real openmeteo client.
+var modelSuffixes = []string{
+	"gem_seamless",
+	"ecmwf_ifs",
+	"gfs_seamless",
+	"ncep_nbm_conus",
+	"gfs_graphcast025",
+	"ecmwf_aifs025_single",
+	"ncep_nam_conus",
+}
+
+// WeatherClient is a mock implementation of the weather service's
+// ForecastProvider and ForecastDiscussionProvider interfaces.
+type WeatherClient struct {
+	client
+}
+
+// NewWeatherClient creates a mock weather client.
+func NewWeatherClient(logger *slog.Logger, opts Options) *WeatherClient {
+	return &WeatherClient{
+		client: newClient(logger, opts, "mock-weather-client"),
+	}
+}
+
+// GetForecast generates a deterministic synthetic forecast for the given
+// coordinates. The response is assembled as JSON keyed by the same field names
+// the real Open-Meteo API uses, then decoded into the provider response type.
+func (c *WeatherClient) GetForecast(_ context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error) {
+	if err := c.simulateCall("forecast"); err != nil {
+		return nil, err
+	}
+
+	if timezone == "" {
+		timezone = "GMT"
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone location %s: %w", timezone, err)
+	}
+
+	rng := rngFor(latitude, longitude)
+
+	now := time.Now().In(location)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	hours := forecastDays * 24
+
+	hourlyTime := make([]string, hours)
+	for i := 0; i < hours; i++ {
+		hourlyTime[i] = dayStart.Add(time.Duration(i) * time.Hour).Format("2006-01-02T15:04")
+	}
+
+	dailyTime := make([]string, forecastDays)
+	for i := 0; i < forecastDays; i++ {
+		dailyTime[i] = dayStart.AddDate(0, 0, i).Format("2006-01-02")
+	}
+
+	raw := map[string]interface{}{
+		"latitude":              latitude,
+		"longitude":             longitude,
+		"generationtime_ms":     rng.Float64() * 10,
+		"utc_offset_seconds":    0,
+		"timezone":              timezone,
+		"timezone_abbreviation": timezone,
+		"elevation":             elevationMeters,
+	}
+
+	hourly := map[string]interface{}{"time": hourlyTime}
+	daily := map[string]interface{}{"time": dailyTime}
+
+	nowIndex := int(now.Sub(dayStart).Hours())
+	if nowIndex >= hours {
+		nowIndex = hours - 1
+	}
+	current := map[string]interface{}{
+		"time":     hourlyTime[nowIndex],
+		"interval": 900,
+	}
+
+	// Base climate for this location, shared across models so they only
+	// disagree by a small per-model offset.
+	baseTemp := 10 + rng.Float64()*30 // degrees F, cold enough to snow
+	baseWind := 3 + rng.Float64()*15  // mph
+
+	for _, suffix := range modelSuffixes {
+		modelOffset := rng.Float64()*6 - 3
+
+		temps := make([]float64, hours)
+		apparent := make([]float64, hours)
+		freezingLevel := make([]float64, hours)
+		isDay := make([]int, hours)
+		weatherCode := make([]int, hours)
+		precipProb := make([]int, hours)
+		precip := make([]float64, hours)
+		cloud := make([]int, hours)
+		cloudLow := make([]int, hours)
+		cloudMid := make([]int, hours)
+		cloudHigh := make([]int, hours)
+		visibility := make([]float64, hours)
+		windSpeed := make([]float64, hours)
+		windDir := make([]int, hours)
+		windGusts := make([]float64, hours)
+		humidity := make([]int, hours)
+		rain := make([]float64, hours)
+		showers := make([]float64, hours)
+		snowfall := make([]float64, hours)
+		snowDepth := make([]float64, hours)
+
+		depth := 1 + rng.Float64()*3 // feet
+		for i := 0; i < hours; i++ {
+			hourOfDay := i % 24
+			diurnal := 8 * math.Sin(float64(hourOfDay-9)*math.Pi/12)
+
+			temps[i] = baseTemp + modelOffset + diurnal + rng.Float64()*2
+			apparent[i] = temps[i] - 3 - rng.Float64()*4
+			freezingLevel[i] = 8000 + rng.Float64()*4000
+			if hourOfDay >= 7 && hourOfDay <= 17 {
+				isDay[i] = 1
+			}
+
+			snowing := rng.Float64() < 0.3
+			if snowing {
+				weatherCode[i] = []int{71, 73, 75, 85}[rng.Intn(4)]
+				precipProb[i] = 50 + rng.Intn(50)
+				snowfall[i] = rng.Float64() * 1.5
+				precip[i] = snowfall[i] / 10
+				cloud[i] = 70 + rng.Intn(30)
+			} else {
+				weatherCode[i] = []int{0, 1, 2, 3}[rng.Intn(4)]
+				precipProb[i] = rng.Intn(30)
+				cloud[i] = rng.Intn(70)
+			}
+			depth += snowfall[i] / 12 // inches of snowfall to feet of depth
+
+			cloudLow[i] = cloud[i] * (50 + rng.Intn(50)) / 100
+			cloudMid[i] = cloud[i] * (30 + rng.Intn(50)) / 100
+			cloudHigh[i] = cloud[i] * (20 + rng.Intn(50)) / 100
+			visibility[i] = 120000 - float64(cloud[i])*1000 + rng.Float64()*5000
+			windSpeed[i] = baseWind + rng.Float64()*10
+			windDir[i] = rng.Intn(360)
+			windGusts[i] = windSpeed[i] * (1.2 + rng.Float64()*0.8)
+			humidity[i] = 40 + rng.Intn(55)
+			snowDepth[i] = depth
+		}
+
+		hourly["freezing_level_height_"+suffix] = freezingLevel
+		hourly["is_day_"+suffix] = isDay
+		hourly["temperature_2m_"+suffix] = temps
+		hourly["weather_code_"+suffix] = weatherCode
+		hourly["apparent_temperature_"+suffix] = apparent
+		hourly["precipitation_probability_"+suffix] = precipProb
+		hourly["precipitation_"+suffix] = precip
+		hourly["cloud_cover_"+suffix] = cloud
+		hourly["cloud_cover_low_"+suffix] = cloudLow
+		hourly["cloud_cover_mid_"+suffix] = cloudMid
+		hourly["cloud_cover_high_"+suffix] = cloudHigh
+		hourly["visibility_"+suffix] = visibility
+		hourly["wind_speed_10m_"+suffix] = windSpeed
+		hourly["wind_direction_10m_"+suffix] = windDir
+		hourly["wind_gusts_10m_"+suffix] = windGusts
+		hourly["relative_humidity_2m_"+suffix] = humidity
+		hourly["rain_"+suffix] = rain
+		hourly["showers_"+suffix] = showers
+		hourly["snowfall_"+suffix] = snowfall
+		hourly["snow_depth_"+suffix] = snowDepth
+
+		dailyWeatherCode := make([]int, forecastDays)
+		dailySwe := make([]float64, forecastDays)
+		sunrise := make([]string, forecastDays)
+		sunset := make([]string, forecastDays)
+		dominantDir := make([]int, forecastDays)
+		for d := 0; d < forecastDays; d++ {
+			dailyWeatherCode[d] = weatherCode[d*24+12]
+			for h := 0; h < 24; h++ {
+				dailySwe[d] += snowfall[d*24+h] / 10
+			}
+			day := dayStart.AddDate(0, 0, d)
+			sunrise[d] = day.Add(7 * time.Hour).Format("2006-01-02T15:04")
+			sunset[d] = day.Add(17 * time.Hour).Format("2006-01-02T15:04")
+			dominantDir[d] = windDir[d*24+12]
+		}
+
+		daily["weather_code_"+suffix] = dailyWeatherCode
+		daily["snowfall_water_equivalent_sum_"+suffix] = dailySwe
+		daily["sunrise_"+suffix] = sunrise
+		daily["sunset_"+suffix] = sunset
+		daily["wind_direction_10m_dominant_"+suffix] = dominantDir
+
+		current["temperature_2m_"+suffix] = temps[nowIndex] + rng.Float64() - 0.5
+		current["weather_code_"+suffix] = weatherCode[nowIndex]
+		current["wind_speed_10m_"+suffix] = windSpeed[nowIndex]
+		current["wind_direction_10m_"+suffix] = windDir[nowIndex]
+		current["wind_gusts_10m_"+suffix] = windGusts[nowIndex]
+		current["cloud_cover_"+suffix] = cloud[nowIndex]
+		current["relative_humidity_2m_"+suffix] = humidity[nowIndex]
+	}
+
+	raw["hourly"] = hourly
+	raw["daily"] = daily
+	raw["current"] = current
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal synthetic forecast: %w", err)
+	}
+
+	var apiResp openmeteo.ForecastAPIResponse
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode synthetic forecast: %w", err)
+	}
+
+	return &apiResp, nil
+}
+
+// GetPoint generates a deterministic synthetic NWS point response.
+func (c *WeatherClient) GetPoint(latitude, longitude float64) (*nws.PointAPIResponse, error) {
+	if err := c.simulateCall("point"); err != nil {
+		return nil, err
+	}
+
+	rng := rngFor(latitude, longitude)
+
+	var apiResp nws.PointAPIResponse
+	apiResp.Id = fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", latitude, longitude)
+	apiResp.Type = "Feature"
+	apiResp.Properties.Cwa = mockOfficeFor(rng)
+	apiResp.Properties.GridId = apiResp.Properties.Cwa
+	apiResp.Properties.GridX = rng.Intn(200)
+	apiResp.Properties.GridY = rng.Intn(200)
+	return &apiResp, nil
+}
+
+// GetAreaForecastDiscussion generates a synthetic AFD product.
+func (c *WeatherClient) GetAreaForecastDiscussion(locationId string) (*nws.AFDAPIResponse, error) {
+	if err := c.simulateCall("area forecast discussion"); err != nil {
+		return nil, err
+	}
+
+	return &nws.AFDAPIResponse{
+		IssuingOffice: locationId,
+		IssuanceTime:  time.Now().UTC(),
+		ProductCode:   "AFD",
+		ProductName:   "Area Forecast Discussion",
+		ProductText: fmt.Sprintf("Area Forecast Discussion\nNational Weather Service %s\n\n"+
+			".SYNOPSIS...Synthetic forecast discussion generated by the mock provider.\n", locationId),
+	}, nil
+}
+
+// mockOfficeFor picks a stable forecast office identifier from the rng.
+func mockOfficeFor(rng *rand.Rand) string {
+	offices := []string{"GJT", "BOU", "SLC", "REV", "MSO"}
+	return offices[rng.Intn(len(offices))]
+}
@@ -1,16 +1,21 @@
 package nac
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"medi/internal/providers/decode"
 	"net/http"
 	"net/url"
 )
 
 const baseURL = "https://api.avalanche.org"
 
+// mapLayerMaxResponseBytes bounds the national map layer body, which carries
+// every forecast zone polygon and runs an order of magnitude larger than the
+// other NAC responses.
+const mapLayerMaxResponseBytes = 20 << 20
+
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
@@ -25,6 +30,12 @@ func NewClient(logger *slog.Logger) *Client {
 	}
 }
 
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
 // GetMapLayer fetches the GeoJSON map layer with all forecast zone polygons.
 func (c *Client) GetMapLayer() (*MapLayerResponse, error) {
 	u, err := url.Parse(c.baseURL)
@@ -55,9 +66,9 @@ func (c *Client) GetMapLayer() (*MapLayerResponse, error) {
 	}
 
 	var apiResp MapLayerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decode.JSON(resp, mapLayerMaxResponseBytes, &apiResp); err != nil {
 		c.logger.Error("failed to decode NAC map layer response", "error", err)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	c.logger.Debug("successfully fetched NAC map layer", "feature_count", len(apiResp.Features))
@@ -110,13 +121,13 @@ func (c *Client) GetForecast(centerId string, zoneId int) (*ForecastResponse, er
 	}
 
 	var apiResp ForecastResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &apiResp); err != nil {
 		c.logger.Error("failed to decode NAC forecast response",
 			"center_id", centerId,
 			"zone_id", zoneId,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	c.logger.Debug("successfully fetched NAC forecast",
@@ -126,3 +137,43 @@ func (c *Client) GetForecast(centerId string, zoneId int) (*ForecastResponse, er
 
 	return &apiResp, nil
 }
+
+// GetAccidents fetches the full avalanche accident dataset.
+func (c *Client) GetAccidents() ([]Accident, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	u.Path = "/v2/public/accidents"
+
+	c.logger.Debug("fetching NAC accidents", "url", u.String())
+
+	resp, err := c.httpClient.Get(u.String())
+	if err != nil {
+		c.logger.Error("failed to fetch NAC accidents", "error", err)
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.Error("NAC accidents API returned error",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+		)
+		return nil, fmt.Errorf("fetch returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var accidents []Accident
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &accidents); err != nil {
+		c.logger.Error("failed to decode NAC accidents response", "error", err)
+		return nil, err
+	}
+
+	c.logger.Debug("successfully fetched NAC accidents", "accident_count", len(accidents))
+
+	return accidents, nil
+}
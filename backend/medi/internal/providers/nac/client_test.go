@@ -0,0 +1,58 @@
+package nac
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"medi/internal/providers/decode"
+)
+
+// mapLayerFixture reads the national map layer snapshot shared with the
+// avalanche package tests.
+func mapLayerFixture(tb testing.TB) []byte {
+	tb.Helper()
+
+	data, err := os.ReadFile("../../avalanche/testdata/nac_map_layer_response.json")
+	if err != nil {
+		tb.Fatalf("failed to read map layer testdata: %v", err)
+	}
+	return data
+}
+
+// BenchmarkDecodeMapLayer measures decoding the full national map layer
+// through the bounded decode path the client uses.
+func BenchmarkDecodeMapLayer(b *testing.B) {
+	data := mapLayerFixture(b)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		resp := &http.Response{
+			Header: http.Header{"Content-Type": []string{"application/json"}},
+			Body:   io.NopCloser(bytes.NewReader(data)),
+		}
+		var apiResp MapLayerResponse
+		if err := decode.JSON(resp, mapLayerMaxResponseBytes, &apiResp); err != nil {
+			b.Fatalf("decode failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkDecodeMapLayerUnbounded is the baseline without the limit wrapper,
+// for spotting regressions in the bounded path.
+func BenchmarkDecodeMapLayerUnbounded(b *testing.B) {
+	data := mapLayerFixture(b)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var apiResp MapLayerResponse
+		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&apiResp); err != nil {
+			b.Fatalf("decode failed: %v", err)
+		}
+	}
+}
@@ -1,12 +1,18 @@
 package nac
 
 // FindZone returns the first MapLayerFeature whose geometry contains the given
-// latitude/longitude, or nil if no zone matches.
+// latitude/longitude, or nil if no zone matches. The bounding box scanned at
+// decode time prefilters the zones, so only candidates that can actually
+// contain the point pay for decoding their full ring coordinates.
 func FindZone(lat, lon float64, mapLayer *MapLayerResponse) *MapLayerFeature {
 	for i := range mapLayer.Features {
-		for _, ring := range mapLayer.Features[i].Geometry.Coordinates() {
+		feature := &mapLayer.Features[i]
+		if !feature.Geometry.BoundsContain(lat, lon) {
+			continue
+		}
+		for _, ring := range feature.Geometry.Coordinates() {
 			if pointInPolygon(lat, lon, ring) {
-				return &mapLayer.Features[i]
+				return feature
 			}
 		}
 	}
@@ -30,3 +36,203 @@ func pointInPolygon(lat, lon float64, polygon [][2]float64) bool {
 	}
 	return inside
 }
+
+// SamplePoint is a representative location inside a zone polygon.
+type SamplePoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// SamplePoints returns up to max representative points inside the zone
+// geometry: the area centroid when it falls inside the polygon, then interior
+// points of a quantile grid over the bounding box. Grid candidates outside the
+// polygon (common for concave zones) are skipped.
+func SamplePoints(g *MapLayerGeometry, max int) []SamplePoint {
+	rings := g.Coordinates()
+	if len(rings) == 0 || max <= 0 {
+		return nil
+	}
+
+	points := make([]SamplePoint, 0, max)
+	add := func(lat, lon float64) {
+		if len(points) == max {
+			return
+		}
+		for _, p := range points {
+			if p.Latitude == lat && p.Longitude == lon {
+				return
+			}
+		}
+		for _, ring := range rings {
+			if pointInPolygon(lat, lon, ring) {
+				points = append(points, SamplePoint{Latitude: lat, Longitude: lon})
+				return
+			}
+		}
+	}
+
+	add(centroid(rings))
+
+	minLat, maxLat, minLon, maxLon := boundingBox(rings)
+	quantiles := []float64{0.5, 0.25, 0.75}
+	for _, qLat := range quantiles {
+		for _, qLon := range quantiles {
+			add(minLat+(maxLat-minLat)*qLat, minLon+(maxLon-minLon)*qLon)
+		}
+	}
+
+	return points
+}
+
+// centroid computes the area-weighted centroid over all rings using the
+// shoelace formula, falling back to the vertex mean for degenerate rings.
+func centroid(rings [][][2]float64) (lat, lon float64) {
+	var totalArea, latSum, lonSum float64
+	for _, ring := range rings {
+		area, ringLat, ringLon := ringCentroid(ring)
+		if area < 0 {
+			area = -area
+		}
+		totalArea += area
+		latSum += ringLat * area
+		lonSum += ringLon * area
+	}
+
+	if totalArea == 0 {
+		var count float64
+		for _, ring := range rings {
+			for _, vertex := range ring {
+				latSum += vertex[1]
+				lonSum += vertex[0]
+				count++
+			}
+		}
+		if count == 0 {
+			return 0, 0
+		}
+		return latSum / count, lonSum / count
+	}
+
+	return latSum / totalArea, lonSum / totalArea
+}
+
+// ringCentroid returns the signed shoelace area and centroid of one ring.
+// GeoJSON coordinates are [longitude, latitude].
+func ringCentroid(ring [][2]float64) (area, lat, lon float64) {
+	n := len(ring)
+	if n < 3 {
+		return 0, 0, 0
+	}
+
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		cross := ring[j][0]*ring[i][1] - ring[i][0]*ring[j][1]
+		area += cross
+		lon += (ring[j][0] + ring[i][0]) * cross
+		lat += (ring[j][1] + ring[i][1]) * cross
+	}
+	area /= 2
+	if area == 0 {
+		return 0, 0, 0
+	}
+	return area, lat / (6 * area), lon / (6 * area)
+}
+
+// boundingBox returns the extremes of all ring vertices.
+func boundingBox(rings [][][2]float64) (minLat, maxLat, minLon, maxLon float64) {
+	first := true
+	for _, ring := range rings {
+		for _, vertex := range ring {
+			if first {
+				minLat, maxLat = vertex[1], vertex[1]
+				minLon, maxLon = vertex[0], vertex[0]
+				first = false
+				continue
+			}
+			if vertex[1] < minLat {
+				minLat = vertex[1]
+			}
+			if vertex[1] > maxLat {
+				maxLat = vertex[1]
+			}
+			if vertex[0] < minLon {
+				minLon = vertex[0]
+			}
+			if vertex[0] > maxLon {
+				maxLon = vertex[0]
+			}
+		}
+	}
+	return minLat, maxLat, minLon, maxLon
+}
+
+// IntersectsBox reports whether any polygon ring of the geometry intersects
+// the latitude/longitude box. It covers polygons fully inside the box, boxes
+// fully inside a polygon, and partial overlaps; centroid containment alone
+// misses the latter two.
+func IntersectsBox(g *MapLayerGeometry, minLat, maxLat, minLon, maxLon float64) bool {
+	if !g.boundsOverlap(minLat, maxLat, minLon, maxLon) {
+		return false
+	}
+	for _, ring := range g.Coordinates() {
+		if ringIntersectsBox(ring, minLat, maxLat, minLon, maxLon) {
+			return true
+		}
+	}
+	return false
+}
+
+func ringIntersectsBox(ring [][2]float64, minLat, maxLat, minLon, maxLon float64) bool {
+	// A ring vertex inside the box covers polygons fully or partially inside it
+	for _, vertex := range ring {
+		if vertex[1] >= minLat && vertex[1] <= maxLat &&
+			vertex[0] >= minLon && vertex[0] <= maxLon {
+			return true
+		}
+	}
+
+	// A box corner inside the ring covers boxes the polygon surrounds
+	corners := [4][2]float64{
+		{minLon, minLat}, {maxLon, minLat}, {maxLon, maxLat}, {minLon, maxLat},
+	}
+	for _, corner := range corners {
+		if pointInPolygon(corner[1], corner[0], ring) {
+			return true
+		}
+	}
+
+	// Remaining overlaps, like a box slicing clean through the polygon, show
+	// up as a ring edge crossing a box edge
+	edges := [4][2][2]float64{
+		{corners[0], corners[1]},
+		{corners[1], corners[2]},
+		{corners[2], corners[3]},
+		{corners[3], corners[0]},
+	}
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		for _, edge := range edges {
+			if segmentsIntersect(ring[j], ring[i], edge[0], edge[1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// segmentsIntersect reports whether the open segments a1-a2 and b1-b2 cross,
+// via orientation tests. Collinear touches are already handled by the vertex
+// and corner containment checks.
+func segmentsIntersect(a1, a2, b1, b2 [2]float64) bool {
+	d1 := crossProduct(b1, b2, a1)
+	d2 := crossProduct(b1, b2, a2)
+	d3 := crossProduct(a1, a2, b1)
+	d4 := crossProduct(a1, a2, b2)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+// crossProduct returns the z-component of (b-a) x (c-a).
+func crossProduct(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
@@ -0,0 +1,139 @@
+package nac
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// buildGeometry unmarshals a GeoJSON geometry through the custom unmarshaler
+func buildGeometry(t *testing.T, geojson string) *MapLayerGeometry {
+	t.Helper()
+
+	var geometry MapLayerGeometry
+	if err := json.Unmarshal([]byte(geojson), &geometry); err != nil {
+		t.Fatalf("failed to unmarshal geometry: %v", err)
+	}
+	return &geometry
+}
+
+func TestSamplePointsConvexZone(t *testing.T) {
+	// Rectangular zone spanning 39-40N, 107-106W
+	geometry := buildGeometry(t, `{
+		"type": "Polygon",
+		"coordinates": [[[-107, 39], [-106, 39], [-106, 40], [-107, 40], [-107, 39]]]
+	}`)
+
+	points := SamplePoints(geometry, 5)
+	if len(points) != 5 {
+		t.Fatalf("got %d sample points, want 5", len(points))
+	}
+
+	// The centroid comes first
+	if points[0].Latitude != 39.5 || points[0].Longitude != -106.5 {
+		t.Errorf("first point = (%v, %v), want centroid (39.5, -106.5)", points[0].Latitude, points[0].Longitude)
+	}
+
+	for _, point := range points {
+		inside := false
+		for _, ring := range geometry.Coordinates() {
+			if pointInPolygon(point.Latitude, point.Longitude, ring) {
+				inside = true
+			}
+		}
+		if !inside {
+			t.Errorf("sample point (%v, %v) falls outside the zone", point.Latitude, point.Longitude)
+		}
+	}
+}
+
+func TestSamplePointsConcaveZone(t *testing.T) {
+	// L-shaped zone: a bottom bar with a column up the left side. Its area
+	// centroid and most of its bounding box fall in the notch.
+	geometry := buildGeometry(t, `{
+		"type": "Polygon",
+		"coordinates": [[[0, 0], [4, 0], [4, 1.5], [1.5, 1.5], [1.5, 4], [0, 4], [0, 0]]]
+	}`)
+
+	points := SamplePoints(geometry, 5)
+	if len(points) == 0 {
+		t.Fatal("no sample points for concave zone")
+	}
+
+	for _, point := range points {
+		if point.Latitude > 1.5 && point.Longitude > 1.5 {
+			t.Errorf("sample point (%v, %v) falls in the concave notch", point.Latitude, point.Longitude)
+		}
+		inside := false
+		for _, ring := range geometry.Coordinates() {
+			if pointInPolygon(point.Latitude, point.Longitude, ring) {
+				inside = true
+			}
+		}
+		if !inside {
+			t.Errorf("sample point (%v, %v) falls outside the zone", point.Latitude, point.Longitude)
+		}
+	}
+}
+
+func TestSamplePointsRespectsCap(t *testing.T) {
+	geometry := buildGeometry(t, `{
+		"type": "Polygon",
+		"coordinates": [[[-107, 39], [-106, 39], [-106, 40], [-107, 40], [-107, 39]]]
+	}`)
+
+	points := SamplePoints(geometry, 2)
+	if len(points) != 2 {
+		t.Errorf("got %d sample points, want 2", len(points))
+	}
+	if got := SamplePoints(geometry, 0); got != nil {
+		t.Errorf("max 0 returned %d points, want none", len(got))
+	}
+}
+
+func TestIntersectsBox(t *testing.T) {
+	// Square zone spanning 39-40N, 107-106W
+	geometry := buildGeometry(t, `{
+		"type": "Polygon",
+		"coordinates": [[[-107, 39], [-106, 39], [-106, 40], [-107, 40], [-107, 39]]]
+	}`)
+
+	tests := []struct {
+		name                           string
+		minLat, maxLat, minLon, maxLon float64
+		want                           bool
+	}{
+		{"polygon fully inside box", 38, 41, -108, -105, true},
+		{"box fully inside polygon", 39.4, 39.6, -106.6, -106.4, true},
+		{"partial overlap at a corner", 39.5, 40.5, -107.5, -106.5, true},
+		{"box slicing through the middle", 39.4, 39.6, -108, -105, true},
+		{"disjoint to the east", 39, 40, -105.5, -104.5, false},
+		{"disjoint to the north", 40.5, 41.5, -107, -106, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IntersectsBox(geometry, tt.minLat, tt.maxLat, tt.minLon, tt.maxLon)
+			if got != tt.want {
+				t.Errorf("IntersectsBox = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersectsBoxMultiPolygon(t *testing.T) {
+	// Two disjoint squares; the box overlaps only the second
+	geometry := buildGeometry(t, `{
+		"type": "MultiPolygon",
+		"coordinates": [
+			[[[-107, 39], [-106, 39], [-106, 40], [-107, 40], [-107, 39]]],
+			[[[-104, 37], [-103, 37], [-103, 38], [-104, 38], [-104, 37]]]
+		]
+	}`)
+
+	if !IntersectsBox(geometry, 37.2, 37.8, -103.8, -103.2) {
+		t.Error("expected the box inside the second polygon to intersect")
+	}
+	if IntersectsBox(geometry, 35, 36, -104, -103) {
+		t.Error("expected a box south of both polygons not to intersect")
+	}
+}
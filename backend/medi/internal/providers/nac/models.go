@@ -1,8 +1,12 @@
 package nac
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"sync"
 	"time"
 )
 
@@ -12,6 +16,63 @@ type MapLayerResponse struct {
 	Features []MapLayerFeature `json:"features"`
 }
 
+// UnmarshalJSON walks the FeatureCollection with json.Decoder token
+// iteration, decoding features one at a time so each zone's coordinate
+// payload is processed and released individually instead of the whole
+// layer's at once.
+func (m *MapLayerResponse) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read map layer: %w", err)
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "type":
+			if err := dec.Decode(&m.Type); err != nil {
+				return fmt.Errorf("failed to decode map layer type: %w", err)
+			}
+		case "features":
+			if err := expectDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				var feature MapLayerFeature
+				if err := dec.Decode(&feature); err != nil {
+					return fmt.Errorf("failed to decode map layer feature %d: %w", len(m.Features), err)
+				}
+				m.Features = append(m.Features, feature)
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return fmt.Errorf("failed to skip map layer field %q: %w", key, err)
+			}
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// expectDelim consumes the next token and fails unless it is the wanted
+// structural delimiter
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read map layer: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("unexpected map layer token %v, want %v", tok, want)
+	}
+	return nil
+}
+
 type MapLayerFeature struct {
 	Id         int                `json:"id"`
 	Type       string             `json:"type"`
@@ -21,7 +82,10 @@ type MapLayerFeature struct {
 
 type MapLayerProperties struct {
 	Name         string `json:"name"`
+	Center       string `json:"center"`
+	CenterLink   string `json:"center_link"`
 	CenterId     string `json:"center_id"`
+	State        string `json:"state"`
 	DangerLevel  int    `json:"danger_level"`
 	Danger       string `json:"danger"`
 	TravelAdvice string `json:"travel_advice"`
@@ -34,15 +98,32 @@ type MapLayerProperties struct {
 	} `json:"warning"`
 }
 
-// MapLayerGeometry handles both Polygon and MultiPolygon GeoJSON types.
+// MapLayerGeometry handles both Polygon and MultiPolygon GeoJSON types. To
+// keep the cached national layer compact it retains only the raw coordinate
+// bytes and a bounding box; the full rings are re-decoded on first use, so
+// only zones a lookup actually hits pay for their parsed geometry.
 type MapLayerGeometry struct {
 	Type string `json:"type"`
-	// rawCoordinates holds the raw JSON for custom unmarshaling.
+	// rawCoordinates holds the raw JSON, re-decoded on demand.
 	rawCoordinates json.RawMessage
-	// polygon stores coordinates as [][][2]float64 regardless of type.
+	// bounds is the coordinate extremes, scanned during unmarshaling.
+	bounds geoBounds
+	// decodeOnce guards the lazy ring decoding; a pointer so features stay
+	// copyable.
+	decodeOnce *sync.Once
+	// polygon stores coordinates as [][][2]float64 regardless of type, nil
+	// until first requested.
 	polygon [][][2]float64
 }
 
+// geoBounds is the bounding box of a geometry's vertices. valid is false for
+// a geometry without coordinates (or one built directly in tests), which
+// filters nothing.
+type geoBounds struct {
+	minLat, maxLat, minLon, maxLon float64
+	valid                          bool
+}
+
 func (g *MapLayerGeometry) UnmarshalJSON(data []byte) error {
 	var raw struct {
 		Type        string          `json:"type"`
@@ -51,37 +132,125 @@ func (g *MapLayerGeometry) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	switch raw.Type {
+	case "Polygon", "MultiPolygon":
+	default:
+		return fmt.Errorf("unsupported geometry type: %s", raw.Type)
+	}
+
 	g.Type = raw.Type
 	g.rawCoordinates = raw.Coordinates
+	g.decodeOnce = new(sync.Once)
+	g.polygon = nil
 
-	switch raw.Type {
-	case "Polygon":
-		var coords [][][2]float64
-		if err := json.Unmarshal(raw.Coordinates, &coords); err != nil {
-			return fmt.Errorf("failed to unmarshal Polygon coordinates: %w", err)
+	bounds, err := scanBounds(raw.Coordinates)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s coordinates: %w", raw.Type, err)
+	}
+	g.bounds = bounds
+	return nil
+}
+
+// scanBounds walks the coordinate tokens once, without building ring slices,
+// and records the vertex extremes. GeoJSON positions are
+// [longitude, latitude]; any nesting depth works because positions reset at
+// every opening bracket.
+func scanBounds(raw json.RawMessage) (geoBounds, error) {
+	bounds := geoBounds{
+		minLat: math.Inf(1), maxLat: math.Inf(-1),
+		minLon: math.Inf(1), maxLon: math.Inf(-1),
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	position := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
 		}
-		g.polygon = coords
-	case "MultiPolygon":
-		var multi [][][][2]float64
-		if err := json.Unmarshal(raw.Coordinates, &multi); err != nil {
-			return fmt.Errorf("failed to unmarshal MultiPolygon coordinates: %w", err)
+		if err != nil {
+			return geoBounds{}, err
 		}
-		for _, poly := range multi {
-			g.polygon = append(g.polygon, poly...)
+		switch v := tok.(type) {
+		case json.Delim:
+			if v == '[' {
+				position = 0
+			}
+		case float64:
+			switch position {
+			case 0:
+				bounds.minLon = math.Min(bounds.minLon, v)
+				bounds.maxLon = math.Max(bounds.maxLon, v)
+			case 1:
+				bounds.minLat = math.Min(bounds.minLat, v)
+				bounds.maxLat = math.Max(bounds.maxLat, v)
+				bounds.valid = true
+			}
+			position++
 		}
-	default:
-		return fmt.Errorf("unsupported geometry type: %s", raw.Type)
 	}
-	return nil
+	if !bounds.valid {
+		return geoBounds{}, nil
+	}
+	return bounds, nil
+}
+
+// BoundsContain reports whether the point can fall inside the geometry at
+// all, by its bounding box. A geometry without a scanned box filters nothing.
+func (g *MapLayerGeometry) BoundsContain(lat, lon float64) bool {
+	if !g.bounds.valid {
+		return true
+	}
+	return lat >= g.bounds.minLat && lat <= g.bounds.maxLat &&
+		lon >= g.bounds.minLon && lon <= g.bounds.maxLon
+}
+
+// boundsOverlap reports whether the geometry's bounding box intersects the
+// given latitude/longitude box; again a missing box filters nothing.
+func (g *MapLayerGeometry) boundsOverlap(minLat, maxLat, minLon, maxLon float64) bool {
+	if !g.bounds.valid {
+		return true
+	}
+	return g.bounds.minLat <= maxLat && g.bounds.maxLat >= minLat &&
+		g.bounds.minLon <= maxLon && g.bounds.maxLon >= minLon
 }
 
-// Coordinates returns all polygon rings as [][][2]float64.
+// Coordinates returns all polygon rings as [][][2]float64, decoding them from
+// the raw bytes on first call and retaining the result.
 // For Polygon types this is the rings directly.
 // For MultiPolygon types all polygons' rings are flattened into a single slice.
 func (g *MapLayerGeometry) Coordinates() [][][2]float64 {
+	if g.decodeOnce == nil {
+		return g.polygon
+	}
+	g.decodeOnce.Do(func() {
+		g.polygon = decodeRings(g.Type, g.rawCoordinates)
+	})
 	return g.polygon
 }
 
+// decodeRings parses the retained coordinate bytes into rings. The syntax was
+// already validated by the bounds scan, so a shape mismatch — which the eager
+// decoder used to reject at fetch time — leaves the zone without geometry.
+func decodeRings(geometryType string, raw json.RawMessage) [][][2]float64 {
+	switch geometryType {
+	case "Polygon":
+		var coords [][][2]float64
+		if err := json.Unmarshal(raw, &coords); err == nil {
+			return coords
+		}
+	case "MultiPolygon":
+		var multi [][][][2]float64
+		if err := json.Unmarshal(raw, &multi); err == nil {
+			var rings [][][2]float64
+			for _, poly := range multi {
+				rings = append(rings, poly...)
+			}
+			return rings
+		}
+	}
+	return nil
+}
+
 // ForecastResponse is the response from the NAC forecast endpoint.
 type ForecastResponse struct {
 	Id                int         `json:"id"`
@@ -152,3 +321,20 @@ type ForecastResponse struct {
 		Config interface{} `json:"config"`
 	} `json:"forecast_zone"`
 }
+
+// Accident is a single avalanche accident/incident record from the public
+// accidents endpoint. Older records frequently lack coordinates.
+type Accident struct {
+	Id           string   `json:"id"`
+	Date         string   `json:"date"` // YYYY-MM-DD
+	LocationName string   `json:"location_name"`
+	State        string   `json:"state"`
+	CenterId     string   `json:"center_id"`
+	ForecastZone string   `json:"forecast_zone"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
+	Caught       int      `json:"caught"`
+	Buried       int      `json:"buried"`
+	Killed       int      `json:"killed"`
+	Url          string   `json:"url"`
+}
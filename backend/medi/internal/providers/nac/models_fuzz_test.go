@@ -0,0 +1,48 @@
+package nac
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// FuzzMapLayerGeometryUnmarshal hammers the tolerant geometry decoder with
+// arbitrary JSON: the decoder must reject or accept without panicking, and an
+// accepted geometry must expose a usable bounding box and ring decode.
+func FuzzMapLayerGeometryUnmarshal(f *testing.F) {
+	f.Add([]byte(`{"type":"Polygon","coordinates":[[[-107.1,39.0],[-107.2,39.1],[-107.0,39.2],[-107.1,39.0]]]}`))
+	f.Add([]byte(`{"type":"MultiPolygon","coordinates":[[[[-107.1,39.0],[-107.2,39.1],[-107.0,39.2],[-107.1,39.0]]]]}`))
+	f.Add([]byte(`{"type":"Point","coordinates":[-107.1,39.0]}`))
+	f.Add([]byte(`{"type":"Polygon","coordinates":[[[[[[[[1,2]]]]]]]]}`))
+	f.Add([]byte(`{"type":"Polygon","coordinates":"not an array"}`))
+	f.Add([]byte(`{"type":"Polygon\xff\xfe","coordinates":[]}`))
+	f.Add([]byte(`{"type":"Polygon"}`))
+	f.Add([]byte(`null`))
+
+	// Seed every geometry from the committed map layer fixture
+	if data, err := os.ReadFile("../../avalanche/testdata/nac_map_layer_response.json"); err == nil {
+		var layer struct {
+			Features []struct {
+				Geometry json.RawMessage `json:"geometry"`
+			} `json:"features"`
+		}
+		if json.Unmarshal(data, &layer) == nil {
+			for _, feature := range layer.Features {
+				f.Add([]byte(feature.Geometry))
+			}
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var geometry MapLayerGeometry
+		if err := json.Unmarshal(data, &geometry); err != nil {
+			return
+		}
+		if geometry.Type != "Polygon" && geometry.Type != "MultiPolygon" {
+			t.Errorf("accepted unsupported geometry type %q", geometry.Type)
+		}
+		// Both must hold whatever the coordinates looked like
+		geometry.BoundsContain(39.0, -107.0)
+		geometry.Coordinates()
+	})
+}
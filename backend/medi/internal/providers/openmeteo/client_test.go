@@ -0,0 +1,146 @@
+package openmeteo
+
+import (
+	"context"
+	"log/slog"
+	"medi/internal/providers/retry"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client pointed at a stub server and a handle to the
+// query values of the last request it received
+func newTestClient(t *testing.T) (*Client, *url.Values) {
+	t.Helper()
+
+	var lastQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hourly": {"time": ["2025-02-01T00:00"]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{
+		httpClient:  server.Client(),
+		baseURL:     server.URL,
+		logger:      slog.Default(),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+	return client, &lastQuery
+}
+
+func TestGetForecastQueryUsesForecastDays(t *testing.T) {
+	client, query := newTestClient(t)
+
+	if _, err := client.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 16, "America/Denver"); err != nil {
+		t.Fatalf("GetForecast failed: %v", err)
+	}
+
+	if got := query.Get("forecast_days"); got != "16" {
+		t.Errorf("forecast_days = %q, want 16", got)
+	}
+	if query.Has("start_date") || query.Has("end_date") {
+		t.Errorf("day-anchored request carried date params: %v", *query)
+	}
+	if got := query.Get("timezone"); got != "America/Denver" {
+		t.Errorf("timezone = %q, want America/Denver", got)
+	}
+}
+
+func TestGetForecastWindowQueryUsesDates(t *testing.T) {
+	client, query := newTestClient(t)
+
+	window := ForecastWindow{
+		StartDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+	}
+	if _, err := client.GetForecastWindow(context.Background(), 39.11539, -107.6584, 2900, window, ""); err != nil {
+		t.Fatalf("GetForecastWindow failed: %v", err)
+	}
+
+	if got := query.Get("start_date"); got != "2025-02-01" {
+		t.Errorf("start_date = %q, want 2025-02-01", got)
+	}
+	if got := query.Get("end_date"); got != "2025-02-05" {
+		t.Errorf("end_date = %q, want 2025-02-05", got)
+	}
+	if query.Has("forecast_days") {
+		t.Errorf("date-anchored request carried forecast_days=%q", query.Get("forecast_days"))
+	}
+	if got := query.Get("timezone"); got != "GMT" {
+		t.Errorf("timezone = %q, want the GMT default", got)
+	}
+}
+
+func TestForecastWindowValidation(t *testing.T) {
+	client, _ := newTestClient(t)
+	day := func(d int) time.Time { return time.Date(2025, 2, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		window  ForecastWindow
+		wantErr string
+	}{
+		{
+			name:    "missing end date",
+			window:  ForecastWindow{StartDate: day(1)},
+			wantErr: "both a start and an end date",
+		},
+		{
+			name:    "end before start",
+			window:  ForecastWindow{StartDate: day(5), EndDate: day(1)},
+			wantErr: "before start",
+		},
+		{
+			name:    "span too long",
+			window:  ForecastWindow{StartDate: day(1), EndDate: day(18)},
+			wantErr: "maximum is 16",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.GetForecastWindow(context.Background(), 39.11539, -107.6584, 2900, tc.window, "")
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetForecastRejectsEmptyForecast(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{
+		httpClient:  server.Client(),
+		baseURL:     server.URL,
+		logger:      slog.Default(),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+
+	_, err := client.GetForecast(context.Background(), 39.11539, -107.6584, 2900, 3, "UTC")
+	if err == nil || !strings.Contains(err.Error(), "no hourly timestamps") {
+		t.Errorf("error = %v, want the empty-forecast rejection", err)
+	}
+}
+
+func TestForecastDaysAndWindowAreMutuallyExclusive(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	window := &ForecastWindow{
+		StartDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+	}
+	_, err := client.getForecast(context.Background(), 39.11539, -107.6584, 2900, 7, window, "")
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("error = %v, want the mutual-exclusion error", err)
+	}
+}
@@ -0,0 +1,139 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"medi/internal/providers/retry"
+)
+
+// API Docs: https://open-meteo.com/en/docs/elevation-api
+// Sample request: https://api.open-meteo.com/v1/elevation?latitude=39.11,39.12&longitude=-107.65,-107.66
+const (
+	baseElevationURL = "https://api.open-meteo.com/v1/elevation"
+
+	// maxElevationPointsPerRequest is the API's cap on coordinates per call;
+	// larger batches are chunked across requests
+	maxElevationPointsPerRequest = 100
+
+	// maxElevationResponseBytes bounds the response body; even a full batch
+	// is only a hundred numbers
+	maxElevationResponseBytes = 1 << 20
+)
+
+// ErrElevationBatch marks a bulk lookup where a chunk failed or came back
+// with the wrong number of values. The whole batch fails rather than
+// returning elevations misaligned with the requested points.
+var ErrElevationBatch = errors.New("bulk elevation lookup failed")
+
+// ElevationAPIResponse is the raw elevation API payload: one value in meters
+// per requested coordinate, in request order.
+type ElevationAPIResponse struct {
+	Elevation []float64 `json:"elevation"`
+}
+
+// ElevationClient fetches digital elevation model values from the Open-Meteo
+// elevation API, which accepts up to 100 coordinates per request.
+type ElevationClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	logger      *slog.Logger
+	retryPolicy retry.Policy
+}
+
+func NewElevationClient(logger *slog.Logger) *ElevationClient {
+	return &ElevationClient{
+		httpClient:  &http.Client{},
+		baseURL:     baseElevationURL,
+		logger:      logger.With("component", "openmeteo-elevation-client"),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+}
+
+// SetRetryPolicy overrides the default retry behavior, e.g. to change the
+// configured wait cap
+func (c *ElevationClient) SetRetryPolicy(policy retry.Policy) {
+	c.retryPolicy = policy
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *ElevationClient) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// checkElevationResponse rejects responses that decoded cleanly but carry no
+// values; the API occasionally sheds load with an empty 200
+func checkElevationResponse(apiResp *ElevationAPIResponse) error {
+	if len(apiResp.Elevation) == 0 {
+		return fmt.Errorf("elevation response has no values")
+	}
+	return nil
+}
+
+// GetElevations fetches the elevation in meters for every point, aligned to
+// the input order. Batches past the API's 100-point cap span multiple
+// requests; a failing chunk fails the whole batch with ErrElevationBatch so
+// callers never receive values misaligned with their points.
+func (c *ElevationClient) GetElevations(ctx context.Context, latitudes, longitudes []float64) ([]float64, error) {
+	if len(latitudes) != len(longitudes) {
+		return nil, fmt.Errorf("%w: %d latitudes for %d longitudes", ErrElevationBatch, len(latitudes), len(longitudes))
+	}
+	if len(latitudes) == 0 {
+		return nil, nil
+	}
+
+	elevations := make([]float64, 0, len(latitudes))
+	for start := 0; start < len(latitudes); start += maxElevationPointsPerRequest {
+		end := start + maxElevationPointsPerRequest
+		if end > len(latitudes) {
+			end = len(latitudes)
+		}
+		chunk, err := c.getElevationChunk(ctx, latitudes[start:end], longitudes[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("%w: points %d-%d: %v", ErrElevationBatch, start, end-1, err)
+		}
+		elevations = append(elevations, chunk...)
+	}
+	return elevations, nil
+}
+
+// getElevationChunk fetches one request's worth of points and verifies the
+// value count matches before the values can be stitched into the batch
+func (c *ElevationClient) getElevationChunk(ctx context.Context, latitudes, longitudes []float64) ([]float64, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("latitude", joinCoordinates(latitudes))
+	q.Set("longitude", joinCoordinates(longitudes))
+	u.RawQuery = q.Encode()
+
+	fullUrl := u.String()
+	c.logger.Debug("fetching elevations", "points", len(latitudes), "url", fullUrl)
+
+	apiResp, err := retry.GetJSON[ElevationAPIResponse](ctx, c.httpClient, c.logger, c.retryPolicy, fullUrl, maxElevationResponseBytes, checkElevationResponse)
+	if err != nil {
+		return nil, err
+	}
+	if len(apiResp.Elevation) != len(latitudes) {
+		return nil, fmt.Errorf("elevation response has %d values for %d points", len(apiResp.Elevation), len(latitudes))
+	}
+	return apiResp.Elevation, nil
+}
+
+// joinCoordinates renders values as the comma-separated list the API expects
+func joinCoordinates(values []float64) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%f", value)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,186 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"medi/internal/providers/retry"
+)
+
+// newTestElevationClient returns an elevation client pointed at a stub server
+// that serves one elevation per requested point, plus the query values of
+// every request it received. Requests past failAfter (when positive) get a
+// 500 instead.
+func newTestElevationClient(t *testing.T, failAfter int) (*ElevationClient, *[]url.Values) {
+	t.Helper()
+
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if failAfter > 0 && len(queries) > failAfter {
+			http.Error(w, "upstream down", http.StatusInternalServerError)
+			return
+		}
+
+		latitudes := strings.Split(r.URL.Query().Get("latitude"), ",")
+		values := make([]string, len(latitudes))
+		for i := range latitudes {
+			// Echo back a value derived from the point's position in the
+			// request so alignment is checkable end to end
+			values[i] = fmt.Sprintf("%d", 1000+i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"elevation": [%s]}`, strings.Join(values, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &ElevationClient{
+		httpClient:  server.Client(),
+		baseURL:     server.URL,
+		logger:      slog.Default(),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+	return client, &queries
+}
+
+// ringCoordinates builds n distinct test points
+func ringCoordinates(n int) (latitudes, longitudes []float64) {
+	latitudes = make([]float64, n)
+	longitudes = make([]float64, n)
+	for i := 0; i < n; i++ {
+		latitudes[i] = 39.0 + float64(i)*0.001
+		longitudes[i] = -107.0 - float64(i)*0.001
+	}
+	return latitudes, longitudes
+}
+
+func TestGetElevationsQueryConstruction(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       int
+		wantRequests int
+	}{
+		{"single point", 1, 1},
+		{"full chunk", 100, 1},
+		{"past the chunk cap", 150, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, queries := newTestElevationClient(t, 0)
+			latitudes, longitudes := ringCoordinates(tt.points)
+
+			elevations, err := client.GetElevations(context.Background(), latitudes, longitudes)
+			if err != nil {
+				t.Fatalf("GetElevations returned error: %v", err)
+			}
+			if len(elevations) != tt.points {
+				t.Fatalf("got %d elevations, want %d", len(elevations), tt.points)
+			}
+			if len(*queries) != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", len(*queries), tt.wantRequests)
+			}
+
+			// Each request carries one comma-separated value per point in its
+			// chunk, in input order
+			seen := 0
+			for _, query := range *queries {
+				gotLatitudes := strings.Split(query.Get("latitude"), ",")
+				gotLongitudes := strings.Split(query.Get("longitude"), ",")
+				if len(gotLatitudes) != len(gotLongitudes) {
+					t.Fatalf("request has %d latitudes for %d longitudes", len(gotLatitudes), len(gotLongitudes))
+				}
+				if len(gotLatitudes) > maxElevationPointsPerRequest {
+					t.Errorf("request carries %d points, over the %d cap", len(gotLatitudes), maxElevationPointsPerRequest)
+				}
+				for i, got := range gotLatitudes {
+					if want := fmt.Sprintf("%f", latitudes[seen+i]); got != want {
+						t.Fatalf("latitude %d = %q, want %q", seen+i, got, want)
+					}
+				}
+				seen += len(gotLatitudes)
+			}
+			if seen != tt.points {
+				t.Errorf("requests covered %d points, want %d", seen, tt.points)
+			}
+		})
+	}
+}
+
+func TestGetElevationsChunkBoundaries(t *testing.T) {
+	client, queries := newTestElevationClient(t, 0)
+	latitudes, longitudes := ringCoordinates(150)
+
+	elevations, err := client.GetElevations(context.Background(), latitudes, longitudes)
+	if err != nil {
+		t.Fatalf("GetElevations returned error: %v", err)
+	}
+
+	first := strings.Split((*queries)[0].Get("latitude"), ",")
+	second := strings.Split((*queries)[1].Get("latitude"), ",")
+	if len(first) != 100 || len(second) != 50 {
+		t.Fatalf("chunk sizes = %d and %d, want 100 and 50", len(first), len(second))
+	}
+	if first[99] != fmt.Sprintf("%f", latitudes[99]) {
+		t.Errorf("first chunk ends at %q, want point 99", first[99])
+	}
+	if second[0] != fmt.Sprintf("%f", latitudes[100]) {
+		t.Errorf("second chunk starts at %q, want point 100", second[0])
+	}
+
+	// The stub serves 1000+i per position within each request, so the values
+	// restarting at the chunk boundary prove chunks were stitched in order
+	if elevations[99] != 1099 || elevations[100] != 1000 {
+		t.Errorf("elevations around the boundary = %v, %v, want 1099, 1000", elevations[99], elevations[100])
+	}
+}
+
+func TestGetElevationsFailedChunkFailsWholeBatch(t *testing.T) {
+	client, _ := newTestElevationClient(t, 1)
+	latitudes, longitudes := ringCoordinates(150)
+
+	elevations, err := client.GetElevations(context.Background(), latitudes, longitudes)
+	if !errors.Is(err, ErrElevationBatch) {
+		t.Fatalf("error = %v, want ErrElevationBatch", err)
+	}
+	if elevations != nil {
+		t.Errorf("got %d elevations from a failed batch, want none", len(elevations))
+	}
+}
+
+func TestGetElevationsRejectsMisalignedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// One value short of the two requested points
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"elevation": [2900.0]}`))
+	}))
+	t.Cleanup(server.Close)
+	client := &ElevationClient{
+		httpClient:  server.Client(),
+		baseURL:     server.URL,
+		logger:      slog.Default(),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+
+	if _, err := client.GetElevations(context.Background(), []float64{39.1, 39.2}, []float64{-107.1, -107.2}); !errors.Is(err, ErrElevationBatch) {
+		t.Fatalf("error = %v, want ErrElevationBatch", err)
+	}
+}
+
+func TestGetElevationsRejectsMismatchedInputs(t *testing.T) {
+	client, queries := newTestElevationClient(t, 0)
+
+	if _, err := client.GetElevations(context.Background(), []float64{39.1, 39.2}, []float64{-107.1}); !errors.Is(err, ErrElevationBatch) {
+		t.Fatalf("error = %v, want ErrElevationBatch", err)
+	}
+	if len(*queries) != 0 {
+		t.Errorf("mismatched inputs reached the server in %d requests", len(*queries))
+	}
+}
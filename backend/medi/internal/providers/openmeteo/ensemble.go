@@ -0,0 +1,167 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"medi/internal/providers/retry"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// API Docs: https://open-meteo.com/en/docs/ensemble-api
+// Sample request: https://ensemble-api.open-meteo.com/v1/ensemble?latitude=39.11&longitude=-107.65&hourly=snowfall,temperature_2m&models=gfs_seamless,ecmwf_ifs025&timezone=GMT&forecast_days=7&timeformat=iso8601&wind_speed_unit=mph&temperature_unit=fahrenheit&precipitation_unit=inch
+const (
+	baseEnsembleURL = "https://ensemble-api.open-meteo.com/v1/ensemble"
+
+	// maxEnsembleResponseBytes bounds the ensemble response body; two
+	// variables across the GFS and ECMWF member sets run larger than the
+	// deterministic multi-model forecast
+	maxEnsembleResponseBytes = 10 << 20
+)
+
+// EnsembleAPIResponse is the raw ensemble API payload. Unlike the forecast
+// API, the member series cannot be typed statically: the key set depends on
+// how many members each model ran, so Hourly collects them by prefix.
+type EnsembleAPIResponse struct {
+	Latitude             float64        `json:"latitude"`
+	Longitude            float64        `json:"longitude"`
+	GenerationtimeMs     float64        `json:"generationtime_ms"`
+	UtcOffsetSeconds     int            `json:"utc_offset_seconds"`
+	Timezone             string         `json:"timezone"`
+	TimezoneAbbreviation string         `json:"timezone_abbreviation"`
+	Elevation            float64        `json:"elevation"`
+	Hourly               EnsembleHourly `json:"hourly"`
+}
+
+// EnsembleHourly holds the hourly member series keyed by their raw API names
+// (e.g. snowfall_gfs_seamless_member07; the control run carries no member
+// suffix). Values are pointers because individual members go missing for
+// individual hours, and a member's series may be shorter than the time axis.
+type EnsembleHourly struct {
+	Time          []string
+	Snowfall      map[string][]*float64
+	Temperature2M map[string][]*float64
+}
+
+// UnmarshalJSON gathers the variable-suffixed member keys into per-variable
+// maps instead of requiring a struct field per member.
+func (h *EnsembleHourly) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	h.Snowfall = map[string][]*float64{}
+	h.Temperature2M = map[string][]*float64{}
+	for key, value := range raw {
+		switch {
+		case key == "time":
+			if err := json.Unmarshal(value, &h.Time); err != nil {
+				return fmt.Errorf("hourly time axis: %w", err)
+			}
+		case strings.HasPrefix(key, "snowfall"):
+			var series []*float64
+			if err := json.Unmarshal(value, &series); err != nil {
+				return fmt.Errorf("hourly series %s: %w", key, err)
+			}
+			h.Snowfall[key] = series
+		case strings.HasPrefix(key, "temperature_2m"):
+			var series []*float64
+			if err := json.Unmarshal(value, &series); err != nil {
+				return fmt.Errorf("hourly series %s: %w", key, err)
+			}
+			h.Temperature2M[key] = series
+		}
+	}
+	return nil
+}
+
+// EnsembleClient fetches individual ensemble member runs from the Open-Meteo
+// ensemble API, which lives on its own host separate from the forecast API.
+type EnsembleClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	logger      *slog.Logger
+	retryPolicy retry.Policy
+}
+
+func NewEnsembleClient(logger *slog.Logger) *EnsembleClient {
+	return &EnsembleClient{
+		httpClient:  &http.Client{},
+		baseURL:     baseEnsembleURL,
+		logger:      logger.With("component", "openmeteo-ensemble-client"),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+}
+
+// SetRetryPolicy overrides the default retry behavior, e.g. to change the
+// configured wait cap
+func (c *EnsembleClient) SetRetryPolicy(policy retry.Policy) {
+	c.retryPolicy = policy
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *EnsembleClient) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// checkEnsembleResponse rejects responses that decoded cleanly but carry no
+// members to aggregate; the API occasionally sheds load with an empty 200
+func checkEnsembleResponse(apiResp *EnsembleAPIResponse) error {
+	if len(apiResp.Hourly.Time) == 0 {
+		return fmt.Errorf("ensemble has no hourly timestamps")
+	}
+	if len(apiResp.Hourly.Snowfall) == 0 {
+		return fmt.Errorf("ensemble has no snowfall members")
+	}
+	return nil
+}
+
+// GetEnsemble fetches hourly snowfall and temperature for every GFS and
+// ECMWF ensemble member at the given point
+func (c *EnsembleClient) GetEnsemble(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*EnsembleAPIResponse, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	hourlyVars := []string{
+		"snowfall",
+		"temperature_2m",
+	}
+
+	modelVars := []string{
+		"gfs_seamless",
+		"ecmwf_ifs025",
+	}
+
+	q := u.Query()
+
+	q.Set("latitude", fmt.Sprintf("%f", latitude))
+	q.Set("longitude", fmt.Sprintf("%f", longitude))
+	q.Set("elevation", fmt.Sprintf("%f", elevationMeters))
+	q.Set("hourly", strings.Join(hourlyVars, ","))
+	q.Set("models", strings.Join(modelVars, ","))
+
+	if timezone == "" {
+		timezone = "GMT"
+	}
+
+	q.Set("timezone", timezone)
+	q.Set("forecast_days", strconv.Itoa(forecastDays))
+	q.Set("timeformat", "iso8601")
+	q.Set("wind_speed_unit", "mph")
+	q.Set("temperature_unit", "fahrenheit")
+	q.Set("precipitation_unit", "inch")
+	u.RawQuery = q.Encode()
+
+	fullUrl := u.String()
+	c.logger.Debug("fetching ensemble", "url", fullUrl)
+
+	return retry.GetJSON[EnsembleAPIResponse](ctx, c.httpClient, c.logger, c.retryPolicy, fullUrl, maxEnsembleResponseBytes, checkEnsembleResponse)
+}
@@ -0,0 +1,41 @@
+package openmeteo
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// FuzzEnsembleHourlyUnmarshal covers the member-gathering decoder, which
+// accepts whatever suffixed keys the ensemble API invents: it must decode or
+// reject without panicking, and an accepted payload must leave both series
+// maps usable.
+func FuzzEnsembleHourlyUnmarshal(f *testing.F) {
+	f.Add([]byte(`{"time":["2025-01-15T00:00"],"snowfall":[0.1],"snowfall_member01":[null],"temperature_2m":[20.5]}`))
+	f.Add([]byte(`{"time":"not an array"}`))
+	f.Add([]byte(`{"snowfall_member01":{"nested":"object"}}`))
+	f.Add([]byte(`{"temperature_2m_member99":[1,2,"three"]}`))
+	f.Add([]byte(`{"` + strings.Repeat("snowfall_", 50) + `":[]}`))
+	f.Add([]byte(`null`))
+
+	// Seed the committed ensemble fixture's hourly block
+	if data, err := os.ReadFile("../../weather/testdata/openmeteo_ensemble_response.json"); err == nil {
+		var resp struct {
+			Hourly json.RawMessage `json:"hourly"`
+		}
+		if json.Unmarshal(data, &resp) == nil && len(resp.Hourly) > 0 {
+			f.Add([]byte(resp.Hourly))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var hourly EnsembleHourly
+		if err := json.Unmarshal(data, &hourly); err != nil {
+			return
+		}
+		if hourly.Snowfall == nil || hourly.Temperature2M == nil {
+			t.Error("accepted payload left a series map nil")
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"medi/internal/providers/retry"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newTestEnsembleClient returns an ensemble client pointed at a stub server
+// and a handle to the query values of the last request it received
+func newTestEnsembleClient(t *testing.T) (*EnsembleClient, *url.Values) {
+	t.Helper()
+
+	var lastQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hourly": {"time": ["2025-02-01T00:00"], "snowfall_gfs_seamless": [0.1]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &EnsembleClient{
+		httpClient:  server.Client(),
+		baseURL:     server.URL,
+		logger:      slog.Default(),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+	return client, &lastQuery
+}
+
+func TestGetEnsembleQueryRequestsMemberModels(t *testing.T) {
+	client, query := newTestEnsembleClient(t)
+
+	if _, err := client.GetEnsemble(context.Background(), 39.11539, -107.6584, 2900, 7, "America/Denver"); err != nil {
+		t.Fatalf("GetEnsemble failed: %v", err)
+	}
+
+	if got := query.Get("models"); got != "gfs_seamless,ecmwf_ifs025" {
+		t.Errorf("models = %q, want the GFS and ECMWF ensembles", got)
+	}
+	if got := query.Get("hourly"); got != "snowfall,temperature_2m" {
+		t.Errorf("hourly = %q, want snowfall,temperature_2m", got)
+	}
+	if got := query.Get("forecast_days"); got != "7" {
+		t.Errorf("forecast_days = %q, want 7", got)
+	}
+	if got := query.Get("timezone"); got != "America/Denver" {
+		t.Errorf("timezone = %q, want America/Denver", got)
+	}
+	if got := query.Get("precipitation_unit"); got != "inch" {
+		t.Errorf("precipitation_unit = %q, want inch", got)
+	}
+}
+
+func TestEnsembleHourlyUnmarshalCollectsMemberKeys(t *testing.T) {
+	payload := []byte(`{
+		"time": ["2025-02-01T00:00", "2025-02-01T01:00"],
+		"snowfall_gfs_seamless": [0.1, 0.2],
+		"snowfall_gfs_seamless_member01": [0.3, null],
+		"snowfall_ecmwf_ifs025_member07": [0.4],
+		"temperature_2m_gfs_seamless": [28.5, 27.1],
+		"wind_speed_10m_gfs_seamless": [12.0, 14.0]
+	}`)
+
+	var hourly EnsembleHourly
+	if err := json.Unmarshal(payload, &hourly); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if len(hourly.Time) != 2 {
+		t.Errorf("got %d timestamps, want 2", len(hourly.Time))
+	}
+	if len(hourly.Snowfall) != 3 {
+		t.Errorf("got %d snowfall members, want 3", len(hourly.Snowfall))
+	}
+	if len(hourly.Temperature2M) != 1 {
+		t.Errorf("got %d temperature members, want 1", len(hourly.Temperature2M))
+	}
+
+	member := hourly.Snowfall["snowfall_gfs_seamless_member01"]
+	if len(member) != 2 || member[0] == nil || *member[0] != 0.3 || member[1] != nil {
+		t.Errorf("member01 = %v, want [0.3, nil]", member)
+	}
+	if short := hourly.Snowfall["snowfall_ecmwf_ifs025_member07"]; len(short) != 1 {
+		t.Errorf("short member has %d values, want 1", len(short))
+	}
+}
+
+func TestCheckEnsembleResponseRejectsEmptyPayloads(t *testing.T) {
+	if err := checkEnsembleResponse(&EnsembleAPIResponse{}); err == nil {
+		t.Error("empty response accepted")
+	}
+	noMembers := &EnsembleAPIResponse{Hourly: EnsembleHourly{Time: []string{"2025-02-01T00:00"}}}
+	if err := checkEnsembleResponse(noMembers); err == nil {
+		t.Error("response without snowfall members accepted")
+	}
+}
@@ -8,7 +8,63 @@ type ForecastAPIResponse struct {
 	Timezone             string  `json:"timezone"`
 	TimezoneAbbreviation string  `json:"timezone_abbreviation"`
 	Elevation            float64 `json:"elevation"`
-	HourlyUnits          struct {
+	// Current holds instantaneous values when the current= parameter is
+	// requested. Fields are pointers so models absent from the block can be
+	// detected and fall back to hourly-derived values.
+	Current struct {
+		Time                                 string   `json:"time"`
+		Interval                             int      `json:"interval"`
+		Temperature2MGemSeamless             *float64 `json:"temperature_2m_gem_seamless"`
+		WeatherCodeGemSeamless               *int     `json:"weather_code_gem_seamless"`
+		WindSpeed10MGemSeamless              *float64 `json:"wind_speed_10m_gem_seamless"`
+		WindDirection10MGemSeamless          *float64 `json:"wind_direction_10m_gem_seamless"`
+		WindGusts10MGemSeamless              *float64 `json:"wind_gusts_10m_gem_seamless"`
+		CloudCoverGemSeamless                *int     `json:"cloud_cover_gem_seamless"`
+		RelativeHumidity2MGemSeamless        *int     `json:"relative_humidity_2m_gem_seamless"`
+		Temperature2MEcmwfIfs                *float64 `json:"temperature_2m_ecmwf_ifs"`
+		WeatherCodeEcmwfIfs                  *int     `json:"weather_code_ecmwf_ifs"`
+		WindSpeed10MEcmwfIfs                 *float64 `json:"wind_speed_10m_ecmwf_ifs"`
+		WindDirection10MEcmwfIfs             *float64 `json:"wind_direction_10m_ecmwf_ifs"`
+		WindGusts10MEcmwfIfs                 *float64 `json:"wind_gusts_10m_ecmwf_ifs"`
+		CloudCoverEcmwfIfs                   *int     `json:"cloud_cover_ecmwf_ifs"`
+		RelativeHumidity2MEcmwfIfs           *int     `json:"relative_humidity_2m_ecmwf_ifs"`
+		Temperature2MGfsSeamless             *float64 `json:"temperature_2m_gfs_seamless"`
+		WeatherCodeGfsSeamless               *int     `json:"weather_code_gfs_seamless"`
+		WindSpeed10MGfsSeamless              *float64 `json:"wind_speed_10m_gfs_seamless"`
+		WindDirection10MGfsSeamless          *float64 `json:"wind_direction_10m_gfs_seamless"`
+		WindGusts10MGfsSeamless              *float64 `json:"wind_gusts_10m_gfs_seamless"`
+		CloudCoverGfsSeamless                *int     `json:"cloud_cover_gfs_seamless"`
+		RelativeHumidity2MGfsSeamless        *int     `json:"relative_humidity_2m_gfs_seamless"`
+		Temperature2MNcepNbmConus            *float64 `json:"temperature_2m_ncep_nbm_conus"`
+		WeatherCodeNcepNbmConus              *int     `json:"weather_code_ncep_nbm_conus"`
+		WindSpeed10MNcepNbmConus             *float64 `json:"wind_speed_10m_ncep_nbm_conus"`
+		WindDirection10MNcepNbmConus         *float64 `json:"wind_direction_10m_ncep_nbm_conus"`
+		WindGusts10MNcepNbmConus             *float64 `json:"wind_gusts_10m_ncep_nbm_conus"`
+		CloudCoverNcepNbmConus               *int     `json:"cloud_cover_ncep_nbm_conus"`
+		RelativeHumidity2MNcepNbmConus       *int     `json:"relative_humidity_2m_ncep_nbm_conus"`
+		Temperature2MGfsGraphcast025         *float64 `json:"temperature_2m_gfs_graphcast025"`
+		WeatherCodeGfsGraphcast025           *int     `json:"weather_code_gfs_graphcast025"`
+		WindSpeed10MGfsGraphcast025          *float64 `json:"wind_speed_10m_gfs_graphcast025"`
+		WindDirection10MGfsGraphcast025      *float64 `json:"wind_direction_10m_gfs_graphcast025"`
+		WindGusts10MGfsGraphcast025          *float64 `json:"wind_gusts_10m_gfs_graphcast025"`
+		CloudCoverGfsGraphcast025            *int     `json:"cloud_cover_gfs_graphcast025"`
+		RelativeHumidity2MGfsGraphcast025    *int     `json:"relative_humidity_2m_gfs_graphcast025"`
+		Temperature2MEcmwfAifs025Single      *float64 `json:"temperature_2m_ecmwf_aifs025_single"`
+		WeatherCodeEcmwfAifs025Single        *int     `json:"weather_code_ecmwf_aifs025_single"`
+		WindSpeed10MEcmwfAifs025Single       *float64 `json:"wind_speed_10m_ecmwf_aifs025_single"`
+		WindDirection10MEcmwfAifs025Single   *float64 `json:"wind_direction_10m_ecmwf_aifs025_single"`
+		WindGusts10MEcmwfAifs025Single       *float64 `json:"wind_gusts_10m_ecmwf_aifs025_single"`
+		CloudCoverEcmwfAifs025Single         *int     `json:"cloud_cover_ecmwf_aifs025_single"`
+		RelativeHumidity2MEcmwfAifs025Single *int     `json:"relative_humidity_2m_ecmwf_aifs025_single"`
+		Temperature2MNcepNamConus            *float64 `json:"temperature_2m_ncep_nam_conus"`
+		WeatherCodeNcepNamConus              *int     `json:"weather_code_ncep_nam_conus"`
+		WindSpeed10MNcepNamConus             *float64 `json:"wind_speed_10m_ncep_nam_conus"`
+		WindDirection10MNcepNamConus         *float64 `json:"wind_direction_10m_ncep_nam_conus"`
+		WindGusts10MNcepNamConus             *float64 `json:"wind_gusts_10m_ncep_nam_conus"`
+		CloudCoverNcepNamConus               *int     `json:"cloud_cover_ncep_nam_conus"`
+		RelativeHumidity2MNcepNamConus       *int     `json:"relative_humidity_2m_ncep_nam_conus"`
+	} `json:"current"`
+	HourlyUnits struct {
 		Time                                       string `json:"time"`
 		FreezingLevelHeightGemSeamless             string `json:"freezing_level_height_gem_seamless"`
 		IsDayGemSeamless                           string `json:"is_day_gem_seamless"`
@@ -166,7 +222,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighGemSeamless                  []int         `json:"cloud_cover_high_gem_seamless"`
 		VisibilityGemSeamless                      []interface{} `json:"visibility_gem_seamless"`
 		WindSpeed10MGemSeamless                    []float64     `json:"wind_speed_10m_gem_seamless"`
-		WindDirection10MGemSeamless                []int         `json:"wind_direction_10m_gem_seamless"`
+		WindDirection10MGemSeamless                []float64     `json:"wind_direction_10m_gem_seamless"`
 		WindGusts10MGemSeamless                    []float64     `json:"wind_gusts_10m_gem_seamless"`
 		RelativeHumidity2MGemSeamless              []int         `json:"relative_humidity_2m_gem_seamless"`
 		RainGemSeamless                            []float64     `json:"rain_gem_seamless"`
@@ -186,7 +242,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighEcmwfIfs                     []int         `json:"cloud_cover_high_ecmwf_ifs"`
 		VisibilityEcmwfIfs                         []float64     `json:"visibility_ecmwf_ifs"`
 		WindSpeed10MEcmwfIfs                       []float64     `json:"wind_speed_10m_ecmwf_ifs"`
-		WindDirection10MEcmwfIfs                   []int         `json:"wind_direction_10m_ecmwf_ifs"`
+		WindDirection10MEcmwfIfs                   []float64     `json:"wind_direction_10m_ecmwf_ifs"`
 		WindGusts10MEcmwfIfs                       []float64     `json:"wind_gusts_10m_ecmwf_ifs"`
 		RelativeHumidity2MEcmwfIfs                 []int         `json:"relative_humidity_2m_ecmwf_ifs"`
 		RainEcmwfIfs                               []float64     `json:"rain_ecmwf_ifs"`
@@ -206,7 +262,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighGfsSeamless                  []int         `json:"cloud_cover_high_gfs_seamless"`
 		VisibilityGfsSeamless                      []float64     `json:"visibility_gfs_seamless"`
 		WindSpeed10MGfsSeamless                    []float64     `json:"wind_speed_10m_gfs_seamless"`
-		WindDirection10MGfsSeamless                []int         `json:"wind_direction_10m_gfs_seamless"`
+		WindDirection10MGfsSeamless                []float64     `json:"wind_direction_10m_gfs_seamless"`
 		WindGusts10MGfsSeamless                    []float64     `json:"wind_gusts_10m_gfs_seamless"`
 		RelativeHumidity2MGfsSeamless              []int         `json:"relative_humidity_2m_gfs_seamless"`
 		RainGfsSeamless                            []float64     `json:"rain_gfs_seamless"`
@@ -226,7 +282,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighNcepNbmConus                 []interface{} `json:"cloud_cover_high_ncep_nbm_conus"`
 		VisibilityNcepNbmConus                     []float64     `json:"visibility_ncep_nbm_conus"`
 		WindSpeed10MNcepNbmConus                   []float64     `json:"wind_speed_10m_ncep_nbm_conus"`
-		WindDirection10MNcepNbmConus               []int         `json:"wind_direction_10m_ncep_nbm_conus"`
+		WindDirection10MNcepNbmConus               []float64     `json:"wind_direction_10m_ncep_nbm_conus"`
 		WindGusts10MNcepNbmConus                   []float64     `json:"wind_gusts_10m_ncep_nbm_conus"`
 		RelativeHumidity2MNcepNbmConus             []int         `json:"relative_humidity_2m_ncep_nbm_conus"`
 		RainNcepNbmConus                           []float64     `json:"rain_ncep_nbm_conus"`
@@ -246,7 +302,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighGfsGraphcast025              []int         `json:"cloud_cover_high_gfs_graphcast025"`
 		VisibilityGfsGraphcast025                  []interface{} `json:"visibility_gfs_graphcast025"`
 		WindSpeed10MGfsGraphcast025                []float64     `json:"wind_speed_10m_gfs_graphcast025"`
-		WindDirection10MGfsGraphcast025            []int         `json:"wind_direction_10m_gfs_graphcast025"`
+		WindDirection10MGfsGraphcast025            []float64     `json:"wind_direction_10m_gfs_graphcast025"`
 		WindGusts10MGfsGraphcast025                []interface{} `json:"wind_gusts_10m_gfs_graphcast025"`
 		RelativeHumidity2MGfsGraphcast025          []interface{} `json:"relative_humidity_2m_gfs_graphcast025"`
 		RainGfsGraphcast025                        []float64     `json:"rain_gfs_graphcast025"`
@@ -266,7 +322,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighEcmwfAifs025Single           []int         `json:"cloud_cover_high_ecmwf_aifs025_single"`
 		VisibilityEcmwfAifs025Single               []interface{} `json:"visibility_ecmwf_aifs025_single"`
 		WindSpeed10MEcmwfAifs025Single             []float64     `json:"wind_speed_10m_ecmwf_aifs025_single"`
-		WindDirection10MEcmwfAifs025Single         []int         `json:"wind_direction_10m_ecmwf_aifs025_single"`
+		WindDirection10MEcmwfAifs025Single         []float64     `json:"wind_direction_10m_ecmwf_aifs025_single"`
 		WindGusts10MEcmwfAifs025Single             []interface{} `json:"wind_gusts_10m_ecmwf_aifs025_single"`
 		RelativeHumidity2MEcmwfAifs025Single       []int         `json:"relative_humidity_2m_ecmwf_aifs025_single"`
 		RainEcmwfAifs025Single                     []float64     `json:"rain_ecmwf_aifs025_single"`
@@ -286,7 +342,7 @@ type ForecastAPIResponse struct {
 		CloudCoverHighNcepNamConus                 []int         `json:"cloud_cover_high_ncep_nam_conus"`
 		VisibilityNcepNamConus                     []float64     `json:"visibility_ncep_nam_conus"`
 		WindSpeed10MNcepNamConus                   []float64     `json:"wind_speed_10m_ncep_nam_conus"`
-		WindDirection10MNcepNamConus               []int         `json:"wind_direction_10m_ncep_nam_conus"`
+		WindDirection10MNcepNamConus               []float64     `json:"wind_direction_10m_ncep_nam_conus"`
 		WindGusts10MNcepNamConus                   []float64     `json:"wind_gusts_10m_ncep_nam_conus"`
 		RelativeHumidity2MNcepNamConus             []int         `json:"relative_humidity_2m_ncep_nam_conus"`
 		RainNcepNamConus                           []float64     `json:"rain_ncep_nam_conus"`
@@ -338,36 +394,36 @@ type ForecastAPIResponse struct {
 		WeatherCodeGemSeamless                       []int         `json:"weather_code_gem_seamless"`
 		SunriseGemSeamless                           []string      `json:"sunrise_gem_seamless"`
 		SunsetGemSeamless                            []string      `json:"sunset_gem_seamless"`
-		WindDirection10MDominantGemSeamless          []int         `json:"wind_direction_10m_dominant_gem_seamless"`
+		WindDirection10MDominantGemSeamless          []float64     `json:"wind_direction_10m_dominant_gem_seamless"`
 		SnowfallWaterEquivalentSumEcmwfIfs           []float64     `json:"snowfall_water_equivalent_sum_ecmwf_ifs"`
 		WeatherCodeEcmwfIfs                          []int         `json:"weather_code_ecmwf_ifs"`
 		SunriseEcmwfIfs                              []string      `json:"sunrise_ecmwf_ifs"`
 		SunsetEcmwfIfs                               []string      `json:"sunset_ecmwf_ifs"`
-		WindDirection10MDominantEcmwfIfs             []int         `json:"wind_direction_10m_dominant_ecmwf_ifs"`
+		WindDirection10MDominantEcmwfIfs             []float64     `json:"wind_direction_10m_dominant_ecmwf_ifs"`
 		SnowfallWaterEquivalentSumGfsSeamless        []float64     `json:"snowfall_water_equivalent_sum_gfs_seamless"`
 		WeatherCodeGfsSeamless                       []int         `json:"weather_code_gfs_seamless"`
 		SunriseGfsSeamless                           []string      `json:"sunrise_gfs_seamless"`
 		SunsetGfsSeamless                            []string      `json:"sunset_gfs_seamless"`
-		WindDirection10MDominantGfsSeamless          []int         `json:"wind_direction_10m_dominant_gfs_seamless"`
+		WindDirection10MDominantGfsSeamless          []float64     `json:"wind_direction_10m_dominant_gfs_seamless"`
 		SnowfallWaterEquivalentSumNcepNbmConus       []float64     `json:"snowfall_water_equivalent_sum_ncep_nbm_conus"`
 		WeatherCodeNcepNbmConus                      []int         `json:"weather_code_ncep_nbm_conus"`
 		SunriseNcepNbmConus                          []string      `json:"sunrise_ncep_nbm_conus"`
 		SunsetNcepNbmConus                           []string      `json:"sunset_ncep_nbm_conus"`
-		WindDirection10MDominantNcepNbmConus         []int         `json:"wind_direction_10m_dominant_ncep_nbm_conus"`
+		WindDirection10MDominantNcepNbmConus         []float64     `json:"wind_direction_10m_dominant_ncep_nbm_conus"`
 		SnowfallWaterEquivalentSumGfsGraphcast025    []interface{} `json:"snowfall_water_equivalent_sum_gfs_graphcast025"`
 		WeatherCodeGfsGraphcast025                   []int         `json:"weather_code_gfs_graphcast025"`
 		SunriseGfsGraphcast025                       []string      `json:"sunrise_gfs_graphcast025"`
 		SunsetGfsGraphcast025                        []string      `json:"sunset_gfs_graphcast025"`
-		WindDirection10MDominantGfsGraphcast025      []int         `json:"wind_direction_10m_dominant_gfs_graphcast025"`
+		WindDirection10MDominantGfsGraphcast025      []float64     `json:"wind_direction_10m_dominant_gfs_graphcast025"`
 		SnowfallWaterEquivalentSumEcmwfAifs025Single []float64     `json:"snowfall_water_equivalent_sum_ecmwf_aifs025_single"`
 		WeatherCodeEcmwfAifs025Single                []int         `json:"weather_code_ecmwf_aifs025_single"`
 		SunriseEcmwfAifs025Single                    []string      `json:"sunrise_ecmwf_aifs025_single"`
 		SunsetEcmwfAifs025Single                     []string      `json:"sunset_ecmwf_aifs025_single"`
-		WindDirection10MDominantEcmwfAifs025Single   []int         `json:"wind_direction_10m_dominant_ecmwf_aifs025_single"`
+		WindDirection10MDominantEcmwfAifs025Single   []float64     `json:"wind_direction_10m_dominant_ecmwf_aifs025_single"`
 		SnowfallWaterEquivalentSumNcepNamConus       []float64     `json:"snowfall_water_equivalent_sum_ncep_nam_conus"`
 		WeatherCodeNcepNamConus                      []int         `json:"weather_code_ncep_nam_conus"`
 		SunriseNcepNamConus                          []string      `json:"sunrise_ncep_nam_conus"`
 		SunsetNcepNamConus                           []string      `json:"sunset_ncep_nam_conus"`
-		WindDirection10MDominantNcepNamConus         []int         `json:"wind_direction_10m_dominant_ncep_nam_conus"`
+		WindDirection10MDominantNcepNamConus         []float64     `json:"wind_direction_10m_dominant_ncep_nam_conus"`
 	} `json:"daily"`
 }
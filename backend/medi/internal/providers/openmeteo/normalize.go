@@ -0,0 +1,77 @@
+package openmeteo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// maxHourlyDefectFraction bounds how much of the hourly time axis may need
+// repair before the response is rejected as corrupt rather than normalized;
+// a cached upstream proxy occasionally splices runs together
+const maxHourlyDefectFraction = 0.05
+
+// NormalizeHourly validates the hourly time axis, which the mapping layer
+// assumes is strictly increasing and unique. Duplicate timestamps are
+// dropped keeping the first occurrence and out-of-order entries are sorted
+// back into place, with every per-model series reordered by the same
+// permutation so values stay aligned with their timestamps. It returns the
+// repair counts, or an error when more than maxHourlyDefectFraction of the
+// entries needed repair.
+func (r *ForecastAPIResponse) NormalizeHourly() (duplicates, outOfOrder int, err error) {
+	times := r.Hourly.Time
+	if len(times) == 0 {
+		return 0, 0, nil
+	}
+
+	// The API's fixed-width layout (2006-01-02T15:04) makes lexicographic
+	// order chronological, so no parsing is needed
+	keep := make([]int, 0, len(times))
+	seen := make(map[string]bool, len(times))
+	maxSoFar := ""
+	for i, stamp := range times {
+		if seen[stamp] {
+			duplicates++
+			continue
+		}
+		seen[stamp] = true
+		if stamp < maxSoFar {
+			outOfOrder++
+		} else {
+			maxSoFar = stamp
+		}
+		keep = append(keep, i)
+	}
+
+	affected := duplicates + outOfOrder
+	if affected == 0 {
+		return 0, 0, nil
+	}
+	if float64(affected) > maxHourlyDefectFraction*float64(len(times)) {
+		return duplicates, outOfOrder, fmt.Errorf(
+			"hourly time axis has %d duplicate and %d out-of-order entries of %d, beyond repair",
+			duplicates, outOfOrder, len(times))
+	}
+
+	sort.SliceStable(keep, func(a, b int) bool { return times[keep[a]] < times[keep[b]] })
+	reorderSeries(&r.Hourly, keep, len(times))
+	return duplicates, outOfOrder, nil
+}
+
+// reorderSeries rebuilds every slice field of the block that matches the
+// original axis length, picking elements in permutation order. Shorter or
+// absent series are left alone.
+func reorderSeries(block any, perm []int, axisLen int) {
+	v := reflect.ValueOf(block).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Slice || field.Len() != axisLen {
+			continue
+		}
+		reordered := reflect.MakeSlice(field.Type(), len(perm), len(perm))
+		for j, idx := range perm {
+			reordered.Index(j).Set(field.Index(idx))
+		}
+		field.Set(reordered)
+	}
+}
@@ -0,0 +1,117 @@
+package openmeteo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// hourlyAxisResponse builds a response whose gfs_seamless temperature series
+// mirrors its index in the given time axis, so reordering is observable
+func hourlyAxisResponse(times []string) *ForecastAPIResponse {
+	resp := &ForecastAPIResponse{}
+	resp.Hourly.Time = times
+	temperatures := make([]float64, len(times))
+	weatherCodes := make([]int, len(times))
+	for i := range times {
+		temperatures[i] = float64(i)
+		weatherCodes[i] = i
+	}
+	resp.Hourly.Temperature2MGfsSeamless = temperatures
+	resp.Hourly.WeatherCodeGfsSeamless = weatherCodes
+	return resp
+}
+
+func TestNormalizeHourlyCleanAxisUntouched(t *testing.T) {
+	resp := hourlyAxisResponse([]string{"2025-01-13T00:00", "2025-01-13T01:00", "2025-01-13T02:00"})
+
+	duplicates, outOfOrder, err := resp.NormalizeHourly()
+	if err != nil || duplicates != 0 || outOfOrder != 0 {
+		t.Fatalf("clean axis reported dup=%d ooo=%d err=%v", duplicates, outOfOrder, err)
+	}
+	if resp.Hourly.Temperature2MGfsSeamless[2] != 2 {
+		t.Error("series modified on a clean axis")
+	}
+}
+
+func TestNormalizeHourlySortsOutOfOrderEntries(t *testing.T) {
+	// 24 entries with one hour spliced out of place keeps the defect under 5%
+	times := make([]string, 0, 24)
+	for _, hour := range []string{"00", "01", "03", "02", "04", "05", "06", "07",
+		"08", "09", "10", "11", "12", "13", "14", "15",
+		"16", "17", "18", "19", "20", "21", "22", "23"} {
+		times = append(times, "2025-01-13T"+hour+":00")
+	}
+	resp := hourlyAxisResponse(times)
+
+	duplicates, outOfOrder, err := resp.NormalizeHourly()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if duplicates != 0 || outOfOrder != 1 {
+		t.Errorf("dup=%d ooo=%d, want 0 and 1", duplicates, outOfOrder)
+	}
+
+	for i := 1; i < len(resp.Hourly.Time); i++ {
+		if resp.Hourly.Time[i-1] >= resp.Hourly.Time[i] {
+			t.Fatalf("axis not strictly increasing at %d: %v", i, resp.Hourly.Time[i-1:i+1])
+		}
+	}
+	// The 03:00 value (originally index 2) must follow its timestamp to
+	// position 3, in every series
+	if resp.Hourly.Temperature2MGfsSeamless[3] != 2 || resp.Hourly.WeatherCodeGfsSeamless[3] != 2 {
+		t.Errorf("series not reordered with the axis: temp[3]=%v code[3]=%d",
+			resp.Hourly.Temperature2MGfsSeamless[3], resp.Hourly.WeatherCodeGfsSeamless[3])
+	}
+}
+
+func TestNormalizeHourlyDropsDuplicatesKeepingFirst(t *testing.T) {
+	times := make([]string, 0, 25)
+	for hour := 0; hour < 24; hour++ {
+		times = append(times, "2025-01-13T"+twoDigit(hour)+":00")
+		if hour == 5 {
+			// The proxy replayed 05:00
+			times = append(times, "2025-01-13T05:00")
+		}
+	}
+	resp := hourlyAxisResponse(times)
+
+	duplicates, outOfOrder, err := resp.NormalizeHourly()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if duplicates != 1 || outOfOrder != 0 {
+		t.Errorf("dup=%d ooo=%d, want 1 and 0", duplicates, outOfOrder)
+	}
+	if len(resp.Hourly.Time) != 24 || len(resp.Hourly.Temperature2MGfsSeamless) != 24 {
+		t.Fatalf("axis length %d, series length %d, want 24",
+			len(resp.Hourly.Time), len(resp.Hourly.Temperature2MGfsSeamless))
+	}
+	// Index 5 keeps the first occurrence's value; the replay (value 6) is gone
+	if resp.Hourly.Temperature2MGfsSeamless[5] != 5 || resp.Hourly.Temperature2MGfsSeamless[6] != 7 {
+		t.Errorf("duplicate not dropped keeping the first occurrence: %v",
+			resp.Hourly.Temperature2MGfsSeamless[4:8])
+	}
+}
+
+func TestNormalizeHourlyRejectsHeavyCorruption(t *testing.T) {
+	// 3 duplicates in 24 entries is over the 5% repair budget
+	times := make([]string, 0, 27)
+	for hour := 0; hour < 24; hour++ {
+		times = append(times, "2025-01-13T"+twoDigit(hour)+":00")
+	}
+	times = append(times, times[0], times[1], times[2])
+	resp := hourlyAxisResponse(times)
+
+	_, _, err := resp.NormalizeHourly()
+	if err == nil {
+		t.Fatal("expected an error for a heavily corrupted axis")
+	}
+	if !strings.Contains(err.Error(), "beyond repair") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func twoDigit(hour int) string {
+	return fmt.Sprintf("%02d", hour)
+}
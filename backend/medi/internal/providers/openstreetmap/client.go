@@ -1,12 +1,15 @@
 package openstreetmap
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"log/slog"
+	"medi/internal/providers/decode"
 	"net/http"
 	"net/url"
+
+	"medi/internal/providers/retry"
 )
 
 // API Docs: https://nominatim.org/release-docs/develop/api/Lookup/
@@ -16,17 +19,40 @@ const (
 )
 
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
-	logger     *slog.Logger
+	httpClient  *http.Client
+	baseURL     string
+	logger      *slog.Logger
+	retryPolicy retry.Policy
 }
 
 func NewClient(logger *slog.Logger) *Client {
 	return &Client{
-		httpClient: &http.Client{},
-		baseURL:    baseURL,
-		logger:     logger.With("component", "openstreetmap-client"),
+		httpClient:  &http.Client{},
+		baseURL:     baseURL,
+		logger:      logger.With("component", "openstreetmap-client"),
+		retryPolicy: retry.DefaultPolicy(),
+	}
+}
+
+// SetRetryPolicy overrides the default retry policy, e.g. to apply the
+// configured wait cap
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	c.retryPolicy = policy
+}
+
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// checkLookupResponse rejects a lookup that decoded to nothing at all;
+// Nominatim under load has been seen answering 200 with an empty object
+func checkLookupResponse(apiResp *LookupAPIResponse) error {
+	if apiResp.PlaceId == 0 && apiResp.DisplayName == "" {
+		return fmt.Errorf("lookup has no place")
 	}
+	return nil
 }
 
 func (c *Client) Lookup(latitude, longitude float64) (*LookupAPIResponse, error) {
@@ -48,41 +74,23 @@ func (c *Client) Lookup(latitude, longitude float64) (*LookupAPIResponse, error)
 		"url", u.String(),
 	)
 
-	// Make the HTTP request
-	resp, err := c.httpClient.Get(u.String())
+	// Make the HTTP request, retrying rate-limit, outage, and incomplete-body
+	// responses
+	apiResp, err := retry.GetJSON[LookupAPIResponse](context.Background(), c.httpClient, c.logger, c.retryPolicy,
+		u.String(), decode.DefaultMaxBytes, checkLookupResponse)
 	if err != nil {
 		c.logger.Error("failed to fetch OpenStreetMap data",
 			"latitude", latitude,
 			"longitude", longitude,
 			"error", err,
 		)
+		// Keep the typed error so callers can propagate the advised delay
+		var providerErr *retry.ProviderError
+		if errors.As(err, &providerErr) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to fetch: %w", err)
 	}
-	defer func(Body io.ReadCloser) {
-		_ = Body.Close()
-	}(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Error("OpenStreetMap API returned error",
-			"status_code", resp.StatusCode,
-			"latitude", latitude,
-			"longitude", longitude,
-			"response_body", string(body),
-		)
-		return nil, fmt.Errorf("fetch returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse the JSON response
-	var apiResp LookupAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		c.logger.Error("failed to decode OpenStreetMap response",
-			"latitude", latitude,
-			"longitude", longitude,
-			"error", err,
-		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
 
 	c.logger.Debug("successfully fetched OpenStreetMap location data",
 		"latitude", latitude,
@@ -90,5 +98,5 @@ func (c *Client) Lookup(latitude, longitude float64) (*LookupAPIResponse, error)
 		"display_name", apiResp.DisplayName,
 	)
 
-	return &apiResp, nil
+	return apiResp, nil
 }
@@ -0,0 +1,237 @@
+// Package quota keeps daily per-provider call counts against the informal
+// free-tier quotas of the upstream APIs (Nominatim, NWS, Open-Meteo). Every
+// call through an instrumented client increments a counter that rolls over
+// at UTC midnight; counts persist through a Store so a restart does not
+// reset the day's accounting. Crossing a configured soft limit logs one
+// warning per provider per day, and in rejection mode further calls fail
+// with ErrQuotaExceeded before leaving the process — cached responses never
+// reach the transport, so only fresh fetches are refused.
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded indicates a provider's soft daily limit is spent and the
+// tracker is configured to reject further calls; handlers map it to 503
+var ErrQuotaExceeded = errors.New("provider daily quota exceeded")
+
+// State is a day's counts as the Store persists them
+type State struct {
+	// Day is the UTC date the counts belong to, formatted 2006-01-02
+	Day    string           `json:"day"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+// Store persists quota state across restarts. Load returns the zero State
+// when nothing has been saved yet.
+type Store interface {
+	Load() (State, error)
+	Save(State) error
+}
+
+// FileStore persists the state as a small JSON file
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a store writing to path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the saved state; a missing file is an empty state, not an error
+func (s *FileStore) Load() (State, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("corrupt quota state at %s: %w", s.path, err)
+	}
+	return state, nil
+}
+
+// Save writes the state, replacing whatever was there
+func (s *FileStore) Save(state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// Usage is one provider's accounting for the current UTC day
+type Usage struct {
+	Provider string
+	Used     int64
+	// Limit is the configured soft limit, zero when the provider has none
+	Limit int64
+	// PercentUsed is Used against Limit, rounded; zero without a limit
+	PercentUsed int
+}
+
+// Tracker counts provider calls against soft daily limits. A nil Store
+// keeps the counts in memory only.
+type Tracker struct {
+	mu     sync.Mutex
+	store  Store
+	limits map[string]int64
+	reject bool
+	logger *slog.Logger
+	now    func() time.Time
+
+	day    string
+	counts map[string]int64
+	// warned suppresses repeat limit warnings within the day
+	warned map[string]bool
+}
+
+// NewTracker creates a tracker with the configured per-provider soft limits,
+// seeded from the store when it already holds today's counts. reject turns
+// crossing a limit from a warning into refusal of further calls.
+func NewTracker(store Store, limits map[string]int, reject bool, logger *slog.Logger) *Tracker {
+	t := &Tracker{
+		store:  store,
+		limits: make(map[string]int64, len(limits)),
+		reject: reject,
+		logger: logger,
+		now:    time.Now,
+		counts: map[string]int64{},
+		warned: map[string]bool{},
+	}
+	for provider, limit := range limits {
+		t.limits[provider] = int64(limit)
+	}
+
+	if store != nil {
+		state, err := store.Load()
+		if err != nil && logger != nil {
+			logger.Warn("quota state unavailable, starting from zero", "error", err)
+		}
+		t.day = state.Day
+		if state.Counts != nil {
+			t.counts = state.Counts
+		}
+	}
+	return t
+}
+
+// SetClock overrides the time source, for tests
+func (t *Tracker) SetClock(now func() time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.now = now
+}
+
+// Record accounts one call to the provider. In rejection mode a provider at
+// or past its limit gets an error wrapping ErrQuotaExceeded and the call is
+// not counted, since it will not be made.
+func (t *Tracker) Record(provider string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollover()
+
+	limit := t.limits[provider]
+	if t.reject && limit > 0 && t.counts[provider] >= limit {
+		return fmt.Errorf("%w: %s used %d of %d today", ErrQuotaExceeded, provider, t.counts[provider], limit)
+	}
+
+	t.counts[provider]++
+	if limit > 0 && t.counts[provider] >= limit && !t.warned[provider] {
+		t.warned[provider] = true
+		if t.logger != nil {
+			t.logger.Warn("provider daily quota soft limit reached",
+				"provider", provider,
+				"used", t.counts[provider],
+				"limit", limit,
+			)
+		}
+	}
+
+	if t.store != nil {
+		if err := t.store.Save(State{Day: t.day, Counts: t.counts}); err != nil && t.logger != nil {
+			t.logger.Warn("failed to persist quota state", "error", err)
+		}
+	}
+	return nil
+}
+
+// Snapshot returns the current UTC day and its per-provider usage, covering
+// every provider with either calls today or a configured limit
+func (t *Tracker) Snapshot() (string, map[string]Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollover()
+
+	usage := make(map[string]Usage, len(t.counts))
+	for provider, used := range t.counts {
+		usage[provider] = t.usageLocked(provider, used)
+	}
+	for provider := range t.limits {
+		if _, ok := usage[provider]; !ok {
+			usage[provider] = t.usageLocked(provider, 0)
+		}
+	}
+	return t.day, usage
+}
+
+func (t *Tracker) usageLocked(provider string, used int64) Usage {
+	u := Usage{Provider: provider, Used: used, Limit: t.limits[provider]}
+	if u.Limit > 0 {
+		u.PercentUsed = int((used*100 + u.Limit/2) / u.Limit)
+	}
+	return u
+}
+
+// rollover resets the counts when the UTC day has moved past the one the
+// counts belong to. Callers hold the lock.
+func (t *Tracker) rollover() {
+	today := t.now().UTC().Format(time.DateOnly)
+	if t.day == today {
+		return
+	}
+	t.day = today
+	t.counts = map[string]int64{}
+	t.warned = map[string]bool{}
+}
+
+// Client wraps base so every request is accounted before it leaves; a
+// rejected request fails without reaching the network or the inner transport
+func (t *Tracker) Client(provider string, base *http.Client) *http.Client {
+	if base == nil {
+		base = &http.Client{}
+	}
+	inner := http.DefaultTransport
+	if base.Transport != nil {
+		inner = base.Transport
+	}
+	wrapped := *base
+	wrapped.Transport = &transport{tracker: t, provider: provider, inner: inner}
+	return &wrapped
+}
+
+// transport accounts each request against the tracker before delegating
+type transport struct {
+	tracker  *Tracker
+	provider string
+	inner    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.tracker.Record(t.provider); err != nil {
+		return nil, err
+	}
+	return t.inner.RoundTrip(req)
+}
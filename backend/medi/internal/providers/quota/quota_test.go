@@ -0,0 +1,168 @@
+package quota
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore is an in-process Store for tests that do not need a file
+type memoryStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+func (s *memoryStore) Load() (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+func (s *memoryStore) Save(state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	return nil
+}
+
+func fixedClock(at time.Time) func() time.Time {
+	return func() time.Time { return at }
+}
+
+func TestTrackerCountsAcrossGoroutines(t *testing.T) {
+	tracker := NewTracker(nil, nil, false, nil)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if err := tracker.Record("nws"); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, usage := tracker.Snapshot()
+	if usage["nws"].Used != 1000 {
+		t.Errorf("Used = %d after 10x100 concurrent records, want 1000", usage["nws"].Used)
+	}
+}
+
+func TestFileStorePersistenceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotas.json")
+	at := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	tracker := NewTracker(NewFileStore(path), map[string]int{"openstreetmap": 1000}, false, nil)
+	tracker.SetClock(fixedClock(at))
+	for i := 0; i < 5; i++ {
+		if err := tracker.Record("openstreetmap"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A fresh tracker over the same store stands in for a restart
+	reloaded := NewTracker(NewFileStore(path), map[string]int{"openstreetmap": 1000}, false, nil)
+	reloaded.SetClock(fixedClock(at.Add(time.Hour)))
+
+	day, usage := reloaded.Snapshot()
+	if day != "2025-03-10" {
+		t.Errorf("day = %q after reload, want 2025-03-10", day)
+	}
+	if got := usage["openstreetmap"]; got.Used != 5 || got.Limit != 1000 {
+		t.Errorf("usage = %+v after reload, want 5 used of 1000", got)
+	}
+}
+
+func TestTrackerRollsOverAtUTCMidnight(t *testing.T) {
+	at := time.Date(2025, 3, 10, 23, 50, 0, 0, time.UTC)
+	tracker := NewTracker(&memoryStore{}, nil, false, nil)
+	tracker.SetClock(fixedClock(at))
+
+	for i := 0; i < 3; i++ {
+		if err := tracker.Record("openmeteo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tracker.SetClock(fixedClock(at.Add(20 * time.Minute)))
+	day, usage := tracker.Snapshot()
+	if day != "2025-03-11" {
+		t.Errorf("day = %q past UTC midnight, want 2025-03-11", day)
+	}
+	if usage["openmeteo"].Used != 0 {
+		t.Errorf("Used = %d past UTC midnight, want 0", usage["openmeteo"].Used)
+	}
+
+	if err := tracker.Record("openmeteo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, usage := tracker.Snapshot(); usage["openmeteo"].Used != 1 {
+		t.Errorf("Used = %d on the new day, want 1", usage["openmeteo"].Used)
+	}
+}
+
+func TestTrackerRejectionMode(t *testing.T) {
+	tracker := NewTracker(nil, map[string]int{"openstreetmap": 2}, true, nil)
+
+	for i := 0; i < 2; i++ {
+		if err := tracker.Record("openstreetmap"); err != nil {
+			t.Fatalf("record %d under the limit: %v", i+1, err)
+		}
+	}
+
+	err := tracker.Record("openstreetmap")
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("record past the limit = %v, want ErrQuotaExceeded", err)
+	}
+	if _, usage := tracker.Snapshot(); usage["openstreetmap"].Used != 2 {
+		t.Errorf("Used = %d, rejected calls must not count", usage["openstreetmap"].Used)
+	}
+
+	// Other providers are unaffected
+	if err := tracker.Record("nws"); err != nil {
+		t.Errorf("record for an unlimited provider: %v", err)
+	}
+}
+
+func TestTrackerWarnOnlyModeKeepsCounting(t *testing.T) {
+	tracker := NewTracker(nil, map[string]int{"nws": 2}, false, nil)
+
+	for i := 0; i < 5; i++ {
+		if err := tracker.Record("nws"); err != nil {
+			t.Fatalf("record %d in warn-only mode: %v", i+1, err)
+		}
+	}
+	_, usage := tracker.Snapshot()
+	if got := usage["nws"]; got.Used != 5 || got.PercentUsed != 250 {
+		t.Errorf("usage = %+v, want 5 used at 250%%", got)
+	}
+}
+
+func TestClientRejectsBeforeTheNetwork(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer server.Close()
+
+	tracker := NewTracker(nil, map[string]int{"nws": 1}, true, nil)
+	client := tracker.Client("nws", nil)
+
+	if _, err := client.Get(server.URL); err != nil {
+		t.Fatalf("first request under the limit: %v", err)
+	}
+	if _, err := client.Get(server.URL); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("second request = %v, want ErrQuotaExceeded", err)
+	}
+	if hits != 1 {
+		t.Errorf("server saw %d requests, the rejected one must not reach it", hits)
+	}
+}
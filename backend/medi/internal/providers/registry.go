@@ -0,0 +1,207 @@
+// Package providers assembles the external data clients behind the
+// interfaces the domain services consume, so swapping implementations
+// app-wide (mock mode, instrumentation, record/replay) happens in exactly
+// one place instead of inside each service constructor.
+package providers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/providers/cotrip"
+	"medi/internal/providers/health"
+	"medi/internal/providers/metno"
+	"medi/internal/providers/mock"
+	"medi/internal/providers/nac"
+	"medi/internal/providers/nws"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/providers/openstreetmap"
+	"medi/internal/providers/quota"
+	"medi/internal/providers/retry"
+	"medi/internal/providers/usgs"
+)
+
+// The interfaces below mirror the consumer-side definitions in the domain
+// packages. Keeping them structurally identical lets a registry field satisfy
+// a service constructor without this package importing the services, which
+// would create an import cycle.
+
+// WeatherForecastProvider fetches multi-model weather forecasts.
+type WeatherForecastProvider interface {
+	GetForecast(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error)
+}
+
+// EnsembleForecastProvider fetches individual ensemble member runs.
+type EnsembleForecastProvider interface {
+	GetEnsemble(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.EnsembleAPIResponse, error)
+}
+
+// FallbackForecastProvider fetches a single-model fallback forecast.
+type FallbackForecastProvider interface {
+	GetForecast(ctx context.Context, latitude, longitude, elevationMeters float64) (*metno.CompactAPIResponse, error)
+}
+
+// ForecastDiscussionProvider fetches NWS area forecast discussions.
+type ForecastDiscussionProvider interface {
+	GetPoint(latitude, longitude float64) (*nws.PointAPIResponse, error)
+	GetAreaForecastDiscussion(locationId string) (*nws.AFDAPIResponse, error)
+}
+
+// ElevationProvider fetches point elevation data.
+type ElevationProvider interface {
+	GetElevationPoint(latitude, longitude float64) (*usgs.ElevationPointAPIResponse, error)
+}
+
+// BulkElevationProvider fetches elevations for many points per request.
+type BulkElevationProvider interface {
+	GetElevations(ctx context.Context, latitudes, longitudes []float64) ([]float64, error)
+}
+
+// ReverseGeocodeProvider resolves coordinates to place names.
+type ReverseGeocodeProvider interface {
+	Lookup(latitude, longitude float64) (*openstreetmap.LookupAPIResponse, error)
+}
+
+// MapLayerProvider fetches the avalanche forecast zone polygons.
+type MapLayerProvider interface {
+	GetMapLayer() (*nac.MapLayerResponse, error)
+}
+
+// AvalancheForecastProvider fetches avalanche forecasts per center and zone.
+type AvalancheForecastProvider interface {
+	GetForecast(centerId string, zoneId int) (*nac.ForecastResponse, error)
+}
+
+// AccidentProvider fetches the avalanche accident dataset.
+type AccidentProvider interface {
+	GetAccidents() ([]nac.Accident, error)
+}
+
+// RoadConditionsProvider fetches state DOT road condition segments and
+// reports whether a coordinate is inside its coverage area.
+type RoadConditionsProvider interface {
+	GetRoadConditions() (*cotrip.RoadConditionsAPIResponse, error)
+	Covers(latitude, longitude float64) bool
+}
+
+// Providers is the registry of external data providers handed to the service
+// constructors.
+type Providers struct {
+	WeatherForecast    WeatherForecastProvider
+	Ensemble           EnsembleForecastProvider // nil when no ensemble upstream exists
+	FallbackForecast   FallbackForecastProvider // nil when no fallback exists
+	ForecastDiscussion ForecastDiscussionProvider
+	Elevation          ElevationProvider
+	BulkElevation      BulkElevationProvider // nil when no batch elevation upstream exists
+	ReverseGeocode     ReverseGeocodeProvider
+	AvalancheMapLayer  MapLayerProvider
+	AvalancheForecast  AvalancheForecastProvider
+	Accidents          AccidentProvider
+	RoadConditions     RoadConditionsProvider // nil when no state DOT feed applies
+
+	// Health records the recent call history of every live client; nil for
+	// the mock registries, which make no HTTP calls
+	Health *health.Recorder
+	// Quota counts every live client's calls against the configured soft
+	// daily limits; nil for the mock registries
+	Quota *quota.Tracker
+}
+
+// NewLive assembles the production HTTP clients, each routed through the
+// health recorder's instrumented transport so the health endpoint can report
+// recent per-provider call history.
+func NewLive(cfg *config.Config, logger *slog.Logger) *Providers {
+	recorder := health.NewRecorder()
+
+	var quotaStore quota.Store
+	if cfg.Providers.QuotaStatePath != "" {
+		quotaStore = quota.NewFileStore(cfg.Providers.QuotaStatePath)
+	}
+	tracker := quota.NewTracker(quotaStore, cfg.Providers.QuotaLimits, cfg.Providers.QuotaRejectOverLimit, logger)
+
+	// Quota wraps outermost so a rejected call is never recorded as a
+	// provider failure by the health transport underneath
+	instrument := func(name string) *http.Client {
+		return tracker.Client(name, recorder.Client(name))
+	}
+
+	nacClient := nac.NewClient(logger)
+	nacClient.SetHTTPClient(instrument("nac"))
+
+	// Apply the configured retry wait cap to the rate-limited upstreams
+	retryPolicy := retry.DefaultPolicy()
+	if cfg.Providers.RetryMaxDelaySeconds > 0 {
+		retryPolicy.MaxDelay = time.Duration(cfg.Providers.RetryMaxDelaySeconds) * time.Second
+	}
+	nwsClient := nws.NewClient(logger)
+	nwsClient.SetRetryPolicy(retryPolicy)
+	nwsClient.SetHTTPClient(instrument("nws"))
+	osmClient := openstreetmap.NewClient(logger)
+	osmClient.SetRetryPolicy(retryPolicy)
+	osmClient.SetHTTPClient(instrument("openstreetmap"))
+
+	weatherClient := openmeteo.NewClient(logger)
+	weatherClient.SetHTTPClient(instrument("openmeteo"))
+	ensembleClient := openmeteo.NewEnsembleClient(logger)
+	ensembleClient.SetHTTPClient(instrument("openmeteo-ensemble"))
+	elevationClient := openmeteo.NewElevationClient(logger)
+	elevationClient.SetHTTPClient(instrument("openmeteo-elevation"))
+	metnoClient := metno.NewClient(logger)
+	metnoClient.SetHTTPClient(instrument("metno"))
+	usgsClient := usgs.NewClient(logger)
+	usgsClient.SetHTTPClient(instrument("usgs"))
+	cotripClient := cotrip.NewClient(cfg.Providers.COTripAPIKey, logger)
+	cotripClient.SetHTTPClient(instrument("cotrip"))
+
+	return &Providers{
+		WeatherForecast:    weatherClient,
+		Ensemble:           ensembleClient,
+		FallbackForecast:   metnoClient,
+		ForecastDiscussion: nwsClient,
+		Elevation:          usgsClient,
+		BulkElevation:      elevationClient,
+		ReverseGeocode:     osmClient,
+		AvalancheMapLayer:  nacClient,
+		AvalancheForecast:  nacClient,
+		Accidents:          nacClient,
+		RoadConditions:     cotripClient,
+		Health:             recorder,
+		Quota:              tracker,
+	}
+}
+
+// NewMock assembles deterministic in-memory providers so the whole API runs
+// self-contained. There is no mock fallback forecast provider — the mock
+// primary never fails unless an error rate is configured — and no mock
+// ensemble provider, so the probabilistic snowfall block is unavailable.
+// There is also no mock bulk elevation provider; the terrain sampler falls
+// back to per-point lookups against the mock elevation client.
+func NewMock(logger *slog.Logger, opts mock.Options) *Providers {
+	weatherClient := mock.NewWeatherClient(logger, opts)
+	locationClient := mock.NewLocationClient(logger, opts)
+	avalancheClient := mock.NewAvalancheClient(logger, opts)
+	return &Providers{
+		WeatherForecast:    weatherClient,
+		ForecastDiscussion: weatherClient,
+		Elevation:          locationClient,
+		ReverseGeocode:     locationClient,
+		AvalancheMapLayer:  avalancheClient,
+		AvalancheForecast:  avalancheClient,
+		Accidents:          avalancheClient,
+	}
+}
+
+// NewScenario assembles the mock registry with the weather and avalanche
+// forecasts replaced by a scripted storyline, so demos play out the same storm
+// at every location.
+func NewScenario(scenario *mock.Scenario, logger *slog.Logger, opts mock.Options) *Providers {
+	p := NewMock(logger, opts)
+	weatherClient := mock.NewScenarioWeatherClient(logger, opts, scenario)
+	p.WeatherForecast = weatherClient
+	p.ForecastDiscussion = weatherClient
+	p.AvalancheForecast = mock.NewScenarioAvalancheClient(logger, opts, scenario)
+	return p
+}
@@ -0,0 +1,285 @@
+// Package retry implements the retry behavior shared by the provider HTTP
+// clients. Rate-limit responses (429) and transient upstream outages (503)
+// are retried with exponential backoff, except that upstream Retry-After
+// advice overrides the blind backoff. Waits are capped so an absurd header
+// cannot stall a request indefinitely.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"medi/internal/providers/decode"
+)
+
+// Default policy values used when a client does not override them
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 500 * time.Millisecond
+	DefaultMaxDelay    = 30 * time.Second
+)
+
+// Codes classifying retryable failures that are not plain status codes: a
+// 200 whose body never arrived or was cut off mid-JSON
+const (
+	CodeEmptyResponse     = "empty_response"
+	CodeTruncatedResponse = "truncated_response"
+)
+
+// ProviderError is returned when retries are exhausted on a retryable status.
+// It keeps the upstream's advised delay so callers can propagate it, e.g. in
+// a Retry-After header on our own 503 response.
+type ProviderError struct {
+	StatusCode int
+	// Code classifies failures beyond the status code, such as an empty or
+	// truncated 200 body; empty for plain status retries
+	Code string
+	// RetryAfter is the delay the upstream advised, already capped at the
+	// policy maximum; zero when the upstream gave no advice
+	RetryAfter time.Duration
+	Body       string
+}
+
+func (e *ProviderError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("fetch returned status %d (%s): %s", e.StatusCode, e.Code, e.Body)
+	}
+	return fmt.Sprintf("fetch returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Policy controls how requests are retried
+type Policy struct {
+	// MaxAttempts is the total number of tries, including the first
+	MaxAttempts int
+	// BaseDelay is the first backoff wait; each retry doubles it
+	BaseDelay time.Duration
+	// MaxDelay caps every wait, including upstream Retry-After advice
+	MaxDelay time.Duration
+	// Sleep is the wait function, injectable for tests; nil means time.Sleep
+	Sleep func(time.Duration)
+	// Now is the time source for HTTP-date Retry-After headers, nil means
+	// time.Now
+	Now func() time.Time
+}
+
+// DefaultPolicy returns the policy the provider clients start from
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: DefaultMaxAttempts,
+		BaseDelay:   DefaultBaseDelay,
+		MaxDelay:    DefaultMaxDelay,
+	}
+}
+
+func (p Policy) sleep(d time.Duration) {
+	if p.Sleep != nil {
+		p.Sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+func (p Policy) now() time.Time {
+	if p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
+
+// retryable reports whether a status code indicates a transient condition
+// worth retrying
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// Get issues a GET request, retrying transport errors and retryable statuses
+// per the policy. Non-retryable responses, including other error statuses,
+// are returned to the caller untouched. When retries are exhausted on a
+// retryable status the returned error is a *ProviderError.
+func Get(client *http.Client, logger *slog.Logger, policy Policy, url string) (*http.Response, error) {
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		resp, err := client.Get(url)
+		if err != nil {
+			lastErr = err
+			if attempt == policy.MaxAttempts {
+				break
+			}
+			policy.sleep(policy.backoff(attempt))
+			continue
+		}
+
+		if !retryable(resp.StatusCode) {
+			return resp, nil
+		}
+
+		body := readBody(resp)
+		wait, advised := policy.retryWait(resp, attempt, logger)
+		lastErr = &ProviderError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: advised,
+			Body:       body,
+		}
+		if attempt == policy.MaxAttempts {
+			break
+		}
+		policy.sleep(wait)
+	}
+	return nil, lastErr
+}
+
+// GetJSON issues a GET and decodes the 200 response body into a fresh T.
+// Beyond what Get retries, a 200 whose body is empty or truncated counts as
+// retryable too; some upstreams shed load that way instead of sending a 503.
+// A non-nil check lets the provider reject responses that decoded but are
+// structurally useless (e.g. a forecast with no timestamps); a rejection is
+// returned to the caller without retrying.
+func GetJSON[T any](ctx context.Context, client *http.Client, logger *slog.Logger, policy Policy, url string, maxBytes int64, check func(*T) error) (*T, error) {
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			lastErr = err
+			if attempt == policy.MaxAttempts {
+				break
+			}
+			policy.sleep(policy.backoff(attempt))
+			continue
+		}
+
+		if retryable(resp.StatusCode) {
+			body := readBody(resp)
+			wait, advised := policy.retryWait(resp, attempt, logger)
+			lastErr = &ProviderError{
+				StatusCode: resp.StatusCode,
+				RetryAfter: advised,
+				Body:       body,
+			}
+			if attempt == policy.MaxAttempts {
+				break
+			}
+			policy.sleep(wait)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetch returned status %d: %s", resp.StatusCode, readBody(resp))
+		}
+
+		// Decode into a fresh value each attempt so a truncated body cannot
+		// leave partial fields behind a later successful decode
+		value := new(T)
+		err = decode.JSON(resp, maxBytes, value)
+		_ = resp.Body.Close()
+		if err != nil {
+			var code string
+			switch {
+			case errors.Is(err, decode.ErrEmptyBody):
+				code = CodeEmptyResponse
+			case errors.Is(err, decode.ErrTruncatedBody):
+				code = CodeTruncatedResponse
+			default:
+				return nil, err
+			}
+			logger.Warn("retrying incomplete provider response",
+				"code", code,
+				"attempt", attempt,
+				"error", err,
+			)
+			lastErr = &ProviderError{
+				StatusCode: resp.StatusCode,
+				Code:       code,
+				Body:       err.Error(),
+			}
+			if attempt == policy.MaxAttempts {
+				break
+			}
+			policy.sleep(policy.backoff(attempt))
+			continue
+		}
+
+		if check != nil {
+			if err := check(value); err != nil {
+				return nil, fmt.Errorf("response failed sanity check: %w", err)
+			}
+		}
+		return value, nil
+	}
+	return nil, lastErr
+}
+
+// readBody drains and closes a retryable response's body for error reporting
+func readBody(resp *http.Response) string {
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	return string(body)
+}
+
+// retryWait picks the wait before the next attempt: the upstream's advice
+// when given, otherwise exponential backoff, both capped at MaxDelay. It
+// returns the wait and the capped advised delay (zero without advice).
+func (p Policy) retryWait(resp *http.Response, attempt int, logger *slog.Logger) (wait, advised time.Duration) {
+	advice, ok := parseRetryAfter(resp, p.now())
+	if !ok {
+		return p.backoff(attempt), 0
+	}
+	if advice > p.MaxDelay {
+		logger.Warn("upstream advised a delay past the retry cap",
+			"status_code", resp.StatusCode,
+			"advised", advice,
+			"cap", p.MaxDelay,
+		)
+		advice = p.MaxDelay
+	}
+	return advice, advice
+}
+
+// backoff computes the exponential wait after the given attempt, capped at
+// MaxDelay
+func (p Policy) backoff(attempt int) time.Duration {
+	wait := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxDelay {
+		wait = p.MaxDelay
+	}
+	return wait
+}
+
+// parseRetryAfter reads the response's retry advice: the Retry-After header
+// in its delta-seconds or HTTP-date form, falling back to the X-RateLimit
+// style reset header some APIs send instead
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	for _, header := range []string{"Retry-After", "X-RateLimit-Reset"} {
+		value := resp.Header.Get(header)
+		if value == "" {
+			continue
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			wait := at.Sub(now)
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,305 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// fakeSleeper records requested waits instead of sleeping
+type fakeSleeper struct {
+	waits []time.Duration
+}
+
+func (f *fakeSleeper) sleep(d time.Duration) {
+	f.waits = append(f.waits, d)
+}
+
+func testPolicy(sleeper *fakeSleeper, now time.Time) Policy {
+	policy := DefaultPolicy()
+	policy.Sleep = sleeper.sleep
+	policy.Now = func() time.Time { return now }
+	return policy
+}
+
+func TestRetryAfterSecondsForm(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	resp, err := Get(server.Client(), testLogger(), testPolicy(sleeper, time.Now()), server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("final status = %d, want 200", resp.StatusCode)
+	}
+	if requests != 3 {
+		t.Errorf("server saw %d requests, want 3", requests)
+	}
+	want := []time.Duration{2 * time.Second, 2 * time.Second}
+	if len(sleeper.waits) != len(want) || sleeper.waits[0] != want[0] || sleeper.waits[1] != want[1] {
+		t.Errorf("waits = %v, want %v", sleeper.waits, want)
+	}
+}
+
+func TestRetryAfterHTTPDateForm(t *testing.T) {
+	now := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", now.Add(5*time.Second).Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	resp, err := Get(server.Client(), testLogger(), testPolicy(sleeper, now), server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if len(sleeper.waits) != 1 || sleeper.waits[0] != 5*time.Second {
+		t.Errorf("waits = %v, want [5s]", sleeper.waits)
+	}
+}
+
+func TestRetryAfterPastCapIsClamped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	policy := testPolicy(sleeper, time.Now())
+	policy.MaxDelay = 5 * time.Second
+
+	_, err := Get(server.Client(), testLogger(), policy, server.URL)
+	if err == nil {
+		t.Fatal("Get succeeded against an always-429 server")
+	}
+
+	for _, wait := range sleeper.waits {
+		if wait != 5*time.Second {
+			t.Errorf("wait = %v, want the 5s cap", wait)
+		}
+	}
+
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) {
+		t.Fatalf("error is %T, want *ProviderError", err)
+	}
+	if providerErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("ProviderError status = %d, want 429", providerErr.StatusCode)
+	}
+	if providerErr.RetryAfter != 5*time.Second {
+		t.Errorf("ProviderError RetryAfter = %v, want capped 5s", providerErr.RetryAfter)
+	}
+}
+
+func TestBlindBackoffWithoutAdvice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	_, err := Get(server.Client(), testLogger(), testPolicy(sleeper, time.Now()), server.URL)
+	if err == nil {
+		t.Fatal("Get succeeded against an always-503 server")
+	}
+
+	want := []time.Duration{DefaultBaseDelay, 2 * DefaultBaseDelay}
+	if len(sleeper.waits) != len(want) || sleeper.waits[0] != want[0] || sleeper.waits[1] != want[1] {
+		t.Errorf("waits = %v, want exponential %v", sleeper.waits, want)
+	}
+
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) {
+		t.Fatalf("error is %T, want *ProviderError", err)
+	}
+	if providerErr.RetryAfter != 0 {
+		t.Errorf("ProviderError RetryAfter = %v, want 0 without advice", providerErr.RetryAfter)
+	}
+}
+
+func TestNonRetryableStatusPassesThrough(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	resp, err := Get(server.Client(), testLogger(), testPolicy(sleeper, time.Now()), server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want the server's 404", resp.StatusCode)
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests, want 1 (no retries)", requests)
+	}
+	if len(sleeper.waits) != 0 {
+		t.Errorf("waits = %v, want none", sleeper.waits)
+	}
+}
+
+type jsonPayload struct {
+	Name string `json:"name"`
+}
+
+func TestGetJSONRetriesEmptyBody(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 1 {
+			return // 200 with no body at all
+		}
+		_, _ = w.Write([]byte(`{"name": "aspen"}`))
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	payload, err := GetJSON[jsonPayload](context.Background(), server.Client(), testLogger(),
+		testPolicy(sleeper, time.Now()), server.URL, 64, nil)
+	if err != nil {
+		t.Fatalf("GetJSON returned error: %v", err)
+	}
+	if payload.Name != "aspen" {
+		t.Errorf("payload = %+v", payload)
+	}
+	if requests != 2 {
+		t.Errorf("server saw %d requests, want 2", requests)
+	}
+}
+
+func TestGetJSONRetriesTruncatedBody(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 1 {
+			_, _ = w.Write([]byte(`{"name": "asp`)) // cut off mid-value
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "aspen"}`))
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	payload, err := GetJSON[jsonPayload](context.Background(), server.Client(), testLogger(),
+		testPolicy(sleeper, time.Now()), server.URL, 64, nil)
+	if err != nil {
+		t.Fatalf("GetJSON returned error: %v", err)
+	}
+	if payload.Name != "aspen" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestGetJSONExhaustedEmptyBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	_, err := GetJSON[jsonPayload](context.Background(), server.Client(), testLogger(),
+		testPolicy(sleeper, time.Now()), server.URL, 64, nil)
+
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) {
+		t.Fatalf("error is %T (%v), want *ProviderError", err, err)
+	}
+	if providerErr.Code != CodeEmptyResponse {
+		t.Errorf("Code = %q, want %q", providerErr.Code, CodeEmptyResponse)
+	}
+	if len(sleeper.waits) != DefaultMaxAttempts-1 {
+		t.Errorf("waits = %v, want %d backoffs", sleeper.waits, DefaultMaxAttempts-1)
+	}
+}
+
+func TestGetJSONTruncatedErrorReportsBytes(t *testing.T) {
+	body := `{"name": "asp`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	_, err := GetJSON[jsonPayload](context.Background(), server.Client(), testLogger(),
+		testPolicy(sleeper, time.Now()), server.URL, 64, nil)
+
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) {
+		t.Fatalf("error is %T (%v), want *ProviderError", err, err)
+	}
+	if providerErr.Code != CodeTruncatedResponse {
+		t.Errorf("Code = %q, want %q", providerErr.Code, CodeTruncatedResponse)
+	}
+	if want := fmt.Sprintf("after %d bytes", len(body)); !strings.Contains(providerErr.Body, want) {
+		t.Errorf("error body %q does not report %q", providerErr.Body, want)
+	}
+}
+
+func TestGetJSONSanityRejectionDoesNotRetry(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	sleeper := &fakeSleeper{}
+	check := func(p *jsonPayload) error {
+		if p.Name == "" {
+			return errors.New("payload has no name")
+		}
+		return nil
+	}
+	_, err := GetJSON[jsonPayload](context.Background(), server.Client(), testLogger(),
+		testPolicy(sleeper, time.Now()), server.URL, 64, check)
+	if err == nil || !strings.Contains(err.Error(), "sanity check") {
+		t.Fatalf("error = %v, want a sanity check rejection", err)
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests, want 1 (no retries)", requests)
+	}
+}
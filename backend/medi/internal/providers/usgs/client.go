@@ -1,10 +1,10 @@
 package usgs
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"medi/internal/providers/decode"
 	"net/http"
 	"net/url"
 )
@@ -29,6 +29,12 @@ func NewClient(logger *slog.Logger) *Client {
 	}
 }
 
+// SetHTTPClient overrides the default HTTP client, e.g. to route requests
+// through an instrumented transport
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
 func (c *Client) GetElevationPoint(latitude, longitude float64) (*ElevationPointAPIResponse, error) {
 	// Build URL with query parameters
 	u, err := url.Parse(c.baseURL)
@@ -75,13 +81,13 @@ func (c *Client) GetElevationPoint(latitude, longitude float64) (*ElevationPoint
 
 	// Parse the JSON response
 	var apiResp ElevationPointAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decode.JSON(resp, decode.DefaultMaxBytes, &apiResp); err != nil {
 		c.logger.Error("failed to decode USGS response",
 			"latitude", latitude,
 			"longitude", longitude,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	c.logger.Debug("successfully fetched USGS elevation data",
@@ -0,0 +1,336 @@
+// Package schema derives standalone JSON Schema documents from response
+// structs by reflection, for clients that validate payloads outside OpenAPI.
+// The generator covers what the API's serialization actually produces: json
+// tags and omitempty, embedded structs with outer fields shadowing promoted
+// ones, pointers and nil slices/maps as null, map types like the weather
+// service's ModelValues as objects with constrained values, and enum value
+// sets injected per type or per property.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft identifies the JSON Schema dialect the generator emits
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Options controls generation for one document
+type Options struct {
+	// Title names the document
+	Title string
+	// Enums constrains named types to fixed value sets, keyed by the Go
+	// type, e.g. a danger level's 0-5 range
+	Enums map[reflect.Type][]any
+	// PropertyEnums constrains fields whose Go type is too plain to key on,
+	// addressed as "StructName.jsonName". For map- and slice-typed fields
+	// the constraint lands on the element schema.
+	PropertyEnums map[string][]any
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Generate builds a JSON Schema document for the struct type of root. Named
+// struct types become definitions referenced by $ref, so recursive and shared
+// types stay readable.
+func Generate(root any, opts Options) (map[string]any, error) {
+	t := reflect.TypeOf(root)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema root must be a struct, got %T", root)
+	}
+
+	g := &generator{
+		opts:  opts,
+		defs:  map[string]any{},
+		names: map[reflect.Type]string{},
+		taken: map[string]bool{},
+	}
+	rootSchema, err := g.schemaFor(t)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := map[string]any{"$schema": Draft}
+	if opts.Title != "" {
+		doc["title"] = opts.Title
+	}
+	for key, value := range rootSchema {
+		doc[key] = value
+	}
+	// Walking a struct whose field is later shadowed by an outer DTO can
+	// leave definitions nothing references; prune them so the document only
+	// describes shapes that actually appear
+	g.pruneDefs(rootSchema)
+	if len(g.defs) > 0 {
+		doc["definitions"] = g.defs
+	}
+	return doc, nil
+}
+
+// pruneDefs drops definitions unreachable from the root schema
+func (g *generator) pruneDefs(root map[string]any) {
+	reachable := map[string]bool{}
+	var mark func(node any)
+	mark = func(node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			if ref, ok := n["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/definitions/")
+				if !reachable[name] {
+					reachable[name] = true
+					mark(g.defs[name])
+				}
+			}
+			for _, value := range n {
+				mark(value)
+			}
+		case []any:
+			for _, value := range n {
+				mark(value)
+			}
+		}
+	}
+	mark(root)
+	for name := range g.defs {
+		if !reachable[name] {
+			delete(g.defs, name)
+		}
+	}
+}
+
+type generator struct {
+	opts Options
+	// defs collects named struct schemas; names and taken keep the
+	// type-to-definition-name mapping collision free
+	defs  map[string]any
+	names map[reflect.Type]string
+	taken map[string]bool
+}
+
+// schemaFor builds the schema for one type. Named structs come back as a
+// $ref; everything else is inline.
+func (g *generator) schemaFor(t reflect.Type) (map[string]any, error) {
+	if values, ok := g.opts.Enums[t]; ok {
+		s, err := g.scalarSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		s["enum"] = values
+		return s, nil
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(elem), nil
+	case reflect.Slice:
+		items, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		// A nil slice marshals as null, so every slice field is nullable
+		return map[string]any{"type": []any{"array", "null"}, "items": items}, nil
+	case reflect.Array:
+		items, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items, "minItems": t.Len(), "maxItems": t.Len()}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map type %s has non-string keys", t)
+		}
+		values, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		// Covers ModelValues[T] and friends: an open object whose values all
+		// match the element schema, null when the map is nil
+		return map[string]any{"type": []any{"object", "null"}, "additionalProperties": values}, nil
+	case reflect.Interface:
+		// Anything goes; the empty schema matches every value
+		return map[string]any{}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name, err := g.defName(t)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"$ref": "#/definitions/" + name}, nil
+	default:
+		return g.scalarSchema(t)
+	}
+}
+
+// scalarSchema maps primitive kinds onto JSON Schema types
+func (g *generator) scalarSchema(t reflect.Type) (map[string]any, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	default:
+		return nil, fmt.Errorf("cannot derive a schema for %s (kind %s)", t, t.Kind())
+	}
+}
+
+// defName returns the definition name for a named struct type, building its
+// definition on first use. The definition is registered before the fields are
+// walked so recursive types terminate.
+func (g *generator) defName(t reflect.Type) (string, error) {
+	if name, ok := g.names[t]; ok {
+		return name, nil
+	}
+
+	name := t.Name()
+	if g.taken[name] {
+		// Same type name in two packages; qualify with the package
+		parts := strings.Split(t.PkgPath(), "/")
+		pkg := parts[len(parts)-1]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	if g.taken[name] {
+		return "", fmt.Errorf("definition name %q is taken twice", name)
+	}
+	g.names[t] = name
+	g.taken[name] = true
+	g.defs[name] = nil // placeholder so recursion finds the name
+
+	s, err := g.structSchema(t)
+	if err != nil {
+		return "", err
+	}
+	g.defs[name] = s
+	return name, nil
+}
+
+// structSchema builds the object schema for a struct type. additionalProperties
+// is closed so a renamed field fails validation instead of passing silently.
+func (g *generator) structSchema(t reflect.Type) (map[string]any, error) {
+	properties := map[string]any{}
+	required := map[string]bool{}
+	if err := g.addFields(t, properties, required, false); err != nil {
+		return nil, err
+	}
+
+	s := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	var names []string
+	for name, always := range required {
+		if always {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		s["required"] = names
+	}
+	return s, nil
+}
+
+// addFields walks a struct's fields into properties and required. Embedded
+// structs are flattened first so outer fields shadow promoted ones, matching
+// encoding/json; optional marks every field reached through an embedded
+// pointer, which vanishes wholesale when the pointer is nil.
+func (g *generator) addFields(t reflect.Type, properties map[string]any, required map[string]bool, optional bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, tagOpts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			embeddedOptional := optional
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+				embeddedOptional = true
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := g.addFields(embedded, properties, required, embeddedOptional); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonName := name
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+
+		fieldSchema, err := g.schemaFor(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+		if values, ok := g.opts.PropertyEnums[t.Name()+"."+jsonName]; ok {
+			applyEnum(fieldSchema, values)
+		}
+
+		omitempty := strings.Contains(","+tagOpts+",", ",omitempty,")
+		properties[jsonName] = fieldSchema
+		required[jsonName] = !omitempty && !optional
+	}
+	return nil
+}
+
+// applyEnum attaches an enum constraint at the schema's element level: map
+// values and array items carry the constraint, scalars take it directly
+func applyEnum(s map[string]any, values []any) {
+	if elements, ok := s["additionalProperties"].(map[string]any); ok {
+		applyEnum(elements, values)
+		return
+	}
+	if items, ok := s["items"].(map[string]any); ok {
+		applyEnum(items, values)
+		return
+	}
+	s["enum"] = values
+}
+
+// nullable widens a schema to also accept null, the way encoding/json
+// serializes nil pointers
+func nullable(s map[string]any) map[string]any {
+	if _, ok := s["$ref"]; ok {
+		return map[string]any{"oneOf": []any{s, map[string]any{"type": "null"}}}
+	}
+	switch typed := s["type"].(type) {
+	case string:
+		s["type"] = []any{typed, "null"}
+	case []any:
+		for _, t := range typed {
+			if t == "null" {
+				return s
+			}
+		}
+		s["type"] = append(typed, "null")
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The test types mirror the shapes the API serializes: a ModelValues-style
+// map, a normalized integer enum, an embedded struct with an outer shadow,
+// nullable pointers, and omitempty fields.
+
+type testLevel int
+
+type testValues map[string]float64
+
+type testWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type testEmbedded struct {
+	Kept   string `json:"kept"`
+	Window string `json:"window"`
+}
+
+type testPayload struct {
+	testEmbedded
+	Window   *testWindow `json:"window"` // shadows the embedded string field
+	Level    testLevel   `json:"level"`
+	PerModel testValues  `json:"perModel"`
+	Note     *string     `json:"note"`
+	Tags     []string    `json:"tags"`
+	Extra    string      `json:"extra,omitempty"`
+	Code     int         `json:"code"`
+	Skipped  string      `json:"-"`
+	hidden   string
+}
+
+// keep the unexported field from looking dead; the generator must skip it
+var _ = testPayload{}.hidden
+
+func testOptions() Options {
+	return Options{
+		Title: "test payload",
+		Enums: map[reflect.Type][]any{
+			reflect.TypeOf(testLevel(0)): {0, 1, 2},
+		},
+		PropertyEnums: map[string][]any{
+			"testPayload.code": {10, 20},
+		},
+	}
+}
+
+func generateTestDoc(t *testing.T) map[string]any {
+	t.Helper()
+	doc, err := Generate(testPayload{}, testOptions())
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	return doc
+}
+
+func payloadDef(t *testing.T, doc map[string]any) map[string]any {
+	t.Helper()
+	defs, _ := doc["definitions"].(map[string]any)
+	def, ok := defs["testPayload"].(map[string]any)
+	if !ok {
+		t.Fatalf("definitions missing testPayload: %v", defs)
+	}
+	return def
+}
+
+func TestGenerateMapAndNullableFields(t *testing.T) {
+	properties := payloadDef(t, generateTestDoc(t))["properties"].(map[string]any)
+
+	// The ModelValues-style map is an open object with typed values, null
+	// when the map is nil
+	perModel := properties["perModel"].(map[string]any)
+	if values, ok := perModel["additionalProperties"].(map[string]any); !ok || values["type"] != "number" {
+		t.Errorf("perModel additionalProperties = %v, want a number schema", perModel["additionalProperties"])
+	}
+	if types, _ := json.Marshal(perModel["type"]); string(types) != `["object","null"]` {
+		t.Errorf("perModel type = %s, want object or null", types)
+	}
+
+	// Nil pointers and nil slices marshal as null
+	note := properties["note"].(map[string]any)
+	if types, _ := json.Marshal(note["type"]); string(types) != `["string","null"]` {
+		t.Errorf("note type = %s, want string or null", types)
+	}
+	window := properties["window"].(map[string]any)
+	if _, ok := window["oneOf"]; !ok {
+		t.Errorf("window schema = %v, want a oneOf with null for the struct pointer", window)
+	}
+}
+
+func TestGenerateEnums(t *testing.T) {
+	properties := payloadDef(t, generateTestDoc(t))["properties"].(map[string]any)
+
+	level := properties["level"].(map[string]any)
+	if enum, _ := json.Marshal(level["enum"]); string(enum) != `[0,1,2]` {
+		t.Errorf("level enum = %s, want [0,1,2]", enum)
+	}
+	code := properties["code"].(map[string]any)
+	if enum, _ := json.Marshal(code["enum"]); string(enum) != `[10,20]` {
+		t.Errorf("code enum = %s, want [10,20]", enum)
+	}
+}
+
+func TestGenerateRequiredAndHiddenFields(t *testing.T) {
+	def := payloadDef(t, generateTestDoc(t))
+	properties := def["properties"].(map[string]any)
+
+	if _, ok := properties["Skipped"]; ok {
+		t.Error(`json:"-" field appeared in the schema`)
+	}
+	if _, ok := properties["hidden"]; ok {
+		t.Error("unexported field appeared in the schema")
+	}
+	if _, ok := properties["kept"]; !ok {
+		t.Error("embedded field missing from the schema")
+	}
+
+	required := def["required"].([]string)
+	set := map[string]bool{}
+	for _, name := range required {
+		set[name] = true
+	}
+	if set["extra"] {
+		t.Error("omitempty field listed as required")
+	}
+	for _, name := range []string{"kept", "window", "level", "perModel", "note", "tags", "code"} {
+		if !set[name] {
+			t.Errorf("required %v missing %q", required, name)
+		}
+	}
+}
+
+func TestGenerateEmbeddedShadowing(t *testing.T) {
+	properties := payloadDef(t, generateTestDoc(t))["properties"].(map[string]any)
+
+	// The outer pointer field wins over the embedded string of the same name
+	window := properties["window"].(map[string]any)
+	if window["type"] == "string" {
+		t.Errorf("window schema = %v, embedded field was not shadowed", window)
+	}
+}
+
+// roundTrip marshals a payload and decodes it the way a client would
+func roundTrip(t *testing.T, payload testPayload) any {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	return value
+}
+
+func validPayload() testPayload {
+	note := "fresh"
+	return testPayload{
+		testEmbedded: testEmbedded{Kept: "yes"},
+		Window:       &testWindow{Start: "06:00", End: "09:00"},
+		Level:        1,
+		PerModel:     testValues{"GfsSeamless": 4.5},
+		Note:         &note,
+		Tags:         []string{"a"},
+		Code:         10,
+	}
+}
+
+func TestValidateAcceptsMarshaledPayload(t *testing.T) {
+	doc := generateTestDoc(t)
+	if err := Validate(doc, roundTrip(t, validPayload())); err != nil {
+		t.Errorf("valid payload rejected: %v", err)
+	}
+
+	// Nil pointer, nil map, and nil slice serialize as null and still pass
+	if err := Validate(doc, roundTrip(t, testPayload{Code: 20})); err != nil {
+		t.Errorf("zero-value payload rejected: %v", err)
+	}
+}
+
+func TestValidateRejectsContractBreaks(t *testing.T) {
+	// Round-trip the schema itself so the test exercises the committed-file
+	// form, where required lists and enums decode as []any
+	data, err := json.Marshal(generateTestDoc(t))
+	if err != nil {
+		t.Fatalf("marshal schema failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal schema failed: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(payload map[string]any)
+		want   string
+	}{
+		{"unknown property", func(p map[string]any) { p["renamed"] = 1 }, "unexpected property"},
+		{"missing required", func(p map[string]any) { delete(p, "kept") }, "missing required"},
+		{"enum violation", func(p map[string]any) { p["level"] = 9 }, "enum"},
+		{"type change", func(p map[string]any) { p["tags"] = "not-a-list" }, "type"},
+		{"null for non-nullable", func(p map[string]any) { p["kept"] = nil }, "type"},
+	}
+	for _, tc := range cases {
+		payload := roundTrip(t, validPayload()).(map[string]any)
+		tc.mutate(payload)
+		err := Validate(doc, payload)
+		if err == nil {
+			t.Errorf("%s: mutated payload accepted", tc.name)
+		} else if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error %q does not mention %q", tc.name, err, tc.want)
+		}
+	}
+}
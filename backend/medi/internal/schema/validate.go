@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Validate checks a decoded JSON value (the result of unmarshaling into any)
+// against a document produced by Generate. It understands the subset of JSON
+// Schema the generator emits — $ref into definitions, type, enum, oneOf,
+// properties with required and additionalProperties, and items — which keeps
+// contract tests free of an external validator dependency.
+func Validate(doc map[string]any, value any) error {
+	return validateValue(doc, doc, value, "$")
+}
+
+func validateValue(root, s map[string]any, value any, path string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		resolved, err := resolveRef(root, ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return validateValue(root, resolved, value, path)
+	}
+
+	if branches, ok := asSlice(s["oneOf"]); ok {
+		for _, branch := range branches {
+			if branchSchema, ok := branch.(map[string]any); ok {
+				if validateValue(root, branchSchema, value, path) == nil {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("%s: value %v matches no oneOf branch", path, value)
+	}
+
+	if values, ok := asSlice(s["enum"]); ok && !enumContains(values, value) {
+		return fmt.Errorf("%s: value %v not in enum %v", path, value, values)
+	}
+
+	if typed, ok := s["type"]; ok {
+		if err := checkType(typed, value, path); err != nil {
+			return err
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateObject(root, s, v, path)
+	case []any:
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, element := range v {
+				if err := validateValue(root, items, element, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateObject(root, s map[string]any, obj map[string]any, path string) error {
+	properties, _ := s["properties"].(map[string]any)
+
+	if names, ok := asSlice(s["required"]); ok {
+		for _, name := range names {
+			key, _ := name.(string)
+			if _, present := obj[key]; !present {
+				return fmt.Errorf("%s: missing required property %q", path, key)
+			}
+		}
+	}
+
+	for key, v := range obj {
+		if propSchema, ok := properties[key].(map[string]any); ok {
+			if err := validateValue(root, propSchema, v, path+"."+key); err != nil {
+				return err
+			}
+			continue
+		}
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: unexpected property %q", path, key)
+			}
+		case map[string]any:
+			if err := validateValue(root, additional, v, path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveRef follows a "#/definitions/Name" pointer into the document
+func resolveRef(root map[string]any, ref string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(ref, "#/definitions/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+	definitions, _ := root["definitions"].(map[string]any)
+	resolved, ok := definitions[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("$ref %q has no definition", ref)
+	}
+	return resolved, nil
+}
+
+// checkType verifies the value's JSON type against a type keyword, which may
+// be a single name or a list of alternatives
+func checkType(typed any, value any, path string) error {
+	var allowed []string
+	switch t := typed.(type) {
+	case string:
+		allowed = []string{t}
+	default:
+		names, _ := asSlice(typed)
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				allowed = append(allowed, s)
+			}
+		}
+	}
+	got := jsonTypeOf(value)
+	for _, name := range allowed {
+		if got == name {
+			return nil
+		}
+		// Every integer is also a number; an integral float satisfies integer
+		if name == "integer" && got == "number" {
+			if f, ok := toFloat(value); ok && f == math.Trunc(f) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s: value %v has type %s, want %v", path, value, got, allowed)
+}
+
+// jsonTypeOf names the JSON type of a decoded value
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		if _, ok := toFloat(value); ok {
+			return "number"
+		}
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// enumContains matches the value against the enum, comparing numbers by value
+// so an int in the schema matches the float64 json.Unmarshal produces
+func enumContains(values []any, value any) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+		cf, cok := toFloat(candidate)
+		vf, vok := toFloat(value)
+		if cok && vok && cf == vf {
+			return true
+		}
+	}
+	return false
+}
+
+// asSlice accepts both []any (a schema read back from JSON) and typed slices
+// (a schema still in memory)
+func asSlice(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []string:
+		converted := make([]any, len(v))
+		for i, s := range v {
+			converted[i] = s
+		}
+		return converted, true
+	}
+	return nil, false
+}
+
+// toFloat normalizes the numeric types that appear in schemas and decoded
+// documents
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
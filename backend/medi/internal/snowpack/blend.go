@@ -0,0 +1,124 @@
+// Package snowpack anchors the first forecast hours to ground truth. Snow
+// telemetry stations (SNOTEL and similar) report observed temperature and
+// snow depth; when a station sits close enough to the forecast point, the
+// near-term hours are nudged toward its latest observation with a weight
+// that decays to zero. Only state variables blend — accumulation variables
+// like snowfall stay model-only, because an observation of what is on the
+// ground says nothing about what is about to fall.
+package snowpack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"medi/internal/types"
+)
+
+// startWeight is the blend weight at the first forecast hour; the observation
+// never fully replaces the model, it only pulls the value toward what the
+// station measured.
+const startWeight = 0.7
+
+// DefaultHours is how many leading forecast hours blend toward the
+// observation when the configuration does not say otherwise
+const DefaultHours = 6
+
+var (
+	// ErrStationTooFar indicates the station is beyond the blend distance
+	ErrStationTooFar = errors.New("station is too far from the forecast point")
+	// ErrElevationDelta indicates the station sits at a different enough
+	// elevation that its readings do not transfer to the point
+	ErrElevationDelta = errors.New("station elevation differs too much from the forecast point")
+	// ErrStaleObservation indicates the observation is too old to anchor
+	// the current forecast
+	ErrStaleObservation = errors.New("station observation is too old")
+)
+
+// Observation is a station's latest report of the state variables a blend
+// may use
+type Observation struct {
+	Station     string
+	Coordinates types.Coords
+	Elevation   types.Elevation
+	ObservedAt  time.Time
+	Temperature types.Temperature
+	SnowDepth   types.SnowDepth
+}
+
+// Params bounds which stations may anchor a blend and how far into the
+// forecast the nudge reaches. Zero limits mean unlimited, so a partially
+// configured blend still works; a zero Hours falls back to DefaultHours.
+type Params struct {
+	// Hours is how many leading forecast hours blend toward the observation
+	Hours int
+	// MaxDistanceKm is the farthest a station may sit from the point
+	MaxDistanceKm float64
+	// MaxElevationDeltaFt is the largest elevation difference allowed
+	MaxElevationDeltaFt float64
+	// MaxObservationAge is the oldest an observation may be
+	MaxObservationAge time.Duration
+}
+
+// BlendHours returns the effective hour count, applying the default
+func (p Params) BlendHours() int {
+	if p.Hours > 0 {
+		return p.Hours
+	}
+	return DefaultHours
+}
+
+// Eligible reports whether the observation may anchor a blend for the point,
+// returning nil or an error wrapping the sentinel for the failed rule
+func Eligible(obs Observation, point types.ForecastPoint, now time.Time, params Params) error {
+	distance := types.HaversineKm(
+		obs.Coordinates.Latitude, obs.Coordinates.Longitude,
+		point.Coordinates.Latitude, point.Coordinates.Longitude,
+	)
+	if params.MaxDistanceKm > 0 && distance > params.MaxDistanceKm {
+		return fmt.Errorf("%w: %.1f km away, max %.1f", ErrStationTooFar, distance, params.MaxDistanceKm)
+	}
+	delta := math.Abs(obs.Elevation.Feet - point.Elevation.Feet)
+	if params.MaxElevationDeltaFt > 0 && delta > params.MaxElevationDeltaFt {
+		return fmt.Errorf("%w: %.0f ft apart, max %.0f", ErrElevationDelta, delta, params.MaxElevationDeltaFt)
+	}
+	if age := now.Sub(obs.ObservedAt); params.MaxObservationAge > 0 && age > params.MaxObservationAge {
+		return fmt.Errorf("%w: observed %s ago, max %s", ErrStaleObservation, age.Round(time.Minute), params.MaxObservationAge)
+	}
+	return nil
+}
+
+// Weight returns the blend weight for hour index hour of hours total: a
+// linear decay from startWeight at the first hour to zero at hour hours.
+// Indexes outside the blend window get zero.
+func Weight(hour, hours int) float64 {
+	if hours <= 0 || hour < 0 || hour >= hours {
+		return 0
+	}
+	return startWeight * float64(hours-hour) / float64(hours)
+}
+
+// Toward moves a forecast value toward the observed value by the weight
+func Toward(forecast, observed, weight float64) float64 {
+	return forecast + weight*(observed-forecast)
+}
+
+// Adjustment records what blending did to one hour, kept on the forecast so
+// a response can show that its near-term values were anchored to a station
+type Adjustment struct {
+	// Hour is the index into the blend window, 0 at the first blended hour
+	Hour   int     `json:"hour"`
+	Weight float64 `json:"weight"`
+	// TemperatureDeltaF and SnowDepthDeltaFt are the signed nudges applied
+	TemperatureDeltaF float64 `json:"temperatureDeltaF"`
+	SnowDepthDeltaFt  float64 `json:"snowDepthDeltaFt"`
+}
+
+// Blend is the corrections block for one applied station blend
+type Blend struct {
+	Station     string       `json:"station"`
+	DistanceKm  float64      `json:"distanceKm"`
+	ObservedAt  time.Time    `json:"observedAt"`
+	Adjustments []Adjustment `json:"adjustments"`
+}
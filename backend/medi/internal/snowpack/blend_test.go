@@ -0,0 +1,129 @@
+package snowpack
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+func testObservation(observedAt time.Time) Observation {
+	return Observation{
+		Station:     "Berthoud Summit",
+		Coordinates: types.NewCoords(39.8038, -105.7779),
+		Elevation:   types.NewElevationFromFeet(11300),
+		ObservedAt:  observedAt,
+		Temperature: types.NewTemperatureFromFahrenheit(28),
+		SnowDepth:   types.NewSnowDepthFromFeet(4.5),
+	}
+}
+
+func testPoint() types.ForecastPoint {
+	return types.ForecastPoint{
+		Coordinates: types.NewCoords(39.7980, -105.7770),
+		Elevation:   types.NewElevationFromFeet(11000),
+	}
+}
+
+func testParams() Params {
+	return Params{
+		Hours:               6,
+		MaxDistanceKm:       30,
+		MaxElevationDeltaFt: 1000,
+		MaxObservationAge:   3 * time.Hour,
+	}
+}
+
+func TestWeightDecaySchedule(t *testing.T) {
+	cases := []struct {
+		hour int
+		want float64
+	}{
+		{0, 0.7},
+		{3, 0.35},
+		{5, 0.7 / 6},
+		{6, 0},  // first hour past the window
+		{-1, 0}, // out of range
+		{10, 0},
+	}
+	for _, tc := range cases {
+		if got := Weight(tc.hour, 6); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("Weight(%d, 6) = %v, want %v", tc.hour, got, tc.want)
+		}
+	}
+
+	// The schedule decays strictly inside the window
+	for hour := 1; hour < 6; hour++ {
+		if Weight(hour, 6) >= Weight(hour-1, 6) {
+			t.Errorf("Weight(%d, 6) = %v does not decay from Weight(%d, 6) = %v",
+				hour, Weight(hour, 6), hour-1, Weight(hour-1, 6))
+		}
+	}
+}
+
+func TestEligibleAcceptsNearbyFreshStation(t *testing.T) {
+	now := time.Now()
+	if err := Eligible(testObservation(now.Add(-time.Hour)), testPoint(), now, testParams()); err != nil {
+		t.Errorf("nearby fresh station rejected: %v", err)
+	}
+}
+
+func TestEligibleRejectsDistantStation(t *testing.T) {
+	now := time.Now()
+	obs := testObservation(now.Add(-time.Hour))
+	obs.Coordinates = types.NewCoords(43.4799, -110.7624) // Jackson Hole, ~700 km away
+
+	err := Eligible(obs, testPoint(), now, testParams())
+	if !errors.Is(err, ErrStationTooFar) {
+		t.Errorf("distant station: err = %v, want ErrStationTooFar", err)
+	}
+}
+
+func TestEligibleRejectsElevationDelta(t *testing.T) {
+	now := time.Now()
+	obs := testObservation(now.Add(-time.Hour))
+	obs.Elevation = types.NewElevationFromFeet(8500) // 2500 ft below the point
+
+	err := Eligible(obs, testPoint(), now, testParams())
+	if !errors.Is(err, ErrElevationDelta) {
+		t.Errorf("valley station: err = %v, want ErrElevationDelta", err)
+	}
+}
+
+func TestEligibleRejectsStaleObservation(t *testing.T) {
+	now := time.Now()
+	err := Eligible(testObservation(now.Add(-8*time.Hour)), testPoint(), now, testParams())
+	if !errors.Is(err, ErrStaleObservation) {
+		t.Errorf("stale observation: err = %v, want ErrStaleObservation", err)
+	}
+}
+
+func TestEligibleZeroLimitsMeanUnlimited(t *testing.T) {
+	now := time.Now()
+	obs := testObservation(now.Add(-24 * time.Hour))
+	obs.Coordinates = types.NewCoords(43.4799, -110.7624)
+
+	if err := Eligible(obs, testPoint(), now, Params{}); err != nil {
+		t.Errorf("unconfigured limits rejected a station: %v", err)
+	}
+}
+
+func TestTowardMovesPartWayToObservation(t *testing.T) {
+	if got := Toward(20, 30, 0.7); math.Abs(got-27) > 1e-9 {
+		t.Errorf("Toward(20, 30, 0.7) = %v, want 27", got)
+	}
+	if got := Toward(20, 30, 0); got != 20 {
+		t.Errorf("Toward(20, 30, 0) = %v, want the forecast untouched", got)
+	}
+}
+
+func TestBlendHoursDefault(t *testing.T) {
+	if got := (Params{}).BlendHours(); got != DefaultHours {
+		t.Errorf("zero Hours = %d, want the default %d", got, DefaultHours)
+	}
+	if got := (Params{Hours: 12}).BlendHours(); got != 12 {
+		t.Errorf("Hours: 12 = %d, want 12", got)
+	}
+}
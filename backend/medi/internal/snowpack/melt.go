@@ -0,0 +1,52 @@
+package snowpack
+
+// Spring melt runs on heat accumulated above freezing. Degree hours — the
+// sum of (temperature − 32°F) over the hours above freezing — are the
+// standard index for it, and a day whose melt energy jumps well past the
+// previous day's while real snow still sits on the ground is the classic
+// rapid-warmup setup for wet slides and runoff spikes.
+
+// meltBaseTempF is the base the degree-hour sum measures against; hours at
+// or below it contribute nothing
+const meltBaseTempF = 32.0
+
+// RapidWarmupFactor is the day-over-day growth in melt degree hours past
+// which a warm-up rates as rapid
+const RapidWarmupFactor = 2.0
+
+// RapidWarmupMinDegreeHours is the melt energy a day must reach before its
+// growth means anything; without a floor, a near-zero day doubling to
+// another near-zero day would flag
+const RapidWarmupMinDegreeHours = 40.0
+
+// SubstantialSnowDepthInches is the lying snow below which a rapid warm-up
+// has too little left to melt to matter
+const SubstantialSnowDepthInches = 12.0
+
+// MeltDegreeHours sums the degrees above freezing across the hourly
+// temperatures: each hour above 32°F contributes (temperature − 32), in
+// °F·h. A cold day sums to zero.
+func MeltDegreeHours(hourlyTempsF []float64) float64 {
+	total := 0.0
+	for _, tempF := range hourlyTempsF {
+		if tempF > meltBaseTempF {
+			total += tempF - meltBaseTempF
+		}
+	}
+	return total
+}
+
+// RapidWarmup reports whether the day's melt energy more than doubled over
+// the previous day's while substantial snow remains on the ground. The
+// current day must also clear the minimum degree-hour floor, so the first
+// genuinely warm day after a cold stretch flags but trace-melt noise does
+// not.
+func RapidWarmup(previousDegreeHours, currentDegreeHours, snowDepthInches float64) bool {
+	if snowDepthInches < SubstantialSnowDepthInches {
+		return false
+	}
+	if currentDegreeHours < RapidWarmupMinDegreeHours {
+		return false
+	}
+	return currentDegreeHours > previousDegreeHours*RapidWarmupFactor
+}
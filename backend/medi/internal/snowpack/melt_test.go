@@ -0,0 +1,47 @@
+package snowpack
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeltDegreeHours(t *testing.T) {
+	cases := []struct {
+		name  string
+		temps []float64
+		want  float64
+	}{
+		{"empty", nil, 0},
+		{"all below freezing", []float64{10, 20, 28, 31.9}, 0},
+		{"exactly freezing contributes nothing", []float64{32, 32, 32}, 0},
+		{"mixed day counts only the warm hours", []float64{30, 34, 40, 28}, 2 + 8},
+		{"uniform warm day", []float64{42, 42, 42, 42}, 40},
+	}
+	for _, tc := range cases {
+		if got := MeltDegreeHours(tc.temps); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: MeltDegreeHours = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRapidWarmup(t *testing.T) {
+	cases := []struct {
+		name              string
+		previous, current float64
+		depthInches       float64
+		want              bool
+	}{
+		{"classic spring jump", 40, 120, 24, true},
+		{"first warm day after a cold stretch", 0, 60, 24, true},
+		{"steady warm spell is not rapid", 100, 150, 24, false},
+		{"exact doubling is not more than double", 40, 80, 24, false},
+		{"below the degree-hour floor", 5, 30, 24, false},
+		{"thin snowpack never flags", 40, 120, 6, false},
+	}
+	for _, tc := range cases {
+		if got := RapidWarmup(tc.previous, tc.current, tc.depthInches); got != tc.want {
+			t.Errorf("%s: RapidWarmup(%v, %v, %v) = %v, want %v",
+				tc.name, tc.previous, tc.current, tc.depthInches, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"medi/internal/providers/nac"
+)
+
+// NACForecastBuilder assembles a nac.ForecastResponse through a JSON
+// round-trip — the same way real responses arrive — so tests never
+// re-declare the response's anonymous struct types. Sections that are never
+// set stay absent from the document, matching a sparse API payload.
+type NACForecastBuilder struct {
+	doc      map[string]interface{}
+	danger   []map[string]interface{}
+	zones    []map[string]interface{}
+	problems []map[string]interface{}
+}
+
+// NACProblem describes one avalanche problem for the builder. MediaURL is
+// raw JSON matching the API's media url field, which may be a plain string
+// or an object keyed by image size.
+type NACProblem struct {
+	Rank       int
+	Name       string
+	Likelihood string
+	Discussion string
+	Location   []string
+	Size       []string
+	MediaURL   json.RawMessage
+}
+
+// NewNACForecastBuilder starts an empty forecast document.
+func NewNACForecastBuilder() *NACForecastBuilder {
+	return &NACForecastBuilder{doc: map[string]interface{}{}}
+}
+
+// Id sets the forecast id.
+func (b *NACForecastBuilder) Id(id int) *NACForecastBuilder {
+	b.doc["id"] = id
+	return b
+}
+
+// Published sets the publication time.
+func (b *NACForecastBuilder) Published(t time.Time) *NACForecastBuilder {
+	b.doc["published_time"] = t
+	return b
+}
+
+// Expires sets the expiry time.
+func (b *NACForecastBuilder) Expires(t time.Time) *NACForecastBuilder {
+	b.doc["expires_time"] = t
+	return b
+}
+
+// Author sets the forecaster name.
+func (b *NACForecastBuilder) Author(name string) *NACForecastBuilder {
+	b.doc["author"] = name
+	return b
+}
+
+// BottomLine sets the bottom line HTML.
+func (b *NACForecastBuilder) BottomLine(html string) *NACForecastBuilder {
+	b.doc["bottom_line"] = html
+	return b
+}
+
+// HazardDiscussion sets the hazard discussion HTML.
+func (b *NACForecastBuilder) HazardDiscussion(html string) *NACForecastBuilder {
+	b.doc["hazard_discussion"] = html
+	return b
+}
+
+// Center sets the issuing avalanche center.
+func (b *NACForecastBuilder) Center(id, name, url, city, state string) *NACForecastBuilder {
+	b.doc["avalanche_center"] = map[string]interface{}{
+		"id": id, "name": name, "url": url, "city": city, "state": state,
+	}
+	return b
+}
+
+// Danger appends a danger rating for one valid day.
+func (b *NACForecastBuilder) Danger(lower, middle, upper int, validDay string) *NACForecastBuilder {
+	b.danger = append(b.danger, map[string]interface{}{
+		"lower": lower, "middle": middle, "upper": upper, "valid_day": validDay,
+	})
+	return b
+}
+
+// Zone appends a forecast zone. config is the zone's raw config document, or
+// nil for centers that publish none.
+func (b *NACForecastBuilder) Zone(id int, name, url, state string, config interface{}) *NACForecastBuilder {
+	b.zones = append(b.zones, map[string]interface{}{
+		"id": id, "name": name, "url": url, "state": state, "config": config,
+	})
+	return b
+}
+
+// Problem appends an avalanche problem.
+func (b *NACForecastBuilder) Problem(p NACProblem) *NACForecastBuilder {
+	problem := map[string]interface{}{
+		"rank":       p.Rank,
+		"name":       p.Name,
+		"likelihood": p.Likelihood,
+		"discussion": p.Discussion,
+		"location":   p.Location,
+		"size":       p.Size,
+	}
+	if p.MediaURL != nil {
+		problem["media"] = map[string]interface{}{"url": p.MediaURL}
+	}
+	b.problems = append(b.problems, problem)
+	return b
+}
+
+// Build marshals the document and decodes it into a nac.ForecastResponse.
+func (b *NACForecastBuilder) Build(tb testing.TB) *nac.ForecastResponse {
+	tb.Helper()
+
+	if len(b.danger) > 0 {
+		b.doc["danger"] = b.danger
+	}
+	if len(b.zones) > 0 {
+		b.doc["forecast_zone"] = b.zones
+	}
+	if len(b.problems) > 0 {
+		b.doc["forecast_avalanche_problems"] = b.problems
+	}
+
+	data, err := json.Marshal(b.doc)
+	if err != nil {
+		tb.Fatalf("failed to marshal forecast document: %v", err)
+	}
+	var resp nac.ForecastResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		tb.Fatalf("failed to decode forecast document: %v", err)
+	}
+	return &resp
+}
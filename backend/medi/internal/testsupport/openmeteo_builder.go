@@ -0,0 +1,123 @@
+package testsupport
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"medi/internal/providers/openmeteo"
+)
+
+// OpenMeteoResponseBuilder assembles a ForecastAPIResponse without spelling
+// out its anonymous struct types. Series setters address fields by their
+// Open-Meteo JSON names, mirroring how the API itself is parameterized:
+//
+//	resp := NewOpenMeteoResponseBuilder().
+//		HourlyTimes("2025-01-23T00:00", 24).
+//		Hourly("temperature_2m", "gfs_seamless", []float64{...}).
+//		Build()
+type OpenMeteoResponseBuilder struct {
+	resp openmeteo.ForecastAPIResponse
+}
+
+// NewOpenMeteoResponseBuilder starts an empty response in the Denver
+// timezone, the default for everything in this codebase.
+func NewOpenMeteoResponseBuilder() *OpenMeteoResponseBuilder {
+	b := &OpenMeteoResponseBuilder{}
+	b.resp.Timezone = "America/Denver"
+	b.resp.TimezoneAbbreviation = "MST"
+	return b
+}
+
+// Timezone overrides the response timezone.
+func (b *OpenMeteoResponseBuilder) Timezone(tz string) *OpenMeteoResponseBuilder {
+	b.resp.Timezone = tz
+	return b
+}
+
+// Coordinates sets the echoed request coordinates.
+func (b *OpenMeteoResponseBuilder) Coordinates(latitude, longitude float64) *OpenMeteoResponseBuilder {
+	b.resp.Latitude = latitude
+	b.resp.Longitude = longitude
+	return b
+}
+
+// HourlyTimes fills the hourly time axis with count hourly stamps from
+// start, formatted the way the API emits them (2006-01-02T15:04).
+func (b *OpenMeteoResponseBuilder) HourlyTimes(start string, count int) *OpenMeteoResponseBuilder {
+	b.resp.Hourly.Time = timeAxis(start, "2006-01-02T15:04", time.Hour, count)
+	return b
+}
+
+// DailyTimes fills the daily time axis with count dates from start
+// (2006-01-02).
+func (b *OpenMeteoResponseBuilder) DailyTimes(start string, count int) *OpenMeteoResponseBuilder {
+	b.resp.Daily.Time = timeAxis(start, "2006-01-02", 24*time.Hour, count)
+	return b
+}
+
+// Hourly sets one model's hourly series, addressing the field by its JSON
+// name: series + "_" + model, e.g. ("snowfall", "gfs_seamless"). The values
+// slice must match the field's element type.
+func (b *OpenMeteoResponseBuilder) Hourly(series, model string, values interface{}) *OpenMeteoResponseBuilder {
+	setSeries(&b.resp.Hourly, series+"_"+model, values)
+	return b
+}
+
+// Daily sets one model's daily series, addressed like Hourly.
+func (b *OpenMeteoResponseBuilder) Daily(series, model string, values interface{}) *OpenMeteoResponseBuilder {
+	setSeries(&b.resp.Daily, series+"_"+model, values)
+	return b
+}
+
+// Build returns a copy of the assembled response, so a builder can be
+// reused after tweaking.
+func (b *OpenMeteoResponseBuilder) Build() *openmeteo.ForecastAPIResponse {
+	resp := b.resp
+	return &resp
+}
+
+// timeAxis renders count stamps stepping from start, in start's own layout.
+func timeAxis(start, layout string, step time.Duration, count int) []string {
+	base, err := time.Parse(layout, start)
+	if err != nil {
+		panic(fmt.Sprintf("testsupport: start %q does not match layout %s: %v", start, layout, err))
+	}
+	axis := make([]string, count)
+	for i := range axis {
+		axis[i] = base.Add(time.Duration(i) * step).Format(layout)
+	}
+	return axis
+}
+
+// setSeries assigns values to the struct field carrying the given JSON tag.
+// Fields typed []interface{} accept any slice; everything else requires the
+// exact slice type. Unknown names and type mismatches panic — they are bugs
+// in the test, not conditions to report.
+func setSeries(block interface{}, jsonName string, values interface{}) {
+	v := reflect.ValueOf(block).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != jsonName {
+			continue
+		}
+		field := v.Field(i)
+		val := reflect.ValueOf(values)
+		if val.Type().AssignableTo(field.Type()) {
+			field.Set(val)
+			return
+		}
+		if field.Type() == reflect.TypeOf([]interface{}(nil)) && val.Kind() == reflect.Slice {
+			boxed := make([]interface{}, val.Len())
+			for j := range boxed {
+				boxed[j] = val.Index(j).Interface()
+			}
+			field.Set(reflect.ValueOf(boxed))
+			return
+		}
+		panic(fmt.Sprintf("testsupport: series %s holds %s, not %s", jsonName, field.Type(), val.Type()))
+	}
+	panic(fmt.Sprintf("testsupport: no series named %s", jsonName))
+}
@@ -0,0 +1,154 @@
+// Package testsupport provides the fixtures shared by tests across the
+// domain packages: cached loaders for the committed testdata snapshots, and
+// builders that assemble provider response structs without re-declaring
+// their anonymous struct types inline.
+package testsupport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"medi/internal/providers/metno"
+	"medi/internal/providers/nac"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/providers/openstreetmap"
+	"medi/internal/providers/usgs"
+)
+
+// moduleRoot locates the module directory from this file's compiled-in
+// source path, so fixtures resolve no matter which package's tests run.
+var moduleRoot = func() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("testsupport: cannot locate module root")
+	}
+	return filepath.Dir(filepath.Dir(filepath.Dir(file)))
+}()
+
+var (
+	fixtureMu    sync.Mutex
+	fixtureCache = map[string][]byte{}
+)
+
+// fixtureBytes reads a testdata file relative to the module root, caching
+// the raw bytes so the large snapshots hit disk once per test binary. The
+// returned slice is shared; callers must not modify it.
+func fixtureBytes(tb testing.TB, relPath string) []byte {
+	tb.Helper()
+
+	fixtureMu.Lock()
+	defer fixtureMu.Unlock()
+	if data, ok := fixtureCache[relPath]; ok {
+		return data
+	}
+	data, err := os.ReadFile(filepath.Join(moduleRoot, relPath))
+	if err != nil {
+		tb.Fatalf("failed to read fixture %s: %v", relPath, err)
+	}
+	fixtureCache[relPath] = data
+	return data
+}
+
+// decodeFixture unmarshals a cached fixture into v, giving every caller a
+// fresh value that is safe to mutate.
+func decodeFixture(tb testing.TB, relPath string, v interface{}) {
+	tb.Helper()
+
+	if err := json.Unmarshal(fixtureBytes(tb, relPath), v); err != nil {
+		tb.Fatalf("failed to unmarshal fixture %s: %v", relPath, err)
+	}
+}
+
+// NewForecastFixture returns a fresh copy of the committed Open-Meteo
+// forecast snapshot for Aspen: 16 days with all 7 models populated.
+func NewForecastFixture(tb testing.TB) *openmeteo.ForecastAPIResponse {
+	tb.Helper()
+
+	var resp openmeteo.ForecastAPIResponse
+	decodeFixture(tb, "internal/weather/testdata/openmeteo_forecast_response.json", &resp)
+	return &resp
+}
+
+// NewEnsembleFixture returns a fresh copy of the trimmed Open-Meteo ensemble
+// snapshot for Aspen: 48 hours of snowfall and temperature member series,
+// including a null hour and a short member.
+func NewEnsembleFixture(tb testing.TB) *openmeteo.EnsembleAPIResponse {
+	tb.Helper()
+
+	var resp openmeteo.EnsembleAPIResponse
+	decodeFixture(tb, "internal/weather/testdata/openmeteo_ensemble_response.json", &resp)
+	return &resp
+}
+
+// MetNoCompactFixture returns a fresh copy of the recorded MET Norway
+// compact Locationforecast snapshot.
+func MetNoCompactFixture(tb testing.TB) *metno.CompactAPIResponse {
+	tb.Helper()
+
+	var resp metno.CompactAPIResponse
+	decodeFixture(tb, "internal/weather/testdata/metno_compact_response.json", &resp)
+	return &resp
+}
+
+// NACMapLayerFixture returns a fresh copy of the national avalanche map
+// layer snapshot.
+func NACMapLayerFixture(tb testing.TB) *nac.MapLayerResponse {
+	tb.Helper()
+
+	var resp nac.MapLayerResponse
+	decodeFixture(tb, nacMapLayerPath, &resp)
+	return &resp
+}
+
+// NACMapLayerFixtureBytes returns the raw map layer snapshot for tests that
+// serve it over HTTP. The slice is shared; callers must not modify it.
+func NACMapLayerFixtureBytes(tb testing.TB) []byte {
+	tb.Helper()
+	return fixtureBytes(tb, nacMapLayerPath)
+}
+
+const nacMapLayerPath = "internal/avalanche/testdata/nac_map_layer_response.json"
+
+// NACForecastFixture returns a fresh copy of the recorded CAIC Aspen zone
+// forecast snapshot.
+func NACForecastFixture(tb testing.TB) *nac.ForecastResponse {
+	tb.Helper()
+
+	var resp nac.ForecastResponse
+	decodeFixture(tb, "internal/avalanche/testdata/nac_forecast_response.json", &resp)
+	return &resp
+}
+
+// NACAccidentsFixture returns a fresh copy of the trimmed accident dataset
+// snapshot.
+func NACAccidentsFixture(tb testing.TB) []nac.Accident {
+	tb.Helper()
+
+	var accidents []nac.Accident
+	decodeFixture(tb, "internal/avalanche/testdata/nac_accidents_response.json", &accidents)
+	return accidents
+}
+
+// USGSElevationFixture returns a fresh copy of the recorded USGS elevation
+// point snapshot for Aspen.
+func USGSElevationFixture(tb testing.TB) *usgs.ElevationPointAPIResponse {
+	tb.Helper()
+
+	var resp usgs.ElevationPointAPIResponse
+	decodeFixture(tb, "internal/location/testdata/usgs_elevation_response.json", &resp)
+	return &resp
+}
+
+// OSMLookupFixture returns a fresh copy of the recorded OpenStreetMap
+// reverse geocode snapshot for Aspen.
+func OSMLookupFixture(tb testing.TB) *openstreetmap.LookupAPIResponse {
+	tb.Helper()
+
+	var resp openstreetmap.LookupAPIResponse
+	decodeFixture(tb, "internal/location/testdata/openstreetmap_lookup_response.json", &resp)
+	return &resp
+}
@@ -0,0 +1,85 @@
+package testsupport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewForecastFixtureReturnsFreshCopies(t *testing.T) {
+	first := NewForecastFixture(t)
+	if len(first.Hourly.Time) == 0 {
+		t.Fatal("fixture has no hourly time axis")
+	}
+
+	// Mutating one copy must not bleed into the next load
+	first.Hourly.Temperature2MGfsSeamless[0] = -999
+	second := NewForecastFixture(t)
+	if second.Hourly.Temperature2MGfsSeamless[0] == -999 {
+		t.Error("second load sees the first copy's mutation")
+	}
+}
+
+func TestOpenMeteoBuilderSetsSeriesByName(t *testing.T) {
+	resp := NewOpenMeteoResponseBuilder().
+		HourlyTimes("2025-01-23T00:00", 3).
+		DailyTimes("2025-01-23", 1).
+		Hourly("temperature_2m", "gfs_seamless", []float64{10, 12, 14}).
+		Hourly("visibility", "gem_seamless", []float64{1, 2, 3}).
+		Daily("weather_code", "gem_seamless", []int{73}).
+		Build()
+
+	if got := resp.Hourly.Time[2]; got != "2025-01-23T02:00" {
+		t.Errorf("hourly axis ends at %q, want 2025-01-23T02:00", got)
+	}
+	if got := resp.Hourly.Temperature2MGfsSeamless[1]; got != 12 {
+		t.Errorf("GFS temperature[1] = %v, want 12", got)
+	}
+	// The GEM visibility field is []interface{}; float values get boxed
+	if got := resp.Hourly.VisibilityGemSeamless[0]; got != interface{}(1.0) {
+		t.Errorf("GEM visibility[0] = %v, want 1", got)
+	}
+	if got := resp.Daily.WeatherCodeGemSeamless[0]; got != 73 {
+		t.Errorf("GEM daily weather code = %d, want 73", got)
+	}
+}
+
+func TestOpenMeteoBuilderRejectsUnknownSeries(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("setting an unknown series did not panic")
+		}
+	}()
+	NewOpenMeteoResponseBuilder().Hourly("temperature_2m", "no_such_model", []float64{1})
+}
+
+func TestNACForecastBuilderRoundTrip(t *testing.T) {
+	published := time.Date(2025, 1, 15, 7, 0, 0, 0, time.UTC)
+
+	resp := NewNACForecastBuilder().
+		Id(42).
+		Published(published).
+		Center("CAIC", "Colorado Avalanche Information Center", "", "", "").
+		Danger(1, 2, 3, "current").
+		Zone(2690, "Aspen", "", "CO", nil).
+		Problem(NACProblem{Rank: 1, Name: "Wind Slab", Likelihood: "likely"}).
+		Build(t)
+
+	if resp.Id != 42 {
+		t.Errorf("Id = %d, want 42", resp.Id)
+	}
+	if !resp.PublishedTime.Equal(published) {
+		t.Errorf("PublishedTime = %v, want %v", resp.PublishedTime, published)
+	}
+	if resp.AvalancheCenter.Id != "CAIC" {
+		t.Errorf("AvalancheCenter.Id = %q, want CAIC", resp.AvalancheCenter.Id)
+	}
+	if len(resp.Danger) != 1 || resp.Danger[0].Upper != 3 || resp.Danger[0].ValidDay != "current" {
+		t.Errorf("Danger = %+v, want one current rating with upper 3", resp.Danger)
+	}
+	if len(resp.ForecastZone) != 1 || resp.ForecastZone[0].Id != 2690 || resp.ForecastZone[0].Config != nil {
+		t.Errorf("ForecastZone = %+v, want zone 2690 with nil config", resp.ForecastZone)
+	}
+	if len(resp.ForecastAvalancheProblems) != 1 || resp.ForecastAvalancheProblems[0].Likelihood != "likely" {
+		t.Errorf("ForecastAvalancheProblems = %+v, want one likely Wind Slab", resp.ForecastAvalancheProblems)
+	}
+}
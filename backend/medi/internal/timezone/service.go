@@ -3,6 +3,7 @@ package timezone
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ringsaturn/tzf"
 )
@@ -12,9 +13,19 @@ type Service interface {
 	GetTimezone(latitude, longitude float64) (string, error)
 }
 
-// service implements timezone lookup using tzf
+// loadTimeout bounds how long a lookup waits for the finder to finish
+// loading before giving up
+const loadTimeout = 30 * time.Second
+
+// service implements timezone lookup using tzf. The finder loads in a
+// background goroutine so construction returns immediately; lookups block
+// until the load finishes.
 type service struct {
-	finder tzf.F
+	// ready is closed once the background load has finished, successfully
+	// or not
+	ready   chan struct{}
+	finder  tzf.F
+	loadErr error
 }
 
 var (
@@ -22,29 +33,61 @@ var (
 	once     sync.Once
 )
 
-// NewService creates or returns the singleton timezone service
-// Uses singleton pattern because tzf.Finder loads timezone data into memory (~50MB)
+// NewService creates or returns the singleton timezone service.
+// Uses singleton pattern because tzf.Finder loads timezone data into memory
+// (~50MB); the load runs in the background so startup is not delayed, and
+// the /ready endpoint gates traffic until it completes.
 func NewService() (Service, error) {
-	var err error
 	once.Do(func() {
-		finder, findErr := tzf.NewDefaultFinder()
-		if findErr != nil {
-			err = fmt.Errorf("failed to initialize timezone finder: %w", findErr)
+		instance = newService(tzf.NewDefaultFinder)
+	})
+	return instance, nil
+}
+
+// newService starts the given loader in the background and returns the
+// service immediately; split from NewService so tests can inject a loader
+func newService(load func() (tzf.F, error)) *service {
+	s := &service{ready: make(chan struct{})}
+	go func() {
+		defer close(s.ready)
+		finder, err := load()
+		if err != nil {
+			s.loadErr = fmt.Errorf("failed to initialize timezone finder: %w", err)
 			return
 		}
-		instance = &service{
-			finder: finder,
-		}
-	})
-	if err != nil {
-		return nil, err
+		s.finder = finder
+	}()
+	return s
+}
+
+// Ready reports whether the singleton finder has loaded successfully; false
+// while the background load is still running or after it failed
+func Ready() bool {
+	if instance == nil {
+		return false
+	}
+	select {
+	case <-instance.ready:
+		return instance.loadErr == nil
+	default:
+		return false
 	}
-	return instance, nil
 }
 
-// GetTimezone returns the IANA timezone name for the given coordinates
+// GetTimezone returns the IANA timezone name for the given coordinates.
 // Returns timezone names like "America/Denver", "Europe/London", etc.
+// A call arriving while the finder is still loading blocks until the load
+// finishes, up to loadTimeout.
 func (s *service) GetTimezone(latitude, longitude float64) (string, error) {
+	select {
+	case <-s.ready:
+	case <-time.After(loadTimeout):
+		return "", fmt.Errorf("timezone finder is still loading")
+	}
+	if s.loadErr != nil {
+		return "", s.loadErr
+	}
+
 	timezone := s.finder.GetTimezoneName(longitude, latitude)
 	if timezone == "" {
 		return "", fmt.Errorf("could not determine timezone for coordinates lat=%f, lon=%f", latitude, longitude)
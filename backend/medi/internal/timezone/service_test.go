@@ -2,6 +2,9 @@ package timezone
 
 import (
 	"testing"
+	"time"
+
+	"github.com/ringsaturn/tzf"
 )
 
 func TestService_GetTimezone(t *testing.T) {
@@ -55,3 +58,40 @@ func TestService_GetTimezone(t *testing.T) {
 		})
 	}
 }
+
+// stubFinder answers every lookup with a fixed zone
+type stubFinder struct{}
+
+func (stubFinder) GetTimezoneName(lng, lat float64) string             { return "America/Denver" }
+func (stubFinder) GetTimezoneNames(lng, lat float64) ([]string, error) { return nil, nil }
+func (stubFinder) TimezoneNames() []string                             { return nil }
+func (stubFinder) DataVersion() string                                 { return "stub" }
+
+func TestGetTimezoneBlocksUntilLoadFinishes(t *testing.T) {
+	release := make(chan struct{})
+	svc := newService(func() (tzf.F, error) {
+		<-release
+		return stubFinder{}, nil
+	})
+
+	// A lookup issued mid-load must wait for the finder, not fail
+	result := make(chan error, 1)
+	go func() {
+		got, err := svc.GetTimezone(39.11539, -107.65840)
+		if err == nil && got != "America/Denver" {
+			t.Errorf("GetTimezone() = %v, want America/Denver", got)
+		}
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		t.Fatalf("GetTimezone() returned %v before the load finished", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("GetTimezone() error = %v after the load finished", err)
+	}
+}
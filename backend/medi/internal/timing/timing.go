@@ -0,0 +1,104 @@
+// Package timing collects per-call durations for the optional debug timings
+// block on responses. A handler that opts in creates a Collector, carries it
+// down through the request context, and instrumented call sites record spans
+// into it; everything is a no-op when no collector rides the context, so the
+// instrumentation costs nothing on normal requests.
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Span is one recorded call: what ran, how long it took, and what the cache
+// said about it.
+type Span struct {
+	Name       string  `json:"name"`
+	DurationMs float64 `json:"durationMs"`
+	// CacheStatus is the cache disposition where the call site consulted one
+	// ("HIT", "STALE", "MISS"), empty otherwise
+	CacheStatus string `json:"cacheStatus,omitempty"`
+	// Retries counts attempts beyond the first at the call site, when known
+	Retries int `json:"retries,omitempty"`
+}
+
+// Report is the timings block attached to a response.
+type Report struct {
+	// TotalMs is the wall time from collector creation to Finish
+	TotalMs float64 `json:"totalMs"`
+	Spans   []Span  `json:"spans"`
+}
+
+// Collector accumulates spans for one request. It is safe for concurrent use;
+// handlers fan provider calls out across goroutines. A nil *Collector is
+// valid and records nothing.
+type Collector struct {
+	now   func() time.Time
+	start time.Time
+
+	mu    sync.Mutex
+	spans []Span
+}
+
+// NewCollector starts a collector; a nil clock means time.Now.
+func NewCollector(now func() time.Time) *Collector {
+	if now == nil {
+		now = time.Now
+	}
+	return &Collector{now: now, start: now()}
+}
+
+// StartSpan begins timing a call and returns the function that records it
+func (c *Collector) StartSpan(name string) func() {
+	if c == nil {
+		return func() {}
+	}
+	start := c.now()
+	return func() {
+		c.Record(Span{Name: name, DurationMs: durationMs(c.now().Sub(start))})
+	}
+}
+
+// Record appends a completed span
+func (c *Collector) Record(span Span) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spans = append(c.spans, span)
+}
+
+// Finish closes the collector and returns the report, with the total measured
+// from the collector's creation
+func (c *Collector) Finish() *Report {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &Report{
+		TotalMs: durationMs(c.now().Sub(c.start)),
+		Spans:   append([]Span(nil), c.spans...),
+	}
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// contextKey keeps the collector private to this package's accessors
+type contextKey struct{}
+
+// NewContext carries the collector on the context for instrumented call sites
+func NewContext(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the context's collector, or nil when the request did
+// not opt into timing; the nil collector's methods are all no-ops
+func FromContext(ctx context.Context) *Collector {
+	c, _ := ctx.Value(contextKey{}).(*Collector)
+	return c
+}
@@ -0,0 +1,78 @@
+package timing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCollectorRecordsSpans(t *testing.T) {
+	currentTime := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+	collector := NewCollector(func() time.Time { return currentTime })
+
+	done := collector.StartSpan("provider.call")
+	currentTime = currentTime.Add(250 * time.Millisecond)
+	done()
+
+	collector.Record(Span{Name: "cache", CacheStatus: "MISS"})
+
+	currentTime = currentTime.Add(50 * time.Millisecond)
+	report := collector.Finish()
+
+	if report.TotalMs != 300 {
+		t.Errorf("TotalMs = %v, want 300", report.TotalMs)
+	}
+	if len(report.Spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(report.Spans))
+	}
+	if report.Spans[0].Name != "provider.call" || report.Spans[0].DurationMs != 250 {
+		t.Errorf("unexpected timed span: %+v", report.Spans[0])
+	}
+	if report.Spans[1].CacheStatus != "MISS" {
+		t.Errorf("unexpected cache span: %+v", report.Spans[1])
+	}
+}
+
+func TestCollectorIsConcurrencySafe(t *testing.T) {
+	collector := NewCollector(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer collector.StartSpan(fmt.Sprintf("call-%d", i))()
+		}(i)
+	}
+	wg.Wait()
+
+	if report := collector.Finish(); len(report.Spans) != 100 {
+		t.Errorf("got %d spans from concurrent recorders, want 100", len(report.Spans))
+	}
+}
+
+func TestNilCollectorIsInert(t *testing.T) {
+	var collector *Collector
+
+	// None of these may panic
+	collector.StartSpan("noop")()
+	collector.Record(Span{Name: "noop"})
+
+	if report := collector.Finish(); report != nil {
+		t.Errorf("nil collector produced a report: %+v", report)
+	}
+}
+
+func TestContextCarry(t *testing.T) {
+	if FromContext(context.Background()) != nil {
+		t.Error("expected no collector on a bare context")
+	}
+
+	collector := NewCollector(nil)
+	ctx := NewContext(context.Background(), collector)
+	if FromContext(ctx) != collector {
+		t.Error("expected the context to return the carried collector")
+	}
+}
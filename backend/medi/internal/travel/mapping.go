@@ -0,0 +1,50 @@
+package travel
+
+import (
+	"strings"
+	"time"
+
+	"medi/internal/providers/cotrip"
+)
+
+// mapRoadConditionFeature translates a COtrip road condition feature into a
+// domain pass segment, without a distance (the caller sets it).
+func mapRoadConditionFeature(feature cotrip.RoadConditionFeature) PassSegment {
+	props := feature.Properties
+
+	conditions := make([]string, 0, len(props.CurrentConditions))
+	for _, condition := range props.CurrentConditions {
+		conditions = append(conditions, condition.ConditionDescription)
+	}
+
+	segment := PassSegment{
+		Id:         props.Id,
+		Name:       props.NameId,
+		Route:      props.RouteName,
+		Status:     segmentStatus(conditions),
+		Conditions: conditions,
+		URL:        "https://www.cotrip.org/",
+	}
+
+	if lastUpdated, err := time.Parse(time.RFC3339, props.LastUpdated); err == nil {
+		segment.LastUpdated = lastUpdated
+	}
+
+	return segment
+}
+
+// segmentStatus derives the travel status from the condition descriptions:
+// a closure beats a traction/chain law, which beats anything else.
+func segmentStatus(conditions []string) string {
+	status := StatusOpen
+	for _, condition := range conditions {
+		lowered := strings.ToLower(condition)
+		if strings.Contains(lowered, "closed") {
+			return StatusClosed
+		}
+		if strings.Contains(lowered, "traction law") || strings.Contains(lowered, "chain law") {
+			status = StatusTractionLaw
+		}
+	}
+	return status
+}
@@ -0,0 +1,209 @@
+package travel
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"medi/internal/providers/cotrip"
+)
+
+// mockRoadProvider implements RoadConditionsProvider for testing
+type mockRoadProvider struct {
+	response *cotrip.RoadConditionsAPIResponse
+	err      error
+	covers   bool
+}
+
+func (m *mockRoadProvider) GetRoadConditions() (*cotrip.RoadConditionsAPIResponse, error) {
+	return m.response, m.err
+}
+
+func (m *mockRoadProvider) Covers(latitude, longitude float64) bool {
+	return m.covers
+}
+
+// loadRoadConditionsFixture reads the trimmed COtrip snapshot.
+func loadRoadConditionsFixture(t *testing.T) *cotrip.RoadConditionsAPIResponse {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/cotrip_road_conditions_response.json")
+	if err != nil {
+		t.Fatalf("Failed to read road conditions testdata: %v", err)
+	}
+	var resp cotrip.RoadConditionsAPIResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("Failed to unmarshal road conditions: %v", err)
+	}
+	return &resp
+}
+
+func testTravelLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestMapRoadConditionFeature(t *testing.T) {
+	resp := loadRoadConditionsFixture(t)
+	if len(resp.Features) != 4 {
+		t.Fatalf("Expected 4 features in fixture, got %d", len(resp.Features))
+	}
+
+	segments := make(map[string]PassSegment)
+	for _, feature := range resp.Features {
+		segment := mapRoadConditionFeature(feature)
+		segments[segment.Id] = segment
+	}
+
+	eisenhower := segments["OpenTMS-RoadCondition4322"]
+	if eisenhower.Name != "I-70: Eisenhower Tunnel" {
+		t.Errorf("Expected Eisenhower Tunnel name, got %q", eisenhower.Name)
+	}
+	if eisenhower.Route != "I-70" {
+		t.Errorf("Expected route I-70, got %q", eisenhower.Route)
+	}
+	if eisenhower.Status != StatusTractionLaw {
+		t.Errorf("Expected traction law status, got %q", eisenhower.Status)
+	}
+	if len(eisenhower.Conditions) != 2 {
+		t.Errorf("Expected 2 conditions, got %d", len(eisenhower.Conditions))
+	}
+	wantUpdated := time.Date(2025, 12, 18, 14, 35, 0, 0, time.FixedZone("", -7*3600))
+	if !eisenhower.LastUpdated.Equal(wantUpdated) {
+		t.Errorf("Expected LastUpdated %v, got %v", wantUpdated, eisenhower.LastUpdated)
+	}
+	if eisenhower.URL == "" {
+		t.Error("Expected a provider link")
+	}
+
+	if redMountain := segments["OpenTMS-RoadCondition4401"]; redMountain.Status != StatusClosed {
+		t.Errorf("Expected Red Mountain Pass closed, got %q", redMountain.Status)
+	}
+	if vail := segments["OpenTMS-RoadCondition4367"]; vail.Status != StatusTractionLaw {
+		t.Errorf("Expected Vail Pass traction law via chain law, got %q", vail.Status)
+	}
+	if hoosier := segments["OpenTMS-RoadCondition4489"]; hoosier.Status != StatusOpen {
+		t.Errorf("Expected Hoosier Pass open, got %q", hoosier.Status)
+	}
+}
+
+func TestSegmentStatusPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		want       string
+	}{
+		{"no conditions", nil, StatusOpen},
+		{"dry", []string{"Dry"}, StatusOpen},
+		{"traction law", []string{"Winter Conditions - Passenger Vehicle Traction Law"}, StatusTractionLaw},
+		{"chain law", []string{"Commercial Chain Law in Effect"}, StatusTractionLaw},
+		{"closure beats traction law", []string{"Passenger Vehicle Traction Law", "Closed due to adverse conditions"}, StatusClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := segmentStatus(tt.conditions); got != tt.want {
+				t.Errorf("segmentStatus(%v) = %q, want %q", tt.conditions, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNearbyPassesRadiusAndSorting(t *testing.T) {
+	provider := &mockRoadProvider{response: loadRoadConditionsFixture(t), covers: true}
+	svc := NewTravelServiceWithProvider(testTravelLogger(), provider)
+
+	// Near Loveland Pass: Eisenhower, Vail, and Hoosier are within 100 km,
+	// Red Mountain Pass is roughly 250 km southwest
+	passes, err := svc.GetNearbyPasses(39.60, -105.95, 100)
+	if err != nil {
+		t.Fatalf("GetNearbyPasses failed: %v", err)
+	}
+
+	if passes.Warning != "" {
+		t.Errorf("Expected no warning, got %q", passes.Warning)
+	}
+	if len(passes.Segments) != 3 {
+		t.Fatalf("Expected 3 segments within 100 km, got %d", len(passes.Segments))
+	}
+
+	wantOrder := []string{
+		"OpenTMS-RoadCondition4322", // Eisenhower Tunnel
+		"OpenTMS-RoadCondition4367", // Vail Pass
+		"OpenTMS-RoadCondition4489", // Hoosier Pass
+	}
+	for i, id := range wantOrder {
+		if passes.Segments[i].Id != id {
+			t.Errorf("Segment %d: expected %s, got %s", i, id, passes.Segments[i].Id)
+		}
+	}
+	for i := 1; i < len(passes.Segments); i++ {
+		if passes.Segments[i].DistanceKm < passes.Segments[i-1].DistanceKm {
+			t.Errorf("Segments not sorted by distance: %f before %f",
+				passes.Segments[i-1].DistanceKm, passes.Segments[i].DistanceKm)
+		}
+	}
+
+	// A tight radius keeps only the nearest segment
+	passes, err = svc.GetNearbyPasses(39.60, -105.95, 15)
+	if err != nil {
+		t.Fatalf("GetNearbyPasses failed: %v", err)
+	}
+	if len(passes.Segments) != 1 || passes.Segments[0].Id != "OpenTMS-RoadCondition4322" {
+		t.Fatalf("Expected only the Eisenhower Tunnel segment within 15 km, got %d segments", len(passes.Segments))
+	}
+}
+
+func TestGetNearbyPassesOutsideCoverage(t *testing.T) {
+	provider := &mockRoadProvider{response: loadRoadConditionsFixture(t), covers: false}
+	svc := NewTravelServiceWithProvider(testTravelLogger(), provider)
+
+	// Salt Lake City is outside the Colorado feed
+	passes, err := svc.GetNearbyPasses(40.7608, -111.8910, 100)
+	if err != nil {
+		t.Fatalf("GetNearbyPasses failed: %v", err)
+	}
+	if len(passes.Segments) != 0 {
+		t.Errorf("Expected no segments outside coverage, got %d", len(passes.Segments))
+	}
+	if passes.Warning == "" {
+		t.Error("Expected a coverage warning")
+	}
+}
+
+func TestGetNearbyPassesNoProvider(t *testing.T) {
+	svc := NewTravelServiceWithProvider(testTravelLogger(), nil)
+
+	passes, err := svc.GetNearbyPasses(39.60, -105.95, 100)
+	if err != nil {
+		t.Fatalf("GetNearbyPasses failed: %v", err)
+	}
+	if len(passes.Segments) != 0 || passes.Warning == "" {
+		t.Error("Expected an empty result with a warning when no provider is configured")
+	}
+}
+
+func TestGetNearbyPassesProviderError(t *testing.T) {
+	provider := &mockRoadProvider{err: errors.New("boom"), covers: true}
+	svc := NewTravelServiceWithProvider(testTravelLogger(), provider)
+
+	if _, err := svc.GetNearbyPasses(39.60, -105.95, 100); err == nil {
+		t.Fatal("Expected an error when the provider fails")
+	}
+}
+
+func TestGetNearbyPassesNilLogger(t *testing.T) {
+	// A nil logger falls back to slog.Default(); the no-provider warning must
+	// not panic
+	svc := NewTravelServiceWithProvider(nil, nil)
+
+	passes, err := svc.GetNearbyPasses(39.60, -105.95, 100)
+	if err != nil {
+		t.Fatalf("GetNearbyPasses failed: %v", err)
+	}
+	if passes.Warning == "" {
+		t.Error("Expected a warning when no provider is configured")
+	}
+}
@@ -0,0 +1,127 @@
+package travel
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"medi/internal/logging"
+	"medi/internal/providers"
+	"medi/internal/providers/cotrip"
+	"medi/internal/types"
+)
+
+// Pass segment status values, in order of severity.
+const (
+	StatusOpen        = "open"
+	StatusTractionLaw = "traction law"
+	StatusClosed      = "closed"
+)
+
+// RoadConditionsProvider fetches road condition segments for one state DOT
+// feed and reports whether a coordinate falls inside its coverage area.
+// Other states are added by implementing this interface and extending the
+// provider registry.
+type RoadConditionsProvider interface {
+	GetRoadConditions() (*cotrip.RoadConditionsAPIResponse, error)
+	Covers(latitude, longitude float64) bool
+}
+
+// PassSegment is one named road segment with its current travel status.
+type PassSegment struct {
+	Id          string
+	Name        string
+	Route       string
+	Status      string // open, closed, or traction law
+	Conditions  []string
+	LastUpdated time.Time
+	DistanceKm  float64 // distance from the query point to the nearest vertex
+	URL         string  // link to the segment on the provider's site
+}
+
+// PassConditions holds the pass segments near a query point.
+type PassConditions struct {
+	Segments []PassSegment // within the radius, sorted by distance
+	// Warning is set when the query point lies outside every configured
+	// provider's coverage; Segments is empty in that case
+	Warning string
+}
+
+// Service provides road and pass condition data for access planning.
+type Service interface {
+	GetNearbyPasses(latitude, longitude, radiusKm float64) (*PassConditions, error)
+}
+
+type travelService struct {
+	roadProvider RoadConditionsProvider
+	logger       *slog.Logger
+}
+
+// NewTravelService creates a new travel service from the provider registry.
+func NewTravelService(p *providers.Providers, logger *slog.Logger) Service {
+	return NewTravelServiceWithProvider(logger, p.RoadConditions)
+}
+
+// NewTravelServiceWithProvider creates a new travel service with a custom
+// provider. This is useful for testing with mock providers.
+func NewTravelServiceWithProvider(logger *slog.Logger, roadProvider RoadConditionsProvider) Service {
+	return &travelService{
+		roadProvider: roadProvider,
+		logger:       logging.OrDefault(logger).With("component", "travel-service"),
+	}
+}
+
+// GetNearbyPasses returns pass segments within radiusKm of the coordinates,
+// sorted by distance. Points outside the provider's coverage degrade to an
+// empty list with a warning rather than an error.
+func (s *travelService) GetNearbyPasses(latitude, longitude, radiusKm float64) (*PassConditions, error) {
+	if s.roadProvider == nil || !s.roadProvider.Covers(latitude, longitude) {
+		s.logger.Warn("no road conditions coverage for coordinates",
+			"latitude", latitude,
+			"longitude", longitude,
+		)
+		return &PassConditions{
+			Segments: []PassSegment{},
+			Warning:  fmt.Sprintf("no road conditions coverage for (%.4f, %.4f)", latitude, longitude),
+		}, nil
+	}
+
+	apiResponse, err := s.roadProvider.GetRoadConditions()
+	if err != nil {
+		s.logger.Error("failed to get road conditions", "error", err)
+		return nil, fmt.Errorf("failed to get road conditions: %w", err)
+	}
+
+	result := &PassConditions{Segments: []PassSegment{}}
+	for _, feature := range apiResponse.Features {
+		distance := segmentDistanceKm(feature.Geometry.Points(), latitude, longitude)
+		if distance < 0 || distance > radiusKm {
+			continue
+		}
+
+		segment := mapRoadConditionFeature(feature)
+		segment.DistanceKm = distance
+		result.Segments = append(result.Segments, segment)
+	}
+
+	sort.Slice(result.Segments, func(i, j int) bool {
+		return result.Segments[i].DistanceKm < result.Segments[j].DistanceKm
+	})
+
+	return result, nil
+}
+
+// segmentDistanceKm returns the distance from the query point to the nearest
+// vertex of the segment geometry, or -1 when the geometry is empty.
+func segmentDistanceKm(points [][2]float64, latitude, longitude float64) float64 {
+	nearest := -1.0
+	for _, point := range points {
+		// GeoJSON order is [lon, lat]
+		distance := types.HaversineKm(latitude, longitude, point[1], point[0])
+		if nearest < 0 || distance < nearest {
+			nearest = distance
+		}
+	}
+	return nearest
+}
@@ -1,7 +1,29 @@
 package types
 
+import "math"
+
+// Conversion factors between the units the providers deliver and the units
+// clients want. Each factor lives only here, and the directional constructors
+// derive the second unit from it, so a conversion is never duplicated at a
+// different precision.
 const (
-	FeetToMeters = 0.3048
-	InchesToMm   = 25.4
-	MphToKph     = 1.60934
+	FeetToMeters = 0.3048   // international foot, exact
+	InchesToMm   = 25.4     // international inch, exact
+	MphToKph     = 1.609344 // international mile in km, exact
+	// MsToMph is derived from the exact mile (1609.344 m) rather than a
+	// rounded handbook figure
+	MsToMph = 3600.0 / 1609.344
 )
+
+// finite reports whether every value is usable as a measurement. NaN and
+// infinities can leak out of padded provider series and arithmetic on them;
+// the constructors map such inputs to their zero value instead of letting
+// them propagate into domain models.
+func finite(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
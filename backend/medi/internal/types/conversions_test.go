@@ -0,0 +1,210 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func TestTemperatureConversions(t *testing.T) {
+	tests := []struct {
+		fahrenheit float64
+		celsius    float64
+	}{
+		{32, 0},
+		{212, 100},
+		{-40, -40},
+		{0, -160.0 / 9},
+	}
+	for _, tt := range tests {
+		fromF := NewTemperatureFromFahrenheit(tt.fahrenheit)
+		if math.Abs(fromF.Celsius-tt.celsius) > tolerance {
+			t.Errorf("NewTemperatureFromFahrenheit(%v).Celsius = %v, want %v", tt.fahrenheit, fromF.Celsius, tt.celsius)
+		}
+		fromC := NewTemperatureFromCelsius(tt.celsius)
+		if math.Abs(fromC.Fahrenheit-tt.fahrenheit) > tolerance {
+			t.Errorf("NewTemperatureFromCelsius(%v).Fahrenheit = %v, want %v", tt.celsius, fromC.Fahrenheit, tt.fahrenheit)
+		}
+		roundTrip := NewTemperatureFromCelsius(fromF.Celsius)
+		if math.Abs(roundTrip.Fahrenheit-tt.fahrenheit) > tolerance {
+			t.Errorf("round trip of %v F came back as %v", tt.fahrenheit, roundTrip.Fahrenheit)
+		}
+	}
+}
+
+func TestPrecipitationConversions(t *testing.T) {
+	tests := []struct {
+		inches float64
+		mm     float64
+	}{
+		{0, 0},
+		{1, 25.4},
+		{0.05, 1.27},
+		{2.5, 63.5},
+	}
+	for _, tt := range tests {
+		fromInches := NewPrecipitationFromInches(tt.inches)
+		if math.Abs(fromInches.Mm-tt.mm) > tolerance {
+			t.Errorf("NewPrecipitationFromInches(%v).Mm = %v, want %v", tt.inches, fromInches.Mm, tt.mm)
+		}
+		fromMm := NewPrecipitationFromMillimeters(tt.mm)
+		if math.Abs(fromMm.Inches-tt.inches) > tolerance {
+			t.Errorf("NewPrecipitationFromMillimeters(%v).Inches = %v, want %v", tt.mm, fromMm.Inches, tt.inches)
+		}
+	}
+}
+
+func TestSnowDepthConversions(t *testing.T) {
+	tests := []struct {
+		feet   float64
+		meters float64
+	}{
+		{0, 0},
+		{1, 0.3048},
+		{3.5, 1.0668},
+	}
+	for _, tt := range tests {
+		fromFeet := NewSnowDepthFromFeet(tt.feet)
+		if math.Abs(fromFeet.Meters-tt.meters) > tolerance {
+			t.Errorf("NewSnowDepthFromFeet(%v).Meters = %v, want %v", tt.feet, fromFeet.Meters, tt.meters)
+		}
+		fromMeters := NewSnowDepthFromMeters(tt.meters)
+		if math.Abs(fromMeters.Feet-tt.feet) > tolerance {
+			t.Errorf("NewSnowDepthFromMeters(%v).Feet = %v, want %v", tt.meters, fromMeters.Feet, tt.feet)
+		}
+		fromCm := NewSnowDepthFromCentimeters(tt.meters * 100)
+		if math.Abs(fromCm.Feet-tt.feet) > tolerance {
+			t.Errorf("NewSnowDepthFromCentimeters(%v).Feet = %v, want %v", tt.meters*100, fromCm.Feet, tt.feet)
+		}
+	}
+}
+
+func TestElevationConversions(t *testing.T) {
+	fromFeet := NewElevationFromFeet(5280)
+	if math.Abs(fromFeet.Meters-1609.344) > tolerance {
+		t.Errorf("NewElevationFromFeet(5280).Meters = %v, want 1609.344", fromFeet.Meters)
+	}
+	fromMeters := NewElevationFromMeters(1609.344)
+	if math.Abs(fromMeters.Feet-5280) > tolerance {
+		t.Errorf("NewElevationFromMeters(1609.344).Feet = %v, want 5280", fromMeters.Feet)
+	}
+}
+
+func TestWindSpeedConversions(t *testing.T) {
+	fromMph := NewWindSpeedFromMph(10)
+	if math.Abs(fromMph.Kph-16.09344) > tolerance {
+		t.Errorf("NewWindSpeedFromMph(10).Kph = %v, want 16.09344", fromMph.Kph)
+	}
+	fromKph := NewWindSpeedFromKph(16.09344)
+	if math.Abs(fromKph.Mph-10) > tolerance {
+		t.Errorf("NewWindSpeedFromKph(16.09344).Mph = %v, want 10", fromKph.Mph)
+	}
+	// 1 m/s held for an hour covers 3.6 km
+	if math.Abs(MsToMph*MphToKph-3.6) > tolerance {
+		t.Errorf("MsToMph * MphToKph = %v, want 3.6", MsToMph*MphToKph)
+	}
+}
+
+// Non-finite provider values must collapse to the zero value instead of
+// spreading NaN through the domain models.
+func TestNonFiniteInputs(t *testing.T) {
+	inputs := []float64{math.NaN(), math.Inf(1), math.Inf(-1)}
+	for _, in := range inputs {
+		if got := NewTemperatureFromFahrenheit(in); got != (Temperature{}) {
+			t.Errorf("NewTemperatureFromFahrenheit(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewTemperatureFromCelsius(in); got != (Temperature{}) {
+			t.Errorf("NewTemperatureFromCelsius(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewPrecipitationFromInches(in); got != (Precipitation{}) {
+			t.Errorf("NewPrecipitationFromInches(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewPrecipitationFromMillimeters(in); got != (Precipitation{}) {
+			t.Errorf("NewPrecipitationFromMillimeters(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewSnowDepthFromFeet(in); got != (SnowDepth{}) {
+			t.Errorf("NewSnowDepthFromFeet(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewElevationFromFeet(in); got != (Elevation{}) {
+			t.Errorf("NewElevationFromFeet(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewWindSpeedFromMph(in); got != (WindSpeed{}) {
+			t.Errorf("NewWindSpeedFromMph(%v) = %+v, want zero value", in, got)
+		}
+		if got := NewCoords(in, 0); got != (Coords{}) {
+			t.Errorf("NewCoords(%v, 0) = %+v, want zero value", in, got)
+		}
+		if got := NewCoords(0, in); got != (Coords{}) {
+			t.Errorf("NewCoords(0, %v) = %+v, want zero value", in, got)
+		}
+	}
+}
+
+func TestNewWindDirection(t *testing.T) {
+	tests := []struct {
+		degrees  float64
+		wantDeg  float64
+		cardinal string
+	}{
+		{0, 0, "N"},
+		{90, 90, "E"},
+		{270, 270, "W"},
+		{340, 340, "NNW"},
+		// Sector boundaries: north ends just below 11.25, NNW just below 348.75
+		{11.24, 11.24, "N"},
+		{11.25, 11.25, "NNE"},
+		{348.74, 348.74, "NNW"},
+		{348.75, 348.75, "N"},
+		{359.9, 359.9, "N"},
+		{360, 0, "N"},
+		{-45, 315, "NW"},
+		{405, 45, "NE"},
+	}
+	for _, tt := range tests {
+		got := NewWindDirection(tt.degrees)
+		if got.Degrees == nil {
+			t.Errorf("NewWindDirection(%v) came back variable", tt.degrees)
+			continue
+		}
+		if *got.Degrees != tt.wantDeg || got.Cardinal != tt.cardinal {
+			t.Errorf("NewWindDirection(%v) = {%v %s}, want {%v %s}",
+				tt.degrees, *got.Degrees, got.Cardinal, tt.wantDeg, tt.cardinal)
+		}
+	}
+}
+
+func TestNewWindDirectionFromCardinal(t *testing.T) {
+	nw := NewWindDirectionFromCardinal("nw ")
+	if nw.Cardinal != "NW" || nw.Degrees == nil || *nw.Degrees != 315 {
+		t.Errorf("NewWindDirectionFromCardinal(\"nw \") = %+v, want NW at 315", nw)
+	}
+	for _, label := range []string{"", "VRB", "north-northwest"} {
+		if got := NewWindDirectionFromCardinal(label); !got.IsVariable() {
+			t.Errorf("NewWindDirectionFromCardinal(%q) = %+v, want variable", label, got)
+		}
+	}
+}
+
+func TestCalmWindHasVariableDirection(t *testing.T) {
+	calm := NewWind(0.4, 2, 270)
+	if !calm.Direction.IsVariable() || calm.Direction.Cardinal != CardinalVariable {
+		t.Errorf("calm wind direction = %+v, want variable", calm.Direction)
+	}
+
+	blowing := NewWindWithoutGusts(12, 270)
+	if blowing.Direction.IsVariable() || blowing.Direction.Cardinal != "W" {
+		t.Errorf("12 mph wind direction = %+v, want W", blowing.Direction)
+	}
+}
+
+func TestVariableWindDirectionJSONOmitsDegrees(t *testing.T) {
+	out, err := json.Marshal(VariableWindDirection())
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(out) != `{"cardinal":"VRB"}` {
+		t.Errorf("variable direction JSON = %s, want the degrees field omitted", out)
+	}
+}
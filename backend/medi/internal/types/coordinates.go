@@ -1,13 +1,36 @@
 package types
 
+import "math"
+
 type Coords struct {
 	Latitude  float64 `json:"latitude" example:"40.7128" doc:"Latitude in decimal degrees"`
 	Longitude float64 `json:"longitude" example:"-74.0060" doc:"Longitude in decimal degrees"`
 }
 
+// NewCoords builds a coordinate pair; a non-finite component yields the zero
+// value rather than a point that breaks downstream trigonometry.
 func NewCoords(latitude, longitude float64) Coords {
+	if !finite(latitude, longitude) {
+		return Coords{}
+	}
 	return Coords{
 		Latitude:  latitude,
 		Longitude: longitude,
 	}
 }
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances.
+const earthRadiusKm = 6371.0
+
+// HaversineKm computes the great-circle distance in kilometers between two
+// coordinate pairs.
+func HaversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const degToRad = math.Pi / 180
+
+	dLat := (lat2 - lat1) * degToRad
+	dLon := (lon2 - lon1) * degToRad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*degToRad)*math.Cos(lat2*degToRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -5,9 +5,26 @@ type Elevation struct {
 	Meters float64 `json:"meters" example:"1609.34" doc:"Elevation in meters"`
 }
 
+// NewElevationFromFeet builds an Elevation from a height in feet; a
+// non-finite input yields the zero value.
 func NewElevationFromFeet(feet float64) Elevation {
+	if !finite(feet) {
+		return Elevation{}
+	}
 	return Elevation{
 		Meters: feet * FeetToMeters,
 		Feet:   feet,
 	}
 }
+
+// NewElevationFromMeters builds an Elevation from a height in meters; a
+// non-finite input yields the zero value.
+func NewElevationFromMeters(meters float64) Elevation {
+	if !finite(meters) {
+		return Elevation{}
+	}
+	return Elevation{
+		Meters: meters,
+		Feet:   meters / FeetToMeters,
+	}
+}
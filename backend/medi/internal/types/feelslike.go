@@ -0,0 +1,77 @@
+package types
+
+import "math"
+
+// Feels-like source labels, reporting which regime selected the value
+const (
+	FeelsLikeSourceWindChill = "windChill"
+	FeelsLikeSourceHeatIndex = "heatIndex"
+	FeelsLikeSourceApparent  = "apparentTemperature"
+)
+
+// Wind chill is defined by the NWS only for cold, windy air; heat index only
+// for hot air. Between the regimes the model's apparent temperature is the
+// best available answer, which also covers the awkward band around 50°F where
+// wind chill cuts off.
+const (
+	// windChillMaxTempF and windChillMinWindMph bound the NWS wind chill
+	// formula's validity; outside them the formula can read warmer than the air
+	windChillMaxTempF   = 50.0
+	windChillMinWindMph = 3.0
+	// heatIndexMinTempF is where the NWS heat index regression takes over
+	heatIndexMinTempF = 80.0
+)
+
+// FeelsLike is the perceived temperature for an hour, selected from wind
+// chill, heat index, or the model's apparent temperature depending on the
+// conditions; Source says which one was used.
+type FeelsLike struct {
+	Temperature Temperature `json:"temperature"`
+	Source      string      `json:"source" example:"windChill" doc:"Which formula produced the value: windChill, heatIndex, or apparentTemperature"`
+}
+
+// NewFeelsLike selects the perceived temperature for the given conditions:
+// the NWS wind chill when the air is at or below 50°F with wind of at least
+// 3 mph, the NWS heat index at or above 80°F, and the supplied apparent
+// temperature otherwise. Inputs are Fahrenheit, mph, and percent relative
+// humidity.
+func NewFeelsLike(temperatureF, windSpeedMph, relativeHumidityPercent float64, apparent Temperature) FeelsLike {
+	switch {
+	case !finite(temperatureF):
+		return FeelsLike{Temperature: apparent, Source: FeelsLikeSourceApparent}
+	case temperatureF <= windChillMaxTempF && windSpeedMph >= windChillMinWindMph:
+		return FeelsLike{
+			Temperature: NewTemperatureFromFahrenheit(windChillFahrenheit(temperatureF, windSpeedMph)),
+			Source:      FeelsLikeSourceWindChill,
+		}
+	case temperatureF >= heatIndexMinTempF:
+		return FeelsLike{
+			Temperature: NewTemperatureFromFahrenheit(heatIndexFahrenheit(temperatureF, relativeHumidityPercent)),
+			Source:      FeelsLikeSourceHeatIndex,
+		}
+	default:
+		return FeelsLike{Temperature: apparent, Source: FeelsLikeSourceApparent}
+	}
+}
+
+// windChillFahrenheit is the NWS wind chill formula (2001 revision)
+func windChillFahrenheit(temperatureF, windSpeedMph float64) float64 {
+	v := math.Pow(windSpeedMph, 0.16)
+	return 35.74 + 0.6215*temperatureF - 35.75*v + 0.4275*temperatureF*v
+}
+
+// heatIndexFahrenheit is the NWS heat index: the Rothfusz regression with the
+// low- and high-humidity adjustments the NWS applies alongside it
+func heatIndexFahrenheit(temperatureF, relativeHumidityPercent float64) float64 {
+	t, rh := temperatureF, relativeHumidityPercent
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh -
+		0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+		0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+
+	if rh < 13 && t >= 80 && t <= 112 {
+		hi -= ((13 - rh) / 4) * math.Sqrt((17-math.Abs(t-95))/17)
+	} else if rh > 85 && t >= 80 && t <= 87 {
+		hi += ((rh - 85) / 10) * ((87 - t) / 5)
+	}
+	return hi
+}
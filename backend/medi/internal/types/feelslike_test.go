@@ -0,0 +1,74 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFeelsLikeSelectsRegime(t *testing.T) {
+	apparent := NewTemperatureFromFahrenheit(47)
+	tests := []struct {
+		name         string
+		temperatureF float64
+		windMph      float64
+		humidityPct  float64
+		wantSource   string
+	}{
+		{"cold and windy selects wind chill", 20, 15, 60, FeelsLikeSourceWindChill},
+		{"wind chill at both boundaries", 50, 3, 60, FeelsLikeSourceWindChill},
+		{"cold but calm falls back to apparent", 20, 2.9, 60, FeelsLikeSourceApparent},
+		{"hot selects heat index", 90, 5, 70, FeelsLikeSourceHeatIndex},
+		{"heat index at its boundary", 80, 0, 40, FeelsLikeSourceHeatIndex},
+		{"just below the heat index floor", 79.9, 0, 40, FeelsLikeSourceApparent},
+		{"mild air uses apparent", 65, 10, 50, FeelsLikeSourceApparent},
+
+		// The transition band: wind chill cuts off above 50°F even in wind,
+		// so 48-50 gets wind chill and 50.1-52 the apparent temperature
+		{"48F windy is still wind chill", 48, 10, 50, FeelsLikeSourceWindChill},
+		{"50F windy is still wind chill", 50, 10, 50, FeelsLikeSourceWindChill},
+		{"just above 50F switches to apparent", 50.1, 10, 50, FeelsLikeSourceApparent},
+		{"52F windy is apparent", 52, 25, 50, FeelsLikeSourceApparent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFeelsLike(tt.temperatureF, tt.windMph, tt.humidityPct, apparent)
+			if got.Source != tt.wantSource {
+				t.Errorf("NewFeelsLike(%v, %v, %v).Source = %q, want %q",
+					tt.temperatureF, tt.windMph, tt.humidityPct, got.Source, tt.wantSource)
+			}
+			if got.Source == FeelsLikeSourceApparent && got.Temperature != apparent {
+				t.Errorf("apparent selection returned %v, want the supplied apparent %v", got.Temperature, apparent)
+			}
+		})
+	}
+}
+
+func TestNewFeelsLikeFormulaValues(t *testing.T) {
+	// 30°F with 10 mph wind is 21.25°F on the NWS wind chill chart
+	windChill := NewFeelsLike(30, 10, 50, Temperature{})
+	if math.Abs(windChill.Temperature.Fahrenheit-21.25) > 0.05 {
+		t.Errorf("wind chill at 30F/10mph = %v, want about 21.25", windChill.Temperature.Fahrenheit)
+	}
+
+	// 90°F at 70%% humidity is about 105.9°F under the Rothfusz regression
+	heatIndex := NewFeelsLike(90, 0, 70, Temperature{})
+	if math.Abs(heatIndex.Temperature.Fahrenheit-105.92) > 0.05 {
+		t.Errorf("heat index at 90F/70%% = %v, want about 105.92", heatIndex.Temperature.Fahrenheit)
+	}
+
+	// Wind chill must read colder than the air, heat index warmer
+	if windChill.Temperature.Fahrenheit >= 30 {
+		t.Errorf("wind chill %v not below the air temperature", windChill.Temperature.Fahrenheit)
+	}
+	if heatIndex.Temperature.Fahrenheit <= 90 {
+		t.Errorf("heat index %v not above the air temperature", heatIndex.Temperature.Fahrenheit)
+	}
+}
+
+func TestNewFeelsLikeNonFiniteTemperature(t *testing.T) {
+	apparent := NewTemperatureFromFahrenheit(33)
+	got := NewFeelsLike(math.NaN(), 10, 50, apparent)
+	if got.Source != FeelsLikeSourceApparent || got.Temperature != apparent {
+		t.Errorf("NewFeelsLike(NaN, ...) = %+v, want the apparent fallback", got)
+	}
+}
@@ -6,4 +6,13 @@ type ForecastPoint struct {
 	Coordinates Coords       `json:"coordinates" doc:"Geographic coordinates"`
 	Elevation   Elevation    `json:"elevation" doc:"Elevation data"`
 	Location    LocationInfo `json:"location" doc:"Human-readable location information"`
+	// TerrainAspect is the downslope direction of the local terrain, estimated
+	// from surrounding elevation samples; nil when the terrain is effectively
+	// flat or sampling was unavailable
+	TerrainAspect *WindDirection `json:"terrainAspect,omitempty" doc:"Downslope direction of the local terrain"`
+	// SlopeAngle is the estimated local slope steepness in degrees
+	SlopeAngle float64 `json:"slopeAngle,omitempty" doc:"Local slope angle in degrees"`
+	// ElevationSource records where the elevation came from: "user-supplied"
+	// when the caller overrode it, empty when the elevation provider was used
+	ElevationSource string `json:"elevationSource,omitempty" doc:"Provenance of the elevation value"`
 }
@@ -1,13 +1,30 @@
 package types
 
 type Precipitation struct {
-	Inches float64
-	Mm     float64
+	Inches float64 `json:"inches" example:"1" doc:"Precipitation amount in inches"`
+	Mm     float64 `json:"mm" example:"25.4" doc:"Precipitation amount in millimeters"`
 }
 
+// NewPrecipitationFromInches builds a Precipitation from an amount in inches;
+// a non-finite input yields the zero value.
 func NewPrecipitationFromInches(amountInInches float64) Precipitation {
+	if !finite(amountInInches) {
+		return Precipitation{}
+	}
 	return Precipitation{
 		Inches: amountInInches,
 		Mm:     amountInInches * InchesToMm,
 	}
 }
+
+// NewPrecipitationFromMillimeters builds a Precipitation from an amount in
+// millimeters; a non-finite input yields the zero value.
+func NewPrecipitationFromMillimeters(amountInMm float64) Precipitation {
+	if !finite(amountInMm) {
+		return Precipitation{}
+	}
+	return Precipitation{
+		Inches: amountInMm / InchesToMm,
+		Mm:     amountInMm,
+	}
+}
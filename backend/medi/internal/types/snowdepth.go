@@ -1,13 +1,37 @@
 package types
 
 type SnowDepth struct {
-	Feet   float64
-	Meters float64
+	Feet   float64 `json:"feet" example:"1" doc:"Snow depth in feet"`
+	Meters float64 `json:"meters" example:"0.3048" doc:"Snow depth in meters"`
 }
 
+// NewSnowDepthFromFeet builds a SnowDepth from a depth in feet; a non-finite
+// input yields the zero value.
 func NewSnowDepthFromFeet(amountInFeet float64) SnowDepth {
+	if !finite(amountInFeet) {
+		return SnowDepth{}
+	}
 	return SnowDepth{
 		Feet:   amountInFeet,
 		Meters: amountInFeet * FeetToMeters,
 	}
 }
+
+// NewSnowDepthFromMeters builds a SnowDepth from a depth in meters; a
+// non-finite input yields the zero value.
+func NewSnowDepthFromMeters(amountInMeters float64) SnowDepth {
+	if !finite(amountInMeters) {
+		return SnowDepth{}
+	}
+	return SnowDepth{
+		Feet:   amountInMeters / FeetToMeters,
+		Meters: amountInMeters,
+	}
+}
+
+// NewSnowDepthFromCentimeters builds a SnowDepth from a depth in centimeters,
+// the unit most European sources report; a non-finite input yields the zero
+// value.
+func NewSnowDepthFromCentimeters(amountInCm float64) SnowDepth {
+	return NewSnowDepthFromMeters(amountInCm / 100)
+}
@@ -1,14 +1,30 @@
 package types
 
 type Temperature struct {
-	Celsius    float64
-	Fahrenheit float64
+	Celsius    float64 `json:"celsius" example:"0" doc:"Temperature in degrees Celsius"`
+	Fahrenheit float64 `json:"fahrenheit" example:"32" doc:"Temperature in degrees Fahrenheit"`
 }
 
+// NewTemperatureFromFahrenheit builds a Temperature from a Fahrenheit reading;
+// a non-finite input yields the zero value.
 func NewTemperatureFromFahrenheit(fahrenheit float64) Temperature {
-	var celsius = (fahrenheit - 32) * 5 / 9
+	if !finite(fahrenheit) {
+		return Temperature{}
+	}
 	return Temperature{
-		Celsius:    celsius,
+		Celsius:    (fahrenheit - 32) * 5 / 9,
 		Fahrenheit: fahrenheit,
 	}
 }
+
+// NewTemperatureFromCelsius builds a Temperature from a Celsius reading;
+// a non-finite input yields the zero value.
+func NewTemperatureFromCelsius(celsius float64) Temperature {
+	if !finite(celsius) {
+		return Temperature{}
+	}
+	return Temperature{
+		Celsius:    celsius,
+		Fahrenheit: celsius*9/5 + 32,
+	}
+}
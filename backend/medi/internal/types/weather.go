@@ -1,5 +1,7 @@
 package types
 
+import "sort"
+
 // WeatherCode represents a WMO weather code
 type WeatherCode int
 
@@ -88,3 +90,13 @@ func NewWeather(code int) Weather {
 		Description: GetWeatherDescription(code),
 	}
 }
+
+// WeatherCodes returns every known WMO weather code, sorted
+func WeatherCodes() []int {
+	codes := make([]int, 0, len(weatherDescriptions))
+	for code := range weatherDescriptions {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
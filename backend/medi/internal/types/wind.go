@@ -1,75 +1,143 @@
 package types
 
+import (
+	"math"
+	"strings"
+)
+
 type Wind struct {
-	Speed     WindSpeed
-	Gusts     WindSpeed
-	Direction WindDirection
+	Speed WindSpeed `json:"speed"`
+	// Gusts is nil when the source model does not report gusts
+	Gusts     *WindSpeed    `json:"gusts,omitempty"`
+	Direction WindDirection `json:"direction"`
+	// GustsEstimated marks Gusts values backfilled from wind speed rather
+	// than reported by the model
+	GustsEstimated bool `json:"gustsEstimated,omitempty"`
 }
 
+// CardinalVariable is the compass label for a variable direction: the wind
+// is effectively calm or the source reported no direction
+const CardinalVariable = "VRB"
+
+// calmWindSpeedMph is the speed at or below which a reported direction is
+// meaningless; models emit an arbitrary direction for near-calm air
+const calmWindSpeedMph = 1.0
+
 type WindDirection struct {
-	Degrees  float64
-	Cardinal string
+	// Degrees is nil when the direction is variable
+	Degrees  *float64 `json:"degrees,omitempty" example:"270" doc:"Wind direction in degrees; omitted when variable"`
+	Cardinal string   `json:"cardinal" example:"W" doc:"Wind direction on the 16-point compass rose, or VRB when variable"`
+}
+
+// IsVariable reports whether this is the variable/calm representation
+func (d WindDirection) IsVariable() bool {
+	return d.Degrees == nil
 }
 
+// NewWindSpeedFromMph builds a WindSpeed from a speed in miles per hour; a
+// non-finite input yields the zero value.
 func NewWindSpeedFromMph(speedInMph float64) WindSpeed {
+	if !finite(speedInMph) {
+		return WindSpeed{}
+	}
 	return WindSpeed{
 		Mph: speedInMph,
 		Kph: speedInMph * MphToKph,
 	}
 }
 
-func NewWindDirection(degrees int) WindDirection {
-	if degrees < 0 || degrees >= 360 {
-		return WindDirection{
-			Degrees:  -1,
-			Cardinal: "Unknown",
-		}
+// NewWindSpeedFromKph builds a WindSpeed from a speed in kilometers per hour;
+// a non-finite input yields the zero value.
+func NewWindSpeedFromKph(speedInKph float64) WindSpeed {
+	if !finite(speedInKph) {
+		return WindSpeed{}
 	}
+	return WindSpeed{
+		Mph: speedInKph / MphToKph,
+		Kph: speedInKph,
+	}
+}
+
+// cardinalDirections indexes the 16-point compass rose by 22.5 degree sector
+var cardinalDirections = [16]string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
 
-	degreesFloat := float64(degrees)
-	direction := (degreesFloat / 22.5) + .5 // .5 for rounding
-	var directionMap = make(map[int]string)
-	directionMap[0] = "N"
-	directionMap[1] = "NNE"
-	directionMap[2] = "NE"
-	directionMap[3] = "ENE"
-	directionMap[4] = "E"
-	directionMap[5] = "ESE"
-	directionMap[6] = "SE"
-	directionMap[7] = "SSE"
-	directionMap[8] = "S"
-	directionMap[9] = "SSW"
-	directionMap[10] = "SW"
-	directionMap[11] = "WSW"
-	directionMap[12] = "W"
-	directionMap[13] = "WNW"
-	directionMap[14] = "NW"
-	directionMap[15] = "NNW"
-
-	index := int(direction) % 16
-	directionCardinal := directionMap[index]
-
-	windDirection := WindDirection{
-		Degrees:  degreesFloat,
-		Cardinal: directionCardinal,
+// VariableWindDirection returns the representation for a calm or unreported
+// direction
+func VariableWindDirection() WindDirection {
+	return WindDirection{Cardinal: CardinalVariable}
+}
+
+// NewWindDirection builds a WindDirection from degrees, normalized into
+// [0, 360) so 360.0 is due north rather than out of range; a non-finite
+// input yields the variable representation.
+func NewWindDirection(degrees float64) WindDirection {
+	if !finite(degrees) {
+		return VariableWindDirection()
+	}
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
 	}
 
-	return windDirection
+	// Each 22.5 degree sector is centered on its cardinal, so north covers
+	// [348.75, 360) plus [0, 11.25)
+	index := int(math.Floor(degrees/22.5+0.5)) % 16
+
+	return WindDirection{
+		Degrees:  &degrees,
+		Cardinal: cardinalDirections[index],
+	}
 }
 
-type WindSpeed struct {
-	Mph float64
-	Kph float64
+// NewWindDirectionFromCardinal builds a WindDirection from a compass-rose
+// label like "NW", using the sector center as the degree value. Empty,
+// "VRB", and unrecognized labels yield the variable representation.
+func NewWindDirectionFromCardinal(cardinal string) WindDirection {
+	label := strings.ToUpper(strings.TrimSpace(cardinal))
+	for i, name := range cardinalDirections {
+		if name == label {
+			degrees := float64(i) * 22.5
+			return WindDirection{
+				Degrees:  &degrees,
+				Cardinal: name,
+			}
+		}
+	}
+	return VariableWindDirection()
+}
+
+// windDirectionForSpeed treats the direction as variable when the wind is
+// effectively calm
+func windDirectionForSpeed(speedInMph, directionDegrees float64) WindDirection {
+	if !finite(speedInMph) || math.Abs(speedInMph) <= calmWindSpeedMph {
+		return VariableWindDirection()
+	}
+	return NewWindDirection(directionDegrees)
 }
 
-func NewWind(speedInMph, gustsInMph float64, directionDegrees int) Wind {
+type WindSpeed struct {
+	Mph float64 `json:"mph" example:"10" doc:"Wind speed in miles per hour"`
+	Kph float64 `json:"kph" example:"16.09" doc:"Wind speed in kilometers per hour"`
+}
 
+func NewWind(speedInMph, gustsInMph, directionDegrees float64) Wind {
 	speed := NewWindSpeedFromMph(speedInMph)
 	gusts := NewWindSpeedFromMph(gustsInMph)
-	direction := NewWindDirection(directionDegrees)
 	return Wind{
 		Speed:     speed,
-		Gusts:     gusts,
-		Direction: direction,
+		Gusts:     &gusts,
+		Direction: windDirectionForSpeed(speedInMph, directionDegrees),
+	}
+}
+
+// NewWindWithoutGusts builds a Wind for models that do not report gusts,
+// leaving Gusts nil rather than storing a sentinel value.
+func NewWindWithoutGusts(speedInMph, directionDegrees float64) Wind {
+	return Wind{
+		Speed:     NewWindSpeedFromMph(speedInMph),
+		Direction: windDirectionForSpeed(speedInMph, directionDegrees),
 	}
 }
@@ -0,0 +1,122 @@
+package weather
+
+import (
+	"context"
+	"log/slog"
+	"medi/internal/providers/mock"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/types"
+	"testing"
+)
+
+// synthForecastResponse generates a fully-populated Open-Meteo response via
+// the mock provider and zeroes all accumulation arrays so tests can place
+// precise values.
+func synthForecastResponse(t *testing.T, forecastDays int) *openmeteo.ForecastAPIResponse {
+	t.Helper()
+
+	client := mock.NewWeatherClient(slog.Default(), mock.Options{})
+	apiResp, err := client.GetForecast(context.Background(), 39.11539, -107.6584, 2900, forecastDays, "UTC")
+	if err != nil {
+		t.Fatalf("mock GetForecast returned error: %v", err)
+	}
+
+	hours := len(apiResp.Hourly.Time)
+	apiResp.Hourly.SnowfallGfsSeamless = make([]float64, hours)
+	apiResp.Hourly.SnowfallGemSeamless = make([]float64, hours)
+	apiResp.Hourly.SnowfallEcmwfIfs = make([]float64, hours)
+	apiResp.Hourly.SnowfallNcepNbmConus = make([]float64, hours)
+	apiResp.Hourly.SnowfallGfsGraphcast025 = make([]float64, hours)
+	apiResp.Hourly.SnowfallEcmwfAifs025Single = make([]float64, hours)
+	apiResp.Hourly.SnowfallNcepNamConus = make([]float64, hours)
+	apiResp.Hourly.PrecipitationGfsSeamless = make([]float64, hours)
+	apiResp.Hourly.PrecipitationGemSeamless = make([]float64, hours)
+	apiResp.Hourly.PrecipitationEcmwfIfs = make([]float64, hours)
+	apiResp.Hourly.PrecipitationNcepNbmConus = make([]float64, hours)
+	apiResp.Hourly.PrecipitationGfsGraphcast025 = make([]float64, hours)
+	apiResp.Hourly.PrecipitationEcmwfAifs025Single = make([]float64, hours)
+	apiResp.Hourly.PrecipitationNcepNamConus = make([]float64, hours)
+	apiResp.Hourly.RainGfsSeamless = make([]float64, hours)
+	apiResp.Hourly.RainGemSeamless = make([]float64, hours)
+	apiResp.Hourly.RainEcmwfIfs = make([]float64, hours)
+	apiResp.Hourly.RainNcepNbmConus = make([]float64, hours)
+	apiResp.Hourly.RainGfsGraphcast025 = make([]float64, hours)
+	apiResp.Hourly.RainEcmwfAifs025Single = make([]float64, hours)
+	apiResp.Hourly.RainNcepNamConus = make([]float64, hours)
+	apiResp.Hourly.ShowersGfsSeamless = make([]float64, hours)
+	apiResp.Hourly.ShowersGemSeamless = make([]float64, hours)
+	apiResp.Hourly.ShowersEcmwfIfs = make([]float64, hours)
+	apiResp.Hourly.ShowersNcepNbmConus = make([]float64, hours)
+	apiResp.Hourly.ShowersGfsGraphcast025 = make([]float64, hours)
+	apiResp.Hourly.ShowersEcmwfAifs025Single = make([]float64, hours)
+	apiResp.Hourly.ShowersNcepNamConus = make([]float64, hours)
+
+	return apiResp
+}
+
+func TestAccumulationDayAttributionAtMidnight(t *testing.T) {
+	apiResp := synthForecastResponse(t, 3)
+
+	// Snow falls exactly in the 23:00-00:00 hour of day one. Open-Meteo
+	// stamps that accumulation at the following midnight (index 24).
+	apiResp.Hourly.SnowfallGfsSeamless[24] = 0.6
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	if len(forecast.DailyForecasts) < 2 {
+		t.Fatalf("expected at least 2 daily forecasts, got %d", len(forecast.DailyForecasts))
+	}
+
+	dayOne := forecast.DailyForecasts[0]
+	dayTwo := forecast.DailyForecasts[1]
+
+	// The snowfall belongs to day one's 23:00 hour
+	if len(dayOne.HourlyForecasts) != 24 {
+		t.Fatalf("day one has %d hourly forecasts, want 24", len(dayOne.HourlyForecasts))
+	}
+	lastHour := dayOne.HourlyForecasts[23]
+	if lastHour.Start.Hour() != 23 {
+		t.Fatalf("last hourly forecast starts at hour %d, want 23", lastHour.Start.Hour())
+	}
+	snowfall, ok := lastHour.Snowfall.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS snowfall in last hour of day one")
+	}
+	if snowfall.Inches != 0.6 {
+		t.Errorf("23:00-00:00 snowfall = %v inches, want 0.6", snowfall.Inches)
+	}
+
+	// Day one's total includes it, day two's does not
+	dayOneTotal, ok := dayOne.TotalSnowfall.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS total snowfall for day one")
+	}
+	if dayOneTotal.Inches != 0.6 {
+		t.Errorf("day one total snowfall = %v inches, want 0.6", dayOneTotal.Inches)
+	}
+
+	dayTwoTotal, ok := dayTwo.TotalSnowfall.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS total snowfall for day two")
+	}
+	if dayTwoTotal.Inches != 0 {
+		t.Errorf("day two total snowfall = %v inches, want 0", dayTwoTotal.Inches)
+	}
+
+	// Midnight-stamped snowfall must not leak into day two's first hour
+	firstHourDayTwo := dayTwo.HourlyForecasts[0]
+	snowfall, ok = firstHourDayTwo.Snowfall.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS snowfall in first hour of day two")
+	}
+	if snowfall.Inches != 0 {
+		t.Errorf("day two 00:00-01:00 snowfall = %v inches, want 0", snowfall.Inches)
+	}
+}
@@ -0,0 +1,218 @@
+package weather
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// NWS area forecast discussions arrive as fixed-width teletype products:
+// hard-wrapped paragraphs, CRLF mixed with LF, section markers like
+// ".SHORT TERM..." terminated by "&&", and coded blocks (temps/pops tables,
+// watch/warning zone lists) that are tabular on purpose. ParseForecastDiscussion
+// keeps the raw product intact for clients that render it verbatim and adds a
+// normalized variant with the wrapping undone, split into named sections.
+
+// minUnwrapLineLength is the shortest line treated as hard-wrapped prose.
+// AFD products wrap near column 66, so a line this short that stops
+// mid-sentence is a masthead or code line, not a wrapped paragraph.
+const minUnwrapLineLength = 45
+
+// maxDiscussionBytes caps how much product text the normalizer processes.
+// Real AFD products run a few tens of kilobytes; anything past the cap is a
+// malformed or hostile payload and only the raw text keeps it.
+const maxDiscussionBytes = 1 << 20
+
+// ForecastDiscussion is a parsed NWS area forecast discussion.
+type ForecastDiscussion struct {
+	IssuingOffice string
+	IssuanceTime  time.Time
+	// RawText is the product exactly as issued, for clients that want the
+	// teletype layout
+	RawText string
+	// NormalizedText is the full product with encoding artifacts removed and
+	// hard-wrapped paragraphs unwrapped
+	NormalizedText string
+	Sections       []DiscussionSection
+}
+
+// DiscussionSection is one named section of the discussion, e.g. "KEY
+// MESSAGES" or "SHORT TERM /THROUGH FRIDAY NIGHT/", with normalized body text.
+type DiscussionSection struct {
+	Name string
+	Text string
+}
+
+// ParseForecastDiscussion normalizes an AFD product text and splits it into
+// sections. The raw text is preserved unchanged.
+func ParseForecastDiscussion(productText string) *ForecastDiscussion {
+	normalized := normalizeDiscussionText(productText)
+	return &ForecastDiscussion{
+		RawText:        productText,
+		NormalizedText: normalized,
+		Sections:       parseDiscussionSections(normalized),
+	}
+}
+
+// normalizeDiscussionText converts the product to clean UTF-8 with LF line
+// endings and unwraps hard-wrapped paragraphs. Bullets, section markers,
+// and tabular blocks keep their line structure.
+func normalizeDiscussionText(raw string) string {
+	if len(raw) > maxDiscussionBytes {
+		raw = raw[:maxDiscussionBytes]
+	}
+	text := strings.ToValidUTF8(raw, "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	unwrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if len(unwrapped) == 0 || !joinLines(unwrapped[len(unwrapped)-1], line) {
+			unwrapped = append(unwrapped, line)
+			continue
+		}
+
+		previous := unwrapped[len(unwrapped)-1]
+		continuation := strings.TrimLeft(line, " ")
+		if endsHyphenatedWord(previous) && startsLowercase(continuation) {
+			// Re-join a word hyphenated across the wrap, e.g. "south-\nfacing"
+			unwrapped[len(unwrapped)-1] = previous + continuation
+		} else {
+			unwrapped[len(unwrapped)-1] = previous + " " + continuation
+		}
+	}
+
+	return strings.Join(unwrapped, "\n")
+}
+
+// joinLines reports whether line is a wrapped continuation of previous and
+// the two should be merged into one
+func joinLines(previous, line string) bool {
+	if previous == "" || line == "" {
+		return false
+	}
+
+	// Structural lines never merge into the previous line
+	trimmed := strings.TrimLeft(line, " ")
+	if isSectionHeader(line) || trimmed == "&&" || trimmed == "$$" {
+		return false
+	}
+	if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
+		return false
+	}
+	// Deep indentation marks an intentional layout, like the zone lists under
+	// watches/warnings
+	if len(line)-len(trimmed) >= 4 {
+		return false
+	}
+	if isTabularLine(previous) || isTabularLine(trimmed) {
+		return false
+	}
+
+	// Only prose that was cut off mid-sentence at the wrap column unwraps;
+	// short lines ended where they did on purpose
+	if endsHyphenatedWord(previous) && startsLowercase(trimmed) {
+		return true
+	}
+	if len(previous) < minUnwrapLineLength || endsSentence(previous) {
+		return false
+	}
+	return true
+}
+
+// endsHyphenatedWord reports whether the line breaks a word at a hyphen,
+// e.g. "south-" wrapped before "facing". A digit before the hyphen is a
+// numeric range like "16- to 17-to-1", not a broken word.
+func endsHyphenatedWord(line string) bool {
+	if !strings.HasSuffix(line, "-") {
+		return false
+	}
+	runes := []rune(strings.TrimSuffix(line, "-"))
+	return len(runes) > 0 && unicode.IsLower(runes[len(runes)-1])
+}
+
+// isSectionHeader reports whether line opens a section, e.g. ".KEY MESSAGES..."
+func isSectionHeader(line string) bool {
+	if !strings.HasPrefix(line, ".") {
+		return false
+	}
+	name, _, found := strings.Cut(line[1:], "...")
+	return found && name != "" && name == strings.ToUpper(name)
+}
+
+// isTabularLine reports whether a line belongs to a coded or tabular block,
+// like the point temps/pops table: columns separated by runs of spaces, or
+// code lines with no prose at all
+func isTabularLine(line string) bool {
+	if strings.Contains(line, "  ") || strings.Contains(line, " / ") {
+		return true
+	}
+	for _, r := range line {
+		if unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// endsSentence reports whether the line's final rune terminates a sentence
+// or introduces a list
+func endsSentence(line string) bool {
+	trimmed := strings.TrimRight(line, `"')`)
+	return strings.HasSuffix(trimmed, ".") ||
+		strings.HasSuffix(trimmed, "!") ||
+		strings.HasSuffix(trimmed, "?") ||
+		strings.HasSuffix(trimmed, ":")
+}
+
+func startsLowercase(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// parseDiscussionSections splits normalized text into its named sections.
+// A section runs from its ".NAME..." header to the "&&" or "$$" delimiter or
+// the next header; text before the first header (the product masthead) is not
+// a section.
+func parseDiscussionSections(normalized string) []DiscussionSection {
+	var sections []DiscussionSection
+	var current *DiscussionSection
+	var body []string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Text = strings.TrimSpace(strings.Join(body, "\n"))
+		sections = append(sections, *current)
+		current = nil
+		body = nil
+	}
+
+	for _, line := range strings.Split(normalized, "\n") {
+		switch {
+		case isSectionHeader(line):
+			flush()
+			name, rest, _ := strings.Cut(line[1:], "...")
+			current = &DiscussionSection{Name: name}
+			// Some products run the body straight on from the header line
+			if rest = strings.TrimSpace(rest); rest != "" {
+				body = append(body, rest)
+			}
+		case strings.TrimSpace(line) == "&&" || strings.TrimSpace(line) == "$$":
+			flush()
+		case current != nil:
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	return sections
+}
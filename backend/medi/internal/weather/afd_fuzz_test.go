@@ -0,0 +1,47 @@
+package weather
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"medi/internal/providers/nws"
+)
+
+// FuzzParseForecastDiscussion throws arbitrary teletype products at the AFD
+// parser: it must never panic, must keep the raw text byte-for-byte, and must
+// only produce valid UTF-8 with named sections.
+func FuzzParseForecastDiscussion(f *testing.F) {
+	f.Add(".SHORT TERM...\nSnow develops overnight with accumulations of\n8 to 12 inches above treeline.\n&&\n")
+	f.Add(".KEY MESSAGES...- First bullet\n- Second bullet\n$$")
+	f.Add("no sections at all, just prose\r\nwith mixed line\rendings")
+	f.Add(".LONG TERM /FRIDAY THROUGH MONDAY/...ridge builds mid-\nweek ahead of the next trough.")
+	f.Add("\xff\xfe.BROKEN UTF8...body")
+	f.Add(strings.Repeat(".A...\n&&\n", 200))
+	f.Add("")
+
+	// Seed the committed AFD fixture's product text
+	if data, err := os.ReadFile("testdata/nws_afd_response.json"); err == nil {
+		var apiResponse nws.AFDAPIResponse
+		if json.Unmarshal(data, &apiResponse) == nil && apiResponse.ProductText != "" {
+			f.Add(apiResponse.ProductText)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, productText string) {
+		discussion := ParseForecastDiscussion(productText)
+		if discussion.RawText != productText {
+			t.Error("raw text was not preserved")
+		}
+		if !utf8.ValidString(discussion.NormalizedText) {
+			t.Error("normalized text is not valid UTF-8")
+		}
+		for _, section := range discussion.Sections {
+			if section.Name == "" {
+				t.Error("parsed a section without a name")
+			}
+		}
+	})
+}
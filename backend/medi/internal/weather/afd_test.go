@@ -0,0 +1,221 @@
+package weather
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"medi/internal/providers/nws"
+)
+
+func TestNormalizeDiscussionText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "unwraps hard-wrapped paragraph",
+			raw: "Weaker flow has set up across the region amidst a transient \n" +
+				"ridge. Moisture continues to dry out under this pattern as \n" +
+				"PWAT's have dipped to 0.12 in. per the 18Z GJT sounding.\n",
+			want: "Weaker flow has set up across the region amidst a transient " +
+				"ridge. Moisture continues to dry out under this pattern as " +
+				"PWAT's have dipped to 0.12 in. per the 18Z GJT sounding.\n",
+		},
+		{
+			name: "rejoins word hyphenated across the wrap",
+			raw: "Snow totals are looking respectable across the south-\n" +
+				"facing slopes of the San Juans.\n",
+			want: "Snow totals are looking respectable across the south-" +
+				"facing slopes of the San Juans.\n",
+		},
+		{
+			name: "numeric range hyphen joins with a space",
+			raw: "Accumulations along the UT-AZ border remain near 3-\n" +
+				"to 8 inches through Friday afternoon.\n",
+			want: "Accumulations along the UT-AZ border remain near 3- " +
+				"to 8 inches through Friday afternoon.\n",
+		},
+		{
+			name: "key messages bullets stay separate items",
+			raw: ".KEY MESSAGES...\n" +
+				"\n" +
+				"- The next round of snow starts near midnight tonight and\n" +
+				"  lingers through Friday afternoon.\n" +
+				"\n" +
+				"- A gradual warming trend is expected through the weekend.\n",
+			want: ".KEY MESSAGES...\n" +
+				"\n" +
+				"- The next round of snow starts near midnight tonight and " +
+				"lingers through Friday afternoon.\n" +
+				"\n" +
+				"- A gradual warming trend is expected through the weekend.\n",
+		},
+		{
+			name: "coded temps and pops table is untouched",
+			raw: ".PRELIMINARY POINT TEMPS/POPS...\n" +
+				"GJT  25  46  27  49 /  90  70  10   0\n" +
+				"CNY  24  48  26  51 /  80  40  10   0\n",
+			want: ".PRELIMINARY POINT TEMPS/POPS...\n" +
+				"GJT  25  46  27  49 /  90  70  10   0\n" +
+				"CNY  24  48  26  51 /  80  40  10   0\n",
+		},
+		{
+			name: "indented advisory zone lists keep their layout",
+			raw: "CO...Winter Weather Advisory from 2 AM to 11 PM MST Friday for\n" +
+				"     COZ003-009-012-013.\n",
+			want: "CO...Winter Weather Advisory from 2 AM to 11 PM MST Friday for\n" +
+				"     COZ003-009-012-013.\n",
+		},
+		{
+			name: "masthead lines stay on their own lines",
+			raw: "000\n" +
+				"FXUS65 KGJT 192357\n" +
+				"AFDGJT\n" +
+				"\n" +
+				"Area Forecast Discussion\n" +
+				"National Weather Service Grand Junction CO\n" +
+				"457 PM MST Thu Feb 19 2026\n",
+			want: "000\n" +
+				"FXUS65 KGJT 192357\n" +
+				"AFDGJT\n" +
+				"\n" +
+				"Area Forecast Discussion\n" +
+				"National Weather Service Grand Junction CO\n" +
+				"457 PM MST Thu Feb 19 2026\n",
+		},
+		{
+			name: "normalizes CRLF and trailing spaces",
+			raw:  "Quiet weather continues.  \r\nHigh pressure holds.\r\n",
+			want: "Quiet weather continues.\nHigh pressure holds.\n",
+		},
+		{
+			name: "drops invalid UTF-8 bytes",
+			raw:  "Snow\xfffall tapers off tonight.\n",
+			want: "Snowfall tapers off tonight.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeDiscussionText(tt.raw); got != tt.want {
+				t.Errorf("normalizeDiscussionText() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseForecastDiscussionSections(t *testing.T) {
+	raw := "000\n" +
+		"FXUS65 KGJT 192357\n" +
+		"\n" +
+		".KEY MESSAGES...\n" +
+		"\n" +
+		"- Heavy snow develops tonight across the southern mountains and\n" +
+		"  lingers through Friday afternoon.\n" +
+		"\n" +
+		"&&\n" +
+		"\n" +
+		".SHORT TERM /THROUGH FRIDAY NIGHT/...\n" +
+		"Issued at 125 PM MST Thu Feb 19 2026\n" +
+		"\n" +
+		"A shortwave trough will push through on Friday, bringing one \n" +
+		"last push of Pacific moisture from the southwest.\n" +
+		"\n" +
+		"&&\n" +
+		"\n" +
+		".SYNOPSIS...Quiet weather returns behind the front.\n" +
+		"\n" +
+		"&&\n" +
+		"\n" +
+		"$$\n" +
+		"\n" +
+		"SHORT TERM...BW\n"
+
+	discussion := ParseForecastDiscussion(raw)
+
+	if discussion.RawText != raw {
+		t.Error("RawText was modified by parsing")
+	}
+
+	wantSections := []DiscussionSection{
+		{
+			Name: "KEY MESSAGES",
+			Text: "- Heavy snow develops tonight across the southern mountains and " +
+				"lingers through Friday afternoon.",
+		},
+		{
+			Name: "SHORT TERM /THROUGH FRIDAY NIGHT/",
+			Text: "Issued at 125 PM MST Thu Feb 19 2026\n" +
+				"\n" +
+				"A shortwave trough will push through on Friday, bringing one " +
+				"last push of Pacific moisture from the southwest.",
+		},
+		{
+			Name: "SYNOPSIS",
+			Text: "Quiet weather returns behind the front.",
+		},
+	}
+
+	if len(discussion.Sections) != len(wantSections) {
+		t.Fatalf("parsed %d sections, want %d: %+v", len(discussion.Sections), len(wantSections), discussion.Sections)
+	}
+	for i, want := range wantSections {
+		got := discussion.Sections[i]
+		if got.Name != want.Name {
+			t.Errorf("section %d name = %q, want %q", i, got.Name, want.Name)
+		}
+		if got.Text != want.Text {
+			t.Errorf("section %q text =\n%q\nwant\n%q", want.Name, got.Text, want.Text)
+		}
+	}
+}
+
+func TestParseForecastDiscussionSnapshot(t *testing.T) {
+	data, err := os.ReadFile("testdata/nws_afd_response.json")
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+
+	var apiResponse nws.AFDAPIResponse
+	if err := json.Unmarshal(data, &apiResponse); err != nil {
+		t.Fatalf("failed to unmarshal snapshot: %v", err)
+	}
+
+	discussion := ParseForecastDiscussion(apiResponse.ProductText)
+
+	if discussion.RawText != apiResponse.ProductText {
+		t.Error("RawText was modified by parsing")
+	}
+
+	wantNames := []string{
+		"KEY MESSAGES",
+		"SHORT TERM /THROUGH FRIDAY NIGHT/",
+		"LONG TERM /SATURDAY THROUGH THURSDAY/",
+		"AVIATION /00Z TAFS THROUGH 00Z SATURDAY/",
+		"GJT WATCHES/WARNINGS/ADVISORIES",
+	}
+	if len(discussion.Sections) != len(wantNames) {
+		t.Fatalf("parsed %d sections, want %d", len(discussion.Sections), len(wantNames))
+	}
+	for i, want := range wantNames {
+		if discussion.Sections[i].Name != want {
+			t.Errorf("section %d name = %q, want %q", i, discussion.Sections[i].Name, want)
+		}
+	}
+
+	// Wrapped sentences come back together on one line
+	if !strings.Contains(discussion.NormalizedText, "amidst a transient ridge. Moisture continues") {
+		t.Error("normalized text did not unwrap the short term paragraph")
+	}
+	if !strings.Contains(discussion.NormalizedText, "south-facing slopes of the San Juans") {
+		t.Error("normalized text did not rejoin the hyphenated wrap")
+	}
+
+	// The advisory zone lists keep their indented layout
+	if !strings.Contains(discussion.NormalizedText, "\n     COZ003-009-012-013.") {
+		t.Error("normalized text unwrapped the watches/warnings zone list")
+	}
+}
@@ -0,0 +1,98 @@
+package weather
+
+import (
+	"sort"
+	"time"
+)
+
+// NWS alert overlay on the forecast days. Active alerts (winter storm
+// warnings, winter weather advisories, high wind warnings, avalanche
+// warnings) carry a valid period that rarely lines up with calendar days:
+// most span midnight and some run for the whole forecast. The overlay clamps
+// each alert to the local days it touches so the UI can badge exactly the
+// days affected.
+
+// WeatherAlert is an active NWS alert for the forecast location, already
+// fetched and reduced to the fields the overlay needs.
+type WeatherAlert struct {
+	// Event is the NWS event name, e.g. "Winter Storm Warning"
+	Event string
+	// Severity is the NWS severity: Minor, Moderate, Severe, or Extreme
+	Severity string
+	// Onset and Ends bound the alert's valid period; a zero Ends means the
+	// alert is open-ended and covers the rest of the forecast horizon
+	Onset time.Time
+	Ends  time.Time
+}
+
+// DailyAlert badges one forecast day with the portion of an alert that
+// overlaps it.
+type DailyAlert struct {
+	Event    string
+	Severity string
+	// OverlapStart and OverlapEnd clamp the alert's valid period to this
+	// day's local midnight-to-midnight window
+	OverlapStart time.Time
+	OverlapEnd   time.Time
+}
+
+// ApplyWeatherAlerts attaches each alert to every daily forecast whose local
+// calendar day its valid period overlaps. Day boundaries are the location's
+// midnights, computed from each day's timestamp so DST transitions keep their
+// actual length; alerts entirely outside the forecast horizon attach to
+// nothing. Per day the alerts are ordered by onset, then event name.
+func (f *Forecast) ApplyWeatherAlerts(alerts []WeatherAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+	loc := f.location()
+
+	for i := range f.DailyForecasts {
+		day := &f.DailyForecasts[i]
+		local := day.Timestamp.In(loc)
+		dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		dayEnd := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+
+		var overlapping []DailyAlert
+		for _, alert := range alerts {
+			overlap, ok := alertOverlap(alert, dayStart, dayEnd)
+			if !ok {
+				continue
+			}
+			overlapping = append(overlapping, overlap)
+		}
+		sort.Slice(overlapping, func(a, b int) bool {
+			if overlapping[a].OverlapStart.Equal(overlapping[b].OverlapStart) {
+				return overlapping[a].Event < overlapping[b].Event
+			}
+			return overlapping[a].OverlapStart.Before(overlapping[b].OverlapStart)
+		})
+		day.Alerts = overlapping
+	}
+}
+
+// alertOverlap clamps an alert's valid period to the half-open day window
+// [dayStart, dayEnd); ok is false when the two do not intersect
+func alertOverlap(alert WeatherAlert, dayStart, dayEnd time.Time) (DailyAlert, bool) {
+	if !alert.Onset.Before(dayEnd) {
+		return DailyAlert{}, false
+	}
+	if !alert.Ends.IsZero() && !alert.Ends.After(dayStart) {
+		return DailyAlert{}, false
+	}
+
+	start := dayStart
+	if alert.Onset.After(dayStart) {
+		start = alert.Onset
+	}
+	end := dayEnd
+	if !alert.Ends.IsZero() && alert.Ends.Before(dayEnd) {
+		end = alert.Ends
+	}
+	return DailyAlert{
+		Event:        alert.Event,
+		Severity:     alert.Severity,
+		OverlapStart: start,
+		OverlapEnd:   end,
+	}, true
+}
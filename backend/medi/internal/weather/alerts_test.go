@@ -0,0 +1,154 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+// alertsTestForecast builds a Denver forecast with the given number of days
+// starting Jan 15, timestamps at local midnight
+func alertsTestForecast(t *testing.T, days int) *Forecast {
+	t.Helper()
+
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	forecast := &Forecast{Timezone: "America/Denver"}
+	for i := 0; i < days; i++ {
+		forecast.DailyForecasts = append(forecast.DailyForecasts, DailyForecast{
+			Timestamp: time.Date(2025, 1, 15+i, 0, 0, 0, 0, denver),
+		})
+	}
+	return forecast
+}
+
+func denverTime(t *testing.T, day, hour int) time.Time {
+	t.Helper()
+
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	return time.Date(2025, 1, day, hour, 0, 0, 0, denver)
+}
+
+func TestApplyWeatherAlertsSpanningMidnight(t *testing.T) {
+	forecast := alertsTestForecast(t, 3)
+	forecast.ApplyWeatherAlerts([]WeatherAlert{{
+		Event:    "Winter Storm Warning",
+		Severity: "Severe",
+		Onset:    denverTime(t, 15, 20),
+		Ends:     denverTime(t, 16, 10),
+	}})
+
+	first := forecast.DailyForecasts[0].Alerts
+	if len(first) != 1 {
+		t.Fatalf("day one has %d alerts, want 1", len(first))
+	}
+	if !first[0].OverlapStart.Equal(denverTime(t, 15, 20)) || !first[0].OverlapEnd.Equal(denverTime(t, 16, 0)) {
+		t.Errorf("day one overlap %v - %v, want 20:00 to midnight", first[0].OverlapStart, first[0].OverlapEnd)
+	}
+
+	second := forecast.DailyForecasts[1].Alerts
+	if len(second) != 1 {
+		t.Fatalf("day two has %d alerts, want 1", len(second))
+	}
+	if !second[0].OverlapStart.Equal(denverTime(t, 16, 0)) || !second[0].OverlapEnd.Equal(denverTime(t, 16, 10)) {
+		t.Errorf("day two overlap %v - %v, want midnight to 10:00", second[0].OverlapStart, second[0].OverlapEnd)
+	}
+	if second[0].Event != "Winter Storm Warning" || second[0].Severity != "Severe" {
+		t.Errorf("day two alert = %+v, missing event metadata", second[0])
+	}
+
+	if alerts := forecast.DailyForecasts[2].Alerts; alerts != nil {
+		t.Errorf("day three has %d alerts, want none", len(alerts))
+	}
+}
+
+func TestApplyWeatherAlertsConvertsTimezones(t *testing.T) {
+	forecast := alertsTestForecast(t, 2)
+	// 05:00-09:00 UTC on Jan 16 is 22:00-02:00 across the local midnight
+	forecast.ApplyWeatherAlerts([]WeatherAlert{{
+		Event:    "High Wind Warning",
+		Severity: "Moderate",
+		Onset:    time.Date(2025, 1, 16, 5, 0, 0, 0, time.UTC),
+		Ends:     time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC),
+	}})
+
+	first := forecast.DailyForecasts[0].Alerts
+	if len(first) != 1 {
+		t.Fatalf("day one has %d alerts, want 1", len(first))
+	}
+	if !first[0].OverlapEnd.Equal(denverTime(t, 16, 0)) {
+		t.Errorf("day one overlap ends %v, want local midnight", first[0].OverlapEnd)
+	}
+
+	second := forecast.DailyForecasts[1].Alerts
+	if len(second) != 1 {
+		t.Fatalf("day two has %d alerts, want 1", len(second))
+	}
+	if !second[0].OverlapEnd.Equal(denverTime(t, 16, 2)) {
+		t.Errorf("day two overlap ends %v, want 02:00 local", second[0].OverlapEnd)
+	}
+}
+
+func TestApplyWeatherAlertsOutsideForecastWindow(t *testing.T) {
+	forecast := alertsTestForecast(t, 3)
+	forecast.ApplyWeatherAlerts([]WeatherAlert{
+		{
+			Event: "Winter Weather Advisory",
+			Onset: denverTime(t, 10, 6),
+			Ends:  denverTime(t, 12, 18),
+		},
+		{
+			Event: "Avalanche Warning",
+			Onset: denverTime(t, 20, 0),
+			Ends:  denverTime(t, 21, 0),
+		},
+	})
+
+	for i, day := range forecast.DailyForecasts {
+		if len(day.Alerts) != 0 {
+			t.Errorf("day %d has %d alerts, want none for alerts outside the window", i, len(day.Alerts))
+		}
+	}
+}
+
+func TestApplyWeatherAlertsCoveringWholeHorizon(t *testing.T) {
+	forecast := alertsTestForecast(t, 16)
+	// Open-ended alert that began before the forecast window
+	forecast.ApplyWeatherAlerts([]WeatherAlert{{
+		Event:    "Avalanche Warning",
+		Severity: "Extreme",
+		Onset:    denverTime(t, 14, 6),
+	}})
+
+	for i, day := range forecast.DailyForecasts {
+		if len(day.Alerts) != 1 {
+			t.Fatalf("day %d has %d alerts, want 1 across the whole horizon", i, len(day.Alerts))
+		}
+	}
+
+	// A middle day is covered midnight to midnight
+	middle := forecast.DailyForecasts[8].Alerts[0]
+	if !middle.OverlapStart.Equal(denverTime(t, 23, 0)) || !middle.OverlapEnd.Equal(denverTime(t, 24, 0)) {
+		t.Errorf("middle day overlap %v - %v, want the full local day", middle.OverlapStart, middle.OverlapEnd)
+	}
+}
+
+func TestApplyWeatherAlertsOrdersByOnset(t *testing.T) {
+	forecast := alertsTestForecast(t, 1)
+	forecast.ApplyWeatherAlerts([]WeatherAlert{
+		{Event: "High Wind Warning", Onset: denverTime(t, 15, 12), Ends: denverTime(t, 15, 18)},
+		{Event: "Winter Storm Warning", Onset: denverTime(t, 15, 4), Ends: denverTime(t, 15, 23)},
+	})
+
+	alerts := forecast.DailyForecasts[0].Alerts
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2", len(alerts))
+	}
+	if alerts[0].Event != "Winter Storm Warning" || alerts[1].Event != "High Wind Warning" {
+		t.Errorf("alerts out of onset order: %q then %q", alerts[0].Event, alerts[1].Event)
+	}
+}
@@ -0,0 +1,141 @@
+package weather
+
+import (
+	"medi/internal/types"
+)
+
+// Avalanche forecasts rate danger per elevation band — below, near, and
+// above treeline — while a point forecast speaks for one elevation. The
+// band table re-reads the next 24 hours at a representative elevation per
+// band: temperatures move along the lapse rate, and each hour's
+// precipitation is re-bucketed between rain and snow by the band's adjusted
+// temperature, so a valley rain hour can still add snow to the upper column.
+
+const (
+	// bandTableHours is the window the table condenses
+	bandTableHours = 24
+	// bandRepresentativeOffsetFt is how far outside its boundary the lower
+	// and upper bands' representative elevations sit; the middle band uses
+	// the midpoint of its boundaries
+	bandRepresentativeOffsetFt = 500.0
+	// bandSnowRainThresholdF splits each band-adjusted hour between snow and
+	// rain
+	bandSnowRainThresholdF = 34.0
+	// bandSnowLiquidRatio is the inches of snow assumed per inch of liquid
+	// when a band's temperature turns a point rain hour into snowfall
+	bandSnowLiquidRatio = 10.0
+	// defaultBandLapseRateCPerKm is used when the configured rate is unset
+	defaultBandLapseRateCPerKm = 6.5
+)
+
+// BandForecast is one column of the elevation band table.
+type BandForecast struct {
+	// Band places the column: lower, middle, or upper
+	Band string
+	// ElevationFt is the representative elevation the column was computed at
+	ElevationFt float64
+	// Snowfall24h spans the per-model totals for the next 24 hours,
+	// re-bucketed by the band's temperature
+	Snowfall24h SnowfallRange
+	// HighTempF and LowTempF are the ensemble median extremes at the band
+	// elevation
+	HighTempF float64
+	LowTempF  float64
+	// MaxWindMph is the strongest sustained wind any model forecasts in the
+	// window, with its compass direction; wind is not elevation-adjusted
+	MaxWindMph    float64
+	WindDirection string
+}
+
+// ComputeBandForecasts builds the three-band table around the boundary
+// elevations splitting lower/middle and middle/upper, in feet, using the
+// forecast's next 24 hours. A non-positive lapse rate falls back to the
+// default.
+func ComputeBandForecasts(forecast *Forecast, lowerBoundaryFt, upperBoundaryFt, lapseRateCPerKm float64) []BandForecast {
+	if forecast == nil {
+		return nil
+	}
+	if lapseRateCPerKm <= 0 {
+		lapseRateCPerKm = defaultBandLapseRateCPerKm
+	}
+
+	hours := forecast.Hours()
+	if len(hours) > bandTableHours {
+		hours = hours[:bandTableHours]
+	}
+
+	columns := []struct {
+		band string
+		feet float64
+	}{
+		{"lower", lowerBoundaryFt - bandRepresentativeOffsetFt},
+		{"middle", (lowerBoundaryFt + upperBoundaryFt) / 2},
+		{"upper", upperBoundaryFt + bandRepresentativeOffsetFt},
+	}
+
+	table := make([]BandForecast, 0, len(columns))
+	for _, column := range columns {
+		table = append(table, computeBandColumn(hours, forecast.ForecastPoint.Elevation, column.band, column.feet, lapseRateCPerKm))
+	}
+	return table
+}
+
+// computeBandColumn condenses the hourly window at one band elevation
+func computeBandColumn(hours []HourlyForecast, pointElevation types.Elevation, band string, bandFeet, lapseRateCPerKm float64) BandForecast {
+	column := BandForecast{Band: band, ElevationFt: bandFeet, WindDirection: types.CardinalVariable}
+	deltaKm := (bandFeet*types.FeetToMeters - pointElevation.Meters) / 1000
+
+	highs := ModelValues[float64]{}
+	lows := ModelValues[float64]{}
+	totals := ModelValues[float64]{}
+	for _, hour := range hours {
+		// Per-model band temperature for this hour, for the extremes and the
+		// rain/snow split
+		bandTempsF := map[string]float64{}
+		for model, temperature := range hour.Temperature {
+			adjusted := types.NewTemperatureFromCelsius(temperature.Celsius - lapseRateCPerKm*deltaKm)
+			bandTempsF[model] = adjusted.Fahrenheit
+			if high, ok := highs[model]; !ok || adjusted.Fahrenheit > high {
+				highs[model] = adjusted.Fahrenheit
+			}
+			if low, ok := lows[model]; !ok || adjusted.Fahrenheit < low {
+				lows[model] = adjusted.Fahrenheit
+			}
+		}
+
+		for model, snow := range hour.Snowfall {
+			// An hour too warm at this band falls as rain instead; models
+			// without a band temperature keep their point snowfall
+			if tempF, ok := bandTempsF[model]; ok && tempF > bandSnowRainThresholdF {
+				continue
+			}
+			totals[model] += snow.Inches
+		}
+		for model, liquid := range hour.LiquidPrecipitation {
+			// A point rain hour cold enough at this band falls as snow
+			if tempF, ok := bandTempsF[model]; ok && tempF <= bandSnowRainThresholdF {
+				totals[model] += liquid.Inches * bandSnowLiquidRatio
+			}
+		}
+
+		for _, wind := range hour.Wind {
+			if wind.Speed.Mph > column.MaxWindMph {
+				column.MaxWindMph = wind.Speed.Mph
+				column.WindDirection = wind.Direction.Cardinal
+			}
+		}
+	}
+
+	column.HighTempF = medianValue(highs)
+	column.LowTempF = medianValue(lows)
+
+	var perModel []float64
+	for _, total := range totals {
+		perModel = append(perModel, total)
+	}
+	if minInches, ok := minFloat(perModel); ok {
+		maxInches, _ := maxFloat(perModel)
+		column.Snowfall24h = SnowfallRange{MinInches: minInches, MaxInches: maxInches}
+	}
+	return column
+}
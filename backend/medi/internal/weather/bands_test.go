@@ -0,0 +1,111 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// bandTestForecast builds a one-day Denver forecast at the point elevation
+// with a constant hourly temperature, snowfall, and liquid precipitation,
+// plus one 45 mph west wind hour.
+func bandTestForecast(pointElevationFt, tempF, snowInches, liquidInches float64) *Forecast {
+	denver, _ := time.LoadLocation(TimezoneDenver)
+
+	forecast := &Forecast{
+		Timezone:     TimezoneDenver,
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7392, -104.9903),
+			Elevation:   types.NewElevationFromFeet(pointElevationFt),
+		},
+	}
+	day := DailyForecast{Timestamp: time.Date(2025, 1, 15, 0, 0, 0, 0, denver)}
+	for hour := 0; hour < 24; hour++ {
+		start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+		hourly := HourlyForecast{
+			Start:               start,
+			End:                 start.Add(time.Hour),
+			Temperature:         ModelValues[types.Temperature]{ModelGfsSeamless: types.NewTemperatureFromFahrenheit(tempF)},
+			Snowfall:            ModelValues[types.Precipitation]{ModelGfsSeamless: types.NewPrecipitationFromInches(snowInches)},
+			LiquidPrecipitation: ModelValues[types.Precipitation]{ModelGfsSeamless: types.NewPrecipitationFromInches(liquidInches)},
+		}
+		windMph := 20.0
+		if hour == 12 {
+			windMph = 45
+		}
+		hourly.Wind = ModelValues[types.Wind]{ModelGfsSeamless: types.Wind{
+			Speed:     types.NewWindSpeedFromMph(windMph),
+			Direction: types.NewWindDirection(270),
+		}}
+		day.HourlyForecasts = append(day.HourlyForecasts, hourly)
+	}
+	forecast.DailyForecasts = append(forecast.DailyForecasts, day)
+	return forecast
+}
+
+func TestBandForecastsLookingUpCoolsAndConvertsRain(t *testing.T) {
+	// A 40°F rainy point at 8000 ft below boundaries at 10000/11000 ft: the
+	// bands above must read colder, and the ones dropping below the rain/snow
+	// threshold turn the liquid into snowfall
+	forecast := bandTestForecast(8000, 40, 0, 0.1)
+	bands := ComputeBandForecasts(forecast, 10000, 11000, 6.5)
+
+	if len(bands) != 3 {
+		t.Fatalf("got %d bands, want 3", len(bands))
+	}
+	lower, middle, upper := bands[0], bands[1], bands[2]
+
+	if !(lower.HighTempF > middle.HighTempF && middle.HighTempF > upper.HighTempF) {
+		t.Errorf("highs %v / %v / %v do not cool with elevation looking up",
+			lower.HighTempF, middle.HighTempF, upper.HighTempF)
+	}
+	// 3500 ft above the point along 6.5°C/km is about a 12.5°F drop
+	if want := 27.5; math.Abs(upper.HighTempF-want) > 0.2 {
+		t.Errorf("upper HighTempF = %v, want about %v", upper.HighTempF, want)
+	}
+
+	// At 9500 ft the hours stay just above the threshold and remain rain;
+	// at and above 10500 ft they fall as snow at the 10:1 ratio
+	if lower.Snowfall24h.MaxInches != 0 {
+		t.Errorf("lower band snowfall = %v in, rain at the band should not count", lower.Snowfall24h.MaxInches)
+	}
+	if want := 24.0; middle.Snowfall24h.MaxInches != want || upper.Snowfall24h.MaxInches != want {
+		t.Errorf("middle/upper snowfall = %v / %v in, want %v from converted liquid",
+			middle.Snowfall24h.MaxInches, upper.Snowfall24h.MaxInches, want)
+	}
+
+	if upper.MaxWindMph != 45 || upper.WindDirection != "W" {
+		t.Errorf("wind = %v mph %s, want the 45 mph west hour", upper.MaxWindMph, upper.WindDirection)
+	}
+}
+
+func TestBandForecastsLookingDownWarmsAndDropsSnow(t *testing.T) {
+	// A 28°F snowy point at 12000 ft above the same boundaries: the lower
+	// band reads warmer, warm enough that its hours fall as rain instead
+	forecast := bandTestForecast(12000, 28, 0.2, 0)
+	bands := ComputeBandForecasts(forecast, 10000, 11000, 6.5)
+
+	lower, middle, upper := bands[0], bands[1], bands[2]
+
+	if !(lower.HighTempF > upper.HighTempF) {
+		t.Errorf("lower HighTempF = %v not warmer than upper %v looking down",
+			lower.HighTempF, upper.HighTempF)
+	}
+	if lower.Snowfall24h.MaxInches != 0 {
+		t.Errorf("lower band snowfall = %v in, want 0 once the band is too warm", lower.Snowfall24h.MaxInches)
+	}
+	// The middle band sits just under the threshold and keeps the snow
+	if want := 4.8; math.Abs(middle.Snowfall24h.MaxInches-want) > 1e-9 || math.Abs(upper.Snowfall24h.MaxInches-want) > 1e-9 {
+		t.Errorf("middle/upper snowfall = %v / %v in, want %v",
+			middle.Snowfall24h.MaxInches, upper.Snowfall24h.MaxInches, want)
+	}
+}
+
+func TestBandForecastsNilForecast(t *testing.T) {
+	if bands := ComputeBandForecasts(nil, 10000, 11000, 6.5); bands != nil {
+		t.Errorf("bands = %v for a nil forecast, want nil", bands)
+	}
+}
@@ -0,0 +1,172 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"medi/internal/types"
+)
+
+// Bias corrections are bounded so a bad verification run or a typo in the
+// admin endpoint cannot distort a forecast beyond plausibility
+const (
+	maxBiasTemperatureOffsetF = 10.0
+	minBiasSnowfallFactor     = 0.5
+	maxBiasSnowfallFactor     = 2.0
+)
+
+var (
+	// ErrInvalidBiasTemperatureOffset indicates a temperature offset outside ±10°F
+	ErrInvalidBiasTemperatureOffset = errors.New("temperature offset must be between -10 and 10 °F")
+	// ErrInvalidBiasSnowfallFactor indicates a snowfall factor outside 0.5–2.0
+	ErrInvalidBiasSnowfallFactor = errors.New("snowfall factor must be between 0.5 and 2.0")
+)
+
+// BiasCorrection adjusts one model's output at one location: an additive
+// temperature offset and a multiplicative snowfall factor, derived from
+// verification against observations. The zero offset and a factor of 1 are
+// the identity.
+type BiasCorrection struct {
+	TemperatureOffsetF float64 `json:"temperatureOffsetF"`
+	SnowfallFactor     float64 `json:"snowfallFactor"`
+}
+
+// Validate checks the correction against the bounds above
+func (c BiasCorrection) Validate() error {
+	if c.TemperatureOffsetF < -maxBiasTemperatureOffsetF || c.TemperatureOffsetF > maxBiasTemperatureOffsetF {
+		return fmt.Errorf("%w: got %.1f", ErrInvalidBiasTemperatureOffset, c.TemperatureOffsetF)
+	}
+	if c.SnowfallFactor < minBiasSnowfallFactor || c.SnowfallFactor > maxBiasSnowfallFactor {
+		return fmt.Errorf("%w: got %.2f", ErrInvalidBiasSnowfallFactor, c.SnowfallFactor)
+	}
+	return nil
+}
+
+// isIdentity reports whether applying the correction would change nothing
+func (c BiasCorrection) isIdentity() bool {
+	return c.TemperatureOffsetF == 0 && c.SnowfallFactor == 1
+}
+
+// BiasCorrector supplies per-model corrections for a forecast point; an empty
+// map means the point has no known biases
+type BiasCorrector interface {
+	CorrectionsFor(point types.ForecastPoint) ModelValues[BiasCorrection]
+}
+
+// BiasStore is an in-memory BiasCorrector keyed by location, populated by the
+// admin endpoint or a verification job. Locations use the same 4-decimal key
+// as the forecast caches, so corrections land on the points clients actually
+// request.
+type BiasStore struct {
+	mu         sync.Mutex
+	byLocation map[string]ModelValues[BiasCorrection]
+}
+
+// NewBiasStore creates an empty store; until corrections are set every lookup
+// returns the identity
+func NewBiasStore() *BiasStore {
+	return &BiasStore{byLocation: map[string]ModelValues[BiasCorrection]{}}
+}
+
+func biasLocationKey(latitude, longitude float64) string {
+	return fmt.Sprintf("%.4f,%.4f", latitude, longitude)
+}
+
+// Set records a correction for one model at one location, replacing any
+// previous value; the correction must be within bounds
+func (s *BiasStore) Set(latitude, longitude float64, model string, correction BiasCorrection) error {
+	if err := correction.Validate(); err != nil {
+		return err
+	}
+
+	key := biasLocationKey(latitude, longitude)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byLocation[key] == nil {
+		s.byLocation[key] = ModelValues[BiasCorrection]{}
+	}
+	s.byLocation[key][model] = correction
+	return nil
+}
+
+// CorrectionsFor returns a copy of the corrections stored for the point's
+// location, nil when none exist
+func (s *BiasStore) CorrectionsFor(point types.ForecastPoint) ModelValues[BiasCorrection] {
+	key := biasLocationKey(point.Coordinates.Latitude, point.Coordinates.Longitude)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.byLocation[key]
+	if !ok {
+		return nil
+	}
+	corrections := ModelValues[BiasCorrection]{}
+	corrections.Merge(stored)
+	return corrections
+}
+
+// applyBiasCorrections replaces the forecast's raw values with corrected ones:
+// temperatures shift by the offset, snowfall scales by the factor. Only models
+// present in both the forecast and the corrections are touched, identity
+// corrections are skipped, and Corrections records what was applied.
+func applyBiasCorrections(forecast *Forecast, corrections ModelValues[BiasCorrection]) {
+	applied := ModelValues[BiasCorrection]{}
+
+	for di := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[di]
+		for model, correction := range corrections {
+			if correction.isIdentity() {
+				continue
+			}
+			touched := false
+			for hi := range day.HourlyForecasts {
+				hour := &day.HourlyForecasts[hi]
+				touched = correctTemperature(hour.Temperature, model, correction) || touched
+				touched = correctTemperature(hour.ApparentTemperature, model, correction) || touched
+				touched = correctSnowfall(hour.Snowfall, model, correction) || touched
+			}
+			touched = correctTemperature(day.HighTemperature, model, correction) || touched
+			touched = correctTemperature(day.LowTemperature, model, correction) || touched
+			touched = correctSnowfall(day.TotalSnowfall, model, correction) || touched
+			if value, ok := day.SnowfallWaterEquivalentSum[model]; ok {
+				day.SnowfallWaterEquivalentSum[model] = value * correction.SnowfallFactor
+				touched = true
+			}
+			if touched {
+				applied[model] = correction
+			}
+		}
+	}
+
+	for model, correction := range corrections {
+		if correction.isIdentity() {
+			continue
+		}
+		if correctTemperature(forecast.CurrentConditions.Temperature, model, correction) {
+			applied[model] = correction
+		}
+	}
+
+	if len(applied) > 0 {
+		forecast.Corrections = applied
+	}
+}
+
+func correctTemperature(values ModelValues[types.Temperature], model string, correction BiasCorrection) bool {
+	value, ok := values[model]
+	if !ok {
+		return false
+	}
+	values[model] = types.NewTemperatureFromFahrenheit(value.Fahrenheit + correction.TemperatureOffsetF)
+	return true
+}
+
+func correctSnowfall(values ModelValues[types.Precipitation], model string, correction BiasCorrection) bool {
+	value, ok := values[model]
+	if !ok {
+		return false
+	}
+	values[model] = types.NewPrecipitationFromInches(value.Inches * correction.SnowfallFactor)
+	return true
+}
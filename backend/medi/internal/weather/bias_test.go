@@ -0,0 +1,176 @@
+package weather
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"medi/internal/types"
+)
+
+func TestBiasCorrectionValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		correction BiasCorrection
+		wantErr    error
+	}{
+		{"identity", BiasCorrection{SnowfallFactor: 1}, nil},
+		{"bounds", BiasCorrection{TemperatureOffsetF: -10, SnowfallFactor: 2}, nil},
+		{"too warm", BiasCorrection{TemperatureOffsetF: 10.5, SnowfallFactor: 1}, ErrInvalidBiasTemperatureOffset},
+		{"too cold", BiasCorrection{TemperatureOffsetF: -11, SnowfallFactor: 1}, ErrInvalidBiasTemperatureOffset},
+		{"factor too small", BiasCorrection{SnowfallFactor: 0.4}, ErrInvalidBiasSnowfallFactor},
+		{"factor too large", BiasCorrection{SnowfallFactor: 2.1}, ErrInvalidBiasSnowfallFactor},
+		{"zero factor", BiasCorrection{}, ErrInvalidBiasSnowfallFactor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.correction.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBiasStoreSetAndLookup(t *testing.T) {
+	store := NewBiasStore()
+
+	correction := BiasCorrection{TemperatureOffsetF: -3, SnowfallFactor: 1.2}
+	if err := store.Set(39.11539, -107.65840, ModelGfsSeamless, correction); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	point := types.ForecastPoint{Coordinates: types.NewCoords(39.11539, -107.65840)}
+	got := store.CorrectionsFor(point)
+	if got[ModelGfsSeamless] != correction {
+		t.Errorf("CorrectionsFor = %+v, want %+v for %s", got, correction, ModelGfsSeamless)
+	}
+
+	// A different location has nothing on file
+	elsewhere := types.ForecastPoint{Coordinates: types.NewCoords(40.0, -105.0)}
+	if corrections := store.CorrectionsFor(elsewhere); corrections != nil {
+		t.Errorf("CorrectionsFor(elsewhere) = %+v, want nil", corrections)
+	}
+
+	// Out-of-bounds corrections are rejected and not stored
+	if err := store.Set(39.11539, -107.65840, ModelGemSeamless, BiasCorrection{TemperatureOffsetF: 15, SnowfallFactor: 1}); !errors.Is(err, ErrInvalidBiasTemperatureOffset) {
+		t.Errorf("Set() error = %v, want ErrInvalidBiasTemperatureOffset", err)
+	}
+	if got := store.CorrectionsFor(point); got.HasModel(ModelGemSeamless) {
+		t.Error("rejected correction was stored")
+	}
+}
+
+// buildBiasForecast builds a one-day forecast with known values for two
+// models so corrections to one can be checked against the other
+func buildBiasForecast() *Forecast {
+	return &Forecast{
+		CurrentConditions: CurrentConditions{
+			Temperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(20),
+				ModelGemSeamless: types.NewTemperatureFromFahrenheit(20),
+			},
+		},
+		DailyForecasts: []DailyForecast{
+			{
+				HourlyForecasts: []HourlyForecast{
+					{
+						Temperature: ModelValues[types.Temperature]{
+							ModelGfsSeamless: types.NewTemperatureFromFahrenheit(25),
+							ModelGemSeamless: types.NewTemperatureFromFahrenheit(25),
+						},
+						Snowfall: ModelValues[types.Precipitation]{
+							ModelGfsSeamless: types.NewPrecipitationFromInches(2),
+							ModelGemSeamless: types.NewPrecipitationFromInches(2),
+						},
+					},
+				},
+				HighTemperature: ModelValues[types.Temperature]{
+					ModelGfsSeamless: types.NewTemperatureFromFahrenheit(30),
+				},
+				LowTemperature: ModelValues[types.Temperature]{
+					ModelGfsSeamless: types.NewTemperatureFromFahrenheit(10),
+				},
+				TotalSnowfall: ModelValues[types.Precipitation]{
+					ModelGfsSeamless: types.NewPrecipitationFromInches(6),
+				},
+				SnowfallWaterEquivalentSum: ModelValues[float64]{
+					ModelGfsSeamless: 0.6,
+				},
+			},
+		},
+	}
+}
+
+func TestApplyBiasCorrections(t *testing.T) {
+	forecast := buildBiasForecast()
+	corrections := ModelValues[BiasCorrection]{
+		ModelGfsSeamless: {TemperatureOffsetF: -3, SnowfallFactor: 1.5},
+	}
+	applyBiasCorrections(forecast, corrections)
+
+	day := forecast.DailyForecasts[0]
+	hour := day.HourlyForecasts[0]
+
+	// Corrected values replace the raw ones for the targeted model
+	if got := hour.Temperature[ModelGfsSeamless].Fahrenheit; got != 22 {
+		t.Errorf("hourly temperature = %v°F, want 22", got)
+	}
+	if got := hour.Snowfall[ModelGfsSeamless].Inches; got != 3 {
+		t.Errorf("hourly snowfall = %v in, want 3", got)
+	}
+	if got := day.HighTemperature[ModelGfsSeamless].Fahrenheit; got != 27 {
+		t.Errorf("daily high = %v°F, want 27", got)
+	}
+	if got := day.LowTemperature[ModelGfsSeamless].Fahrenheit; got != 7 {
+		t.Errorf("daily low = %v°F, want 7", got)
+	}
+	if got := day.TotalSnowfall[ModelGfsSeamless].Inches; got != 9 {
+		t.Errorf("daily snowfall total = %v in, want 9", got)
+	}
+	if got := day.SnowfallWaterEquivalentSum[ModelGfsSeamless]; math.Abs(got-0.9) > 1e-9 {
+		t.Errorf("daily SWE = %v, want 0.9", got)
+	}
+	if got := forecast.CurrentConditions.Temperature[ModelGfsSeamless].Fahrenheit; got != 17 {
+		t.Errorf("current temperature = %v°F, want 17", got)
+	}
+
+	// Models without a correction keep their raw values
+	if got := hour.Temperature[ModelGemSeamless].Fahrenheit; got != 25 {
+		t.Errorf("uncorrected model temperature = %v°F, want 25", got)
+	}
+	if got := hour.Snowfall[ModelGemSeamless].Inches; got != 2 {
+		t.Errorf("uncorrected model snowfall = %v in, want 2", got)
+	}
+
+	// The response annotation lists exactly what was applied
+	if len(forecast.Corrections) != 1 || forecast.Corrections[ModelGfsSeamless] != corrections[ModelGfsSeamless] {
+		t.Errorf("Corrections = %+v, want only %s", forecast.Corrections, ModelGfsSeamless)
+	}
+}
+
+func TestApplyBiasCorrectionsIdentityIsNoOp(t *testing.T) {
+	forecast := buildBiasForecast()
+	applyBiasCorrections(forecast, ModelValues[BiasCorrection]{
+		ModelGfsSeamless: {TemperatureOffsetF: 0, SnowfallFactor: 1},
+	})
+
+	if got := forecast.DailyForecasts[0].HourlyForecasts[0].Temperature[ModelGfsSeamless].Fahrenheit; got != 25 {
+		t.Errorf("temperature = %v°F, want 25 after identity correction", got)
+	}
+	if forecast.Corrections != nil {
+		t.Errorf("Corrections = %+v, want nil when nothing was applied", forecast.Corrections)
+	}
+}
+
+func TestApplyBiasCorrectionsSkipsAbsentModels(t *testing.T) {
+	forecast := buildBiasForecast()
+	applyBiasCorrections(forecast, ModelValues[BiasCorrection]{
+		ModelNcepNamConus: {TemperatureOffsetF: 5, SnowfallFactor: 1},
+	})
+
+	if forecast.Corrections != nil {
+		t.Errorf("Corrections = %+v, want nil for a model absent from the forecast", forecast.Corrections)
+	}
+}
@@ -0,0 +1,296 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/providers/metno"
+	"medi/internal/types"
+)
+
+// The primary Open-Meteo mapping and the MET Norway fallback feed the same
+// domain model through separate code paths, and representations drift
+// silently (the fallback used to store cloud cover as 0-100 while the
+// primary stores 0-1). This smoke test runs one synthetic day of identical
+// weather through both mappings and asserts the core fields agree. Fields
+// the fallback cannot supply are pinned to entries in its missing-fields
+// list; any divergence outside that allowlist fails, so the mappings either
+// converge or the gap is acknowledged explicitly.
+
+// consistencyScenario is one day of weather expressed in neutral units, to
+// be rendered into each provider's response format
+type consistencyScenario struct {
+	temperaturesC []float64
+	windMph       []float64
+	gustsMph      []float64
+	precipIn      []float64
+	weatherCodes  []int
+	symbolCodes   []string
+}
+
+func newConsistencyScenario() consistencyScenario {
+	s := consistencyScenario{}
+	for i := 0; i < 24; i++ {
+		s.temperaturesC = append(s.temperaturesC, -8.0+0.5*float64(i))
+		s.windMph = append(s.windMph, 5.0+0.25*float64(i))
+		s.gustsMph = append(s.gustsMph, (5.0+0.25*float64(i))*1.4)
+		if i >= 6 && i < 12 {
+			s.precipIn = append(s.precipIn, 0.1)
+			s.weatherCodes = append(s.weatherCodes, 73)
+			s.symbolCodes = append(s.symbolCodes, "snow")
+		} else {
+			s.precipIn = append(s.precipIn, 0)
+			s.weatherCodes = append(s.weatherCodes, 3)
+			s.symbolCodes = append(s.symbolCodes, "cloudy")
+		}
+	}
+	return s
+}
+
+// mapScenarioThroughPrimary renders the scenario into the GFS series of a
+// fully populated Open-Meteo response and maps it
+func mapScenarioThroughPrimary(t *testing.T, s consistencyScenario) *Forecast {
+	t.Helper()
+
+	apiResp := synthForecastResponse(t, 2)
+	for i := 0; i < 24; i++ {
+		apiResp.Hourly.Temperature2MGfsSeamless[i] = s.temperaturesC[i]*9/5 + 32
+		apiResp.Hourly.WindSpeed10MGfsSeamless[i] = s.windMph[i]
+		apiResp.Hourly.WindGusts10MGfsSeamless[i] = s.gustsMph[i]
+		apiResp.Hourly.WindDirection10MGfsSeamless[i] = 270
+		apiResp.Hourly.WeatherCodeGfsSeamless[i] = s.weatherCodes[i]
+		apiResp.Hourly.CloudCoverGfsSeamless[i] = 55
+		apiResp.Hourly.RelativeHumidity2MGfsSeamless[i] = 70
+		// Open-Meteo stamps each hour's accumulation on the following time
+		apiResp.Hourly.PrecipitationGfsSeamless[i+1] = s.precipIn[i]
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(types.ForecastPoint{}, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+	return forecast
+}
+
+// mapScenarioThroughFallback renders the scenario into a MET Norway compact
+// response on the same time axis and maps it
+func mapScenarioThroughFallback(t *testing.T, s consistencyScenario, start time.Time) *Forecast {
+	t.Helper()
+
+	apiResp := &metno.CompactAPIResponse{}
+	for i := 0; i < 24; i++ {
+		gustMs := s.gustsMph[i] / types.MsToMph
+		step := metno.TimeStep{Time: start.Add(time.Duration(i) * time.Hour).Format(time.RFC3339)}
+		step.Data.Instant.Details = metno.InstantDetails{
+			AirTemperature:    s.temperaturesC[i],
+			WindSpeed:         s.windMph[i] / types.MsToMph,
+			WindFromDirection: 270,
+			WindSpeedOfGust:   &gustMs,
+			CloudAreaFraction: 55,
+			RelativeHumidity:  70,
+		}
+		step.Data.Next1Hours = &metno.NextHours{}
+		step.Data.Next1Hours.Summary.SymbolCode = s.symbolCodes[i]
+		step.Data.Next1Hours.Details.PrecipitationAmount = s.precipIn[i] * types.InchesToMm
+		apiResp.Properties.Timeseries = append(apiResp.Properties.Timeseries, step)
+	}
+
+	forecast, err := mapMetNoResponseToForecast(types.ForecastPoint{}, "UTC", apiResp)
+	if err != nil {
+		t.Fatalf("mapMetNoResponseToForecast returned error: %v", err)
+	}
+	return forecast
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPrimaryAndFallbackMappingsAgreeOnCoreFields(t *testing.T) {
+	scenario := newConsistencyScenario()
+	primary := mapScenarioThroughPrimary(t, scenario)
+
+	if len(primary.DailyForecasts) == 0 || len(primary.DailyForecasts[0].HourlyForecasts) != 24 {
+		t.Fatal("primary mapping did not produce a full first day")
+	}
+	start := primary.DailyForecasts[0].HourlyForecasts[0].Start.UTC()
+
+	fallback := mapScenarioThroughFallback(t, scenario, start)
+	if len(fallback.DailyForecasts) != 1 || len(fallback.DailyForecasts[0].HourlyForecasts) != 24 {
+		t.Fatal("fallback mapping did not produce a full first day")
+	}
+
+	primaryDay := primary.DailyForecasts[0]
+	fallbackDay := fallback.DailyForecasts[0]
+
+	for i := 0; i < 24; i++ {
+		p := primaryDay.HourlyForecasts[i]
+		f := fallbackDay.HourlyForecasts[i]
+
+		if !p.Start.Equal(f.Start) {
+			t.Fatalf("hour %d starts diverge: primary %v, fallback %v", i, p.Start, f.Start)
+		}
+		if !closeTo(p.Temperature[ModelGfsSeamless].Celsius, f.Temperature[ModelMetNo].Celsius) {
+			t.Errorf("hour %d temperature: primary %.4f°C, fallback %.4f°C",
+				i, p.Temperature[ModelGfsSeamless].Celsius, f.Temperature[ModelMetNo].Celsius)
+		}
+		if !closeTo(p.Wind[ModelGfsSeamless].Speed.Mph, f.Wind[ModelMetNo].Speed.Mph) {
+			t.Errorf("hour %d wind speed: primary %.4f mph, fallback %.4f mph",
+				i, p.Wind[ModelGfsSeamless].Speed.Mph, f.Wind[ModelMetNo].Speed.Mph)
+		}
+		pGusts, fGusts := p.Wind[ModelGfsSeamless].Gusts, f.Wind[ModelMetNo].Gusts
+		if pGusts == nil || fGusts == nil || !closeTo(pGusts.Mph, fGusts.Mph) {
+			t.Errorf("hour %d wind gusts diverge: primary %v, fallback %v", i, pGusts, fGusts)
+		}
+		pDir, fDir := p.Wind[ModelGfsSeamless].Direction, f.Wind[ModelMetNo].Direction
+		if pDir.Cardinal != fDir.Cardinal ||
+			(!pDir.IsVariable() && !fDir.IsVariable() && !closeTo(*pDir.Degrees, *fDir.Degrees)) {
+			t.Errorf("hour %d wind direction: primary %s, fallback %s", i, pDir.Cardinal, fDir.Cardinal)
+		}
+		if !closeTo(p.Precipitation[ModelGfsSeamless].Inches, f.Precipitation[ModelMetNo].Inches) {
+			t.Errorf("hour %d precipitation: primary %.4f in, fallback %.4f in",
+				i, p.Precipitation[ModelGfsSeamless].Inches, f.Precipitation[ModelMetNo].Inches)
+		}
+		if !closeTo(p.CloudCover[ModelGfsSeamless], f.CloudCover[ModelMetNo]) {
+			t.Errorf("hour %d cloud cover: primary %.4f, fallback %.4f",
+				i, p.CloudCover[ModelGfsSeamless], f.CloudCover[ModelMetNo])
+		}
+		if !closeTo(p.RelativeHumidity[ModelGfsSeamless], f.RelativeHumidity[ModelMetNo]) {
+			t.Errorf("hour %d relative humidity: primary %.4f, fallback %.4f",
+				i, p.RelativeHumidity[ModelGfsSeamless], f.RelativeHumidity[ModelMetNo])
+		}
+		if p.Weather[ModelGfsSeamless] != f.Weather[ModelMetNo] {
+			t.Errorf("hour %d weather: primary %+v, fallback %+v",
+				i, p.Weather[ModelGfsSeamless], f.Weather[ModelMetNo])
+		}
+	}
+
+	// Daily aggregates both mappings derive
+	if !closeTo(primaryDay.HighTemperature[ModelGfsSeamless].Celsius, fallbackDay.HighTemperature[ModelMetNo].Celsius) {
+		t.Errorf("daily high: primary %.4f°C, fallback %.4f°C",
+			primaryDay.HighTemperature[ModelGfsSeamless].Celsius, fallbackDay.HighTemperature[ModelMetNo].Celsius)
+	}
+	if !closeTo(primaryDay.LowTemperature[ModelGfsSeamless].Celsius, fallbackDay.LowTemperature[ModelMetNo].Celsius) {
+		t.Errorf("daily low: primary %.4f°C, fallback %.4f°C",
+			primaryDay.LowTemperature[ModelGfsSeamless].Celsius, fallbackDay.LowTemperature[ModelMetNo].Celsius)
+	}
+	if !closeTo(primaryDay.MaxWindSpeed[ModelGfsSeamless].Mph, fallbackDay.MaxWindSpeed[ModelMetNo].Mph) {
+		t.Errorf("daily max wind: primary %.4f mph, fallback %.4f mph",
+			primaryDay.MaxWindSpeed[ModelGfsSeamless].Mph, fallbackDay.MaxWindSpeed[ModelMetNo].Mph)
+	}
+	if !closeTo(primaryDay.MaxWindGusts[ModelGfsSeamless].Mph, fallbackDay.MaxWindGusts[ModelMetNo].Mph) {
+		t.Errorf("daily max gusts: primary %.4f mph, fallback %.4f mph",
+			primaryDay.MaxWindGusts[ModelGfsSeamless].Mph, fallbackDay.MaxWindGusts[ModelMetNo].Mph)
+	}
+	if !closeTo(primaryDay.TotalPrecipitation[ModelGfsSeamless].Inches, fallbackDay.TotalPrecipitation[ModelMetNo].Inches) {
+		t.Errorf("daily total precipitation: primary %.4f in, fallback %.4f in",
+			primaryDay.TotalPrecipitation[ModelGfsSeamless].Inches, fallbackDay.TotalPrecipitation[ModelMetNo].Inches)
+	}
+}
+
+func TestFallbackDivergencesAreAllowlisted(t *testing.T) {
+	scenario := newConsistencyScenario()
+	primary := mapScenarioThroughPrimary(t, scenario)
+	start := primary.DailyForecasts[0].HourlyForecasts[0].Start.UTC()
+	fallback := mapScenarioThroughFallback(t, scenario, start)
+
+	primaryDay := primary.DailyForecasts[0]
+	fallbackDay := fallback.DailyForecasts[0]
+	primaryHour := primaryDay.HourlyForecasts[0]
+	fallbackHour := fallbackDay.HourlyForecasts[0]
+
+	// allowlist maps each core field only the primary mapping supplies to the
+	// entry in the fallback's missing-fields list acknowledging the gap
+	allowlist := map[string]string{
+		"hourly snowfall":               "snowfall",
+		"hourly rainfall":               "rainfall/showers split",
+		"hourly showers":                "rainfall/showers split",
+		"hourly liquid precipitation":   "rainfall/showers split",
+		"hourly apparent temperature":   "apparent temperature",
+		"hourly precip probability":     "precipitation probability",
+		"hourly visibility":             "visibility",
+		"hourly snow depth":             "snow depth",
+		"hourly freezing level":         "freezing level height",
+		"hourly is-day":                 "day/night flag",
+		"hourly cloud cover low":        "cloud cover bands",
+		"hourly cloud cover mid":        "cloud cover bands",
+		"hourly cloud cover high":       "cloud cover bands",
+		"daily weather":                 "daily weather summary",
+		"daily snowfall water equiv":    "snowfall",
+		"daily total snowfall":          "snowfall",
+		"daily total rainfall":          "rainfall/showers split",
+		"daily total showers":           "rainfall/showers split",
+		"daily total liquid precip":     "rainfall/showers split",
+		"daily sunrise":                 "sunrise/sunset",
+		"daily sunset":                  "sunrise/sunset",
+		"daily dominant wind direction": "dominant wind direction",
+		"daily highest freezing level":  "freezing level height",
+		"daily lowest freezing level":   "freezing level height",
+	}
+
+	coverage := []struct {
+		field    string
+		primary  bool
+		fallback bool
+	}{
+		{"hourly temperature", len(primaryHour.Temperature) > 0, len(fallbackHour.Temperature) > 0},
+		{"hourly wind", len(primaryHour.Wind) > 0, len(fallbackHour.Wind) > 0},
+		{"hourly precipitation", len(primaryHour.Precipitation) > 0, len(fallbackHour.Precipitation) > 0},
+		{"hourly cloud cover", len(primaryHour.CloudCover) > 0, len(fallbackHour.CloudCover) > 0},
+		{"hourly relative humidity", len(primaryHour.RelativeHumidity) > 0, len(fallbackHour.RelativeHumidity) > 0},
+		{"hourly weather", len(primaryHour.Weather) > 0, len(fallbackHour.Weather) > 0},
+		{"hourly snowfall", len(primaryHour.Snowfall) > 0, len(fallbackHour.Snowfall) > 0},
+		{"hourly rainfall", len(primaryHour.Rainfall) > 0, len(fallbackHour.Rainfall) > 0},
+		{"hourly showers", len(primaryHour.Showers) > 0, len(fallbackHour.Showers) > 0},
+		{"hourly liquid precipitation", len(primaryHour.LiquidPrecipitation) > 0, len(fallbackHour.LiquidPrecipitation) > 0},
+		{"hourly apparent temperature", len(primaryHour.ApparentTemperature) > 0, len(fallbackHour.ApparentTemperature) > 0},
+		{"hourly precip probability", len(primaryHour.PrecipitationProbability) > 0, len(fallbackHour.PrecipitationProbability) > 0},
+		{"hourly visibility", len(primaryHour.Visibility) > 0, len(fallbackHour.Visibility) > 0},
+		{"hourly snow depth", len(primaryHour.SnowDepth) > 0, len(fallbackHour.SnowDepth) > 0},
+		{"hourly freezing level", len(primaryHour.FreezingLevelHeight) > 0, len(fallbackHour.FreezingLevelHeight) > 0},
+		{"hourly is-day", len(primaryHour.IsDay) > 0, len(fallbackHour.IsDay) > 0},
+		{"hourly cloud cover low", len(primaryHour.CloudCoverLow) > 0, len(fallbackHour.CloudCoverLow) > 0},
+		{"hourly cloud cover mid", len(primaryHour.CloudCoverMid) > 0, len(fallbackHour.CloudCoverMid) > 0},
+		{"hourly cloud cover high", len(primaryHour.CloudCoverHigh) > 0, len(fallbackHour.CloudCoverHigh) > 0},
+		{"daily high temperature", len(primaryDay.HighTemperature) > 0, len(fallbackDay.HighTemperature) > 0},
+		{"daily low temperature", len(primaryDay.LowTemperature) > 0, len(fallbackDay.LowTemperature) > 0},
+		{"daily max wind", len(primaryDay.MaxWindSpeed) > 0, len(fallbackDay.MaxWindSpeed) > 0},
+		{"daily max gusts", len(primaryDay.MaxWindGusts) > 0, len(fallbackDay.MaxWindGusts) > 0},
+		{"daily total precipitation", len(primaryDay.TotalPrecipitation) > 0, len(fallbackDay.TotalPrecipitation) > 0},
+		{"daily weather", len(primaryDay.Weather) > 0, len(fallbackDay.Weather) > 0},
+		{"daily snowfall water equiv", len(primaryDay.SnowfallWaterEquivalentSum) > 0, len(fallbackDay.SnowfallWaterEquivalentSum) > 0},
+		{"daily total snowfall", len(primaryDay.TotalSnowfall) > 0, len(fallbackDay.TotalSnowfall) > 0},
+		{"daily total rainfall", len(primaryDay.TotalRain) > 0, len(fallbackDay.TotalRain) > 0},
+		{"daily total showers", len(primaryDay.TotalShowers) > 0, len(fallbackDay.TotalShowers) > 0},
+		{"daily total liquid precip", len(primaryDay.TotalLiquidPrecipitation) > 0, len(fallbackDay.TotalLiquidPrecipitation) > 0},
+		{"daily sunrise", len(primaryDay.Sunrise) > 0, len(fallbackDay.Sunrise) > 0},
+		{"daily sunset", len(primaryDay.Sunset) > 0, len(fallbackDay.Sunset) > 0},
+		{"daily dominant wind direction", len(primaryDay.WindDominantDirection) > 0, len(fallbackDay.WindDominantDirection) > 0},
+		{"daily highest freezing level", len(primaryDay.HighestFreezingLevelHeightFt) > 0, len(fallbackDay.HighestFreezingLevelHeightFt) > 0},
+		{"daily lowest freezing level", len(primaryDay.LowestFreezingLevelHeightFt) > 0, len(fallbackDay.LowestFreezingLevelHeightFt) > 0},
+	}
+
+	acknowledged := map[string]bool{}
+	for _, field := range fallback.DegradedMissingFields {
+		acknowledged[field] = true
+	}
+
+	for _, c := range coverage {
+		entry, allowed := allowlist[c.field]
+		switch {
+		case c.primary && c.fallback:
+			if allowed {
+				t.Errorf("%s: allowlist entry is stale, the fallback now supplies it", c.field)
+			}
+		case c.primary && !c.fallback:
+			if !allowed {
+				t.Errorf("%s: fallback diverges from primary without an allowlist entry", c.field)
+			} else if !acknowledged[entry] {
+				t.Errorf("%s: allowlisted as %q but the fallback's missing-fields list does not mention it", c.field, entry)
+			}
+		case !c.primary && c.fallback:
+			t.Errorf("%s: fallback supplies a field the primary does not", c.field)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package weather
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"medi/internal/types"
+)
+
+// CumulativeSeries holds running-total accumulation curves, in inches, for
+// one precipitation variable.
+type CumulativeSeries struct {
+	// PerModel maps each model to its running total per hour. A model's
+	// series stops at its horizon rather than flatlining, so it may be
+	// shorter than the Times axis; hours where the model has no value carry
+	// the previous total forward.
+	PerModel ModelValues[[]float64]
+
+	// P25, P50, and P75 are ensemble percentile curves across the models
+	// still within horizon at each hour, for shaded uncertainty bands. They
+	// stop at the last hour any model covers.
+	P25 []float64
+	P50 []float64
+	P75 []float64
+}
+
+// CumulativeAccumulation carries the cumulative plot data clients would
+// otherwise integrate from the hourly series themselves.
+type CumulativeAccumulation struct {
+	// Times is the hour-start axis every series aligns with
+	Times []time.Time
+
+	Snowfall            CumulativeSeries
+	LiquidPrecipitation CumulativeSeries
+}
+
+// applyCumulativeAccumulation computes running snowfall and liquid
+// precipitation totals per model across the forecast's hourly series.
+func applyCumulativeAccumulation(forecast *Forecast) {
+	hours := forecast.Hours()
+	if len(hours) == 0 {
+		return
+	}
+
+	cumulative := &CumulativeAccumulation{Times: make([]time.Time, 0, len(hours))}
+	for _, hour := range hours {
+		cumulative.Times = append(cumulative.Times, hour.Start)
+	}
+
+	cumulative.Snowfall = cumulativeSeries(hours, forecast.ModelHorizons,
+		func(h HourlyForecast) ModelValues[types.Precipitation] { return h.Snowfall })
+	cumulative.LiquidPrecipitation = cumulativeSeries(hours, forecast.ModelHorizons,
+		func(h HourlyForecast) ModelValues[types.Precipitation] { return h.LiquidPrecipitation })
+
+	forecast.Cumulative = cumulative
+}
+
+// cumulativeSeries integrates one hourly variable into per-model running
+// totals and ensemble percentile curves. A missing or NaN hourly value is a
+// gap: the total carries forward instead of resetting. A model past its
+// horizon ends its series instead of flatlining.
+func cumulativeSeries(hours []HourlyForecast, horizons ModelValues[time.Time], source func(HourlyForecast) ModelValues[types.Precipitation]) CumulativeSeries {
+	models := map[string]bool{}
+	for _, hour := range hours {
+		for model := range source(hour) {
+			models[model] = true
+		}
+	}
+
+	series := CumulativeSeries{PerModel: ModelValues[[]float64]{}}
+	for model := range models {
+		total := 0.0
+		var totals []float64
+		for _, hour := range hours {
+			if horizon, ok := horizons[model]; ok && hour.End.After(horizon) {
+				break
+			}
+			if value, ok := source(hour)[model]; ok && !math.IsNaN(value.Inches) {
+				total += value.Inches
+			}
+			totals = append(totals, total)
+		}
+		if len(totals) > 0 {
+			series.PerModel[model] = totals
+		}
+	}
+
+	for i := range hours {
+		var values []float64
+		for _, totals := range series.PerModel {
+			if i < len(totals) {
+				values = append(values, totals[i])
+			}
+		}
+		if len(values) == 0 {
+			break
+		}
+		sort.Float64s(values)
+		series.P25 = append(series.P25, percentile(values, 0.25))
+		series.P50 = append(series.P50, percentile(values, 0.50))
+		series.P75 = append(series.P75, percentile(values, 0.75))
+	}
+
+	return series
+}
@@ -0,0 +1,134 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// buildCumulativeForecast wraps per-model hourly snowfall (inches, NaN for a
+// gap hour where the model has no value) in a single-day forecast
+func buildCumulativeForecast(t *testing.T, snowfall map[string][]float64) *Forecast {
+	t.Helper()
+
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	hours := 0
+	for _, series := range snowfall {
+		if len(series) > hours {
+			hours = len(series)
+		}
+	}
+
+	day := DailyForecast{Timestamp: start}
+	for i := 0; i < hours; i++ {
+		hour := HourlyForecast{
+			Start:               start.Add(time.Duration(i) * time.Hour),
+			End:                 start.Add(time.Duration(i+1) * time.Hour),
+			Snowfall:            ModelValues[types.Precipitation]{},
+			LiquidPrecipitation: ModelValues[types.Precipitation]{},
+		}
+		for model, series := range snowfall {
+			if i < len(series) && !math.IsNaN(series[i]) {
+				hour.Snowfall[model] = types.NewPrecipitationFromInches(series[i])
+			}
+		}
+		day.HourlyForecasts = append(day.HourlyForecasts, hour)
+	}
+
+	return &Forecast{
+		Timezone:       "UTC",
+		DailyForecasts: []DailyForecast{day},
+	}
+}
+
+func assertSeries(t *testing.T, name string, got, expected []float64) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("%s = %v, want %v", name, got, expected)
+	}
+	for i := range got {
+		if math.Abs(got[i]-expected[i]) > 1e-9 {
+			t.Fatalf("%s = %v, want %v", name, got, expected)
+		}
+	}
+}
+
+func TestCumulativeSeriesRunningTotal(t *testing.T) {
+	forecast := buildCumulativeForecast(t, map[string][]float64{
+		ModelGfsSeamless: {1, 0, 2, 0.5},
+	})
+	applyCumulativeAccumulation(forecast)
+
+	if forecast.Cumulative == nil {
+		t.Fatal("Cumulative not computed")
+	}
+	if len(forecast.Cumulative.Times) != 4 {
+		t.Fatalf("Times length = %d, want 4", len(forecast.Cumulative.Times))
+	}
+	assertSeries(t, "snowfall totals",
+		forecast.Cumulative.Snowfall.PerModel[ModelGfsSeamless], []float64{1, 1, 3, 3.5})
+}
+
+func TestCumulativeSeriesCarriesGapsForward(t *testing.T) {
+	// The middle hours have no value for the model: the total carries
+	// forward instead of resetting
+	gap := math.NaN()
+	forecast := buildCumulativeForecast(t, map[string][]float64{
+		ModelGfsSeamless: {1, gap, gap, 2},
+	})
+	applyCumulativeAccumulation(forecast)
+
+	assertSeries(t, "snowfall totals",
+		forecast.Cumulative.Snowfall.PerModel[ModelGfsSeamless], []float64{1, 1, 1, 3})
+}
+
+func TestCumulativeSeriesStopsAtModelHorizon(t *testing.T) {
+	forecast := buildCumulativeForecast(t, map[string][]float64{
+		ModelGfsSeamless:  {1, 1, 1, 1},
+		ModelNcepNamConus: {2, 2, 2, 2},
+	})
+	// The short-range model's horizon ends after two hours; its padded tail
+	// must stop the series, not flatline it
+	start := forecast.DailyForecasts[0].HourlyForecasts[0].Start
+	forecast.ModelHorizons = ModelValues[time.Time]{
+		ModelNcepNamConus: start.Add(2 * time.Hour),
+	}
+	applyCumulativeAccumulation(forecast)
+
+	assertSeries(t, "full-horizon totals",
+		forecast.Cumulative.Snowfall.PerModel[ModelGfsSeamless], []float64{1, 2, 3, 4})
+	assertSeries(t, "short-horizon totals",
+		forecast.Cumulative.Snowfall.PerModel[ModelNcepNamConus], []float64{2, 4})
+}
+
+func TestCumulativeSeriesPercentiles(t *testing.T) {
+	forecast := buildCumulativeForecast(t, map[string][]float64{
+		ModelGfsSeamless: {0, 0},
+		ModelEcmwIfs:     {2, 2},
+		ModelGemSeamless: {4, 4},
+	})
+	applyCumulativeAccumulation(forecast)
+
+	snowfall := forecast.Cumulative.Snowfall
+	assertSeries(t, "p25", snowfall.P25, []float64{1, 2})
+	assertSeries(t, "p50", snowfall.P50, []float64{2, 4})
+	assertSeries(t, "p75", snowfall.P75, []float64{3, 6})
+}
+
+func TestCumulativeSeriesPercentilesStopWithLastModel(t *testing.T) {
+	forecast := buildCumulativeForecast(t, map[string][]float64{
+		ModelGfsSeamless: {1, 1, 1},
+	})
+	start := forecast.DailyForecasts[0].HourlyForecasts[0].Start
+	forecast.ModelHorizons = ModelValues[time.Time]{
+		ModelGfsSeamless: start.Add(2 * time.Hour),
+	}
+	applyCumulativeAccumulation(forecast)
+
+	snowfall := forecast.Cumulative.Snowfall
+	if len(snowfall.P50) != 2 {
+		t.Errorf("P50 length = %d, want the curve to stop with the last model", len(snowfall.P50))
+	}
+}
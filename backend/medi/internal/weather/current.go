@@ -0,0 +1,130 @@
+package weather
+
+import (
+	"medi/internal/providers/openmeteo"
+	"medi/internal/types"
+)
+
+// CurrentConditions value sources
+const (
+	CurrentSourceCurrent = "current"
+	CurrentSourceHourly  = "hourly"
+)
+
+// currentModelValues holds one model's slice of the API's current= block.
+// Fields are nil for models the API did not include in the block.
+type currentModelValues struct {
+	temperature      *float64
+	weatherCode      *int
+	windSpeed        *float64
+	windDirection    *float64
+	windGusts        *float64
+	cloudCover       *int
+	relativeHumidity *int
+}
+
+func currentValuesByModel(apiResponse *openmeteo.ForecastAPIResponse) map[string]currentModelValues {
+	cur := apiResponse.Current
+	return map[string]currentModelValues{
+		ModelGemSeamless: {
+			temperature:      cur.Temperature2MGemSeamless,
+			weatherCode:      cur.WeatherCodeGemSeamless,
+			windSpeed:        cur.WindSpeed10MGemSeamless,
+			windDirection:    cur.WindDirection10MGemSeamless,
+			windGusts:        cur.WindGusts10MGemSeamless,
+			cloudCover:       cur.CloudCoverGemSeamless,
+			relativeHumidity: cur.RelativeHumidity2MGemSeamless,
+		},
+		ModelEcmwIfs: {
+			temperature:      cur.Temperature2MEcmwfIfs,
+			weatherCode:      cur.WeatherCodeEcmwfIfs,
+			windSpeed:        cur.WindSpeed10MEcmwfIfs,
+			windDirection:    cur.WindDirection10MEcmwfIfs,
+			windGusts:        cur.WindGusts10MEcmwfIfs,
+			cloudCover:       cur.CloudCoverEcmwfIfs,
+			relativeHumidity: cur.RelativeHumidity2MEcmwfIfs,
+		},
+		ModelGfsSeamless: {
+			temperature:      cur.Temperature2MGfsSeamless,
+			weatherCode:      cur.WeatherCodeGfsSeamless,
+			windSpeed:        cur.WindSpeed10MGfsSeamless,
+			windDirection:    cur.WindDirection10MGfsSeamless,
+			windGusts:        cur.WindGusts10MGfsSeamless,
+			cloudCover:       cur.CloudCoverGfsSeamless,
+			relativeHumidity: cur.RelativeHumidity2MGfsSeamless,
+		},
+		ModelNcepNbmConus: {
+			temperature:      cur.Temperature2MNcepNbmConus,
+			weatherCode:      cur.WeatherCodeNcepNbmConus,
+			windSpeed:        cur.WindSpeed10MNcepNbmConus,
+			windDirection:    cur.WindDirection10MNcepNbmConus,
+			windGusts:        cur.WindGusts10MNcepNbmConus,
+			cloudCover:       cur.CloudCoverNcepNbmConus,
+			relativeHumidity: cur.RelativeHumidity2MNcepNbmConus,
+		},
+		ModelGfsGraphcast025: {
+			temperature:      cur.Temperature2MGfsGraphcast025,
+			weatherCode:      cur.WeatherCodeGfsGraphcast025,
+			windSpeed:        cur.WindSpeed10MGfsGraphcast025,
+			windDirection:    cur.WindDirection10MGfsGraphcast025,
+			windGusts:        cur.WindGusts10MGfsGraphcast025,
+			cloudCover:       cur.CloudCoverGfsGraphcast025,
+			relativeHumidity: cur.RelativeHumidity2MGfsGraphcast025,
+		},
+		ModelEcmwfAifs025Single: {
+			temperature:      cur.Temperature2MEcmwfAifs025Single,
+			weatherCode:      cur.WeatherCodeEcmwfAifs025Single,
+			windSpeed:        cur.WindSpeed10MEcmwfAifs025Single,
+			windDirection:    cur.WindDirection10MEcmwfAifs025Single,
+			windGusts:        cur.WindGusts10MEcmwfAifs025Single,
+			cloudCover:       cur.CloudCoverEcmwfAifs025Single,
+			relativeHumidity: cur.RelativeHumidity2MEcmwfAifs025Single,
+		},
+		ModelNcepNamConus: {
+			temperature:      cur.Temperature2MNcepNamConus,
+			weatherCode:      cur.WeatherCodeNcepNamConus,
+			windSpeed:        cur.WindSpeed10MNcepNamConus,
+			windDirection:    cur.WindDirection10MNcepNamConus,
+			windGusts:        cur.WindGusts10MNcepNamConus,
+			cloudCover:       cur.CloudCoverNcepNamConus,
+			relativeHumidity: cur.RelativeHumidity2MNcepNamConus,
+		},
+	}
+}
+
+// applyCurrentBlock overlays instantaneous values from the current= block onto
+// hourly-derived current conditions. A model counts as present in the block
+// when its current temperature is reported; present models are overridden
+// field by field, and anything the block omits keeps the hourly-derived
+// fallback. Source records which path each model took.
+func applyCurrentBlock(conditions *CurrentConditions, apiResponse *openmeteo.ForecastAPIResponse) {
+	source := ModelValues[string]{}
+
+	for model, values := range currentValuesByModel(apiResponse) {
+		source[model] = CurrentSourceHourly
+		if values.temperature == nil {
+			continue
+		}
+		source[model] = CurrentSourceCurrent
+
+		conditions.Temperature[model] = types.NewTemperatureFromFahrenheit(*values.temperature)
+		if values.weatherCode != nil {
+			conditions.Weather[model] = types.NewWeather(*values.weatherCode)
+		}
+		if values.windSpeed != nil && values.windDirection != nil {
+			if values.windGusts != nil {
+				conditions.Wind[model] = types.NewWind(*values.windSpeed, *values.windGusts, *values.windDirection)
+			} else {
+				conditions.Wind[model] = types.NewWindWithoutGusts(*values.windSpeed, *values.windDirection)
+			}
+		}
+		if values.cloudCover != nil {
+			conditions.CloudCover[model] = toPercentage(*values.cloudCover)
+		}
+		if values.relativeHumidity != nil {
+			conditions.RelativeHumidity[model] = toPercentage(*values.relativeHumidity)
+		}
+	}
+
+	conditions.Source = source
+}
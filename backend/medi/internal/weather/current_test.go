@@ -0,0 +1,165 @@
+package weather
+
+import (
+	"testing"
+
+	"medi/internal/providers/openmeteo"
+	"medi/internal/types"
+)
+
+// clearCurrentBlock strips the current= block from a response, as if the API
+// had not been asked for it
+func clearCurrentBlock(apiResp *openmeteo.ForecastAPIResponse) {
+	var empty openmeteo.ForecastAPIResponse
+	apiResp.Current = empty.Current
+}
+
+func TestCurrentBlockPreferredOverHourly(t *testing.T) {
+	apiResp := synthForecastResponse(t, 2)
+	clearCurrentBlock(apiResp)
+
+	// Only GFS is present in the current block, with values distinct from
+	// anything the hourly series produces
+	temperature := -42.5
+	weatherCode := 75
+	windSpeed := 55.0
+	windDirection := 123.0
+	windGusts := 88.0
+	cloudCover := 91
+	relativeHumidity := 97
+
+	apiResp.Current.Temperature2MGfsSeamless = &temperature
+	apiResp.Current.WeatherCodeGfsSeamless = &weatherCode
+	apiResp.Current.WindSpeed10MGfsSeamless = &windSpeed
+	apiResp.Current.WindDirection10MGfsSeamless = &windDirection
+	apiResp.Current.WindGusts10MGfsSeamless = &windGusts
+	apiResp.Current.CloudCoverGfsSeamless = &cloudCover
+	apiResp.Current.RelativeHumidity2MGfsSeamless = &relativeHumidity
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	conditions := forecast.CurrentConditions
+
+	if source, _ := conditions.Source.GetForModel(ModelGfsSeamless); source != CurrentSourceCurrent {
+		t.Errorf("GFS source = %q, want %q", source, CurrentSourceCurrent)
+	}
+	if source, _ := conditions.Source.GetForModel(ModelGemSeamless); source != CurrentSourceHourly {
+		t.Errorf("GEM source = %q, want %q", source, CurrentSourceHourly)
+	}
+
+	gfsTemp, ok := conditions.Temperature.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS current temperature")
+	}
+	if gfsTemp.Fahrenheit != temperature {
+		t.Errorf("GFS current temperature = %v F, want %v", gfsTemp.Fahrenheit, temperature)
+	}
+
+	gfsWeather, ok := conditions.Weather.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS current weather")
+	}
+	if gfsWeather.Code != weatherCode {
+		t.Errorf("GFS current weather code = %d, want %d", gfsWeather.Code, weatherCode)
+	}
+
+	gfsWind, ok := conditions.Wind.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS current wind")
+	}
+	if gfsWind.Speed.Mph != windSpeed {
+		t.Errorf("GFS current wind speed = %v mph, want %v", gfsWind.Speed.Mph, windSpeed)
+	}
+	if gfsWind.Gusts.Mph != windGusts {
+		t.Errorf("GFS current wind gusts = %v mph, want %v", gfsWind.Gusts.Mph, windGusts)
+	}
+
+	gfsCloud, ok := conditions.CloudCover.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS current cloud cover")
+	}
+	if gfsCloud != float64(cloudCover)/100 {
+		t.Errorf("GFS current cloud cover = %v, want %v", gfsCloud, float64(cloudCover)/100)
+	}
+}
+
+func TestCurrentBlockAbsentFallsBackToHourly(t *testing.T) {
+	apiResp := synthForecastResponse(t, 2)
+	clearCurrentBlock(apiResp)
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	conditions := forecast.CurrentConditions
+
+	if len(conditions.Source) != 7 {
+		t.Fatalf("Source has %d models, want 7", len(conditions.Source))
+	}
+	for model, source := range conditions.Source {
+		if source != CurrentSourceHourly {
+			t.Errorf("model %s source = %q, want %q", model, source, CurrentSourceHourly)
+		}
+	}
+
+	// Hourly-derived values are still fully populated
+	if len(conditions.Temperature) != 7 {
+		t.Errorf("Temperature has %d models, want 7", len(conditions.Temperature))
+	}
+	if len(conditions.Wind) != 7 {
+		t.Errorf("Wind has %d models, want 7", len(conditions.Wind))
+	}
+}
+
+func TestCurrentBlockPartialModelKeepsHourlyFields(t *testing.T) {
+	apiResp := synthForecastResponse(t, 2)
+	clearCurrentBlock(apiResp)
+
+	// ECMWF reports only a current temperature; its other fields must keep
+	// the hourly-derived values
+	temperature := -42.5
+	apiResp.Current.Temperature2MEcmwfIfs = &temperature
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelEcmwIfs, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	conditions := forecast.CurrentConditions
+
+	if source, _ := conditions.Source.GetForModel(ModelEcmwIfs); source != CurrentSourceCurrent {
+		t.Errorf("ECMWF source = %q, want %q", source, CurrentSourceCurrent)
+	}
+
+	ecmwfTemp, ok := conditions.Temperature.GetForModel(ModelEcmwIfs)
+	if !ok {
+		t.Fatal("no ECMWF current temperature")
+	}
+	if ecmwfTemp.Fahrenheit != temperature {
+		t.Errorf("ECMWF current temperature = %v F, want %v", ecmwfTemp.Fahrenheit, temperature)
+	}
+
+	ecmwfWind, ok := conditions.Wind.GetForModel(ModelEcmwIfs)
+	if !ok {
+		t.Fatal("no ECMWF wind fallback")
+	}
+	if ecmwfWind.Speed.Mph == 0 && ecmwfWind.Gusts.Mph == 0 {
+		t.Error("ECMWF wind fallback appears unpopulated")
+	}
+}
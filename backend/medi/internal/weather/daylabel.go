@@ -0,0 +1,104 @@
+package weather
+
+import (
+	"strings"
+	"time"
+)
+
+// Day labeling for summary text. Clients and digest emails want "Tonight" or
+// "Tomorrow" for the days riders are actively planning and plain weekday
+// names beyond that, in the reader's language rather than abbreviated English.
+
+const (
+	// dayLabelRelativeHours is how far ahead of the request time a day may
+	// start and still earn a relative label instead of its weekday name
+	dayLabelRelativeHours = 36
+	// dayLabelEveningHour is the local hour from which the day underway reads
+	// "Tonight" and the next day "Tomorrow night": by evening the window that
+	// matters is the overnight one
+	dayLabelEveningHour = 18
+)
+
+// dayLabels carries one language's relative labels and weekday names, the
+// weekdays indexed by time.Weekday (Sunday first)
+type dayLabels struct {
+	today         string
+	tonight       string
+	tomorrow      string
+	tomorrowNight string
+	weekdays      [7]string
+}
+
+// dayLabelTables are the supported languages, keyed by primary subtag.
+// Unsupported languages fall back to English.
+var dayLabelTables = map[string]dayLabels{
+	"en": {
+		today:         "Today",
+		tonight:       "Tonight",
+		tomorrow:      "Tomorrow",
+		tomorrowNight: "Tomorrow night",
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	},
+	"es": {
+		today:         "Hoy",
+		tonight:       "Esta noche",
+		tomorrow:      "Mañana",
+		tomorrowNight: "Mañana por la noche",
+		weekdays:      [7]string{"Domingo", "Lunes", "Martes", "Miércoles", "Jueves", "Viernes", "Sábado"},
+	},
+	"fr": {
+		today:         "Aujourd'hui",
+		tonight:       "Ce soir",
+		tomorrow:      "Demain",
+		tomorrowNight: "Demain soir",
+		weekdays:      [7]string{"Dimanche", "Lundi", "Mardi", "Mercredi", "Jeudi", "Vendredi", "Samedi"},
+	},
+}
+
+// DayLabel names a forecast day for summary text: the request's own calendar
+// day and the next one read relatively (Today or Tonight, Tomorrow or
+// Tomorrow night, flipping to the night forms from evening on), every later
+// day reads as its weekday name. Both the day boundary and the evening cutoff
+// are evaluated at the request time in the forecast's timezone, so a request
+// just after local midnight already calls the new day Today. The language is
+// a BCP 47 tag matched on its primary subtag.
+func DayLabel(date time.Time, loc *time.Location, now time.Time, language string) string {
+	labels := labelsForLanguage(language)
+
+	localNow := now.In(loc)
+	localDate := date.In(loc)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	dayStart := time.Date(localDate.Year(), localDate.Month(), localDate.Day(), 0, 0, 0, 0, loc)
+
+	// Days already underway or starting beyond the relative window read as
+	// weekday names
+	withinWindow := !dayStart.Before(today) &&
+		dayStart.Sub(localNow) < dayLabelRelativeHours*time.Hour
+	if !withinWindow {
+		return labels.weekdays[localDate.Weekday()]
+	}
+
+	evening := localNow.Hour() >= dayLabelEveningHour
+	switch {
+	case dayStart.Equal(today) && evening:
+		return labels.tonight
+	case dayStart.Equal(today):
+		return labels.today
+	case dayStart.Equal(today.AddDate(0, 0, 1)) && evening:
+		return labels.tomorrowNight
+	case dayStart.Equal(today.AddDate(0, 0, 1)):
+		return labels.tomorrow
+	default:
+		return labels.weekdays[localDate.Weekday()]
+	}
+}
+
+// labelsForLanguage matches a BCP 47 tag to a label table on its primary
+// subtag, falling back to English
+func labelsForLanguage(language string) dayLabels {
+	primary, _, _ := strings.Cut(language, "-")
+	if labels, ok := dayLabelTables[strings.ToLower(primary)]; ok {
+		return labels
+	}
+	return dayLabelTables["en"]
+}
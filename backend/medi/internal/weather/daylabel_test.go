@@ -0,0 +1,119 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayLabel(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	// Wednesday
+	newDay := func(day int) time.Time {
+		return time.Date(2025, 1, day, 0, 0, 0, 0, denver)
+	}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		now      time.Time
+		language string
+		want     string
+	}{
+		{
+			name: "afternoon request labels the current day Today",
+			date: newDay(15),
+			now:  time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			want: "Today",
+		},
+		{
+			name: "11pm request labels the current day Tonight",
+			date: newDay(15),
+			now:  time.Date(2025, 1, 15, 23, 0, 0, 0, denver),
+			want: "Tonight",
+		},
+		{
+			name: "just after local midnight the new day is already Today",
+			date: newDay(16),
+			now:  time.Date(2025, 1, 16, 0, 30, 0, 0, denver),
+			want: "Today",
+		},
+		{
+			name: "afternoon request labels the next day Tomorrow",
+			date: newDay(16),
+			now:  time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			want: "Tomorrow",
+		},
+		{
+			name: "evening request flips the next day to Tomorrow night",
+			date: newDay(16),
+			now:  time.Date(2025, 1, 15, 23, 0, 0, 0, denver),
+			want: "Tomorrow night",
+		},
+		{
+			name: "two days out falls past the relative window",
+			date: newDay(17),
+			now:  time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			want: "Friday",
+		},
+		{
+			name: "a day already past reads as its weekday",
+			date: newDay(14),
+			now:  time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			want: "Tuesday",
+		},
+		{
+			name: "request time converts into the forecast timezone",
+			date: newDay(15),
+			// 06:30 UTC is still 23:30 the previous evening in Denver
+			now:  time.Date(2025, 1, 16, 6, 30, 0, 0, time.UTC),
+			want: "Tonight",
+		},
+		{
+			name:     "spanish relative label",
+			date:     newDay(15),
+			now:      time.Date(2025, 1, 15, 23, 0, 0, 0, denver),
+			language: "es",
+			want:     "Esta noche",
+		},
+		{
+			name:     "spanish weekday",
+			date:     newDay(17),
+			now:      time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			language: "es",
+			want:     "Viernes",
+		},
+		{
+			name:     "french relative label",
+			date:     newDay(16),
+			now:      time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			language: "fr",
+			want:     "Demain",
+		},
+		{
+			name:     "region subtag matches on the primary subtag",
+			date:     newDay(15),
+			now:      time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			language: "es-MX",
+			want:     "Hoy",
+		},
+		{
+			name:     "unsupported language falls back to english",
+			date:     newDay(15),
+			now:      time.Date(2025, 1, 15, 14, 0, 0, 0, denver),
+			language: "de",
+			want:     "Today",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DayLabel(tt.date, denver, tt.now, tt.language); got != tt.want {
+				t.Errorf("DayLabel(%v at %v, %q) = %q, want %q",
+					tt.date.Format(time.DateOnly), tt.now, tt.language, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package weather
+
+import (
+	"time"
+
+	"medi/internal/astro"
+)
+
+// DaylightStatus values for DailyForecast.DaylightStatus.
+const (
+	DaylightStatusNormal     = "normal"
+	DaylightStatusPolarDay   = "polar_day"   // the sun never sets
+	DaylightStatusPolarNight = "polar_night" // the sun never rises
+)
+
+// skinningHeavyPrecipInches is the hourly water-equivalent precipitation at
+// or above which an hour stops counting toward SkinningHours.
+const skinningHeavyPrecipInches = 0.2
+
+// applyDaylight attaches computed solar times and a daylight status to each
+// daily forecast and derives SkinningHours from the primary model's hourly
+// series. maxTempF is the configured temperature ceiling for an hour to still
+// count.
+func applyDaylight(forecast *Forecast, maxTempF float64) {
+	latitude := forecast.ForecastPoint.Coordinates.Latitude
+	longitude := forecast.ForecastPoint.Coordinates.Longitude
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+		sun := astro.Sun(day.Timestamp, latitude, longitude)
+		day.Daylight = &sun
+		day.DaylightStatus = daylightStatus(&sun, day.HourlyForecasts)
+		day.SkinningHours = skinningHours(day.HourlyForecasts, forecast.PrimaryModel, &sun, day.DaylightStatus, maxTempF)
+	}
+}
+
+// daylightStatus classifies the day. When the sun neither rises nor sets, the
+// hourly is-day flags — which the models still report where sunrise and
+// sunset come back empty — tell polar day from polar night; without them the
+// computed day length decides.
+func daylightStatus(sun *astro.SunInfo, hours []HourlyForecast) string {
+	if sun.Rise != nil || sun.Set != nil {
+		return DaylightStatusNormal
+	}
+
+	lit, dark := 0, 0
+	for _, hour := range hours {
+		for _, isDay := range hour.IsDay {
+			if isDay {
+				lit++
+			} else {
+				dark++
+			}
+		}
+	}
+	if lit > dark {
+		return DaylightStatusPolarDay
+	}
+	if dark > 0 {
+		return DaylightStatusPolarNight
+	}
+
+	if sun.DayLength >= 12*time.Hour {
+		return DaylightStatusPolarDay
+	}
+	return DaylightStatusPolarNight
+}
+
+// skinningHours counts the hours between civil dawn and civil dusk where the
+// primary model keeps the temperature below maxTempF and the precipitation
+// under the heavy threshold. Under the midnight sun every hour is daylight,
+// gated only by the model's own day flag; during polar night none count.
+func skinningHours(hours []HourlyForecast, primaryModel string, sun *astro.SunInfo, status string, maxTempF float64) int {
+	if status == DaylightStatusPolarNight {
+		return 0
+	}
+
+	count := 0
+	for _, hour := range hours {
+		if status == DaylightStatusPolarDay {
+			if isDay, ok := hour.IsDay.GetForModel(primaryModel); ok && !isDay {
+				continue
+			}
+		} else {
+			if sun.CivilDawn != nil && hour.Start.Before(*sun.CivilDawn) {
+				continue
+			}
+			if sun.CivilDusk != nil && !hour.Start.Before(*sun.CivilDusk) {
+				continue
+			}
+		}
+
+		temperature, ok := hour.Temperature.GetForModel(primaryModel)
+		if !ok || temperature.Fahrenheit >= maxTempF {
+			continue
+		}
+		if precipitation, ok := hour.Precipitation.GetForModel(primaryModel); ok &&
+			precipitation.Inches >= skinningHeavyPrecipInches {
+			continue
+		}
+
+		count++
+	}
+	return count
+}
@@ -0,0 +1,183 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// daylightDay builds a day of hourly forecasts for the primary model, with the
+// temperature for each hour supplied by tempF and an optional per-hour
+// precipitation total in inches.
+func daylightDay(date time.Time, tempF func(hour int) float64, precipInches map[int]float64) DailyForecast {
+	var hours []HourlyForecast
+	for h := 0; h < 24; h++ {
+		hour := HourlyForecast{
+			Start: date.Add(time.Duration(h) * time.Hour),
+			End:   date.Add(time.Duration(h+1) * time.Hour),
+			Temperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(tempF(h)),
+			},
+			Precipitation: ModelValues[types.Precipitation]{
+				ModelGfsSeamless: types.NewPrecipitationFromInches(precipInches[h]),
+			},
+		}
+		hours = append(hours, hour)
+	}
+	return DailyForecast{Timestamp: date, HourlyForecasts: hours}
+}
+
+func TestSkinningHoursAfternoonWarmup(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, denver)
+
+	// Cold through midday, then a warm-up from noon; a heavy squall at 08:00.
+	// Civil daylight in Denver that day runs roughly 06:50 to 17:28, so the
+	// countable cold hours are 07, 09, 10, and 11.
+	day := daylightDay(date,
+		func(hour int) float64 {
+			if hour >= 12 && hour < 18 {
+				return 42
+			}
+			return 25
+		},
+		map[int]float64{8: 0.3},
+	)
+	forecast := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Coordinates: types.NewCoords(39.7392, -104.9903)},
+		PrimaryModel:   ModelGfsSeamless,
+		DailyForecasts: []DailyForecast{day},
+	}
+
+	applyDaylight(forecast, 40.0)
+
+	got := forecast.DailyForecasts[0]
+	if got.Daylight == nil {
+		t.Fatal("Expected the daylight block to be populated")
+	}
+	if got.Daylight.Rise == nil || got.Daylight.Set == nil {
+		t.Fatal("Expected sunrise and sunset in midwinter Denver")
+	}
+	if got.SkinningHours != 4 {
+		t.Errorf("SkinningHours = %d, want 4 (warm afternoon and squall hour excluded)", got.SkinningHours)
+	}
+}
+
+func TestSkinningHoursPolarNight(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	date := time.Date(2025, 12, 21, 0, 0, 0, 0, oslo)
+
+	// Bitter cold all day, but at 78°N the sun never reaches civil twilight.
+	day := daylightDay(date, func(int) float64 { return 5 }, nil)
+	forecast := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Coordinates: types.NewCoords(78.2232, 15.6267)},
+		PrimaryModel:   ModelGfsSeamless,
+		DailyForecasts: []DailyForecast{day},
+	}
+
+	applyDaylight(forecast, 40.0)
+
+	got := forecast.DailyForecasts[0]
+	if got.Daylight == nil {
+		t.Fatal("Expected the daylight block to be populated")
+	}
+	if got.SkinningHours != 0 {
+		t.Errorf("SkinningHours = %d, want 0 during polar night", got.SkinningHours)
+	}
+}
+
+// polarDay builds a day of hourly forecasts at a fixed temperature with the
+// primary model's is-day flag supplied per hour, for high-latitude fixtures
+// where the clock says nothing about darkness.
+func polarDay(date time.Time, tempF float64, isDay func(hour int) bool) DailyForecast {
+	var hours []HourlyForecast
+	for h := 0; h < 24; h++ {
+		hours = append(hours, HourlyForecast{
+			Start: date.Add(time.Duration(h) * time.Hour),
+			End:   date.Add(time.Duration(h+1) * time.Hour),
+			Temperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(tempF),
+			},
+			IsDay: ModelValues[bool]{ModelGfsSeamless: isDay(h)},
+		})
+	}
+	return DailyForecast{Timestamp: date, HourlyForecasts: hours}
+}
+
+func TestDaylightStatusNormalDay(t *testing.T) {
+	denver, _ := time.LoadLocation("America/Denver")
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, denver)
+
+	forecast := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Coordinates: types.NewCoords(39.7392, -104.9903)},
+		PrimaryModel:   ModelGfsSeamless,
+		DailyForecasts: []DailyForecast{daylightDay(date, func(int) float64 { return 25 }, nil)},
+	}
+	applyDaylight(forecast, 40.0)
+
+	if got := forecast.DailyForecasts[0].DaylightStatus; got != DaylightStatusNormal {
+		t.Errorf("DaylightStatus = %q, want %q in midwinter Denver", got, DaylightStatusNormal)
+	}
+}
+
+func TestDaylightStatusPolarDayFromIsDay(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, oslo)
+
+	// Tromsø at midsummer: the model flags every hour as day, and a cold
+	// snap keeps every one of them skinnable.
+	day := polarDay(date, 30, func(int) bool { return true })
+	forecast := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Coordinates: types.NewCoords(69.6496, 18.9560)},
+		PrimaryModel:   ModelGfsSeamless,
+		DailyForecasts: []DailyForecast{day},
+	}
+	applyDaylight(forecast, 40.0)
+
+	got := forecast.DailyForecasts[0]
+	if got.DaylightStatus != DaylightStatusPolarDay {
+		t.Fatalf("DaylightStatus = %q, want %q under the midnight sun", got.DaylightStatus, DaylightStatusPolarDay)
+	}
+	if got.Daylight.Rise != nil || got.Daylight.Set != nil {
+		t.Error("Expected no sunrise or sunset during polar day")
+	}
+	if got.SkinningHours != 24 {
+		t.Errorf("SkinningHours = %d, want 24 when every hour is cold daylight", got.SkinningHours)
+	}
+}
+
+func TestDaylightStatusPolarNightFromIsDay(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	date := time.Date(2025, 12, 21, 0, 0, 0, 0, oslo)
+
+	// Tromsø at midwinter: civil twilight still brushes midday, but the sun
+	// never clears the horizon and the model flags every hour as night.
+	day := polarDay(date, 5, func(int) bool { return false })
+	forecast := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Coordinates: types.NewCoords(69.6496, 18.9560)},
+		PrimaryModel:   ModelGfsSeamless,
+		DailyForecasts: []DailyForecast{day},
+	}
+	applyDaylight(forecast, 40.0)
+
+	got := forecast.DailyForecasts[0]
+	if got.DaylightStatus != DaylightStatusPolarNight {
+		t.Fatalf("DaylightStatus = %q, want %q during polar night", got.DaylightStatus, DaylightStatusPolarNight)
+	}
+	if got.SkinningHours != 0 {
+		t.Errorf("SkinningHours = %d, want 0 during polar night", got.SkinningHours)
+	}
+}
@@ -0,0 +1,92 @@
+package weather
+
+import "time"
+
+// location resolves the forecast's IANA timezone, falling back to UTC when
+// the name does not load
+func (f *Forecast) location() *time.Location {
+	loc, err := time.LoadLocation(f.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DayFor returns the daily forecast covering the given instant's calendar
+// date, interpreted in the forecast's timezone
+func (f *Forecast) DayFor(date time.Time) (*DailyForecast, bool) {
+	loc := f.location()
+	year, month, day := date.In(loc).Date()
+	for i := range f.DailyForecasts {
+		dayYear, dayMonth, dayDay := f.DailyForecasts[i].Timestamp.In(loc).Date()
+		if dayYear == year && dayMonth == month && dayDay == day {
+			return &f.DailyForecasts[i], true
+		}
+	}
+	return nil, false
+}
+
+// Today returns the daily forecast for the injected clock's current date
+func (f *Forecast) Today(now time.Time) (*DailyForecast, bool) {
+	return f.DayFor(now)
+}
+
+// Tomorrow returns the daily forecast for the calendar day after the injected
+// clock's current date. The date math runs in the forecast's timezone at noon,
+// so DST transition days with 23 or 25 hours still resolve to the right date.
+func (f *Forecast) Tomorrow(now time.Time) (*DailyForecast, bool) {
+	loc := f.location()
+	local := now.In(loc)
+	return f.DayFor(time.Date(local.Year(), local.Month(), local.Day()+1, 12, 0, 0, 0, loc))
+}
+
+// Hours returns every hourly forecast in series order, across day boundaries
+func (f *Forecast) Hours() []HourlyForecast {
+	var hours []HourlyForecast
+	for _, day := range f.DailyForecasts {
+		hours = append(hours, day.HourlyForecasts...)
+	}
+	return hours
+}
+
+// ClampToHorizon caps an instant at HorizonEnd so derived windows cannot
+// reach past the hourly data; a zero HorizonEnd leaves it unchanged
+func (f *Forecast) ClampToHorizon(t time.Time) time.Time {
+	if !f.HorizonEnd.IsZero() && t.After(f.HorizonEnd) {
+		return f.HorizonEnd
+	}
+	return t
+}
+
+// HoursBetween returns the hourly forecasts overlapping the half-open
+// interval [start, end), spanning day boundaries. Partial first and last days
+// and DST days with 23 or 25 hours need no special handling because each
+// entry carries its own Start and End. An end past HorizonEnd is clamped to
+// it rather than trusted.
+func (f *Forecast) HoursBetween(start, end time.Time) []HourlyForecast {
+	end = f.ClampToHorizon(end)
+	var hours []HourlyForecast
+	for _, day := range f.DailyForecasts {
+		for _, hour := range day.HourlyForecasts {
+			if hour.Start.Before(end) && hour.End.After(start) {
+				hours = append(hours, hour)
+			}
+		}
+	}
+	return hours
+}
+
+// NowHour returns the hourly entry whose [Start, End) interval contains the
+// given instant
+func (f *Forecast) NowHour(at time.Time) (*HourlyForecast, bool) {
+	for i := range f.DailyForecasts {
+		day := &f.DailyForecasts[i]
+		for j := range day.HourlyForecasts {
+			hour := &day.HourlyForecasts[j]
+			if !at.Before(hour.Start) && at.Before(hour.End) {
+				return hour, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,148 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+// dstDayForecast builds a Denver forecast spanning the 2025 spring-forward
+// transition: a partial first day starting at 18:00, the 23-hour DST day, and
+// a partial last day with six hours
+func dstDayForecast(t *testing.T) *Forecast {
+	t.Helper()
+	loc, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+
+	buildDay := func(day, firstHour int) DailyForecast {
+		start := time.Date(2025, time.March, day, firstHour, 0, 0, 0, loc)
+		next := time.Date(2025, time.March, day+1, 0, 0, 0, 0, loc)
+		daily := DailyForecast{Timestamp: time.Date(2025, time.March, day, 0, 0, 0, 0, loc)}
+		for cur := start; cur.Before(next); cur = cur.Add(time.Hour) {
+			daily.HourlyForecasts = append(daily.HourlyForecasts, HourlyForecast{
+				Start: cur,
+				End:   cur.Add(time.Hour),
+			})
+		}
+		return daily
+	}
+
+	lastDay := buildDay(10, 0)
+	lastDay.HourlyForecasts = lastDay.HourlyForecasts[:6]
+
+	return &Forecast{
+		Timezone: "America/Denver",
+		DailyForecasts: []DailyForecast{
+			buildDay(8, 18),
+			buildDay(9, 0),
+			lastDay,
+		},
+	}
+}
+
+func TestDayFor(t *testing.T) {
+	forecast := dstDayForecast(t)
+	loc, _ := time.LoadLocation("America/Denver")
+
+	// A UTC instant on the evening of the 9th in Denver is the morning of the
+	// 10th in UTC; the forecast timezone decides
+	at := time.Date(2025, time.March, 10, 3, 0, 0, 0, time.UTC)
+	day, ok := forecast.DayFor(at)
+	if !ok {
+		t.Fatal("DayFor found no day for an in-range instant")
+	}
+	if got := day.Timestamp.In(loc).Day(); got != 9 {
+		t.Errorf("DayFor resolved to day %d, want 9", got)
+	}
+
+	if _, ok := forecast.DayFor(time.Date(2025, time.March, 12, 12, 0, 0, 0, loc)); ok {
+		t.Error("DayFor found a day past the series")
+	}
+}
+
+func TestTodayAndTomorrow(t *testing.T) {
+	forecast := dstDayForecast(t)
+	loc, _ := time.LoadLocation("America/Denver")
+
+	now := time.Date(2025, time.March, 8, 20, 30, 0, 0, loc)
+	today, ok := forecast.Today(now)
+	if !ok || today.Timestamp.In(loc).Day() != 8 {
+		t.Fatalf("Today(%v) resolved wrong day", now)
+	}
+	tomorrow, ok := forecast.Tomorrow(now)
+	if !ok || tomorrow.Timestamp.In(loc).Day() != 9 {
+		t.Fatalf("Tomorrow(%v) resolved wrong day", now)
+	}
+
+	// From the 23-hour DST day, tomorrow is still the 10th
+	now = time.Date(2025, time.March, 9, 6, 0, 0, 0, loc)
+	tomorrow, ok = forecast.Tomorrow(now)
+	if !ok || tomorrow.Timestamp.In(loc).Day() != 10 {
+		t.Fatalf("Tomorrow across the DST day resolved wrong day")
+	}
+}
+
+func TestHoursBetween(t *testing.T) {
+	forecast := dstDayForecast(t)
+	loc, _ := time.LoadLocation("America/Denver")
+
+	// The full local DST day has 23 hourly entries
+	dayStart := time.Date(2025, time.March, 9, 0, 0, 0, 0, loc)
+	dayEnd := time.Date(2025, time.March, 10, 0, 0, 0, 0, loc)
+	hours := forecast.HoursBetween(dayStart, dayEnd)
+	if len(hours) != 23 {
+		t.Errorf("DST day has %d hours, want 23", len(hours))
+	}
+
+	// A window spanning the first day boundary crosses day slices seamlessly;
+	// 02:00 does not exist on the spring-forward morning, so it ends at 03:00
+	hours = forecast.HoursBetween(
+		time.Date(2025, time.March, 8, 22, 0, 0, 0, loc),
+		time.Date(2025, time.March, 9, 3, 0, 0, 0, loc),
+	)
+	// 22:00 and 23:00 on the 8th, 00:00 and 01:00 on the 9th
+	if len(hours) != 4 {
+		t.Fatalf("boundary window has %d hours, want 4", len(hours))
+	}
+	if !hours[0].Start.Equal(time.Date(2025, time.March, 8, 22, 0, 0, 0, loc)) {
+		t.Errorf("boundary window starts at %v", hours[0].Start)
+	}
+	if !hours[3].End.Equal(time.Date(2025, time.March, 9, 3, 0, 0, 0, loc)) {
+		// 01:00 MST ends at 03:00 MDT on the spring-forward morning
+		t.Errorf("boundary window ends at %v", hours[3].End)
+	}
+
+	// A window reaching past the truncated last day stops at the series end
+	hours = forecast.HoursBetween(
+		time.Date(2025, time.March, 10, 4, 0, 0, 0, loc),
+		time.Date(2025, time.March, 11, 0, 0, 0, 0, loc),
+	)
+	if len(hours) != 2 {
+		t.Errorf("tail window has %d hours, want 2", len(hours))
+	}
+}
+
+func TestNowHour(t *testing.T) {
+	forecast := dstDayForecast(t)
+	loc, _ := time.LoadLocation("America/Denver")
+
+	hour, ok := forecast.NowHour(time.Date(2025, time.March, 9, 12, 30, 0, 0, loc))
+	if !ok {
+		t.Fatal("NowHour found no entry for an in-range instant")
+	}
+	if !hour.Start.Equal(time.Date(2025, time.March, 9, 12, 0, 0, 0, loc)) {
+		t.Errorf("NowHour start = %v, want 12:00", hour.Start)
+	}
+
+	// An hour's Start is inside it, its End belongs to the next entry
+	hour, ok = forecast.NowHour(time.Date(2025, time.March, 9, 13, 0, 0, 0, loc))
+	if !ok || !hour.Start.Equal(time.Date(2025, time.March, 9, 13, 0, 0, 0, loc)) {
+		t.Error("NowHour at an exact boundary did not pick the starting hour")
+	}
+
+	// Before the partial first day begins there is no containing hour
+	if _, ok := forecast.NowHour(time.Date(2025, time.March, 8, 12, 0, 0, 0, loc)); ok {
+		t.Error("NowHour found an entry before the series begins")
+	}
+}
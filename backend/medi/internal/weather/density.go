@@ -0,0 +1,169 @@
+package weather
+
+// Snow density classes, from the 2m temperature while the snow falls. Colder
+// storms build lighter snow; snow near or above freezing comes in dense or
+// wet.
+const (
+	SnowDensityVeryLight = "very light"
+	SnowDensityLight     = "light"
+	SnowDensityModerate  = "moderate"
+	SnowDensityDense     = "dense"
+	SnowDensityWet       = "wet"
+)
+
+// Temperature boundaries between the density classes, in °F
+const (
+	snowDensityVeryLightMaxF = 10.0
+	snowDensityLightMaxF     = 20.0
+	snowDensityModerateMaxF  = 28.0
+	snowDensityDenseMaxF     = 32.0
+)
+
+// snowDensityMinStormHours is the fewest snowfall hours a model needs in a
+// day before the storm's density trend is judged
+const snowDensityMinStormHours = 3
+
+// upsideDownRankIncrease is how much the mean density rank must rise from the
+// first half of the storm to the second before the day is flagged; one full
+// class keeps hour-to-hour noise from flagging
+const upsideDownRankIncrease = 1.0
+
+// snowDensityClasses orders the classes from lightest to densest; the index
+// is the rank used for trend detection
+var snowDensityClasses = []string{
+	SnowDensityVeryLight,
+	SnowDensityLight,
+	SnowDensityModerate,
+	SnowDensityDense,
+	SnowDensityWet,
+}
+
+// classifySnowDensity buckets a snowfall hour's temperature into a density class
+func classifySnowDensity(temperatureF float64) string {
+	switch {
+	case temperatureF < snowDensityVeryLightMaxF:
+		return SnowDensityVeryLight
+	case temperatureF < snowDensityLightMaxF:
+		return SnowDensityLight
+	case temperatureF < snowDensityModerateMaxF:
+		return SnowDensityModerate
+	case temperatureF <= snowDensityDenseMaxF:
+		return SnowDensityDense
+	default:
+		return SnowDensityWet
+	}
+}
+
+func snowDensityRank(class string) int {
+	for rank, candidate := range snowDensityClasses {
+		if candidate == class {
+			return rank
+		}
+	}
+	return 0
+}
+
+// applySnowDensity classifies each snowfall hour's density from the model's
+// temperature, rolls up a snowfall-weighted dominant class per day, and flags
+// upside-down storms — density increasing through the storm — which bury the
+// light snow under a cohesive slab. Days with an upside-down consensus get a
+// sentence appended to the summary.
+func applySnowDensity(forecast *Forecast) {
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+
+		dominant := ModelValues[string]{}
+		upsideDown := ModelValues[bool]{}
+		modelsWithStorms := 0
+
+		for _, model := range snowfallModels(day.HourlyForecasts) {
+			// weight accumulates snowfall inches per class; ranks is the
+			// storm's class sequence in hour order
+			weight := map[string]float64{}
+			var ranks []int
+
+			for hi := range day.HourlyForecasts {
+				hour := &day.HourlyForecasts[hi]
+				snowfall, ok := hour.Snowfall.GetForModel(model)
+				if !ok || snowfall.Inches <= 0 {
+					continue
+				}
+				temperature, ok := hour.Temperature.GetForModel(model)
+				if !ok {
+					continue
+				}
+
+				class := classifySnowDensity(temperature.Fahrenheit)
+				if hour.SnowDensity == nil {
+					hour.SnowDensity = ModelValues[string]{}
+				}
+				hour.SnowDensity[model] = class
+				weight[class] += snowfall.Inches
+				ranks = append(ranks, snowDensityRank(class))
+			}
+
+			if len(ranks) == 0 {
+				continue
+			}
+
+			// The class with the most snowfall dominates; ties resolve toward
+			// the denser class
+			best := ""
+			bestWeight := 0.0
+			for _, class := range snowDensityClasses {
+				if w := weight[class]; w >= bestWeight && w > 0 {
+					best = class
+					bestWeight = w
+				}
+			}
+			dominant[model] = best
+
+			if len(ranks) >= snowDensityMinStormHours {
+				modelsWithStorms++
+				if isUpsideDownStorm(ranks) {
+					upsideDown[model] = true
+				}
+			}
+		}
+
+		if len(dominant) > 0 {
+			day.DominantSnowDensity = dominant
+		}
+		if len(upsideDown) > 0 {
+			day.UpsideDownStorm = upsideDown
+		}
+		// Consensus requires a strict majority of the models with a storm
+		// long enough to judge
+		day.UpsideDownStormConsensus = modelsWithStorms > 0 && len(upsideDown)*2 > modelsWithStorms
+		if day.UpsideDownStormConsensus {
+			summary := "Snow is expected to become denser as the storm progresses (upside-down storm)."
+			if day.SnowfallSummary != "" {
+				summary = day.SnowfallSummary + " " + summary
+			}
+			day.SnowfallSummary = summary
+		}
+	}
+}
+
+// snowfallModels collects the models carrying hourly snowfall data for the day
+func snowfallModels(hours []HourlyForecast) []string {
+	if len(hours) == 0 {
+		return nil
+	}
+	return hours[0].Snowfall.Models()
+}
+
+// isUpsideDownStorm reports whether the storm's density trends upward: the
+// mean class rank of the later half exceeds the earlier half's by a full class
+func isUpsideDownStorm(ranks []int) bool {
+	half := len(ranks) / 2
+	return meanRank(ranks[half:])-meanRank(ranks[:half]) >= upsideDownRankIncrease
+}
+
+func meanRank(ranks []int) float64 {
+	total := 0
+	for _, rank := range ranks {
+		total += rank
+	}
+	return float64(total) / float64(len(ranks))
+}
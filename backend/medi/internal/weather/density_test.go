@@ -0,0 +1,129 @@
+package weather
+
+import (
+	"testing"
+
+	"medi/internal/types"
+)
+
+func TestClassifySnowDensity(t *testing.T) {
+	tests := []struct {
+		temperatureF float64
+		want         string
+	}{
+		{5, SnowDensityVeryLight},
+		{9.9, SnowDensityVeryLight},
+		{10, SnowDensityLight},
+		{19.9, SnowDensityLight},
+		{20, SnowDensityModerate},
+		{27.9, SnowDensityModerate},
+		{28, SnowDensityDense},
+		{32, SnowDensityDense},
+		{32.1, SnowDensityWet},
+	}
+
+	for _, tt := range tests {
+		if got := classifySnowDensity(tt.temperatureF); got != tt.want {
+			t.Errorf("classifySnowDensity(%v) = %s, want %s", tt.temperatureF, got, tt.want)
+		}
+	}
+}
+
+// buildDensityForecast builds a one-day forecast where one model drops an
+// inch of snow per hour at the given temperatures, followed by a snowless
+// hour
+func buildDensityForecast(temperaturesF []float64) *Forecast {
+	var hours []HourlyForecast
+	for _, temperatureF := range temperaturesF {
+		hours = append(hours, HourlyForecast{
+			Temperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(temperatureF),
+			},
+			Snowfall: ModelValues[types.Precipitation]{
+				ModelGfsSeamless: types.NewPrecipitationFromInches(1),
+			},
+		})
+	}
+	hours = append(hours, HourlyForecast{
+		Temperature: ModelValues[types.Temperature]{
+			ModelGfsSeamless: types.NewTemperatureFromFahrenheit(35),
+		},
+		Snowfall: ModelValues[types.Precipitation]{
+			ModelGfsSeamless: types.NewPrecipitationFromInches(0),
+		},
+	})
+
+	return &Forecast{DailyForecasts: []DailyForecast{{HourlyForecasts: hours}}}
+}
+
+func TestApplySnowDensityColdToWarmStormIsUpsideDown(t *testing.T) {
+	// A classic upside-down setup: light cold snow turning dense and wet
+	forecast := buildDensityForecast([]float64{12, 14, 18, 26, 30, 33})
+	applySnowDensity(forecast)
+
+	day := forecast.DailyForecasts[0]
+
+	wantClasses := []string{
+		SnowDensityLight, SnowDensityLight, SnowDensityLight,
+		SnowDensityModerate, SnowDensityDense, SnowDensityWet,
+	}
+	for i, want := range wantClasses {
+		if got := day.HourlyForecasts[i].SnowDensity[ModelGfsSeamless]; got != want {
+			t.Errorf("hour %d SnowDensity = %s, want %s", i, got, want)
+		}
+	}
+	// The snowless hour carries no class
+	if day.HourlyForecasts[6].SnowDensity != nil {
+		t.Errorf("snowless hour SnowDensity = %+v, want nil", day.HourlyForecasts[6].SnowDensity)
+	}
+
+	// Three of six inches fell as light snow, so light dominates
+	if got := day.DominantSnowDensity[ModelGfsSeamless]; got != SnowDensityLight {
+		t.Errorf("DominantSnowDensity = %s, want %s", got, SnowDensityLight)
+	}
+
+	if !day.UpsideDownStorm[ModelGfsSeamless] {
+		t.Error("expected the cold-to-warm storm to be flagged upside-down")
+	}
+	if !day.UpsideDownStormConsensus {
+		t.Error("expected an upside-down consensus with the only model flagged")
+	}
+	if day.SnowfallSummary == "" {
+		t.Error("expected the summary to mention the upside-down storm")
+	}
+}
+
+func TestApplySnowDensityWarmToColdStormIsNotFlagged(t *testing.T) {
+	forecast := buildDensityForecast([]float64{33, 30, 26, 18, 14, 12})
+	applySnowDensity(forecast)
+
+	day := forecast.DailyForecasts[0]
+	if day.UpsideDownStorm != nil {
+		t.Errorf("UpsideDownStorm = %+v, want nil for a warm-to-cold storm", day.UpsideDownStorm)
+	}
+	if day.UpsideDownStormConsensus {
+		t.Error("warm-to-cold storm should not reach an upside-down consensus")
+	}
+	// The density classes themselves are still attached
+	if got := day.DominantSnowDensity[ModelGfsSeamless]; got != SnowDensityLight {
+		t.Errorf("DominantSnowDensity = %s, want %s", got, SnowDensityLight)
+	}
+}
+
+func TestApplySnowDensitySkipsShortAndSnowlessDays(t *testing.T) {
+	// Two snowfall hours are too short a storm to judge a trend
+	short := buildDensityForecast([]float64{12, 33})
+	applySnowDensity(short)
+	if short.DailyForecasts[0].UpsideDownStorm != nil {
+		t.Error("a two-hour storm should not be judged for density trend")
+	}
+	if short.DailyForecasts[0].DominantSnowDensity == nil {
+		t.Error("even a short storm should carry a dominant density class")
+	}
+
+	snowless := buildDensityForecast(nil)
+	applySnowDensity(snowless)
+	if snowless.DailyForecasts[0].DominantSnowDensity != nil {
+		t.Error("a snowless day should carry no dominant density class")
+	}
+}
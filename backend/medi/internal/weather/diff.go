@@ -0,0 +1,230 @@
+package weather
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"medi/internal/types"
+)
+
+// diffEpsilon is the smallest change worth reporting; anything below it is
+// float noise from re-serialized snapshots.
+const diffEpsilon = 0.01
+
+// ForecastDiff describes how a forecast for one location changed between two
+// snapshots, typically successive upstream model runs.
+type ForecastDiff struct {
+	OldTimestamp time.Time
+	NewTimestamp time.Time
+	Timezone     string
+	Days         []DayDiff
+}
+
+// DayDiff lists the daily fields that changed for one calendar day. A day
+// present in only one snapshot has the matching flag set and every model
+// reported as added or removed.
+type DayDiff struct {
+	Date      time.Time
+	OnlyInOld bool
+	OnlyInNew bool
+	Fields    []FieldDiff
+}
+
+// FieldDiff compares the ensemble median of one daily field and records the
+// per-model changes behind it.
+type FieldDiff struct {
+	Field     string
+	Unit      string
+	OldMedian float64
+	NewMedian float64
+	Delta     float64
+	Models    []ModelDelta
+}
+
+// ModelDelta is one model's contribution to a field change. InOld and InNew
+// mark models that appeared in or dropped out of the ensemble.
+type ModelDelta struct {
+	Model string
+	InOld bool
+	InNew bool
+	Old   float64
+	New   float64
+}
+
+// dailyDiffFields enumerates the daily ensemble values the diff covers, each
+// reduced to a single float per model.
+var dailyDiffFields = []struct {
+	name   string
+	unit   string
+	values func(*DailyForecast) ModelValues[float64]
+}{
+	{"snowfall", "in", func(d *DailyForecast) ModelValues[float64] {
+		return precipitationInches(d.TotalSnowfall)
+	}},
+	{"precipitation", "in", func(d *DailyForecast) ModelValues[float64] {
+		return precipitationInches(d.TotalPrecipitation)
+	}},
+	{"high temperature", "F", func(d *DailyForecast) ModelValues[float64] {
+		return temperatureFahrenheit(d.HighTemperature)
+	}},
+	{"low temperature", "F", func(d *DailyForecast) ModelValues[float64] {
+		return temperatureFahrenheit(d.LowTemperature)
+	}},
+	{"max wind", "mph", func(d *DailyForecast) ModelValues[float64] {
+		return windSpeedMph(d.MaxWindSpeed)
+	}},
+	{"max gusts", "mph", func(d *DailyForecast) ModelValues[float64] {
+		return windSpeedMph(d.MaxWindGusts)
+	}},
+}
+
+// DiffForecasts compares two forecasts for the same location day by day and
+// returns the daily ensemble fields whose median or per-model values changed.
+// Days are matched by calendar date in the newer forecast's timezone, so a
+// later snapshot that rolls the window forward reports dropped and added days
+// rather than misaligned ones.
+func DiffForecasts(oldForecast, newForecast *Forecast) *ForecastDiff {
+	diff := &ForecastDiff{
+		OldTimestamp: oldForecast.Timestamp,
+		NewTimestamp: newForecast.Timestamp,
+		Timezone:     newForecast.Timezone,
+	}
+	loc := newForecast.location()
+
+	type dayPair struct {
+		old *DailyForecast
+		new *DailyForecast
+	}
+	days := map[string]*dayPair{}
+	keys := []string{}
+	pairFor := func(ts time.Time) *dayPair {
+		key := ts.In(loc).Format(time.DateOnly)
+		if pair, ok := days[key]; ok {
+			return pair
+		}
+		pair := &dayPair{}
+		days[key] = pair
+		keys = append(keys, key)
+		return pair
+	}
+	for i := range oldForecast.DailyForecasts {
+		pairFor(oldForecast.DailyForecasts[i].Timestamp).old = &oldForecast.DailyForecasts[i]
+	}
+	for i := range newForecast.DailyForecasts {
+		pairFor(newForecast.DailyForecasts[i].Timestamp).new = &newForecast.DailyForecasts[i]
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		pair := days[key]
+		dayDiff := DayDiff{
+			OnlyInOld: pair.new == nil,
+			OnlyInNew: pair.old == nil,
+		}
+		switch {
+		case pair.new != nil:
+			dayDiff.Date = pair.new.Timestamp
+		default:
+			dayDiff.Date = pair.old.Timestamp
+		}
+
+		for _, field := range dailyDiffFields {
+			var oldValues, newValues ModelValues[float64]
+			if pair.old != nil {
+				oldValues = field.values(pair.old)
+			}
+			if pair.new != nil {
+				newValues = field.values(pair.new)
+			}
+			if fieldDiff, changed := diffField(field.name, field.unit, oldValues, newValues); changed {
+				dayDiff.Fields = append(dayDiff.Fields, fieldDiff)
+			}
+		}
+
+		if len(dayDiff.Fields) > 0 || dayDiff.OnlyInOld || dayDiff.OnlyInNew {
+			diff.Days = append(diff.Days, dayDiff)
+		}
+	}
+
+	return diff
+}
+
+// diffField compares one daily field across the two ensembles. The second
+// return is false when no model moved by more than diffEpsilon and the
+// ensemble membership is unchanged.
+func diffField(name, unit string, oldValues, newValues ModelValues[float64]) (FieldDiff, bool) {
+	union := ModelValues[float64]{}
+	union.Merge(oldValues)
+	union.Merge(newValues)
+
+	fieldDiff := FieldDiff{
+		Field:     name,
+		Unit:      unit,
+		OldMedian: medianValue(oldValues),
+		NewMedian: medianValue(newValues),
+	}
+	fieldDiff.Delta = fieldDiff.NewMedian - fieldDiff.OldMedian
+
+	for _, model := range union.OrderedModels() {
+		oldValue, inOld := oldValues[model]
+		newValue, inNew := newValues[model]
+		if inOld && inNew && math.Abs(newValue-oldValue) <= diffEpsilon {
+			continue
+		}
+		fieldDiff.Models = append(fieldDiff.Models, ModelDelta{
+			Model: model,
+			InOld: inOld,
+			InNew: inNew,
+			Old:   oldValue,
+			New:   newValue,
+		})
+	}
+
+	if len(fieldDiff.Models) == 0 && math.Abs(fieldDiff.Delta) <= diffEpsilon {
+		return FieldDiff{}, false
+	}
+	return fieldDiff, true
+}
+
+// medianValue returns the median across models, zero for an empty ensemble.
+func medianValue(values ModelValues[float64]) float64 {
+	sorted := make([]float64, 0, len(values))
+	for _, value := range values {
+		sorted = append(sorted, value)
+	}
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func precipitationInches(values ModelValues[types.Precipitation]) ModelValues[float64] {
+	inches := make(ModelValues[float64], len(values))
+	for model, value := range values {
+		inches[model] = value.Inches
+	}
+	return inches
+}
+
+func temperatureFahrenheit(values ModelValues[types.Temperature]) ModelValues[float64] {
+	fahrenheit := make(ModelValues[float64], len(values))
+	for model, value := range values {
+		fahrenheit[model] = value.Fahrenheit
+	}
+	return fahrenheit
+}
+
+func windSpeedMph(values ModelValues[types.WindSpeed]) ModelValues[float64] {
+	mph := make(ModelValues[float64], len(values))
+	for model, value := range values {
+		mph[model] = value.Mph
+	}
+	return mph
+}
@@ -0,0 +1,116 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// diffTestForecast builds a Denver forecast with one daily snowfall ensemble
+// per listed day.
+func diffTestForecast(timestamp time.Time, snowfallByDay map[string]ModelValues[float64]) *Forecast {
+	loc, _ := time.LoadLocation(TimezoneDenver)
+	forecast := &Forecast{
+		Timestamp: timestamp,
+		Timezone:  TimezoneDenver,
+	}
+
+	days := make([]string, 0, len(snowfallByDay))
+	for day := range snowfallByDay {
+		days = append(days, day)
+	}
+	// Map order does not matter; DiffForecasts sorts by date
+	for _, day := range days {
+		date, _ := time.ParseInLocation(time.DateOnly, day, loc)
+		snowfall := ModelValues[types.Precipitation]{}
+		for model, inches := range snowfallByDay[day] {
+			snowfall[model] = types.NewPrecipitationFromInches(inches)
+		}
+		forecast.DailyForecasts = append(forecast.DailyForecasts, DailyForecast{
+			Timestamp:     date,
+			TotalSnowfall: snowfall,
+		})
+	}
+	return forecast
+}
+
+func TestDiffForecastsDayMatching(t *testing.T) {
+	older := diffTestForecast(time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC),
+		map[string]ModelValues[float64]{
+			"2025-01-15": {ModelGfsSeamless: 1.0},
+			"2025-01-16": {ModelGfsSeamless: 2.0},
+		})
+	newer := diffTestForecast(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+		map[string]ModelValues[float64]{
+			"2025-01-16": {ModelGfsSeamless: 4.0},
+			"2025-01-17": {ModelGfsSeamless: 0.5},
+		})
+
+	diff := DiffForecasts(older, newer)
+	if len(diff.Days) != 3 {
+		t.Fatalf("got %d day diffs, want 3", len(diff.Days))
+	}
+
+	if !diff.Days[0].OnlyInOld {
+		t.Error("Jan 15 not flagged as dropped")
+	}
+	if diff.Days[1].OnlyInOld || diff.Days[1].OnlyInNew {
+		t.Error("Jan 16 flagged as one-sided despite matching both forecasts")
+	}
+	if !diff.Days[2].OnlyInNew {
+		t.Error("Jan 17 not flagged as new")
+	}
+
+	// The matched day reports the snowfall move
+	fields := diff.Days[1].Fields
+	if len(fields) != 1 || fields[0].Field != "snowfall" {
+		t.Fatalf("Jan 16 fields = %+v, want one snowfall diff", fields)
+	}
+	if fields[0].Delta != 2.0 {
+		t.Errorf("snowfall median delta = %v, want 2.0", fields[0].Delta)
+	}
+}
+
+func TestDiffForecastsSuppressesNoise(t *testing.T) {
+	older := diffTestForecast(time.Now(), map[string]ModelValues[float64]{
+		"2025-01-16": {ModelGfsSeamless: 2.0, ModelEcmwIfs: 3.0},
+	})
+	newer := diffTestForecast(time.Now(), map[string]ModelValues[float64]{
+		"2025-01-16": {ModelGfsSeamless: 2.005, ModelEcmwIfs: 3.0},
+	})
+
+	diff := DiffForecasts(older, newer)
+	if len(diff.Days) != 0 {
+		t.Errorf("sub-epsilon change produced %d day diffs, want none", len(diff.Days))
+	}
+}
+
+func TestDiffForecastsPerModelChanges(t *testing.T) {
+	older := diffTestForecast(time.Now(), map[string]ModelValues[float64]{
+		"2025-01-16": {ModelGfsSeamless: 2.0, ModelEcmwIfs: 3.0, ModelMetNo: 2.5},
+	})
+	newer := diffTestForecast(time.Now(), map[string]ModelValues[float64]{
+		"2025-01-16": {ModelGfsSeamless: 5.0, ModelEcmwIfs: 3.0, ModelGemSeamless: 4.0},
+	})
+
+	diff := DiffForecasts(older, newer)
+	if len(diff.Days) != 1 || len(diff.Days[0].Fields) != 1 {
+		t.Fatalf("diff = %+v, want one day with one field", diff.Days)
+	}
+
+	// Unchanged EcmwIfs is omitted; the rest follow canonical model order
+	models := diff.Days[0].Fields[0].Models
+	if len(models) != 3 {
+		t.Fatalf("got %d model deltas, want 3", len(models))
+	}
+	if models[0].Model != ModelGfsSeamless || !models[0].InOld || !models[0].InNew {
+		t.Errorf("first delta = %+v, want changed GfsSeamless", models[0])
+	}
+	if models[1].Model != ModelGemSeamless || models[1].InOld || !models[1].InNew {
+		t.Errorf("second delta = %+v, want added GemSeamless", models[1])
+	}
+	if models[2].Model != ModelMetNo || !models[2].InOld || models[2].InNew {
+		t.Errorf("third delta = %+v, want removed MetNo", models[2])
+	}
+}
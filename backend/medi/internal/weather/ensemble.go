@@ -0,0 +1,261 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"medi/internal/logging"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/timing"
+	"medi/internal/types"
+)
+
+// EnsembleProvider fetches individual ensemble member runs for a point, nil
+// when the deployment has no ensemble upstream
+type EnsembleProvider interface {
+	GetEnsemble(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.EnsembleAPIResponse, error)
+}
+
+// ErrEnsembleUnavailable is returned when a probabilistic forecast is
+// requested but no ensemble provider is configured
+var ErrEnsembleUnavailable = errors.New("no ensemble provider configured")
+
+// Exceedance thresholds for daily snowfall totals, in inches
+const (
+	exceedanceLightInches    = 3
+	exceedanceModerateInches = 6
+	exceedanceHeavyInches    = 12
+)
+
+// PercentileBand summarizes the spread of the ensemble members at one point
+// in time: the 10th through 90th percentiles of the member values, in inches.
+type PercentileBand struct {
+	P10 float64
+	P25 float64
+	P50 float64
+	P75 float64
+	P90 float64
+}
+
+// DailySnowfallOutlook holds one day's ensemble snowfall totals.
+type DailySnowfallOutlook struct {
+	Date time.Time
+	// Members counts the members contributing to this day; a member must
+	// cover every hour of the day to avoid undercounting its total
+	Members int
+	// TotalInches bands the member daily totals
+	TotalInches PercentileBand
+	// Exceedance is the probability-of-exceedance table over the totals
+	Exceedance ExceedanceProbabilities
+}
+
+// ExceedanceProbabilities gives the fraction of ensemble members whose daily
+// snowfall total exceeds each threshold.
+type ExceedanceProbabilities struct {
+	Above3In  float64
+	Above6In  float64
+	Above12In float64
+}
+
+// ProbabilisticSnowfall carries snowfall percentile bands computed across
+// individual ensemble member runs, rather than across the deterministic
+// models the rest of the forecast uses.
+type ProbabilisticSnowfall struct {
+	// Members counts the member series that reported any snowfall data
+	Members int
+	// Times is the hour-start axis the hourly bands align with
+	Times []time.Time
+	// Hourly bands the per-hour snowfall rates, in inches; the series stops
+	// at the last hour any member covers
+	Hourly []PercentileBand
+	// Daily bands the per-day totals; partial days at either end of the
+	// hourly axis are dropped rather than reported low
+	Daily []DailySnowfallOutlook
+}
+
+// GetProbabilisticSnowfall fetches the ensemble members for the point and
+// reduces them to percentile bands and exceedance probabilities
+func (s *weatherService) GetProbabilisticSnowfall(ctx context.Context, forecastPoint types.ForecastPoint) (*ProbabilisticSnowfall, error) {
+	if s.ensembleProvider == nil {
+		return nil, ErrEnsembleUnavailable
+	}
+
+	cfg := s.cfg.Current()
+
+	tz, err := s.timezoneService.GetTimezone(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude)
+	if err != nil {
+		s.logger.Error("failed to determine timezone",
+			logging.Coords(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude),
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to determine timezone: %w", err)
+	}
+
+	providerSpan := timing.FromContext(ctx).StartSpan("openmeteo.ensemble")
+	apiResponse, err := s.ensembleProvider.GetEnsemble(
+		ctx,
+		forecastPoint.Coordinates.Latitude,
+		forecastPoint.Coordinates.Longitude,
+		forecastPoint.Elevation.Meters,
+		cfg.App.ForecastDays,
+		tz,
+	)
+	providerSpan()
+	if err != nil {
+		s.logger.Error("failed to get ensemble from provider", "error", err)
+		return nil, fmt.Errorf("failed to get ensemble: %w", err)
+	}
+
+	return mapEnsembleAPIResponseToProbabilisticSnowfall(apiResponse)
+}
+
+// mapEnsembleAPIResponseToProbabilisticSnowfall reduces the raw member
+// series to the probabilistic block. Member arrays are ragged: a member may
+// be shorter than the time axis or carry null hours, so every aggregate is
+// computed over the members that actually have data at that point.
+func mapEnsembleAPIResponseToProbabilisticSnowfall(apiResponse *openmeteo.EnsembleAPIResponse) (*ProbabilisticSnowfall, error) {
+	location, err := time.LoadLocation(apiResponse.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone location %s: %w", apiResponse.Timezone, err)
+	}
+
+	times := make([]time.Time, 0, len(apiResponse.Hourly.Time))
+	for _, t := range apiResponse.Hourly.Time {
+		parsedTime, err := time.ParseInLocation("2006-01-02T15:04", t, location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse hourly timestamp %q: %w", t, err)
+		}
+		times = append(times, parsedTime)
+	}
+
+	// Fix the member iteration order so percentile ties resolve the same way
+	// on every run
+	members := make([]string, 0, len(apiResponse.Hourly.Snowfall))
+	for member := range apiResponse.Hourly.Snowfall {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+
+	probabilistic := &ProbabilisticSnowfall{}
+	for _, member := range members {
+		for _, value := range apiResponse.Hourly.Snowfall[member] {
+			if value != nil {
+				probabilistic.Members++
+				break
+			}
+		}
+	}
+
+	// Hourly bands over whichever members have the hour; the axis ends at
+	// the last hour any member covers
+	for i, t := range times {
+		var values []float64
+		for _, member := range members {
+			series := apiResponse.Hourly.Snowfall[member]
+			if i < len(series) && series[i] != nil {
+				values = append(values, *series[i])
+			}
+		}
+		if len(values) == 0 {
+			break
+		}
+		probabilistic.Times = append(probabilistic.Times, t)
+		probabilistic.Hourly = append(probabilistic.Hourly, percentileBand(values))
+	}
+
+	probabilistic.Daily = dailySnowfallOutlooks(times, members, apiResponse.Hourly.Snowfall)
+
+	return probabilistic, nil
+}
+
+// dailySnowfallOutlooks totals the member series per local calendar day.
+// Only complete days count: a day whose axis hours do not run midnight
+// through 23:00 is dropped, and a member contributes only when it covers
+// every hour of the day, so a short or gappy member cannot drag the totals
+// down.
+func dailySnowfallOutlooks(times []time.Time, members []string, series map[string][]*float64) []DailySnowfallOutlook {
+	type dayIndices struct {
+		date  time.Time
+		first int
+		last  int
+	}
+	var days []dayIndices
+	for i, t := range times {
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		if len(days) == 0 || !days[len(days)-1].date.Equal(date) {
+			days = append(days, dayIndices{date: date, first: i, last: i})
+			continue
+		}
+		days[len(days)-1].last = i
+	}
+
+	var outlooks []DailySnowfallOutlook
+	for _, day := range days {
+		if times[day.first].Hour() != 0 || times[day.last].Hour() != 23 {
+			continue
+		}
+
+		var totals []float64
+		for _, member := range members {
+			memberSeries := series[member]
+			total := 0.0
+			complete := day.last < len(memberSeries)
+			for i := day.first; complete && i <= day.last; i++ {
+				if memberSeries[i] == nil {
+					complete = false
+					break
+				}
+				total += *memberSeries[i]
+			}
+			if complete {
+				totals = append(totals, total)
+			}
+		}
+		if len(totals) == 0 {
+			continue
+		}
+
+		outlooks = append(outlooks, DailySnowfallOutlook{
+			Date:        day.date,
+			Members:     len(totals),
+			TotalInches: percentileBand(totals),
+			Exceedance: ExceedanceProbabilities{
+				Above3In:  exceedanceFraction(totals, exceedanceLightInches),
+				Above6In:  exceedanceFraction(totals, exceedanceModerateInches),
+				Above12In: exceedanceFraction(totals, exceedanceHeavyInches),
+			},
+		})
+	}
+	return outlooks
+}
+
+// percentileBand sorts a copy of the values and interpolates the five
+// standard quantiles
+func percentileBand(values []float64) PercentileBand {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return PercentileBand{
+		P10: percentile(sorted, 0.10),
+		P25: percentile(sorted, 0.25),
+		P50: percentile(sorted, 0.50),
+		P75: percentile(sorted, 0.75),
+		P90: percentile(sorted, 0.90),
+	}
+}
+
+// exceedanceFraction is the fraction of values strictly above the threshold
+func exceedanceFraction(values []float64, threshold float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	exceeding := 0
+	for _, value := range values {
+		if value > threshold {
+			exceeding++
+		}
+	}
+	return float64(exceeding) / float64(len(values))
+}
@@ -0,0 +1,224 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/testsupport"
+	"medi/internal/types"
+)
+
+// memberSeries builds one member's hourly values; NaN marks a null hour
+func memberSeries(values ...float64) []*float64 {
+	series := make([]*float64, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		value := v
+		series[i] = &value
+	}
+	return series
+}
+
+// flatSeries builds a member running at a constant hourly rate
+func flatSeries(rate float64, hours int) []*float64 {
+	series := make([]*float64, hours)
+	for i := range series {
+		value := rate
+		series[i] = &value
+	}
+	return series
+}
+
+// hourlyAxis builds the API's iso8601 hour strings from a start time
+func hourlyAxis(start time.Time, hours int) []string {
+	axis := make([]string, hours)
+	for i := range axis {
+		axis[i] = start.Add(time.Duration(i) * time.Hour).Format("2006-01-02T15:04")
+	}
+	return axis
+}
+
+func ensembleResponse(times []string, snowfall map[string][]*float64) *openmeteo.EnsembleAPIResponse {
+	return &openmeteo.EnsembleAPIResponse{
+		Timezone: "UTC",
+		Hourly: openmeteo.EnsembleHourly{
+			Time:     times,
+			Snowfall: snowfall,
+		},
+	}
+}
+
+func TestPercentileBandKnownQuantiles(t *testing.T) {
+	// Unsorted input: the band sorts a copy before interpolating
+	band := percentileBand([]float64{5, 1, 4, 2, 3})
+
+	expected := PercentileBand{P10: 1.4, P25: 2, P50: 3, P75: 4, P90: 4.6}
+	for _, q := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"P10", band.P10, expected.P10},
+		{"P25", band.P25, expected.P25},
+		{"P50", band.P50, expected.P50},
+		{"P75", band.P75, expected.P75},
+		{"P90", band.P90, expected.P90},
+	} {
+		if math.Abs(q.got-q.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", q.name, q.got, q.want)
+		}
+	}
+}
+
+func TestMapEnsembleHourlyBandsRaggedMembers(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	// Four axis hours, but the longest member stops after three: the bands
+	// end at the last hour any member covers. Member b has a null hour and
+	// member c is shorter still.
+	resp := ensembleResponse(hourlyAxis(start, 4), map[string][]*float64{
+		"snowfall_gfs_seamless":          memberSeries(1, 1, 1),
+		"snowfall_gfs_seamless_member01": memberSeries(2, math.NaN(), 2),
+		"snowfall_ecmwf_ifs025":          memberSeries(3),
+	})
+
+	probabilistic, err := mapEnsembleAPIResponseToProbabilisticSnowfall(resp)
+	if err != nil {
+		t.Fatalf("mapping returned error: %v", err)
+	}
+
+	if probabilistic.Members != 3 {
+		t.Errorf("Members = %d, want 3", probabilistic.Members)
+	}
+	if len(probabilistic.Times) != 3 || len(probabilistic.Hourly) != 3 {
+		t.Fatalf("got %d times and %d hourly bands, want 3 of each",
+			len(probabilistic.Times), len(probabilistic.Hourly))
+	}
+	if !probabilistic.Times[0].Equal(start) {
+		t.Errorf("Times[0] = %v, want %v", probabilistic.Times[0], start)
+	}
+
+	// Hour 0 has all three members, hour 1 only the first, hour 2 two
+	for i, want := range []float64{2, 1, 1.5} {
+		if got := probabilistic.Hourly[i].P50; math.Abs(got-want) > 1e-9 {
+			t.Errorf("Hourly[%d].P50 = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestMapEnsembleDailyTotalsAndExceedance(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	// Two full days plus three hours of a third: the partial day is dropped.
+	// On day two one member goes null and another runs out, so only the two
+	// constant members count toward its totals.
+	const hours = 51
+	short := flatSeries(0.6, 36)
+	gappy := flatSeries(0.3, hours)
+	gappy[30] = nil
+	resp := ensembleResponse(hourlyAxis(start, hours), map[string][]*float64{
+		"snowfall_gfs_seamless":          flatSeries(0.1, hours),
+		"snowfall_gfs_seamless_member01": flatSeries(0.2, hours),
+		"snowfall_gfs_seamless_member02": gappy,
+		"snowfall_ecmwf_ifs025":          short,
+	})
+
+	probabilistic, err := mapEnsembleAPIResponseToProbabilisticSnowfall(resp)
+	if err != nil {
+		t.Fatalf("mapping returned error: %v", err)
+	}
+
+	if len(probabilistic.Daily) != 2 {
+		t.Fatalf("got %d daily outlooks, want 2 with the partial day dropped", len(probabilistic.Daily))
+	}
+
+	// Day one totals over all four members: 2.4, 4.8, 7.2, 14.4 inches
+	day := probabilistic.Daily[0]
+	if !day.Date.Equal(start) {
+		t.Errorf("Daily[0].Date = %v, want %v", day.Date, start)
+	}
+	if day.Members != 4 {
+		t.Errorf("Daily[0].Members = %d, want 4", day.Members)
+	}
+	if got := day.TotalInches.P50; math.Abs(got-6.0) > 1e-9 {
+		t.Errorf("Daily[0].TotalInches.P50 = %v, want 6.0", got)
+	}
+	if day.Exceedance.Above3In != 0.75 || day.Exceedance.Above6In != 0.5 || day.Exceedance.Above12In != 0.25 {
+		t.Errorf("Daily[0].Exceedance = %+v, want 0.75/0.5/0.25", day.Exceedance)
+	}
+
+	// Day two drops the gappy and short members rather than undercounting
+	// their totals
+	day = probabilistic.Daily[1]
+	if day.Members != 2 {
+		t.Errorf("Daily[1].Members = %d, want 2", day.Members)
+	}
+	if got := day.TotalInches.P50; math.Abs(got-3.6) > 1e-9 {
+		t.Errorf("Daily[1].TotalInches.P50 = %v, want 3.6", got)
+	}
+	if day.Exceedance.Above3In != 0.5 {
+		t.Errorf("Daily[1].Exceedance.Above3In = %v, want 0.5", day.Exceedance.Above3In)
+	}
+}
+
+func TestMapEnsembleFixture(t *testing.T) {
+	probabilistic, err := mapEnsembleAPIResponseToProbabilisticSnowfall(testsupport.NewEnsembleFixture(t))
+	if err != nil {
+		t.Fatalf("mapping returned error: %v", err)
+	}
+
+	if probabilistic.Members != 6 {
+		t.Errorf("Members = %d, want 6", probabilistic.Members)
+	}
+	if len(probabilistic.Times) != 48 {
+		t.Fatalf("got %d hourly bands, want 48", len(probabilistic.Times))
+	}
+	if zone, _ := probabilistic.Times[0].Zone(); zone != "MST" {
+		t.Errorf("Times[0] zone = %q, want the fixture's MST", zone)
+	}
+
+	// Hour 0 carries all six members at 0.0-0.5 in/hr; hour 30 loses the
+	// null member and hour 40 the short one
+	if got := probabilistic.Hourly[0].P50; math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("Hourly[0].P50 = %v, want 0.25", got)
+	}
+	if got := probabilistic.Hourly[30].P50; math.Abs(got-0.15) > 1e-9 {
+		t.Errorf("Hourly[30].P50 = %v, want 0.15", got)
+	}
+	if got := probabilistic.Hourly[40].P50; math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("Hourly[40].P50 = %v, want 0.1", got)
+	}
+
+	if len(probabilistic.Daily) != 2 {
+		t.Fatalf("got %d daily outlooks, want 2", len(probabilistic.Daily))
+	}
+	day := probabilistic.Daily[0]
+	if day.Members != 6 {
+		t.Errorf("Daily[0].Members = %d, want all 6 on the complete day", day.Members)
+	}
+	if got := day.TotalInches.P50; math.Abs(got-6.0) > 1e-9 {
+		t.Errorf("Daily[0].TotalInches.P50 = %v, want 6.0", got)
+	}
+	if math.Abs(day.Exceedance.Above3In-4.0/6) > 1e-9 || day.Exceedance.Above6In != 0.5 || day.Exceedance.Above12In != 0 {
+		t.Errorf("Daily[0].Exceedance = %+v, want 4/6, 0.5, 0", day.Exceedance)
+	}
+	if got := probabilistic.Daily[1].Members; got != 4 {
+		t.Errorf("Daily[1].Members = %d, want 4 after the null and short members drop out", got)
+	}
+}
+
+func TestGetProbabilisticSnowfallWithoutProvider(t *testing.T) {
+	cfg := &config.Config{App: config.AppConfig{ForecastDays: 3}}
+	svc := NewWeatherServiceWithProvider(&failingForecastProvider{}, nil, nil, nil, nil, nil, &stubTimezoneService{}, config.NewHolder(cfg), nil)
+
+	_, err := svc.GetProbabilisticSnowfall(context.Background(), types.ForecastPoint{
+		Coordinates: types.NewCoords(39.1154, -107.6584),
+	})
+	if !errors.Is(err, ErrEnsembleUnavailable) {
+		t.Errorf("err = %v, want ErrEnsembleUnavailable", err)
+	}
+}
@@ -0,0 +1,66 @@
+package weather
+
+import "math"
+
+// Wind exposure labels a slope's relationship to the day's dominant wind:
+// windward slopes face into the wind and get scoured, lee slopes face away
+// and collect wind-deposited snow, and cross-loaded slopes sit sideways to
+// the flow.
+const (
+	WindExposureWindward    = "windward"
+	WindExposureLee         = "lee"
+	WindExposureCrossLoaded = "cross-loaded"
+)
+
+// minExposureSlopeDegrees is the slope below which the terrain is effectively
+// flat and wind exposure is not meaningful
+const minExposureSlopeDegrees = 5.0
+
+// applyWindExposure labels each day's wind exposure per model from the
+// point's terrain aspect and the model's dominant wind direction. Days keep a
+// nil map when the terrain is unknown or too flat to matter.
+func applyWindExposure(forecast *Forecast) {
+	aspect := forecast.ForecastPoint.TerrainAspect
+	if aspect == nil || aspect.IsVariable() || forecast.ForecastPoint.SlopeAngle < minExposureSlopeDegrees {
+		return
+	}
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+		if len(day.WindDominantDirection) == 0 {
+			continue
+		}
+
+		exposure := make(ModelValues[string], len(day.WindDominantDirection))
+		for model, direction := range day.WindDominantDirection {
+			// A variable direction cannot load a slope either way
+			if direction.IsVariable() {
+				continue
+			}
+			exposure[model] = classifyWindExposure(*aspect.Degrees, *direction.Degrees)
+		}
+		if len(exposure) == 0 {
+			continue
+		}
+		day.WindExposure = exposure
+	}
+}
+
+// classifyWindExposure labels a slope relative to the direction the wind
+// blows from: a slope whose aspect faces the wind source is windward, one
+// facing directly away is lee, and anything in between is cross-loaded.
+func classifyWindExposure(aspectDegrees, windFromDegrees float64) string {
+	delta := math.Abs(math.Mod(aspectDegrees-windFromDegrees, 360.0))
+	if delta > 180.0 {
+		delta = 360.0 - delta
+	}
+
+	switch {
+	case delta <= 45.0:
+		return WindExposureWindward
+	case delta >= 135.0:
+		return WindExposureLee
+	default:
+		return WindExposureCrossLoaded
+	}
+}
@@ -0,0 +1,98 @@
+package weather
+
+import (
+	"testing"
+
+	"medi/internal/types"
+)
+
+func TestClassifyWindExposure(t *testing.T) {
+	tests := []struct {
+		name          string
+		aspectDegrees float64
+		windDegrees   float64
+		want          string
+	}{
+		{"facing the wind", 90, 90, WindExposureWindward},
+		{"facing away from the wind", 90, 270, WindExposureLee},
+		{"sideways to the wind", 0, 90, WindExposureCrossLoaded},
+		{"windward across north wrap", 350, 10, WindExposureWindward},
+		{"lee across north wrap", 10, 190, WindExposureLee},
+		{"windward boundary", 0, 45, WindExposureWindward},
+		{"lee boundary", 0, 135, WindExposureLee},
+		{"cross-loaded just inside boundary", 0, 46, WindExposureCrossLoaded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyWindExposure(tt.aspectDegrees, tt.windDegrees); got != tt.want {
+				t.Errorf("classifyWindExposure(%v, %v) = %s, want %s", tt.aspectDegrees, tt.windDegrees, got, tt.want)
+			}
+		})
+	}
+}
+
+// buildExposureForecast builds a forecast on an east-facing slope with one
+// day whose dominant wind direction varies per model
+func buildExposureForecast(slopeAngle float64, withAspect bool) *Forecast {
+	point := types.ForecastPoint{SlopeAngle: slopeAngle}
+	if withAspect {
+		aspect := types.NewWindDirection(90)
+		point.TerrainAspect = &aspect
+	}
+
+	return &Forecast{
+		ForecastPoint: point,
+		DailyForecasts: []DailyForecast{
+			{
+				WindDominantDirection: ModelValues[types.WindDirection]{
+					ModelGfsSeamless:  types.NewWindDirection(90),  // east wind onto the slope
+					ModelGemSeamless:  types.NewWindDirection(270), // west wind over the ridge
+					ModelNcepNbmConus: types.NewWindDirection(0),   // north wind along the slope
+				},
+			},
+			{
+				// A day without dominant wind data keeps a nil map
+			},
+		},
+	}
+}
+
+func TestApplyWindExposure(t *testing.T) {
+	forecast := buildExposureForecast(25, true)
+	applyWindExposure(forecast)
+
+	exposure := forecast.DailyForecasts[0].WindExposure
+	want := ModelValues[string]{
+		ModelGfsSeamless:  WindExposureWindward,
+		ModelGemSeamless:  WindExposureLee,
+		ModelNcepNbmConus: WindExposureCrossLoaded,
+	}
+	if len(exposure) != len(want) {
+		t.Fatalf("WindExposure has %d models, want %d", len(exposure), len(want))
+	}
+	for model, label := range want {
+		if exposure[model] != label {
+			t.Errorf("WindExposure[%s] = %s, want %s", model, exposure[model], label)
+		}
+	}
+
+	if forecast.DailyForecasts[1].WindExposure != nil {
+		t.Error("expected no exposure for a day without dominant wind data")
+	}
+}
+
+func TestApplyWindExposureSkipsFlatAndUnknownTerrain(t *testing.T) {
+	// Below the slope threshold the labels would be noise
+	flat := buildExposureForecast(2, true)
+	applyWindExposure(flat)
+	if flat.DailyForecasts[0].WindExposure != nil {
+		t.Error("expected no exposure on near-flat terrain")
+	}
+
+	unknown := buildExposureForecast(25, false)
+	applyWindExposure(unknown)
+	if unknown.DailyForecasts[0].WindExposure != nil {
+		t.Error("expected no exposure without a terrain aspect")
+	}
+}
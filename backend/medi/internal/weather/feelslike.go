@@ -0,0 +1,53 @@
+package weather
+
+import "medi/internal/types"
+
+// Feels-like derivation. The selection rules live in types.NewFeelsLike; this
+// file feeds them each model's hourly inputs and reduces the hours to daily
+// extremes.
+
+// hourlyFeelsLike selects a feels-like value per model for one hour. Models
+// without an apparent temperature are omitted: the selection falls back to
+// apparent between the wind chill and heat index regimes, so a model missing
+// it cannot answer the whole range.
+func hourlyFeelsLike(hour HourlyForecast) ModelValues[types.FeelsLike] {
+	if len(hour.ApparentTemperature) == 0 {
+		return nil
+	}
+	feelsLike := make(ModelValues[types.FeelsLike], len(hour.ApparentTemperature))
+	for model, apparent := range hour.ApparentTemperature {
+		temperature, ok := hour.Temperature[model]
+		if !ok {
+			continue
+		}
+		windSpeedMph := 0.0
+		if wind, windOk := hour.Wind[model]; windOk {
+			windSpeedMph = wind.Speed.Mph
+		}
+		// Stored humidity is a 0-1 fraction; the formula wants percent
+		humidityPercent := hour.RelativeHumidity[model] * 100
+		feelsLike[model] = types.NewFeelsLike(temperature.Fahrenheit, windSpeedMph, humidityPercent, apparent)
+	}
+	return feelsLike
+}
+
+// dailyFeelsLikeRange reduces a day's hourly feels-like values to per-model
+// min and max; models absent from every hour are omitted
+func dailyFeelsLikeRange(hours []HourlyForecast) (minValues, maxValues ModelValues[types.FeelsLike]) {
+	minValues = ModelValues[types.FeelsLike]{}
+	maxValues = ModelValues[types.FeelsLike]{}
+	for _, hour := range hours {
+		for model, value := range hour.FeelsLike {
+			if current, ok := minValues[model]; !ok || value.Temperature.Fahrenheit < current.Temperature.Fahrenheit {
+				minValues[model] = value
+			}
+			if current, ok := maxValues[model]; !ok || value.Temperature.Fahrenheit > current.Temperature.Fahrenheit {
+				maxValues[model] = value
+			}
+		}
+	}
+	if len(minValues) == 0 {
+		return nil, nil
+	}
+	return minValues, maxValues
+}
@@ -0,0 +1,187 @@
+package weather
+
+import (
+	"time"
+
+	"medi/internal/astro"
+)
+
+// Fallback thresholds for the first-tracks window, used when the resolved
+// profile leaves them unset. Snowfall at or above the rate counts as the
+// storm still running; gusts above the cap close the window. The warming
+// ceiling is configurable (app.firstTracksMaxTempF).
+const (
+	firstTracksMinSnowRateInHr = 0.1
+	firstTracksMaxGustMph      = 25.0
+	defaultFirstTracksMaxTempF = 35.0
+)
+
+// Window end reasons for FirstTracksWindow.EndReason.
+const (
+	FirstTracksEndWind    = "wind"
+	FirstTracksEndWarming = "warming"
+	FirstTracksEndSunset  = "sunset"
+)
+
+// FirstTracksWindow recommends when the morning's fresh snow rides best:
+// after the overnight storm tapers, before wind or warming degrade the
+// surface, and within daylight.
+type FirstTracksWindow struct {
+	Start time.Time
+	End   time.Time
+	// EndReason names what closes the window: wind, warming, or sunset
+	EndReason string
+}
+
+// applyFirstTracks attaches a first-tracks window to each daily forecast
+// whose preceding night brought fresh snow. Per model, the window opens at
+// sunrise or when the storm tapers below the profile's significant rate,
+// whichever is later, and closes at the first hour with gusts over the
+// profile's cap or a temperature above maxTempF, or at sunset.
+func applyFirstTracks(forecast *Forecast, maxTempF float64, thresholds Thresholds) {
+	if maxTempF <= 0 {
+		maxTempF = defaultFirstTracksMaxTempF
+	}
+	if thresholds.StormMinRateInHr <= 0 {
+		thresholds.StormMinRateInHr = firstTracksMinSnowRateInHr
+	}
+	if thresholds.WindPenaltyGustMph <= 0 {
+		thresholds.WindPenaltyGustMph = firstTracksMaxGustMph
+	}
+	latitude := forecast.ForecastPoint.Coordinates.Latitude
+	longitude := forecast.ForecastPoint.Coordinates.Longitude
+
+	for i := 1; i < len(forecast.DailyForecasts); i++ {
+		day := &forecast.DailyForecasts[i]
+		prevSun := astro.Sun(forecast.DailyForecasts[i-1].Timestamp, latitude, longitude)
+		sun := astro.Sun(day.Timestamp, latitude, longitude)
+		if prevSun.Set == nil || sun.Rise == nil || sun.Set == nil {
+			// Polar day or night: no overnight/daylight split to reason about
+			continue
+		}
+
+		night := forecast.HoursBetween(*prevSun.Set, *sun.Rise)
+		daylight := forecast.HoursBetween(*sun.Rise, *sun.Set)
+		if len(night) == 0 || len(daylight) == 0 {
+			continue
+		}
+
+		models := overnightSnowfallModels(night)
+		for _, model := range models {
+			window, ok := firstTracksForModel(model, night, daylight, *sun.Rise, *sun.Set, maxTempF, thresholds)
+			if !ok {
+				continue
+			}
+			// Sunset can fall past the hourly data on the final day; a window
+			// clamped down to nothing is dropped
+			window.End = forecast.ClampToHorizon(window.End)
+			if !window.End.After(window.Start) {
+				continue
+			}
+			if day.FirstTracks == nil {
+				day.FirstTracks = ModelValues[FirstTracksWindow]{}
+			}
+			day.FirstTracks[model] = window
+		}
+		day.FirstTracksConsensus = firstTracksConsensus(day.FirstTracks, len(models))
+	}
+}
+
+// overnightSnowfallModels lists the models reporting snowfall data for any
+// of the night's hours, in canonical order
+func overnightSnowfallModels(hours []HourlyForecast) []string {
+	union := ModelValues[bool]{}
+	for _, hour := range hours {
+		for _, model := range hour.Snowfall.Models() {
+			union[model] = true
+		}
+	}
+	return union.OrderedModels()
+}
+
+// firstTracksForModel computes one model's window, reporting false when the
+// night brought no fresh snow or the conditions leave no usable period
+func firstTracksForModel(model string, night, daylight []HourlyForecast, sunrise, sunset time.Time, maxTempF float64, thresholds Thresholds) (FirstTracksWindow, bool) {
+	// The window opens after the last significant snowfall hour of the
+	// night, but never before sunrise
+	freshSnow := false
+	start := sunrise
+	for _, hour := range night {
+		snow, ok := hour.Snowfall.GetForModel(model)
+		if !ok || snow.Inches < thresholds.StormMinRateInHr {
+			continue
+		}
+		freshSnow = true
+		if hour.End.After(start) {
+			start = hour.End
+		}
+	}
+	if !freshSnow {
+		return FirstTracksWindow{}, false
+	}
+
+	// A storm still running at the would-be start keeps pushing it back;
+	// if it snows through to sunset there is no window today
+	for _, hour := range daylight {
+		if !hour.End.After(start) {
+			continue
+		}
+		if hour.Start.After(start) {
+			break
+		}
+		snow, ok := hour.Snowfall.GetForModel(model)
+		if !ok || snow.Inches < thresholds.StormMinRateInHr {
+			break
+		}
+		start = hour.End
+	}
+	if !start.Before(sunset) {
+		return FirstTracksWindow{}, false
+	}
+
+	end, reason := sunset, FirstTracksEndSunset
+	for _, hour := range daylight {
+		if hour.Start.Before(start) {
+			continue
+		}
+		if wind, ok := hour.Wind.GetForModel(model); ok && wind.Gusts != nil && wind.Gusts.Mph > thresholds.WindPenaltyGustMph {
+			end, reason = hour.Start, FirstTracksEndWind
+			break
+		}
+		if temp, ok := hour.Temperature.GetForModel(model); ok && temp.Fahrenheit > maxTempF {
+			end, reason = hour.Start, FirstTracksEndWarming
+			break
+		}
+	}
+	if !end.After(start) {
+		return FirstTracksWindow{}, false
+	}
+
+	return FirstTracksWindow{Start: start, End: end, EndReason: reason}, true
+}
+
+// firstTracksConsensus intersects the model windows when a majority of the
+// models with overnight snowfall data found one; nil when the models
+// disagree or the overlap is empty
+func firstTracksConsensus(windows ModelValues[FirstTracksWindow], modelsWithData int) *FirstTracksWindow {
+	if len(windows) == 0 || len(windows)*2 <= modelsWithData {
+		return nil
+	}
+
+	var start, end time.Time
+	reason := FirstTracksEndSunset
+	for _, model := range windows.OrderedModels() {
+		window := windows[model]
+		if start.IsZero() || window.Start.After(start) {
+			start = window.Start
+		}
+		if end.IsZero() || window.End.Before(end) {
+			end = window.End
+			reason = window.EndReason
+		}
+	}
+	if !end.After(start) {
+		return nil
+	}
+	return &FirstTracksWindow{Start: start, End: end, EndReason: reason}
+}
@@ -0,0 +1,183 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// firstTracksForecast builds a two-day Denver forecast whose hourly snowfall
+// rate, wind gusts, and temperature come from condAt, keyed by day index and
+// hour of day
+func firstTracksForecast(condAt func(day, hour int) (snowInHr, gustMph, tempF float64)) *Forecast {
+	denver, _ := time.LoadLocation(TimezoneDenver)
+
+	forecast := &Forecast{
+		Timezone:     TimezoneDenver,
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7392, -104.9903),
+		},
+	}
+	for dayIndex := 0; dayIndex < 2; dayIndex++ {
+		day := DailyForecast{Timestamp: time.Date(2025, 1, 13+dayIndex, 0, 0, 0, 0, denver)}
+		for hour := 0; hour < 24; hour++ {
+			start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+			snow, gust, temp := condAt(dayIndex, hour)
+			day.HourlyForecasts = append(day.HourlyForecasts, HourlyForecast{
+				Start:       start,
+				End:         start.Add(time.Hour),
+				Snowfall:    ModelValues[types.Precipitation]{ModelGfsSeamless: types.NewPrecipitationFromInches(snow)},
+				Wind:        ModelValues[types.Wind]{ModelGfsSeamless: types.NewWind(10, gust, 270)},
+				Temperature: ModelValues[types.Temperature]{ModelGfsSeamless: types.NewTemperatureFromFahrenheit(temp)},
+			})
+		}
+		forecast.DailyForecasts = append(forecast.DailyForecasts, day)
+	}
+	return forecast
+}
+
+// overnightStorm snows at the given rate from midnight until endHour on the
+// second day, with calm wind and cold temperatures throughout
+func overnightStorm(endHour int) func(day, hour int) (float64, float64, float64) {
+	return func(day, hour int) (float64, float64, float64) {
+		if day == 1 && hour < endHour {
+			return 0.3, 10, 25
+		}
+		return 0, 10, 25
+	}
+}
+
+func TestFirstTracksOvernightStormEndingEarly(t *testing.T) {
+	forecast := firstTracksForecast(overnightStorm(4))
+	applyFirstTracks(forecast, 35, Thresholds{})
+
+	day := forecast.DailyForecasts[1]
+	window, ok := day.FirstTracks.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no window after an overnight storm ending at 4am")
+	}
+	// The storm ended well before dawn, so the window opens at sunrise
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	if window.Start.In(denver).Hour() != 7 {
+		t.Errorf("window opens at %v, want the 7am sunrise hour", window.Start.In(denver))
+	}
+	if window.EndReason != FirstTracksEndSunset {
+		t.Errorf("EndReason = %q, want %q on a calm cold day", window.EndReason, FirstTracksEndSunset)
+	}
+	if day.FirstTracksConsensus == nil {
+		t.Error("single-model forecast should still carry a consensus window")
+	}
+}
+
+func TestFirstTracksStormAllDay(t *testing.T) {
+	forecast := firstTracksForecast(func(day, hour int) (float64, float64, float64) {
+		return 0.3, 10, 25
+	})
+	applyFirstTracks(forecast, 35, Thresholds{})
+
+	day := forecast.DailyForecasts[1]
+	if _, ok := day.FirstTracks.GetForModel(ModelGfsSeamless); ok {
+		t.Error("a storm running through sunset should leave no window")
+	}
+	if day.FirstTracksConsensus != nil {
+		t.Error("consensus window present with no model windows")
+	}
+}
+
+func TestFirstTracksWindCutsWindowShort(t *testing.T) {
+	forecast := firstTracksForecast(func(day, hour int) (float64, float64, float64) {
+		snow := 0.0
+		if day == 1 && hour < 4 {
+			snow = 0.3
+		}
+		gust := 10.0
+		if day == 1 && hour >= 10 {
+			gust = 40
+		}
+		return snow, gust, 25
+	})
+	applyFirstTracks(forecast, 35, Thresholds{})
+
+	window, ok := forecast.DailyForecasts[1].FirstTracks.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no window on a post-storm morning")
+	}
+	if window.EndReason != FirstTracksEndWind {
+		t.Errorf("EndReason = %q, want %q", window.EndReason, FirstTracksEndWind)
+	}
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	want := time.Date(2025, 1, 14, 10, 0, 0, 0, denver)
+	if !window.End.Equal(want) {
+		t.Errorf("window ends %v, want %v when the gusts arrive", window.End, want)
+	}
+}
+
+func TestFirstTracksWarmingClosesWindow(t *testing.T) {
+	forecast := firstTracksForecast(func(day, hour int) (float64, float64, float64) {
+		snow := 0.0
+		if day == 1 && hour < 4 {
+			snow = 0.3
+		}
+		temp := 25.0
+		if day == 1 && hour >= 11 {
+			temp = 40
+		}
+		return snow, 10, temp
+	})
+	applyFirstTracks(forecast, 35, Thresholds{})
+
+	window, ok := forecast.DailyForecasts[1].FirstTracks.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no window before the warmup")
+	}
+	if window.EndReason != FirstTracksEndWarming {
+		t.Errorf("EndReason = %q, want %q", window.EndReason, FirstTracksEndWarming)
+	}
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	if window.End.In(denver).Hour() != 11 {
+		t.Errorf("window ends %v, want the 11am warmup", window.End.In(denver))
+	}
+}
+
+func TestFirstTracksPolarNightLeavesNoWindow(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+
+	// Tromsø at midwinter: fresh snow overnight, but with no sunrise there is
+	// no morning window to recommend
+	forecast := &Forecast{
+		Timezone:     "Europe/Oslo",
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(69.6496, 18.9560),
+		},
+	}
+	for dayIndex := 0; dayIndex < 2; dayIndex++ {
+		day := DailyForecast{Timestamp: time.Date(2025, 12, 21+dayIndex, 0, 0, 0, 0, oslo)}
+		for hour := 0; hour < 24; hour++ {
+			start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+			day.HourlyForecasts = append(day.HourlyForecasts, HourlyForecast{
+				Start:       start,
+				End:         start.Add(time.Hour),
+				Snowfall:    ModelValues[types.Precipitation]{ModelGfsSeamless: types.NewPrecipitationFromInches(0.3)},
+				Wind:        ModelValues[types.Wind]{ModelGfsSeamless: types.NewWind(10, 10, 270)},
+				Temperature: ModelValues[types.Temperature]{ModelGfsSeamless: types.NewTemperatureFromFahrenheit(5)},
+			})
+		}
+		forecast.DailyForecasts = append(forecast.DailyForecasts, day)
+	}
+
+	applyFirstTracks(forecast, 35, Thresholds{})
+
+	day := forecast.DailyForecasts[1]
+	if day.FirstTracks != nil {
+		t.Error("polar night should leave FirstTracks nil, not a fabricated window")
+	}
+	if day.FirstTracksConsensus != nil {
+		t.Error("polar night should leave FirstTracksConsensus nil")
+	}
+}
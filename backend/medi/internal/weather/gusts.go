@@ -0,0 +1,102 @@
+package weather
+
+import (
+	"medi/internal/types"
+)
+
+// defaultGustFactor is the speed multiplier used when no co-reporting model
+// supplies a gust/speed ratio for the hour.
+const defaultGustFactor = 1.5
+
+// applyGustEstimation backfills hourly gusts for models that report wind
+// speed but no gusts (GraphCast and AIFS). The estimate prefers the mean
+// gust/speed ratio of the models that do report gusts for the same hour and
+// falls back to factor times speed otherwise. Estimated values carry the
+// Wind.GustsEstimated flag, and the day's gust aggregates are extended to
+// cover the estimated models.
+func applyGustEstimation(forecast *Forecast, factor float64) {
+	if factor <= 0 {
+		factor = defaultGustFactor
+	}
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+		estimated := map[string]bool{}
+
+		for j := range day.HourlyForecasts {
+			hour := &day.HourlyForecasts[j]
+
+			ratio, ok := hourlyGustRatio(hour.Wind)
+			if !ok {
+				ratio = factor
+			}
+
+			for model, wind := range hour.Wind {
+				if wind.Gusts != nil || wind.Speed.Mph <= 0 {
+					continue
+				}
+				gusts := types.NewWindSpeedFromMph(wind.Speed.Mph * ratio)
+				wind.Gusts = &gusts
+				wind.GustsEstimated = true
+				hour.Wind[model] = wind
+				estimated[model] = true
+			}
+		}
+
+		for model := range estimated {
+			extendGustAggregates(day, model)
+		}
+	}
+}
+
+// hourlyGustRatio averages gusts over speed across the models reporting both
+// for the hour. It returns ok=false when no model does.
+func hourlyGustRatio(winds ModelValues[types.Wind]) (float64, bool) {
+	sum, count := 0.0, 0
+	for _, wind := range winds {
+		if wind.Gusts == nil || wind.GustsEstimated || wind.Speed.Mph <= 0 {
+			continue
+		}
+		sum += wind.Gusts.Mph / wind.Speed.Mph
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// extendGustAggregates fills the day's Max/MinWindGusts for a model whose
+// gusts were estimated, leaving reported aggregates untouched.
+func extendGustAggregates(day *DailyForecast, model string) {
+	if day.MaxWindGusts.HasModel(model) || day.MinWindGusts.HasModel(model) {
+		return
+	}
+
+	max, min, seen := 0.0, 0.0, false
+	for _, hour := range day.HourlyForecasts {
+		wind, ok := hour.Wind.GetForModel(model)
+		if !ok || wind.Gusts == nil {
+			continue
+		}
+		if !seen || wind.Gusts.Mph > max {
+			max = wind.Gusts.Mph
+		}
+		if !seen || wind.Gusts.Mph < min {
+			min = wind.Gusts.Mph
+		}
+		seen = true
+	}
+	if !seen {
+		return
+	}
+
+	if day.MaxWindGusts == nil {
+		day.MaxWindGusts = ModelValues[types.WindSpeed]{}
+	}
+	if day.MinWindGusts == nil {
+		day.MinWindGusts = ModelValues[types.WindSpeed]{}
+	}
+	day.MaxWindGusts[model] = types.NewWindSpeedFromMph(max)
+	day.MinWindGusts[model] = types.NewWindSpeedFromMph(min)
+}
@@ -0,0 +1,123 @@
+package weather
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// gustTestForecast wraps the hours into a single-day forecast.
+func gustTestForecast(hours ...HourlyForecast) *Forecast {
+	day := DailyForecast{
+		Timestamp:       time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+		HourlyForecasts: hours,
+		MaxWindGusts:    ModelValues[types.WindSpeed]{},
+		MinWindGusts:    ModelValues[types.WindSpeed]{},
+	}
+	return &Forecast{DailyForecasts: []DailyForecast{day}}
+}
+
+func TestGustEstimationUsesHourlyRatio(t *testing.T) {
+	// GfsSeamless gusts at 2x speed, GemSeamless at 1.5x: mean ratio 1.75
+	hour := HourlyForecast{
+		Wind: ModelValues[types.Wind]{
+			ModelGfsSeamless:     types.NewWind(10, 20, 270),
+			ModelGemSeamless:     types.NewWind(20, 30, 270),
+			ModelGfsGraphcast025: types.NewWindWithoutGusts(10, 270),
+		},
+	}
+	forecast := gustTestForecast(hour)
+	applyGustEstimation(forecast, 1.5)
+
+	wind, _ := forecast.DailyForecasts[0].HourlyForecasts[0].Wind.GetForModel(ModelGfsGraphcast025)
+	if wind.Gusts == nil {
+		t.Fatal("gusts not backfilled")
+	}
+	if wind.Gusts.Mph != 17.5 {
+		t.Errorf("estimated gusts = %v mph, want 17.5 from the mean ratio", wind.Gusts.Mph)
+	}
+	if !wind.GustsEstimated {
+		t.Error("estimated gusts not flagged")
+	}
+
+	// Reported values are left alone
+	reported, _ := forecast.DailyForecasts[0].HourlyForecasts[0].Wind.GetForModel(ModelGfsSeamless)
+	if reported.GustsEstimated || reported.Gusts.Mph != 20 {
+		t.Errorf("reported wind modified: %+v", reported)
+	}
+}
+
+func TestGustEstimationFixedFactorFallback(t *testing.T) {
+	// No model reports gusts this hour, so the configured factor applies
+	hour := HourlyForecast{
+		Wind: ModelValues[types.Wind]{
+			ModelGfsGraphcast025:    types.NewWindWithoutGusts(12, 180),
+			ModelEcmwfAifs025Single: types.NewWindWithoutGusts(8, 180),
+		},
+	}
+	forecast := gustTestForecast(hour)
+	applyGustEstimation(forecast, 1.5)
+
+	wind, _ := forecast.DailyForecasts[0].HourlyForecasts[0].Wind.GetForModel(ModelGfsGraphcast025)
+	if wind.Gusts == nil || wind.Gusts.Mph != 18 {
+		t.Fatalf("estimated gusts = %+v, want 18 mph from the fixed factor", wind.Gusts)
+	}
+	if !wind.GustsEstimated {
+		t.Error("estimated gusts not flagged")
+	}
+}
+
+func TestGustEstimationExtendsDailyAggregates(t *testing.T) {
+	forecast := gustTestForecast(
+		HourlyForecast{Wind: ModelValues[types.Wind]{
+			ModelGfsSeamless:     types.NewWind(10, 15, 270),
+			ModelGfsGraphcast025: types.NewWindWithoutGusts(10, 270),
+		}},
+		HourlyForecast{Wind: ModelValues[types.Wind]{
+			ModelGfsSeamless:     types.NewWind(10, 15, 270),
+			ModelGfsGraphcast025: types.NewWindWithoutGusts(20, 270),
+		}},
+	)
+	day := &forecast.DailyForecasts[0]
+	day.MaxWindGusts[ModelGfsSeamless] = types.NewWindSpeedFromMph(15)
+	day.MinWindGusts[ModelGfsSeamless] = types.NewWindSpeedFromMph(15)
+
+	applyGustEstimation(forecast, 1.5)
+
+	// Ratio is 1.5 both hours: estimates 15 and 30
+	if got := day.MaxWindGusts[ModelGfsGraphcast025].Mph; got != 30 {
+		t.Errorf("MaxWindGusts = %v, want 30 from the estimated series", got)
+	}
+	if got := day.MinWindGusts[ModelGfsGraphcast025].Mph; got != 15 {
+		t.Errorf("MinWindGusts = %v, want 15 from the estimated series", got)
+	}
+	if got := day.MaxWindGusts[ModelGfsSeamless].Mph; got != 15 {
+		t.Errorf("reported MaxWindGusts changed to %v", got)
+	}
+}
+
+func TestGustEstimatedFlagSerializes(t *testing.T) {
+	hour := HourlyForecast{
+		Wind: ModelValues[types.Wind]{
+			ModelGfsSeamless:     types.NewWind(10, 20, 270),
+			ModelGfsGraphcast025: types.NewWindWithoutGusts(10, 270),
+		},
+	}
+	forecast := gustTestForecast(hour)
+	applyGustEstimation(forecast, 1.5)
+
+	data, err := json.Marshal(forecast.DailyForecasts[0].HourlyForecasts[0].Wind)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"gustsEstimated":true`) {
+		t.Errorf("serialized wind %s does not mark the estimate", data)
+	}
+	// The reported model must not carry the flag
+	if strings.Count(string(data), `"gustsEstimated":true`) != 1 {
+		t.Errorf("flag count wrong in %s", data)
+	}
+}
@@ -0,0 +1,183 @@
+package weather
+
+import (
+	"sort"
+	"time"
+)
+
+// ModelInfo describes a weather model's published characteristics and the
+// display metadata charting clients render it with.
+type ModelInfo struct {
+	// Name is the human-readable model name
+	Name string
+	// ShortLabel is the compact label for chart legends and pickers
+	ShortLabel string
+	// Color is the stable hex color every chart draws this model's series
+	// with, so a model looks the same across the app
+	Color string
+	// SortOrder positions the model in pickers and legends, ascending
+	SortOrder int
+	// DefaultVisible marks the models a chart shows before the client
+	// expands "more models"
+	DefaultVisible bool
+	// HorizonHours is the nominal forecast length the model publishes; hours
+	// past it carry no real data even when the response pads the series
+	HorizonHours int
+}
+
+// modelRegistry records each model's nominal horizon and display metadata.
+// The short-range members (NAM in particular) end days before the seamless
+// models, and the padded series past their horizon must not be mistaken for
+// a forecast of nothing. The experimental AI members and the short-range NAM
+// hide behind "more models" by default.
+var modelRegistry = map[string]ModelInfo{
+	ModelGfsSeamless:        {Name: "GFS Seamless", ShortLabel: "GFS", Color: "#1f77b4", SortOrder: 1, DefaultVisible: true, HorizonHours: 16 * 24},
+	ModelEcmwIfs:            {Name: "ECMWF IFS", ShortLabel: "ECMWF", Color: "#d62728", SortOrder: 2, DefaultVisible: true, HorizonHours: 10 * 24},
+	ModelGemSeamless:        {Name: "GEM Seamless", ShortLabel: "GEM", Color: "#2ca02c", SortOrder: 3, DefaultVisible: true, HorizonHours: 10 * 24},
+	ModelNcepNbmConus:       {Name: "NCEP NBM CONUS", ShortLabel: "NBM", Color: "#9467bd", SortOrder: 4, DefaultVisible: true, HorizonHours: 8 * 24},
+	ModelGfsGraphcast025:    {Name: "GFS GraphCast", ShortLabel: "GraphCast", Color: "#ff7f0e", SortOrder: 5, HorizonHours: 10 * 24},
+	ModelEcmwfAifs025Single: {Name: "ECMWF AIFS", ShortLabel: "AIFS", Color: "#8c564b", SortOrder: 6, HorizonHours: 15 * 24},
+	ModelNcepNamConus:       {Name: "NCEP NAM CONUS", ShortLabel: "NAM", Color: "#7f7f7f", SortOrder: 7, HorizonHours: 84},
+	ModelMetNo:              {Name: "MET Norway", ShortLabel: "MET.no", Color: "#17becf", SortOrder: 8, DefaultVisible: true, HorizonHours: 10 * 24},
+}
+
+// ModelInfoFor returns the registry entry for a model name
+func ModelInfoFor(model string) (ModelInfo, bool) {
+	info, ok := modelRegistry[model]
+	return info, ok
+}
+
+// DefaultVisibleModels returns the model names charts show by default, in
+// canonical order
+func DefaultVisibleModels() []string {
+	var models []string
+	for _, model := range canonicalModelOrder {
+		if modelRegistry[model].DefaultVisible {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// applyHorizonEnd records where the hourly data stops: the final hourly
+// entry's End. The entry itself still covers a real [Start, Start+1h)
+// interval, but anything derived past that End would describe hours with no
+// data behind them.
+func applyHorizonEnd(forecast *Forecast) {
+	for i := len(forecast.DailyForecasts) - 1; i >= 0; i-- {
+		hours := forecast.DailyForecasts[i].HourlyForecasts
+		if len(hours) > 0 {
+			forecast.HorizonEnd = hours[len(hours)-1].End
+			return
+		}
+	}
+}
+
+// applyModelHorizons computes each model's HorizonEnd from the registry and
+// the mapped series, then strips models from the hours and daily aggregates
+// they do not cover. A day a model only partially covers loses that model's
+// daily values entirely, so a 6-hour tail never masquerades as a daily total;
+// AvailableModels records which models survived for each day.
+func applyModelHorizons(forecast *Forecast) {
+	if len(forecast.DailyForecasts) == 0 {
+		return
+	}
+	firstDay := forecast.DailyForecasts[0]
+	lastDay := forecast.DailyForecasts[len(forecast.DailyForecasts)-1]
+	if len(firstDay.HourlyForecasts) == 0 || len(lastDay.HourlyForecasts) == 0 {
+		return
+	}
+	seriesStart := firstDay.HourlyForecasts[0].Start
+	seriesEnd := lastDay.HourlyForecasts[len(lastDay.HourlyForecasts)-1].End
+
+	horizons := ModelValues[time.Time]{}
+	for _, model := range firstDay.HourlyForecasts[0].Temperature.Models() {
+		end := seriesEnd
+		if info, ok := modelRegistry[model]; ok {
+			if nominal := seriesStart.Add(time.Duration(info.HorizonHours) * time.Hour); nominal.Before(end) {
+				end = nominal
+			}
+		}
+		horizons[model] = end
+	}
+	forecast.ModelHorizons = horizons
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+
+		dayEnd := day.Timestamp.Add(24 * time.Hour)
+		if hours := day.HourlyForecasts; len(hours) > 0 {
+			dayEnd = hours[len(hours)-1].End
+		}
+
+		var available []string
+		for model, horizonEnd := range horizons {
+			if horizonEnd.Before(dayEnd) {
+				pruneDailyModel(day, model)
+			} else {
+				available = append(available, model)
+			}
+		}
+		sort.Strings(available)
+		day.AvailableModels = available
+
+		for j := range day.HourlyForecasts {
+			hour := &day.HourlyForecasts[j]
+			for model, horizonEnd := range horizons {
+				if hour.End.After(horizonEnd) {
+					pruneHourlyModel(hour, model)
+				}
+			}
+		}
+	}
+}
+
+// pruneDailyModel removes a model from every per-model daily aggregate.
+func pruneDailyModel(day *DailyForecast, model string) {
+	delete(day.Weather, model)
+	delete(day.SnowfallWaterEquivalentSum, model)
+	delete(day.Sunrise, model)
+	delete(day.Sunset, model)
+	delete(day.WindDominantDirection, model)
+	delete(day.HighestFreezingLevelHeightFt, model)
+	delete(day.LowestFreezingLevelHeightFt, model)
+	delete(day.HighTemperature, model)
+	delete(day.LowTemperature, model)
+	delete(day.MaxFeelsLike, model)
+	delete(day.MinFeelsLike, model)
+	delete(day.TotalPrecipitation, model)
+	delete(day.TotalRain, model)
+	delete(day.TotalShowers, model)
+	delete(day.TotalSnowfall, model)
+	delete(day.TotalLiquidPrecipitation, model)
+	delete(day.MaxWindSpeed, model)
+	delete(day.MinWindSpeed, model)
+	delete(day.MaxWindGusts, model)
+	delete(day.MinWindGusts, model)
+	delete(day.PeakSnowfallHour, model)
+	delete(day.SnowfallIntensity, model)
+}
+
+// pruneHourlyModel removes a model from every per-model hourly value.
+func pruneHourlyModel(hour *HourlyForecast, model string) {
+	delete(hour.FreezingLevelHeight, model)
+	delete(hour.IsDay, model)
+	delete(hour.Weather, model)
+	delete(hour.Temperature, model)
+	delete(hour.ApparentTemperature, model)
+	delete(hour.FeelsLike, model)
+	delete(hour.PrecipitationProbability, model)
+	delete(hour.Precipitation, model)
+	delete(hour.CloudCover, model)
+	delete(hour.CloudCoverLow, model)
+	delete(hour.CloudCoverMid, model)
+	delete(hour.CloudCoverHigh, model)
+	delete(hour.Visibility, model)
+	delete(hour.Wind, model)
+	delete(hour.RelativeHumidity, model)
+	delete(hour.Rainfall, model)
+	delete(hour.Showers, model)
+	delete(hour.Snowfall, model)
+	delete(hour.SnowDepth, model)
+	delete(hour.LiquidPrecipitation, model)
+}
@@ -0,0 +1,79 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// The NAM horizon of 84 hours cuts off midway through day four: days one
+// through three are fully covered, day four only gets 12 hours.
+func TestModelHorizonCutoffDay(t *testing.T) {
+	apiResp := synthForecastResponse(t, 6)
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+	if len(forecast.DailyForecasts) != 6 {
+		t.Fatalf("got %d daily forecasts, want 6", len(forecast.DailyForecasts))
+	}
+
+	seriesStart := forecast.DailyForecasts[0].HourlyForecasts[0].Start
+	namHorizon, ok := forecast.ModelHorizons.GetForModel(ModelNcepNamConus)
+	if !ok {
+		t.Fatal("ModelHorizons is missing NAM")
+	}
+	if want := seriesStart.Add(84 * time.Hour); !namHorizon.Equal(want) {
+		t.Errorf("NAM horizon = %v, want %v", namHorizon, want)
+	}
+	gfsHorizon, _ := forecast.ModelHorizons.GetForModel(ModelGfsSeamless)
+	lastDay := forecast.DailyForecasts[5]
+	if want := lastDay.HourlyForecasts[len(lastDay.HourlyForecasts)-1].End; !gfsHorizon.Equal(want) {
+		t.Errorf("GFS horizon = %v, want series end %v", gfsHorizon, want)
+	}
+
+	dayThree := forecast.DailyForecasts[2]
+	if !dayThree.HighTemperature.HasModel(ModelNcepNamConus) {
+		t.Error("day three high temperature missing NAM inside its horizon")
+	}
+	found := false
+	for _, model := range dayThree.AvailableModels {
+		if model == ModelNcepNamConus {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("day three AvailableModels = %v, want NAM included", dayThree.AvailableModels)
+	}
+
+	// Day four is only half covered: no NAM daily aggregates, and NAM absent
+	// from the availability list
+	dayFour := forecast.DailyForecasts[3]
+	if dayFour.HighTemperature.HasModel(ModelNcepNamConus) {
+		t.Error("day four high temperature includes NAM past its horizon")
+	}
+	if dayFour.TotalSnowfall.HasModel(ModelNcepNamConus) {
+		t.Error("day four snowfall total includes NAM from a partially covered day")
+	}
+	for _, model := range dayFour.AvailableModels {
+		if model == ModelNcepNamConus {
+			t.Errorf("day four AvailableModels = %v, want NAM excluded", dayFour.AvailableModels)
+		}
+	}
+	if !dayFour.HighTemperature.HasModel(ModelGfsSeamless) {
+		t.Error("day four high temperature missing GFS, its horizon covers it")
+	}
+
+	// Within day four the hours up to 84h keep NAM, the rest lose it
+	if !dayFour.HourlyForecasts[11].Temperature.HasModel(ModelNcepNamConus) {
+		t.Error("hour 83 temperature missing NAM inside its horizon")
+	}
+	if dayFour.HourlyForecasts[12].Temperature.HasModel(ModelNcepNamConus) {
+		t.Error("hour 84 temperature includes NAM past its horizon")
+	}
+}
@@ -0,0 +1,150 @@
+package weather
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"medi/internal/providers/openmeteo"
+	"medi/internal/types"
+)
+
+// trimHourlySeries cuts every hourly array in the response to the first
+// hours entries via a JSON round-trip, leaving a series that stops at an
+// awkward mid-afternoon timestamp instead of a day boundary.
+func trimHourlySeries(t *testing.T, apiResp *openmeteo.ForecastAPIResponse, hours int) *openmeteo.ForecastAPIResponse {
+	t.Helper()
+
+	data, err := json.Marshal(apiResp)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	hourly, ok := raw["hourly"].(map[string]interface{})
+	if !ok {
+		t.Fatal("response has no hourly block")
+	}
+	for key, value := range hourly {
+		series, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		if len(series) > hours {
+			hourly[key] = series[:hours]
+		}
+	}
+
+	data, err = json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("failed to re-marshal response: %v", err)
+	}
+	var trimmed openmeteo.ForecastAPIResponse
+	if err := json.Unmarshal(data, &trimmed); err != nil {
+		t.Fatalf("failed to decode trimmed response: %v", err)
+	}
+	return &trimmed
+}
+
+// mapTrimmedForecast maps a 2-day response whose hourly series stops at the
+// 13:00 entry of the second day
+func mapTrimmedForecast(t *testing.T) *Forecast {
+	t.Helper()
+
+	apiResp := synthForecastResponse(t, 2)
+	apiResp = trimHourlySeries(t, apiResp, 24+14)
+
+	forecast, err := mapForecastAPIResponseToForecast(types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+	return forecast
+}
+
+func TestHorizonEndStopsAtFinalHourlyEntry(t *testing.T) {
+	forecast := mapTrimmedForecast(t)
+
+	hours := forecast.Hours()
+	if len(hours) != 24+14 {
+		t.Fatalf("got %d hourly forecasts, want %d", len(hours), 24+14)
+	}
+	last := hours[len(hours)-1]
+	if last.Start.Hour() != 13 {
+		t.Fatalf("final hourly Start is %v, want a 13:00 timestamp", last.Start)
+	}
+	if !forecast.HorizonEnd.Equal(last.End) {
+		t.Errorf("HorizonEnd = %v, want the final hour's End %v", forecast.HorizonEnd, last.End)
+	}
+
+	if len(forecast.DailyForecasts) != 2 {
+		t.Fatalf("got %d daily forecasts, want 2", len(forecast.DailyForecasts))
+	}
+	if got := forecast.DailyForecasts[0].HoursCovered; got != 24 {
+		t.Errorf("first day HoursCovered = %d, want 24", got)
+	}
+	if got := forecast.DailyForecasts[1].HoursCovered; got != 14 {
+		t.Errorf("final day HoursCovered = %d, want 14", got)
+	}
+}
+
+func TestHoursBetweenClampsToHorizonEnd(t *testing.T) {
+	forecast := mapTrimmedForecast(t)
+
+	// An interval reaching hours past the horizon gets only the data that
+	// exists, and nothing whose End extends past HorizonEnd
+	hours := forecast.HoursBetween(forecast.HorizonEnd.Add(-2*time.Hour), forecast.HorizonEnd.Add(6*time.Hour))
+	if len(hours) != 2 {
+		t.Fatalf("got %d hours across the horizon, want 2", len(hours))
+	}
+	for _, hour := range hours {
+		if hour.End.After(forecast.HorizonEnd) {
+			t.Errorf("hour ending %v extends past HorizonEnd %v", hour.End, forecast.HorizonEnd)
+		}
+	}
+
+	if hours := forecast.HoursBetween(forecast.HorizonEnd, forecast.HorizonEnd.Add(24*time.Hour)); len(hours) != 0 {
+		t.Errorf("got %d hours entirely past the horizon, want none", len(hours))
+	}
+}
+
+func TestClampToHorizon(t *testing.T) {
+	forecast := mapTrimmedForecast(t)
+
+	past := forecast.HorizonEnd.Add(3 * time.Hour)
+	if got := forecast.ClampToHorizon(past); !got.Equal(forecast.HorizonEnd) {
+		t.Errorf("ClampToHorizon(%v) = %v, want %v", past, got, forecast.HorizonEnd)
+	}
+	within := forecast.HorizonEnd.Add(-3 * time.Hour)
+	if got := forecast.ClampToHorizon(within); !got.Equal(within) {
+		t.Errorf("ClampToHorizon(%v) = %v, want it unchanged", within, got)
+	}
+
+	// Older cached forecasts without a horizon pass instants through
+	unset := &Forecast{}
+	if got := unset.ClampToHorizon(past); !got.Equal(past) {
+		t.Errorf("zero-horizon ClampToHorizon(%v) = %v, want it unchanged", past, got)
+	}
+}
+
+func TestInterpolateEndsAtFinalHourlyStart(t *testing.T) {
+	forecast := mapTrimmedForecast(t)
+
+	points, err := Interpolate(forecast, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("Interpolate returned error: %v", err)
+	}
+	if len(points) == 0 {
+		t.Fatal("Interpolate returned no points")
+	}
+	last := points[len(points)-1]
+	hours := forecast.Hours()
+	if !last.Time.Equal(hours[len(hours)-1].Start) {
+		t.Errorf("final sample at %v, want the final hourly Start %v", last.Time, hours[len(hours)-1].Start)
+	}
+}
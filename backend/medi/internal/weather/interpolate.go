@@ -0,0 +1,184 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"medi/internal/types"
+)
+
+// maxInterpolatedPoints caps the derived series so a fine resolution over a
+// long forecast cannot blow up response size
+const maxInterpolatedPoints = 5000
+
+var (
+	// ErrInvalidStep is returned for steps that do not divide one hour
+	ErrInvalidStep = errors.New("interpolation step must evenly divide one hour")
+	// ErrTooManyPoints is returned when the requested resolution would
+	// exceed maxInterpolatedPoints
+	ErrTooManyPoints = errors.New("interpolation would produce too many points")
+)
+
+// InterpolatedPoint is one sample of the derived sub-hourly series.
+// Continuous fields are interpolated; Weather and Snowfall are step-held from
+// the source hour, so snowfall stays attributed to the hour it falls in.
+type InterpolatedPoint struct {
+	Time                  time.Time
+	Temperature           ModelValues[types.Temperature]
+	WindSpeed             ModelValues[types.WindSpeed]
+	FreezingLevelHeightFt ModelValues[float64]
+	Weather               ModelValues[types.Weather]
+	Snowfall              ModelValues[types.Precipitation]
+}
+
+// Interpolate derives a sub-hourly series from the forecast's hourly points
+// for charting. Temperature, wind speed, and freezing level are interpolated
+// with monotone cubics (Fritsch-Butland tangents), which never overshoot the
+// neighboring hourly values. Models missing an hour leave a gap: samples
+// inside an interval with a missing endpoint omit that model rather than
+// bridging it. The series ends at the final hourly Start — values are only
+// ever interpolated between data points, never extrapolated toward
+// HorizonEnd.
+func Interpolate(forecast *Forecast, step time.Duration) ([]InterpolatedPoint, error) {
+	if step <= 0 || step > time.Hour || time.Hour%step != 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStep, step)
+	}
+
+	hours := forecast.Hours()
+	if len(hours) == 0 {
+		return nil, nil
+	}
+
+	perHour := int(time.Hour / step)
+	totalPoints := (len(hours)-1)*perHour + 1
+	if totalPoints > maxInterpolatedPoints {
+		return nil, fmt.Errorf("%w: %d points at resolution %s, limit %d", ErrTooManyPoints, totalPoints, step, maxInterpolatedPoints)
+	}
+
+	temperature := continuousSeries(hours,
+		func(h HourlyForecast) ModelValues[types.Temperature] { return h.Temperature },
+		func(t types.Temperature) float64 { return t.Fahrenheit })
+	windSpeed := continuousSeries(hours,
+		func(h HourlyForecast) ModelValues[types.Wind] { return h.Wind },
+		func(w types.Wind) float64 { return w.Speed.Mph })
+	freezingLevel := continuousSeries(hours,
+		func(h HourlyForecast) ModelValues[float64] { return h.FreezingLevelHeight },
+		func(v float64) float64 { return v })
+
+	temperatureSlopes := seriesSlopes(temperature)
+	windSpeedSlopes := seriesSlopes(windSpeed)
+	freezingLevelSlopes := seriesSlopes(freezingLevel)
+
+	points := make([]InterpolatedPoint, 0, totalPoints)
+	for p := 0; p < totalPoints; p++ {
+		i := p / perHour
+		sub := p % perHour
+		u := float64(sub) / float64(perHour)
+
+		points = append(points, InterpolatedPoint{
+			Time:     hours[i].Start.Add(time.Duration(sub) * step),
+			Weather:  hours[i].Weather,
+			Snowfall: hours[i].Snowfall,
+			Temperature: interpolateModelValues(temperature, temperatureSlopes, i, u,
+				types.NewTemperatureFromFahrenheit),
+			WindSpeed: interpolateModelValues(windSpeed, windSpeedSlopes, i, u,
+				types.NewWindSpeedFromMph),
+			FreezingLevelHeightFt: interpolateModelValues(freezingLevel, freezingLevelSlopes, i, u,
+				func(v float64) float64 { return v }),
+		})
+	}
+
+	return points, nil
+}
+
+// continuousSeries flattens one per-model field of the hourly forecasts into
+// NaN-padded series suitable for interpolation
+func continuousSeries[T any](hours []HourlyForecast, field func(HourlyForecast) ModelValues[T], value func(T) float64) map[string][]float64 {
+	series := map[string][]float64{}
+	for i, hour := range hours {
+		for model, v := range field(hour) {
+			s, ok := series[model]
+			if !ok {
+				s = make([]float64, len(hours))
+				for j := range s {
+					s[j] = math.NaN()
+				}
+				series[model] = s
+			}
+			s[i] = value(v)
+		}
+	}
+	return series
+}
+
+func seriesSlopes(series map[string][]float64) map[string][]float64 {
+	slopes := make(map[string][]float64, len(series))
+	for model, s := range series {
+		slopes[model] = monotoneSlopes(s)
+	}
+	return slopes
+}
+
+// monotoneSlopes computes per-point tangents for monotone cubic interpolation
+// over a uniformly spaced series, using the Fritsch-Butland harmonic mean of
+// the neighboring secants. NaN values split the series: points bordering a
+// gap fall back to the one-sided secant, which degrades that interval to
+// linear and keeps it within its endpoints.
+func monotoneSlopes(y []float64) []float64 {
+	m := make([]float64, len(y))
+	secant := func(i int) float64 { return y[i+1] - y[i] }
+
+	for i := range y {
+		left, right := math.NaN(), math.NaN()
+		if i > 0 {
+			left = secant(i - 1)
+		}
+		if i < len(y)-1 {
+			right = secant(i)
+		}
+
+		switch {
+		case math.IsNaN(left) && math.IsNaN(right):
+			m[i] = 0
+		case math.IsNaN(left):
+			m[i] = right
+		case math.IsNaN(right):
+			m[i] = left
+		case left*right <= 0:
+			// Local extremum: flatten the tangent to avoid overshoot
+			m[i] = 0
+		default:
+			m[i] = 2 * left * right / (left + right)
+		}
+	}
+	return m
+}
+
+// interpolateModelValues evaluates the cubic for each model at fraction u of
+// interval i. Models whose interval endpoint is missing (NaN) are omitted.
+func interpolateModelValues[U any](series map[string][]float64, slopes map[string][]float64, i int, u float64, mapValue func(float64) U) ModelValues[U] {
+	values := make(ModelValues[U], len(series))
+	for model, y := range series {
+		var v float64
+		if u == 0 {
+			v = y[i]
+		} else {
+			m := slopes[model]
+			v = hermite(y[i], y[i+1], m[i], m[i+1], u)
+		}
+		if math.IsNaN(v) {
+			continue
+		}
+		values[model] = mapValue(v)
+	}
+	return values
+}
+
+// hermite evaluates the cubic Hermite basis on the unit interval
+func hermite(y0, y1, m0, m1, u float64) float64 {
+	u2 := u * u
+	u3 := u2 * u
+	return y0*(2*u3-3*u2+1) + m0*(u3-2*u2+u) + y1*(-2*u3+3*u2) + m1*(u3-u2)
+}
@@ -0,0 +1,157 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// buildHourlySeries wraps per-model hourly temperatures (Fahrenheit) in a
+// single-day forecast starting at a fixed hour
+func buildHourlySeries(t *testing.T, temps map[string][]float64) *Forecast {
+	t.Helper()
+
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	hours := 0
+	for _, series := range temps {
+		if len(series) > hours {
+			hours = len(series)
+		}
+	}
+
+	day := DailyForecast{Timestamp: start}
+	for i := 0; i < hours; i++ {
+		hour := HourlyForecast{
+			Start:       start.Add(time.Duration(i) * time.Hour),
+			End:         start.Add(time.Duration(i+1) * time.Hour),
+			Temperature: ModelValues[types.Temperature]{},
+		}
+		for model, series := range temps {
+			if i < len(series) && !math.IsNaN(series[i]) {
+				hour.Temperature[model] = types.NewTemperatureFromFahrenheit(series[i])
+			}
+		}
+		day.HourlyForecasts = append(day.HourlyForecasts, hour)
+	}
+
+	return &Forecast{
+		Timezone:       "UTC",
+		DailyForecasts: []DailyForecast{day},
+	}
+}
+
+func TestInterpolateMonotoneNoOvershoot(t *testing.T) {
+	temps := []float64{10, 20, 22, 40, 41, 80}
+	forecast := buildHourlySeries(t, map[string][]float64{ModelGfsSeamless: temps})
+
+	points, err := Interpolate(forecast, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("Interpolate returned error: %v", err)
+	}
+	if want := (len(temps)-1)*4 + 1; len(points) != want {
+		t.Fatalf("got %d points, want %d", len(points), want)
+	}
+
+	previous := math.Inf(-1)
+	for p, point := range points {
+		value, ok := point.Temperature[ModelGfsSeamless]
+		if !ok {
+			t.Fatalf("point %d missing temperature", p)
+		}
+		// Never overshoot the neighboring hourly values
+		i := p / 4
+		if i < len(temps)-1 {
+			lo, hi := temps[i], temps[i+1]
+			if value.Fahrenheit < lo-1e-9 || value.Fahrenheit > hi+1e-9 {
+				t.Errorf("point %d = %v F outside hourly bounds [%v, %v]", p, value.Fahrenheit, lo, hi)
+			}
+		}
+		// A monotone input must produce a monotone series
+		if value.Fahrenheit < previous-1e-9 {
+			t.Errorf("point %d = %v F breaks monotonicity (previous %v)", p, value.Fahrenheit, previous)
+		}
+		previous = value.Fahrenheit
+
+		// The hourly points themselves are reproduced exactly
+		if p%4 == 0 && value.Fahrenheit != temps[p/4] {
+			t.Errorf("point %d = %v F, want exact hourly value %v", p, value.Fahrenheit, temps[p/4])
+		}
+	}
+}
+
+func TestInterpolateSkipsGaps(t *testing.T) {
+	gap := math.NaN()
+	forecast := buildHourlySeries(t, map[string][]float64{
+		ModelGfsSeamless: {30, 32, 34, 36, 38},
+		ModelGemSeamless: {30, 32, gap, 36, 38},
+	})
+
+	points, err := Interpolate(forecast, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("Interpolate returned error: %v", err)
+	}
+
+	for p, point := range points {
+		if _, ok := point.Temperature[ModelGfsSeamless]; !ok {
+			t.Errorf("point %d missing the complete model", p)
+		}
+		_, hasGem := point.Temperature[ModelGemSeamless]
+		// Samples touching the missing hour 2 must omit the model instead
+		// of bridging the gap: intervals 1-2 and 2-3 plus the hour itself
+		inGap := p > 2 && p < 6
+		if inGap && hasGem {
+			t.Errorf("point %d bridges the gap for the incomplete model", p)
+		}
+		if !inGap && !hasGem {
+			t.Errorf("point %d missing the incomplete model outside the gap", p)
+		}
+	}
+}
+
+func TestInterpolateStepHoldsCategoricalFields(t *testing.T) {
+	forecast := buildHourlySeries(t, map[string][]float64{ModelGfsSeamless: {30, 30, 30}})
+	hours := forecast.DailyForecasts[0].HourlyForecasts
+	hours[0].Weather = ModelValues[types.Weather]{ModelGfsSeamless: types.NewWeather(71)}
+	hours[0].Snowfall = ModelValues[types.Precipitation]{ModelGfsSeamless: types.NewPrecipitationFromInches(0.4)}
+	hours[1].Weather = ModelValues[types.Weather]{ModelGfsSeamless: types.NewWeather(0)}
+
+	points, err := Interpolate(forecast, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("Interpolate returned error: %v", err)
+	}
+
+	for p := 0; p < 4; p++ {
+		if got := points[p].Weather[ModelGfsSeamless].Code; got != 71 {
+			t.Errorf("point %d weather code = %d, want step-held 71", p, got)
+		}
+		if got := points[p].Snowfall[ModelGfsSeamless].Inches; got != 0.4 {
+			t.Errorf("point %d snowfall = %v, want its hour's 0.4", p, got)
+		}
+	}
+	if got := points[4].Weather[ModelGfsSeamless].Code; got != 0 {
+		t.Errorf("point 4 weather code = %d, want next hour's 0", got)
+	}
+	if _, ok := points[4].Snowfall[ModelGfsSeamless]; ok {
+		t.Error("point 4 inherits snowfall from the previous hour")
+	}
+}
+
+func TestInterpolateGuards(t *testing.T) {
+	forecast := buildHourlySeries(t, map[string][]float64{ModelGfsSeamless: {30, 31}})
+
+	if _, err := Interpolate(forecast, 7*time.Minute); err == nil {
+		t.Error("expected error for a step that does not divide one hour")
+	}
+	if _, err := Interpolate(forecast, 2*time.Hour); err == nil {
+		t.Error("expected error for a step above one hour")
+	}
+
+	// 120 hours at one-minute resolution exceeds the point cap
+	long := make([]float64, 120)
+	forecast = buildHourlySeries(t, map[string][]float64{ModelGfsSeamless: long})
+	if _, err := Interpolate(forecast, time.Minute); err == nil {
+		t.Error("expected error when the point cap is exceeded")
+	}
+}
@@ -0,0 +1,69 @@
+package weather
+
+import "medi/internal/snowpack"
+
+// applyMeltSignals attaches the spring melt indicators to each daily
+// forecast: the per-model melt degree hours for the day, and the rapid
+// warm-up flag when a day's melt energy jumps past the previous day's with
+// substantial snow still on the ground. The thresholds live in the snowpack
+// package; models without snow depth data get degree hours but never a
+// flag, since there is no way to tell whether anything is left to melt.
+func applyMeltSignals(forecast *Forecast) {
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+		for _, model := range overnightModels(day.HourlyForecasts) {
+			temps := make([]float64, 0, len(day.HourlyForecasts))
+			for _, hour := range day.HourlyForecasts {
+				if temperature, ok := hour.Temperature.GetForModel(model); ok {
+					temps = append(temps, temperature.Fahrenheit)
+				}
+			}
+			if day.MeltDegreeHours == nil {
+				day.MeltDegreeHours = ModelValues[float64]{}
+			}
+			day.MeltDegreeHours[model] = snowpack.MeltDegreeHours(temps)
+		}
+	}
+
+	// Rapid warm-up compares against the previous day, so the first day of
+	// the horizon has no baseline and never flags
+	for i := 1; i < len(forecast.DailyForecasts); i++ {
+		day := &forecast.DailyForecasts[i]
+		previous := forecast.DailyForecasts[i-1]
+
+		rated, flagged := 0, 0
+		for model, degreeHours := range day.MeltDegreeHours {
+			previousDegreeHours, ok := previous.MeltDegreeHours.GetForModel(model)
+			if !ok {
+				continue
+			}
+			if !hasSnowDepthData(day.HourlyForecasts, model) {
+				continue
+			}
+			depthInches := maxSnowDepthInches(day.HourlyForecasts, model)
+
+			rated++
+			if !snowpack.RapidWarmup(previousDegreeHours, degreeHours, depthInches) {
+				continue
+			}
+			if day.RapidWarmup == nil {
+				day.RapidWarmup = ModelValues[bool]{}
+			}
+			day.RapidWarmup[model] = true
+			flagged++
+		}
+		day.RapidWarmupConsensus = rated > 0 && flagged*2 > rated
+	}
+}
+
+// hasSnowDepthData reports whether the model carries snow depth in any of
+// the day's hours; a zero from maxSnowDepthInches alone cannot tell bare
+// ground apart from a model that never reports depth
+func hasSnowDepthData(hours []HourlyForecast, model string) bool {
+	for _, hour := range hours {
+		if _, ok := hour.SnowDepth.GetForModel(model); ok {
+			return true
+		}
+	}
+	return false
+}
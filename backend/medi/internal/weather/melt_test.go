@@ -0,0 +1,142 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// meltForecast builds a week-long Denver forecast whose hourly temperature
+// comes from tempAt, keyed by day index and hour of day. Models with a
+// non-nil depthAt also carry a flat snow depth per day, in inches; models
+// without one have no snow depth data at all.
+func meltForecast(days int, tempAt func(day, hour int) float64, depthAt func(day int) float64, models ...string) *Forecast {
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	if len(models) == 0 {
+		models = []string{ModelGfsSeamless}
+	}
+
+	forecast := &Forecast{
+		Timezone:     TimezoneDenver,
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7392, -104.9903),
+		},
+	}
+	for dayIndex := 0; dayIndex < days; dayIndex++ {
+		day := DailyForecast{Timestamp: time.Date(2025, 3, 10+dayIndex, 0, 0, 0, 0, denver)}
+		for hour := 0; hour < 24; hour++ {
+			start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+			hourly := HourlyForecast{
+				Start:       start,
+				End:         start.Add(time.Hour),
+				Temperature: ModelValues[types.Temperature]{},
+			}
+			for _, model := range models {
+				hourly.Temperature[model] = types.NewTemperatureFromFahrenheit(tempAt(dayIndex, hour))
+				if depthAt != nil {
+					if hourly.SnowDepth == nil {
+						hourly.SnowDepth = ModelValues[types.SnowDepth]{}
+					}
+					hourly.SnowDepth[model] = types.NewSnowDepthFromFeet(depthAt(dayIndex) / 12)
+				}
+			}
+			day.HourlyForecasts = append(day.HourlyForecasts, hourly)
+		}
+		forecast.DailyForecasts = append(forecast.DailyForecasts, day)
+	}
+	return forecast
+}
+
+func TestMeltSignalsColdWeek(t *testing.T) {
+	forecast := meltForecast(7,
+		func(day, hour int) float64 { return 18 },
+		func(day int) float64 { return 30 },
+	)
+	applyMeltSignals(forecast)
+
+	for i, day := range forecast.DailyForecasts {
+		degreeHours, ok := day.MeltDegreeHours.GetForModel(ModelGfsSeamless)
+		if !ok {
+			t.Fatalf("day %d: MeltDegreeHours missing for a model with temperature data", i)
+		}
+		if degreeHours != 0 {
+			t.Errorf("day %d: MeltDegreeHours = %v through a cold week, want 0", i, degreeHours)
+		}
+		if len(day.RapidWarmup) != 0 || day.RapidWarmupConsensus {
+			t.Errorf("day %d: rapid warm-up flagged through a cold week", i)
+		}
+	}
+}
+
+func TestMeltSignalsSpringWarmupRamp(t *testing.T) {
+	// A classic ramp: two cold days, a trace-melt day under the floor, then
+	// the jump — day 3 dwarfs day 2's melt energy while two feet of snow
+	// remain; day 4 stays warm but does not double again
+	highs := []float64{28, 30, 33, 48, 50}
+	forecast := meltForecast(len(highs),
+		func(day, hour int) float64 { return highs[day] },
+		func(day int) float64 { return 24 },
+	)
+	applyMeltSignals(forecast)
+
+	// Sanity-check the degree-hour sums behind the flag
+	wantDegreeHours := []float64{0, 0, 1 * 24, 16 * 24, 18 * 24}
+	for i, want := range wantDegreeHours {
+		got, _ := forecast.DailyForecasts[i].MeltDegreeHours.GetForModel(ModelGfsSeamless)
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("day %d: MeltDegreeHours = %v, want %v", i, got, want)
+		}
+	}
+
+	for i, day := range forecast.DailyForecasts {
+		flagged, _ := day.RapidWarmup.GetForModel(ModelGfsSeamless)
+		want := i == 3
+		if flagged != want {
+			t.Errorf("day %d: RapidWarmup = %v, want %v", i, flagged, want)
+		}
+		if day.RapidWarmupConsensus != want {
+			t.Errorf("day %d: RapidWarmupConsensus = %v, want %v", i, day.RapidWarmupConsensus, want)
+		}
+	}
+}
+
+func TestMeltSignalsModelsWithoutSnowDepthExcluded(t *testing.T) {
+	highs := []float64{30, 48}
+	forecast := meltForecast(len(highs),
+		func(day, hour int) float64 { return highs[day] },
+		nil, // no model carries snow depth
+	)
+	applyMeltSignals(forecast)
+
+	day := forecast.DailyForecasts[1]
+	if degreeHours, ok := day.MeltDegreeHours.GetForModel(ModelGfsSeamless); !ok || degreeHours == 0 {
+		t.Fatalf("MeltDegreeHours = %v (present %v); degree hours should not need snow depth", degreeHours, ok)
+	}
+	if len(day.RapidWarmup) != 0 {
+		t.Error("RapidWarmup flagged a model with no snow depth data")
+	}
+	if day.RapidWarmupConsensus {
+		t.Error("RapidWarmupConsensus set with no rated models")
+	}
+}
+
+func TestWeeklyDayCarriesMeltSignals(t *testing.T) {
+	highs := []float64{30, 48}
+	forecast := meltForecast(len(highs),
+		func(day, hour int) float64 { return highs[day] },
+		func(day int) float64 { return 24 },
+		ModelGfsSeamless, ModelEcmwIfs,
+	)
+	applyMeltSignals(forecast)
+
+	entry := weeklyDay(&forecast.DailyForecasts[1], Thresholds{})
+	if want := 16.0 * 24; math.Abs(entry.MeltDegreeHours-want) > 1e-9 {
+		t.Errorf("WeeklyDay.MeltDegreeHours = %v, want %v", entry.MeltDegreeHours, want)
+	}
+	if !entry.RapidWarmup {
+		t.Error("WeeklyDay.RapidWarmup not carried from the day's consensus")
+	}
+}
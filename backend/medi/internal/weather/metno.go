@@ -0,0 +1,234 @@
+package weather
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"medi/internal/providers/metno"
+	"medi/internal/types"
+)
+
+// ModelMetNo is the pseudo-model name used when the fallback provider serves
+// the forecast. MET Norway exposes a single merged forecast, not an ensemble,
+// so it appears as one model in ModelValues maps.
+const ModelMetNo = "MetNo"
+
+// metNoMissingFields lists what the compact Locationforecast cannot supply
+// compared to the primary multi-model forecast.
+var metNoMissingFields = []string{
+	"ensemble models",
+	"snowfall",
+	"rainfall/showers split",
+	"snow depth",
+	"freezing level height",
+	"precipitation probability",
+	"apparent temperature",
+	"feels-like",
+	"visibility",
+	"sunrise/sunset",
+	"daily weather summary",
+	"dominant wind direction",
+	"cloud cover bands",
+	"day/night flag",
+}
+
+// metNoSymbolCodes maps MET Norway symbol codes (with the _day/_night suffix
+// stripped) to the closest WMO weather code. Unmapped symbols leave the hour's
+// weather unset.
+var metNoSymbolCodes = map[string]int{
+	"clearsky":            0,
+	"fair":                1,
+	"partlycloudy":        2,
+	"cloudy":              3,
+	"fog":                 45,
+	"lightrain":           61,
+	"rain":                63,
+	"heavyrain":           65,
+	"lightrainshowers":    80,
+	"rainshowers":         81,
+	"heavyrainshowers":    82,
+	"lightsleet":          66,
+	"sleet":               66,
+	"heavysleet":          67,
+	"lightsleetshowers":   66,
+	"sleetshowers":        66,
+	"heavysleetshowers":   67,
+	"lightsnow":           71,
+	"snow":                73,
+	"heavysnow":           75,
+	"lightsnowshowers":    85,
+	"snowshowers":         85,
+	"heavysnowshowers":    86,
+	"rainandthunder":      95,
+	"heavyrainandthunder": 95,
+	"snowandthunder":      95,
+	"sleetandthunder":     95,
+}
+
+func metNoWeatherCode(symbolCode string) (int, bool) {
+	base, _, _ := strings.Cut(symbolCode, "_")
+	code, ok := metNoSymbolCodes[base]
+	return code, ok
+}
+
+// mapMetNoResponseToForecast maps a MET Norway compact forecast into the
+// domain model under the single ModelMetNo pseudo-model. Only hourly steps
+// are used; the 6-hour steps the API switches to later in the forecast do
+// not fit the hourly model and are dropped. The result is flagged degraded
+// with the fields the fallback cannot supply.
+func mapMetNoResponseToForecast(forecastPoint types.ForecastPoint, tz string, apiResponse *metno.CompactAPIResponse) (*Forecast, error) {
+	location, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone location %s: %w", tz, err)
+	}
+
+	forecast := &Forecast{
+		Timestamp:             time.Now().UTC(),
+		ForecastPoint:         forecastPoint,
+		Timezone:              tz,
+		PrimaryModel:          ModelMetNo,
+		Degraded:              true,
+		DegradedMissingFields: append([]string(nil), metNoMissingFields...),
+	}
+
+	var days []DailyForecast
+	dayIndex := map[string]int{}
+
+	for _, step := range apiResponse.Properties.Timeseries {
+		if step.Data.Next1Hours == nil {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, step.Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timeseries time %q: %w", step.Time, err)
+		}
+		localStart := start.In(location)
+
+		details := step.Data.Instant.Details
+		hour := HourlyForecast{
+			Start: localStart,
+			End:   localStart.Add(time.Hour),
+			Temperature: ModelValues[types.Temperature]{
+				ModelMetNo: types.NewTemperatureFromFahrenheit(details.AirTemperature*9/5 + 32),
+			},
+			Wind: ModelValues[types.Wind]{
+				ModelMetNo: metNoWind(details),
+			},
+			// MET Norway reports percentages 0-100; the domain model carries
+			// 0-1 fractions like the primary mapping
+			CloudCover: ModelValues[float64]{
+				ModelMetNo: details.CloudAreaFraction / 100.0,
+			},
+			RelativeHumidity: ModelValues[float64]{
+				ModelMetNo: details.RelativeHumidity / 100.0,
+			},
+			Precipitation: ModelValues[types.Precipitation]{
+				ModelMetNo: types.NewPrecipitationFromMillimeters(step.Data.Next1Hours.Details.PrecipitationAmount),
+			},
+		}
+		if code, ok := metNoWeatherCode(step.Data.Next1Hours.Summary.SymbolCode); ok {
+			hour.Weather = ModelValues[types.Weather]{ModelMetNo: types.NewWeather(code)}
+		}
+
+		dayKey := localStart.Format("2006-01-02")
+		idx, ok := dayIndex[dayKey]
+		if !ok {
+			idx = len(days)
+			dayIndex[dayKey] = idx
+			year, month, day := localStart.Date()
+			days = append(days, DailyForecast{
+				Timestamp: time.Date(year, month, day, 0, 0, 0, 0, location),
+			})
+		}
+		days[idx].HourlyForecasts = append(days[idx].HourlyForecasts, hour)
+	}
+
+	for i := range days {
+		days[i].HoursCovered = len(days[i].HourlyForecasts)
+		aggregateMetNoDay(&days[i])
+	}
+	forecast.DailyForecasts = days
+	applyHorizonEnd(forecast)
+
+	if len(apiResponse.Properties.Timeseries) > 0 {
+		forecast.CurrentConditions = metNoCurrentConditions(apiResponse.Properties.Timeseries[0])
+	}
+
+	applyCumulativeAccumulation(forecast)
+	applyRainOnSnow(forecast)
+	applySnowDensity(forecast)
+	applyMoonlight(forecast)
+	applyWeakLayerRisk(forecast)
+	applyWindExposure(forecast)
+
+	return forecast, nil
+}
+
+func metNoWind(details metno.InstantDetails) types.Wind {
+	speedMph := details.WindSpeed * types.MsToMph
+	direction := details.WindFromDirection
+	if details.WindSpeedOfGust == nil {
+		return types.NewWindWithoutGusts(speedMph, direction)
+	}
+	return types.NewWind(speedMph, *details.WindSpeedOfGust*types.MsToMph, direction)
+}
+
+// aggregateMetNoDay derives the daily aggregates the fallback can support
+// from the day's hourly values
+func aggregateMetNoDay(day *DailyForecast) {
+	var temps, speeds, gusts, precip []float64
+	for _, hour := range day.HourlyForecasts {
+		temps = append(temps, hour.Temperature[ModelMetNo].Fahrenheit)
+		wind := hour.Wind[ModelMetNo]
+		speeds = append(speeds, wind.Speed.Mph)
+		if wind.Gusts != nil {
+			gusts = append(gusts, wind.Gusts.Mph)
+		}
+		precip = append(precip, hour.Precipitation[ModelMetNo].Inches)
+	}
+
+	if high, ok := maxFloat(temps); ok {
+		low, _ := minFloat(temps)
+		day.HighTemperature = ModelValues[types.Temperature]{ModelMetNo: types.NewTemperatureFromFahrenheit(high)}
+		day.LowTemperature = ModelValues[types.Temperature]{ModelMetNo: types.NewTemperatureFromFahrenheit(low)}
+	}
+	if maxSpeed, ok := maxFloat(speeds); ok {
+		minSpeed, _ := minFloat(speeds)
+		day.MaxWindSpeed = ModelValues[types.WindSpeed]{ModelMetNo: types.NewWindSpeedFromMph(maxSpeed)}
+		day.MinWindSpeed = ModelValues[types.WindSpeed]{ModelMetNo: types.NewWindSpeedFromMph(minSpeed)}
+	}
+	if maxGust, ok := maxFloat(gusts); ok {
+		minGust, _ := minFloat(gusts)
+		day.MaxWindGusts = ModelValues[types.WindSpeed]{ModelMetNo: types.NewWindSpeedFromMph(maxGust)}
+		day.MinWindGusts = ModelValues[types.WindSpeed]{ModelMetNo: types.NewWindSpeedFromMph(minGust)}
+	}
+	if total, ok := sum(precip); ok {
+		day.TotalPrecipitation = ModelValues[types.Precipitation]{ModelMetNo: types.NewPrecipitationFromInches(total)}
+	}
+}
+
+func metNoCurrentConditions(step metno.TimeStep) CurrentConditions {
+	details := step.Data.Instant.Details
+	conditions := CurrentConditions{
+		Source: ModelValues[string]{ModelMetNo: CurrentSourceCurrent},
+		Temperature: ModelValues[types.Temperature]{
+			ModelMetNo: types.NewTemperatureFromFahrenheit(details.AirTemperature*9/5 + 32),
+		},
+		Wind: ModelValues[types.Wind]{
+			ModelMetNo: metNoWind(details),
+		},
+		CloudCover: ModelValues[float64]{
+			ModelMetNo: details.CloudAreaFraction / 100.0,
+		},
+		RelativeHumidity: ModelValues[float64]{
+			ModelMetNo: details.RelativeHumidity / 100.0,
+		},
+	}
+	if step.Data.Next1Hours != nil {
+		if code, ok := metNoWeatherCode(step.Data.Next1Hours.Summary.SymbolCode); ok {
+			conditions.Weather = ModelValues[types.Weather]{ModelMetNo: types.NewWeather(code)}
+		}
+	}
+	return conditions
+}
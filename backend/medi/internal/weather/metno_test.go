@@ -0,0 +1,186 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/providers/metno"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/testsupport"
+	"medi/internal/types"
+)
+
+func TestMapMetNoResponseToForecast(t *testing.T) {
+	apiResponse := testsupport.MetNoCompactFixture(t)
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.1154, -107.6584),
+		Elevation:   types.NewElevationFromFeet(9514),
+	}
+
+	forecast, err := mapMetNoResponseToForecast(forecastPoint, TimezoneDenver, apiResponse)
+	if err != nil {
+		t.Fatalf("mapMetNoResponseToForecast returned error: %v", err)
+	}
+
+	if forecast.PrimaryModel != ModelMetNo {
+		t.Errorf("primary model = %q, want %q", forecast.PrimaryModel, ModelMetNo)
+	}
+	if !forecast.Degraded {
+		t.Error("fallback forecast not flagged degraded")
+	}
+	hasSnowfall := false
+	for _, field := range forecast.DegradedMissingFields {
+		if field == "snowfall" {
+			hasSnowfall = true
+		}
+	}
+	if !hasSnowfall {
+		t.Errorf("missing fields %v do not list snowfall", forecast.DegradedMissingFields)
+	}
+
+	// The fixture has 30 hourly steps starting 2025-11-15T00:00Z, which is
+	// 17:00 the previous evening in Denver, plus two 6-hour steps that must
+	// be dropped
+	if len(forecast.DailyForecasts) != 2 {
+		t.Fatalf("got %d daily forecasts, want 2", len(forecast.DailyForecasts))
+	}
+	dayOne := forecast.DailyForecasts[0]
+	dayTwo := forecast.DailyForecasts[1]
+	if len(dayOne.HourlyForecasts) != 7 {
+		t.Errorf("day one has %d hours, want 7", len(dayOne.HourlyForecasts))
+	}
+	if len(dayTwo.HourlyForecasts) != 23 {
+		t.Errorf("day two has %d hours, want 23", len(dayTwo.HourlyForecasts))
+	}
+
+	location, _ := time.LoadLocation(TimezoneDenver)
+	hourOne := dayOne.HourlyForecasts[0]
+	wantStart := time.Date(2025, 11, 14, 17, 0, 0, 0, location)
+	if !hourOne.Start.Equal(wantStart) {
+		t.Errorf("first hour start = %v, want %v", hourOne.Start, wantStart)
+	}
+
+	// Unit conversions: -5 C, 5 m/s with 10 m/s gusts, 1.27 mm
+	if got := hourOne.Temperature[ModelMetNo].Fahrenheit; got != 23 {
+		t.Errorf("first hour temperature = %v F, want 23", got)
+	}
+	wind := hourOne.Wind[ModelMetNo]
+	if math.Abs(wind.Speed.Mph-5*types.MsToMph) > 1e-9 {
+		t.Errorf("first hour wind speed = %v mph, want %v", wind.Speed.Mph, 5*types.MsToMph)
+	}
+	if wind.Gusts == nil || math.Abs(wind.Gusts.Mph-10*types.MsToMph) > 1e-9 {
+		t.Errorf("first hour wind gusts = %v, want %v mph", wind.Gusts, 10*types.MsToMph)
+	}
+	if got := hourOne.Precipitation[ModelMetNo].Inches; math.Abs(got-0.05) > 1e-9 {
+		t.Errorf("first hour precipitation = %v inches, want 0.05", got)
+	}
+	if got := hourOne.Weather[ModelMetNo].Code; got != 71 {
+		t.Errorf("first hour weather code = %d, want 71 (light snow)", got)
+	}
+
+	// Step 5 in the fixture omits wind_speed_of_gust
+	if gusts := dayOne.HourlyForecasts[5].Wind[ModelMetNo].Gusts; gusts != nil {
+		t.Errorf("hour without gust data has gusts %v, want nil", gusts)
+	}
+	// Step 10 carries an unmapped symbol code
+	if _, present := dayTwo.HourlyForecasts[3].Weather[ModelMetNo]; present {
+		t.Error("hour with unmapped symbol code has a weather value")
+	}
+
+	// Daily aggregates: day one spans -5 to -2 C with 6 x 1.27 mm of precip
+	if got := dayOne.LowTemperature[ModelMetNo].Fahrenheit; got != 23 {
+		t.Errorf("day one low = %v F, want 23", got)
+	}
+	if got := dayOne.HighTemperature[ModelMetNo].Fahrenheit; math.Abs(got-28.4) > 1e-9 {
+		t.Errorf("day one high = %v F, want 28.4", got)
+	}
+	if got := dayOne.TotalPrecipitation[ModelMetNo].Inches; math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("day one total precipitation = %v inches, want 0.3", got)
+	}
+
+	// Current conditions come from the first instantaneous step
+	if got := forecast.CurrentConditions.Source[ModelMetNo]; got != CurrentSourceCurrent {
+		t.Errorf("current conditions source = %q, want %q", got, CurrentSourceCurrent)
+	}
+	if got := forecast.CurrentConditions.Temperature[ModelMetNo].Fahrenheit; got != 23 {
+		t.Errorf("current temperature = %v F, want 23", got)
+	}
+}
+
+type failingForecastProvider struct{}
+
+func (p *failingForecastProvider) GetForecast(_ context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error) {
+	return nil, errors.New("open-meteo unavailable")
+}
+
+type fixtureFallbackProvider struct {
+	t    *testing.T
+	fail bool
+}
+
+func (p *fixtureFallbackProvider) GetForecast(_ context.Context, latitude, longitude, elevationMeters float64) (*metno.CompactAPIResponse, error) {
+	if p.fail {
+		return nil, errors.New("met norway unavailable")
+	}
+	return testsupport.MetNoCompactFixture(p.t), nil
+}
+
+type stubTimezoneService struct{}
+
+func (s *stubTimezoneService) GetTimezone(latitude, longitude float64) (string, error) {
+	return TimezoneDenver, nil
+}
+
+func TestGetForecastFallsBackWhenPrimaryFails(t *testing.T) {
+	cfg := &config.Config{
+		App: config.AppConfig{ForecastDays: 3, PowderAlertInches: 6},
+	}
+	fallback := &fixtureFallbackProvider{t: t}
+	svc := NewWeatherServiceWithProvider(&failingForecastProvider{}, fallback, nil, nil, nil, nil, &stubTimezoneService{}, config.NewHolder(cfg), slog.Default())
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.1154, -107.6584),
+		Elevation:   types.NewElevationFromFeet(9514),
+	}
+
+	forecast, err := svc.GetForecast(context.Background(), forecastPoint, "")
+	if err != nil {
+		t.Fatalf("GetForecast returned error despite fallback: %v", err)
+	}
+	if !forecast.Degraded {
+		t.Error("fallback forecast not flagged degraded")
+	}
+	if forecast.PrimaryModel != ModelMetNo {
+		t.Errorf("primary model = %q, want %q", forecast.PrimaryModel, ModelMetNo)
+	}
+
+	// When the fallback is also down the primary's error surfaces
+	fallback.fail = true
+	if _, err := svc.GetForecast(context.Background(), forecastPoint, ""); err == nil {
+		t.Fatal("expected error when both providers fail, got nil")
+	}
+}
+
+func TestGetForecastNilLoggerDefaults(t *testing.T) {
+	cfg := &config.Config{
+		App: config.AppConfig{ForecastDays: 3, PowderAlertInches: 6},
+	}
+	// Both providers down with a nil logger: the error logging path runs
+	// against slog.Default() rather than panicking
+	svc := NewWeatherServiceWithProvider(&failingForecastProvider{},
+		&fixtureFallbackProvider{t: t, fail: true}, nil, nil, nil, nil, &stubTimezoneService{}, config.NewHolder(cfg), nil)
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.1154, -107.6584),
+		Elevation:   types.NewElevationFromFeet(9514),
+	}
+	if _, err := svc.GetForecast(context.Background(), forecastPoint, ""); err == nil {
+		t.Fatal("expected error when both providers fail, got nil")
+	}
+}
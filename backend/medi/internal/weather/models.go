@@ -1,8 +1,15 @@
 package weather
 
 import (
-	"medi/internal/types"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
+
+	"medi/internal/astro"
+	"medi/internal/snowpack"
+	"medi/internal/types"
 )
 
 const (
@@ -44,6 +51,113 @@ func (w ModelValues[T]) HasModel(model string) bool {
 	return ok
 }
 
+// canonicalModelOrder fixes the order models are iterated and serialized in:
+// the primary seamless model first, the other ensemble members after it, and
+// the fallback pseudo-model last. Models not listed sort alphabetically after
+// these.
+var canonicalModelOrder = []string{
+	ModelGfsSeamless,
+	ModelEcmwIfs,
+	ModelGemSeamless,
+	ModelNcepNbmConus,
+	ModelGfsGraphcast025,
+	ModelEcmwfAifs025Single,
+	ModelNcepNamConus,
+	ModelMetNo,
+}
+
+// ModelNames returns every model name a forecast may carry, in canonical
+// order; the serialization layer uses it to enumerate valid ModelValues keys
+func ModelNames() []string {
+	return append([]string(nil), canonicalModelOrder...)
+}
+
+var canonicalModelRank = func() map[string]int {
+	ranks := make(map[string]int, len(canonicalModelOrder))
+	for i, model := range canonicalModelOrder {
+		ranks[model] = i
+	}
+	return ranks
+}()
+
+// OrderedModels returns the models present in the map in canonical order
+func (w ModelValues[T]) OrderedModels() []string {
+	models := w.Models()
+	sort.Slice(models, func(i, j int) bool {
+		ri, iKnown := canonicalModelRank[models[i]]
+		rj, jKnown := canonicalModelRank[models[j]]
+		switch {
+		case iKnown && jKnown:
+			return ri < rj
+		case iKnown:
+			return true
+		case jKnown:
+			return false
+		default:
+			return models[i] < models[j]
+		}
+	})
+	return models
+}
+
+// ForEachOrdered calls fn once per model/value pair in canonical order
+func (w ModelValues[T]) ForEachOrdered(fn func(model string, value T)) {
+	for _, model := range w.OrderedModels() {
+		fn(model, w[model])
+	}
+}
+
+// Filter returns a new map holding only the listed models that are present;
+// a nil map stays nil
+func (w ModelValues[T]) Filter(models []string) ModelValues[T] {
+	if w == nil {
+		return nil
+	}
+	filtered := ModelValues[T]{}
+	for _, model := range models {
+		if value, ok := w[model]; ok {
+			filtered[model] = value
+		}
+	}
+	return filtered
+}
+
+// Merge copies the other map's entries into w, overwriting models present in
+// both; a merge source for combining per-model series from separate fetches
+func (w ModelValues[T]) Merge(other ModelValues[T]) {
+	for model, value := range other {
+		w[model] = value
+	}
+}
+
+// MarshalJSON emits keys in canonical model order rather than the map's
+// iteration order, so serialized forecasts are byte-stable across runs
+func (w ModelValues[T]) MarshalJSON() ([]byte, error) {
+	if w == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, model := range w.OrderedModels() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(model)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(w[model])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value for model %s: %w", model, err)
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
 type Forecast struct {
 	Timestamp         time.Time
 	ForecastPoint     types.ForecastPoint
@@ -51,9 +165,73 @@ type Forecast struct {
 	PrimaryModel      string
 	CurrentConditions CurrentConditions
 	DailyForecasts    []DailyForecast
+
+	// LookupTimezone is the zone the tzf polygon lookup resolved for the
+	// coordinates — the zone the upstream request asked for. Timezone stays
+	// the zone the provider echoed back, which is the zone the hourly
+	// timestamps were parsed in.
+	LookupTimezone string
+	// TimezoneMismatch is set when the lookup zone, the provider echo, and
+	// the NWS point zone (when one is known for the location) do not all
+	// agree — border locations where the day bucketing deserves scrutiny
+	TimezoneMismatch bool
+
+	// ThresholdProfile names the thresholds profile the derived blocks
+	// (powder alerts, first tracks, weekly scores) were computed under;
+	// Thresholds carries the resolved values
+	ThresholdProfile string
+	Thresholds       Thresholds
+
+	// ModelHorizons records, per model, the end of the window the model
+	// actually forecasts; values past it are stripped from the days and hours
+	ModelHorizons ModelValues[time.Time]
+
+	// HorizonEnd is where the hourly data stops: the final hourly entry's
+	// End. Derived windows and intervals are clamped to it so nothing reaches
+	// into hours the data does not cover.
+	HorizonEnd time.Time
+
+	// PowderAlerts flags the top 24-hour windows in the next five days whose
+	// ensemble median snowfall clears the configured threshold
+	PowderAlerts []PowderAlert
+
+	// Cumulative holds per-model running precipitation totals and ensemble
+	// percentile curves, computed once during mapping; responses include it
+	// only on request
+	Cumulative *CumulativeAccumulation
+
+	// Truncated is set when the daily block covers fewer days than the
+	// hourly series, which happens when some models fail upstream;
+	// TruncatedReason says what was cut short
+	Truncated       bool
+	TruncatedReason string
+
+	// Corrections lists the bias corrections applied after mapping, per
+	// model; nil when the location has none on file
+	Corrections ModelValues[BiasCorrection]
+
+	// StationBlend records the near-term nudge of the primary model toward a
+	// nearby station observation; nil when blending is disabled or no
+	// station qualified
+	StationBlend *snowpack.Blend
+
+	// ProbabilisticSnowfall bands the snowfall spread across individual
+	// ensemble member runs; it costs a second upstream fetch, so it is
+	// populated only for requests that opted in
+	ProbabilisticSnowfall *ProbabilisticSnowfall
+
+	// Degraded is set when the forecast came from the fallback provider
+	// rather than the multi-model primary; DegradedMissingFields lists the
+	// fields the fallback cannot supply
+	Degraded              bool
+	DegradedMissingFields []string
 }
 
 type CurrentConditions struct {
+	// Source records, per model, whether values came from the API's current
+	// block (CurrentSourceCurrent) or from the most recent past hourly entry
+	// (CurrentSourceHourly)
+	Source           ModelValues[string]
 	Temperature      ModelValues[types.Temperature]
 	Weather          ModelValues[types.Weather]
 	Wind             ModelValues[types.Wind]
@@ -68,6 +246,10 @@ type CurrentConditions struct {
 type DailyForecast struct {
 	Timestamp       time.Time
 	HourlyForecasts []HourlyForecast
+	// HoursCovered counts the hourly entries belonging to this local day; a
+	// value under the day's length means the hourly series covers it only
+	// partly, typically the final day at the forecast horizon
+	HoursCovered int
 
 	Weather                    ModelValues[types.Weather]
 	SnowfallWaterEquivalentSum ModelValues[float64]
@@ -79,26 +261,135 @@ type DailyForecast struct {
 	LowestFreezingLevelHeightFt  ModelValues[float64]
 	HighTemperature              ModelValues[types.Temperature]
 	LowTemperature               ModelValues[types.Temperature]
-	TotalPrecipitation           ModelValues[types.Precipitation]
-	TotalRainfall                ModelValues[types.Precipitation]
-	TotalShowers                 ModelValues[types.Precipitation]
-	TotalSnowfall                ModelValues[types.Precipitation]
-	TotalLiquidPrecipitation     ModelValues[types.Precipitation]
-	MaxWindSpeed                 ModelValues[types.WindSpeed]
-	MinWindSpeed                 ModelValues[types.WindSpeed]
-	MaxWindGusts                 ModelValues[types.WindSpeed]
-	MinWindGusts                 ModelValues[types.WindSpeed]
+	// MaxFeelsLike and MinFeelsLike are the day's hourly feels-like extremes
+	// per model, each carrying the source that produced it
+	MaxFeelsLike             ModelValues[types.FeelsLike]
+	MinFeelsLike             ModelValues[types.FeelsLike]
+	TotalPrecipitation       ModelValues[types.Precipitation]
+	TotalRain                ModelValues[types.Precipitation]
+	TotalShowers             ModelValues[types.Precipitation]
+	TotalSnowfall            ModelValues[types.Precipitation]
+	TotalLiquidPrecipitation ModelValues[types.Precipitation]
+	MaxWindSpeed             ModelValues[types.WindSpeed]
+	MinWindSpeed             ModelValues[types.WindSpeed]
+	MaxWindGusts             ModelValues[types.WindSpeed]
+	MinWindGusts             ModelValues[types.WindSpeed]
+
+	// PeakSnowfallHour is the Start of the hour with the highest snowfall per
+	// model. Models without snowfall are omitted.
+	PeakSnowfallHour ModelValues[time.Time]
+	// SnowfallIntensity buckets the day's hours by snowfall rate per model
+	SnowfallIntensity ModelValues[SnowfallIntensityHistogram]
+	// ConsensusPeakWindow is the 3-hour window where the most models place
+	// their peak snowfall hour, nil when no model forecasts snow
+	ConsensusPeakWindow *PeakWindow
+	// SnowfallSummary is a short sentence describing the consensus peak when
+	// a clear peak exists, empty otherwise
+	SnowfallSummary string
+
+	// RainOnSnow holds, per model, a rain-on-snow event for the day; models
+	// not forecasting one are omitted and the map is nil when none do
+	RainOnSnow ModelValues[RainOnSnowEvent]
+	// RainOnSnowConsensus is set when a majority of the models with liquid
+	// precipitation data flag the day
+	RainOnSnowConsensus bool
+
+	// DominantSnowDensity is the day's snowfall-weighted dominant density
+	// class per model; nil when no model forecasts snow
+	DominantSnowDensity ModelValues[string]
+	// UpsideDownStorm flags models whose storm densifies over its course,
+	// burying light snow under a slab; only flagged models appear, and
+	// UpsideDownStormConsensus is set when a majority of them agree
+	UpsideDownStorm          ModelValues[bool]
+	UpsideDownStormConsensus bool
+
+	// WindExposure labels the slope windward, lee, or cross-loaded per model,
+	// relative to the day's dominant wind direction; nil when the point's
+	// terrain is unknown or effectively flat
+	WindExposure ModelValues[string]
+
+	// WeakLayerFormationRisk is a heuristic rating of how favorable the
+	// overnight hours are for surface hoar and faceting; nil when the day has
+	// no overnight hours
+	WeakLayerFormationRisk *WeakLayerRisk
+
+	// OvernightRefreeze flags, per model, a melt-freeze night: after this day
+	// reached above freezing, the hours between sunset and the next sunrise
+	// spend at least the configured time below the refreeze threshold. Only
+	// models with overnight temperature data appear; nil when none have it.
+	OvernightRefreeze ModelValues[bool]
+	// CornSnowHour is, per model, the hour of the following morning when the
+	// temperature first climbs back above freezing after a refreeze — the
+	// corn-snow window opener. Models without a refreeze are omitted.
+	CornSnowHour ModelValues[time.Time]
+
+	// MeltDegreeHours is, per model, the day's melt energy: the sum of the
+	// hourly degrees above freezing, in °F·h. Zero through a cold week;
+	// models without hourly temperature data are omitted.
+	MeltDegreeHours ModelValues[float64]
+	// RapidWarmup flags models whose melt degree hours more than double
+	// day-over-day while substantial snow remains on the ground — the
+	// wet-slide and runoff setup. Models without snow depth data never
+	// appear, and RapidWarmupConsensus is set when a majority of the models
+	// with both signals agree.
+	RapidWarmup          ModelValues[bool]
+	RapidWarmupConsensus bool
+
+	// FirstTracks recommends, per model, the morning window after an
+	// overnight storm: it opens when the snowfall tapers (or at sunrise)
+	// and closes on strong gusts, warming, or sunset. Only models whose
+	// preceding night brought fresh snow appear; nil when none did.
+	FirstTracks ModelValues[FirstTracksWindow]
+	// FirstTracksConsensus is the overlap of the model windows when a
+	// majority of the models with overnight snowfall data found one
+	FirstTracksConsensus *FirstTracksWindow
+
+	// AvailableModels lists the models whose horizon covers this whole day,
+	// sorted; a UI can gray out the rest instead of showing gaps
+	AvailableModels []string
+
+	// Daylight carries computed solar times for this day: civil twilight and
+	// day length are not supplied upstream for every model
+	Daylight *astro.SunInfo
+	// DaylightStatus distinguishes ordinary days from polar day and polar
+	// night, when the sun neither rises nor sets and clients must not expect
+	// sunrise or sunset times
+	DaylightStatus string
+	// SkinningHours counts the daylight hours cold enough to keep the snow
+	// surface travelable and free of heavy precipitation, per the primary
+	// model
+	SkinningHours int
+
+	// Moon describes moonlight for the night of this day; computed locally,
+	// so it is present even on degraded fallback forecasts
+	Moon *astro.MoonInfo
+	// NightTravelLight rates how much usable moonlight the night offers,
+	// combining illumination with nighttime cloud cover
+	NightTravelLight string
+
+	// Alerts lists the active NWS alerts whose valid period overlaps this day
+	// in the location's timezone, clamped to the day, so a UI can badge
+	// specific days; nil when none overlap
+	Alerts []DailyAlert
 }
 
-// TODO openmeteo precip note: Some variables like precipitation are calculated from the preceding hour as an average or sum.
+// HourlyForecast covers the half-open interval [Start, End). Instantaneous
+// variables (temperature, wind, cloud cover, etc.) are valid at Start.
+// Open-Meteo delivers accumulation variables (Precipitation, Rainfall,
+// Showers, Snowfall) stamped at the end of the hour they describe; the mapping
+// shifts them so the values here are the accumulation that falls during
+// [Start, End).
 type HourlyForecast struct {
-	Start                    time.Time
-	End                      time.Time
-	FreezingLevelHeight      ModelValues[float64]
-	IsDay                    ModelValues[bool]
-	Weather                  ModelValues[types.Weather]
-	Temperature              ModelValues[types.Temperature]
-	ApparentTemperature      ModelValues[types.Temperature]
+	Start               time.Time
+	End                 time.Time
+	FreezingLevelHeight ModelValues[float64]
+	IsDay               ModelValues[bool]
+	Weather             ModelValues[types.Weather]
+	Temperature         ModelValues[types.Temperature]
+	ApparentTemperature ModelValues[types.Temperature]
+	// FeelsLike selects, per model, between wind chill, heat index, and the
+	// apparent temperature depending on the conditions (see types.NewFeelsLike)
+	FeelsLike                ModelValues[types.FeelsLike]
 	PrecipitationProbability ModelValues[float64]
 	Precipitation            ModelValues[types.Precipitation]
 	CloudCover               ModelValues[float64]
@@ -112,6 +403,9 @@ type HourlyForecast struct {
 	Showers                  ModelValues[types.Precipitation]
 	Snowfall                 ModelValues[types.Precipitation]
 	SnowDepth                ModelValues[types.SnowDepth]
+	// SnowDensity classifies each snowfall hour's density from the
+	// temperature during the fall; hours without snowfall are omitted
+	SnowDensity ModelValues[string]
 
 	// Sum of Rainfall and Showers
 	LiquidPrecipitation ModelValues[types.Precipitation]
@@ -0,0 +1,126 @@
+package weather
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderedModels(t *testing.T) {
+	values := ModelValues[float64]{
+		ModelMetNo:        1,
+		"ZzzExperimental": 2,
+		ModelNcepNamConus: 3,
+		"AaaExperimental": 4,
+		ModelGfsSeamless:  5,
+	}
+
+	want := []string{
+		ModelGfsSeamless, ModelNcepNamConus, ModelMetNo,
+		"AaaExperimental", "ZzzExperimental",
+	}
+	got := values.OrderedModels()
+	if len(got) != len(want) {
+		t.Fatalf("OrderedModels returned %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("OrderedModels returned %v, want %v", got, want)
+		}
+	}
+}
+
+func TestForEachOrdered(t *testing.T) {
+	values := ModelValues[int]{
+		ModelEcmwIfs:     2,
+		ModelGfsSeamless: 1,
+	}
+
+	var models []string
+	var sum int
+	values.ForEachOrdered(func(model string, value int) {
+		models = append(models, model)
+		sum += value
+	})
+	if len(models) != 2 || models[0] != ModelGfsSeamless || models[1] != ModelEcmwIfs {
+		t.Errorf("ForEachOrdered visited %v, want [%s %s]", models, ModelGfsSeamless, ModelEcmwIfs)
+	}
+	if sum != 3 {
+		t.Errorf("ForEachOrdered value sum = %d, want 3", sum)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	values := ModelValues[int]{
+		ModelGfsSeamless: 1,
+		ModelEcmwIfs:     2,
+		ModelGemSeamless: 3,
+	}
+
+	filtered := values.Filter([]string{ModelGfsSeamless, ModelGemSeamless, ModelNcepNamConus})
+	if len(filtered) != 2 {
+		t.Fatalf("Filter returned %d models, want 2", len(filtered))
+	}
+	if !filtered.HasModel(ModelGfsSeamless) || !filtered.HasModel(ModelGemSeamless) {
+		t.Errorf("Filter returned %v, want GFS and GEM", filtered.Models())
+	}
+	if filtered.HasModel(ModelEcmwIfs) {
+		t.Error("Filter kept a model not in the list")
+	}
+
+	var nilValues ModelValues[int]
+	if nilValues.Filter([]string{ModelGfsSeamless}) != nil {
+		t.Error("Filter of a nil map did not stay nil")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	values := ModelValues[int]{ModelGfsSeamless: 1, ModelEcmwIfs: 2}
+	values.Merge(ModelValues[int]{ModelEcmwIfs: 20, ModelGemSeamless: 3})
+
+	if len(values) != 3 {
+		t.Fatalf("merged map has %d models, want 3", len(values))
+	}
+	if values[ModelEcmwIfs] != 20 {
+		t.Errorf("merge did not overwrite ECMWF value, got %d", values[ModelEcmwIfs])
+	}
+	if values[ModelGemSeamless] != 3 {
+		t.Errorf("merge did not add GEM value, got %d", values[ModelGemSeamless])
+	}
+}
+
+func TestMarshalJSONCanonicalOrder(t *testing.T) {
+	values := ModelValues[int]{
+		ModelMetNo:        3,
+		ModelGfsSeamless:  1,
+		ModelNcepNamConus: 2,
+	}
+
+	want := `{"GfsSeamless":1,"NcepNamConus":2,"MetNo":3}`
+	first, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(first) != want {
+		t.Errorf("marshal = %s, want %s", first, want)
+	}
+
+	// Repeated marshals are byte-identical despite map iteration randomness
+	for i := 0; i < 20; i++ {
+		again, err := json.Marshal(values)
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("marshal output changed between runs: %s vs %s", first, again)
+		}
+	}
+
+	var nilValues ModelValues[int]
+	data, err := json.Marshal(nilValues)
+	if err != nil {
+		t.Fatalf("marshal of nil map failed: %v", err)
+	}
+	if string(data) != "null" {
+		t.Errorf("marshal of nil map = %s, want null", data)
+	}
+}
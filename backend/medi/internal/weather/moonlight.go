@@ -0,0 +1,93 @@
+package weather
+
+import (
+	"medi/internal/astro"
+)
+
+// NightTravelLight ratings for DailyForecast.NightTravelLight.
+const (
+	NightTravelLightGood = "good" // bright moon and mostly clear skies
+	NightTravelLightFair = "fair" // some moonlight reaches the snow
+	NightTravelLightPoor = "poor" // headlamp-only travel
+)
+
+// nightTravelLight thresholds on effective moonlight (illumination attenuated
+// by nighttime cloud cover).
+const (
+	nightTravelLightGoodThreshold = 0.40
+	nightTravelLightFairThreshold = 0.15
+)
+
+// applyMoonlight attaches a Moon block and a NightTravelLight rating to each
+// daily forecast. The astronomy is computed locally so this also runs for
+// degraded fallback forecasts.
+func applyMoonlight(forecast *Forecast) {
+	latitude := forecast.ForecastPoint.Coordinates.Latitude
+	longitude := forecast.ForecastPoint.Coordinates.Longitude
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+		moon := astro.Moon(day.Timestamp, latitude, longitude)
+		day.Moon = &moon
+
+		cloudCover, haveClouds := nighttimeCloudCover(day.HourlyForecasts)
+		day.NightTravelLight = nightTravelLight(moon.Illumination, cloudCover, haveClouds)
+	}
+}
+
+// nighttimeCloudCover averages cloud cover across models for the night hours
+// of the day. It returns ok=false when no night hour carries cloud cover
+// data — including under the midnight sun, where there are no night hours at
+// all and the rating falls back to illumination alone.
+func nighttimeCloudCover(hours []HourlyForecast) (float64, bool) {
+	sum, count := 0.0, 0
+	for _, hour := range hours {
+		if !nightHour(hour) {
+			continue
+		}
+		for _, cover := range hour.CloudCover {
+			sum += cover
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// nightHour reports whether an hour is dark. The model day flags decide when
+// present — they stay correct through polar day and polar night, where a
+// clock-based split would not — with before 06:00 and from 18:00 local as the
+// fallback.
+func nightHour(hour HourlyForecast) bool {
+	if len(hour.IsDay) > 0 {
+		for _, isDay := range hour.IsDay {
+			if isDay {
+				return false
+			}
+		}
+		return true
+	}
+	h := hour.Start.Hour()
+	return h < 6 || h >= 18
+}
+
+// nightTravelLight rates usable moonlight: the illuminated fraction scaled
+// down by how much of the night sky is clouded over. Without cloud data the
+// rating falls back to illumination alone.
+func nightTravelLight(illumination, cloudCover float64, haveClouds bool) string {
+	effective := illumination
+	if haveClouds {
+		effective = illumination * (1 - cloudCover/100)
+	}
+
+	switch {
+	case effective >= nightTravelLightGoodThreshold:
+		return NightTravelLightGood
+	case effective >= nightTravelLightFairThreshold:
+		return NightTravelLightFair
+	default:
+		return NightTravelLightPoor
+	}
+}
@@ -0,0 +1,75 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+func TestNightTravelLight(t *testing.T) {
+	tests := []struct {
+		name         string
+		illumination float64
+		cloudCover   float64
+		haveClouds   bool
+		want         string
+	}{
+		{"full moon clear", 1.0, 0, true, NightTravelLightGood},
+		{"full moon overcast", 1.0, 95, true, NightTravelLightPoor},
+		{"half moon scattered", 0.5, 40, true, NightTravelLightFair},
+		{"new moon clear", 0.02, 0, true, NightTravelLightPoor},
+		{"no cloud data falls back to illumination", 0.5, 0, false, NightTravelLightGood},
+	}
+	for _, tc := range tests {
+		if got := nightTravelLight(tc.illumination, tc.cloudCover, tc.haveClouds); got != tc.want {
+			t.Errorf("%s: nightTravelLight = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplyMoonlightAttachesMoonBlock(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	// Full moon night with overcast skies after dark
+	day := DailyForecast{Timestamp: time.Date(2025, 1, 13, 0, 0, 0, 0, denver)}
+	for hour := 0; hour < 24; hour++ {
+		start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+		cover := 10.0
+		if hour < 6 || hour >= 18 {
+			cover = 100.0
+		}
+		day.HourlyForecasts = append(day.HourlyForecasts, HourlyForecast{
+			Start:      start,
+			End:        start.Add(time.Hour),
+			CloudCover: ModelValues[float64]{ModelGfsSeamless: cover},
+		})
+	}
+
+	forecast := &Forecast{
+		Timezone: "America/Denver",
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7392, -104.9903),
+		},
+		DailyForecasts: []DailyForecast{day},
+	}
+	applyMoonlight(forecast)
+
+	moon := forecast.DailyForecasts[0].Moon
+	if moon == nil {
+		t.Fatal("Moon block not attached")
+	}
+	if moon.Illumination < 0.98 {
+		t.Errorf("Illumination = %.3f, want ~1.0 at full moon", moon.Illumination)
+	}
+	if moon.Rise == nil || moon.Set == nil {
+		t.Fatal("expected both moonrise and moonset at mid latitude")
+	}
+	// A fully clouded night blacks out even a full moon
+	if got := forecast.DailyForecasts[0].NightTravelLight; got != NightTravelLightPoor {
+		t.Errorf("NightTravelLight = %s, want %s under full overcast", got, NightTravelLightPoor)
+	}
+}
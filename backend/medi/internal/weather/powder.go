@@ -0,0 +1,183 @@
+package weather
+
+import (
+	"sort"
+	"time"
+
+	"medi/internal/types"
+)
+
+// Powder alert windowing
+const (
+	// powderAlertWindowHours is the width of an alert window
+	powderAlertWindowHours = 24
+	// powderAlertHorizonHours limits alerts to the next 5 days
+	powderAlertHorizonHours = 5 * 24
+	// powderAlertMaxWindows caps how many windows are reported
+	powderAlertMaxWindows = 2
+)
+
+// PowderAlert flags a 24-hour window whose ensemble median snowfall reaches
+// the configured threshold.
+type PowderAlert struct {
+	Start time.Time
+	End   time.Time
+	// MedianInches is the summed ensemble median snowfall over the window
+	MedianInches float64
+	// ExpectedMinInches and ExpectedMaxInches bound the expected range as the
+	// 25th and 75th percentiles of the per-model window totals
+	ExpectedMinInches float64
+	ExpectedMaxInches float64
+	// Confidence is the fraction of models whose own window total clears the
+	// threshold
+	Confidence float64
+}
+
+// computePowderAlerts scans the next five days of hourly forecasts for
+// 24-hour windows whose ensemble median snowfall reaches the profile's alert
+// threshold. At most the top two non-overlapping windows are returned,
+// ordered by start time.
+func computePowderAlerts(dailyForecasts []DailyForecast, thresholds Thresholds) []PowderAlert {
+	thresholdInches := thresholds.PowderAlertInches
+	if thresholdInches <= 0 {
+		return nil
+	}
+
+	hours := make([]HourlyForecast, 0, powderAlertHorizonHours)
+	for _, day := range dailyForecasts {
+		for _, hour := range day.HourlyForecasts {
+			if len(hours) == powderAlertHorizonHours {
+				break
+			}
+			hours = append(hours, hour)
+		}
+	}
+	if len(hours) < powderAlertWindowHours {
+		return nil
+	}
+
+	medians := make([]float64, len(hours))
+	for i, hour := range hours {
+		medians[i] = medianSnowfall(hour.Snowfall)
+	}
+
+	// Total the medians over every candidate window
+	type window struct {
+		start int
+		total float64
+	}
+	candidates := make([]window, 0)
+	runningTotal := 0.0
+	for i, median := range medians {
+		runningTotal += median
+		if i >= powderAlertWindowHours {
+			runningTotal -= medians[i-powderAlertWindowHours]
+		}
+		if i >= powderAlertWindowHours-1 && runningTotal >= thresholdInches {
+			candidates = append(candidates, window{start: i - powderAlertWindowHours + 1, total: runningTotal})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Take the snowiest windows first, skipping any that overlap an already
+	// selected window. Ties resolve to the earliest window.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].total == candidates[j].total {
+			return candidates[i].start < candidates[j].start
+		}
+		return candidates[i].total > candidates[j].total
+	})
+
+	selected := make([]window, 0, powderAlertMaxWindows)
+	for _, candidate := range candidates {
+		overlaps := false
+		for _, s := range selected {
+			if candidate.start < s.start+powderAlertWindowHours && s.start < candidate.start+powderAlertWindowHours {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		selected = append(selected, candidate)
+		if len(selected) == powderAlertMaxWindows {
+			break
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].start < selected[j].start })
+
+	alerts := make([]PowderAlert, 0, len(selected))
+	for _, w := range selected {
+		alerts = append(alerts, buildPowderAlert(hours[w.start:w.start+powderAlertWindowHours], w.total, thresholdInches))
+	}
+	return alerts
+}
+
+// medianSnowfall returns the median hourly snowfall across models
+func medianSnowfall(snowfall ModelValues[types.Precipitation]) float64 {
+	values := make([]float64, 0, len(snowfall))
+	for _, amount := range snowfall {
+		values = append(values, amount.Inches)
+	}
+	if len(values) == 0 {
+		return 0
+	}
+
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// buildPowderAlert derives the expected range and confidence for one window
+// from the per-model window totals
+func buildPowderAlert(windowHours []HourlyForecast, medianTotal, thresholdInches float64) PowderAlert {
+	totals := map[string]float64{}
+	for _, hour := range windowHours {
+		for model, amount := range hour.Snowfall {
+			totals[model] += amount.Inches
+		}
+	}
+
+	values := make([]float64, 0, len(totals))
+	clearing := 0
+	for _, total := range totals {
+		values = append(values, total)
+		if total >= thresholdInches {
+			clearing++
+		}
+	}
+	sort.Float64s(values)
+
+	alert := PowderAlert{
+		Start:        windowHours[0].Start,
+		End:          windowHours[len(windowHours)-1].End,
+		MedianInches: medianTotal,
+	}
+	if len(values) > 0 {
+		alert.ExpectedMinInches = percentile(values, 0.25)
+		alert.ExpectedMaxInches = percentile(values, 0.75)
+		alert.Confidence = float64(clearing) / float64(len(values))
+	}
+	return alert
+}
+
+// percentile interpolates the p-quantile of an ascending-sorted slice
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := rank - float64(lower)
+	return sorted[lower] + (sorted[lower+1]-sorted[lower])*frac
+}
@@ -0,0 +1,159 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// buildForecastDays builds daily forecasts with hourly snowfall taken from
+// per-model rate series of length days*24
+func buildForecastDays(t *testing.T, days int, rates map[string][]float64) []DailyForecast {
+	t.Helper()
+
+	dayStart := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	forecasts := make([]DailyForecast, days)
+	for d := 0; d < days; d++ {
+		hourly := make([]HourlyForecast, 24)
+		for h := 0; h < 24; h++ {
+			snowfall := make(ModelValues[types.Precipitation])
+			for model, values := range rates {
+				snowfall[model] = types.NewPrecipitationFromInches(values[d*24+h])
+			}
+			start := dayStart.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour)
+			hourly[h] = HourlyForecast{
+				Start:    start,
+				End:      start.Add(time.Hour),
+				Snowfall: snowfall,
+			}
+		}
+		forecasts[d] = DailyForecast{
+			Timestamp:       dayStart.AddDate(0, 0, d),
+			HourlyForecasts: hourly,
+		}
+	}
+	return forecasts
+}
+
+func TestComputePowderAlertsQualifyingStorm(t *testing.T) {
+	hours := 5 * 24
+	gfs := make([]float64, hours)
+	gem := make([]float64, hours)
+	ecmwf := make([]float64, hours)
+
+	// A clear storm on day two: every model drops 0.8 in/hr from 12:00 to
+	// midnight, 9.6 inches in 12 hours
+	for h := 60; h < 72; h++ {
+		gfs[h] = 0.8
+		gem[h] = 0.8
+		ecmwf[h] = 0.8
+	}
+
+	// A marginal event on day four only GFS believes in; the ensemble
+	// median stays at zero so it must not alert
+	for h := 102; h < 108; h++ {
+		gfs[h] = 0.9
+	}
+
+	days := buildForecastDays(t, 5, map[string][]float64{
+		ModelGfsSeamless: gfs,
+		ModelGemSeamless: gem,
+		ModelEcmwIfs:     ecmwf,
+	})
+
+	alerts := computePowderAlerts(days, Thresholds{PowderAlertInches: 6.0})
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(alerts))
+	}
+
+	alert := alerts[0]
+	if math.Abs(alert.MedianInches-9.6) > 1e-9 {
+		t.Errorf("median = %v inches, want 9.6", alert.MedianInches)
+	}
+	if alert.Confidence != 1 {
+		t.Errorf("confidence = %v, want 1", alert.Confidence)
+	}
+	if !alert.End.After(alert.Start) {
+		t.Errorf("window end %v not after start %v", alert.End, alert.Start)
+	}
+	// The window must contain the storm
+	stormPeak := days[2].HourlyForecasts[18].Start
+	if stormPeak.Before(alert.Start) || !stormPeak.Before(alert.End) {
+		t.Errorf("storm peak %v outside alert window %v - %v", stormPeak, alert.Start, alert.End)
+	}
+}
+
+func TestComputePowderAlertsMarginalConfidence(t *testing.T) {
+	hours := 5 * 24
+	gfs := make([]float64, hours)
+	gem := make([]float64, hours)
+	ecmwf := make([]float64, hours)
+
+	// Models disagree on a day-two event: GFS 7.0", GEM 6.5", ECMWF 2.0".
+	// The hourly medians sum to 6.5", just over the threshold.
+	for h := 60; h < 70; h++ {
+		gfs[h] = 0.7
+		gem[h] = 0.65
+		ecmwf[h] = 0.2
+	}
+
+	days := buildForecastDays(t, 5, map[string][]float64{
+		ModelGfsSeamless: gfs,
+		ModelGemSeamless: gem,
+		ModelEcmwIfs:     ecmwf,
+	})
+
+	alerts := computePowderAlerts(days, Thresholds{PowderAlertInches: 6.0})
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(alerts))
+	}
+
+	alert := alerts[0]
+	if math.Abs(alert.Confidence-2.0/3.0) > 1e-9 {
+		t.Errorf("confidence = %v, want 2/3", alert.Confidence)
+	}
+	// p25 and p75 of the per-model totals 2.0, 6.5, 7.0
+	if math.Abs(alert.ExpectedMinInches-4.25) > 1e-9 {
+		t.Errorf("expected min = %v inches, want 4.25", alert.ExpectedMinInches)
+	}
+	if math.Abs(alert.ExpectedMaxInches-6.75) > 1e-9 {
+		t.Errorf("expected max = %v inches, want 6.75", alert.ExpectedMaxInches)
+	}
+}
+
+func TestComputePowderAlertsTopTwoWindows(t *testing.T) {
+	hours := 5 * 24
+	gfs := make([]float64, hours)
+
+	// Three separate storms; only the two largest may be reported, in
+	// chronological order
+	for h := 6; h < 14; h++ {
+		gfs[h] = 1.0 // day zero, 8 inches
+	}
+	for h := 54; h < 64; h++ {
+		gfs[h] = 1.0 // day two, 10 inches
+	}
+	for h := 110; h < 117; h++ {
+		gfs[h] = 1.0 // day four, 7 inches
+	}
+
+	days := buildForecastDays(t, 5, map[string][]float64{
+		ModelGfsSeamless: gfs,
+	})
+
+	alerts := computePowderAlerts(days, Thresholds{PowderAlertInches: 6.0})
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2", len(alerts))
+	}
+	if !alerts[0].Start.Before(alerts[1].Start) {
+		t.Errorf("alerts out of order: %v before %v", alerts[0].Start, alerts[1].Start)
+	}
+	if math.Abs(alerts[0].MedianInches-8) > 1e-9 {
+		t.Errorf("first alert median = %v inches, want 8", alerts[0].MedianInches)
+	}
+	if math.Abs(alerts[1].MedianInches-10) > 1e-9 {
+		t.Errorf("second alert median = %v inches, want 10", alerts[1].MedianInches)
+	}
+}
@@ -0,0 +1,165 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"medi/internal/types"
+)
+
+// Elevation band layout relative to the forecast point: every 500 m from
+// 500 m below the point to 1500 m above it.
+const (
+	profileBandStepMeters  = 500.0
+	profileBandsBelowPoint = 1
+	profileBandsAbovePoint = 3
+)
+
+// maxProfileHours caps the requested window at the longest hourly series the
+// multi-model forecast can deliver
+const maxProfileHours = 16 * 24
+
+// Per-hour calibration accepts only plausible lapse rates: negative fits mean
+// the freezing level contradicts the point temperature (an inversion the
+// linear profile cannot represent) and steeper fits mean the freezing level
+// sits too close to the point to divide by.
+const maxCalibratedLapseRateCPerKm = 15.0
+
+var (
+	// ErrInvalidProfileHours is returned when the requested hour count is out
+	// of range
+	ErrInvalidProfileHours = errors.New("profile hours must be between 1 and 384")
+	// ErrNoProfileTemperatures is returned when the primary model supplies no
+	// hourly temperatures to extrapolate from
+	ErrNoProfileTemperatures = errors.New("no primary model temperatures to build a profile from")
+)
+
+// TemperatureProfile is an hour × elevation matrix of estimated temperatures
+// around a forecast point, derived from the primary model's hourly
+// temperature and a lapse rate.
+type TemperatureProfile struct {
+	Timezone       string
+	PrimaryModel   string
+	PointElevation types.Elevation
+
+	// LapseRateCPerKm is the configured fixed rate, used for hours the model
+	// freezing level cannot calibrate
+	LapseRateCPerKm float64
+
+	// BandElevations lists the band elevations from valley to summit; every
+	// hour's Temperatures slice aligns with it
+	BandElevations []types.Elevation
+
+	Hours []TemperatureProfileHour
+}
+
+// TemperatureProfileHour is one row of the matrix.
+type TemperatureProfileHour struct {
+	Start time.Time
+
+	// Temperatures holds the estimated temperature per band, aligned with the
+	// profile's BandElevations
+	Temperatures []types.Temperature
+
+	// FreezingAltitude is where the hour's profile crosses 0°C, nil when the
+	// whole band range stays on one side of freezing
+	FreezingAltitude *types.Elevation
+
+	// LapseRateCPerKm is the rate this hour was built with; Calibrated is set
+	// when it was fit to the primary model's freezing level instead of the
+	// configured constant
+	LapseRateCPerKm float64
+	Calibrated      bool
+}
+
+// ComputeTemperatureProfile extrapolates the primary model's hourly
+// temperature to standard elevation bands around the forecast point. Hours
+// where the model reports a usable freezing level get a lapse rate calibrated
+// to hit 0°C exactly there; the rest fall back to the fixed configured rate.
+func ComputeTemperatureProfile(forecast *Forecast, lapseRateCPerKm float64, hours int) (*TemperatureProfile, error) {
+	if hours < 1 || hours > maxProfileHours {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidProfileHours, hours)
+	}
+
+	pointElevation := forecast.ForecastPoint.Elevation
+
+	profile := &TemperatureProfile{
+		Timezone:        forecast.Timezone,
+		PrimaryModel:    forecast.PrimaryModel,
+		PointElevation:  pointElevation,
+		LapseRateCPerKm: lapseRateCPerKm,
+	}
+	for band := -profileBandsBelowPoint; band <= profileBandsAbovePoint; band++ {
+		meters := pointElevation.Meters + float64(band)*profileBandStepMeters
+		profile.BandElevations = append(profile.BandElevations, types.NewElevationFromMeters(meters))
+	}
+
+	for _, hour := range forecast.Hours() {
+		if len(profile.Hours) == hours {
+			break
+		}
+
+		temperature, ok := hour.Temperature.GetForModel(forecast.PrimaryModel)
+		if !ok {
+			continue
+		}
+
+		rate := lapseRateCPerKm
+		calibrated := false
+		if freezingLevelFt, ok := hour.FreezingLevelHeight.GetForModel(forecast.PrimaryModel); ok {
+			if fit, ok := calibrateLapseRate(temperature.Celsius, pointElevation.Meters, freezingLevelFt*types.FeetToMeters); ok {
+				rate = fit
+				calibrated = true
+			}
+		}
+
+		profileHour := TemperatureProfileHour{
+			Start:           hour.Start,
+			LapseRateCPerKm: rate,
+			Calibrated:      calibrated,
+		}
+		for _, band := range profile.BandElevations {
+			celsius := temperature.Celsius - rate*(band.Meters-pointElevation.Meters)/1000
+			profileHour.Temperatures = append(profileHour.Temperatures, types.NewTemperatureFromCelsius(celsius))
+		}
+		profileHour.FreezingAltitude = freezingAltitude(temperature.Celsius, pointElevation.Meters, rate, profile.BandElevations)
+
+		profile.Hours = append(profile.Hours, profileHour)
+	}
+
+	if len(profile.Hours) == 0 {
+		return nil, ErrNoProfileTemperatures
+	}
+	return profile, nil
+}
+
+// calibrateLapseRate fits the lapse rate that makes the linear profile
+// through the point temperature reach 0°C at the model's freezing level.
+// It reports false for fits outside the plausible range, leaving the hour on
+// the fixed rate.
+func calibrateLapseRate(pointCelsius, pointMeters, freezingLevelMeters float64) (float64, bool) {
+	deltaKm := (freezingLevelMeters - pointMeters) / 1000
+	if deltaKm == 0 {
+		return 0, false
+	}
+	rate := pointCelsius / deltaKm
+	if rate <= 0 || rate > maxCalibratedLapseRateCPerKm {
+		return 0, false
+	}
+	return rate, true
+}
+
+// freezingAltitude solves the hour's profile for 0°C, nil when the crossing
+// falls outside the band range (the whole matrix is above or below freezing).
+func freezingAltitude(pointCelsius, pointMeters, rate float64, bands []types.Elevation) *types.Elevation {
+	if rate <= 0 {
+		return nil
+	}
+	meters := pointMeters + pointCelsius/rate*1000
+	if meters < bands[0].Meters || meters > bands[len(bands)-1].Meters {
+		return nil
+	}
+	altitude := types.NewElevationFromMeters(meters)
+	return &altitude
+}
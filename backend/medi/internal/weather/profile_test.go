@@ -0,0 +1,219 @@
+package weather
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// buildProfileForecast wraps primary-model hourly temperatures (Celsius) and
+// optional freezing levels (meters, NaN to omit the hour's value) in a
+// single-day forecast for a point at the given elevation
+func buildProfileForecast(t *testing.T, elevationMeters float64, tempsC, freezingLevelsM []float64) *Forecast {
+	t.Helper()
+
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	day := DailyForecast{Timestamp: start}
+	for i, tempC := range tempsC {
+		hour := HourlyForecast{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			End:   start.Add(time.Duration(i+1) * time.Hour),
+			Temperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromCelsius(tempC),
+			},
+		}
+		if i < len(freezingLevelsM) && !math.IsNaN(freezingLevelsM[i]) {
+			hour.FreezingLevelHeight = ModelValues[float64]{
+				ModelGfsSeamless: freezingLevelsM[i] / types.FeetToMeters,
+			}
+		}
+		day.HourlyForecasts = append(day.HourlyForecasts, hour)
+	}
+
+	return &Forecast{
+		Timezone:     "UTC",
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Elevation: types.NewElevationFromMeters(elevationMeters),
+		},
+		DailyForecasts: []DailyForecast{day},
+	}
+}
+
+func TestCalibrateLapseRate(t *testing.T) {
+	tests := []struct {
+		name           string
+		pointCelsius   float64
+		freezingLevelM float64
+		expectedRate   float64
+		expectedOk     bool
+	}{
+		{"freezing level above a warm point", 6.5, 4000, 6.5, true},
+		{"freezing level below a cold point", -4, 2000, 4, true},
+		{"inversion contradicts the point temperature", 5, 2500, 0, false},
+		{"freezing level too close to divide by", 5, 3100, 0, false},
+		{"freezing level at the point", 5, 3000, 0, false},
+		{"point exactly at freezing", 0, 4000, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, ok := calibrateLapseRate(tt.pointCelsius, 3000, tt.freezingLevelM)
+			if ok != tt.expectedOk {
+				t.Fatalf("calibrateLapseRate ok = %v, want %v", ok, tt.expectedOk)
+			}
+			if math.Abs(rate-tt.expectedRate) > 1e-9 {
+				t.Errorf("calibrateLapseRate = %v, want %v", rate, tt.expectedRate)
+			}
+		})
+	}
+}
+
+func TestComputeTemperatureProfileFixedRate(t *testing.T) {
+	forecast := buildProfileForecast(t, 3000, []float64{8}, nil)
+
+	profile, err := ComputeTemperatureProfile(forecast, 6.5, 24)
+	if err != nil {
+		t.Fatalf("ComputeTemperatureProfile returned error: %v", err)
+	}
+
+	expectedBands := []float64{2500, 3000, 3500, 4000, 4500}
+	if len(profile.BandElevations) != len(expectedBands) {
+		t.Fatalf("got %d bands, want %d", len(profile.BandElevations), len(expectedBands))
+	}
+	for i, meters := range expectedBands {
+		if math.Abs(profile.BandElevations[i].Meters-meters) > 1e-9 {
+			t.Errorf("band %d = %v m, want %v m", i, profile.BandElevations[i].Meters, meters)
+		}
+	}
+
+	if len(profile.Hours) != 1 {
+		t.Fatalf("got %d hours, want 1", len(profile.Hours))
+	}
+	hour := profile.Hours[0]
+	if hour.Calibrated {
+		t.Error("hour without a freezing level flagged calibrated")
+	}
+	if hour.LapseRateCPerKm != 6.5 {
+		t.Errorf("hour lapse rate = %v, want the fixed 6.5", hour.LapseRateCPerKm)
+	}
+
+	// 8°C at the point, cooling 6.5°C per km upward
+	expectedTemps := []float64{11.25, 8, 4.75, 1.5, -1.75}
+	for i, celsius := range expectedTemps {
+		if math.Abs(hour.Temperatures[i].Celsius-celsius) > 1e-9 {
+			t.Errorf("band %d temperature = %v °C, want %v °C", i, hour.Temperatures[i].Celsius, celsius)
+		}
+	}
+
+	// The profile crosses 0°C at 3000 + 8/6.5 km
+	if hour.FreezingAltitude == nil {
+		t.Fatal("FreezingAltitude = nil, want a crossing inside the band range")
+	}
+	if expected := 3000 + 8/6.5*1000; math.Abs(hour.FreezingAltitude.Meters-expected) > 1e-6 {
+		t.Errorf("FreezingAltitude = %v m, want %v m", hour.FreezingAltitude.Meters, expected)
+	}
+}
+
+func TestComputeTemperatureProfileCalibrated(t *testing.T) {
+	// 4°C at 3000 m with the freezing level at 4000 m: the calibrated rate is
+	// 4°C per km, not the fixed 6.5
+	forecast := buildProfileForecast(t, 3000, []float64{4}, []float64{4000})
+
+	profile, err := ComputeTemperatureProfile(forecast, 6.5, 24)
+	if err != nil {
+		t.Fatalf("ComputeTemperatureProfile returned error: %v", err)
+	}
+
+	hour := profile.Hours[0]
+	if !hour.Calibrated {
+		t.Fatal("hour with a usable freezing level not flagged calibrated")
+	}
+	if math.Abs(hour.LapseRateCPerKm-4) > 1e-9 {
+		t.Errorf("calibrated lapse rate = %v, want 4", hour.LapseRateCPerKm)
+	}
+
+	// The 4000 m band lands exactly on the freezing level
+	if math.Abs(hour.Temperatures[3].Celsius) > 1e-9 {
+		t.Errorf("4000 m band temperature = %v °C, want 0 °C", hour.Temperatures[3].Celsius)
+	}
+	if hour.FreezingAltitude == nil {
+		t.Fatal("FreezingAltitude = nil, want the model freezing level")
+	}
+	if math.Abs(hour.FreezingAltitude.Meters-4000) > 1e-6 {
+		t.Errorf("FreezingAltitude = %v m, want 4000 m", hour.FreezingAltitude.Meters)
+	}
+}
+
+func TestComputeTemperatureProfileImplausibleFreezingLevel(t *testing.T) {
+	// A freezing level below a warm point cannot be fit with a positive lapse
+	// rate, so the hour stays on the fixed one
+	forecast := buildProfileForecast(t, 3000, []float64{4}, []float64{2500})
+
+	profile, err := ComputeTemperatureProfile(forecast, 6.5, 24)
+	if err != nil {
+		t.Fatalf("ComputeTemperatureProfile returned error: %v", err)
+	}
+
+	hour := profile.Hours[0]
+	if hour.Calibrated {
+		t.Error("implausible freezing level flagged calibrated")
+	}
+	if hour.LapseRateCPerKm != 6.5 {
+		t.Errorf("hour lapse rate = %v, want the fixed 6.5", hour.LapseRateCPerKm)
+	}
+}
+
+func TestComputeTemperatureProfileCrossingOutsideBands(t *testing.T) {
+	// -20°C at the point puts the crossing far below the lowest band
+	forecast := buildProfileForecast(t, 3000, []float64{-20}, nil)
+
+	profile, err := ComputeTemperatureProfile(forecast, 6.5, 24)
+	if err != nil {
+		t.Fatalf("ComputeTemperatureProfile returned error: %v", err)
+	}
+	if profile.Hours[0].FreezingAltitude != nil {
+		t.Errorf("FreezingAltitude = %v, want nil outside the band range", profile.Hours[0].FreezingAltitude)
+	}
+}
+
+func TestComputeTemperatureProfileHourWindow(t *testing.T) {
+	temps := make([]float64, 48)
+	forecast := buildProfileForecast(t, 3000, temps, nil)
+
+	profile, err := ComputeTemperatureProfile(forecast, 6.5, 24)
+	if err != nil {
+		t.Fatalf("ComputeTemperatureProfile returned error: %v", err)
+	}
+	if len(profile.Hours) != 24 {
+		t.Errorf("got %d hours, want 24", len(profile.Hours))
+	}
+
+	// Asking past the horizon returns what the forecast has
+	profile, err = ComputeTemperatureProfile(forecast, 6.5, 100)
+	if err != nil {
+		t.Fatalf("ComputeTemperatureProfile returned error: %v", err)
+	}
+	if len(profile.Hours) != 48 {
+		t.Errorf("got %d hours, want all 48", len(profile.Hours))
+	}
+
+	if _, err := ComputeTemperatureProfile(forecast, 6.5, 0); !errors.Is(err, ErrInvalidProfileHours) {
+		t.Errorf("hours=0 error = %v, want ErrInvalidProfileHours", err)
+	}
+	if _, err := ComputeTemperatureProfile(forecast, 6.5, maxProfileHours+1); !errors.Is(err, ErrInvalidProfileHours) {
+		t.Errorf("hours over the cap error = %v, want ErrInvalidProfileHours", err)
+	}
+}
+
+func TestComputeTemperatureProfileNoPrimaryTemperatures(t *testing.T) {
+	forecast := buildProfileForecast(t, 3000, []float64{4}, nil)
+	forecast.PrimaryModel = ModelEcmwIfs
+
+	if _, err := ComputeTemperatureProfile(forecast, 6.5, 24); !errors.Is(err, ErrNoProfileTemperatures) {
+		t.Errorf("error = %v, want ErrNoProfileTemperatures", err)
+	}
+}
@@ -0,0 +1,126 @@
+package weather
+
+import (
+	"time"
+)
+
+// Rain-on-snow detection thresholds in inches. Rain soaking into an existing
+// snowpack is a major wet avalanche and flooding signal, so the bar for
+// flagging is deliberately low.
+const (
+	// rainOnSnowRainThresholdInches is the daily liquid precipitation a model
+	// must forecast before the day is flagged
+	rainOnSnowRainThresholdInches = 0.25
+	// rainOnSnowMinSnowpackInches is the snowpack evidence required: either
+	// the reported snow depth or the forecast snow accumulated over the
+	// preceding days
+	rainOnSnowMinSnowpackInches = 10.0
+)
+
+// RainOnSnowEvent records one model forecasting rain onto an existing
+// snowpack for a day.
+type RainOnSnowEvent struct {
+	// RainInches is the day's total liquid precipitation
+	RainInches float64
+	// Hours lists the Start times of the hours with liquid precipitation
+	Hours []time.Time
+}
+
+// applyRainOnSnow flags days where a model forecasts meaningful rain while a
+// snowpack exists, attaching per-model events and an ensemble consensus flag.
+// Snowpack evidence is the day's reported snow depth or, for models without
+// depth data, the snowfall the forecast itself accumulated on prior days.
+// Days with a consensus get the event mentioned in the summary sentence.
+func applyRainOnSnow(forecast *Forecast) {
+	// priorSnowfallInches accumulates each model's forecast snowfall from the
+	// days already processed
+	priorSnowfallInches := ModelValues[float64]{}
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+
+		events := ModelValues[RainOnSnowEvent]{}
+		modelsWithRainData := 0
+
+		for _, model := range liquidModels(day.HourlyForecasts) {
+			modelsWithRainData++
+
+			rainInches, hours := dayLiquidPrecipitation(day.HourlyForecasts, model)
+			if rainInches < rainOnSnowRainThresholdInches {
+				continue
+			}
+
+			snowpackInches := maxSnowDepthInches(day.HourlyForecasts, model)
+			if prior := priorSnowfallInches[model]; prior > snowpackInches {
+				snowpackInches = prior
+			}
+			if snowpackInches < rainOnSnowMinSnowpackInches {
+				continue
+			}
+
+			events[model] = RainOnSnowEvent{
+				RainInches: rainInches,
+				Hours:      hours,
+			}
+		}
+
+		if len(events) > 0 {
+			day.RainOnSnow = events
+		}
+		// Consensus requires a strict majority of the models with liquid
+		// precipitation data
+		day.RainOnSnowConsensus = modelsWithRainData > 0 && len(events)*2 > modelsWithRainData
+		if day.RainOnSnowConsensus {
+			summary := "Rain falling on the existing snowpack is expected."
+			if day.SnowfallSummary != "" {
+				summary = day.SnowfallSummary + " " + summary
+			}
+			day.SnowfallSummary = summary
+		}
+
+		for model, snowfall := range day.TotalSnowfall {
+			priorSnowfallInches[model] += snowfall.Inches
+		}
+	}
+}
+
+// liquidModels collects the models that carry hourly liquid precipitation
+// data for the day.
+func liquidModels(hours []HourlyForecast) []string {
+	if len(hours) == 0 {
+		return nil
+	}
+	return hours[0].LiquidPrecipitation.Models()
+}
+
+// dayLiquidPrecipitation sums a model's hourly liquid precipitation and
+// returns the Start times of the hours where it falls.
+func dayLiquidPrecipitation(hours []HourlyForecast, model string) (float64, []time.Time) {
+	total := 0.0
+	var rainHours []time.Time
+	for _, hour := range hours {
+		liquid, ok := hour.LiquidPrecipitation.GetForModel(model)
+		if !ok || liquid.Inches <= 0 {
+			continue
+		}
+		total += liquid.Inches
+		rainHours = append(rainHours, hour.Start)
+	}
+	return total, rainHours
+}
+
+// maxSnowDepthInches returns the model's deepest reported snow depth of the
+// day, or zero when the model carries no depth data.
+func maxSnowDepthInches(hours []HourlyForecast, model string) float64 {
+	deepest := 0.0
+	for _, hour := range hours {
+		depth, ok := hour.SnowDepth.GetForModel(model)
+		if !ok {
+			continue
+		}
+		if inches := depth.Feet * 12; inches > deepest {
+			deepest = inches
+		}
+	}
+	return deepest
+}
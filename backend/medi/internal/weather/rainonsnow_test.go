@@ -0,0 +1,174 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// rainOnSnowDay builds a day where the given models each forecast the liquid
+// precipitation split evenly over two morning hours, with an optional snow
+// depth reported for every hour.
+func rainOnSnowDay(date time.Time, liquidInches map[string]float64, depthFeet map[string]float64) DailyForecast {
+	var hours []HourlyForecast
+	for h := 0; h < 24; h++ {
+		hour := HourlyForecast{
+			Start:               date.Add(time.Duration(h) * time.Hour),
+			End:                 date.Add(time.Duration(h+1) * time.Hour),
+			LiquidPrecipitation: ModelValues[types.Precipitation]{},
+		}
+		for model, total := range liquidInches {
+			amount := 0.0
+			if h == 9 || h == 10 {
+				amount = total / 2
+			}
+			hour.LiquidPrecipitation[model] = types.NewPrecipitationFromInches(amount)
+		}
+		if depthFeet != nil {
+			hour.SnowDepth = ModelValues[types.SnowDepth]{}
+			for model, feet := range depthFeet {
+				hour.SnowDepth[model] = types.NewSnowDepthFromFeet(feet)
+			}
+		}
+		hours = append(hours, hour)
+	}
+
+	return DailyForecast{Timestamp: date, HourlyForecasts: hours}
+}
+
+func TestRainOnSnowMidwinterEvent(t *testing.T) {
+	date := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	forecast := &Forecast{
+		DailyForecasts: []DailyForecast{
+			rainOnSnowDay(date,
+				map[string]float64{ModelGfsSeamless: 0.6, ModelEcmwIfs: 0.5},
+				map[string]float64{ModelGfsSeamless: 2.0, ModelEcmwIfs: 2.0}, // 24 in snowpack
+			),
+		},
+	}
+
+	applyRainOnSnow(forecast)
+
+	day := forecast.DailyForecasts[0]
+	if len(day.RainOnSnow) != 2 {
+		t.Fatalf("Expected both models flagged, got %d", len(day.RainOnSnow))
+	}
+	if !day.RainOnSnowConsensus {
+		t.Error("Expected a rain-on-snow consensus")
+	}
+
+	event := day.RainOnSnow[ModelGfsSeamless]
+	if event.RainInches < 0.59 || event.RainInches > 0.61 {
+		t.Errorf("RainInches = %v, want 0.6", event.RainInches)
+	}
+	if len(event.Hours) != 2 {
+		t.Fatalf("Expected 2 rain hours recorded, got %d", len(event.Hours))
+	}
+	if want := date.Add(9 * time.Hour); !event.Hours[0].Equal(want) {
+		t.Errorf("First rain hour = %v, want %v", event.Hours[0], want)
+	}
+
+	if day.SnowfallSummary != "Rain falling on the existing snowpack is expected." {
+		t.Errorf("Summary does not mention the event: %q", day.SnowfallSummary)
+	}
+}
+
+func TestRainOnSnowNoSnowpack(t *testing.T) {
+	date := time.Date(2025, 10, 5, 0, 0, 0, 0, time.UTC)
+	forecast := &Forecast{
+		DailyForecasts: []DailyForecast{
+			// Heavy autumn rain, bare ground
+			rainOnSnowDay(date, map[string]float64{ModelGfsSeamless: 1.2}, nil),
+		},
+	}
+
+	applyRainOnSnow(forecast)
+
+	day := forecast.DailyForecasts[0]
+	if day.RainOnSnow != nil {
+		t.Errorf("Expected no flag without a snowpack, got %v", day.RainOnSnow)
+	}
+	if day.RainOnSnowConsensus {
+		t.Error("Expected no consensus without a snowpack")
+	}
+	if day.SnowfallSummary != "" {
+		t.Errorf("Expected no summary mention, got %q", day.SnowfallSummary)
+	}
+}
+
+func TestRainOnSnowModelsDisagree(t *testing.T) {
+	date := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	forecast := &Forecast{
+		DailyForecasts: []DailyForecast{
+			// Only one of three models clears the rain threshold
+			rainOnSnowDay(date,
+				map[string]float64{ModelGfsSeamless: 0.4, ModelEcmwIfs: 0.1, ModelGemSeamless: 0.05},
+				map[string]float64{ModelGfsSeamless: 1.5, ModelEcmwIfs: 1.5, ModelGemSeamless: 1.5},
+			),
+		},
+	}
+
+	applyRainOnSnow(forecast)
+
+	day := forecast.DailyForecasts[0]
+	if len(day.RainOnSnow) != 1 {
+		t.Fatalf("Expected only one model flagged, got %d", len(day.RainOnSnow))
+	}
+	if !day.RainOnSnow.HasModel(ModelGfsSeamless) {
+		t.Error("Expected the GFS flag to survive")
+	}
+	if day.RainOnSnowConsensus {
+		t.Error("Expected no consensus when one of three models flags")
+	}
+	if day.SnowfallSummary != "" {
+		t.Errorf("Expected no summary mention without consensus, got %q", day.SnowfallSummary)
+	}
+}
+
+func TestRainOnSnowPriorAccumulation(t *testing.T) {
+	storm := time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)
+	rainDay := storm.Add(24 * time.Hour)
+
+	// Day one dumps a foot of snow with no depth data; day two brings rain
+	dayOne := rainOnSnowDay(storm, map[string]float64{ModelGfsSeamless: 0}, nil)
+	dayOne.TotalSnowfall = ModelValues[types.Precipitation]{
+		ModelGfsSeamless: types.NewPrecipitationFromInches(12),
+	}
+	forecast := &Forecast{
+		DailyForecasts: []DailyForecast{
+			dayOne,
+			rainOnSnowDay(rainDay, map[string]float64{ModelGfsSeamless: 0.5}, nil),
+		},
+	}
+
+	applyRainOnSnow(forecast)
+
+	if forecast.DailyForecasts[0].RainOnSnow != nil {
+		t.Error("Expected no flag on the snow day itself")
+	}
+	day := forecast.DailyForecasts[1]
+	if !day.RainOnSnow.HasModel(ModelGfsSeamless) {
+		t.Fatal("Expected the prior day's accumulation to count as snowpack")
+	}
+	if !day.RainOnSnowConsensus {
+		t.Error("Expected a single-model consensus")
+	}
+}
+
+func TestRainOnSnowAppendsToExistingSummary(t *testing.T) {
+	date := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	day := rainOnSnowDay(date,
+		map[string]float64{ModelGfsSeamless: 0.6},
+		map[string]float64{ModelGfsSeamless: 2.0},
+	)
+	day.SnowfallSummary = "Snowfall is expected to peak between 09:00 and 12:00."
+	forecast := &Forecast{DailyForecasts: []DailyForecast{day}}
+
+	applyRainOnSnow(forecast)
+
+	want := "Snowfall is expected to peak between 09:00 and 12:00. Rain falling on the existing snowpack is expected."
+	if got := forecast.DailyForecasts[0].SnowfallSummary; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package weather
+
+import (
+	"time"
+
+	"medi/internal/astro"
+)
+
+// refreezeTempF is the temperature the overnight hours must drop below for
+// the surface to refreeze solidly; 28°F leaves margin below freezing for the
+// snowpack to lose its melt water
+const refreezeTempF = 28.0
+
+// cornSnowTempF is the threshold the next morning must climb back over for
+// the refrozen surface to soften into corn
+const cornSnowTempF = 32.0
+
+// defaultRefreezeMinHours is used when the configured minimum is unset
+const defaultRefreezeMinHours = 3
+
+// applyOvernightRefreeze attaches the melt-freeze indicators to each daily
+// forecast: whether the night after the day refreezes per model, and when
+// the following morning first climbs back above freezing. minHours is how
+// many hours below the refreeze threshold the night must spend, from config.
+func applyOvernightRefreeze(forecast *Forecast, minHours int) {
+	if minHours <= 0 {
+		minHours = defaultRefreezeMinHours
+	}
+	latitude := forecast.ForecastPoint.Coordinates.Latitude
+	longitude := forecast.ForecastPoint.Coordinates.Longitude
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+
+		// The night runs from this day's sunset to the next day's sunrise;
+		// without either bound (polar day or night) no refreeze is rated
+		sun := astro.Sun(day.Timestamp, latitude, longitude)
+		nextSun := astro.Sun(day.Timestamp.AddDate(0, 0, 1), latitude, longitude)
+		if sun.Set == nil || nextSun.Rise == nil {
+			continue
+		}
+
+		night := forecast.HoursBetween(*sun.Set, *nextSun.Rise)
+		if len(night) == 0 {
+			continue
+		}
+		// The corn window is the morning after the refreeze, sunrise to noon
+		morning := forecast.HoursBetween(*nextSun.Rise, nextSun.Rise.Add(6*time.Hour))
+
+		for _, model := range overnightModels(night) {
+			high, ok := day.HighTemperature.GetForModel(model)
+			if !ok {
+				continue
+			}
+
+			refreeze := high.Fahrenheit > cornSnowTempF && hoursBelow(night, model, refreezeTempF) >= minHours
+			if day.OvernightRefreeze == nil {
+				day.OvernightRefreeze = ModelValues[bool]{}
+			}
+			day.OvernightRefreeze[model] = refreeze
+			if !refreeze {
+				continue
+			}
+
+			if thaw, ok := firstHourAbove(morning, model, cornSnowTempF); ok {
+				if day.CornSnowHour == nil {
+					day.CornSnowHour = ModelValues[time.Time]{}
+				}
+				day.CornSnowHour[model] = thaw
+			}
+		}
+	}
+}
+
+// hoursBelow counts the hours where the model's temperature sits below the
+// threshold
+func hoursBelow(hours []HourlyForecast, model string, thresholdF float64) int {
+	count := 0
+	for _, hour := range hours {
+		if temperature, ok := hour.Temperature.GetForModel(model); ok && temperature.Fahrenheit < thresholdF {
+			count++
+		}
+	}
+	return count
+}
+
+// firstHourAbove returns the start of the first hour where the model's
+// temperature exceeds the threshold
+func firstHourAbove(hours []HourlyForecast, model string, thresholdF float64) (time.Time, bool) {
+	for _, hour := range hours {
+		if temperature, ok := hour.Temperature.GetForModel(model); ok && temperature.Fahrenheit > thresholdF {
+			return hour.Start, true
+		}
+	}
+	return time.Time{}, false
+}
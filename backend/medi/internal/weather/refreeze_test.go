@@ -0,0 +1,144 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// refreezeForecast builds a two-day Denver forecast whose hourly temperature
+// comes from tempAt, keyed by day index and hour of day. Day highs are set
+// from the warmest constructed hour per day.
+func refreezeForecast(tempAt func(day, hour int) float64) *Forecast {
+	denver, _ := time.LoadLocation(TimezoneDenver)
+
+	forecast := &Forecast{
+		Timezone:     TimezoneDenver,
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7392, -104.9903),
+		},
+	}
+	for dayIndex := 0; dayIndex < 2; dayIndex++ {
+		day := DailyForecast{Timestamp: time.Date(2025, 1, 13+dayIndex, 0, 0, 0, 0, denver)}
+		high := -1000.0
+		for hour := 0; hour < 24; hour++ {
+			start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+			tempF := tempAt(dayIndex, hour)
+			if tempF > high {
+				high = tempF
+			}
+			day.HourlyForecasts = append(day.HourlyForecasts, HourlyForecast{
+				Start:       start,
+				End:         start.Add(time.Hour),
+				Temperature: ModelValues[types.Temperature]{ModelGfsSeamless: types.NewTemperatureFromFahrenheit(tempF)},
+			})
+		}
+		day.HighTemperature = ModelValues[types.Temperature]{ModelGfsSeamless: types.NewTemperatureFromFahrenheit(high)}
+		forecast.DailyForecasts = append(forecast.DailyForecasts, day)
+	}
+	return forecast
+}
+
+// meltFreezeCycle is a classic spring day: 40°F through midday, 22°F through
+// the night, warming past freezing again at warmHour the next morning
+func meltFreezeCycle(warmHour int) func(day, hour int) float64 {
+	return func(day, hour int) float64 {
+		if day == 0 {
+			if hour >= 10 && hour < 16 {
+				return 40
+			}
+			return 22
+		}
+		if hour >= warmHour {
+			return 40
+		}
+		return 22
+	}
+}
+
+func TestOvernightRefreezeMeltFreezeCycle(t *testing.T) {
+	forecast := refreezeForecast(meltFreezeCycle(10))
+	applyOvernightRefreeze(forecast, 3)
+
+	day := forecast.DailyForecasts[0]
+	refreeze, ok := day.OvernightRefreeze.GetForModel(ModelGfsSeamless)
+	if !ok || !refreeze {
+		t.Fatalf("OvernightRefreeze = %v (present %v), want true after a warm day and cold night", refreeze, ok)
+	}
+
+	thaw, ok := day.CornSnowHour.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("CornSnowHour not attached to a refreeze night")
+	}
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	want := time.Date(2025, 1, 14, 10, 0, 0, 0, denver)
+	if !thaw.Equal(want) {
+		t.Errorf("CornSnowHour = %v, want %v", thaw, want)
+	}
+}
+
+func TestOvernightRefreezeWarmNight(t *testing.T) {
+	// The day melts but the night holds at 33°F: no refreeze, no corn window
+	forecast := refreezeForecast(func(day, hour int) float64 {
+		if hour >= 10 && hour < 16 {
+			return 42
+		}
+		return 33
+	})
+	applyOvernightRefreeze(forecast, 3)
+
+	day := forecast.DailyForecasts[0]
+	refreeze, ok := day.OvernightRefreeze.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("model with temperature data missing from OvernightRefreeze")
+	}
+	if refreeze {
+		t.Error("OvernightRefreeze = true on a night that stays above the threshold")
+	}
+	if _, ok := day.CornSnowHour.GetForModel(ModelGfsSeamless); ok {
+		t.Error("CornSnowHour attached without a refreeze")
+	}
+}
+
+func TestOvernightRefreezeNeedsAMeltDay(t *testing.T) {
+	// A cold night after a day that never broke freezing is just winter
+	forecast := refreezeForecast(func(day, hour int) float64 {
+		if hour >= 10 && hour < 16 {
+			return 30
+		}
+		return 20
+	})
+	applyOvernightRefreeze(forecast, 3)
+
+	if refreeze, _ := forecast.DailyForecasts[0].OvernightRefreeze.GetForModel(ModelGfsSeamless); refreeze {
+		t.Error("OvernightRefreeze = true after a day that never reached above freezing")
+	}
+}
+
+func TestOvernightRefreezeMinHours(t *testing.T) {
+	// Only 18:00 and 19:00 drop below the threshold
+	briefDip := func(day, hour int) float64 {
+		switch {
+		case day == 0 && hour >= 10 && hour < 16:
+			return 40
+		case day == 0 && (hour == 18 || hour == 19):
+			return 25
+		default:
+			return 30
+		}
+	}
+
+	forecast := refreezeForecast(briefDip)
+	applyOvernightRefreeze(forecast, 3)
+	if refreeze, _ := forecast.DailyForecasts[0].OvernightRefreeze.GetForModel(ModelGfsSeamless); refreeze {
+		t.Error("two cold hours should not satisfy a three-hour minimum")
+	}
+
+	forecast = refreezeForecast(briefDip)
+	applyOvernightRefreeze(forecast, 2)
+	if refreeze, _ := forecast.DailyForecasts[0].OvernightRefreeze.GetForModel(ModelGfsSeamless); !refreeze {
+		t.Error("two cold hours should satisfy a two-hour minimum")
+	}
+}
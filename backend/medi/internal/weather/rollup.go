@@ -0,0 +1,92 @@
+package weather
+
+// Rollup horizons in hours
+const (
+	rollupShortHorizonHours = 24
+	rollupLongHorizonHours  = 72
+)
+
+// SnowfallRange bounds snowfall totals across sampled points and models.
+type SnowfallRange struct {
+	MinInches float64
+	MaxInches float64
+}
+
+// Rollup aggregates point forecasts sampled across an area, such as an
+// avalanche forecast zone.
+type Rollup struct {
+	// PointCount is the number of forecasts aggregated
+	PointCount int
+	// MinElevationFt and MaxElevationFt bound the elevations sampled
+	MinElevationFt float64
+	MaxElevationFt float64
+	// Snowfall24Hours and Snowfall72Hours span the per-model snowfall totals
+	// across all sampled points for the next 24 and 72 hours
+	Snowfall24Hours SnowfallRange
+	Snowfall72Hours SnowfallRange
+	// MaxWindSpeedMph and MaxWindGustMph are the highest winds any model
+	// forecasts at any sampled point within the next 72 hours
+	MaxWindSpeedMph float64
+	MaxWindGustMph  float64
+}
+
+// ComputeRollup aggregates forecasts for multiple sampled points into a single
+// area rollup. Nil forecasts are skipped.
+func ComputeRollup(forecasts []*Forecast) Rollup {
+	rollup := Rollup{}
+
+	var elevations, totals24, totals72 []float64
+	for _, forecast := range forecasts {
+		if forecast == nil {
+			continue
+		}
+		rollup.PointCount++
+		elevations = append(elevations, forecast.ForecastPoint.Elevation.Feet)
+
+		hours := forecast.Hours()
+		if len(hours) > rollupLongHorizonHours {
+			hours = hours[:rollupLongHorizonHours]
+		}
+
+		pointTotals24 := map[string]float64{}
+		pointTotals72 := map[string]float64{}
+		for i, hour := range hours {
+			for model, amount := range hour.Snowfall {
+				if i < rollupShortHorizonHours {
+					pointTotals24[model] += amount.Inches
+				}
+				pointTotals72[model] += amount.Inches
+			}
+			for _, wind := range hour.Wind {
+				if wind.Speed.Mph > rollup.MaxWindSpeedMph {
+					rollup.MaxWindSpeedMph = wind.Speed.Mph
+				}
+				if wind.Gusts != nil && wind.Gusts.Mph > rollup.MaxWindGustMph {
+					rollup.MaxWindGustMph = wind.Gusts.Mph
+				}
+			}
+		}
+
+		for _, total := range pointTotals24 {
+			totals24 = append(totals24, total)
+		}
+		for _, total := range pointTotals72 {
+			totals72 = append(totals72, total)
+		}
+	}
+
+	if len(elevations) > 0 {
+		rollup.MinElevationFt, _ = minFloat(elevations)
+		rollup.MaxElevationFt, _ = maxFloat(elevations)
+	}
+	if minInches, ok := minFloat(totals24); ok {
+		maxInches, _ := maxFloat(totals24)
+		rollup.Snowfall24Hours = SnowfallRange{MinInches: minInches, MaxInches: maxInches}
+	}
+	if minInches, ok := minFloat(totals72); ok {
+		maxInches, _ := maxFloat(totals72)
+		rollup.Snowfall72Hours = SnowfallRange{MinInches: minInches, MaxInches: maxInches}
+	}
+
+	return rollup
+}
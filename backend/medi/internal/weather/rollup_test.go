@@ -0,0 +1,80 @@
+package weather
+
+import (
+	"math"
+	"testing"
+
+	"medi/internal/types"
+)
+
+func TestComputeRollup(t *testing.T) {
+	hours := 5 * 24
+
+	// Point one at 9000 ft: 6 inches in the first 24 hours plus 3 more
+	// within 72 hours, and the strongest wind
+	gfsOne := make([]float64, hours)
+	for h := 0; h < 12; h++ {
+		gfsOne[h] = 0.5
+	}
+	for h := 50; h < 56; h++ {
+		gfsOne[h] = 0.5
+	}
+	daysOne := buildForecastDays(t, 5, map[string][]float64{ModelGfsSeamless: gfsOne})
+	daysOne[1].HourlyForecasts[3].Wind = ModelValues[types.Wind]{
+		ModelGfsSeamless: types.NewWind(35, 60, 270),
+	}
+	// A storm-force wind beyond the 72-hour horizon must not count
+	daysOne[3].HourlyForecasts[8].Wind = ModelValues[types.Wind]{
+		ModelGfsSeamless: types.NewWind(100, 120, 0),
+	}
+	pointOne := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Elevation: types.NewElevationFromFeet(9000)},
+		DailyForecasts: daysOne,
+	}
+
+	// Point two at 11500 ft: 2 inches in the first 24 hours, calmer winds
+	gfsTwo := make([]float64, hours)
+	gfsTwo[0] = 1.0
+	gfsTwo[1] = 1.0
+	daysTwo := buildForecastDays(t, 5, map[string][]float64{ModelGfsSeamless: gfsTwo})
+	daysTwo[0].HourlyForecasts[5].Wind = ModelValues[types.Wind]{
+		ModelGfsSeamless: types.NewWind(20, 40, 180),
+	}
+	pointTwo := &Forecast{
+		ForecastPoint:  types.ForecastPoint{Elevation: types.NewElevationFromFeet(11500)},
+		DailyForecasts: daysTwo,
+	}
+
+	rollup := ComputeRollup([]*Forecast{pointOne, nil, pointTwo})
+
+	if rollup.PointCount != 2 {
+		t.Errorf("point count = %d, want 2", rollup.PointCount)
+	}
+	if rollup.MinElevationFt != 9000 || rollup.MaxElevationFt != 11500 {
+		t.Errorf("elevation range = %v - %v ft, want 9000 - 11500", rollup.MinElevationFt, rollup.MaxElevationFt)
+	}
+	if math.Abs(rollup.Snowfall24Hours.MinInches-2) > 1e-9 || math.Abs(rollup.Snowfall24Hours.MaxInches-6) > 1e-9 {
+		t.Errorf("24h snowfall range = %v - %v inches, want 2 - 6",
+			rollup.Snowfall24Hours.MinInches, rollup.Snowfall24Hours.MaxInches)
+	}
+	if math.Abs(rollup.Snowfall72Hours.MinInches-2) > 1e-9 || math.Abs(rollup.Snowfall72Hours.MaxInches-9) > 1e-9 {
+		t.Errorf("72h snowfall range = %v - %v inches, want 2 - 9",
+			rollup.Snowfall72Hours.MinInches, rollup.Snowfall72Hours.MaxInches)
+	}
+	if rollup.MaxWindSpeedMph != 35 {
+		t.Errorf("max wind speed = %v mph, want 35", rollup.MaxWindSpeedMph)
+	}
+	if rollup.MaxWindGustMph != 60 {
+		t.Errorf("max wind gust = %v mph, want 60", rollup.MaxWindGustMph)
+	}
+}
+
+func TestComputeRollupEmpty(t *testing.T) {
+	rollup := ComputeRollup(nil)
+	if rollup.PointCount != 0 {
+		t.Errorf("point count = %d, want 0", rollup.PointCount)
+	}
+	if rollup.Snowfall24Hours.MaxInches != 0 || rollup.MaxWindSpeedMph != 0 {
+		t.Error("empty rollup has non-zero aggregates")
+	}
+}
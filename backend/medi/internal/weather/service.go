@@ -1,19 +1,31 @@
 package weather
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"medi/internal/config"
+	"medi/internal/logging"
+	"medi/internal/providers"
+	"medi/internal/providers/metno"
 	"medi/internal/providers/nws"
 	"medi/internal/providers/openmeteo"
 	"medi/internal/timezone"
+	"medi/internal/timing"
 	"medi/internal/types"
+	"sync"
 	"time"
 )
 
 type ForecastProvider interface {
 	// GetForecast fetches the weather forecast for the given latitude, longitude, elevation, and timezone
-	GetForecast(latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error)
+	GetForecast(ctx context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error)
+}
+
+type FallbackForecastProvider interface {
+	// GetForecast fetches a single-model fallback forecast for the given
+	// latitude, longitude, and elevation in meters
+	GetForecast(ctx context.Context, latitude, longitude, elevationMeters float64) (*metno.CompactAPIResponse, error)
 }
 
 type ForecastDiscussionProvider interface {
@@ -22,44 +34,111 @@ type ForecastDiscussionProvider interface {
 }
 
 type Service interface {
-	GetForecast(point types.ForecastPoint) (*Forecast, error)
+	// GetForecast fetches and derives the forecast for the point. The profile
+	// names a configured thresholds profile for the snow-focused derived
+	// blocks; the empty name resolves the default profile.
+	GetForecast(ctx context.Context, point types.ForecastPoint, profile string) (*Forecast, error)
+	GetForecastDiscussion(point types.ForecastPoint) (*ForecastDiscussion, error)
+	// GetProbabilisticSnowfall reduces the ensemble member runs for the
+	// point to snowfall percentile bands; it is a separate upstream fetch
+	// from GetForecast
+	GetProbabilisticSnowfall(ctx context.Context, point types.ForecastPoint) (*ProbabilisticSnowfall, error)
+	// SetBiasCorrection records a verified bias correction for one model at
+	// one location; subsequent forecasts there come back corrected
+	SetBiasCorrection(latitude, longitude float64, model string, correction BiasCorrection) error
+	// GetSnowReport assembles the resort-style snow report figures for the
+	// point from the forecast, the snowfall archive, and any nearby station
+	GetSnowReport(ctx context.Context, point types.ForecastPoint) (*SnowReport, error)
 }
 
 type weatherService struct {
 	forecastProvider           ForecastProvider
+	fallbackProvider           FallbackForecastProvider
 	forecastDiscussionProvider ForecastDiscussionProvider
 	timezoneService            timezone.Service
-	cfg                        *config.Config
-	logger                     *slog.Logger
+	// cfg is read through the holder on every request so tunables like the
+	// powder alert threshold follow a hot reload
+	cfg    *config.Holder
+	logger *slog.Logger
+
+	// biasStore holds per-location corrections; biasCorrector is the lookup
+	// side applied after mapping, identity until corrections are stored
+	biasStore     *BiasStore
+	biasCorrector BiasCorrector
+
+	// observationProvider supplies nearby snow telemetry observations for
+	// the station blend; nil disables blending regardless of config
+	observationProvider ObservationProvider
+
+	// ensembleProvider supplies individual ensemble member runs for the
+	// probabilistic snowfall block; nil makes the block unavailable
+	ensembleProvider EnsembleProvider
+
+	// snowHistoryProvider supplies the observed daily snowfall archive for
+	// the snow report; nil omits the past and season figures
+	snowHistoryProvider SnowHistoryProvider
+
+	// nwsPointZones remembers the timezone NWS reported for a point during
+	// discussion lookups, keyed by rounded coordinates, so the forecast path
+	// can cross-check it against the tzf and provider zones
+	nwsPointZones sync.Map
 }
 
-func NewWeatherService(config *config.Config, logger *slog.Logger) (Service, error) {
+func NewWeatherService(p *providers.Providers, config *config.Holder, logger *slog.Logger) (Service, error) {
 	tzSvc, err := timezone.NewService()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create timezone service: %w", err)
 	}
-	return NewWeatherServiceWithProvider(openmeteo.NewClient(logger), nws.NewClient(logger), tzSvc, config, logger), nil
+	// The registry has no station observation or snowfall archive client
+	// yet, so the blend and the past snow figures stay off until one lands;
+	// tests exercise them through the explicit constructor
+	return NewWeatherServiceWithProvider(p.WeatherForecast, p.FallbackForecast, p.ForecastDiscussion, nil, nil, p.Ensemble, tzSvc, config, logger), nil
 }
 
 func NewWeatherServiceWithProvider(
 	forecastProvider ForecastProvider,
+	fallbackProvider FallbackForecastProvider,
 	forecastDiscussionProvider ForecastDiscussionProvider,
+	observationProvider ObservationProvider,
+	snowHistoryProvider SnowHistoryProvider,
+	ensembleProvider EnsembleProvider,
 	timezoneService timezone.Service,
-	cfg *config.Config,
+	cfg *config.Holder,
 	logger *slog.Logger,
 ) Service {
+	biasStore := NewBiasStore()
 	return &weatherService{
 		forecastProvider:           forecastProvider,
+		fallbackProvider:           fallbackProvider,
 		forecastDiscussionProvider: forecastDiscussionProvider,
+		observationProvider:        observationProvider,
+		snowHistoryProvider:        snowHistoryProvider,
+		ensembleProvider:           ensembleProvider,
 		timezoneService:            timezoneService,
 		cfg:                        cfg,
-		logger:                     logger.With("component", "weather-service"),
+		logger:                     logging.OrDefault(logger).With("component", "weather-service"),
+		biasStore:                  biasStore,
+		biasCorrector:              biasStore,
 	}
 }
 
-func (s *weatherService) GetForecast(forecastPoint types.ForecastPoint) (*Forecast, error) {
+// SetBiasCorrection stores the correction; it takes effect on the next
+// forecast fetched for the location
+func (s *weatherService) SetBiasCorrection(latitude, longitude float64, model string, correction BiasCorrection) error {
+	return s.biasStore.Set(latitude, longitude, model, correction)
+}
+
+func (s *weatherService) GetForecast(ctx context.Context, forecastPoint types.ForecastPoint, profile string) (*Forecast, error) {
 	// TODO validate forecastPoint data
-	forecastDays := s.cfg.App.ForecastDays
+	cfg := s.cfg.Current()
+	forecastDays := cfg.App.ForecastDays
+
+	// Resolve the thresholds profile before any upstream fetch so an unknown
+	// name fails fast
+	thresholds, err := ResolveThresholds(cfg, profile)
+	if err != nil {
+		return nil, err
+	}
 
 	// TODO improve model selection logic and coordination
 	primaryModel := ModelGfsSeamless
@@ -68,36 +147,105 @@ func (s *weatherService) GetForecast(forecastPoint types.ForecastPoint) (*Foreca
 	tz, err := s.timezoneService.GetTimezone(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude)
 	if err != nil {
 		s.logger.Error("failed to determine timezone",
-			"latitude", forecastPoint.Coordinates.Latitude,
-			"longitude", forecastPoint.Coordinates.Longitude,
+			logging.Coords(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude),
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to determine timezone: %w", err)
 	}
 
 	s.logger.Debug("determined timezone for location",
-		"latitude", forecastPoint.Coordinates.Latitude,
-		"longitude", forecastPoint.Coordinates.Longitude,
+		logging.DebugCoords(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude),
 		"timezone", tz,
 	)
 
 	// Get forecast with timezone
+	providerSpan := timing.FromContext(ctx).StartSpan("openmeteo.forecast")
 	apiResponse, err := s.forecastProvider.GetForecast(
+		ctx,
 		forecastPoint.Coordinates.Latitude,
 		forecastPoint.Coordinates.Longitude,
 		forecastPoint.Elevation.Meters,
 		forecastDays,
 		tz,
 	)
+	providerSpan()
 	if err != nil {
 		s.logger.Error("failed to get forecast from provider", "error", err)
+		if s.fallbackProvider != nil {
+			fallback, fallbackErr := s.fallbackForecast(ctx, forecastPoint, tz, profile, thresholds)
+			if fallbackErr == nil {
+				return fallback, nil
+			}
+			s.logger.Error("fallback forecast provider failed", "error", fallbackErr)
+		}
 		return nil, fmt.Errorf("failed to get forecast: %w", err)
 	}
 
-	return mapForecastAPIResponseToForecast(forecastPoint, primaryModel, apiResponse)
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, primaryModel, apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	s.checkTimezoneConsistency(forecast, tz)
+	applyThresholds(forecast, profile, thresholds)
+
+	if cfg.App.EstimateMissingGusts {
+		applyGustEstimation(forecast, cfg.App.GustEstimateFactor)
+	}
+	forecast.PowderAlerts = computePowderAlerts(forecast.DailyForecasts, thresholds)
+	applyDaylight(forecast, cfg.App.SkinningMaxTempF)
+	applyOvernightRefreeze(forecast, cfg.App.RefreezeMinHours)
+	applyFirstTracks(forecast, cfg.App.FirstTracksMaxTempF, thresholds)
+	applyMeltSignals(forecast)
+
+	if corrections := s.biasCorrector.CorrectionsFor(forecastPoint); len(corrections) > 0 {
+		applyBiasCorrections(forecast, corrections)
+	}
+
+	s.stationBlend(ctx, forecast, forecastPoint, cfg)
+
+	return forecast, nil
 }
 
-func (s *weatherService) GetForecastDiscussion(forecastPoint types.ForecastPoint) (string, error) {
+// fallbackForecast serves a degraded single-model forecast from the fallback
+// provider when the primary is unavailable
+func (s *weatherService) fallbackForecast(ctx context.Context, forecastPoint types.ForecastPoint, tz, profile string, thresholds Thresholds) (*Forecast, error) {
+	providerSpan := timing.FromContext(ctx).StartSpan("metno.forecast")
+	apiResponse, err := s.fallbackProvider.GetForecast(
+		ctx,
+		forecastPoint.Coordinates.Latitude,
+		forecastPoint.Coordinates.Longitude,
+		forecastPoint.Elevation.Meters,
+	)
+	providerSpan()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback forecast: %w", err)
+	}
+
+	forecast, err := mapMetNoResponseToForecast(forecastPoint, tz, apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	s.checkTimezoneConsistency(forecast, tz)
+	applyThresholds(forecast, profile, thresholds)
+
+	cfg := s.cfg.Current()
+	applyDaylight(forecast, cfg.App.SkinningMaxTempF)
+	applyOvernightRefreeze(forecast, cfg.App.RefreezeMinHours)
+	applyFirstTracks(forecast, cfg.App.FirstTracksMaxTempF, thresholds)
+	applyMeltSignals(forecast)
+
+	if corrections := s.biasCorrector.CorrectionsFor(forecastPoint); len(corrections) > 0 {
+		applyBiasCorrections(forecast, corrections)
+	}
+
+	s.logger.Warn("serving degraded fallback forecast",
+		"provider", "metno",
+		logging.Coords(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude),
+	)
+	return forecast, nil
+}
+
+func (s *weatherService) GetForecastDiscussion(forecastPoint types.ForecastPoint) (*ForecastDiscussion, error) {
 	// Get point data from NWS
 	pointResp, err := s.forecastDiscussionProvider.GetPoint(
 		forecastPoint.Coordinates.Latitude,
@@ -105,11 +253,19 @@ func (s *weatherService) GetForecastDiscussion(forecastPoint types.ForecastPoint
 	)
 	if err != nil {
 		s.logger.Error("failed to get NWS point data",
-			"latitude", forecastPoint.Coordinates.Latitude,
-			"longitude", forecastPoint.Coordinates.Longitude,
+			logging.Coords(forecastPoint.Coordinates.Latitude, forecastPoint.Coordinates.Longitude),
 			"error", err,
 		)
-		return "", fmt.Errorf("failed to get NWS point data: %w", err)
+		return nil, fmt.Errorf("failed to get NWS point data: %w", err)
+	}
+
+	// Remember the zone NWS claims for the point; the forecast path uses it
+	// to cross-check the tzf and provider zones
+	if zone := pointResp.Properties.TimeZone; zone != "" {
+		s.nwsPointZones.Store(timezoneCheckKey(
+			forecastPoint.Coordinates.Latitude,
+			forecastPoint.Coordinates.Longitude,
+		), zone)
 	}
 
 	// Get area forecast discussion using location ID
@@ -120,10 +276,22 @@ func (s *weatherService) GetForecastDiscussion(forecastPoint types.ForecastPoint
 			"location_id", locationId,
 			"error", err,
 		)
-		return "", fmt.Errorf("failed to get NWS area forecast discussion: %w", err)
+		return nil, fmt.Errorf("failed to get NWS area forecast discussion: %w", err)
 	}
 
-	return afdResp.ProductText, nil
+	discussion := ParseForecastDiscussion(afdResp.ProductText)
+	discussion.IssuingOffice = afdResp.IssuingOffice
+	discussion.IssuanceTime = afdResp.IssuanceTime
+	return discussion, nil
+}
+
+// hourlyTimesPool recycles the per-request scratch slice of parsed hourly
+// timestamps used by mapForecastAPIResponseToForecast
+var hourlyTimesPool = sync.Pool{
+	New: func() any {
+		times := make([]time.Time, 0, 16*24)
+		return &times
+	},
 }
 
 func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primaryModel string, apiResponse *openmeteo.ForecastAPIResponse) (*Forecast, error) {
@@ -145,11 +313,31 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 	}
 	currentTime := time.Now().In(location)
 
-	// We want the index in the array of the most recent timestamp that is earlier than now
-	nowIndex := 0
-	for i, t := range apiResponse.Hourly.Time {
+	// Parse each hourly timestamp exactly once up front; the nowIndex scan
+	// and the per-day loops below reuse the parsed values. The scratch slice
+	// is pooled because a 16-day forecast holds 384 entries per request.
+	timesPtr := hourlyTimesPool.Get().(*[]time.Time)
+	hourlyTimes := (*timesPtr)[:0]
+	defer func() {
+		*timesPtr = hourlyTimes[:0]
+		hourlyTimesPool.Put(timesPtr)
+	}()
+	for _, t := range apiResponse.Hourly.Time {
 		parsedTime, err := time.ParseInLocation("2006-01-02T15:04", t, location)
 		if err != nil {
+			// Unparseable entries stay zero and are skipped below
+			parsedTime = time.Time{}
+		}
+		hourlyTimes = append(hourlyTimes, parsedTime)
+	}
+
+	// We want the index in the array of the most recent timestamp that is earlier than now.
+	// Date-anchored windows can start entirely in the future, in which case
+	// there is no "now" to report and current conditions are omitted.
+	nowIndex := 0
+	windowIncludesNow := false
+	for i, parsedTime := range hourlyTimes {
+		if parsedTime.IsZero() {
 			continue
 		}
 
@@ -158,6 +346,7 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 		}
 
 		nowIndex = i
+		windowIncludesNow = true
 	}
 
 	currentConditions := CurrentConditions{
@@ -184,8 +373,8 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 			ModelGemSeamless:        types.NewWind(apiResponse.Hourly.WindSpeed10MGemSeamless[nowIndex], apiResponse.Hourly.WindGusts10MGemSeamless[nowIndex], apiResponse.Hourly.WindDirection10MGemSeamless[nowIndex]),
 			ModelEcmwIfs:            types.NewWind(apiResponse.Hourly.WindSpeed10MEcmwfIfs[nowIndex], apiResponse.Hourly.WindGusts10MEcmwfIfs[nowIndex], apiResponse.Hourly.WindDirection10MEcmwfIfs[nowIndex]),
 			ModelNcepNbmConus:       types.NewWind(apiResponse.Hourly.WindSpeed10MNcepNbmConus[nowIndex], apiResponse.Hourly.WindGusts10MNcepNbmConus[nowIndex], apiResponse.Hourly.WindDirection10MNcepNbmConus[nowIndex]),
-			ModelGfsGraphcast025:    types.NewWind(apiResponse.Hourly.WindSpeed10MGfsGraphcast025[nowIndex], -1, apiResponse.Hourly.WindDirection10MGfsGraphcast025[nowIndex]),
-			ModelEcmwfAifs025Single: types.NewWind(apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single[nowIndex], -1, apiResponse.Hourly.WindDirection10MEcmwfAifs025Single[nowIndex]),
+			ModelGfsGraphcast025:    types.NewWindWithoutGusts(apiResponse.Hourly.WindSpeed10MGfsGraphcast025[nowIndex], apiResponse.Hourly.WindDirection10MGfsGraphcast025[nowIndex]),
+			ModelEcmwfAifs025Single: types.NewWindWithoutGusts(apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single[nowIndex], apiResponse.Hourly.WindDirection10MEcmwfAifs025Single[nowIndex]),
 			ModelNcepNamConus:       types.NewWind(apiResponse.Hourly.WindSpeed10MNcepNamConus[nowIndex], apiResponse.Hourly.WindGusts10MNcepNamConus[nowIndex], apiResponse.Hourly.WindDirection10MNcepNamConus[nowIndex]),
 		},
 		Visibility: ModelValues[float64]{
@@ -249,37 +438,123 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 		},
 	}
 
-	forecast.CurrentConditions = currentConditions
+	// Prefer instantaneous values from the current= block where the API
+	// includes a model, keeping the hourly-derived values above as fallback
+	if windowIncludesNow {
+		applyCurrentBlock(&currentConditions, apiResponse)
+		forecast.CurrentConditions = currentConditions
+	}
 
 	dailyForecasts := make([]DailyForecast, 0, len(apiResponse.Daily.Time))
 	hourlyIndex := 0
 
+	// Per-model hourly series feeding the daily aggregates below. Models
+	// with no data for a variable are left out of the map.
+
+	temperatureSeries := map[string][]float64{
+		ModelGfsSeamless:        apiResponse.Hourly.Temperature2MGfsSeamless,
+		ModelGemSeamless:        apiResponse.Hourly.Temperature2MGemSeamless,
+		ModelEcmwIfs:            apiResponse.Hourly.Temperature2MEcmwfIfs,
+		ModelNcepNbmConus:       apiResponse.Hourly.Temperature2MNcepNbmConus,
+		ModelGfsGraphcast025:    apiResponse.Hourly.Temperature2MGfsGraphcast025,
+		ModelEcmwfAifs025Single: apiResponse.Hourly.Temperature2MEcmwfAifs025Single,
+		ModelNcepNamConus:       apiResponse.Hourly.Temperature2MNcepNamConus,
+	}
+
+	windSpeedSeries := map[string][]float64{
+		ModelGfsSeamless:        apiResponse.Hourly.WindSpeed10MGfsSeamless,
+		ModelGemSeamless:        apiResponse.Hourly.WindSpeed10MGemSeamless,
+		ModelEcmwIfs:            apiResponse.Hourly.WindSpeed10MEcmwfIfs,
+		ModelNcepNbmConus:       apiResponse.Hourly.WindSpeed10MNcepNbmConus,
+		ModelGfsGraphcast025:    apiResponse.Hourly.WindSpeed10MGfsGraphcast025,
+		ModelEcmwfAifs025Single: apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single,
+		ModelNcepNamConus:       apiResponse.Hourly.WindSpeed10MNcepNamConus,
+	}
+
+	windGustSeries := map[string][]float64{
+		ModelGfsSeamless:  apiResponse.Hourly.WindGusts10MGfsSeamless,
+		ModelGemSeamless:  apiResponse.Hourly.WindGusts10MGemSeamless,
+		ModelEcmwIfs:      apiResponse.Hourly.WindGusts10MEcmwfIfs,
+		ModelNcepNbmConus: apiResponse.Hourly.WindGusts10MNcepNbmConus,
+		ModelNcepNamConus: apiResponse.Hourly.WindGusts10MNcepNamConus,
+		// No data
+		// ModelGfsGraphcast025: apiResponse.Hourly.WindGusts10MGfsGraphcast025,
+		// ModelEcmwfAifs025Single: apiResponse.Hourly.WindGusts10MEcmwfAifs025Single,
+	}
+
+	freezingLevelSeries := map[string][]float64{
+		ModelGfsSeamless: apiResponse.Hourly.FreezingLevelHeightGfsSeamless,
+	}
+
+	rainSeries := map[string][]float64{
+		ModelGfsSeamless:        apiResponse.Hourly.RainGfsSeamless,
+		ModelGemSeamless:        apiResponse.Hourly.RainGemSeamless,
+		ModelEcmwIfs:            apiResponse.Hourly.RainEcmwfIfs,
+		ModelNcepNbmConus:       apiResponse.Hourly.RainNcepNbmConus,
+		ModelGfsGraphcast025:    apiResponse.Hourly.RainGfsGraphcast025,
+		ModelEcmwfAifs025Single: apiResponse.Hourly.RainEcmwfAifs025Single,
+		ModelNcepNamConus:       apiResponse.Hourly.RainNcepNamConus,
+	}
+
+	precipitationSeries := map[string][]float64{
+		ModelGfsSeamless:        apiResponse.Hourly.PrecipitationGfsSeamless,
+		ModelGemSeamless:        apiResponse.Hourly.PrecipitationGemSeamless,
+		ModelEcmwIfs:            apiResponse.Hourly.PrecipitationEcmwfIfs,
+		ModelNcepNbmConus:       apiResponse.Hourly.PrecipitationNcepNbmConus,
+		ModelGfsGraphcast025:    apiResponse.Hourly.PrecipitationGfsGraphcast025,
+		ModelEcmwfAifs025Single: apiResponse.Hourly.PrecipitationEcmwfAifs025Single,
+		ModelNcepNamConus:       apiResponse.Hourly.PrecipitationNcepNamConus,
+	}
+
+	showersSeries := map[string][]float64{
+		ModelGfsSeamless:        apiResponse.Hourly.ShowersGfsSeamless,
+		ModelGemSeamless:        apiResponse.Hourly.ShowersGemSeamless,
+		ModelEcmwIfs:            apiResponse.Hourly.ShowersEcmwfIfs,
+		ModelNcepNbmConus:       apiResponse.Hourly.ShowersNcepNbmConus,
+		ModelGfsGraphcast025:    apiResponse.Hourly.ShowersGfsGraphcast025,
+		ModelEcmwfAifs025Single: apiResponse.Hourly.ShowersEcmwfAifs025Single,
+		ModelNcepNamConus:       apiResponse.Hourly.ShowersNcepNamConus,
+	}
+
+	snowfallSeries := map[string][]float64{
+		ModelGfsSeamless:        apiResponse.Hourly.SnowfallGfsSeamless,
+		ModelGemSeamless:        apiResponse.Hourly.SnowfallGemSeamless,
+		ModelEcmwIfs:            apiResponse.Hourly.SnowfallEcmwfIfs,
+		ModelNcepNbmConus:       apiResponse.Hourly.SnowfallNcepNbmConus,
+		ModelGfsGraphcast025:    apiResponse.Hourly.SnowfallGfsGraphcast025,
+		ModelEcmwfAifs025Single: apiResponse.Hourly.SnowfallEcmwfAifs025Single,
+		ModelNcepNamConus:       apiResponse.Hourly.SnowfallNcepNamConus,
+	}
+
 	// Get each daily forecast
 	for i, day := range apiResponse.Daily.Time {
 
-		dailyForecastHourlyIndexes := make([]int, 24)
+		// Track the hourly index range belonging to this local day
+		dayFirstHourlyIndex := -1
+		dayLastHourlyIndex := -1
 
 		dayTime, err := time.ParseInLocation("2006-01-02", day, location)
 		if err != nil {
 			continue
 		}
 
-		hourlyForecasts := make([]HourlyForecast, 0)
-		for j := hourlyIndex; j < len(apiResponse.Hourly.Time); j++ {
-			hourlyTime, err := time.ParseInLocation("2006-01-02T15:04", apiResponse.Hourly.Time[j], location)
-			if err != nil {
+		hourlyForecasts := make([]HourlyForecast, 0, 24)
+		for j := hourlyIndex; j < len(hourlyTimes); j++ {
+			hourlyTime := hourlyTimes[j]
+			if hourlyTime.IsZero() {
 				continue
 			}
 
 			// Check if hourly time is within the current day
 			if hourlyTime.Year() == dayTime.Year() && hourlyTime.Month() == dayTime.Month() && hourlyTime.Day() == dayTime.Day() {
-				hourlyIndex = j
-
-				start, startErr := time.ParseInLocation("2006-01-02T15:04", apiResponse.Hourly.Time[j], location)
-				if startErr != nil {
-					return nil, fmt.Errorf("failed to parse hourly time: %w", startErr)
+				// The next day's scan resumes at the first unconsumed hour
+				hourlyIndex = j + 1
+				if dayFirstHourlyIndex == -1 {
+					dayFirstHourlyIndex = j
 				}
+				dayLastHourlyIndex = j
 
+				start := hourlyTime
 				end := start.Add(1 * time.Hour)
 
 				// TODO construct hourly forecast
@@ -337,13 +612,13 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 						// ModelNcepNamConus:       toPercentage(apiResponse.Hourly.PrecipitationProbabilityNcepNamConus[j])
 					},
 					Precipitation: ModelValues[types.Precipitation]{
-						ModelGfsSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationGfsSeamless[j]),
-						ModelGemSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationGemSeamless[j]),
-						ModelEcmwIfs:            types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationEcmwfIfs[j]),
-						ModelNcepNbmConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationNcepNbmConus[j]),
-						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationGfsGraphcast025[j]),
-						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationEcmwfAifs025Single[j]),
-						ModelNcepNamConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.PrecipitationNcepNamConus[j]),
+						ModelGfsSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationGfsSeamless, j)),
+						ModelGemSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationGemSeamless, j)),
+						ModelEcmwIfs:            types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationEcmwfIfs, j)),
+						ModelNcepNbmConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationNcepNbmConus, j)),
+						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationGfsGraphcast025, j)),
+						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationEcmwfAifs025Single, j)),
+						ModelNcepNamConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.PrecipitationNcepNamConus, j)),
 					},
 					CloudCover: ModelValues[float64]{
 						ModelGfsSeamless:        toPercentage(apiResponse.Hourly.CloudCoverGfsSeamless[j]),
@@ -399,8 +674,8 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 						ModelGemSeamless:        types.NewWind(apiResponse.Hourly.WindSpeed10MGemSeamless[j], apiResponse.Hourly.WindGusts10MGemSeamless[j], apiResponse.Hourly.WindDirection10MGemSeamless[j]),
 						ModelEcmwIfs:            types.NewWind(apiResponse.Hourly.WindSpeed10MEcmwfIfs[j], apiResponse.Hourly.WindGusts10MEcmwfIfs[j], apiResponse.Hourly.WindDirection10MEcmwfIfs[j]),
 						ModelNcepNbmConus:       types.NewWind(apiResponse.Hourly.WindSpeed10MNcepNbmConus[j], apiResponse.Hourly.WindGusts10MNcepNbmConus[j], apiResponse.Hourly.WindDirection10MNcepNbmConus[j]),
-						ModelGfsGraphcast025:    types.NewWind(apiResponse.Hourly.WindSpeed10MGfsGraphcast025[j], -1, apiResponse.Hourly.WindDirection10MGfsGraphcast025[j]),
-						ModelEcmwfAifs025Single: types.NewWind(apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single[j], -1, apiResponse.Hourly.WindDirection10MEcmwfAifs025Single[j]),
+						ModelGfsGraphcast025:    types.NewWindWithoutGusts(apiResponse.Hourly.WindSpeed10MGfsGraphcast025[j], apiResponse.Hourly.WindDirection10MGfsGraphcast025[j]),
+						ModelEcmwfAifs025Single: types.NewWindWithoutGusts(apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single[j], apiResponse.Hourly.WindDirection10MEcmwfAifs025Single[j]),
 						ModelNcepNamConus:       types.NewWind(apiResponse.Hourly.WindSpeed10MNcepNamConus[j], apiResponse.Hourly.WindGusts10MNcepNamConus[j], apiResponse.Hourly.WindDirection10MNcepNamConus[j]),
 					},
 					RelativeHumidity: ModelValues[float64]{
@@ -414,31 +689,31 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 						// ModelGfsGraphcast025:    toPercentage(apiResponse.Hourly.RelativeHumidity2MGfsGraphcast025[j]),
 					},
 					Rainfall: ModelValues[types.Precipitation]{
-						ModelGfsSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.RainGfsSeamless[j]),
-						ModelGemSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.RainGemSeamless[j]),
-						ModelEcmwIfs:            types.NewPrecipitationFromInches(apiResponse.Hourly.RainEcmwfIfs[j]),
-						ModelNcepNbmConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.RainNcepNbmConus[j]),
-						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(apiResponse.Hourly.RainGfsGraphcast025[j]),
-						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(apiResponse.Hourly.RainEcmwfAifs025Single[j]),
-						ModelNcepNamConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.RainNcepNamConus[j]),
+						ModelGfsSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainGfsSeamless, j)),
+						ModelGemSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainGemSeamless, j)),
+						ModelEcmwIfs:            types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainEcmwfIfs, j)),
+						ModelNcepNbmConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainNcepNbmConus, j)),
+						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainGfsGraphcast025, j)),
+						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainEcmwfAifs025Single, j)),
+						ModelNcepNamConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.RainNcepNamConus, j)),
 					},
 					Snowfall: ModelValues[types.Precipitation]{
-						ModelGfsSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallGfsSeamless[j]),
-						ModelGemSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallGemSeamless[j]),
-						ModelEcmwIfs:            types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallEcmwfIfs[j]),
-						ModelNcepNbmConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallNcepNbmConus[j]),
-						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallGfsGraphcast025[j]),
-						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallEcmwfAifs025Single[j]),
-						ModelNcepNamConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.SnowfallNcepNamConus[j]),
+						ModelGfsSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallGfsSeamless, j)),
+						ModelGemSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallGemSeamless, j)),
+						ModelEcmwIfs:            types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallEcmwfIfs, j)),
+						ModelNcepNbmConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallNcepNbmConus, j)),
+						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallGfsGraphcast025, j)),
+						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallEcmwfAifs025Single, j)),
+						ModelNcepNamConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.SnowfallNcepNamConus, j)),
 					},
 					Showers: ModelValues[types.Precipitation]{
-						ModelGfsSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersGfsSeamless[j]),
-						ModelGemSeamless:        types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersGemSeamless[j]),
-						ModelEcmwIfs:            types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersEcmwfIfs[j]),
-						ModelNcepNbmConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersNcepNbmConus[j]),
-						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersGfsGraphcast025[j]),
-						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersEcmwfAifs025Single[j]),
-						ModelNcepNamConus:       types.NewPrecipitationFromInches(apiResponse.Hourly.ShowersNcepNamConus[j]),
+						ModelGfsSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersGfsSeamless, j)),
+						ModelGemSeamless:        types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersGemSeamless, j)),
+						ModelEcmwIfs:            types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersEcmwfIfs, j)),
+						ModelNcepNbmConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersNcepNbmConus, j)),
+						ModelGfsGraphcast025:    types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersGfsGraphcast025, j)),
+						ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersEcmwfAifs025Single, j)),
+						ModelNcepNamConus:       types.NewPrecipitationFromInches(accumulationAt(apiResponse.Hourly.ShowersNcepNamConus, j)),
 					},
 					SnowDepth: ModelValues[types.SnowDepth]{
 						ModelGfsSeamless:  types.NewSnowDepthFromFeet(apiResponse.Hourly.SnowDepthGfsSeamless[j]),
@@ -460,182 +735,152 @@ func mapForecastAPIResponseToForecast(forecastPoint types.ForecastPoint, primary
 				}
 				hourlyForecast.LiquidPrecipitation = liquidPrecipitation
 
+				hourlyForecast.FeelsLike = hourlyFeelsLike(hourlyForecast)
+
 				hourlyForecasts = append(hourlyForecasts, hourlyForecast)
 			} else {
 				break
 			}
 		}
 
-		var hourlySliceStart = dailyForecastHourlyIndexes[0]
-		var hourlySliceEnd = dailyForecastHourlyIndexes[len(dailyForecastHourlyIndexes)-1]
+		// Instantaneous variables (temperature, wind, freezing level) belong
+		// to the timestamps of this local day
+		var hourlySliceStart, hourlySliceEnd int
+		if dayFirstHourlyIndex >= 0 {
+			hourlySliceStart = dayFirstHourlyIndex
+			hourlySliceEnd = dayLastHourlyIndex + 1
+		}
+
+		// Accumulation variables describe the preceding hour, so the day's
+		// totals come from the timestamps in (00:00, 24:00] of the local day:
+		// the value stamped at the next day's midnight covers 23:00-00:00
+		var accumSliceStart, accumSliceEnd int
+		if dayFirstHourlyIndex >= 0 {
+			accumSliceStart = dayFirstHourlyIndex + 1
+			accumSliceEnd = dayLastHourlyIndex + 2
+		}
 
 		// TODO construct daily forecast
 		dailyForecast := DailyForecast{
 			HourlyForecasts: hourlyForecasts,
+			HoursCovered:    len(hourlyForecasts),
 			Timestamp:       dayTime,
-			Weather: ModelValues[types.Weather]{
-				ModelGfsSeamless:        types.NewWeather(apiResponse.Daily.WeatherCodeGfsSeamless[i]),
-				ModelGemSeamless:        types.NewWeather(apiResponse.Daily.WeatherCodeGemSeamless[i]),
-				ModelEcmwIfs:            types.NewWeather(apiResponse.Daily.WeatherCodeEcmwfIfs[i]),
-				ModelNcepNbmConus:       types.NewWeather(apiResponse.Daily.WeatherCodeNcepNbmConus[i]),
-				ModelGfsGraphcast025:    types.NewWeather(apiResponse.Daily.WeatherCodeGfsGraphcast025[i]),
-				ModelEcmwfAifs025Single: types.NewWeather(apiResponse.Daily.WeatherCodeEcmwfAifs025Single[i]),
-				ModelNcepNamConus:       types.NewWeather(apiResponse.Daily.WeatherCodeNcepNamConus[i]),
-			},
-			SnowfallWaterEquivalentSum: ModelValues[float64]{
-				ModelGfsSeamless:        apiResponse.Daily.SnowfallWaterEquivalentSumGfsSeamless[i],
-				ModelGemSeamless:        apiResponse.Daily.SnowfallWaterEquivalentSumGemSeamless[i],
-				ModelEcmwIfs:            apiResponse.Daily.SnowfallWaterEquivalentSumEcmwfIfs[i],
-				ModelNcepNbmConus:       apiResponse.Daily.SnowfallWaterEquivalentSumNcepNbmConus[i],
-				ModelEcmwfAifs025Single: apiResponse.Daily.SnowfallWaterEquivalentSumEcmwfAifs025Single[i],
-				ModelNcepNamConus:       apiResponse.Daily.SnowfallWaterEquivalentSumNcepNamConus[i],
-				// No data
-				// ModelGfsGraphcast025:    apiResponse.Daily.SnowfallWaterEquivalentSumGfsGraphcast025[i],
-			},
-			Sunrise: ModelValues[time.Time]{
-				ModelGfsSeamless:        toTime(apiResponse.Daily.SunriseGfsSeamless[i]),
-				ModelGemSeamless:        toTime(apiResponse.Daily.SunriseGemSeamless[i]),
-				ModelEcmwIfs:            toTime(apiResponse.Daily.SunriseEcmwfIfs[i]),
-				ModelNcepNbmConus:       toTime(apiResponse.Daily.SunriseNcepNbmConus[i]),
-				ModelGfsGraphcast025:    toTime(apiResponse.Daily.SunriseGfsGraphcast025[i]),
-				ModelEcmwfAifs025Single: toTime(apiResponse.Daily.SunriseEcmwfAifs025Single[i]),
-				ModelNcepNamConus:       toTime(apiResponse.Daily.SunriseNcepNamConus[i]),
-			},
-			Sunset: ModelValues[time.Time]{
-				ModelGfsSeamless:        toTime(apiResponse.Daily.SunsetGfsSeamless[i]),
-				ModelGemSeamless:        toTime(apiResponse.Daily.SunsetGemSeamless[i]),
-				ModelEcmwIfs:            toTime(apiResponse.Daily.SunsetEcmwfIfs[i]),
-				ModelNcepNbmConus:       toTime(apiResponse.Daily.SunsetNcepNbmConus[i]),
-				ModelGfsGraphcast025:    toTime(apiResponse.Daily.SunsetGfsGraphcast025[i]),
-				ModelEcmwfAifs025Single: toTime(apiResponse.Daily.SunsetEcmwfAifs025Single[i]),
-				ModelNcepNamConus:       toTime(apiResponse.Daily.SunsetNcepNamConus[i]),
-			},
-			WindDominantDirection: ModelValues[types.WindDirection]{
-				ModelGfsSeamless:        types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantGfsSeamless[i]),
-				ModelGemSeamless:        types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantGemSeamless[i]),
-				ModelEcmwIfs:            types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantEcmwfIfs[i]),
-				ModelNcepNbmConus:       types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantNcepNbmConus[i]),
-				ModelEcmwfAifs025Single: types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantEcmwfAifs025Single[i]),
-				ModelNcepNamConus:       types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantNcepNamConus[i]),
+			Weather: dailyModelValues(i, map[string][]int{
+				ModelGfsSeamless:        apiResponse.Daily.WeatherCodeGfsSeamless,
+				ModelGemSeamless:        apiResponse.Daily.WeatherCodeGemSeamless,
+				ModelEcmwIfs:            apiResponse.Daily.WeatherCodeEcmwfIfs,
+				ModelNcepNbmConus:       apiResponse.Daily.WeatherCodeNcepNbmConus,
+				ModelGfsGraphcast025:    apiResponse.Daily.WeatherCodeGfsGraphcast025,
+				ModelEcmwfAifs025Single: apiResponse.Daily.WeatherCodeEcmwfAifs025Single,
+				ModelNcepNamConus:       apiResponse.Daily.WeatherCodeNcepNamConus,
+			}, types.NewWeather),
+			SnowfallWaterEquivalentSum: dailyModelValues(i, map[string][]float64{
+				ModelGfsSeamless:        apiResponse.Daily.SnowfallWaterEquivalentSumGfsSeamless,
+				ModelGemSeamless:        apiResponse.Daily.SnowfallWaterEquivalentSumGemSeamless,
+				ModelEcmwIfs:            apiResponse.Daily.SnowfallWaterEquivalentSumEcmwfIfs,
+				ModelNcepNbmConus:       apiResponse.Daily.SnowfallWaterEquivalentSumNcepNbmConus,
+				ModelEcmwfAifs025Single: apiResponse.Daily.SnowfallWaterEquivalentSumEcmwfAifs025Single,
+				ModelNcepNamConus:       apiResponse.Daily.SnowfallWaterEquivalentSumNcepNamConus,
 				// No data
-				// ModelGfsGraphcast025:    types.NewWindDirection(apiResponse.Daily.WindDirection10MDominantGfsGraphcast025[i]),
-			},
-			HighestFreezingLevelHeightFt: ModelValues[float64]{
-				ModelGfsSeamless: maxFloat(apiResponse.Hourly.FreezingLevelHeightGfsSeamless[hourlySliceStart:hourlySliceEnd]),
-			},
-			LowestFreezingLevelHeightFt: ModelValues[float64]{
-				ModelGfsSeamless: minFloat(apiResponse.Hourly.FreezingLevelHeightGfsSeamless[hourlySliceStart:hourlySliceEnd]),
-			},
-			HighTemperature: ModelValues[types.Temperature]{
-				ModelGfsSeamless:        types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewTemperatureFromFahrenheit(maxFloat(apiResponse.Hourly.Temperature2MEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-			},
-			LowTemperature: ModelValues[types.Temperature]{
-				ModelGfsSeamless:        types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewTemperatureFromFahrenheit(minFloat(apiResponse.Hourly.Temperature2MEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-			},
-			MaxWindSpeed: ModelValues[types.WindSpeed]{
-				ModelGfsSeamless:        types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindSpeed10MNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-			},
-			MinWindSpeed: ModelValues[types.WindSpeed]{
-				ModelGfsSeamless:        types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindSpeed10MNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-			},
-			MaxWindGusts: ModelValues[types.WindSpeed]{
-				ModelGfsSeamless:  types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:  types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:      types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus: types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus: types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MNcepNamConus[hourlySliceStart:hourlySliceEnd])),
+				// ModelGfsGraphcast025:    apiResponse.Daily.SnowfallWaterEquivalentSumGfsGraphcast025,
+			}, func(v float64) float64 { return v }),
+			Sunrise: dailyModelValues(i, map[string][]string{
+				ModelGfsSeamless:        apiResponse.Daily.SunriseGfsSeamless,
+				ModelGemSeamless:        apiResponse.Daily.SunriseGemSeamless,
+				ModelEcmwIfs:            apiResponse.Daily.SunriseEcmwfIfs,
+				ModelNcepNbmConus:       apiResponse.Daily.SunriseNcepNbmConus,
+				ModelGfsGraphcast025:    apiResponse.Daily.SunriseGfsGraphcast025,
+				ModelEcmwfAifs025Single: apiResponse.Daily.SunriseEcmwfAifs025Single,
+				ModelNcepNamConus:       apiResponse.Daily.SunriseNcepNamConus,
+			}, toTime),
+			Sunset: dailyModelValues(i, map[string][]string{
+				ModelGfsSeamless:        apiResponse.Daily.SunsetGfsSeamless,
+				ModelGemSeamless:        apiResponse.Daily.SunsetGemSeamless,
+				ModelEcmwIfs:            apiResponse.Daily.SunsetEcmwfIfs,
+				ModelNcepNbmConus:       apiResponse.Daily.SunsetNcepNbmConus,
+				ModelGfsGraphcast025:    apiResponse.Daily.SunsetGfsGraphcast025,
+				ModelEcmwfAifs025Single: apiResponse.Daily.SunsetEcmwfAifs025Single,
+				ModelNcepNamConus:       apiResponse.Daily.SunsetNcepNamConus,
+			}, toTime),
+			WindDominantDirection: dailyModelValues(i, map[string][]float64{
+				ModelGfsSeamless:        apiResponse.Daily.WindDirection10MDominantGfsSeamless,
+				ModelGemSeamless:        apiResponse.Daily.WindDirection10MDominantGemSeamless,
+				ModelEcmwIfs:            apiResponse.Daily.WindDirection10MDominantEcmwfIfs,
+				ModelNcepNbmConus:       apiResponse.Daily.WindDirection10MDominantNcepNbmConus,
+				ModelEcmwfAifs025Single: apiResponse.Daily.WindDirection10MDominantEcmwfAifs025Single,
+				ModelNcepNamConus:       apiResponse.Daily.WindDirection10MDominantNcepNamConus,
 				// No data
-				// ModelGfsGraphcast025:    types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				// ModelEcmwfAifs025Single: types.NewWindSpeedFromMph(maxFloat(apiResponse.Hourly.WindGusts10MEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-			},
-			MinWindGusts: ModelValues[types.WindSpeed]{
-				ModelGfsSeamless:  types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:  types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:      types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus: types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus: types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-				// No data
-				// ModelGfsGraphcast025:    types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				// ModelEcmwfAifs025Single: types.NewWindSpeedFromMph(minFloat(apiResponse.Hourly.WindGusts10MEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-			},
-			TotalRainfall: ModelValues[types.Precipitation]{
-				ModelGfsSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.RainNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-			},
-			TotalPrecipitation: ModelValues[types.Precipitation]{
-				ModelGfsSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.PrecipitationNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-			},
-			TotalShowers: ModelValues[types.Precipitation]{
-				ModelGfsSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.ShowersNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-			},
-			TotalSnowfall: ModelValues[types.Precipitation]{
-				ModelGfsSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallGfsSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelGemSeamless:        types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallGemSeamless[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwIfs:            types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallEcmwfIfs[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNbmConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallNcepNbmConus[hourlySliceStart:hourlySliceEnd])),
-				ModelGfsGraphcast025:    types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallGfsGraphcast025[hourlySliceStart:hourlySliceEnd])),
-				ModelEcmwfAifs025Single: types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallEcmwfAifs025Single[hourlySliceStart:hourlySliceEnd])),
-				ModelNcepNamConus:       types.NewPrecipitationFromInches(sum(apiResponse.Hourly.SnowfallNcepNamConus[hourlySliceStart:hourlySliceEnd])),
-			},
+				// ModelGfsGraphcast025:    apiResponse.Daily.WindDirection10MDominantGfsGraphcast025,
+			}, types.NewWindDirection),
+			HighestFreezingLevelHeightFt: rangeModelValues(freezingLevelSeries, hourlySliceStart, hourlySliceEnd, maxFloat, func(v float64) float64 { return v }),
+			LowestFreezingLevelHeightFt:  rangeModelValues(freezingLevelSeries, hourlySliceStart, hourlySliceEnd, minFloat, func(v float64) float64 { return v }),
+			HighTemperature:              rangeModelValues(temperatureSeries, hourlySliceStart, hourlySliceEnd, maxFloat, types.NewTemperatureFromFahrenheit),
+			LowTemperature:               rangeModelValues(temperatureSeries, hourlySliceStart, hourlySliceEnd, minFloat, types.NewTemperatureFromFahrenheit),
+			MaxWindSpeed:                 rangeModelValues(windSpeedSeries, hourlySliceStart, hourlySliceEnd, maxFloat, types.NewWindSpeedFromMph),
+			MinWindSpeed:                 rangeModelValues(windSpeedSeries, hourlySliceStart, hourlySliceEnd, minFloat, types.NewWindSpeedFromMph),
+			MaxWindGusts:                 rangeModelValues(windGustSeries, hourlySliceStart, hourlySliceEnd, maxFloat, types.NewWindSpeedFromMph),
+			MinWindGusts:                 rangeModelValues(windGustSeries, hourlySliceStart, hourlySliceEnd, minFloat, types.NewWindSpeedFromMph),
+			TotalRain:                    rangeModelValues(rainSeries, accumSliceStart, accumSliceEnd, sum, types.NewPrecipitationFromInches),
+			TotalPrecipitation:           rangeModelValues(precipitationSeries, accumSliceStart, accumSliceEnd, sum, types.NewPrecipitationFromInches),
+			TotalShowers:                 rangeModelValues(showersSeries, accumSliceStart, accumSliceEnd, sum, types.NewPrecipitationFromInches),
+			TotalSnowfall:                rangeModelValues(snowfallSeries, accumSliceStart, accumSliceEnd, sum, types.NewPrecipitationFromInches),
 		}
 
-		totalLiquidPrecipitation := make(ModelValues[types.Precipitation], len(dailyForecast.TotalRainfall))
-		for model, rain := range dailyForecast.TotalRainfall {
+		// Derive per-model snowfall peak timing and intensity distribution
+		peaks, histograms, window := computeSnowfallStats(hourlyForecasts)
+		dailyForecast.PeakSnowfallHour = peaks
+		dailyForecast.SnowfallIntensity = histograms
+		dailyForecast.ConsensusPeakWindow = window
+		dailyForecast.SnowfallSummary = snowfallSummary(peaks, window)
+
+		totalLiquidPrecipitation := make(ModelValues[types.Precipitation], len(dailyForecast.TotalRain))
+		for model, rain := range dailyForecast.TotalRain {
 			showers := dailyForecast.TotalShowers[model]
 			totalLiquidPrecipitation[model] = types.NewPrecipitationFromInches(rain.Inches + showers.Inches)
 		}
 		dailyForecast.TotalLiquidPrecipitation = totalLiquidPrecipitation
 
+		dailyForecast.MinFeelsLike, dailyForecast.MaxFeelsLike = dailyFeelsLikeRange(hourlyForecasts)
+
 		dailyForecasts = append(dailyForecasts, dailyForecast)
 	}
 
 	forecast.DailyForecasts = dailyForecasts
+	applyHorizonEnd(forecast)
+
+	// The hourly series spans the full requested range, so a shorter daily
+	// block means Open-Meteo truncated it upstream
+	hourlyDays := (len(apiResponse.Hourly.Time) + 23) / 24
+	if len(apiResponse.Daily.Time) < hourlyDays {
+		forecast.Truncated = true
+		forecast.TruncatedReason = fmt.Sprintf("daily data covers %d of %d days", len(apiResponse.Daily.Time), hourlyDays)
+	}
+
+	applyModelHorizons(forecast)
+	applyCumulativeAccumulation(forecast)
+	applyRainOnSnow(forecast)
+	applySnowDensity(forecast)
+	applyMoonlight(forecast)
+	applyWeakLayerRisk(forecast)
+	applyWindExposure(forecast)
 
 	return forecast, nil
 
 }
 
+// dailyModelValues builds a ModelValues map from per-model daily series,
+// omitting models whose series does not cover day i. Open-Meteo truncates a
+// model's daily arrays when that model fails upstream.
+func dailyModelValues[T, U any](i int, series map[string][]T, mapValue func(T) U) ModelValues[U] {
+	values := make(ModelValues[U], len(series))
+	for model, s := range series {
+		if i < len(s) {
+			values[model] = mapValue(s[i])
+		}
+	}
+	return values
+}
+
 func toPercentage(value int) float64 {
 	return float64(value) / 100.0
 }
@@ -648,9 +893,11 @@ func toTime(value string) time.Time {
 	return time.Time{}
 }
 
-func minFloat(value []float64) float64 {
+// minFloat returns the smallest value; ok is false for an empty slice so
+// callers can omit the model instead of storing a sentinel.
+func minFloat(value []float64) (float64, bool) {
 	if len(value) == 0 {
-		return -1
+		return 0, false
 	}
 
 	minValue := value[0]
@@ -659,12 +906,13 @@ func minFloat(value []float64) float64 {
 			minValue = v
 		}
 	}
-	return minValue
+	return minValue, true
 }
 
-func maxFloat(value []float64) float64 {
+// maxFloat returns the largest value; ok is false for an empty slice.
+func maxFloat(value []float64) (float64, bool) {
 	if len(value) == 0 {
-		return -1
+		return 0, false
 	}
 
 	maxValue := value[0]
@@ -673,13 +921,57 @@ func maxFloat(value []float64) float64 {
 			maxValue = v
 		}
 	}
-	return maxValue
+	return maxValue, true
+}
+
+// accumulationAt returns the accumulation that fell during the hour starting
+// at index i. Open-Meteo accumulation values describe the preceding hour, so
+// the value stamped at i+1 covers [time[i], time[i+1]]. Past the end of the
+// horizon there is no covering value, so zero is returned.
+func accumulationAt(values []float64, i int) float64 {
+	if i+1 < len(values) {
+		return values[i+1]
+	}
+	return 0
+}
+
+// sliceRange returns values[start:end] clamped to the bounds of the slice
+func sliceRange(values []float64, start, end int) []float64 {
+	if start < 0 {
+		start = 0
+	}
+	if end > len(values) {
+		end = len(values)
+	}
+	if start >= end {
+		return nil
+	}
+	return values[start:end]
 }
 
-func sum(value []float64) float64 {
+// sum totals the values; ok is false for an empty slice, which means the
+// model has no data rather than a zero total.
+func sum(value []float64) (float64, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+
 	total := 0.0
 	for _, v := range value {
 		total += v
 	}
-	return total
+	return total, true
+}
+
+// rangeModelValues aggregates each model's hourly series over [start, end)
+// and maps the result into a ModelValues map. Models whose slice is empty are
+// omitted entirely.
+func rangeModelValues[U any](series map[string][]float64, start, end int, aggregate func([]float64) (float64, bool), mapValue func(float64) U) ModelValues[U] {
+	values := make(ModelValues[U], len(series))
+	for model, s := range series {
+		if result, ok := aggregate(sliceRange(s, start, end)); ok {
+			values[model] = mapValue(result)
+		}
+	}
+	return values
 }
@@ -87,7 +87,7 @@ func TestMapForecastAPIResponseToForecast_Integration(t *testing.T) {
 		if gfsWind.Speed.Mph < 0 || gfsWind.Speed.Mph > 200 {
 			t.Errorf("GFS wind speed %v mph seems unreasonable", gfsWind.Speed.Mph)
 		}
-		t.Logf("Current GFS wind: %.1f mph from %.0f°", gfsWind.Speed.Mph, gfsWind.Direction.Degrees)
+		t.Logf("Current GFS wind: %.1f mph from %s", gfsWind.Speed.Mph, gfsWind.Direction.Cardinal)
 	}
 
 	// Validate daily forecasts
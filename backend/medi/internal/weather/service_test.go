@@ -1,10 +1,8 @@
 package weather
 
 import (
-	"encoding/json"
-	"medi/internal/providers/openmeteo"
+	"medi/internal/testsupport"
 	"medi/internal/types"
-	"os"
 	"testing"
 	"time"
 )
@@ -105,46 +103,55 @@ func TestToTime(t *testing.T) {
 
 func TestMinFloat(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    []float64
-		expected float64
+		name       string
+		input      []float64
+		expected   float64
+		expectedOk bool
 	}{
 		{
-			name:     "single value",
-			input:    []float64{5.5},
-			expected: 5.5,
+			name:       "single value",
+			input:      []float64{5.5},
+			expected:   5.5,
+			expectedOk: true,
 		},
 		{
-			name:     "multiple values",
-			input:    []float64{5.5, 2.2, 8.8, 1.1},
-			expected: 1.1,
+			name:       "multiple values",
+			input:      []float64{5.5, 2.2, 8.8, 1.1},
+			expected:   1.1,
+			expectedOk: true,
 		},
 		{
-			name:     "negative values",
-			input:    []float64{-5.5, -2.2, -8.8},
-			expected: -8.8,
+			name:       "negative values",
+			input:      []float64{-5.5, -2.2, -8.8},
+			expected:   -8.8,
+			expectedOk: true,
 		},
 		{
-			name:     "mixed positive and negative",
-			input:    []float64{5.5, -2.2, 8.8},
-			expected: -2.2,
+			name:       "mixed positive and negative",
+			input:      []float64{5.5, -2.2, 8.8},
+			expected:   -2.2,
+			expectedOk: true,
 		},
 		{
-			name:     "empty slice",
-			input:    []float64{},
-			expected: -1,
+			name:       "empty slice",
+			input:      []float64{},
+			expectedOk: false,
 		},
 		{
-			name:     "all same values",
-			input:    []float64{3.0, 3.0, 3.0},
-			expected: 3.0,
+			name:       "all same values",
+			input:      []float64{3.0, 3.0, 3.0},
+			expected:   3.0,
+			expectedOk: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := minFloat(tt.input)
-			if result != tt.expected {
+			result, ok := minFloat(tt.input)
+			if ok != tt.expectedOk {
+				t.Errorf("minFloat(%v) ok = %v, want %v", tt.input, ok, tt.expectedOk)
+			}
+			if ok && result != tt.expected {
 				t.Errorf("minFloat(%v) = %v, want %v", tt.input, result, tt.expected)
 			}
 		})
@@ -153,46 +160,55 @@ func TestMinFloat(t *testing.T) {
 
 func TestMaxFloat(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    []float64
-		expected float64
+		name       string
+		input      []float64
+		expected   float64
+		expectedOk bool
 	}{
 		{
-			name:     "single value",
-			input:    []float64{5.5},
-			expected: 5.5,
+			name:       "single value",
+			input:      []float64{5.5},
+			expected:   5.5,
+			expectedOk: true,
 		},
 		{
-			name:     "multiple values",
-			input:    []float64{5.5, 2.2, 8.8, 1.1},
-			expected: 8.8,
+			name:       "multiple values",
+			input:      []float64{5.5, 2.2, 8.8, 1.1},
+			expected:   8.8,
+			expectedOk: true,
 		},
 		{
-			name:     "negative values",
-			input:    []float64{-5.5, -2.2, -8.8},
-			expected: -2.2,
+			name:       "negative values",
+			input:      []float64{-5.5, -2.2, -8.8},
+			expected:   -2.2,
+			expectedOk: true,
 		},
 		{
-			name:     "mixed positive and negative",
-			input:    []float64{5.5, -2.2, 8.8},
-			expected: 8.8,
+			name:       "mixed positive and negative",
+			input:      []float64{5.5, -2.2, 8.8},
+			expected:   8.8,
+			expectedOk: true,
 		},
 		{
-			name:     "empty slice",
-			input:    []float64{},
-			expected: -1,
+			name:       "empty slice",
+			input:      []float64{},
+			expectedOk: false,
 		},
 		{
-			name:     "all same values",
-			input:    []float64{3.0, 3.0, 3.0},
-			expected: 3.0,
+			name:       "all same values",
+			input:      []float64{3.0, 3.0, 3.0},
+			expected:   3.0,
+			expectedOk: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := maxFloat(tt.input)
-			if result != tt.expected {
+			result, ok := maxFloat(tt.input)
+			if ok != tt.expectedOk {
+				t.Errorf("maxFloat(%v) ok = %v, want %v", tt.input, ok, tt.expectedOk)
+			}
+			if ok && result != tt.expected {
 				t.Errorf("maxFloat(%v) = %v, want %v", tt.input, result, tt.expected)
 			}
 		})
@@ -201,46 +217,55 @@ func TestMaxFloat(t *testing.T) {
 
 func TestSum(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    []float64
-		expected float64
+		name       string
+		input      []float64
+		expected   float64
+		expectedOk bool
 	}{
 		{
-			name:     "single value",
-			input:    []float64{5.5},
-			expected: 5.5,
+			name:       "single value",
+			input:      []float64{5.5},
+			expected:   5.5,
+			expectedOk: true,
 		},
 		{
-			name:     "multiple values",
-			input:    []float64{1.0, 2.0, 3.0},
-			expected: 6.0,
+			name:       "multiple values",
+			input:      []float64{1.0, 2.0, 3.0},
+			expected:   6.0,
+			expectedOk: true,
 		},
 		{
-			name:     "negative values",
-			input:    []float64{-1.0, -2.0, -3.0},
-			expected: -6.0,
+			name:       "negative values",
+			input:      []float64{-1.0, -2.0, -3.0},
+			expected:   -6.0,
+			expectedOk: true,
 		},
 		{
-			name:     "mixed positive and negative",
-			input:    []float64{5.0, -2.0, 3.0},
-			expected: 6.0,
+			name:       "mixed positive and negative",
+			input:      []float64{5.0, -2.0, 3.0},
+			expected:   6.0,
+			expectedOk: true,
 		},
 		{
-			name:     "empty slice",
-			input:    []float64{},
-			expected: 0.0,
+			name:       "empty slice",
+			input:      []float64{},
+			expectedOk: false,
 		},
 		{
-			name:     "zero values",
-			input:    []float64{0.0, 0.0, 0.0},
-			expected: 0.0,
+			name:       "zero values",
+			input:      []float64{0.0, 0.0, 0.0},
+			expected:   0.0,
+			expectedOk: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := sum(tt.input)
-			if result != tt.expected {
+			result, ok := sum(tt.input)
+			if ok != tt.expectedOk {
+				t.Errorf("sum(%v) ok = %v, want %v", tt.input, ok, tt.expectedOk)
+			}
+			if ok && result != tt.expected {
 				t.Errorf("sum(%v) = %v, want %v", tt.input, result, tt.expected)
 			}
 		})
@@ -249,15 +274,7 @@ func TestSum(t *testing.T) {
 
 func TestMapForecastAPIResponseToForecast(t *testing.T) {
 	// Load real API response from testdata file
-	data, err := os.ReadFile("testdata/openmeteo_forecast_response.json")
-	if err != nil {
-		t.Fatalf("Failed to read testdata file: %v", err)
-	}
-
-	var apiResponse openmeteo.ForecastAPIResponse
-	if err := json.Unmarshal(data, &apiResponse); err != nil {
-		t.Fatalf("Failed to unmarshal API response: %v", err)
-	}
+	apiResponse := testsupport.NewForecastFixture(t)
 
 	// Create forecast point matching the API response (Aspen, Colorado)
 	forecastPoint := types.ForecastPoint{
@@ -270,7 +287,7 @@ func TestMapForecastAPIResponseToForecast(t *testing.T) {
 		},
 	}
 
-	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, &apiResponse)
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResponse)
 
 	if err != nil {
 		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
@@ -315,7 +332,7 @@ func TestMapForecastAPIResponseToForecast(t *testing.T) {
 		if gfsWind.Speed.Mph < 0 || gfsWind.Speed.Mph > 200 {
 			t.Errorf("GFS wind speed %v mph seems unreasonable", gfsWind.Speed.Mph)
 		}
-		t.Logf("Current GFS wind: %.1f mph from %.0f°", gfsWind.Speed.Mph, gfsWind.Direction.Degrees)
+		t.Logf("Current GFS wind: %.1f mph from %s", gfsWind.Speed.Mph, gfsWind.Direction.Cardinal)
 	}
 
 	// Verify daily forecasts were created (should be 16 days based on real API)
@@ -377,15 +394,7 @@ func TestMapForecastAPIResponseToForecast(t *testing.T) {
 
 func TestMapForecastAPIResponseToForecast_AspenSnapshot(t *testing.T) {
 	// Load the refreshed snapshot captured from the live API
-	data, err := os.ReadFile("testdata/openmeteo_forecast_response.json")
-	if err != nil {
-		t.Fatalf("Failed to read testdata file: %v", err)
-	}
-
-	var apiResponse openmeteo.ForecastAPIResponse
-	if err := json.Unmarshal(data, &apiResponse); err != nil {
-		t.Fatalf("Failed to unmarshal API response: %v", err)
-	}
+	apiResponse := testsupport.NewForecastFixture(t)
 
 	forecastPoint := types.ForecastPoint{
 		Coordinates: types.Coords{
@@ -410,7 +419,7 @@ func TestMapForecastAPIResponseToForecast_AspenSnapshot(t *testing.T) {
 
 	for _, model := range models {
 		t.Run(model, func(t *testing.T) {
-			forecast, err := mapForecastAPIResponseToForecast(forecastPoint, model, &apiResponse)
+			forecast, err := mapForecastAPIResponseToForecast(forecastPoint, model, apiResponse)
 			if err != nil {
 				t.Fatalf("mapForecastAPIResponseToForecast(%s) error: %v", model, err)
 			}
@@ -433,7 +442,7 @@ func TestMapForecastAPIResponseToForecast_AspenSnapshot(t *testing.T) {
 	}
 
 	// Detailed validation with GFS model
-	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, &apiResponse)
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResponse)
 	if err != nil {
 		t.Fatalf("mapForecastAPIResponseToForecast error: %v", err)
 	}
@@ -464,8 +473,8 @@ func TestMapForecastAPIResponseToForecast_AspenSnapshot(t *testing.T) {
 		if wind.Speed.Mph < 0 || wind.Speed.Mph > 200 {
 			t.Errorf("Model %s: wind speed %.1f mph is out of reasonable range", model, wind.Speed.Mph)
 		}
-		if wind.Direction.Degrees < 0 || wind.Direction.Degrees > 360 {
-			t.Errorf("Model %s: wind direction %.0f° is out of range", model, wind.Direction.Degrees)
+		if !wind.Direction.IsVariable() && (*wind.Direction.Degrees < 0 || *wind.Direction.Degrees >= 360) {
+			t.Errorf("Model %s: wind direction %.0f° is out of range", model, *wind.Direction.Degrees)
 		}
 	}
 
@@ -514,194 +523,10 @@ func TestMapForecastAPIResponseToForecast_AspenSnapshot(t *testing.T) {
 }
 
 func TestMapForecastAPIResponseToForecast_InvalidTimezone(t *testing.T) {
-	apiResponse := &openmeteo.ForecastAPIResponse{
-		Timezone: "Invalid/Timezone",
-		Hourly: struct {
-			Time                                       []string      `json:"time"`
-			FreezingLevelHeightGemSeamless             []interface{} `json:"freezing_level_height_gem_seamless"`
-			IsDayGemSeamless                           []int         `json:"is_day_gem_seamless"`
-			Temperature2MGemSeamless                   []float64     `json:"temperature_2m_gem_seamless"`
-			WeatherCodeGemSeamless                     []int         `json:"weather_code_gem_seamless"`
-			ApparentTemperatureGemSeamless             []float64     `json:"apparent_temperature_gem_seamless"`
-			PrecipitationProbabilityGemSeamless        []int         `json:"precipitation_probability_gem_seamless"`
-			PrecipitationGemSeamless                   []float64     `json:"precipitation_gem_seamless"`
-			CloudCoverGemSeamless                      []int         `json:"cloud_cover_gem_seamless"`
-			CloudCoverLowGemSeamless                   []int         `json:"cloud_cover_low_gem_seamless"`
-			CloudCoverMidGemSeamless                   []int         `json:"cloud_cover_mid_gem_seamless"`
-			CloudCoverHighGemSeamless                  []int         `json:"cloud_cover_high_gem_seamless"`
-			VisibilityGemSeamless                      []interface{} `json:"visibility_gem_seamless"`
-			WindSpeed10MGemSeamless                    []float64     `json:"wind_speed_10m_gem_seamless"`
-			WindDirection10MGemSeamless                []int         `json:"wind_direction_10m_gem_seamless"`
-			WindGusts10MGemSeamless                    []float64     `json:"wind_gusts_10m_gem_seamless"`
-			RelativeHumidity2MGemSeamless              []int         `json:"relative_humidity_2m_gem_seamless"`
-			RainGemSeamless                            []float64     `json:"rain_gem_seamless"`
-			ShowersGemSeamless                         []float64     `json:"showers_gem_seamless"`
-			SnowfallGemSeamless                        []float64     `json:"snowfall_gem_seamless"`
-			SnowDepthGemSeamless                       []float64     `json:"snow_depth_gem_seamless"`
-			FreezingLevelHeightEcmwfIfs                []interface{} `json:"freezing_level_height_ecmwf_ifs"`
-			IsDayEcmwfIfs                              []int         `json:"is_day_ecmwf_ifs"`
-			Temperature2MEcmwfIfs                      []float64     `json:"temperature_2m_ecmwf_ifs"`
-			WeatherCodeEcmwfIfs                        []int         `json:"weather_code_ecmwf_ifs"`
-			ApparentTemperatureEcmwfIfs                []float64     `json:"apparent_temperature_ecmwf_ifs"`
-			PrecipitationProbabilityEcmwfIfs           []int         `json:"precipitation_probability_ecmwf_ifs"`
-			PrecipitationEcmwfIfs                      []float64     `json:"precipitation_ecmwf_ifs"`
-			CloudCoverEcmwfIfs                         []int         `json:"cloud_cover_ecmwf_ifs"`
-			CloudCoverLowEcmwfIfs                      []int         `json:"cloud_cover_low_ecmwf_ifs"`
-			CloudCoverMidEcmwfIfs                      []int         `json:"cloud_cover_mid_ecmwf_ifs"`
-			CloudCoverHighEcmwfIfs                     []int         `json:"cloud_cover_high_ecmwf_ifs"`
-			VisibilityEcmwfIfs                         []float64     `json:"visibility_ecmwf_ifs"`
-			WindSpeed10MEcmwfIfs                       []float64     `json:"wind_speed_10m_ecmwf_ifs"`
-			WindDirection10MEcmwfIfs                   []int         `json:"wind_direction_10m_ecmwf_ifs"`
-			WindGusts10MEcmwfIfs                       []float64     `json:"wind_gusts_10m_ecmwf_ifs"`
-			RelativeHumidity2MEcmwfIfs                 []int         `json:"relative_humidity_2m_ecmwf_ifs"`
-			RainEcmwfIfs                               []float64     `json:"rain_ecmwf_ifs"`
-			ShowersEcmwfIfs                            []float64     `json:"showers_ecmwf_ifs"`
-			SnowfallEcmwfIfs                           []float64     `json:"snowfall_ecmwf_ifs"`
-			SnowDepthEcmwfIfs                          []float64     `json:"snow_depth_ecmwf_ifs"`
-			FreezingLevelHeightGfsSeamless             []float64     `json:"freezing_level_height_gfs_seamless"`
-			IsDayGfsSeamless                           []int         `json:"is_day_gfs_seamless"`
-			Temperature2MGfsSeamless                   []float64     `json:"temperature_2m_gfs_seamless"`
-			WeatherCodeGfsSeamless                     []int         `json:"weather_code_gfs_seamless"`
-			ApparentTemperatureGfsSeamless             []float64     `json:"apparent_temperature_gfs_seamless"`
-			PrecipitationProbabilityGfsSeamless        []int         `json:"precipitation_probability_gfs_seamless"`
-			PrecipitationGfsSeamless                   []float64     `json:"precipitation_gfs_seamless"`
-			CloudCoverGfsSeamless                      []int         `json:"cloud_cover_gfs_seamless"`
-			CloudCoverLowGfsSeamless                   []int         `json:"cloud_cover_low_gfs_seamless"`
-			CloudCoverMidGfsSeamless                   []int         `json:"cloud_cover_mid_gfs_seamless"`
-			CloudCoverHighGfsSeamless                  []int         `json:"cloud_cover_high_gfs_seamless"`
-			VisibilityGfsSeamless                      []float64     `json:"visibility_gfs_seamless"`
-			WindSpeed10MGfsSeamless                    []float64     `json:"wind_speed_10m_gfs_seamless"`
-			WindDirection10MGfsSeamless                []int         `json:"wind_direction_10m_gfs_seamless"`
-			WindGusts10MGfsSeamless                    []float64     `json:"wind_gusts_10m_gfs_seamless"`
-			RelativeHumidity2MGfsSeamless              []int         `json:"relative_humidity_2m_gfs_seamless"`
-			RainGfsSeamless                            []float64     `json:"rain_gfs_seamless"`
-			ShowersGfsSeamless                         []float64     `json:"showers_gfs_seamless"`
-			SnowfallGfsSeamless                        []float64     `json:"snowfall_gfs_seamless"`
-			SnowDepthGfsSeamless                       []float64     `json:"snow_depth_gfs_seamless"`
-			FreezingLevelHeightNcepNbmConus            []interface{} `json:"freezing_level_height_ncep_nbm_conus"`
-			IsDayNcepNbmConus                          []int         `json:"is_day_ncep_nbm_conus"`
-			Temperature2MNcepNbmConus                  []float64     `json:"temperature_2m_ncep_nbm_conus"`
-			WeatherCodeNcepNbmConus                    []int         `json:"weather_code_ncep_nbm_conus"`
-			ApparentTemperatureNcepNbmConus            []float64     `json:"apparent_temperature_ncep_nbm_conus"`
-			PrecipitationProbabilityNcepNbmConus       []int         `json:"precipitation_probability_ncep_nbm_conus"`
-			PrecipitationNcepNbmConus                  []float64     `json:"precipitation_ncep_nbm_conus"`
-			CloudCoverNcepNbmConus                     []int         `json:"cloud_cover_ncep_nbm_conus"`
-			CloudCoverLowNcepNbmConus                  []interface{} `json:"cloud_cover_low_ncep_nbm_conus"`
-			CloudCoverMidNcepNbmConus                  []interface{} `json:"cloud_cover_mid_ncep_nbm_conus"`
-			CloudCoverHighNcepNbmConus                 []interface{} `json:"cloud_cover_high_ncep_nbm_conus"`
-			VisibilityNcepNbmConus                     []float64     `json:"visibility_ncep_nbm_conus"`
-			WindSpeed10MNcepNbmConus                   []float64     `json:"wind_speed_10m_ncep_nbm_conus"`
-			WindDirection10MNcepNbmConus               []int         `json:"wind_direction_10m_ncep_nbm_conus"`
-			WindGusts10MNcepNbmConus                   []float64     `json:"wind_gusts_10m_ncep_nbm_conus"`
-			RelativeHumidity2MNcepNbmConus             []int         `json:"relative_humidity_2m_ncep_nbm_conus"`
-			RainNcepNbmConus                           []float64     `json:"rain_ncep_nbm_conus"`
-			ShowersNcepNbmConus                        []float64     `json:"showers_ncep_nbm_conus"`
-			SnowfallNcepNbmConus                       []float64     `json:"snowfall_ncep_nbm_conus"`
-			SnowDepthNcepNbmConus                      []interface{} `json:"snow_depth_ncep_nbm_conus"`
-			FreezingLevelHeightGfsGraphcast025         []interface{} `json:"freezing_level_height_gfs_graphcast025"`
-			IsDayGfsGraphcast025                       []int         `json:"is_day_gfs_graphcast025"`
-			Temperature2MGfsGraphcast025               []float64     `json:"temperature_2m_gfs_graphcast025"`
-			WeatherCodeGfsGraphcast025                 []int         `json:"weather_code_gfs_graphcast025"`
-			ApparentTemperatureGfsGraphcast025         []interface{} `json:"apparent_temperature_gfs_graphcast025"`
-			PrecipitationProbabilityGfsGraphcast025    []interface{} `json:"precipitation_probability_gfs_graphcast025"`
-			PrecipitationGfsGraphcast025               []float64     `json:"precipitation_gfs_graphcast025"`
-			CloudCoverGfsGraphcast025                  []int         `json:"cloud_cover_gfs_graphcast025"`
-			CloudCoverLowGfsGraphcast025               []int         `json:"cloud_cover_low_gfs_graphcast025"`
-			CloudCoverMidGfsGraphcast025               []int         `json:"cloud_cover_mid_gfs_graphcast025"`
-			CloudCoverHighGfsGraphcast025              []int         `json:"cloud_cover_high_gfs_graphcast025"`
-			VisibilityGfsGraphcast025                  []interface{} `json:"visibility_gfs_graphcast025"`
-			WindSpeed10MGfsGraphcast025                []float64     `json:"wind_speed_10m_gfs_graphcast025"`
-			WindDirection10MGfsGraphcast025            []int         `json:"wind_direction_10m_gfs_graphcast025"`
-			WindGusts10MGfsGraphcast025                []interface{} `json:"wind_gusts_10m_gfs_graphcast025"`
-			RelativeHumidity2MGfsGraphcast025          []interface{} `json:"relative_humidity_2m_gfs_graphcast025"`
-			RainGfsGraphcast025                        []float64     `json:"rain_gfs_graphcast025"`
-			ShowersGfsGraphcast025                     []float64     `json:"showers_gfs_graphcast025"`
-			SnowfallGfsGraphcast025                    []float64     `json:"snowfall_gfs_graphcast025"`
-			SnowDepthGfsGraphcast025                   []interface{} `json:"snow_depth_gfs_graphcast025"`
-			FreezingLevelHeightEcmwfAifs025Single      []interface{} `json:"freezing_level_height_ecmwf_aifs025_single"`
-			IsDayEcmwfAifs025Single                    []int         `json:"is_day_ecmwf_aifs025_single"`
-			Temperature2MEcmwfAifs025Single            []float64     `json:"temperature_2m_ecmwf_aifs025_single"`
-			WeatherCodeEcmwfAifs025Single              []int         `json:"weather_code_ecmwf_aifs025_single"`
-			ApparentTemperatureEcmwfAifs025Single      []float64     `json:"apparent_temperature_ecmwf_aifs025_single"`
-			PrecipitationProbabilityEcmwfAifs025Single []interface{} `json:"precipitation_probability_ecmwf_aifs025_single"`
-			PrecipitationEcmwfAifs025Single            []float64     `json:"precipitation_ecmwf_aifs025_single"`
-			CloudCoverEcmwfAifs025Single               []int         `json:"cloud_cover_ecmwf_aifs025_single"`
-			CloudCoverLowEcmwfAifs025Single            []int         `json:"cloud_cover_low_ecmwf_aifs025_single"`
-			CloudCoverMidEcmwfAifs025Single            []int         `json:"cloud_cover_mid_ecmwf_aifs025_single"`
-			CloudCoverHighEcmwfAifs025Single           []int         `json:"cloud_cover_high_ecmwf_aifs025_single"`
-			VisibilityEcmwfAifs025Single               []interface{} `json:"visibility_ecmwf_aifs025_single"`
-			WindSpeed10MEcmwfAifs025Single             []float64     `json:"wind_speed_10m_ecmwf_aifs025_single"`
-			WindDirection10MEcmwfAifs025Single         []int         `json:"wind_direction_10m_ecmwf_aifs025_single"`
-			WindGusts10MEcmwfAifs025Single             []interface{} `json:"wind_gusts_10m_ecmwf_aifs025_single"`
-			RelativeHumidity2MEcmwfAifs025Single       []int         `json:"relative_humidity_2m_ecmwf_aifs025_single"`
-			RainEcmwfAifs025Single                     []float64     `json:"rain_ecmwf_aifs025_single"`
-			ShowersEcmwfAifs025Single                  []float64     `json:"showers_ecmwf_aifs025_single"`
-			SnowfallEcmwfAifs025Single                 []float64     `json:"snowfall_ecmwf_aifs025_single"`
-			SnowDepthEcmwfAifs025Single                []interface{} `json:"snow_depth_ecmwf_aifs025_single"`
-			FreezingLevelHeightNcepNamConus            []interface{} `json:"freezing_level_height_ncep_nam_conus"`
-			IsDayNcepNamConus                          []int         `json:"is_day_ncep_nam_conus"`
-			Temperature2MNcepNamConus                  []float64     `json:"temperature_2m_ncep_nam_conus"`
-			WeatherCodeNcepNamConus                    []int         `json:"weather_code_ncep_nam_conus"`
-			ApparentTemperatureNcepNamConus            []float64     `json:"apparent_temperature_ncep_nam_conus"`
-			PrecipitationProbabilityNcepNamConus       []interface{} `json:"precipitation_probability_ncep_nam_conus"`
-			PrecipitationNcepNamConus                  []float64     `json:"precipitation_ncep_nam_conus"`
-			CloudCoverNcepNamConus                     []int         `json:"cloud_cover_ncep_nam_conus"`
-			CloudCoverLowNcepNamConus                  []int         `json:"cloud_cover_low_ncep_nam_conus"`
-			CloudCoverMidNcepNamConus                  []int         `json:"cloud_cover_mid_ncep_nam_conus"`
-			CloudCoverHighNcepNamConus                 []int         `json:"cloud_cover_high_ncep_nam_conus"`
-			VisibilityNcepNamConus                     []float64     `json:"visibility_ncep_nam_conus"`
-			WindSpeed10MNcepNamConus                   []float64     `json:"wind_speed_10m_ncep_nam_conus"`
-			WindDirection10MNcepNamConus               []int         `json:"wind_direction_10m_ncep_nam_conus"`
-			WindGusts10MNcepNamConus                   []float64     `json:"wind_gusts_10m_ncep_nam_conus"`
-			RelativeHumidity2MNcepNamConus             []int         `json:"relative_humidity_2m_ncep_nam_conus"`
-			RainNcepNamConus                           []float64     `json:"rain_ncep_nam_conus"`
-			ShowersNcepNamConus                        []float64     `json:"showers_ncep_nam_conus"`
-			SnowfallNcepNamConus                       []float64     `json:"snowfall_ncep_nam_conus"`
-			SnowDepthNcepNamConus                      []float64     `json:"snow_depth_ncep_nam_conus"`
-		}{
-			Time: []string{"2025-01-23T00:00"},
-		},
-		Daily: struct {
-			Time                                         []string      `json:"time"`
-			SnowfallWaterEquivalentSumGemSeamless        []float64     `json:"snowfall_water_equivalent_sum_gem_seamless"`
-			WeatherCodeGemSeamless                       []int         `json:"weather_code_gem_seamless"`
-			SunriseGemSeamless                           []string      `json:"sunrise_gem_seamless"`
-			SunsetGemSeamless                            []string      `json:"sunset_gem_seamless"`
-			WindDirection10MDominantGemSeamless          []int         `json:"wind_direction_10m_dominant_gem_seamless"`
-			SnowfallWaterEquivalentSumEcmwfIfs           []float64     `json:"snowfall_water_equivalent_sum_ecmwf_ifs"`
-			WeatherCodeEcmwfIfs                          []int         `json:"weather_code_ecmwf_ifs"`
-			SunriseEcmwfIfs                              []string      `json:"sunrise_ecmwf_ifs"`
-			SunsetEcmwfIfs                               []string      `json:"sunset_ecmwf_ifs"`
-			WindDirection10MDominantEcmwfIfs             []int         `json:"wind_direction_10m_dominant_ecmwf_ifs"`
-			SnowfallWaterEquivalentSumGfsSeamless        []float64     `json:"snowfall_water_equivalent_sum_gfs_seamless"`
-			WeatherCodeGfsSeamless                       []int         `json:"weather_code_gfs_seamless"`
-			SunriseGfsSeamless                           []string      `json:"sunrise_gfs_seamless"`
-			SunsetGfsSeamless                            []string      `json:"sunset_gfs_seamless"`
-			WindDirection10MDominantGfsSeamless          []int         `json:"wind_direction_10m_dominant_gfs_seamless"`
-			SnowfallWaterEquivalentSumNcepNbmConus       []float64     `json:"snowfall_water_equivalent_sum_ncep_nbm_conus"`
-			WeatherCodeNcepNbmConus                      []int         `json:"weather_code_ncep_nbm_conus"`
-			SunriseNcepNbmConus                          []string      `json:"sunrise_ncep_nbm_conus"`
-			SunsetNcepNbmConus                           []string      `json:"sunset_ncep_nbm_conus"`
-			WindDirection10MDominantNcepNbmConus         []int         `json:"wind_direction_10m_dominant_ncep_nbm_conus"`
-			SnowfallWaterEquivalentSumGfsGraphcast025    []interface{} `json:"snowfall_water_equivalent_sum_gfs_graphcast025"`
-			WeatherCodeGfsGraphcast025                   []int         `json:"weather_code_gfs_graphcast025"`
-			SunriseGfsGraphcast025                       []string      `json:"sunrise_gfs_graphcast025"`
-			SunsetGfsGraphcast025                        []string      `json:"sunset_gfs_graphcast025"`
-			WindDirection10MDominantGfsGraphcast025      []int         `json:"wind_direction_10m_dominant_gfs_graphcast025"`
-			SnowfallWaterEquivalentSumEcmwfAifs025Single []float64     `json:"snowfall_water_equivalent_sum_ecmwf_aifs025_single"`
-			WeatherCodeEcmwfAifs025Single                []int         `json:"weather_code_ecmwf_aifs025_single"`
-			SunriseEcmwfAifs025Single                    []string      `json:"sunrise_ecmwf_aifs025_single"`
-			SunsetEcmwfAifs025Single                     []string      `json:"sunset_ecmwf_aifs025_single"`
-			WindDirection10MDominantEcmwfAifs025Single   []int         `json:"wind_direction_10m_dominant_ecmwf_aifs025_single"`
-			SnowfallWaterEquivalentSumNcepNamConus       []float64     `json:"snowfall_water_equivalent_sum_ncep_nam_conus"`
-			WeatherCodeNcepNamConus                      []int         `json:"weather_code_ncep_nam_conus"`
-			SunriseNcepNamConus                          []string      `json:"sunrise_ncep_nam_conus"`
-			SunsetNcepNamConus                           []string      `json:"sunset_ncep_nam_conus"`
-			WindDirection10MDominantNcepNamConus         []int         `json:"wind_direction_10m_dominant_ncep_nam_conus"`
-		}{
-			Time: []string{},
-		},
-	}
+	apiResponse := testsupport.NewOpenMeteoResponseBuilder().
+		Timezone("Invalid/Timezone").
+		HourlyTimes("2025-01-23T00:00", 1).
+		Build()
 
 	forecastPoint := types.ForecastPoint{}
 
@@ -711,3 +536,66 @@ func TestMapForecastAPIResponseToForecast_InvalidTimezone(t *testing.T) {
 		t.Fatal("Expected error for invalid timezone, got nil")
 	}
 }
+
+func TestRangeModelValuesOmitsEmptySeries(t *testing.T) {
+	series := map[string][]float64{
+		ModelGfsSeamless: {5, 7, 6},
+		ModelGemSeamless: {},
+		ModelEcmwIfs:     nil,
+	}
+
+	values := rangeModelValues(series, 0, 3, maxFloat, func(v float64) float64 { return v })
+
+	if len(values) != 1 {
+		t.Fatalf("got %d models, want only %s", len(values), ModelGfsSeamless)
+	}
+	if values[ModelGfsSeamless] != 7 {
+		t.Errorf("%s = %v, want 7", ModelGfsSeamless, values[ModelGfsSeamless])
+	}
+	if _, present := values[ModelGemSeamless]; present {
+		t.Errorf("%s has an aggregate despite an empty series", ModelGemSeamless)
+	}
+	if _, present := values[ModelEcmwIfs]; present {
+		t.Errorf("%s has an aggregate despite a nil series", ModelEcmwIfs)
+	}
+}
+
+func TestEmptyModelSeriesOmittedFromDailyAggregates(t *testing.T) {
+	apiResp := synthForecastResponse(t, 3)
+	apiResp.Hourly.SnowfallGemSeamless = nil
+	apiResp.Hourly.SnowfallGfsSeamless[30] = 0.4
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	for i, day := range forecast.DailyForecasts {
+		if _, present := day.TotalSnowfall[ModelGemSeamless]; present {
+			t.Errorf("day %d total snowfall includes %s despite a missing series", i, ModelGemSeamless)
+		}
+		if _, present := day.TotalSnowfall[ModelGfsSeamless]; !present {
+			t.Errorf("day %d total snowfall is missing %s", i, ModelGfsSeamless)
+		}
+	}
+}
+
+func BenchmarkMapForecast(b *testing.B) {
+	apiResponse := testsupport.NewForecastFixture(b)
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResponse); err != nil {
+			b.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package weather
+
+import (
+	"fmt"
+	"time"
+)
+
+// Snowfall intensity bucket thresholds in inches per hour
+const (
+	snowfallTraceMax    = 0.2
+	snowfallModerateMax = 0.5
+)
+
+// consensusWindowHours is the width of the consensus peak window
+const consensusWindowHours = 3
+
+// SnowfallIntensityHistogram counts the hours of a day falling into each
+// snowfall intensity bucket (inches per hour).
+type SnowfallIntensityHistogram struct {
+	NoSnowHours   int // 0 in/hr
+	TraceHours    int // trace up to 0.2 in/hr
+	ModerateHours int // 0.2 to 0.5 in/hr
+	HeavyHours    int // more than 0.5 in/hr
+}
+
+// PeakWindow is the 3-hour window where the most models place their peak
+// snowfall hour.
+type PeakWindow struct {
+	Start time.Time
+	End   time.Time
+	// ModelCount is the number of models whose peak falls inside the window
+	ModelCount int
+}
+
+// computeSnowfallStats derives the per-model peak snowfall hour, the per-model
+// intensity histogram, and the ensemble consensus peak window for a day's
+// hourly forecasts. Models without any snowfall are omitted from the peak map,
+// and the consensus window is nil when no model forecasts snow.
+func computeSnowfallStats(hourlyForecasts []HourlyForecast) (ModelValues[time.Time], ModelValues[SnowfallIntensityHistogram], *PeakWindow) {
+	peaks := make(ModelValues[time.Time])
+	histograms := make(ModelValues[SnowfallIntensityHistogram])
+
+	if len(hourlyForecasts) == 0 {
+		return peaks, histograms, nil
+	}
+
+	// Collect the model names from the first hour; every hour carries the
+	// same model set
+	for model := range hourlyForecasts[0].Snowfall {
+		histogram := SnowfallIntensityHistogram{}
+		peakAmount := 0.0
+		var peakStart time.Time
+
+		for _, hour := range hourlyForecasts {
+			snowfall, ok := hour.Snowfall.GetForModel(model)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case snowfall.Inches <= 0:
+				histogram.NoSnowHours++
+			case snowfall.Inches <= snowfallTraceMax:
+				histogram.TraceHours++
+			case snowfall.Inches <= snowfallModerateMax:
+				histogram.ModerateHours++
+			default:
+				histogram.HeavyHours++
+			}
+
+			if snowfall.Inches > peakAmount {
+				peakAmount = snowfall.Inches
+				peakStart = hour.Start
+			}
+		}
+
+		histograms[model] = histogram
+		if peakAmount > 0 {
+			peaks[model] = peakStart
+		}
+	}
+
+	return peaks, histograms, consensusPeakWindow(hourlyForecasts, peaks)
+}
+
+// consensusPeakWindow slides a 3-hour window over the day and returns the
+// window containing the most per-model peak hours. Ties resolve to the
+// earliest window. Returns nil when no model has a peak.
+func consensusPeakWindow(hourlyForecasts []HourlyForecast, peaks ModelValues[time.Time]) *PeakWindow {
+	if len(peaks) == 0 {
+		return nil
+	}
+
+	var best *PeakWindow
+	for i := 0; i+consensusWindowHours <= len(hourlyForecasts); i++ {
+		windowStart := hourlyForecasts[i].Start
+		windowEnd := windowStart.Add(consensusWindowHours * time.Hour)
+
+		count := 0
+		for _, peak := range peaks {
+			if !peak.Before(windowStart) && peak.Before(windowEnd) {
+				count++
+			}
+		}
+
+		if best == nil || count > best.ModelCount {
+			best = &PeakWindow{
+				Start:      windowStart,
+				End:        windowEnd,
+				ModelCount: count,
+			}
+		}
+	}
+
+	return best
+}
+
+// snowfallSummary builds a short human-readable sentence describing the
+// consensus peak window. It returns an empty string unless a clear peak
+// exists, meaning a majority of the models with snowfall agree on the window.
+func snowfallSummary(peaks ModelValues[time.Time], window *PeakWindow) string {
+	if window == nil || len(peaks) == 0 {
+		return ""
+	}
+
+	// Require a strict majority of snow-forecasting models to agree
+	if window.ModelCount*2 <= len(peaks) {
+		return ""
+	}
+
+	return fmt.Sprintf("Snowfall is expected to peak between %s and %s.",
+		window.Start.Format("15:04"), window.End.Format("15:04"))
+}
@@ -0,0 +1,197 @@
+package weather
+
+import (
+	"medi/internal/types"
+	"testing"
+	"time"
+)
+
+// buildSnowDay constructs a day of hourly forecasts from per-model hourly
+// snowfall rates in inches. All models must supply 24 values.
+func buildSnowDay(t *testing.T, rates map[string][24]float64) []HourlyForecast {
+	t.Helper()
+
+	dayStart := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	hourly := make([]HourlyForecast, 24)
+	for i := 0; i < 24; i++ {
+		snowfall := make(ModelValues[types.Precipitation])
+		for model, values := range rates {
+			snowfall[model] = types.NewPrecipitationFromInches(values[i])
+		}
+		hourly[i] = HourlyForecast{
+			Start:    dayStart.Add(time.Duration(i) * time.Hour),
+			End:      dayStart.Add(time.Duration(i+1) * time.Hour),
+			Snowfall: snowfall,
+		}
+	}
+	return hourly
+}
+
+func TestComputeSnowfallStatsBimodalStorm(t *testing.T) {
+	// Bimodal storm: morning pulse peaking at 06:00, stronger evening pulse
+	// peaking at 18:00
+	var gfs [24]float64
+	gfs[5] = 0.3
+	gfs[6] = 0.6 // morning peak
+	gfs[7] = 0.3
+	gfs[17] = 0.4
+	gfs[18] = 0.8 // true peak
+	gfs[19] = 0.4
+
+	// GEM sees only the morning pulse
+	var gem [24]float64
+	gem[5] = 0.1
+	gem[6] = 0.4 // peak
+	gem[7] = 0.1
+
+	hourly := buildSnowDay(t, map[string][24]float64{
+		ModelGfsSeamless: gfs,
+		ModelGemSeamless: gem,
+	})
+
+	peaks, histograms, _ := computeSnowfallStats(hourly)
+
+	gfsPeak, ok := peaks.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS peak found")
+	}
+	if gfsPeak.Hour() != 18 {
+		t.Errorf("GFS peak hour = %d, want 18", gfsPeak.Hour())
+	}
+
+	gemPeak, ok := peaks.GetForModel(ModelGemSeamless)
+	if !ok {
+		t.Fatal("no GEM peak found")
+	}
+	if gemPeak.Hour() != 6 {
+		t.Errorf("GEM peak hour = %d, want 6", gemPeak.Hour())
+	}
+
+	// GFS: 18 zero hours, 0 trace, 4 moderate (0.3, 0.3, 0.4, 0.4),
+	// 2 heavy (0.6, 0.8)
+	gfsHist, ok := histograms.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS histogram found")
+	}
+	if gfsHist.NoSnowHours != 18 {
+		t.Errorf("GFS NoSnowHours = %d, want 18", gfsHist.NoSnowHours)
+	}
+	if gfsHist.TraceHours != 0 {
+		t.Errorf("GFS TraceHours = %d, want 0", gfsHist.TraceHours)
+	}
+	if gfsHist.ModerateHours != 4 {
+		t.Errorf("GFS ModerateHours = %d, want 4", gfsHist.ModerateHours)
+	}
+	if gfsHist.HeavyHours != 2 {
+		t.Errorf("GFS HeavyHours = %d, want 2", gfsHist.HeavyHours)
+	}
+
+	// GEM: 21 zero hours, 2 trace (0.1, 0.1), 1 moderate (0.4), 0 heavy
+	gemHist, ok := histograms.GetForModel(ModelGemSeamless)
+	if !ok {
+		t.Fatal("no GEM histogram found")
+	}
+	if gemHist.NoSnowHours != 21 {
+		t.Errorf("GEM NoSnowHours = %d, want 21", gemHist.NoSnowHours)
+	}
+	if gemHist.TraceHours != 2 {
+		t.Errorf("GEM TraceHours = %d, want 2", gemHist.TraceHours)
+	}
+	if gemHist.ModerateHours != 1 {
+		t.Errorf("GEM ModerateHours = %d, want 1", gemHist.ModerateHours)
+	}
+	if gemHist.HeavyHours != 0 {
+		t.Errorf("GEM HeavyHours = %d, want 0", gemHist.HeavyHours)
+	}
+}
+
+func TestConsensusPeakWindowDisagreement(t *testing.T) {
+	// Three models peak around mid-morning, one disagrees and peaks in the
+	// evening. The consensus window should cover the morning cluster.
+	var a, b, c, d [24]float64
+	a[9] = 0.5
+	b[10] = 0.5
+	c[11] = 0.5
+	d[20] = 0.9
+
+	hourly := buildSnowDay(t, map[string][24]float64{
+		ModelGfsSeamless:  a,
+		ModelGemSeamless:  b,
+		ModelEcmwIfs:      c,
+		ModelNcepNbmConus: d,
+	})
+
+	peaks, _, window := computeSnowfallStats(hourly)
+
+	if window == nil {
+		t.Fatal("expected a consensus window, got nil")
+	}
+	if window.Start.Hour() != 9 {
+		t.Errorf("window start hour = %d, want 9", window.Start.Hour())
+	}
+	if window.End.Hour() != 12 {
+		t.Errorf("window end hour = %d, want 12", window.End.Hour())
+	}
+	if window.ModelCount != 3 {
+		t.Errorf("window model count = %d, want 3", window.ModelCount)
+	}
+
+	// 3 of 4 models agree, so the summary should report the peak
+	summary := snowfallSummary(peaks, window)
+	if summary == "" {
+		t.Error("expected a summary sentence for a clear peak, got empty string")
+	}
+}
+
+func TestConsensusPeakWindowNoSnow(t *testing.T) {
+	var dry [24]float64
+	hourly := buildSnowDay(t, map[string][24]float64{
+		ModelGfsSeamless: dry,
+	})
+
+	peaks, histograms, window := computeSnowfallStats(hourly)
+
+	if len(peaks) != 0 {
+		t.Errorf("expected no peaks for a dry day, got %d", len(peaks))
+	}
+	if window != nil {
+		t.Error("expected nil consensus window for a dry day")
+	}
+
+	hist, ok := histograms.GetForModel(ModelGfsSeamless)
+	if !ok {
+		t.Fatal("no GFS histogram found")
+	}
+	if hist.NoSnowHours != 24 {
+		t.Errorf("NoSnowHours = %d, want 24", hist.NoSnowHours)
+	}
+
+	if summary := snowfallSummary(peaks, window); summary != "" {
+		t.Errorf("expected empty summary for a dry day, got %q", summary)
+	}
+}
+
+func TestSnowfallSummaryNoMajority(t *testing.T) {
+	// Two models peak far apart: no strict majority in any 3-hour window
+	var a, b [24]float64
+	a[6] = 0.5
+	b[20] = 0.5
+
+	hourly := buildSnowDay(t, map[string][24]float64{
+		ModelGfsSeamless: a,
+		ModelGemSeamless: b,
+	})
+
+	peaks, _, window := computeSnowfallStats(hourly)
+
+	if window == nil {
+		t.Fatal("expected a consensus window, got nil")
+	}
+	if window.ModelCount != 1 {
+		t.Errorf("window model count = %d, want 1", window.ModelCount)
+	}
+
+	if summary := snowfallSummary(peaks, window); summary != "" {
+		t.Errorf("expected empty summary without a clear peak, got %q", summary)
+	}
+}
@@ -0,0 +1,210 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"medi/internal/snowpack"
+	"medi/internal/types"
+)
+
+// SnowHistoryProvider supplies observed daily snowfall totals for a point
+// from an archive, oldest day first; nil when no archive is configured
+type SnowHistoryProvider interface {
+	DailySnowfall(ctx context.Context, latitude, longitude float64, since time.Time) ([]DailySnowfall, error)
+}
+
+// DailySnowfall is one archive day's observed snowfall total
+type DailySnowfall struct {
+	Date           time.Time
+	SnowfallInches float64
+}
+
+// snowSeasonStartMonth anchors the season-to-date total; a northern-
+// hemisphere snow season runs from October 1
+const snowSeasonStartMonth = time.October
+
+// snowSeasonStart returns the most recent October 1 at or before now
+func snowSeasonStart(now time.Time) time.Time {
+	year := now.Year()
+	if now.Month() < snowSeasonStartMonth {
+		year--
+	}
+	return time.Date(year, snowSeasonStartMonth, 1, 0, 0, 0, 0, now.Location())
+}
+
+// SnowReportFigure is one line of a resort-style snow report: the figure, the
+// source it came from ("archive", "forecast", "model", or "station:<name>"
+// when an observed depth anchors it), and when that source was valid.
+type SnowReportFigure struct {
+	Inches float64
+	Source string
+	AsOf   time.Time
+}
+
+// SnowReport assembles the figures resorts publish. Figures without a data
+// source behind them are nil and left out of the preformatted string, so a
+// deployment without an archive or a nearby station still reports what it
+// can.
+type SnowReport struct {
+	// Past24h and Past48h are observed snowfall from the archive
+	Past24h *SnowReportFigure
+	Past48h *SnowReportFigure
+	// Outlook7Day is the forecast consensus total over the coming week
+	Outlook7Day *SnowReportFigure
+	// Base is the snow depth, observed where a station reports nearby and
+	// modeled otherwise
+	Base *SnowReportFigure
+	// Season is the season-to-date archive total since October 1
+	Season *SnowReportFigure
+	// Timezone is the forecast timezone the AsOf timestamps render in
+	Timezone string
+	// Report is the preformatted resort-style string, e.g.
+	// "24h: 7in, 48h: 11in, 7-day: 23in, Base: 45in, Season: 210in"
+	Report string
+}
+
+// BuildSnowReport assembles the report from the forecast, the archive days
+// since the season start (oldest first, may be nil), and the latest nearby
+// station observation (may be nil). Observed figures take precedence over
+// modeled ones; figures with no source behind them are omitted.
+func BuildSnowReport(forecast *Forecast, history []DailySnowfall, obs *snowpack.Observation, now time.Time) *SnowReport {
+	report := &SnowReport{Timezone: forecast.Timezone}
+
+	if len(history) > 0 {
+		last := history[len(history)-1]
+		report.Past24h = &SnowReportFigure{
+			Inches: last.SnowfallInches,
+			Source: "archive",
+			AsOf:   last.Date,
+		}
+		past48h := last.SnowfallInches
+		if len(history) > 1 {
+			past48h += history[len(history)-2].SnowfallInches
+		}
+		report.Past48h = &SnowReportFigure{Inches: past48h, Source: "archive", AsOf: last.Date}
+
+		season := 0.0
+		for _, day := range history {
+			season += day.SnowfallInches
+		}
+		report.Season = &SnowReportFigure{Inches: season, Source: "archive", AsOf: last.Date}
+	}
+
+	if outlook, ok := outlookSnowfallInches(forecast, 7); ok {
+		report.Outlook7Day = &SnowReportFigure{
+			Inches: outlook,
+			Source: "forecast",
+			AsOf:   forecast.Timestamp,
+		}
+	}
+
+	// An observed depth beats the model consensus for the base figure
+	if obs != nil {
+		report.Base = &SnowReportFigure{
+			Inches: obs.SnowDepth.Feet * 12,
+			Source: "station:" + obs.Station,
+			AsOf:   obs.ObservedAt,
+		}
+	} else if depth, ok := currentSnowDepthInches(forecast, now); ok {
+		report.Base = &SnowReportFigure{Inches: depth, Source: "model", AsOf: now}
+	}
+
+	report.Report = formatSnowReport(report)
+	return report
+}
+
+// GetSnowReport fetches the forecast for the point and assembles the snow
+// report around it. The archive and observation lookups are each optional: a
+// missing provider or a failed fetch only costs its figures, never the
+// report.
+func (s *weatherService) GetSnowReport(ctx context.Context, point types.ForecastPoint) (*SnowReport, error) {
+	forecast, err := s.GetForecast(ctx, point, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var history []DailySnowfall
+	if s.snowHistoryProvider != nil {
+		history, err = s.snowHistoryProvider.DailySnowfall(ctx,
+			point.Coordinates.Latitude,
+			point.Coordinates.Longitude,
+			snowSeasonStart(now),
+		)
+		if err != nil {
+			s.logger.Warn("serving snow report without the archive figures", "error", err)
+			history = nil
+		}
+	}
+
+	var obs *snowpack.Observation
+	if s.observationProvider != nil {
+		obs, err = s.observationProvider.LatestObservation(ctx,
+			point.Coordinates.Latitude,
+			point.Coordinates.Longitude,
+		)
+		if err != nil {
+			s.logger.Warn("serving snow report without a station observation", "error", err)
+			obs = nil
+		}
+	}
+
+	return BuildSnowReport(forecast, history, obs, now), nil
+}
+
+// outlookSnowfallInches sums the per-day consensus snowfall over up to the
+// first days of the forecast
+func outlookSnowfallInches(forecast *Forecast, days int) (float64, bool) {
+	total := 0.0
+	counted := false
+	for i, day := range forecast.DailyForecasts {
+		if i >= days {
+			break
+		}
+		if len(day.TotalSnowfall) == 0 {
+			continue
+		}
+		total += medianValue(precipitationInches(day.TotalSnowfall))
+		counted = true
+	}
+	return total, counted
+}
+
+// currentSnowDepthInches reads the model consensus snow depth from the first
+// forecast hour that is not already over
+func currentSnowDepthInches(forecast *Forecast, now time.Time) (float64, bool) {
+	for _, day := range forecast.DailyForecasts {
+		for _, hour := range day.HourlyForecasts {
+			if !hour.End.After(now) || len(hour.SnowDepth) == 0 {
+				continue
+			}
+			depths := make(ModelValues[float64], len(hour.SnowDepth))
+			for model, depth := range hour.SnowDepth {
+				depths[model] = depth.Feet * 12
+			}
+			return medianValue(depths), true
+		}
+	}
+	return 0, false
+}
+
+// formatSnowReport renders the figures resorts publish, whole inches,
+// skipping the ones without data
+func formatSnowReport(report *SnowReport) string {
+	var parts []string
+	add := func(label string, figure *SnowReportFigure) {
+		if figure == nil {
+			return
+		}
+		parts = append(parts, fmt.Sprintf("%s: %.0fin", label, figure.Inches))
+	}
+	add("24h", report.Past24h)
+	add("48h", report.Past48h)
+	add("7-day", report.Outlook7Day)
+	add("Base", report.Base)
+	add("Season", report.Season)
+	return strings.Join(parts, ", ")
+}
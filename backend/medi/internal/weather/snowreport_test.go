@@ -0,0 +1,148 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/snowpack"
+	"medi/internal/types"
+)
+
+// snowReportForecast builds a Denver forecast whose first-week consensus
+// snowfall sums to 23 inches and whose modeled snow depth medians 35 inches
+func snowReportForecast(now time.Time) *Forecast {
+	forecast := &Forecast{
+		Timezone:  TimezoneDenver,
+		Timestamp: now,
+	}
+
+	dailyInches := []float64{3, 3, 3, 3, 3, 3, 5, 99}
+	for i, inches := range dailyInches {
+		day := DailyForecast{
+			Timestamp: now.Truncate(24 * time.Hour).Add(time.Duration(i) * 24 * time.Hour),
+			TotalSnowfall: ModelValues[types.Precipitation]{
+				ModelGfsSeamless: types.NewPrecipitationFromInches(inches),
+			},
+		}
+		if i == 0 {
+			day.HourlyForecasts = []HourlyForecast{{
+				Start: now,
+				End:   now.Add(time.Hour),
+				SnowDepth: ModelValues[types.SnowDepth]{
+					ModelGfsSeamless: types.NewSnowDepthFromFeet(30.0 / 12),
+					ModelEcmwIfs:     types.NewSnowDepthFromFeet(40.0 / 12),
+				},
+			}}
+		}
+		forecast.DailyForecasts = append(forecast.DailyForecasts, day)
+	}
+	return forecast
+}
+
+// snowReportHistory sums to a 210-inch season with 7 inches in the last day
+// and 4 the day before
+func snowReportHistory(now time.Time) []DailySnowfall {
+	history := []DailySnowfall{{Date: now.AddDate(0, 0, -3), SnowfallInches: 199}}
+	history = append(history,
+		DailySnowfall{Date: now.AddDate(0, 0, -2), SnowfallInches: 4},
+		DailySnowfall{Date: now.AddDate(0, 0, -1), SnowfallInches: 7},
+	)
+	return history
+}
+
+func snowReportObservation(now time.Time) *snowpack.Observation {
+	return &snowpack.Observation{
+		Station:    "Fremont Pass",
+		ObservedAt: now.Add(-time.Hour),
+		SnowDepth:  types.NewSnowDepthFromFeet(45.0 / 12),
+	}
+}
+
+func TestSnowReportFullData(t *testing.T) {
+	now := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	report := BuildSnowReport(snowReportForecast(now), snowReportHistory(now), snowReportObservation(now), now)
+
+	want := "24h: 7in, 48h: 11in, 7-day: 23in, Base: 45in, Season: 210in"
+	if report.Report != want {
+		t.Errorf("report = %q, want %q", report.Report, want)
+	}
+	if report.Past24h == nil || report.Past24h.Source != "archive" {
+		t.Errorf("past 24h figure = %+v, want an archive-sourced figure", report.Past24h)
+	}
+	if report.Outlook7Day == nil || report.Outlook7Day.Source != "forecast" {
+		t.Errorf("outlook figure = %+v, want a forecast-sourced figure", report.Outlook7Day)
+	}
+	// The nearby station's observed depth wins over the model consensus
+	if report.Base == nil || report.Base.Inches != 45 || report.Base.Source != "station:Fremont Pass" {
+		t.Errorf("base figure = %+v, want the cited station observation", report.Base)
+	}
+	if report.Season == nil || report.Season.Inches != 210 {
+		t.Errorf("season figure = %+v, want the 210 in archive total", report.Season)
+	}
+}
+
+func TestSnowReportWithoutStationFallsBackToModelDepth(t *testing.T) {
+	now := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	report := BuildSnowReport(snowReportForecast(now), snowReportHistory(now), nil, now)
+
+	if report.Base == nil || report.Base.Source != "model" {
+		t.Fatalf("base figure = %+v, want a model-sourced figure", report.Base)
+	}
+	if report.Base.Inches != 35 {
+		t.Errorf("base = %v in, want the 35 in model median", report.Base.Inches)
+	}
+}
+
+func TestSnowReportWithoutArchiveOmitsPastFigures(t *testing.T) {
+	now := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	report := BuildSnowReport(snowReportForecast(now), nil, snowReportObservation(now), now)
+
+	if report.Past24h != nil || report.Past48h != nil || report.Season != nil {
+		t.Errorf("past figures = %+v / %+v / %+v, want all nil without an archive",
+			report.Past24h, report.Past48h, report.Season)
+	}
+	if want := "7-day: 23in, Base: 45in"; report.Report != want {
+		t.Errorf("report = %q, want %q", report.Report, want)
+	}
+}
+
+// failingSnowHistoryProvider simulates an archive outage
+type failingSnowHistoryProvider struct{}
+
+func (failingSnowHistoryProvider) DailySnowfall(_ context.Context, latitude, longitude float64, since time.Time) ([]DailySnowfall, error) {
+	return nil, errors.New("archive unavailable")
+}
+
+func TestGetSnowReportSurvivesArchiveFailure(t *testing.T) {
+	cfg := &config.Config{
+		App: config.AppConfig{ForecastDays: 3, PowderAlertInches: 6},
+	}
+	svc := NewWeatherServiceWithProvider(&echoForecastProvider{t: t, zone: TimezoneDenver},
+		nil, nil, nil, failingSnowHistoryProvider{}, nil, &stubTimezoneService{}, config.NewHolder(cfg), slog.Default())
+
+	report, err := svc.GetSnowReport(context.Background(), timezoneCheckPoint())
+	if err != nil {
+		t.Fatalf("GetSnowReport returned error: %v", err)
+	}
+	if report.Past24h != nil || report.Season != nil {
+		t.Errorf("archive figures present after a failed fetch: %+v / %+v", report.Past24h, report.Season)
+	}
+	if report.Outlook7Day == nil {
+		t.Error("outlook figure missing; the forecast side must survive an archive outage")
+	}
+}
+
+func TestSnowSeasonStart(t *testing.T) {
+	midwinter := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	if got := snowSeasonStart(midwinter); got.Year() != 2024 || got.Month() != time.October {
+		t.Errorf("season start for January = %v, want the previous October", got)
+	}
+	autumn := time.Date(2025, 11, 3, 8, 0, 0, 0, time.UTC)
+	if got := snowSeasonStart(autumn); got.Year() != 2025 || got.Month() != time.October {
+		t.Errorf("season start for November = %v, want that year's October", got)
+	}
+}
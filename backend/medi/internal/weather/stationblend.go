@@ -0,0 +1,110 @@
+package weather
+
+import (
+	"context"
+	"time"
+
+	"medi/internal/config"
+	"medi/internal/snowpack"
+	"medi/internal/types"
+)
+
+// ObservationProvider supplies the latest snow telemetry observation near a
+// point, nil when no station reports close enough to be worth checking
+type ObservationProvider interface {
+	LatestObservation(ctx context.Context, latitude, longitude float64) (*snowpack.Observation, error)
+}
+
+// stationBlendParams maps the configured tunables onto the snowpack package's
+// eligibility and schedule parameters
+func stationBlendParams(cfg *config.Config) snowpack.Params {
+	return snowpack.Params{
+		Hours:               cfg.App.StationBlendHours,
+		MaxDistanceKm:       cfg.App.StationBlendMaxDistanceKm,
+		MaxElevationDeltaFt: cfg.App.StationBlendMaxElevationDeltaFt,
+		MaxObservationAge:   time.Duration(cfg.App.StationBlendMaxObservationAgeHours) * time.Hour,
+	}
+}
+
+// stationBlend runs the optional station blend step: fetch the latest nearby
+// observation, check eligibility, and nudge the leading hours. A provider
+// failure or an ineligible station only costs the blend, never the forecast.
+func (s *weatherService) stationBlend(ctx context.Context, forecast *Forecast, forecastPoint types.ForecastPoint, cfg *config.Config) {
+	if !cfg.App.StationBlendEnabled || s.observationProvider == nil {
+		return
+	}
+
+	obs, err := s.observationProvider.LatestObservation(ctx,
+		forecastPoint.Coordinates.Latitude,
+		forecastPoint.Coordinates.Longitude,
+	)
+	if err != nil {
+		s.logger.Warn("failed to fetch station observation", "error", err)
+		return
+	}
+	if obs == nil {
+		return
+	}
+
+	params := stationBlendParams(cfg)
+	now := time.Now()
+	if err := snowpack.Eligible(*obs, forecastPoint, now, params); err != nil {
+		s.logger.Debug("skipping station blend", "station", obs.Station, "reason", err)
+		return
+	}
+	applyStationBlend(forecast, *obs, now, params)
+}
+
+// applyStationBlend nudges the primary model's temperature and snow depth in
+// the leading forecast hours toward the station observation, with the weight
+// decaying across the blend window. Accumulation variables are never touched
+// — the observation anchors what the snowpack is, not what falls on it. The
+// applied nudges are recorded on the forecast's StationBlend block. Hours
+// already over when the blend runs are skipped, so the window always starts
+// at the current hour.
+func applyStationBlend(forecast *Forecast, obs snowpack.Observation, now time.Time, params snowpack.Params) {
+	blend := &snowpack.Blend{
+		Station: obs.Station,
+		DistanceKm: types.HaversineKm(
+			obs.Coordinates.Latitude, obs.Coordinates.Longitude,
+			forecast.ForecastPoint.Coordinates.Latitude, forecast.ForecastPoint.Coordinates.Longitude,
+		),
+		ObservedAt: obs.ObservedAt,
+	}
+
+	hours := params.BlendHours()
+	index := 0
+	for di := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[di]
+		for hi := range day.HourlyForecasts {
+			hour := &day.HourlyForecasts[hi]
+			if !hour.End.After(now) {
+				continue
+			}
+			if index >= hours {
+				break
+			}
+
+			weight := snowpack.Weight(index, hours)
+			adjustment := snowpack.Adjustment{Hour: index, Weight: weight}
+
+			if temperature, ok := hour.Temperature[forecast.PrimaryModel]; ok {
+				blended := snowpack.Toward(temperature.Fahrenheit, obs.Temperature.Fahrenheit, weight)
+				adjustment.TemperatureDeltaF = blended - temperature.Fahrenheit
+				hour.Temperature[forecast.PrimaryModel] = types.NewTemperatureFromFahrenheit(blended)
+			}
+			if depth, ok := hour.SnowDepth[forecast.PrimaryModel]; ok {
+				blended := snowpack.Toward(depth.Feet, obs.SnowDepth.Feet, weight)
+				adjustment.SnowDepthDeltaFt = blended - depth.Feet
+				hour.SnowDepth[forecast.PrimaryModel] = types.NewSnowDepthFromFeet(blended)
+			}
+
+			blend.Adjustments = append(blend.Adjustments, adjustment)
+			index++
+		}
+	}
+
+	if len(blend.Adjustments) > 0 {
+		forecast.StationBlend = blend
+	}
+}
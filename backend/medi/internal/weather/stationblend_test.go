@@ -0,0 +1,129 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"medi/internal/snowpack"
+	"medi/internal/types"
+)
+
+// buildBlendForecast builds a one-day forecast with constant values for the
+// primary and one secondary model across the given hours, starting at start
+func buildBlendForecast(start time.Time, hours int) *Forecast {
+	day := DailyForecast{}
+	for i := 0; i < hours; i++ {
+		day.HourlyForecasts = append(day.HourlyForecasts, HourlyForecast{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			End:   start.Add(time.Duration(i+1) * time.Hour),
+			Temperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(20),
+				ModelGemSeamless: types.NewTemperatureFromFahrenheit(20),
+			},
+			SnowDepth: ModelValues[types.SnowDepth]{
+				ModelGfsSeamless: types.NewSnowDepthFromFeet(3),
+			},
+			Snowfall: ModelValues[types.Precipitation]{
+				ModelGfsSeamless: types.NewPrecipitationFromInches(1),
+			},
+		})
+	}
+	return &Forecast{
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7980, -105.7770),
+			Elevation:   types.NewElevationFromFeet(11000),
+		},
+		PrimaryModel:   ModelGfsSeamless,
+		DailyForecasts: []DailyForecast{day},
+	}
+}
+
+func blendObservation(observedAt time.Time) snowpack.Observation {
+	return snowpack.Observation{
+		Station:     "Berthoud Summit",
+		Coordinates: types.NewCoords(39.8038, -105.7779),
+		Elevation:   types.NewElevationFromFeet(11300),
+		ObservedAt:  observedAt,
+		Temperature: types.NewTemperatureFromFahrenheit(30),
+		SnowDepth:   types.NewSnowDepthFromFeet(4),
+	}
+}
+
+func TestApplyStationBlendNudgesLeadingHours(t *testing.T) {
+	now := time.Date(2026, time.January, 10, 6, 30, 0, 0, time.UTC)
+	start := now.Truncate(time.Hour)
+	forecast := buildBlendForecast(start, 10)
+
+	applyStationBlend(forecast, blendObservation(now.Add(-time.Hour)), now, snowpack.Params{Hours: 6})
+
+	hours := forecast.DailyForecasts[0].HourlyForecasts
+
+	// Hour 0 moves 70% of the way toward the observation
+	if got := hours[0].Temperature[ModelGfsSeamless].Fahrenheit; math.Abs(got-27) > 1e-9 {
+		t.Errorf("hour 0 temperature = %v°F, want 27", got)
+	}
+	if got := hours[0].SnowDepth[ModelGfsSeamless].Feet; math.Abs(got-3.7) > 1e-9 {
+		t.Errorf("hour 0 snow depth = %v ft, want 3.7", got)
+	}
+	// Hour 3 gets half the starting weight
+	if got := hours[3].Temperature[ModelGfsSeamless].Fahrenheit; math.Abs(got-23.5) > 1e-9 {
+		t.Errorf("hour 3 temperature = %v°F, want 23.5", got)
+	}
+	// Past the window the model stands alone
+	if got := hours[6].Temperature[ModelGfsSeamless].Fahrenheit; got != 20 {
+		t.Errorf("hour 6 temperature = %v°F, want the raw 20", got)
+	}
+
+	// Secondary models and accumulation variables are never touched
+	for i, hour := range hours {
+		if got := hour.Temperature[ModelGemSeamless].Fahrenheit; got != 20 {
+			t.Errorf("hour %d secondary model temperature = %v°F, want 20", i, got)
+		}
+		if got := hour.Snowfall[ModelGfsSeamless].Inches; got != 1 {
+			t.Errorf("hour %d snowfall = %v in, want the raw 1", i, got)
+		}
+	}
+
+	// The corrections block records the applied nudges
+	blend := forecast.StationBlend
+	if blend == nil {
+		t.Fatal("StationBlend not recorded")
+	}
+	if blend.Station != "Berthoud Summit" {
+		t.Errorf("blend station = %q, want Berthoud Summit", blend.Station)
+	}
+	if len(blend.Adjustments) != 6 {
+		t.Fatalf("got %d adjustments, want 6", len(blend.Adjustments))
+	}
+	if got := blend.Adjustments[0].TemperatureDeltaF; math.Abs(got-7) > 1e-9 {
+		t.Errorf("hour 0 temperature delta = %v°F, want 7", got)
+	}
+	if got := blend.Adjustments[0].SnowDepthDeltaFt; math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("hour 0 snow depth delta = %v ft, want 0.7", got)
+	}
+}
+
+func TestApplyStationBlendSkipsElapsedHours(t *testing.T) {
+	// The forecast starts three hours ago; the blend window must begin at
+	// the current hour, not at stale entries
+	now := time.Date(2026, time.January, 10, 9, 30, 0, 0, time.UTC)
+	start := now.Truncate(time.Hour).Add(-3 * time.Hour)
+	forecast := buildBlendForecast(start, 12)
+
+	applyStationBlend(forecast, blendObservation(now.Add(-time.Hour)), now, snowpack.Params{Hours: 6})
+
+	hours := forecast.DailyForecasts[0].HourlyForecasts
+	for i := 0; i < 3; i++ {
+		if got := hours[i].Temperature[ModelGfsSeamless].Fahrenheit; got != 20 {
+			t.Errorf("elapsed hour %d temperature = %v°F, want the raw 20", i, got)
+		}
+	}
+	// The current (partially elapsed) hour is the first blended one
+	if got := hours[3].Temperature[ModelGfsSeamless].Fahrenheit; math.Abs(got-27) > 1e-9 {
+		t.Errorf("current hour temperature = %v°F, want 27", got)
+	}
+	if got := len(forecast.StationBlend.Adjustments); got != 6 {
+		t.Errorf("got %d adjustments, want 6", got)
+	}
+}
@@ -0,0 +1,90 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+
+	"medi/internal/config"
+)
+
+// DefaultThresholdProfile is the profile resolved when a request names none.
+const DefaultThresholdProfile = "default"
+
+// ErrUnknownThresholdProfile is returned when a request names a thresholds
+// profile that is not configured.
+var ErrUnknownThresholdProfile = errors.New("unknown thresholds profile")
+
+// defaultPowderDayInches is the daily median snowfall that scores a full 10
+// on the weekly powder scale when no profile overrides it: one point per inch
+const defaultPowderDayInches = 10.0
+
+// Thresholds bundles the snow-focused tunables behind the derived blocks:
+// powder alerts, first-tracks windows, and the weekly powder score. The
+// service resolves a request's profile into one Thresholds value and passes
+// it into the computations, which never read config themselves.
+type Thresholds struct {
+	// PowderAlertInches is the 24-hour ensemble median snowfall that
+	// triggers a powder alert
+	PowderAlertInches float64
+	// PowderDayInches is the daily median snowfall scoring a full 10 on the
+	// weekly powder scale
+	PowderDayInches float64
+	// StormMinRateInHr is the hourly snowfall rate at or above which a storm
+	// counts as still running
+	StormMinRateInHr float64
+	// WindPenaltyGustMph is the gust speed past which wind is judged to
+	// degrade the snow surface
+	WindPenaltyGustMph float64
+}
+
+// ResolveThresholds resolves a profile name against the configured profiles.
+// The empty name resolves the default profile, which is seeded from the
+// app-level settings; fields a named profile leaves unset inherit the default
+// profile's values.
+func ResolveThresholds(cfg *config.Config, profile string) (Thresholds, error) {
+	base := Thresholds{
+		PowderAlertInches:  cfg.App.PowderAlertInches,
+		PowderDayInches:    defaultPowderDayInches,
+		StormMinRateInHr:   firstTracksMinSnowRateInHr,
+		WindPenaltyGustMph: firstTracksMaxGustMph,
+	}
+	if overrides, ok := cfg.App.ThresholdProfiles[DefaultThresholdProfile]; ok {
+		base = overlayThresholds(base, overrides)
+	}
+	if profile == "" || profile == DefaultThresholdProfile {
+		return base, nil
+	}
+
+	overrides, ok := cfg.App.ThresholdProfiles[profile]
+	if !ok {
+		return Thresholds{}, fmt.Errorf("%w: %q", ErrUnknownThresholdProfile, profile)
+	}
+	return overlayThresholds(base, overrides), nil
+}
+
+// applyThresholds records on the forecast which profile its derived blocks
+// were computed under, so downstream summaries score consistently
+func applyThresholds(forecast *Forecast, profile string, thresholds Thresholds) {
+	if profile == "" {
+		profile = DefaultThresholdProfile
+	}
+	forecast.ThresholdProfile = profile
+	forecast.Thresholds = thresholds
+}
+
+// overlayThresholds lays a profile's set fields over the base values
+func overlayThresholds(base Thresholds, overrides config.ThresholdProfile) Thresholds {
+	if overrides.PowderAlertInches > 0 {
+		base.PowderAlertInches = overrides.PowderAlertInches
+	}
+	if overrides.PowderDayInches > 0 {
+		base.PowderDayInches = overrides.PowderDayInches
+	}
+	if overrides.StormMinRateInHr > 0 {
+		base.StormMinRateInHr = overrides.StormMinRateInHr
+	}
+	if overrides.WindPenaltyGustMph > 0 {
+		base.WindPenaltyGustMph = overrides.WindPenaltyGustMph
+	}
+	return base
+}
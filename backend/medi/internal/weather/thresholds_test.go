@@ -0,0 +1,122 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+
+	"medi/internal/config"
+	"medi/internal/types"
+)
+
+func thresholdsTestConfig() *config.Config {
+	return &config.Config{
+		App: config.AppConfig{
+			PowderAlertInches: 6.0,
+			ThresholdProfiles: map[string]config.ThresholdProfile{
+				"conservative": {PowderAlertInches: 10.0, PowderDayInches: 14.0, StormMinRateInHr: 0.25, WindPenaltyGustMph: 20.0},
+				"powder-hound": {PowderAlertInches: 3.0, PowderDayInches: 6.0, StormMinRateInHr: 0.05, WindPenaltyGustMph: 30.0},
+			},
+		},
+	}
+}
+
+func TestResolveThresholds(t *testing.T) {
+	cfg := thresholdsTestConfig()
+
+	// The empty name resolves the default profile, seeded from the app-level
+	// settings and the built-in values
+	def, err := ResolveThresholds(cfg, "")
+	if err != nil {
+		t.Fatalf("ResolveThresholds returned error: %v", err)
+	}
+	want := Thresholds{PowderAlertInches: 6.0, PowderDayInches: 10.0, StormMinRateInHr: 0.1, WindPenaltyGustMph: 25.0}
+	if def != want {
+		t.Errorf("default thresholds = %+v, want %+v", def, want)
+	}
+	if named, _ := ResolveThresholds(cfg, DefaultThresholdProfile); named != def {
+		t.Errorf("naming the default profile resolved %+v, want %+v", named, def)
+	}
+
+	hound, err := ResolveThresholds(cfg, "powder-hound")
+	if err != nil {
+		t.Fatalf("ResolveThresholds returned error: %v", err)
+	}
+	if hound.PowderAlertInches != 3.0 || hound.WindPenaltyGustMph != 30.0 {
+		t.Errorf("powder-hound thresholds = %+v", hound)
+	}
+
+	if _, err := ResolveThresholds(cfg, "nonexistent"); !errors.Is(err, ErrUnknownThresholdProfile) {
+		t.Errorf("error = %v, want ErrUnknownThresholdProfile", err)
+	}
+}
+
+func TestResolveThresholdsPartialProfileInheritsDefaults(t *testing.T) {
+	cfg := thresholdsTestConfig()
+	cfg.App.ThresholdProfiles["alerts-only"] = config.ThresholdProfile{PowderAlertInches: 8.0}
+
+	got, err := ResolveThresholds(cfg, "alerts-only")
+	if err != nil {
+		t.Fatalf("ResolveThresholds returned error: %v", err)
+	}
+	want := Thresholds{PowderAlertInches: 8.0, PowderDayInches: 10.0, StormMinRateInHr: 0.1, WindPenaltyGustMph: 25.0}
+	if got != want {
+		t.Errorf("thresholds = %+v, want the other fields inherited: %+v", got, want)
+	}
+
+	// A configured "default" entry rebases every profile
+	cfg.App.ThresholdProfiles[DefaultThresholdProfile] = config.ThresholdProfile{StormMinRateInHr: 0.2}
+	got, err = ResolveThresholds(cfg, "alerts-only")
+	if err != nil {
+		t.Fatalf("ResolveThresholds returned error: %v", err)
+	}
+	if got.StormMinRateInHr != 0.2 {
+		t.Errorf("StormMinRateInHr = %v, want 0.2 from the configured default profile", got.StormMinRateInHr)
+	}
+}
+
+func TestProfilesChangePowderAlertOutcome(t *testing.T) {
+	cfg := thresholdsTestConfig()
+
+	// The same 6-inch storm on day two under both profiles
+	hours := 5 * 24
+	gfs := make([]float64, hours)
+	for h := 60; h < 66; h++ {
+		gfs[h] = 1.0
+	}
+	days := buildForecastDays(t, 5, map[string][]float64{ModelGfsSeamless: gfs})
+
+	conservative, _ := ResolveThresholds(cfg, "conservative")
+	hound, _ := ResolveThresholds(cfg, "powder-hound")
+
+	if alerts := computePowderAlerts(days, conservative); len(alerts) != 0 {
+		t.Errorf("got %d alerts under the conservative profile, want none for 6 inches", len(alerts))
+	}
+	if alerts := computePowderAlerts(days, hound); len(alerts) == 0 {
+		t.Error("got no alerts under the powder-hound profile, want the 6-inch storm flagged")
+	}
+}
+
+func TestProfilesChangeWeeklyPowderScore(t *testing.T) {
+	cfg := thresholdsTestConfig()
+	day := &DailyForecast{
+		TotalSnowfall: ModelValues[types.Precipitation]{
+			ModelGfsSeamless: types.NewPrecipitationFromInches(7.0),
+		},
+	}
+
+	def, _ := ResolveThresholds(cfg, "")
+	conservative, _ := ResolveThresholds(cfg, "conservative")
+	hound, _ := ResolveThresholds(cfg, "powder-hound")
+
+	// 7 median inches: one point per inch by default, 5 when a powder day
+	// takes 14 inches, capped at 10 when it only takes 6
+	if got := weeklyDay(day, def).PowderScore; got != 7 {
+		t.Errorf("default PowderScore = %d, want 7", got)
+	}
+	if got := weeklyDay(day, conservative).PowderScore; got != 5 {
+		t.Errorf("conservative PowderScore = %d, want 5", got)
+	}
+	if got := weeklyDay(day, hound).PowderScore; got != 10 {
+		t.Errorf("powder-hound PowderScore = %d, want 10", got)
+	}
+}
@@ -0,0 +1,51 @@
+package weather
+
+import (
+	"fmt"
+
+	"medi/internal/logging"
+)
+
+// The forecast pipeline sees up to three opinions about a location's
+// timezone: the tzf polygon lookup, the zone the forecast provider echoes
+// back with its response, and the zone NWS attaches to its point metadata.
+// Near borders (Malheur County OR, parts of Arizona) they can disagree, and
+// an hourly series bucketed in the wrong zone shifts every day boundary.
+// The provider echo stays authoritative for parsing — it is the zone the
+// data was generated in — so disagreement is surfaced, not corrected.
+
+// timezoneCheckKey rounds coordinates so the discussion and forecast paths
+// agree on what counts as the same location
+func timezoneCheckKey(latitude, longitude float64) string {
+	return fmt.Sprintf("%.3f,%.3f", latitude, longitude)
+}
+
+// checkTimezoneConsistency records the tzf lookup zone on the forecast and
+// sets the mismatch flag when the lookup, the provider echo, or a remembered
+// NWS point zone disagree, logging all three.
+func (s *weatherService) checkTimezoneConsistency(forecast *Forecast, lookupZone string) {
+	forecast.LookupTimezone = lookupZone
+
+	latitude := forecast.ForecastPoint.Coordinates.Latitude
+	longitude := forecast.ForecastPoint.Coordinates.Longitude
+	nwsZone := ""
+	if zone, ok := s.nwsPointZones.Load(timezoneCheckKey(latitude, longitude)); ok {
+		nwsZone = zone.(string)
+	}
+
+	mismatch := lookupZone != forecast.Timezone
+	if nwsZone != "" && nwsZone != forecast.Timezone {
+		mismatch = true
+	}
+	if !mismatch {
+		return
+	}
+
+	forecast.TimezoneMismatch = true
+	s.logger.Warn("timezone sources disagree, parsing with the provider echo",
+		logging.Coords(latitude, longitude),
+		"tzf", lookupZone,
+		"provider", forecast.Timezone,
+		"nws", nwsZone,
+	)
+}
@@ -0,0 +1,127 @@
+package weather
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"medi/internal/config"
+	"medi/internal/providers/nws"
+	"medi/internal/providers/openmeteo"
+	"medi/internal/testsupport"
+	"medi/internal/types"
+)
+
+// echoForecastProvider serves the committed fixture with its echoed timezone
+// replaced, simulating a provider that resolved the border differently than
+// the tzf lookup.
+type echoForecastProvider struct {
+	t    *testing.T
+	zone string
+}
+
+func (p *echoForecastProvider) GetForecast(_ context.Context, latitude, longitude, elevationMeters float64, forecastDays int, timezone string) (*openmeteo.ForecastAPIResponse, error) {
+	resp := testsupport.NewForecastFixture(p.t)
+	resp.Timezone = p.zone
+	return resp, nil
+}
+
+// stubDiscussionProvider answers NWS point lookups with a fixed point zone
+type stubDiscussionProvider struct {
+	zone string
+}
+
+func (p *stubDiscussionProvider) GetPoint(latitude, longitude float64) (*nws.PointAPIResponse, error) {
+	point := &nws.PointAPIResponse{}
+	point.Properties.Cwa = "GJT"
+	point.Properties.TimeZone = p.zone
+	return point, nil
+}
+
+func (p *stubDiscussionProvider) GetAreaForecastDiscussion(locationId string) (*nws.AFDAPIResponse, error) {
+	return &nws.AFDAPIResponse{ProductText: ".SYNOPSIS...quiet.\n&&\n"}, nil
+}
+
+func timezoneCheckService(t *testing.T, provider ForecastProvider, discussion ForecastDiscussionProvider) Service {
+	t.Helper()
+	cfg := &config.Config{
+		App: config.AppConfig{ForecastDays: 3, PowderAlertInches: 6},
+	}
+	return NewWeatherServiceWithProvider(provider, nil, discussion, nil, nil, nil, &stubTimezoneService{}, config.NewHolder(cfg), slog.Default())
+}
+
+func timezoneCheckPoint() types.ForecastPoint {
+	return types.ForecastPoint{
+		Coordinates: types.NewCoords(39.1154, -107.6584),
+		Elevation:   types.NewElevationFromFeet(9514),
+	}
+}
+
+func TestTimezoneMismatchPrefersProviderEcho(t *testing.T) {
+	// tzf resolves Denver, the provider echoes Los Angeles
+	svc := timezoneCheckService(t, &echoForecastProvider{t: t, zone: "America/Los_Angeles"}, nil)
+
+	forecast, err := svc.GetForecast(context.Background(), timezoneCheckPoint(), "")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+
+	if forecast.Timezone != "America/Los_Angeles" {
+		t.Errorf("Timezone = %q, want the provider echo America/Los_Angeles", forecast.Timezone)
+	}
+	if forecast.LookupTimezone != TimezoneDenver {
+		t.Errorf("LookupTimezone = %q, want %q", forecast.LookupTimezone, TimezoneDenver)
+	}
+	if !forecast.TimezoneMismatch {
+		t.Error("TimezoneMismatch not set when tzf and the provider disagree")
+	}
+
+	// The hourly series must be parsed in the echoed zone, not the lookup's
+	hours := forecast.Hours()
+	if len(hours) == 0 {
+		t.Fatal("forecast has no hours")
+	}
+	if got := hours[0].Start.Location().String(); got != "America/Los_Angeles" {
+		t.Errorf("hourly timestamps parsed in %q, want America/Los_Angeles", got)
+	}
+}
+
+func TestTimezoneAgreementLeavesFlagClear(t *testing.T) {
+	svc := timezoneCheckService(t, &echoForecastProvider{t: t, zone: TimezoneDenver}, nil)
+
+	forecast, err := svc.GetForecast(context.Background(), timezoneCheckPoint(), "")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+	if forecast.TimezoneMismatch {
+		t.Error("TimezoneMismatch set although every source agrees")
+	}
+	if forecast.LookupTimezone != TimezoneDenver {
+		t.Errorf("LookupTimezone = %q, want %q", forecast.LookupTimezone, TimezoneDenver)
+	}
+}
+
+func TestTimezoneMismatchFromNWSPointZone(t *testing.T) {
+	// tzf and the provider agree on Denver, but the NWS point metadata from
+	// an earlier discussion lookup says Phoenix
+	svc := timezoneCheckService(t,
+		&echoForecastProvider{t: t, zone: TimezoneDenver},
+		&stubDiscussionProvider{zone: "America/Phoenix"},
+	)
+	point := timezoneCheckPoint()
+
+	if _, err := svc.GetForecastDiscussion(point); err != nil {
+		t.Fatalf("GetForecastDiscussion returned error: %v", err)
+	}
+
+	forecast, err := svc.GetForecast(context.Background(), point, "")
+	if err != nil {
+		t.Fatalf("GetForecast returned error: %v", err)
+	}
+	if !forecast.TimezoneMismatch {
+		t.Error("TimezoneMismatch not set when the NWS point zone disagrees")
+	}
+	if forecast.Timezone != TimezoneDenver {
+		t.Errorf("Timezone = %q, the NWS zone must not displace the provider echo", forecast.Timezone)
+	}
+}
@@ -0,0 +1,112 @@
+package weather
+
+import (
+	"encoding/json"
+	"testing"
+
+	"medi/internal/providers/openmeteo"
+	"medi/internal/types"
+)
+
+// truncateDailySeries shortens daily series in a response via a JSON
+// round-trip. Every daily array longer than days is cut to that length; when
+// only is non-empty just that series is truncated.
+func truncateDailySeries(t *testing.T, apiResp *openmeteo.ForecastAPIResponse, days int, only string) *openmeteo.ForecastAPIResponse {
+	t.Helper()
+
+	data, err := json.Marshal(apiResp)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	daily, ok := raw["daily"].(map[string]interface{})
+	if !ok {
+		t.Fatal("response has no daily block")
+	}
+	for key, value := range daily {
+		if only != "" && key != only {
+			continue
+		}
+		series, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		if len(series) > days {
+			daily[key] = series[:days]
+		}
+	}
+
+	data, err = json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("failed to re-marshal response: %v", err)
+	}
+	var truncated openmeteo.ForecastAPIResponse
+	if err := json.Unmarshal(data, &truncated); err != nil {
+		t.Fatalf("failed to decode truncated response: %v", err)
+	}
+	return &truncated
+}
+
+func TestDailyBlockShorterThanHourlySeries(t *testing.T) {
+	apiResp := synthForecastResponse(t, 16)
+	apiResp = truncateDailySeries(t, apiResp, 10, "")
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	if len(forecast.DailyForecasts) != 10 {
+		t.Errorf("got %d daily forecasts, want 10", len(forecast.DailyForecasts))
+	}
+	if !forecast.Truncated {
+		t.Error("forecast not flagged truncated")
+	}
+	if forecast.TruncatedReason == "" {
+		t.Error("truncated forecast has no reason")
+	}
+}
+
+func TestDailySeriesShorterForOneModel(t *testing.T) {
+	apiResp := synthForecastResponse(t, 16)
+	// Only the GEM weather code series is cut short
+	apiResp = truncateDailySeries(t, apiResp, 5, "weather_code_gem_seamless")
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	if len(forecast.DailyForecasts) != 16 {
+		t.Fatalf("got %d daily forecasts, want 16", len(forecast.DailyForecasts))
+	}
+	if forecast.Truncated {
+		t.Error("forecast flagged truncated when only one model's series is short")
+	}
+
+	dayFive := forecast.DailyForecasts[4]
+	if !dayFive.Weather.HasModel(ModelGemSeamless) {
+		t.Error("day five weather missing GEM, series still covers it")
+	}
+
+	daySix := forecast.DailyForecasts[5]
+	if daySix.Weather.HasModel(ModelGemSeamless) {
+		t.Error("day six weather includes GEM beyond its series length")
+	}
+	if !daySix.Weather.HasModel(ModelGfsSeamless) {
+		t.Error("day six weather missing GFS, its series is full length")
+	}
+}
@@ -0,0 +1,178 @@
+package weather
+
+import (
+	"time"
+
+	"medi/internal/astro"
+)
+
+// Weak layer formation risk ratings for DailyForecast.WeakLayerFormationRisk.
+// Clear, cold, calm, dry nights favor surface hoar growth and near-surface
+// faceting, the usual seeds of persistent weak layers.
+const (
+	WeakLayerRiskHigh     = "high"
+	WeakLayerRiskModerate = "moderate"
+	WeakLayerRiskLow      = "low"
+)
+
+// Overnight thresholds for the weak layer heuristic. A factor holds when the
+// model stays on the favorable side of its threshold for every overnight hour.
+const (
+	weakLayerMaxCloudCoverPercent = 30.0
+	weakLayerMaxWindMph           = 10.0
+	weakLayerMaxTempF             = 20.0
+)
+
+// Factor names listed in WeakLayerRisk.Factors.
+const (
+	weakLayerFactorClearSkies = "clear skies"
+	weakLayerFactorLightWinds = "light winds"
+	weakLayerFactorColdTemps  = "cold temperatures"
+	weakLayerFactorDry        = "no precipitation"
+)
+
+// WeakLayerRisk rates how favorable a day's overnight hours (sunset to
+// sunrise) are for surface hoar and faceting. This is a weather-side
+// heuristic, not a snowpack or stability forecast: it says nothing about
+// whether a formed layer gets buried or reacts.
+type WeakLayerRisk struct {
+	// Risk is high, moderate, or low
+	Risk string
+	// Factors lists the favorable conditions the primary model sustains
+	// through the night, for attribution in clients
+	Factors []string
+	// ModelAgreement is the fraction of models with overnight data that meet
+	// every criterion themselves
+	ModelAgreement float64
+}
+
+// applyWeakLayerRisk attaches a WeakLayerFormationRisk block to each daily
+// forecast with overnight hours. The rating comes from how many factors the
+// primary model sustains, demoted to moderate when the rest of the ensemble
+// disagrees.
+func applyWeakLayerRisk(forecast *Forecast) {
+	latitude := forecast.ForecastPoint.Coordinates.Latitude
+	longitude := forecast.ForecastPoint.Coordinates.Longitude
+
+	for i := range forecast.DailyForecasts {
+		day := &forecast.DailyForecasts[i]
+		sun := astro.Sun(day.Timestamp, latitude, longitude)
+
+		night := overnightHours(day.HourlyForecasts, &sun)
+		if len(night) == 0 {
+			continue
+		}
+
+		factors := weakLayerFactors(night, forecast.PrimaryModel)
+
+		qualifying, withData := 0, 0
+		for _, model := range overnightModels(night) {
+			withData++
+			if len(weakLayerFactors(night, model)) == 4 {
+				qualifying++
+			}
+		}
+
+		risk := &WeakLayerRisk{
+			Factors: factors,
+		}
+		if withData > 0 {
+			risk.ModelAgreement = float64(qualifying) / float64(withData)
+		}
+
+		switch {
+		case len(factors) == 4 && risk.ModelAgreement >= 0.5:
+			risk.Risk = WeakLayerRiskHigh
+		case len(factors) >= 3:
+			risk.Risk = WeakLayerRiskModerate
+		default:
+			risk.Risk = WeakLayerRiskLow
+		}
+
+		day.WeakLayerFormationRisk = risk
+	}
+}
+
+// overnightHours keeps the hours outside the sun-up window: at or after
+// sunset and before sunrise. Under the midnight sun there are none; during
+// polar night every hour counts.
+func overnightHours(hours []HourlyForecast, sun *astro.SunInfo) []HourlyForecast {
+	if sun.Rise == nil && sun.Set == nil && sun.DayLength > 0 {
+		return nil
+	}
+
+	night := make([]HourlyForecast, 0, len(hours))
+	for _, hour := range hours {
+		if daytimeHour(hour.Start, sun) {
+			continue
+		}
+		night = append(night, hour)
+	}
+	return night
+}
+
+func daytimeHour(start time.Time, sun *astro.SunInfo) bool {
+	if sun.Rise != nil && start.Before(*sun.Rise) {
+		return false
+	}
+	if sun.Set != nil && !start.Before(*sun.Set) {
+		return false
+	}
+	return sun.Rise != nil || sun.Set != nil
+}
+
+// overnightModels collects the models carrying temperature data for at least
+// one overnight hour.
+func overnightModels(night []HourlyForecast) []string {
+	union := ModelValues[bool]{}
+	for _, hour := range night {
+		for _, model := range hour.Temperature.Models() {
+			union[model] = true
+		}
+	}
+	return union.OrderedModels()
+}
+
+// weakLayerFactors returns the factors the model sustains through every
+// overnight hour. A factor needs data in at least one hour, and every hour
+// with data must stay on the favorable side of the threshold.
+func weakLayerFactors(night []HourlyForecast, model string) []string {
+	clear, haveClouds := true, false
+	calm, haveWinds := true, false
+	cold, haveTemps := true, false
+	dry, havePrecip := true, false
+
+	for _, hour := range night {
+		if cover, ok := hour.CloudCover.GetForModel(model); ok {
+			haveClouds = true
+			clear = clear && cover < weakLayerMaxCloudCoverPercent
+		}
+		if wind, ok := hour.Wind.GetForModel(model); ok {
+			haveWinds = true
+			calm = calm && wind.Speed.Mph < weakLayerMaxWindMph
+		}
+		if temperature, ok := hour.Temperature.GetForModel(model); ok {
+			haveTemps = true
+			cold = cold && temperature.Fahrenheit < weakLayerMaxTempF
+		}
+		if precipitation, ok := hour.Precipitation.GetForModel(model); ok {
+			havePrecip = true
+			dry = dry && precipitation.Inches == 0
+		}
+	}
+
+	var factors []string
+	if haveClouds && clear {
+		factors = append(factors, weakLayerFactorClearSkies)
+	}
+	if haveWinds && calm {
+		factors = append(factors, weakLayerFactorLightWinds)
+	}
+	if haveTemps && cold {
+		factors = append(factors, weakLayerFactorColdTemps)
+	}
+	if havePrecip && dry {
+		factors = append(factors, weakLayerFactorDry)
+	}
+	return factors
+}
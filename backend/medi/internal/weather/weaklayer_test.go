@@ -0,0 +1,154 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// weakLayerNight describes one model's overnight conditions for the test
+// forecast builder.
+type weakLayerNight struct {
+	cloudCover  float64
+	windMph     float64
+	tempF       float64
+	precipIn    float64
+	secondModel *weakLayerNight
+}
+
+// weakLayerForecast builds a one-day Denver forecast whose night hours carry
+// the given conditions. Daytime hours are deliberately warm, windy, and
+// cloudy so only the overnight window can produce the factors.
+func weakLayerForecast(night weakLayerNight) *Forecast {
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	day := DailyForecast{Timestamp: time.Date(2025, 1, 13, 0, 0, 0, 0, denver)}
+
+	for hour := 0; hour < 24; hour++ {
+		start := day.Timestamp.Add(time.Duration(hour) * time.Hour)
+
+		// Denver mid-January: sun up roughly 07:20 to 16:50
+		conditions := night
+		if hour >= 8 && hour < 16 {
+			conditions = weakLayerNight{cloudCover: 90, windMph: 25, tempF: 35, precipIn: 0.1}
+		}
+
+		forecastHour := HourlyForecast{
+			Start:         start,
+			End:           start.Add(time.Hour),
+			CloudCover:    ModelValues[float64]{ModelGfsSeamless: conditions.cloudCover},
+			Temperature:   ModelValues[types.Temperature]{ModelGfsSeamless: types.NewTemperatureFromFahrenheit(conditions.tempF)},
+			Precipitation: ModelValues[types.Precipitation]{ModelGfsSeamless: types.NewPrecipitationFromInches(conditions.precipIn)},
+			Wind: ModelValues[types.Wind]{ModelGfsSeamless: {
+				Speed: types.NewWindSpeedFromMph(conditions.windMph),
+			}},
+		}
+		if second := night.secondModel; second != nil && !(hour >= 8 && hour < 16) {
+			forecastHour.CloudCover[ModelEcmwIfs] = second.cloudCover
+			forecastHour.Temperature[ModelEcmwIfs] = types.NewTemperatureFromFahrenheit(second.tempF)
+			forecastHour.Precipitation[ModelEcmwIfs] = types.NewPrecipitationFromInches(second.precipIn)
+			forecastHour.Wind[ModelEcmwIfs] = types.Wind{Speed: types.NewWindSpeedFromMph(second.windMph)}
+		}
+		day.HourlyForecasts = append(day.HourlyForecasts, forecastHour)
+	}
+
+	return &Forecast{
+		Timezone:     TimezoneDenver,
+		PrimaryModel: ModelGfsSeamless,
+		ForecastPoint: types.ForecastPoint{
+			Coordinates: types.NewCoords(39.7392, -104.9903),
+		},
+		DailyForecasts: []DailyForecast{day},
+	}
+}
+
+func hasFactor(factors []string, want string) bool {
+	for _, factor := range factors {
+		if factor == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWeakLayerClearColdNight(t *testing.T) {
+	forecast := weakLayerForecast(weakLayerNight{cloudCover: 5, windMph: 3, tempF: 10, precipIn: 0})
+	applyWeakLayerRisk(forecast)
+
+	risk := forecast.DailyForecasts[0].WeakLayerFormationRisk
+	if risk == nil {
+		t.Fatal("WeakLayerFormationRisk not attached")
+	}
+	if risk.Risk != WeakLayerRiskHigh {
+		t.Errorf("Risk = %s, want %s on a clear, cold, calm, dry night", risk.Risk, WeakLayerRiskHigh)
+	}
+	if len(risk.Factors) != 4 {
+		t.Errorf("Factors = %v, want all four", risk.Factors)
+	}
+	if risk.ModelAgreement != 1.0 {
+		t.Errorf("ModelAgreement = %v, want 1.0 with a single qualifying model", risk.ModelAgreement)
+	}
+}
+
+func TestWeakLayerCloudyNight(t *testing.T) {
+	forecast := weakLayerForecast(weakLayerNight{cloudCover: 85, windMph: 3, tempF: 10, precipIn: 0})
+	applyWeakLayerRisk(forecast)
+
+	risk := forecast.DailyForecasts[0].WeakLayerFormationRisk
+	if risk == nil {
+		t.Fatal("WeakLayerFormationRisk not attached")
+	}
+	if risk.Risk != WeakLayerRiskModerate {
+		t.Errorf("Risk = %s, want %s with clouds blocking radiative cooling", risk.Risk, WeakLayerRiskModerate)
+	}
+	if hasFactor(risk.Factors, weakLayerFactorClearSkies) {
+		t.Errorf("Factors = %v, clear skies should be missing on a cloudy night", risk.Factors)
+	}
+	for _, want := range []string{weakLayerFactorLightWinds, weakLayerFactorColdTemps, weakLayerFactorDry} {
+		if !hasFactor(risk.Factors, want) {
+			t.Errorf("Factors = %v, missing %q", risk.Factors, want)
+		}
+	}
+}
+
+func TestWeakLayerWindyNight(t *testing.T) {
+	// Wind and warmth both fail: two factors left, low risk
+	forecast := weakLayerForecast(weakLayerNight{cloudCover: 5, windMph: 22, tempF: 28, precipIn: 0})
+	applyWeakLayerRisk(forecast)
+
+	risk := forecast.DailyForecasts[0].WeakLayerFormationRisk
+	if risk == nil {
+		t.Fatal("WeakLayerFormationRisk not attached")
+	}
+	if risk.Risk != WeakLayerRiskLow {
+		t.Errorf("Risk = %s, want %s on a windy, warm night", risk.Risk, WeakLayerRiskLow)
+	}
+	if hasFactor(risk.Factors, weakLayerFactorLightWinds) || hasFactor(risk.Factors, weakLayerFactorColdTemps) {
+		t.Errorf("Factors = %v, winds and temps should be missing", risk.Factors)
+	}
+}
+
+func TestWeakLayerEnsembleAgreementBoundary(t *testing.T) {
+	// The primary model sees a perfect night but the second model keeps the
+	// sky overcast. Half the ensemble agreeing is still enough for high; one
+	// model fewer would demote it
+	forecast := weakLayerForecast(weakLayerNight{
+		cloudCover: 5, windMph: 3, tempF: 10, precipIn: 0,
+		secondModel: &weakLayerNight{cloudCover: 85, windMph: 3, tempF: 10, precipIn: 0},
+	})
+	applyWeakLayerRisk(forecast)
+
+	risk := forecast.DailyForecasts[0].WeakLayerFormationRisk
+	if risk == nil {
+		t.Fatal("WeakLayerFormationRisk not attached")
+	}
+	if risk.ModelAgreement != 0.5 {
+		t.Errorf("ModelAgreement = %v, want 0.5", risk.ModelAgreement)
+	}
+	if risk.Risk != WeakLayerRiskHigh {
+		t.Errorf("Risk = %s, want %s at exactly half agreement", risk.Risk, WeakLayerRiskHigh)
+	}
+	if len(risk.Factors) != 4 {
+		t.Errorf("primary model factors = %v, want all four", risk.Factors)
+	}
+}
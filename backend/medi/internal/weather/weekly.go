@@ -0,0 +1,132 @@
+package weather
+
+import (
+	"time"
+
+	"medi/internal/types"
+)
+
+// WeeklyDays is the window length of a weekly summary.
+const WeeklyDays = 7
+
+const (
+	// weeklySnowChanceThresholdInches is the daily total a model must
+	// forecast for the day to count toward the chance of snow
+	weeklySnowChanceThresholdInches = 0.1
+	// weeklyPowderScoreCap pins the top of the powder score scale
+	weeklyPowderScoreCap = 10
+)
+
+// WeeklyDay condenses one forecast day to the essentials a small-screen week
+// strip can render: ensemble medians, model ranges, and agreement.
+type WeeklyDay struct {
+	Date time.Time
+	// HighTempF and LowTempF are the ensemble median daily extremes
+	HighTempF float64
+	LowTempF  float64
+	// Snowfall spans the per-model daily totals
+	Snowfall SnowfallRange
+	// MaxWindGustMph is the highest gust any model forecasts for the day
+	MaxWindGustMph float64
+	// Weather is the condition the most models agree on; ties go to the
+	// more severe code
+	Weather types.Weather
+	// PowderScore rates the day 0-10 against the profile's powder-day
+	// snowfall; a full powder day scores the cap, and the default profile
+	// works out to one point per median inch
+	PowderScore int
+	// SnowChancePercent is the share of models forecasting measurable snow
+	SnowChancePercent int
+	// MeltDegreeHours is the ensemble median melt energy for the day, in
+	// °F·h, and RapidWarmup carries the day's warm-up consensus
+	MeltDegreeHours float64
+	RapidWarmup     bool
+}
+
+// WeeklySummary condenses up to seven days starting on start's calendar date
+// in the forecast's timezone. Days outside the forecast horizon are omitted,
+// so a window near the end of the horizon returns fewer entries.
+func (f *Forecast) WeeklySummary(start time.Time) []WeeklyDay {
+	loc := f.location()
+	base := start.In(loc)
+
+	summary := make([]WeeklyDay, 0, WeeklyDays)
+	for offset := 0; offset < WeeklyDays; offset++ {
+		// Noon sidesteps DST gaps when stepping whole days
+		date := time.Date(base.Year(), base.Month(), base.Day()+offset, 12, 0, 0, 0, loc)
+		day, ok := f.DayFor(date)
+		if !ok {
+			continue
+		}
+		summary = append(summary, weeklyDay(day, f.Thresholds))
+	}
+	return summary
+}
+
+// weeklyDay reduces one daily forecast to its week-strip entry.
+func weeklyDay(day *DailyForecast, thresholds Thresholds) WeeklyDay {
+	entry := WeeklyDay{
+		Date:      day.Timestamp,
+		HighTempF: medianValue(temperatureFahrenheit(day.HighTemperature)),
+		LowTempF:  medianValue(temperatureFahrenheit(day.LowTemperature)),
+		Weather:   dominantWeather(day.Weather),
+
+		MeltDegreeHours: medianValue(day.MeltDegreeHours),
+		RapidWarmup:     day.RapidWarmupConsensus,
+	}
+
+	var totals []float64
+	snowy := 0
+	for _, total := range day.TotalSnowfall {
+		totals = append(totals, total.Inches)
+		if total.Inches >= weeklySnowChanceThresholdInches {
+			snowy++
+		}
+	}
+	if minInches, ok := minFloat(totals); ok {
+		maxInches, _ := maxFloat(totals)
+		entry.Snowfall = SnowfallRange{MinInches: minInches, MaxInches: maxInches}
+		entry.SnowChancePercent = (snowy*100 + len(totals)/2) / len(totals)
+	}
+
+	// The profile's powder day spreads across the full scale; older cached
+	// forecasts without resolved thresholds score at the default spacing
+	inchesPerPoint := thresholds.PowderDayInches / weeklyPowderScoreCap
+	if inchesPerPoint <= 0 {
+		inchesPerPoint = defaultPowderDayInches / weeklyPowderScoreCap
+	}
+	medianInches := medianValue(precipitationInches(day.TotalSnowfall))
+	entry.PowderScore = int(medianInches/inchesPerPoint + 0.5)
+	if entry.PowderScore > weeklyPowderScoreCap {
+		entry.PowderScore = weeklyPowderScoreCap
+	}
+
+	for _, gusts := range day.MaxWindGusts {
+		if gusts.Mph > entry.MaxWindGustMph {
+			entry.MaxWindGustMph = gusts.Mph
+		}
+	}
+
+	return entry
+}
+
+// dominantWeather picks the condition most models report for the day; ties go
+// to the higher weather code, which orders roughly by severity.
+func dominantWeather(conditions ModelValues[types.Weather]) types.Weather {
+	counts := map[int]int{}
+	byCode := map[int]types.Weather{}
+	for _, condition := range conditions {
+		counts[condition.Code]++
+		byCode[condition.Code] = condition
+	}
+
+	dominant := types.Weather{}
+	best := 0
+	for code, count := range counts {
+		if count > best || (count == best && code > dominant.Code) {
+			dominant = byCode[code]
+			best = count
+		}
+	}
+	return dominant
+}
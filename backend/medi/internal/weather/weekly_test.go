@@ -0,0 +1,116 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/types"
+)
+
+// weeklyTestForecast builds a Denver forecast with the given number of days
+// starting Jan 15, each carrying a three-model daily ensemble.
+func weeklyTestForecast(days int) *Forecast {
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	forecast := &Forecast{Timezone: TimezoneDenver}
+
+	for i := 0; i < days; i++ {
+		forecast.DailyForecasts = append(forecast.DailyForecasts, DailyForecast{
+			Timestamp: time.Date(2025, 1, 15+i, 0, 0, 0, 0, denver),
+			Weather: ModelValues[types.Weather]{
+				ModelGfsSeamless: {Code: 73, Description: "Moderate snow"},
+				ModelEcmwIfs:     {Code: 73, Description: "Moderate snow"},
+				ModelGemSeamless: {Code: 3, Description: "Overcast"},
+			},
+			HighTemperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(30),
+				ModelEcmwIfs:     types.NewTemperatureFromFahrenheit(34),
+				ModelGemSeamless: types.NewTemperatureFromFahrenheit(32),
+			},
+			LowTemperature: ModelValues[types.Temperature]{
+				ModelGfsSeamless: types.NewTemperatureFromFahrenheit(10),
+				ModelEcmwIfs:     types.NewTemperatureFromFahrenheit(14),
+				ModelGemSeamless: types.NewTemperatureFromFahrenheit(12),
+			},
+			TotalSnowfall: ModelValues[types.Precipitation]{
+				ModelGfsSeamless: types.NewPrecipitationFromInches(2),
+				ModelEcmwIfs:     types.NewPrecipitationFromInches(8),
+				ModelGemSeamless: types.NewPrecipitationFromInches(5),
+			},
+			MaxWindGusts: ModelValues[types.WindSpeed]{
+				ModelGfsSeamless: types.NewWindSpeedFromMph(20),
+				ModelEcmwIfs:     types.NewWindSpeedFromMph(35),
+				ModelGemSeamless: types.NewWindSpeedFromMph(25),
+			},
+		})
+	}
+	return forecast
+}
+
+func TestWeeklySummaryRangeMath(t *testing.T) {
+	forecast := weeklyTestForecast(1)
+	denver, _ := time.LoadLocation(TimezoneDenver)
+
+	summary := forecast.WeeklySummary(time.Date(2025, 1, 15, 8, 0, 0, 0, denver))
+	if len(summary) != 1 {
+		t.Fatalf("got %d entries, want 1", len(summary))
+	}
+
+	day := summary[0]
+	if day.HighTempF != 32 || day.LowTempF != 12 {
+		t.Errorf("median temps = %v/%v, want 32/12", day.HighTempF, day.LowTempF)
+	}
+	if day.Snowfall.MinInches != 2 || day.Snowfall.MaxInches != 8 {
+		t.Errorf("snowfall range = %v-%v, want 2-8", day.Snowfall.MinInches, day.Snowfall.MaxInches)
+	}
+	if day.MaxWindGustMph != 35 {
+		t.Errorf("max gust = %v, want 35", day.MaxWindGustMph)
+	}
+	if day.Weather.Code != 73 {
+		t.Errorf("dominant weather code = %d, want 73 with two of three models", day.Weather.Code)
+	}
+	if day.PowderScore != 5 {
+		t.Errorf("powder score = %d, want 5 from the 5-inch median", day.PowderScore)
+	}
+	if day.SnowChancePercent != 100 {
+		t.Errorf("snow chance = %d, want 100 with every model snowing", day.SnowChancePercent)
+	}
+}
+
+func TestWeeklySummaryDayBoundaries(t *testing.T) {
+	forecast := weeklyTestForecast(5)
+
+	// 03:00 UTC on Jan 16 is still the evening of Jan 15 in Denver
+	summary := forecast.WeeklySummary(time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC))
+	if len(summary) != 5 {
+		t.Fatalf("got %d entries, want the 5 the horizon covers", len(summary))
+	}
+	if got := summary[0].Date.Day(); got != 15 {
+		t.Errorf("first entry is Jan %d, want Jan 15 (Denver date of the start instant)", got)
+	}
+
+	// Shifting the window forward drops the days already past it
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	shifted := forecast.WeeklySummary(time.Date(2025, 1, 17, 0, 0, 0, 0, denver))
+	if len(shifted) != 3 {
+		t.Fatalf("shifted window got %d entries, want 3", len(shifted))
+	}
+	if got := shifted[0].Date.Day(); got != 17 {
+		t.Errorf("shifted window starts Jan %d, want Jan 17", got)
+	}
+}
+
+func TestWeeklySummarySnowChanceRounding(t *testing.T) {
+	forecast := weeklyTestForecast(1)
+	day := &forecast.DailyForecasts[0]
+	day.TotalSnowfall = ModelValues[types.Precipitation]{
+		ModelGfsSeamless: types.NewPrecipitationFromInches(4),
+		ModelEcmwIfs:     types.NewPrecipitationFromInches(0),
+		ModelGemSeamless: types.NewPrecipitationFromInches(0.05),
+	}
+
+	denver, _ := time.LoadLocation(TimezoneDenver)
+	summary := forecast.WeeklySummary(time.Date(2025, 1, 15, 8, 0, 0, 0, denver))
+	if got := summary[0].SnowChancePercent; got != 33 {
+		t.Errorf("snow chance = %d, want 33 with one of three models snowing", got)
+	}
+}
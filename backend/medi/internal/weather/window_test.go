@@ -0,0 +1,75 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	"medi/internal/providers/openmeteo"
+	"medi/internal/types"
+)
+
+// shiftResponseDays moves every timestamp in a synthetic response forward by
+// the given number of days, simulating a date-anchored window
+func shiftResponseDays(t *testing.T, apiResp *openmeteo.ForecastAPIResponse, days int) {
+	t.Helper()
+
+	for i, raw := range apiResp.Hourly.Time {
+		parsed, err := time.ParseInLocation("2006-01-02T15:04", raw, time.UTC)
+		if err != nil {
+			t.Fatalf("failed to parse hourly time %q: %v", raw, err)
+		}
+		apiResp.Hourly.Time[i] = parsed.AddDate(0, 0, days).Format("2006-01-02T15:04")
+	}
+	for i, raw := range apiResp.Daily.Time {
+		parsed, err := time.ParseInLocation("2006-01-02", raw, time.UTC)
+		if err != nil {
+			t.Fatalf("failed to parse daily time %q: %v", raw, err)
+		}
+		apiResp.Daily.Time[i] = parsed.AddDate(0, 0, days).Format("2006-01-02")
+	}
+}
+
+func TestFutureWindowOmitsCurrentConditions(t *testing.T) {
+	apiResp := synthForecastResponse(t, 3)
+	shiftResponseDays(t, apiResp, 2)
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	// No timestamp in the window has passed yet, so there is nothing to
+	// report as "now"
+	if forecast.CurrentConditions.Temperature != nil {
+		t.Errorf("future window produced current temperatures: %v", forecast.CurrentConditions.Temperature)
+	}
+	if forecast.CurrentConditions.Weather != nil {
+		t.Errorf("future window produced current weather: %v", forecast.CurrentConditions.Weather)
+	}
+
+	// The daily series still maps normally
+	if len(forecast.DailyForecasts) != 3 {
+		t.Fatalf("got %d daily forecasts, want 3", len(forecast.DailyForecasts))
+	}
+}
+
+func TestPresentWindowKeepsCurrentConditions(t *testing.T) {
+	apiResp := synthForecastResponse(t, 3)
+
+	forecastPoint := types.ForecastPoint{
+		Coordinates: types.NewCoords(39.11539, -107.6584),
+	}
+
+	forecast, err := mapForecastAPIResponseToForecast(forecastPoint, ModelGfsSeamless, apiResp)
+	if err != nil {
+		t.Fatalf("mapForecastAPIResponseToForecast returned error: %v", err)
+	}
+
+	if forecast.CurrentConditions.Temperature == nil {
+		t.Error("window anchored at today lost its current conditions")
+	}
+}